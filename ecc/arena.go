@@ -0,0 +1,49 @@
+package ecc
+
+// Arena is a bump allocator of 64-bit words, used as scratch memory by kzg.Open and the
+// fft helpers which need temporary slices.
+//
+// A long-running prover can allocate one Arena, sized for its largest instance, and Reset it
+// between proofs: the gigabyte-sized temporary slices are then reused instead of being
+// reclaimed by the garbage collector.
+//
+// A nil *Arena is valid and allocates on the heap. An Arena is not safe for concurrent use,
+// and the slices it returns must not be used after Reset.
+type Arena struct {
+	words  []uint64
+	offset int
+}
+
+// NewArena returns an Arena holding nbWords 64-bit words
+func NewArena(nbWords int) *Arena {
+	return &Arena{words: make([]uint64, nbWords)}
+}
+
+// Words returns a zeroed slice of n words. If the arena is nil or doesn't have n words
+// left, the slice is allocated on the heap.
+func (a *Arena) Words(n int) []uint64 {
+	if a == nil || n > len(a.words)-a.offset {
+		return make([]uint64, n)
+	}
+	res := a.words[a.offset : a.offset+n : a.offset+n]
+	a.offset += n
+	for i := range res {
+		res[i] = 0
+	}
+	return res
+}
+
+// Reset makes the whole arena available again
+func (a *Arena) Reset() {
+	if a != nil {
+		a.offset = 0
+	}
+}
+
+// Available returns the number of words which can still be allocated from the arena
+func (a *Arena) Available() int {
+	if a == nil {
+		return 0
+	}
+	return len(a.words) - a.offset
+}
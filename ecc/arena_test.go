@@ -0,0 +1,40 @@
+package ecc
+
+import "testing"
+
+func TestArena(t *testing.T) {
+	t.Parallel()
+
+	a := NewArena(10)
+	w := a.Words(6)
+	if len(w) != 6 || cap(w) != 6 || a.Available() != 4 {
+		t.Fatal("unexpected allocation from the arena")
+	}
+	for i := range w {
+		w[i] = uint64(i + 1)
+	}
+
+	// doesn't fit: allocated on the heap, the arena is left untouched
+	if len(a.Words(5)) != 5 || a.Available() != 4 {
+		t.Fatal("unexpected allocation when the arena is exhausted")
+	}
+
+	// memory is reused and zeroed after Reset
+	a.Reset()
+	r := a.Words(6)
+	if &r[0] != &w[0] {
+		t.Fatal("Reset should make the memory available again")
+	}
+	for i := range r {
+		if r[i] != 0 {
+			t.Fatal("Words should return zeroed memory")
+		}
+	}
+
+	// nil arena
+	var n *Arena
+	if len(n.Words(3)) != 3 || n.Available() != 0 {
+		t.Fatal("a nil arena should allocate on the heap")
+	}
+	n.Reset()
+}
@@ -0,0 +1,26 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+// Package elgamal provides exponential ElGamal encryption on bls12-377's G1.
+//
+// A message m is encrypted as (rG, mG + rH), where H = xG is the public key and r
+// is sampled by the package for each encryption. Ciphertexts are additively
+// homomorphic: the component-wise sum of two ciphertexts encrypts the sum of the
+// messages.
+//
+// Decryption recovers mG, and m is found with a baby-step giant-step search; it
+// is only practical for small messages, bounded by the size of a DecryptionTable.
+package elgamal
@@ -0,0 +1,122 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package elgamal
+
+import (
+	"crypto/rand"
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
+	"github.com/consensys/gnark-crypto/homomorphic"
+)
+
+func TestFieldEncryption(t *testing.T) {
+
+	priv, err := GenerateFieldKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var dec homomorphic.Decrypter = priv
+	pub := dec.Public()
+
+	var a, b, sum fr.Element
+	a.SetRandom()
+	b.SetRandom()
+	sum.Add(&a, &b)
+	var ba, bb, bsum big.Int
+	a.ToBigIntRegular(&ba)
+	b.ToBigIntRegular(&bb)
+	sum.ToBigIntRegular(&bsum)
+
+	ca, err := pub.Encrypt(rand.Reader, &ba)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cb, err := pub.Encrypt(rand.Reader, &bb)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m, err := dec.Decrypt(ca)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.Cmp(&ba) != 0 {
+		t.Fatal("wrong decryption")
+	}
+
+	csum, err := pub.Add(ca, cb)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m, err = dec.Decrypt(csum)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.Cmp(&bsum) != 0 {
+		t.Fatal("wrong decryption of the sum")
+	}
+
+	// public key serialization
+	var reconstructed FieldPublicKey
+	if _, err = reconstructed.SetBytes(pub.Bytes()); err != nil {
+		t.Fatal(err)
+	}
+	if !reconstructed.H.Equal(&priv.PublicKey.H) {
+		t.Fatal("SetBytes(Bytes()) failed")
+	}
+}
+
+func TestFieldEncryptionMaxAdditions(t *testing.T) {
+
+	priv, err := GenerateFieldKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pub := priv.Public()
+
+	// r-1 has large limbs
+	var m big.Int
+	m.Sub(fr.Modulus(), big.NewInt(1))
+	c, err := pub.Encrypt(rand.Reader, &m)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// doubling 8 times results from 255 additions
+	acc := c
+	for i := 0; i < 8; i++ {
+		if acc, err = pub.Add(acc, acc); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err = pub.Add(acc, c); err != ErrTooManyAdditions {
+		t.Fatal("expected ErrTooManyAdditions")
+	}
+
+	// (MaxAdditions+1)(r-1) = -(MaxAdditions+1) mod r
+	var expected big.Int
+	expected.SetInt64(-(MaxAdditions + 1)).Mod(&expected, fr.Modulus())
+	res, err := priv.Decrypt(acc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Cmp(&expected) != 0 {
+		t.Fatal("wrong decryption after MaxAdditions additions")
+	}
+}
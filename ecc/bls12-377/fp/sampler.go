@@ -0,0 +1,93 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package fp
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+
+	"golang.org/x/crypto/chacha20"
+)
+
+// samplerDomain separates the sampler key derivation from other uses of the seed.
+const samplerDomain = "gnark-crypto/bls12-377/fp/sampler"
+
+// Sampler expands a seed into a deterministic stream of uniformly distributed elements.
+//
+// The stream is the ChaCha20 key stream, keyed by sha256(domain || seed) with a zero nonce.
+// Each candidate is read as a little-endian integer of Bits bits and rejected if it is
+// not smaller than q, so the output is unbiased. A Sampler is not safe for concurrent use.
+type Sampler struct {
+	cipher *chacha20.Cipher
+	buf    [Bytes]byte
+}
+
+// NewSampler returns a Sampler whose stream is derived from seed.
+func NewSampler(seed []byte) *Sampler {
+	h := sha256.New()
+	h.Write([]byte(samplerDomain))
+	h.Write(seed)
+	key := h.Sum(nil)
+
+	var nonce [chacha20.NonceSize]byte
+	cipher, err := chacha20.NewUnauthenticatedCipher(key, nonce[:])
+	if err != nil {
+		// key and nonce have the expected sizes
+		panic(err)
+	}
+	return &Sampler{cipher: cipher}
+}
+
+// Next returns the next element of the stream.
+func (s *Sampler) Next() Element {
+	var z Element
+	s.next(&z)
+	return z
+}
+
+// Fill sets the elements of v to the next len(v) elements of the stream.
+func (s *Sampler) Fill(v []Element) {
+	for i := range v {
+		s.next(&v[i])
+	}
+}
+
+func (s *Sampler) next(z *Element) {
+	// k is the number of bytes of a candidate.
+	const k = (Bits + 7) / 8
+
+	// mask clears the bits of the most significant byte above Bits.
+	const mask = byte(1<<((Bits-1)%8+1) - 1)
+
+	for {
+		// note that s.buf[k:] is always 0
+		for i := 0; i < k; i++ {
+			s.buf[i] = 0
+		}
+		s.cipher.XORKeyStream(s.buf[:k], s.buf[:k])
+		s.buf[k-1] &= mask
+
+		for i := 0; i < Limbs; i++ {
+			z[i] = binary.LittleEndian.Uint64(s.buf[i*8 : (i+1)*8])
+		}
+		if z.smallerThanModulus() {
+			// the candidate is the regular representation of the sampled value
+			z.ToMont()
+			return
+		}
+	}
+}
@@ -0,0 +1,31 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+// Package anemoi provides the Anemoi permutation and its Jive compression mode.
+//
+// Anemoi is an arithmetization-oriented permutation, cheap to verify in SNARK circuits:
+// its S-box, the open Flystel, is built from x -> x^α and a quadratic function, and is
+// expressed with low degree constraints in both directions.
+//
+// This package implements the instance with a single column (a state of two field
+// elements) and α = 11, with 19 rounds for 128 bits of security. The
+// 2-to-1 compression function Jive(x, y) = x + y + u + v, where (u, v) = P(x, y), is suited
+// to Merkle trees; NewAnemoi chains it into a hash.Hash.
+//
+// The round constants are derived from a seed with Keccak256, as for the MiMC constants.
+//
+// See https://eprint.iacr.org/2022/840 for the specification.
+package anemoi
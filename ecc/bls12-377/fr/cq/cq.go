@@ -0,0 +1,464 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package cq
+
+import (
+	"crypto/sha256"
+	"errors"
+	"math/big"
+	"sort"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bls12-377"
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr/fft"
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr/kzg"
+	fiatshamir "github.com/consensys/gnark-crypto/fiat-shamir"
+)
+
+var (
+	ErrNotInTable     = errors.New("some value in the vector is not in the lookup table")
+	ErrSize           = errors.New("the vector is empty or larger than the table")
+	ErrCqVerification = errors.New("cq verification failed")
+	ErrGenerator      = errors.New("wrong generator")
+)
+
+// Proof cq proof that the values of the vector committed in f are in a preprocessed table.
+//
+// Notations: V is the domain of the table (size N), K is the domain of f (size n),
+// A is the polynomial on V such that Aᵢ = mᵢ/(β+tᵢ), where m are the multiplicities,
+// and B the polynomial on K such that Bᵢ = 1/(β+fᵢ).
+//
+// implements io.ReaderFrom and io.WriterTo
+type Proof struct {
+
+	// size of f, after padding
+	size uint64
+
+	// generator of the fft domain of f
+	g fr.Element
+
+	// commitments to f and to the multiplicities
+	f, m kzg.Digest
+
+	// commitments to A, Q_A = (A(T+β)-m)/Z_V, and A₀ = (A-A(0))/X
+	a, qa, a0 kzg.Digest
+
+	// commitments to B, B₀ = (B-B(0))/X, P = Xᴺ⁻ⁿ⁺¹B₀, Q_B = (B(f+β)-1)/Z_K
+	b, b0, p, qb kzg.Digest
+
+	// B(0)
+	bZero fr.Element
+
+	// opening proof of B, f, Q_B, B₀ (in that order)
+	batchedProof kzg.BatchOpeningProof
+}
+
+// Size returns the size of f, after padding
+func (proof *Proof) Size() uint64 {
+	return proof.size
+}
+
+// Generator returns the generator of the fft domain of f
+func (proof *Proof) Generator() fr.Element {
+	return proof.g
+}
+
+// Commitments returns the commitments of f, m, A, Q_A, A₀, B, B₀, P, Q_B (in that order)
+func (proof *Proof) Commitments() []kzg.Digest {
+	return []kzg.Digest{proof.f, proof.m, proof.a, proof.qa, proof.a0, proof.b, proof.b0, proof.p, proof.qb}
+}
+
+// BZero returns the claimed value B(0)
+func (proof *Proof) BZero() fr.Element {
+	return proof.bZero
+}
+
+// BatchedProof returns the opening proof of B, f, Q_B, B₀
+func (proof *Proof) BatchedProof() kzg.BatchOpeningProof {
+	return proof.batchedProof
+}
+
+// Prove generates a proof that the values in f are in the table preprocessed in pk.
+//
+// f is padded to a power of 2 using its last element, and must not be larger
+// than the table.
+func Prove(srs *SRS, pk *ProvingKey, f []fr.Element) (Proof, error) {
+
+	// res
+	var proof Proof
+	var err error
+
+	// size checking
+	bigN := pk.Vk.N
+	if len(f) == 0 || ecc.NextPowerOfTwo(uint64(len(f))) > bigN {
+		return proof, ErrSize
+	}
+	size := len(f)
+	if size < 2 {
+		size = 2
+	}
+	d := fft.NewDomain(uint64(size))
+	n := int(d.Cardinality)
+	proof.size = d.Cardinality
+	proof.g.Set(&d.Generator)
+	kzgSrs := srs.KZG()
+
+	// hash function for Fiat Shamir
+	hFunc := sha256.New()
+
+	// transcript to derive the challenge
+	fs := fiatshamir.NewTranscript(hFunc, "beta", "gamma")
+
+	// resize f
+	lf := make([]fr.Element, n)
+	copy(lf, f)
+	for i := len(f); i < n; i++ {
+		lf[i] = f[len(f)-1]
+	}
+
+	// compute the multiplicities, only on the entries of the table that are looked up
+	index := make(map[fr.Element]int, len(pk.Table))
+	for j := len(pk.Table) - 1; j >= 0; j-- {
+		index[pk.Table[j]] = j
+	}
+	counts := make(map[int]uint64)
+	for i := 0; i < n; i++ {
+		j, ok := index[lf[i]]
+		if !ok {
+			return proof, ErrNotInTable
+		}
+		counts[j]++
+	}
+	touched := make([]int, 0, len(counts))
+	for j := range counts {
+		touched = append(touched, j)
+	}
+	sort.Ints(touched)
+	lagrange := make([]bls12377.G1Affine, len(touched))
+	quotients := make([]bls12377.G1Affine, len(touched))
+	lm := make([]fr.Element, len(touched))
+	for k, j := range touched {
+		lagrange[k] = pk.Lagrange[j]
+		quotients[k] = pk.Quotients[j]
+		lm[k].SetUint64(counts[j])
+	}
+
+	// commit f, m
+	config := ecc.MultiExpConfig{ScalarsMont: true}
+	cf := make([]fr.Element, n)
+	copy(cf, lf)
+	d.FFTInverse(cf, fft.DIF)
+	fft.BitReverse(cf)
+	proof.f, err = kzg.Commit(cf, kzgSrs)
+	if err != nil {
+		return proof, err
+	}
+	if _, err = proof.m.MultiExp(lagrange, lm, config); err != nil {
+		return proof, err
+	}
+
+	// derive challenge for A and B
+	beta, err := deriveRandomness(&fs, "beta", &proof.f, &proof.m)
+	if err != nil {
+		return proof, err
+	}
+
+	// Aᵢ = mᵢ/(β+tᵢ), on the touched entries only
+	la := make([]fr.Element, len(touched))
+	for k, j := range touched {
+		la[k].Add(&beta, &pk.Table[j])
+	}
+	la = fr.BatchInvert(la)
+	var aZero fr.Element
+	for k := range la {
+		la[k].Mul(&la[k], &lm[k])
+		aZero.Add(&aZero, &la[k])
+	}
+
+	// [A], [Q_A] = ∑ᵢAᵢ[Qᵢ]
+	if _, err = proof.a.MultiExp(lagrange, la, config); err != nil {
+		return proof, err
+	}
+	if _, err = proof.qa.MultiExp(quotients, la, config); err != nil {
+		return proof, err
+	}
+
+	// A(0) = ∑ᵢAᵢ/N, [A₀] = ∑ᵢAᵢω⁻ⁱ[Lᵢ] - A(0)[αᴺ⁻¹]
+	var bigNInv, omegaInv fr.Element
+	bigNInv.SetUint64(bigN).Inverse(&bigNInv)
+	aZero.Mul(&aZero, &bigNInv)
+	omegaInv.Inverse(&pk.Generator)
+	scalars := make([]fr.Element, len(touched))
+	for k, j := range touched {
+		scalars[k].Exp(omegaInv, big.NewInt(int64(j))).
+			Mul(&scalars[k], &la[k])
+	}
+	if _, err = proof.a0.MultiExp(lagrange, scalars, config); err != nil {
+		return proof, err
+	}
+	var aZeroBigInt big.Int
+	var tmp bls12377.G1Affine
+	aZero.ToBigIntRegular(&aZeroBigInt)
+	tmp.ScalarMultiplication(&srs.G1[bigN-1], &aZeroBigInt)
+	proof.a0.Sub(&proof.a0, &tmp)
+
+	// Bᵢ = 1/(β+fᵢ)
+	lb := make([]fr.Element, n)
+	for i := 0; i < n; i++ {
+		lb[i].Add(&beta, &lf[i])
+	}
+	lb = fr.BatchInvert(lb)
+	cb := make([]fr.Element, n)
+	copy(cb, lb)
+	d.FFTInverse(cb, fft.DIF)
+	fft.BitReverse(cb)
+	proof.bZero.Set(&cb[0])
+	cb0 := cb[1:]
+	proof.b, err = kzg.Commit(cb, kzgSrs)
+	if err != nil {
+		return proof, err
+	}
+	proof.b0, err = kzg.Commit(cb0, kzgSrs)
+	if err != nil {
+		return proof, err
+	}
+
+	// [P] = [αᴺ⁻ⁿ⁺¹B₀(α)], which proves that deg(B₀) ≤ n-2
+	shift := int(bigN) - n + 1
+	if _, err = proof.p.MultiExp(srs.G1[shift:shift+len(cb0)], cb0, config); err != nil {
+		return proof, err
+	}
+
+	// Q_B = (B(f+β)-1)/Z_K, computed on the coset of K
+	_lb := make([]fr.Element, n)
+	_lf := make([]fr.Element, n)
+	copy(_lb, cb)
+	copy(_lf, cf)
+	d.FFT(_lb, fft.DIF, true)
+	d.FFT(_lf, fft.DIF, true)
+	var tn, one fr.Element
+	one.SetOne()
+	tn.Exp(d.FrMultiplicativeGen, big.NewInt(int64(n))).Sub(&tn, &one).Inverse(&tn)
+	cqb := _lb
+	for i := 0; i < n; i++ {
+		_lf[i].Add(&_lf[i], &beta)
+		cqb[i].Mul(&_lb[i], &_lf[i]).
+			Sub(&cqb[i], &one).
+			Mul(&cqb[i], &tn)
+	}
+	d.FFTInverse(cqb, fft.DIT, true)
+	proof.qb, err = kzg.Commit(cqb, kzgSrs)
+	if err != nil {
+		return proof, err
+	}
+
+	// derive the evaluation challenge, binded to B(0) as well
+	if err = fs.Bind("gamma", proof.bZero.Marshal()); err != nil {
+		return proof, err
+	}
+	gamma, err := deriveRandomness(&fs, "gamma", &proof.a, &proof.qa, &proof.a0, &proof.b, &proof.b0, &proof.p, &proof.qb)
+	if err != nil {
+		return proof, err
+	}
+
+	// compute the opening proofs
+	proof.batchedProof, err = kzg.BatchOpenSinglePoint(
+		[][]fr.Element{
+			cb,
+			cf,
+			cqb,
+			cb0,
+		},
+		[]kzg.Digest{
+			proof.b,
+			proof.f,
+			proof.qb,
+			proof.b0,
+		},
+		gamma,
+		hFunc,
+		kzgSrs,
+	)
+	if err != nil {
+		return proof, err
+	}
+
+	// done
+	return proof, nil
+}
+
+// Verify verifies a cq proof, for the table described by vk.
+func Verify(srs *SRS, vk *VerifyingKey, proof Proof) error {
+
+	// hash function that is used for Fiat Shamir
+	hFunc := sha256.New()
+
+	// transcript to derive the challenge
+	fs := fiatshamir.NewTranscript(hFunc, "beta", "gamma")
+
+	// derive the challenges
+	beta, err := deriveRandomness(&fs, "beta", &proof.f, &proof.m)
+	if err != nil {
+		return err
+	}
+	if err = fs.Bind("gamma", proof.bZero.Marshal()); err != nil {
+		return err
+	}
+	gamma, err := deriveRandomness(&fs, "gamma", &proof.a, &proof.qa, &proof.a0, &proof.b, &proof.b0, &proof.p, &proof.qb)
+	if err != nil {
+		return err
+	}
+
+	// check the sizes
+	if proof.size < 2 || proof.size > vk.N || vk.N >= uint64(len(srs.G2)) {
+		return ErrSize
+	}
+
+	// sum check: ∑ᵢAᵢ = NA(0) = nB(0) = ∑ᵢBᵢ
+	var aZero, n, bigN fr.Element
+	n.SetUint64(proof.size)
+	bigN.SetUint64(vk.N)
+	aZero.Mul(&proof.bZero, &n).
+		Div(&aZero, &bigN)
+
+	// e([A], [T]) e([βA - m], G₂) ==? e([Q_A], [Z_V])
+	var betaBigInt big.Int
+	var betaAMinusM, negQa bls12377.G1Affine
+	beta.ToBigIntRegular(&betaBigInt)
+	betaAMinusM.ScalarMultiplication(&proof.a, &betaBigInt).
+		Sub(&betaAMinusM, &proof.m)
+	negQa.Neg(&proof.qa)
+	if err = pairingCheck(
+		[]bls12377.G1Affine{proof.a, betaAMinusM, negQa},
+		[]bls12377.G2Affine{vk.T, srs.G2[0], vk.ZV},
+	); err != nil {
+		return err
+	}
+
+	// e([A - A(0)], G₂) ==? e([A₀], [α]G₂)
+	var aZeroBigInt big.Int
+	var aMinusAZero, negA0 bls12377.G1Affine
+	aZero.ToBigIntRegular(&aZeroBigInt)
+	aMinusAZero.ScalarMultiplication(&srs.G1[0], &aZeroBigInt).
+		Sub(&proof.a, &aMinusAZero)
+	negA0.Neg(&proof.a0)
+	if err = pairingCheck(
+		[]bls12377.G1Affine{aMinusAZero, negA0},
+		[]bls12377.G2Affine{srs.G2[0], srs.G2[1]},
+	); err != nil {
+		return err
+	}
+
+	// e([B₀], [αᴺ⁻ⁿ⁺¹]G₂) ==? e([P], G₂)
+	var negP bls12377.G1Affine
+	negP.Neg(&proof.p)
+	if err = pairingCheck(
+		[]bls12377.G1Affine{proof.b0, negP},
+		[]bls12377.G2Affine{srs.G2[vk.N-proof.size+1], srs.G2[0]},
+	); err != nil {
+		return err
+	}
+
+	// check the opening proofs
+	if len(proof.batchedProof.ClaimedValues) != 4 {
+		return kzg.ErrInvalidNbDigests
+	}
+	err = kzg.BatchVerifySinglePoint(
+		[]kzg.Digest{
+			proof.b,
+			proof.f,
+			proof.qb,
+			proof.b0,
+		},
+		&proof.batchedProof,
+		gamma,
+		hFunc,
+		srs.KZG(),
+	)
+	if err != nil {
+		return err
+	}
+
+	// B(γ)(f(γ)+β)-1 ==? Q_B(γ)(γⁿ-1) and B(γ) ==? γB₀(γ)+B(0)
+	b := proof.batchedProof.ClaimedValues[0]
+	f := proof.batchedProof.ClaimedValues[1]
+	qb := proof.batchedProof.ClaimedValues[2]
+	b0 := proof.batchedProof.ClaimedValues[3]
+	var lhs, rhs, one fr.Element
+	one.SetOne()
+	lhs.Add(&f, &beta).
+		Mul(&lhs, &b).
+		Sub(&lhs, &one)
+	rhs.Exp(gamma, new(big.Int).SetUint64(proof.size)).
+		Sub(&rhs, &one).
+		Mul(&rhs, &qb)
+	if !lhs.Equal(&rhs) {
+		return ErrCqVerification
+	}
+	rhs.Mul(&gamma, &b0).
+		Add(&rhs, &proof.bZero)
+	if !b.Equal(&rhs) {
+		return ErrCqVerification
+	}
+
+	// check the generator is correct
+	var checkOrder fr.Element
+	checkOrder.Exp(proof.g, new(big.Int).SetUint64(proof.size/2))
+	if checkOrder.Equal(&one) {
+		return ErrGenerator
+	}
+	checkOrder.Square(&checkOrder)
+	if !checkOrder.Equal(&one) {
+		return ErrGenerator
+	}
+
+	return nil
+}
+
+// pairingCheck returns ErrCqVerification if ∏ᵢe(P[i], Q[i]) ≠ 1
+func pairingCheck(P []bls12377.G1Affine, Q []bls12377.G2Affine) error {
+	check, err := bls12377.PairingCheck(P, Q)
+	if err != nil {
+		return err
+	}
+	if !check {
+		return ErrCqVerification
+	}
+	return nil
+}
+
+// TODO put that in fiat-shamir package
+func deriveRandomness(fs *fiatshamir.Transcript, challenge string, points ...*bls12377.G1Affine) (fr.Element, error) {
+
+	var buf [bls12377.SizeOfG1AffineUncompressed]byte
+	var r fr.Element
+
+	for _, p := range points {
+		buf = p.RawBytes()
+		if err := fs.Bind(challenge, buf[:]); err != nil {
+			return r, err
+		}
+	}
+
+	b, err := fs.ComputeChallenge(challenge)
+	if err != nil {
+		return r, err
+	}
+	r.SetBytes(b)
+	return r, nil
+}
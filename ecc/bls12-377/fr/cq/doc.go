@@ -0,0 +1,24 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+// Package cq provides an API to build cq lookup proofs (cached quotients,
+// cf https://eprint.iacr.org/2022/1763.pdf).
+//
+// The table is preprocessed once: the commitments to the Lagrange polynomials and
+// the cached quotients of the table are computed in O(N log N) group operations,
+// where N is the size of the table. After that, the prover cost only depends on
+// the number of looked up values, so very large tables can be used.
+package cq
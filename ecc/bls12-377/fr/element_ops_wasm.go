@@ -0,0 +1,317 @@
+//go:build !purego
+// +build !purego
+
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package fr
+
+import "math/bits"
+
+// MulBy3 x *= 3 (mod q)
+func MulBy3(x *Element) {
+	_x := *x
+	x.Double(x).Add(x, &_x)
+}
+
+// MulBy5 x *= 5 (mod q)
+func MulBy5(x *Element) {
+	_x := *x
+	x.Double(x).Double(x).Add(x, &_x)
+}
+
+// MulBy13 x *= 13 (mod q)
+func MulBy13(x *Element) {
+	var y = Element{
+		18434640649710993230,
+		12067750152132099910,
+		14024878721438555919,
+		347766975729306096,
+	}
+	x.Mul(x, &y)
+}
+
+// Butterfly sets
+//
+//	a = a + b (mod q)
+//	b = a - b (mod q)
+func Butterfly(a, b *Element) {
+	_butterflyGeneric(a, b)
+}
+
+func mul(z, x, y *Element) {
+	_mulWasm(z, x, y)
+}
+
+func square(z, x *Element) {
+	_mulWasm(z, x, x)
+}
+
+const mask32 = 0xFFFFFFFF
+
+// madd32 returns hi, lo such that hi⋅2³² + lo = a⋅b + t + c, for a, b, t, c < 2³²
+func madd32(a, b, t, c uint64) (hi, lo uint64) {
+	r := a*b + t + c
+	return r >> 32, r & mask32
+}
+
+// _mulWasm sets z = x * y (mod q) with a CIOS Montgomery multiplication on 8 32-bit limbs.
+//
+// wasm has no 64x64 -> 128 bits multiplication, so bits.Mul64 is emulated with four
+// 32-bit multiplications and a handful of shifts and additions. Here, each 32x32 -> 64 bits
+// product is a single i64.mul, and the carries are propagated in the upper half of the 64-bit words.
+//
+// Since R = 2^(64⋅4) = 2^(32⋅8), the result is in Montgomery form, as with _mulGeneric.
+// Note that -q⁻¹ mod 2³² is qInvNeg mod 2³².
+func _mulWasm(z, x, y *Element) {
+	// x and y on 32-bit limbs
+	x0, x1 := x[0]&mask32, x[0]>>32
+	x2, x3 := x[1]&mask32, x[1]>>32
+	x4, x5 := x[2]&mask32, x[2]>>32
+	x6, x7 := x[3]&mask32, x[3]>>32
+	y0, y1 := y[0]&mask32, y[0]>>32
+	y2, y3 := y[1]&mask32, y[1]>>32
+	y4, y5 := y[2]&mask32, y[2]>>32
+	y6, y7 := y[3]&mask32, y[3]>>32
+
+	var t0, t1, t2, t3, t4, t5, t6, t7, t8, t9, C, m uint64
+
+	// round 0: (C,t) := t + x⋅y0
+	C, t0 = madd32(x0, y0, t0, 0)
+	C, t1 = madd32(x1, y0, t1, C)
+	C, t2 = madd32(x2, y0, t2, C)
+	C, t3 = madd32(x3, y0, t3, C)
+	C, t4 = madd32(x4, y0, t4, C)
+	C, t5 = madd32(x5, y0, t5, C)
+	C, t6 = madd32(x6, y0, t6, C)
+	C, t7 = madd32(x7, y0, t7, C)
+	C += t8
+	t8, t9 = C&mask32, C>>32
+
+	// (C,t) := (t + m⋅q) / 2³²
+	m = (t0 * qInvNeg) & mask32
+	C, _ = madd32(m, q0&mask32, t0, 0)
+	C, t0 = madd32(m, q0>>32, t1, C)
+	C, t1 = madd32(m, q1&mask32, t2, C)
+	C, t2 = madd32(m, q1>>32, t3, C)
+	C, t3 = madd32(m, q2&mask32, t4, C)
+	C, t4 = madd32(m, q2>>32, t5, C)
+	C, t5 = madd32(m, q3&mask32, t6, C)
+	C, t6 = madd32(m, q3>>32, t7, C)
+	C += t8
+	t7, t8 = C&mask32, t9+C>>32
+
+	// round 1: (C,t) := t + x⋅y1
+	C, t0 = madd32(x0, y1, t0, 0)
+	C, t1 = madd32(x1, y1, t1, C)
+	C, t2 = madd32(x2, y1, t2, C)
+	C, t3 = madd32(x3, y1, t3, C)
+	C, t4 = madd32(x4, y1, t4, C)
+	C, t5 = madd32(x5, y1, t5, C)
+	C, t6 = madd32(x6, y1, t6, C)
+	C, t7 = madd32(x7, y1, t7, C)
+	C += t8
+	t8, t9 = C&mask32, C>>32
+
+	// (C,t) := (t + m⋅q) / 2³²
+	m = (t0 * qInvNeg) & mask32
+	C, _ = madd32(m, q0&mask32, t0, 0)
+	C, t0 = madd32(m, q0>>32, t1, C)
+	C, t1 = madd32(m, q1&mask32, t2, C)
+	C, t2 = madd32(m, q1>>32, t3, C)
+	C, t3 = madd32(m, q2&mask32, t4, C)
+	C, t4 = madd32(m, q2>>32, t5, C)
+	C, t5 = madd32(m, q3&mask32, t6, C)
+	C, t6 = madd32(m, q3>>32, t7, C)
+	C += t8
+	t7, t8 = C&mask32, t9+C>>32
+
+	// round 2: (C,t) := t + x⋅y2
+	C, t0 = madd32(x0, y2, t0, 0)
+	C, t1 = madd32(x1, y2, t1, C)
+	C, t2 = madd32(x2, y2, t2, C)
+	C, t3 = madd32(x3, y2, t3, C)
+	C, t4 = madd32(x4, y2, t4, C)
+	C, t5 = madd32(x5, y2, t5, C)
+	C, t6 = madd32(x6, y2, t6, C)
+	C, t7 = madd32(x7, y2, t7, C)
+	C += t8
+	t8, t9 = C&mask32, C>>32
+
+	// (C,t) := (t + m⋅q) / 2³²
+	m = (t0 * qInvNeg) & mask32
+	C, _ = madd32(m, q0&mask32, t0, 0)
+	C, t0 = madd32(m, q0>>32, t1, C)
+	C, t1 = madd32(m, q1&mask32, t2, C)
+	C, t2 = madd32(m, q1>>32, t3, C)
+	C, t3 = madd32(m, q2&mask32, t4, C)
+	C, t4 = madd32(m, q2>>32, t5, C)
+	C, t5 = madd32(m, q3&mask32, t6, C)
+	C, t6 = madd32(m, q3>>32, t7, C)
+	C += t8
+	t7, t8 = C&mask32, t9+C>>32
+
+	// round 3: (C,t) := t + x⋅y3
+	C, t0 = madd32(x0, y3, t0, 0)
+	C, t1 = madd32(x1, y3, t1, C)
+	C, t2 = madd32(x2, y3, t2, C)
+	C, t3 = madd32(x3, y3, t3, C)
+	C, t4 = madd32(x4, y3, t4, C)
+	C, t5 = madd32(x5, y3, t5, C)
+	C, t6 = madd32(x6, y3, t6, C)
+	C, t7 = madd32(x7, y3, t7, C)
+	C += t8
+	t8, t9 = C&mask32, C>>32
+
+	// (C,t) := (t + m⋅q) / 2³²
+	m = (t0 * qInvNeg) & mask32
+	C, _ = madd32(m, q0&mask32, t0, 0)
+	C, t0 = madd32(m, q0>>32, t1, C)
+	C, t1 = madd32(m, q1&mask32, t2, C)
+	C, t2 = madd32(m, q1>>32, t3, C)
+	C, t3 = madd32(m, q2&mask32, t4, C)
+	C, t4 = madd32(m, q2>>32, t5, C)
+	C, t5 = madd32(m, q3&mask32, t6, C)
+	C, t6 = madd32(m, q3>>32, t7, C)
+	C += t8
+	t7, t8 = C&mask32, t9+C>>32
+
+	// round 4: (C,t) := t + x⋅y4
+	C, t0 = madd32(x0, y4, t0, 0)
+	C, t1 = madd32(x1, y4, t1, C)
+	C, t2 = madd32(x2, y4, t2, C)
+	C, t3 = madd32(x3, y4, t3, C)
+	C, t4 = madd32(x4, y4, t4, C)
+	C, t5 = madd32(x5, y4, t5, C)
+	C, t6 = madd32(x6, y4, t6, C)
+	C, t7 = madd32(x7, y4, t7, C)
+	C += t8
+	t8, t9 = C&mask32, C>>32
+
+	// (C,t) := (t + m⋅q) / 2³²
+	m = (t0 * qInvNeg) & mask32
+	C, _ = madd32(m, q0&mask32, t0, 0)
+	C, t0 = madd32(m, q0>>32, t1, C)
+	C, t1 = madd32(m, q1&mask32, t2, C)
+	C, t2 = madd32(m, q1>>32, t3, C)
+	C, t3 = madd32(m, q2&mask32, t4, C)
+	C, t4 = madd32(m, q2>>32, t5, C)
+	C, t5 = madd32(m, q3&mask32, t6, C)
+	C, t6 = madd32(m, q3>>32, t7, C)
+	C += t8
+	t7, t8 = C&mask32, t9+C>>32
+
+	// round 5: (C,t) := t + x⋅y5
+	C, t0 = madd32(x0, y5, t0, 0)
+	C, t1 = madd32(x1, y5, t1, C)
+	C, t2 = madd32(x2, y5, t2, C)
+	C, t3 = madd32(x3, y5, t3, C)
+	C, t4 = madd32(x4, y5, t4, C)
+	C, t5 = madd32(x5, y5, t5, C)
+	C, t6 = madd32(x6, y5, t6, C)
+	C, t7 = madd32(x7, y5, t7, C)
+	C += t8
+	t8, t9 = C&mask32, C>>32
+
+	// (C,t) := (t + m⋅q) / 2³²
+	m = (t0 * qInvNeg) & mask32
+	C, _ = madd32(m, q0&mask32, t0, 0)
+	C, t0 = madd32(m, q0>>32, t1, C)
+	C, t1 = madd32(m, q1&mask32, t2, C)
+	C, t2 = madd32(m, q1>>32, t3, C)
+	C, t3 = madd32(m, q2&mask32, t4, C)
+	C, t4 = madd32(m, q2>>32, t5, C)
+	C, t5 = madd32(m, q3&mask32, t6, C)
+	C, t6 = madd32(m, q3>>32, t7, C)
+	C += t8
+	t7, t8 = C&mask32, t9+C>>32
+
+	// round 6: (C,t) := t + x⋅y6
+	C, t0 = madd32(x0, y6, t0, 0)
+	C, t1 = madd32(x1, y6, t1, C)
+	C, t2 = madd32(x2, y6, t2, C)
+	C, t3 = madd32(x3, y6, t3, C)
+	C, t4 = madd32(x4, y6, t4, C)
+	C, t5 = madd32(x5, y6, t5, C)
+	C, t6 = madd32(x6, y6, t6, C)
+	C, t7 = madd32(x7, y6, t7, C)
+	C += t8
+	t8, t9 = C&mask32, C>>32
+
+	// (C,t) := (t + m⋅q) / 2³²
+	m = (t0 * qInvNeg) & mask32
+	C, _ = madd32(m, q0&mask32, t0, 0)
+	C, t0 = madd32(m, q0>>32, t1, C)
+	C, t1 = madd32(m, q1&mask32, t2, C)
+	C, t2 = madd32(m, q1>>32, t3, C)
+	C, t3 = madd32(m, q2&mask32, t4, C)
+	C, t4 = madd32(m, q2>>32, t5, C)
+	C, t5 = madd32(m, q3&mask32, t6, C)
+	C, t6 = madd32(m, q3>>32, t7, C)
+	C += t8
+	t7, t8 = C&mask32, t9+C>>32
+
+	// round 7: (C,t) := t + x⋅y7
+	C, t0 = madd32(x0, y7, t0, 0)
+	C, t1 = madd32(x1, y7, t1, C)
+	C, t2 = madd32(x2, y7, t2, C)
+	C, t3 = madd32(x3, y7, t3, C)
+	C, t4 = madd32(x4, y7, t4, C)
+	C, t5 = madd32(x5, y7, t5, C)
+	C, t6 = madd32(x6, y7, t6, C)
+	C, t7 = madd32(x7, y7, t7, C)
+	C += t8
+	t8, t9 = C&mask32, C>>32
+
+	// (C,t) := (t + m⋅q) / 2³²
+	m = (t0 * qInvNeg) & mask32
+	C, _ = madd32(m, q0&mask32, t0, 0)
+	C, t0 = madd32(m, q0>>32, t1, C)
+	C, t1 = madd32(m, q1&mask32, t2, C)
+	C, t2 = madd32(m, q1>>32, t3, C)
+	C, t3 = madd32(m, q2&mask32, t4, C)
+	C, t4 = madd32(m, q2>>32, t5, C)
+	C, t5 = madd32(m, q3&mask32, t6, C)
+	C, t6 = madd32(m, q3>>32, t7, C)
+	C += t8
+	t7, t8 = C&mask32, t9+C>>32
+	z[0] = t0 | t1<<32
+	z[1] = t2 | t3<<32
+	z[2] = t4 | t5<<32
+	z[3] = t6 | t7<<32
+
+	// if z >= q or t8 != 0 → z -= q
+	// this is done without branching on the value of z
+	{
+		r0, b := bits.Sub64(z[0], q0, 0)
+		r1, b := bits.Sub64(z[1], q1, b)
+		r2, b := bits.Sub64(z[2], q2, b)
+		r3, b := bits.Sub64(z[3], q3, b)
+		mask := -(t8 | (b ^ 1)) // all ones if we need to subtract q
+		z[0] ^= mask & (z[0] ^ r0)
+		z[1] ^= mask & (z[1] ^ r1)
+		z[2] ^= mask & (z[2] ^ r2)
+		z[3] ^= mask & (z[3] ^ r3)
+	}
+}
+
+func fromMont(z *Element) {
+	_fromMontGeneric(z)
+}
+
+func reduce(z *Element) {
+	_reduceGeneric(z)
+}
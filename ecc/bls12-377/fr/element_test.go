@@ -17,11 +17,14 @@
 package fr
 
 import (
+	"bytes"
 	"crypto/rand"
+	"encoding/binary"
 	"encoding/json"
 	"fmt"
 	"math/big"
 	"math/bits"
+	"reflect"
 
 	"github.com/consensys/gnark-crypto/internal/field"
 	mrand "math/rand"
@@ -75,6 +78,18 @@ func BenchmarkElementSetBytes(b *testing.B) {
 
 }
 
+func BenchmarkElementSetBytesWide(b *testing.B) {
+	var bb [2 * Bytes]byte
+	if _, err := rand.Read(bb[:]); err != nil {
+		b.Fatal(err)
+	}
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		benchResElement.SetBytesWide(bb[:])
+	}
+}
+
 func BenchmarkElementMulByConstants(b *testing.B) {
 	b.Run("mulBy3", func(b *testing.B) {
 		benchResElement.SetRandom()
@@ -132,6 +147,25 @@ func BenchmarkElementExp(b *testing.B) {
 	}
 }
 
+func BenchmarkElementExpElement(b *testing.B) {
+	var x, e Element
+	x.SetRandom()
+	e.SetRandom()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		benchResElement.ExpElement(x, &e)
+	}
+}
+
+func BenchmarkElementPow5(b *testing.B) {
+	var x Element
+	x.SetRandom()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		benchResElement.Pow5(&x)
+	}
+}
+
 func BenchmarkElementDouble(b *testing.B) {
 	benchResElement.SetRandom()
 	b.ResetTimer()
@@ -240,6 +274,47 @@ func BenchmarkElementCmp(b *testing.B) {
 	}
 }
 
+func TestElementConstants(t *testing.T) {
+	t.Parallel()
+	q := Modulus()
+
+	// q-1 = 2ˢ⋅t, t odd
+	var qMinusOne big.Int
+	qMinusOne.Sub(q, big.NewInt(1))
+	if qMinusOne.TrailingZeroBits() != TwoAdicity {
+		t.Fatal("TwoAdicity is not the 2-adic valuation of q-1")
+	}
+	oddPart := OddPart()
+	if oddPart.Bit(0) != 1 || new(big.Int).Lsh(oddPart, TwoAdicity).Cmp(&qMinusOne) != 0 {
+		t.Fatal("q-1 != 2^TwoAdicity * OddPart()")
+	}
+
+	// smallest quadratic non residue
+	var e Element
+	for i := uint64(2); i < QuadraticNonResidue; i++ {
+		if e.SetUint64(i).Legendre() != 1 {
+			t.Fatalf("%d is not a square mod q", i)
+		}
+	}
+	if e.SetUint64(QuadraticNonResidue).Legendre() != -1 {
+		t.Fatal("QuadraticNonResidue is a square mod q")
+	}
+
+	// Montgomery constants
+	q0 := qElement[0]
+	if q0*QInvNeg != ^uint64(0) {
+		t.Fatal("q * QInvNeg != -1 mod 2⁶⁴")
+	}
+	e.SetBigInt(MontgomeryR()).FromMont()
+	if e != One() {
+		t.Fatal("MontgomeryR() is not the Montgomery form of 1")
+	}
+	e.SetBigInt(MontgomeryRSquare()).FromMont()
+	if e != rSquare {
+		t.Fatal("MontgomeryRSquare() != R² mod q")
+	}
+}
+
 func TestElementCmp(t *testing.T) {
 	var x, y Element
 
@@ -474,6 +549,264 @@ func TestElementBytes(t *testing.T) {
 	properties.TestingRun(t, gopter.ConsoleReporter(false))
 }
 
+func TestElementSetBytesWide(t *testing.T) {
+	t.Parallel()
+	parameters := gopter.DefaultTestParameters()
+	if testing.Short() {
+		parameters.MinSuccessfulTests = nbFuzzShort
+	} else {
+		parameters.MinSuccessfulTests = nbFuzz
+	}
+
+	properties := gopter.NewProperties(parameters)
+
+	genA := gen()
+	genB := gen()
+
+	properties.Property("SetBytesWide should output the same result as SetBytes", prop.ForAll(
+		func(a, b testPairElement) bool {
+			var e [2 * Bytes]byte
+			copy(e[:Bytes], a.bigint.Bytes())
+			copy(e[2*Bytes-len(b.bigint.Bytes()):], b.bigint.Bytes())
+
+			var c, d Element
+			c.SetBytesWide(e[:])
+			d.SetBytes(e[:])
+			return c.Equal(&d)
+		},
+		genA,
+		genB,
+	))
+
+	properties.TestingRun(t, gopter.ConsoleReporter(false))
+
+	var e [2 * Bytes]byte
+	for i := range e {
+		e[i] = 0xff
+	}
+	for _, n := range []int{0, 1, Bytes - 1, Bytes, Bytes + 1, 2 * Bytes} {
+		var c, d Element
+		c.SetBytesWide(e[:n])
+		d.SetBytes(e[:n])
+		if !c.Equal(&d) {
+			t.Fatalf("SetBytesWide failed on %d bytes 0xff", n)
+		}
+	}
+}
+
+func TestElementLimbs(t *testing.T) {
+	t.Parallel()
+	parameters := gopter.DefaultTestParameters()
+	if testing.Short() {
+		parameters.MinSuccessfulTests = nbFuzzShort
+	} else {
+		parameters.MinSuccessfulTests = nbFuzz
+	}
+
+	properties := gopter.NewProperties(parameters)
+
+	genA := gen()
+
+	properties.Property("NewElementFromLimbs(LimbsRegular()) should stay constant", prop.ForAll(
+		func(a testPairElement) bool {
+			b := NewElementFromLimbs(a.element.LimbsRegular())
+			return a.element.Equal(&b)
+		},
+		genA,
+	))
+
+	properties.Property("LimbsRegular() should match the regular big.Int", prop.ForAll(
+		func(a testPairElement) bool {
+			limbs := a.element.LimbsRegular()
+			var b big.Int
+			for i := Limbs - 1; i >= 0; i-- {
+				b.Lsh(&b, 64)
+				b.Or(&b, new(big.Int).SetUint64(limbs[i]))
+			}
+			return b.Cmp(&a.bigint) == 0
+		},
+		genA,
+	))
+
+	properties.Property("UnsafeNewElementFromMontLimbs should not convert the limbs", prop.ForAll(
+		func(a testPairElement) bool {
+			b := UnsafeNewElementFromMontLimbs([Limbs]uint64(a.element))
+			return a.element.Equal(&b)
+		},
+		genA,
+	))
+
+	properties.TestingRun(t, gopter.ConsoleReporter(false))
+
+	// non canonical limbs are reduced
+	q := NewElementFromLimbs([Limbs]uint64(qElement))
+	if !q.IsZero() {
+		t.Fatal("NewElementFromLimbs(q) should be 0")
+	}
+	var max [Limbs]uint64
+	for i := range max {
+		max[i] = ^uint64(0)
+	}
+	e := NewElementFromLimbs(max)
+	var expected big.Int
+	expected.Lsh(big.NewInt(1), 64*Limbs).Sub(&expected, big.NewInt(1)).Mod(&expected, Modulus())
+	if e.ToBigIntRegular(new(big.Int)).Cmp(&expected) != 0 {
+		t.Fatal("NewElementFromLimbs should reduce modulo q")
+	}
+}
+
+func TestElementSliceToBytes(t *testing.T) {
+	t.Parallel()
+
+	a := make([]Element, 10)
+	for i := range a {
+		a[i].SetRandom()
+	}
+	a[0].SetZero()
+	a[1].SetOne()
+	a[2].Neg(&a[1])
+
+	// big-endian matches Bytes()
+	prefix := []byte{42}
+	buf := SliceToBytes(prefix, a, binary.BigEndian)
+	if len(buf) != 1+len(a)*Bytes || buf[0] != 42 {
+		t.Fatal("SliceToBytes should append to dst")
+	}
+	for i := range a {
+		b := a[i].Bytes()
+		if !bytes.Equal(b[:], buf[1+i*Bytes:1+(i+1)*Bytes]) {
+			t.Fatal("SliceToBytes with binary.BigEndian should match Bytes()")
+		}
+	}
+	res, err := BytesToSlice(nil, buf[1:], binary.BigEndian)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(a, res) {
+		t.Fatal("BytesToSlice(SliceToBytes()) should stay constant")
+	}
+
+	// little-endian is the byte-reversed big-endian encoding
+	bufLE := SliceToBytes(make([]byte, 0, len(a)*Bytes), a, binary.LittleEndian)
+	for i := range a {
+		for j := 0; j < Bytes; j++ {
+			if bufLE[i*Bytes+j] != buf[1+(i+1)*Bytes-1-j] {
+				t.Fatal("SliceToBytes with binary.LittleEndian should reverse the bytes of each element")
+			}
+		}
+	}
+	dst := make([]Element, 1, 1+len(a))
+	res, err = BytesToSlice(dst, bufLE, binary.LittleEndian)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(a, res[1:]) || &res[0] != &dst[0] {
+		t.Fatal("BytesToSlice should append to dst in place")
+	}
+
+	if _, err := BytesToSlice(nil, buf[:Bytes+1], binary.BigEndian); err == nil {
+		t.Fatal("BytesToSlice should fail on a truncated buffer")
+	}
+}
+
+func TestElementBatchFromBigIntSlice(t *testing.T) {
+	t.Parallel()
+
+	// large enough to be converted in parallel
+	n := minParallelConversion + 3
+	v := make([]*big.Int, n)
+	for i := range v {
+		var e Element
+		e.SetRandom()
+		v[i] = e.ToBigIntRegular(new(big.Int))
+	}
+	// inputs outside [0, q)
+	v[0] = new(big.Int).Neg(big.NewInt(1))
+	v[1] = new(big.Int).Add(Modulus(), big.NewInt(2))
+
+	res := BatchFromBigIntSlice(v)
+	if len(res) != n {
+		t.Fatal("BatchFromBigIntSlice returned a slice of the wrong length")
+	}
+	for i := range v {
+		var expected Element
+		expected.SetBigInt(v[i])
+		if !res[i].Equal(&expected) {
+			t.Fatal("BatchFromBigIntSlice should match SetBigInt")
+		}
+	}
+
+	if len(BatchFromBigIntSlice(nil)) != 0 {
+		t.Fatal("BatchFromBigIntSlice(nil) should be empty")
+	}
+}
+
+func TestElementExpVariants(t *testing.T) {
+	t.Parallel()
+	parameters := gopter.DefaultTestParameters()
+	if testing.Short() {
+		parameters.MinSuccessfulTests = nbFuzzShort
+	} else {
+		parameters.MinSuccessfulTests = nbFuzz
+	}
+
+	properties := gopter.NewProperties(parameters)
+
+	genA := gen()
+	genB := gen()
+
+	properties.Property("ExpElement should output same result than Exp", prop.ForAll(
+		func(a, b testPairElement) bool {
+			var c, d Element
+			c.ExpElement(a.element, &b.element)
+			d.Exp(a.element, &b.bigint)
+			return c.Equal(&d)
+		},
+		genA,
+		genB,
+	))
+
+	properties.Property("ExpUint64 should output same result than Exp", prop.ForAll(
+		func(a testPairElement, e uint64) bool {
+			var c, d Element
+			c.ExpUint64(a.element, e)
+			d.Exp(a.element, new(big.Int).SetUint64(e))
+			return c.Equal(&d)
+		},
+		genA,
+		ggen.UInt64(),
+	))
+
+	properties.Property("Cube, Pow5 and Pow7 should output same result than ExpUint64", prop.ForAll(
+		func(a testPairElement) bool {
+			var c, d Element
+			ok := true
+			c.Cube(&a.element)
+			d.ExpUint64(a.element, 3)
+			ok = ok && c.Equal(&d)
+			c.Pow5(&a.element)
+			d.ExpUint64(a.element, 5)
+			ok = ok && c.Equal(&d)
+			c.Pow7(&a.element)
+			d.ExpUint64(a.element, 7)
+			return ok && c.Equal(&d)
+		},
+		genA,
+	))
+
+	properties.Property("x⁰ == 1", prop.ForAll(
+		func(a testPairElement) bool {
+			var c, d, zero Element
+			c.ExpUint64(a.element, 0)
+			d.ExpElement(a.element, &zero)
+			return c.IsOne() && d.IsOne()
+		},
+		genA,
+	))
+
+	properties.TestingRun(t, gopter.ConsoleReporter(false))
+}
+
 func TestElementInverseExp(t *testing.T) {
 	// inverse must be equal to exp^-2
 	exp := Modulus()
@@ -678,7 +1011,7 @@ func TestElementButterflies(t *testing.T) {
 
 }
 
-func TestElementLexicographicallyLargest(t *testing.T) {
+func TestElementIsLexLargerThanHalf(t *testing.T) {
 	t.Parallel()
 	parameters := gopter.DefaultTestParameters()
 	if testing.Short() {
@@ -691,13 +1024,13 @@ func TestElementLexicographicallyLargest(t *testing.T) {
 
 	genA := gen()
 
-	properties.Property("element.Cmp should match LexicographicallyLargest output", prop.ForAll(
+	properties.Property("element.Cmp should match IsLexLargerThanHalf output", prop.ForAll(
 		func(a testPairElement) bool {
 			var negA Element
 			negA.Neg(&a.element)
 
 			cmpResult := a.element.Cmp(&negA)
-			lResult := a.element.LexicographicallyLargest()
+			lResult := a.element.IsLexLargerThanHalf()
 
 			if lResult && cmpResult == 1 {
 				return true
@@ -714,6 +1047,88 @@ func TestElementLexicographicallyLargest(t *testing.T) {
 
 }
 
+func TestElementSign0(t *testing.T) {
+	t.Parallel()
+	parameters := gopter.DefaultTestParameters()
+	if testing.Short() {
+		parameters.MinSuccessfulTests = nbFuzzShort
+	} else {
+		parameters.MinSuccessfulTests = nbFuzz
+	}
+
+	properties := gopter.NewProperties(parameters)
+
+	genA := gen()
+
+	properties.Property("Sign0 should match the parity of the big.Int value", prop.ForAll(
+		func(a testPairElement) bool {
+			return a.element.Sign0() == uint64(a.bigint.Bit(0))
+		},
+		genA,
+	))
+
+	properties.Property("Sign0 of a non-zero element and of its negation should differ", prop.ForAll(
+		func(a testPairElement) bool {
+			if a.element.IsZero() {
+				return a.element.Sign0() == 0
+			}
+			var negA Element
+			negA.Neg(&a.element)
+			return a.element.Sign0() != negA.Sign0()
+		},
+		genA,
+	))
+
+	properties.TestingRun(t, gopter.ConsoleReporter(false))
+
+	var e Element
+	if e.Sign0() != 0 || e.IsLexLargerThanHalf() {
+		t.Fatal("0 should have sign 0 and not be larger than (q-1)/2")
+	}
+	e.SetOne()
+	if e.Sign0() != 1 || e.IsLexLargerThanHalf() {
+		t.Fatal("1 should have sign 1 and not be larger than (q-1)/2")
+	}
+	e.Neg(&e)
+	if e.Sign0() != 0 || !e.IsLexLargerThanHalf() {
+		t.Fatal("-1 should have sign 0 and be larger than (q-1)/2")
+	}
+}
+
+func TestElementIsOne(t *testing.T) {
+	t.Parallel()
+	parameters := gopter.DefaultTestParameters()
+	if testing.Short() {
+		parameters.MinSuccessfulTests = nbFuzzShort
+	} else {
+		parameters.MinSuccessfulTests = nbFuzz
+	}
+
+	properties := gopter.NewProperties(parameters)
+
+	genA := gen()
+
+	properties.Property("IsOne should match the big.Int value", prop.ForAll(
+		func(a testPairElement) bool {
+			return a.element.IsOne() == (a.bigint.Cmp(big.NewInt(1)) == 0)
+		},
+		genA,
+	))
+
+	properties.TestingRun(t, gopter.ConsoleReporter(false))
+
+	var e Element
+	if e.IsOne() {
+		t.Fatal("0 should not be one")
+	}
+	if !e.SetOne().IsOne() {
+		t.Fatal("1 should be one")
+	}
+	if e.Neg(&e).IsOne() {
+		t.Fatal("-1 should not be one")
+	}
+}
+
 func TestElementAdd(t *testing.T) {
 	t.Parallel()
 	parameters := gopter.DefaultTestParameters()
@@ -1335,6 +1750,16 @@ func TestElementSquare(t *testing.T) {
 		genA,
 	))
 
+	properties.Property("Square: assembly implementation must be consistent with generic one", prop.ForAll(
+		func(a testPairElement) bool {
+			var c, d Element
+			c.Square(&a.element)
+			_squareGeneric(&d, &a.element)
+			return c.Equal(&d)
+		},
+		genA,
+	))
+
 	specialValueTest := func() {
 		// test special values
 		testValues := make([]Element, len(staticTestValues))
@@ -1349,6 +1774,13 @@ func TestElementSquare(t *testing.T) {
 			var d, e big.Int
 			d.Mul(&aBig, &aBig).Mod(&d, Modulus())
 
+			// checking asm against generic impl
+			var cGeneric Element
+			_squareGeneric(&cGeneric, &a)
+			if !cGeneric.Equal(&c) {
+				t.Fatal("Square failed special test values: asm and generic impl don't match")
+			}
+
 			if c.FromMont().ToBigInt(&e).Cmp(&d) != 0 {
 				t.Fatal("Square failed special test values")
 			}
@@ -1671,22 +2103,22 @@ func TestElementFixedExp(t *testing.T) {
 
 	genA := gen()
 
-	properties.Property(fmt.Sprintf("expBySqrtExp must match Exp(%s)", sqrtExponentElement), prop.ForAll(
+	properties.Property(fmt.Sprintf("ExpBySqrtExp must match Exp(%s)", sqrtExponentElement), prop.ForAll(
 		func(a testPairElement) bool {
 			c := a.element
 			d := a.element
-			c.expBySqrtExp(c)
+			c.ExpBySqrtExp(c)
 			d.Exp(d, _bSqrtExponentElement)
 			return c.Equal(&d)
 		},
 		genA,
 	))
 
-	properties.Property("expByLegendreExp must match Exp(955b2af4d1652ab305a268f2e1bd800acd53b7f680000008508c00000000000)", prop.ForAll(
+	properties.Property("ExpByLegendreExp must match Exp(955b2af4d1652ab305a268f2e1bd800acd53b7f680000008508c00000000000)", prop.ForAll(
 		func(a testPairElement) bool {
 			c := a.element
 			d := a.element
-			c.expByLegendreExp(c)
+			c.ExpByLegendreExp(c)
 			d.Exp(d, _bLegendreExponentElement)
 			return c.Equal(&d)
 		},
@@ -1995,11 +2427,114 @@ func TestElementSetInterface(t *testing.T) {
 
 		r, err = e.SetInterface(ptB)
 		assert.Nil(r)
-		assert.Error(err)
+		assert.ErrorIs(err, ErrInvalidEncoding)
 
+		r, err = e.SetInterface(1.5)
+		assert.Nil(r)
+		assert.ErrorIs(err, ErrInvalidEncoding)
 	}
 }
 
+func TestElementSetStringChecked(t *testing.T) {
+	t.Parallel()
+	parameters := gopter.DefaultTestParameters()
+	if testing.Short() {
+		parameters.MinSuccessfulTests = nbFuzzShort
+	} else {
+		parameters.MinSuccessfulTests = nbFuzz
+	}
+
+	properties := gopter.NewProperties(parameters)
+
+	genA := gen()
+
+	properties.Property("SetStringChecked should match SetString on canonical values", prop.ForAll(
+		func(a testPairElement) bool {
+			var c, d Element
+			if _, err := c.SetStringChecked(a.bigint.String()); err != nil {
+				return false
+			}
+			d.SetString(a.bigint.String())
+			return c.Equal(&d) && c.Equal(&a.element)
+		},
+		genA,
+	))
+
+	properties.TestingRun(t, gopter.ConsoleReporter(false))
+
+	assert := require.New(t)
+	q := Modulus()
+	var qMinusOne big.Int
+	qMinusOne.Sub(q, big.NewInt(1))
+
+	var e Element
+	r, err := e.SetStringChecked("0x" + qMinusOne.Text(16))
+	assert.NoError(err)
+	assert.True(new(Element).Add(r, new(Element).SetOne()).IsZero())
+
+	e.SetOne()
+	for _, s := range []string{"", "abc", "0x", "1_", "1.5", "-1", "-0x1"} {
+		r, err = e.SetStringChecked(s)
+		assert.Nil(r, s)
+		assert.ErrorIs(err, ErrInvalidEncoding, s)
+		assert.True(e.IsOne(), "z should be left unchanged")
+	}
+
+	var qPlusOne big.Int
+	qPlusOne.Add(q, big.NewInt(1))
+	for _, s := range []string{q.String(), "0x" + q.Text(16), qPlusOne.String()} {
+		r, err = e.SetStringChecked(s)
+		assert.Nil(r, s)
+		assert.ErrorIs(err, ErrAboveModulus, s)
+		assert.True(e.IsOne(), "z should be left unchanged")
+	}
+
+	// the lenient parsers reduce modulo q, but report encoding errors
+	_, err = e.SetString(q.String())
+	assert.NoError(err)
+	assert.True(e.IsZero())
+	_, err = e.SetString("abc")
+	assert.ErrorIs(err, ErrInvalidEncoding)
+	assert.ErrorIs(e.UnmarshalJSON([]byte("\"abc\"")), ErrInvalidEncoding)
+}
+
+func TestElementSetBytesCanonical(t *testing.T) {
+	// not parallel, as it measures allocations
+	assert := require.New(t)
+
+	var a, b Element
+	for i := 0; i < 10; i++ {
+		a.SetRandom()
+		buf := a.Bytes()
+		assert.NoError(b.SetBytesCanonical(buf[:]))
+		assert.True(a.Equal(&b))
+	}
+
+	// q and 2^(8⋅Bytes)-1 are rejected, q-1 is accepted
+	b.SetOne()
+	var buf [Bytes]byte
+	Modulus().FillBytes(buf[:])
+	assert.ErrorIs(b.SetBytesCanonical(buf[:]), ErrAboveModulus)
+	assert.True(b.IsOne(), "z should be left unchanged")
+	for i := range buf {
+		buf[i] = 0xff
+	}
+	assert.ErrorIs(b.SetBytesCanonical(buf[:]), ErrAboveModulus)
+	new(big.Int).Sub(Modulus(), big.NewInt(1)).FillBytes(buf[:])
+	assert.NoError(b.SetBytesCanonical(buf[:]))
+	assert.True(b.Add(&b, new(Element).SetOne()).IsZero())
+
+	// wrong lengths
+	assert.ErrorIs(b.SetBytesCanonical(buf[1:]), ErrInvalidEncoding)
+	assert.ErrorIs(b.SetBytesCanonical(append(buf[:], 0)), ErrInvalidEncoding)
+	assert.ErrorIs(b.SetBytesCanonical(nil), ErrInvalidEncoding)
+
+	allocs := testing.AllocsPerRun(10, func() {
+		_ = b.SetBytesCanonical(buf[:])
+	})
+	assert.Equal(0.0, allocs, "SetBytesCanonical shouldn't allocate")
+}
+
 func TestElementNegativeExp(t *testing.T) {
 	t.Parallel()
 
@@ -2840,3 +3375,221 @@ func approximateRef(x *Element) uint64 {
 	hi.Add(&hi, &lo)
 	return hi.Uint64()
 }
+
+func TestElementRecoding(t *testing.T) {
+	t.Parallel()
+	parameters := gopter.DefaultTestParameters()
+	if testing.Short() {
+		parameters.MinSuccessfulTests = nbFuzzShort
+	} else {
+		parameters.MinSuccessfulTests = nbFuzz
+	}
+
+	properties := gopter.NewProperties(parameters)
+
+	genA := gen()
+	genW := ggen.UIntRange(2, 8)
+	genC := ggen.UIntRange(1, 16)
+	genSignedC := ggen.UIntRange(2, 16)
+
+	properties.Property("ToWNAF digits should be odd, bounded, non-adjacent and recompose to the input", prop.ForAll(
+		func(a testPairElement, w uint) bool {
+			digits := a.element.ToWNAF(w)
+			var acc, d big.Int
+			prev := -1
+			for i := len(digits) - 1; i >= 0; i-- {
+				acc.Lsh(&acc, 1)
+				if digits[i] == 0 {
+					continue
+				}
+				if digits[i]%2 == 0 || int(digits[i]) >= 1<<(w-1) || int(digits[i]) <= -(1<<(w-1)) {
+					return false
+				}
+				if prev >= 0 && prev-i < int(w) {
+					return false
+				}
+				prev = i
+				acc.Add(&acc, d.SetInt64(int64(digits[i])))
+			}
+			return acc.Cmp(&a.bigint) == 0
+		},
+		genA, genW,
+	))
+
+	properties.Property("ToFixedWindow digits should be bounded and recompose to the input", prop.ForAll(
+		func(a testPairElement, c uint) bool {
+			digits := a.element.ToFixedWindow(c)
+			if len(digits) != (Bits+int(c)-1)/int(c) {
+				return false
+			}
+			var acc, d big.Int
+			for i := len(digits) - 1; i >= 0; i-- {
+				if uint(digits[i]) >= 1<<c {
+					return false
+				}
+				acc.Lsh(&acc, c)
+				acc.Add(&acc, d.SetUint64(uint64(digits[i])))
+			}
+			return acc.Cmp(&a.bigint) == 0
+		},
+		genA, genC,
+	))
+
+	properties.Property("ToSignedFixedWindow digits should be bounded and recompose to the input", prop.ForAll(
+		func(a testPairElement, c uint) bool {
+			digits := a.element.ToSignedFixedWindow(c)
+			var acc, d big.Int
+			for i := len(digits) - 1; i >= 0; i-- {
+				if digits[i] >= 1<<(c-1) || digits[i] < -(1<<(c-1)) {
+					return false
+				}
+				acc.Lsh(&acc, c)
+				acc.Add(&acc, d.SetInt64(int64(digits[i])))
+			}
+			return acc.Cmp(&a.bigint) == 0
+		},
+		genA, genSignedC,
+	))
+
+	properties.TestingRun(t, gopter.ConsoleReporter(false))
+
+	var zero Element
+	if len(zero.ToWNAF(4)) != 0 {
+		t.Fatal("ToWNAF(0) should be empty")
+	}
+}
+
+func TestElementUnreduced(t *testing.T) {
+	t.Parallel()
+	parameters := gopter.DefaultTestParameters()
+	if testing.Short() {
+		parameters.MinSuccessfulTests = nbFuzzShort
+	} else {
+		parameters.MinSuccessfulTests = nbFuzz
+	}
+
+	properties := gopter.NewProperties(parameters)
+
+	genA := gen()
+	genB := gen()
+	genC := gen()
+
+	properties.Property("MulAcc and Add should output the same result as Mul and Add", prop.ForAll(
+		func(a, b, c testPairElement) bool {
+			var acc ElementUnreduced
+			acc.MulAcc(&a.element, &b.element).Add(&c.element).MulAcc(&c.element, &a.element)
+
+			var expected, tmp Element
+			expected.Mul(&a.element, &b.element).Add(&expected, &c.element)
+			tmp.Mul(&c.element, &a.element)
+			expected.Add(&expected, &tmp)
+
+			var res Element
+			acc.Reduce(&res)
+			return res.Equal(&expected)
+		},
+		genA,
+		genB,
+		genC,
+	))
+
+	properties.Property("Dot should output the same result as a naive inner product", prop.ForAll(
+		func(a, b testPairElement) bool {
+			const n = 17
+			va := make([]Element, n)
+			vb := make([]Element, n)
+			var expected, tmp Element
+			for i := 0; i < n; i++ {
+				va[i].SetUint64(uint64(i)).Add(&va[i], &a.element)
+				vb[i].SetUint64(uint64(i)).Mul(&vb[i], &b.element)
+				tmp.Mul(&va[i], &vb[i])
+				expected.Add(&expected, &tmp)
+			}
+			res := Dot(va, vb)
+			return res.Equal(&expected)
+		},
+		genA,
+		genB,
+	))
+
+	properties.TestingRun(t, gopter.ConsoleReporter(false))
+
+	// largest limbs, many times
+	var qMinusOne, expected, tmp, res Element
+	qMinusOne.SetOne().Neg(&qMinusOne)
+	var acc ElementUnreduced
+	for i := 0; i < 1000; i++ {
+		acc.MulAcc(&qMinusOne, &qMinusOne).Add(&qMinusOne)
+		tmp.Mul(&qMinusOne, &qMinusOne).Add(&tmp, &qMinusOne)
+		expected.Add(&expected, &tmp)
+	}
+	if !acc.Reduce(&res).Equal(&expected) {
+		t.Fatal("accumulating (q-1)² + (q-1) failed")
+	}
+
+	acc.SetZero()
+	if !acc.Reduce(&res).IsZero() {
+		t.Fatal("empty accumulator should reduce to 0")
+	}
+}
+
+func BenchmarkElementDot(b *testing.B) {
+	const n = 1024
+	va := make([]Element, n)
+	vb := make([]Element, n)
+	for i := 0; i < n; i++ {
+		va[i].SetRandom()
+		vb[i].SetRandom()
+	}
+
+	b.Run("naive", func(b *testing.B) {
+		for j := 0; j < b.N; j++ {
+			var tmp Element
+			benchResElement.SetZero()
+			for i := 0; i < n; i++ {
+				tmp.Mul(&va[i], &vb[i])
+				benchResElement.Add(&benchResElement, &tmp)
+			}
+		}
+	})
+
+	b.Run("unreduced", func(b *testing.B) {
+		for j := 0; j < b.N; j++ {
+			benchResElement = Dot(va, vb)
+		}
+	})
+}
+
+func TestElementSumProduct(t *testing.T) {
+	t.Parallel()
+
+	for _, n := range []int{0, 1, 7, minParallelReduction - 1, minParallelReduction, 3*minParallelReduction + 5} {
+		a := make([]Element, n)
+		var sum Element
+		prod := One()
+		for i := 0; i < n; i++ {
+			a[i].SetRandom()
+			sum.Add(&sum, &a[i])
+			prod.Mul(&prod, &a[i])
+		}
+
+		if s := Sum(a); !s.Equal(&sum) {
+			t.Fatalf("Sum mismatch for n = %d", n)
+		}
+		if p := Product(a); !p.Equal(&prod) {
+			t.Fatalf("Product mismatch for n = %d", n)
+		}
+	}
+}
+
+func BenchmarkElementProduct(b *testing.B) {
+	const n = 1 << 20
+	a := make([]Element, n)
+	for i := 0; i < n; i++ {
+		a[i].SetRandom()
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		benchResElement = Product(a)
+	}
+}
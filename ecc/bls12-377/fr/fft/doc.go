@@ -15,4 +15,6 @@
 // Code generated by consensys/gnark-crypto DO NOT EDIT
 
 // Package fft provides in-place discrete Fourier transform.
+//
+// The evaluations and coefficients it operates on can be stored and streamed with fr.Vector.
 package fft
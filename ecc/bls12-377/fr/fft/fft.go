@@ -18,7 +18,6 @@ package fft
 
 import (
 	"math/bits"
-	"runtime"
 
 	"github.com/consensys/gnark-crypto/ecc"
 	"github.com/consensys/gnark-crypto/internal/parallel"
@@ -41,9 +40,10 @@ const butterflyThreshold = 16
 // if decimation == DIT (decimation in time), the input must be in bit-reversed order
 // if decimation == DIF (decimation in frequency), the output will be in bit-reversed order
 // if coset if set, the FFT(a) returns the evaluation of a on a coset.
+// the transform is computed in place, without temporary slices.
 func (domain *Domain) FFT(a []fr.Element, decimation Decimation, coset ...bool) {
 
-	numCPU := uint64(runtime.NumCPU())
+	numCPU := uint64(parallel.MaxProcs())
 
 	_coset := false
 	if len(coset) > 0 {
@@ -91,7 +91,7 @@ func (domain *Domain) FFT(a []fr.Element, decimation Decimation, coset ...bool)
 // len(a) must be a power of 2, and w must be a len(a)th root of unity in field F.
 func (domain *Domain) FFTInverse(a []fr.Element, decimation Decimation, coset ...bool) {
 
-	numCPU := uint64(runtime.NumCPU())
+	numCPU := uint64(parallel.MaxProcs())
 
 	_coset := false
 	if len(coset) > 0 {
@@ -159,7 +159,7 @@ func difFFT(a []fr.Element, twiddles [][]fr.Element, stage, maxSplits int, chDon
 	// but we have only numCPU / stage cpus available
 	if (m > butterflyThreshold) && (stage < maxSplits) {
 		// 1 << stage == estimated used CPUs
-		numCPU := runtime.NumCPU() / (1 << (stage))
+		numCPU := parallel.MaxProcs() / (1 << (stage))
 		parallel.Execute(m, func(start, end int) {
 			for i := start; i < end; i++ {
 				fr.Butterfly(&a[i], &a[i+m])
@@ -223,7 +223,7 @@ func ditFFT(a []fr.Element, twiddles [][]fr.Element, stage, maxSplits int, chDon
 	// but we have only numCPU / stage cpus available
 	if (m > butterflyThreshold) && (stage < maxSplits) {
 		// 1 << stage == estimated used CPUs
-		numCPU := runtime.NumCPU() / (1 << (stage))
+		numCPU := parallel.MaxProcs() / (1 << (stage))
 		parallel.Execute(m, func(start, end int) {
 			for k := start; k < end; k++ {
 				a[k+m].Mul(&a[k+m], &twiddles[stage][k])
@@ -0,0 +1,177 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package fft
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
+
+	curve "github.com/consensys/gnark-crypto/ecc/bls12-377"
+)
+
+// toeplitzEntry returns T[i][j] for the Toeplitz matrix of first column column and first row row
+func toeplitzEntry(column, row []fr.Element, i, j int) fr.Element {
+	if i >= j {
+		return column[i-j]
+	}
+	return row[j-i]
+}
+
+func randomVector(n int) []fr.Element {
+	v := make([]fr.Element, n)
+	for i := range v {
+		v[i].SetRandom()
+	}
+	return v
+}
+
+func TestToeplitzMul(t *testing.T) {
+	for _, n := range []int{1, 2, 5, 16, 33} {
+		column, row, v := randomVector(n), randomVector(n), randomVector(n)
+		res, err := ToeplitzMul(column, row, v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i := 0; i < n; i++ {
+			var expected, tmp fr.Element
+			for j := 0; j < n; j++ {
+				tmp = toeplitzEntry(column, row, i, j)
+				tmp.Mul(&tmp, &v[j])
+				expected.Add(&expected, &tmp)
+			}
+			if !expected.Equal(&res[i]) {
+				t.Fatalf("n=%d: wrong Toeplitz product", n)
+			}
+		}
+	}
+
+	if _, err := ToeplitzMul(randomVector(3), randomVector(2), randomVector(3)); err != ErrToeplitzSize {
+		t.Fatal("sizes should be checked")
+	}
+}
+
+func TestCirculantMul(t *testing.T) {
+	const n = 8
+	c, v := randomVector(n), randomVector(n)
+	res, err := CirculantMul(c, v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < n; i++ {
+		var expected, tmp fr.Element
+		for j := 0; j < n; j++ {
+			tmp.Mul(&c[(i-j+n)%n], &v[j])
+			expected.Add(&expected, &tmp)
+		}
+		if !expected.Equal(&res[i]) {
+			t.Fatal("wrong circulant product")
+		}
+	}
+
+	if _, err := CirculantMul(randomVector(6), randomVector(6)); err != ErrCirculantSize {
+		t.Fatal("the size should be a power of 2")
+	}
+}
+
+func TestToeplitzMulG1(t *testing.T) {
+	const n = 6
+	_, _, g1, _ := curve.Generators()
+	points := make([]curve.G1Affine, n)
+	for i := range points {
+		points[i].ScalarMultiplication(&g1, big.NewInt(int64(i+2)))
+	}
+	column, row := randomVector(n), randomVector(n)
+
+	res, err := ToeplitzMulG1(column, row, points)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < n; i++ {
+		var expected, tmp curve.G1Jac
+		var s big.Int
+		for j := 0; j < n; j++ {
+			e := toeplitzEntry(column, row, i, j)
+			e.ToBigIntRegular(&s)
+			tmp.FromAffine(&points[j])
+			tmp.ScalarMultiplication(&tmp, &s)
+			expected.AddAssign(&tmp)
+		}
+		if !expected.Equal(&res[i]) {
+			t.Fatal("wrong Toeplitz product in G1")
+		}
+	}
+
+	if _, err := ToeplitzMulG1(column, row, points[1:]); err != ErrToeplitzSize {
+		t.Fatal("sizes should be checked")
+	}
+}
+
+func TestFeistKhovratovichG1(t *testing.T) {
+	const n = 8
+	_, _, g1, _ := curve.Generators()
+	points := make([]curve.G1Affine, n)
+	for i := range points {
+		points[i].ScalarMultiplication(&g1, big.NewInt(int64(3*i+1)))
+	}
+	c := randomVector(n)
+
+	h, err := FeistKhovratovichG1(c, points)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for m := 0; m < n; m++ {
+		var expected, tmp curve.G1Jac
+		var s big.Int
+		for j := 0; m+j+1 < n; j++ {
+			c[m+j+1].ToBigIntRegular(&s)
+			tmp.FromAffine(&points[j])
+			tmp.ScalarMultiplication(&tmp, &s)
+			expected.AddAssign(&tmp)
+		}
+		if !expected.Equal(&h[m]) {
+			t.Fatal("wrong Feist-Khovratovich coefficients")
+		}
+	}
+
+	// FFTG1 matches the FFT in fr: ∑ⱼωⁱʲ[cⱼ]G₁ = [FFT(c)ᵢ]G₁
+	domain := NewDomain(n)
+	a := make([]curve.G1Jac, n)
+	var s big.Int
+	for j := range a {
+		c[j].ToBigIntRegular(&s)
+		a[j].FromAffine(&g1)
+		a[j].ScalarMultiplication(&a[j], &s)
+	}
+	FFTG1(a, domain.Generator)
+	domain.FFT(c, DIF)
+	BitReverse(c)
+	for i := range a {
+		var expected curve.G1Jac
+		c[i].ToBigIntRegular(&s)
+		expected.FromAffine(&g1)
+		expected.ScalarMultiplication(&expected, &s)
+		if !expected.Equal(&a[i]) {
+			t.Fatal("wrong FFT in G1")
+		}
+	}
+
+	if _, err := FeistKhovratovichG1(c, points[1:]); err != ErrToeplitzSize {
+		t.Fatal("sizes should be checked")
+	}
+}
@@ -0,0 +1,114 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package hashutils
+
+import (
+	"encoding/binary"
+	"errors"
+	"hash"
+	"io"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
+	"github.com/consensys/gnark-crypto/internal/parallel"
+)
+
+// DefaultChunkSize is a chunk size for HashStream, in bytes
+const DefaultChunkSize = 4096
+
+// ErrChunkSize is returned by HashStream when the chunk size is not positive
+var ErrChunkSize = errors.New("the chunk size should be positive")
+
+// HashStream reads r until EOF, and hashes the data to a sequence of fr elements, one per chunk
+// of chunkSize bytes, e.g. to commit to a large file for data availability.
+//
+// The data is padded with a 0x80 byte followed by zeroes up to a multiple of chunkSize (there is
+// always a padding byte, so an empty stream gives one chunk), and the i-th chunk is hashed with
+// its index:
+//
+//	eᵢ = HashToFr(h, I2OSP(i, 8), chunkᵢ)
+//
+// The chunks are read by batches, which are hashed in parallel, so the memory usage doesn't
+// depend on the size of the stream (apart from the result). See MerkleRoot to compress the
+// result to a single element.
+func HashStream(r io.Reader, h func() hash.Hash, chunkSize int) ([]fr.Element, error) {
+	if chunkSize <= 0 {
+		return nil, ErrChunkSize
+	}
+
+	buf := make([]byte, 4*parallel.MaxProcs()*chunkSize)
+	var res []fr.Element
+	for {
+		n, err := io.ReadFull(r, buf)
+		last := err == io.EOF || err == io.ErrUnexpectedEOF
+		if err != nil && !last {
+			return nil, err
+		}
+
+		// the last batch is not full, there is room for the padding
+		data := buf[:n]
+		if last {
+			data = buf[:(n/chunkSize+1)*chunkSize]
+			data[n] = 0x80
+			for i := n + 1; i < len(data); i++ {
+				data[i] = 0
+			}
+		}
+
+		nbChunks := len(data) / chunkSize
+		offset := len(res)
+		res = append(res, make([]fr.Element, nbChunks)...)
+		parallel.Execute(nbChunks, func(start, end int) {
+			var index [8]byte
+			for i := start; i < end; i++ {
+				binary.BigEndian.PutUint64(index[:], uint64(offset+i))
+				res[offset+i] = HashToFr(h, index[:], data[i*chunkSize:(i+1)*chunkSize])
+			}
+		})
+
+		if last {
+			return res, nil
+		}
+	}
+}
+
+// MerkleRoot returns the root of the binary Merkle tree whose leaves are the elements, padded
+// with zeroes up to a power of 2. The parent of the nodes a and b is HashToFr(h, a.Bytes(), b.Bytes()).
+// The root of a single leaf is the leaf, and the root of no leaves is zero.
+//
+// The chunks of HashStream and the nodes are both hashed from 2 length-prefixed inputs, but the
+// first one is the 8-byte index of the chunk for the former, and an element of fr.Bytes bytes for
+// the latter: the length prefixes differ, so the leaves of a tree built on the output of
+// HashStream can't be confused with internal nodes.
+func MerkleRoot(h func() hash.Hash, leaves []fr.Element) fr.Element {
+	if len(leaves) == 0 {
+		return fr.Element{}
+	}
+	level := make([]fr.Element, ecc.NextPowerOfTwo(uint64(len(leaves))))
+	copy(level, leaves)
+	for len(level) > 1 {
+		next := make([]fr.Element, len(level)/2)
+		parallel.Execute(len(next), func(start, end int) {
+			for i := start; i < end; i++ {
+				a, b := level[2*i].Bytes(), level[2*i+1].Bytes()
+				next[i] = HashToFr(h, a[:], b[:])
+			}
+		})
+		level = next
+	}
+	return level[0]
+}
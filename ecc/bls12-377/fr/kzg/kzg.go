@@ -17,7 +17,9 @@
 package kzg
 
 import (
+	"crypto/sha256"
 	"errors"
+	"fmt"
 	"hash"
 	"math/big"
 	"sync"
@@ -25,15 +27,22 @@ import (
 	"github.com/consensys/gnark-crypto/ecc"
 	"github.com/consensys/gnark-crypto/ecc/bls12-377"
 	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr/fft"
 	"github.com/consensys/gnark-crypto/fiat-shamir"
+	"github.com/consensys/gnark-crypto/internal/parallel"
 )
 
 var (
 	ErrInvalidNbDigests              = errors.New("number of digests is not the same as the number of polynomials")
 	ErrInvalidPolynomialSize         = errors.New("invalid polynomial size (larger than SRS or == 0)")
-	ErrVerifyOpeningProof            = errors.New("can't verify opening proof")
-	ErrVerifyBatchOpeningSinglePoint = errors.New("can't verify batch opening proof at single point")
+	ErrVerifyOpeningProof            = fmt.Errorf("can't verify opening proof: %w", ecc.ErrPairingCheckFailed)
+	ErrVerifyBatchOpeningSinglePoint = fmt.Errorf("can't verify batch opening proof at single point: %w", ecc.ErrPairingCheckFailed)
 	ErrMinSRSSize                    = errors.New("minimum srs size is 2")
+	ErrInvalidShiftedIndex           = errors.New("shifted indices should be valid indices of polynomials, and not empty")
+	ErrLagrangeSRSSize               = errors.New("size of a srs in Lagrange form must be a power of 2")
+	ErrSRSDegenerate                 = errors.New("srs contains a point at infinity")
+	ErrSRSSubgroup                   = errors.New("srs contains a point which is not in the correct subgroup")
+	ErrSRSInconsistent               = fmt.Errorf("srs G1 points are not successive powers of the G2 secret: %w", ecc.ErrPairingCheckFailed)
 )
 
 // Digest commitment of a polynomial.
@@ -61,6 +70,9 @@ func eval(p []fr.Element, point fr.Element) fr.Element {
 //
 // In production, a SRS generated through MPC should be used.
 //
+// The copies of alpha made during the computation are zeroized before returning;
+// zeroizing bAlpha is the responsibility of the caller.
+//
 // implements io.ReaderFrom and io.WriterTo
 func NewSRS(size uint64, bAlpha *big.Int) (*SRS, error) {
 
@@ -68,29 +80,225 @@ func NewSRS(size uint64, bAlpha *big.Int) (*SRS, error) {
 		return nil, ErrMinSRSSize
 	}
 
+	srs, _, err := newSRS(size, bAlpha, false)
+	return srs, err
+}
+
+// NewSRSWithLagrange returns a new SRS using alpha as randomness source, as NewSRS,
+// along with the Lagrange basis [L₀(α)]G₁, …, [Lₙ₋₁(α)]G₁ of the domain of size n = size,
+// so that polynomials given in Lagrange form can be committed without an FFT.
+//
+// size must be a power of 2. Both bases are computed at once, sharing the powers of alpha.
+func NewSRSWithLagrange(size uint64, bAlpha *big.Int) (*SRS, []bls12377.G1Affine, error) {
+
+	if size < 2 {
+		return nil, nil, ErrMinSRSSize
+	}
+	if size&(size-1) != 0 {
+		return nil, nil, ErrLagrangeSRSSize
+	}
+
+	return newSRS(size, bAlpha, true)
+}
+
+// NewTestSRS returns a deterministic SRS, derived from a fixed public seed, to build
+// reproducible test fixtures.
+//
+// The toxic waste of this SRS is public: it MUST NOT be used in production.
+func NewTestSRS(size uint64) (*SRS, error) {
+	seed := sha256.Sum256([]byte("gnark-crypto/kzg: insecure test SRS"))
+	var alpha big.Int
+	alpha.SetBytes(seed[:]).Mod(&alpha, fr.Modulus())
+	return NewSRS(size, &alpha)
+}
+
+func newSRS(size uint64, bAlpha *big.Int, withLagrange bool) (*SRS, []bls12377.G1Affine, error) {
 	var srs SRS
 	srs.G1 = make([]bls12377.G1Affine, size)
 
 	var alpha fr.Element
 	alpha.SetBigInt(bAlpha)
+	defer alpha.SetZero()
 
 	_, _, gen1Aff, gen2Aff := bls12377.Generators()
 	srs.G1[0] = gen1Aff
 	srs.G2[0] = gen2Aff
-	srs.G2[1].ScalarMultiplication(&gen2Aff, bAlpha)
 
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		srs.G2[1].ScalarMultiplication(&gen2Aff, bAlpha)
+		wg.Done()
+	}()
+
+	// alphas[i] = αⁱ⁺¹, computed by chunks in parallel
 	alphas := make([]fr.Element, size-1)
-	alphas[0] = alpha
-	for i := 1; i < len(alphas); i++ {
-		alphas[i].Mul(&alphas[i-1], &alpha)
-	}
-	for i := 0; i < len(alphas); i++ {
-		alphas[i].FromMont()
+	parallel.Execute(len(alphas), func(start, end int) {
+		alphas[start].ExpUint64(alpha, uint64(start+1))
+		for i := start + 1; i < end; i++ {
+			alphas[i].Mul(&alphas[i-1], &alpha)
+		}
+	})
+	defer zeroize(alphas)
+
+	var lagrange []bls12377.G1Affine
+	if withLagrange {
+		scalars := lagrangeScalars(alpha, alphas[size-2], size)
+		defer zeroize(scalars)
+		parallel.Execute(len(scalars), func(start, end int) {
+			for i := start; i < end; i++ {
+				scalars[i].FromMont()
+			}
+		})
+		wg.Add(1)
+		go func() {
+			lagrange = bls12377.BatchScalarMultiplicationG1(&gen1Aff, scalars)
+			wg.Done()
+		}()
 	}
+
+	parallel.Execute(len(alphas), func(start, end int) {
+		for i := start; i < end; i++ {
+			alphas[i].FromMont()
+		}
+	})
 	g1s := bls12377.BatchScalarMultiplicationG1(&gen1Aff, alphas)
 	copy(srs.G1[1:], g1s)
 
-	return &srs, nil
+	wg.Wait()
+
+	return &srs, lagrange, nil
+}
+
+// lagrangeScalars returns Lᵢ(α) for the Lagrange polynomials Lᵢ of the domain of size n,
+// given α and αⁿ⁻¹:
+//
+//	Lᵢ(α) = ωⁱ(αⁿ-1) / (n(α-ωⁱ))
+func lagrangeScalars(alpha, alphaNMinusOne fr.Element, n uint64) []fr.Element {
+	res := make([]fr.Element, n)
+
+	omega, err := fr.RootOfUnity(n)
+	if err != nil {
+		panic(err)
+	}
+
+	// omegas[i] = ωⁱ
+	omegas := make([]fr.Element, n)
+	omegas[0].SetOne()
+	for i := uint64(1); i < n; i++ {
+		omegas[i].Mul(&omegas[i-1], &omega)
+	}
+
+	var alphaN fr.Element
+	alphaN.Mul(&alphaNMinusOne, &alpha)
+	if alphaN.IsOne() {
+		// α = ωʲ for some j, and Lᵢ(α) = δᵢⱼ
+		for i := range omegas {
+			if omegas[i].Equal(&alpha) {
+				res[i].SetOne()
+			}
+		}
+		return res
+	}
+
+	// res[i] = α-ωⁱ, then (α-ωⁱ)⁻¹
+	for i := range res {
+		res[i].Sub(&alpha, &omegas[i])
+	}
+	diffs := res
+	res = fr.BatchInvert(diffs)
+	zeroize(diffs)
+
+	// c = (αⁿ-1)/n
+	var c, nInv fr.Element
+	c.SetOne()
+	c.Sub(&alphaN, &c)
+	nInv.SetUint64(n).Inverse(&nInv)
+	c.Mul(&c, &nInv)
+	alphaN.SetZero()
+
+	for i := range res {
+		res[i].Mul(&res[i], &omegas[i]).Mul(&res[i], &c)
+	}
+	c.SetZero()
+
+	return res
+}
+
+// zeroize overwrites the secret values in s
+func zeroize(s []fr.Element) {
+	for i := range s {
+		s[i].SetZero()
+	}
+}
+
+// Verify checks that the SRS is well formed, that is that all the points are in the
+// correct subgroups and that G1 = [G₁, [α]G₁, [α²]G₁, …] for the α of G2 = [G₂, [α]G₂].
+//
+// The consistency of the powers, e([αⁱ]G₁, [α]G₂) == e([αⁱ⁺¹]G₁, G₂) for all i, is checked
+// on a random linear combination, with a single pairing check. It is meant to validate an
+// SRS loaded from a third-party source, and doesn't modify it.
+func (srs *SRS) Verify() error {
+	n := len(srs.G1)
+	if n < 2 {
+		return ErrMinSRSSize
+	}
+	if srs.G1[0].IsInfinity() || srs.G1[1].IsInfinity() || srs.G2[0].IsInfinity() || srs.G2[1].IsInfinity() {
+		return ErrSRSDegenerate
+	}
+
+	// subgroup membership
+	var g2InSubGroup bool
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		g2InSubGroup = srs.G2[0].IsInSubGroup() && srs.G2[1].IsInSubGroup()
+		wg.Done()
+	}()
+	notInSubGroup := make([]bool, n)
+	parallel.Execute(n, func(start, end int) {
+		for i := start; i < end; i++ {
+			notInSubGroup[i] = !srs.G1[i].IsInSubGroup()
+		}
+	})
+	wg.Wait()
+	if !g2InSubGroup {
+		return ErrSRSSubgroup
+	}
+	for i := range notInSubGroup {
+		if notInSubGroup[i] {
+			return &ecc.IndexedError{Index: i, Err: ErrSRSSubgroup}
+		}
+	}
+
+	// e(∑rᵢ[αⁱ]G₁, [α]G₂) == e(∑rᵢ[αⁱ⁺¹]G₁, G₂)
+	r := make([]fr.Element, n-1)
+	for i := range r {
+		if _, err := r[i].SetRandom(); err != nil {
+			return err
+		}
+	}
+	var left, right bls12377.G1Affine
+	config := ecc.MultiExpConfig{ScalarsMont: true}
+	if _, err := left.MultiExp(srs.G1[:n-1], r, config); err != nil {
+		return err
+	}
+	if _, err := right.MultiExp(srs.G1[1:], r, config); err != nil {
+		return err
+	}
+	right.Neg(&right)
+	ok, err := bls12377.PairingCheck(
+		[]bls12377.G1Affine{left, right},
+		[]bls12377.G2Affine{srs.G2[1], srs.G2[0]},
+	)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrSRSInconsistent
+	}
+
+	return nil
 }
 
 // OpeningProof KZG proof for opening at a single point.
@@ -118,27 +326,123 @@ type BatchOpeningProof struct {
 // Commit commits to a polynomial using a multi exponentiation with the SRS.
 // It is assumed that the polynomial is in canonical form, in Montgomery form.
 func Commit(p []fr.Element, srs *SRS, nbTasks ...int) (Digest, error) {
+	config := ecc.MultiExpConfig{ScalarsMont: true}
+	if len(nbTasks) > 0 {
+		config.NbTasks = nbTasks[0]
+	}
+	return commit(p, srs, config)
+}
+
+func commit(p []fr.Element, srs *SRS, config ecc.MultiExpConfig) (Digest, error) {
 
 	if len(p) == 0 || len(p) > len(srs.G1) {
 		return Digest{}, ErrInvalidPolynomialSize
 	}
 
 	var res bls12377.G1Affine
+	if _, err := res.MultiExp(srs.G1[:len(p)], p, config); err != nil {
+		return Digest{}, err
+	}
+
+	return res, nil
+}
+
+// CommitSparse commits to the polynomial ∑ᵢ coeffs[i]⋅Xⁱ given by its non-zero coefficients,
+// in canonical basis and Montgomery form. Only the SRS points at the indices of coeffs are used,
+// so the multi-exponentiation is of size len(coeffs) instead of the degree of the polynomial,
+// which suits selector polynomials with few non-zero coefficients.
+//
+// The digest is the one Commit computes on the dense coefficients.
+func CommitSparse(coeffs map[uint64]fr.Element, srs *SRS, nbTasks ...int) (Digest, error) {
+	if len(coeffs) == 0 {
+		return Digest{}, ErrInvalidPolynomialSize
+	}
+
+	points := make([]bls12377.G1Affine, 0, len(coeffs))
+	scalars := make([]fr.Element, 0, len(coeffs))
+	for i, c := range coeffs {
+		if i >= uint64(len(srs.G1)) {
+			return Digest{}, ErrInvalidPolynomialSize
+		}
+		points = append(points, srs.G1[i])
+		scalars = append(scalars, c)
+	}
 
 	config := ecc.MultiExpConfig{ScalarsMont: true}
 	if len(nbTasks) > 0 {
 		config.NbTasks = nbTasks[0]
 	}
-	if _, err := res.MultiExp(srs.G1[:len(p)], p, config); err != nil {
+	var res bls12377.G1Affine
+	if _, err := res.MultiExp(points, scalars, config); err != nil {
 		return Digest{}, err
 	}
 
 	return res, nil
 }
 
+// LinearCombination returns ∑ᵢ scalars[i]⋅digests[i], computed with a multi-exponentiation.
+// Since the commitment is linear, it is the digest of ∑ᵢ scalars[i]⋅pᵢ, where digests[i] is
+// the digest of pᵢ. The scalars are in Montgomery form.
+//
+// Together with the methods of bls12377.G1Affine (Add, Sub, ScalarMultiplication
+// and ScalarMultiplicationBase), it spares the verifiers built on KZG the conversions to
+// Jacobian coordinates.
+func LinearCombination(digests []Digest, scalars []fr.Element, nbTasks ...int) (Digest, error) {
+	if len(digests) != len(scalars) {
+		return Digest{}, ErrInvalidNbDigests
+	}
+	var res Digest
+	if len(digests) == 0 {
+		return res, nil
+	}
+	config := ecc.MultiExpConfig{ScalarsMont: true}
+	if len(nbTasks) > 0 {
+		config.NbTasks = nbTasks[0]
+	}
+	if _, err := res.MultiExp(digests, scalars, config); err != nil {
+		return Digest{}, err
+	}
+	return res, nil
+}
+
+// OpenSparse computes an opening proof at given point of the polynomial given by its non-zero
+// coefficients, as in CommitSparse. The proof is verified against the digest of CommitSparse
+// with Verify.
+//
+// Note that the quotient (p-p(a))/(X-a) is dense even if p is sparse: the opening costs
+// as much as Open on the dense coefficients.
+func OpenSparse(coeffs map[uint64]fr.Element, point fr.Element, srs *SRS) (OpeningProof, error) {
+	if len(coeffs) == 0 {
+		return OpeningProof{}, ErrInvalidPolynomialSize
+	}
+
+	var degree uint64
+	for i := range coeffs {
+		if i >= uint64(len(srs.G1)) {
+			return OpeningProof{}, ErrInvalidPolynomialSize
+		}
+		if i > degree {
+			degree = i
+		}
+	}
+
+	p := make([]fr.Element, degree+1)
+	for i, c := range coeffs {
+		p[i] = c
+	}
+
+	return Open(p, point, srs)
+}
+
 // Open computes an opening proof of polynomial p at given point.
 // fft.Domain Cardinality must be larger than p.Degree()
 func Open(p []fr.Element, point fr.Element, srs *SRS) (OpeningProof, error) {
+	return OpenWithArena(p, point, srs, nil)
+}
+
+// OpenWithArena is Open, with its temporary slice taken from arena (see ecc.Arena):
+// the quotient polynomial, that is len(p) * fr.Limbs words.
+func OpenWithArena(p []fr.Element, point fr.Element, srs *SRS, arena *ecc.Arena) (OpeningProof, error) {
 	if len(p) == 0 || len(p) > len(srs.G1) {
 		return OpeningProof{}, ErrInvalidPolynomialSize
 	}
@@ -149,14 +453,14 @@ func Open(p []fr.Element, point fr.Element, srs *SRS) (OpeningProof, error) {
 	}
 
 	// compute H
-	_p := make([]fr.Element, len(p))
+	_p := fr.MakeFromArena(arena, len(p))
 	copy(_p, p)
 	h := dividePolyByXminusA(_p, res.ClaimedValue, point)
 
 	_p = nil // h re-use this memory
 
 	// commit to H
-	hCommit, err := Commit(h, srs)
+	hCommit, err := commit(h, srs, ecc.MultiExpConfig{ScalarsMont: true})
 	if err != nil {
 		return OpeningProof{}, err
 	}
@@ -165,6 +469,69 @@ func Open(p []fr.Element, point fr.Element, srs *SRS) (OpeningProof, error) {
 	return res, nil
 }
 
+// OpeningContext holds the scratch memory of Open and BatchOpenSinglePoint, that is the
+// quotient polynomial, and reuses it between calls. A prover which opens many polynomials
+// per proof should open them with a context, sized for its largest polynomial, to avoid
+// these allocations.
+//
+// The proofs don't reference the memory of the context. An OpeningContext is not safe
+// for concurrent use.
+type OpeningContext struct {
+	srs   *SRS
+	arena *ecc.Arena
+}
+
+// NewOpeningContext returns an OpeningContext with enough scratch memory to open polynomials
+// of at most maxSize coefficients with srs. Larger polynomials can still be opened, but
+// their temporary slices are allocated on the heap.
+func NewOpeningContext(srs *SRS, maxSize int) *OpeningContext {
+	return &OpeningContext{
+		srs:   srs,
+		arena: ecc.NewArena(maxSize * fr.Limbs),
+	}
+}
+
+// Open is Open, with the scratch memory of the context
+func (ctx *OpeningContext) Open(p []fr.Element, point fr.Element) (OpeningProof, error) {
+	ctx.arena.Reset()
+	return OpenWithArena(p, point, ctx.srs, ctx.arena)
+}
+
+// BatchOpenSinglePoint is BatchOpenSinglePoint, with the scratch memory of the context
+func (ctx *OpeningContext) BatchOpenSinglePoint(polynomials [][]fr.Element, digests []Digest, point fr.Element, hf hash.Hash) (BatchOpeningProof, error) {
+	ctx.arena.Reset()
+	return batchOpenSinglePoint(polynomials, digests, point, hf, ctx.srs, ctx.arena)
+}
+
+// OpenLagrange computes an opening proof at given point of the polynomial p given in
+// Lagrange form, that is by its evaluations on the domain in canonical order: evaluations[i] = p(ωⁱ).
+// lagrange is the Lagrange basis [L₀(α)]G₁, …, [Lₙ₋₁(α)]G₁ of the domain, as returned by
+// NewSRSWithLagrange.
+//
+// The claimed value is computed with the barycentric formula and the quotient (p-p(a))/(X-a)
+// is computed in evaluation form, then committed with the Lagrange basis: no FFT is needed,
+// where Open would need an inverse FFT of the evaluations first.
+func OpenLagrange(evaluations []fr.Element, point fr.Element, domain *fft.Domain, lagrange []bls12377.G1Affine) (OpeningProof, error) {
+	if len(evaluations) == 0 || uint64(len(evaluations)) != domain.Cardinality || len(evaluations) != len(lagrange) {
+		return OpeningProof{}, ErrInvalidPolynomialSize
+	}
+
+	// build the proof
+	res := OpeningProof{
+		ClaimedValue: domain.BarycentricEvaluation(evaluations, point),
+	}
+
+	// compute H in Lagrange form
+	h := dividePolyByXminusALagrange(evaluations, res.ClaimedValue, point, domain)
+
+	// commit to H with the Lagrange basis
+	if _, err := res.H.MultiExp(lagrange, h, ecc.MultiExpConfig{ScalarsMont: true}); err != nil {
+		return OpeningProof{}, err
+	}
+
+	return res, nil
+}
+
 // Verify verifies a KZG opening proof at a single point
 func Verify(commitment *Digest, proof *OpeningProof, point fr.Element, srs *SRS) error {
 
@@ -222,6 +589,12 @@ func Verify(commitment *Digest, proof *OpeningProof, point fr.Element, srs *SRS)
 // * digests is the list of committed polynomials to open, need to derive the challenge using Fiat Shamir.
 // * polynomials is the list of polynomials to open, they are supposed to be of the same size.
 func BatchOpenSinglePoint(polynomials [][]fr.Element, digests []Digest, point fr.Element, hf hash.Hash, srs *SRS) (BatchOpeningProof, error) {
+	return batchOpenSinglePoint(polynomials, digests, point, hf, srs, nil)
+}
+
+// batchOpenSinglePoint is BatchOpenSinglePoint, with the folded polynomial, which
+// becomes the quotient, taken from arena (see ecc.Arena).
+func batchOpenSinglePoint(polynomials [][]fr.Element, digests []Digest, point fr.Element, hf hash.Hash, srs *SRS, arena *ecc.Arena) (BatchOpeningProof, error) {
 
 	// check for invalid sizes
 	nbDigests := len(digests)
@@ -276,7 +649,7 @@ func BatchOpenSinglePoint(polynomials [][]fr.Element, digests []Digest, point fr
 	// compute ∑ᵢγⁱfᵢ
 	// note: if we are willing to paralellize that, we could clone the poly and scale them by
 	// gamma n in parallel, before reducing into foldedPolynomials
-	foldedPolynomials := make([]fr.Element, largestPoly)
+	foldedPolynomials := fr.MakeFromArena(arena, largestPoly)
 	copy(foldedPolynomials, polynomials[0])
 	acc := gamma
 	var pj fr.Element
@@ -293,7 +666,7 @@ func BatchOpenSinglePoint(polynomials [][]fr.Element, digests []Digest, point fr
 	h := dividePolyByXminusA(foldedPolynomials, foldedEvaluations, point)
 	foldedPolynomials = nil // same memory as h
 
-	res.H, err = Commit(h, srs)
+	res.H, err = commit(h, srs, ecc.MultiExpConfig{ScalarsMont: true})
 	if err != nil {
 		return BatchOpeningProof{}, err
 	}
@@ -361,6 +734,95 @@ func BatchVerifySinglePoint(digests []Digest, batchOpeningProof *BatchOpeningPro
 
 }
 
+// ShiftedBatchOpeningProof opening proof for many polynomials at a point z, and for
+// a subset of them at the shifted point ωz, ω being typically the generator of a domain.
+//
+// implements io.ReaderFrom and io.WriterTo
+type ShiftedBatchOpeningProof struct {
+	// Proof batch opening proof of all the polynomials at z
+	Proof BatchOpeningProof
+
+	// ShiftedProof batch opening proof of the shifted polynomials at ωz
+	ShiftedProof BatchOpeningProof
+}
+
+// BatchOpenShifted creates an opening proof of the polynomials at point, and of the
+// polynomials whose indices are in shifted at ω*point.
+//
+// * polynomials, digests are the polynomials to open and their commitments
+// * shifted is the list of the indices of the polynomials to open at ω*point as well
+func BatchOpenShifted(polynomials [][]fr.Element, digests []Digest, shifted []int, point, omega fr.Element, hf hash.Hash, srs *SRS) (ShiftedBatchOpeningProof, error) {
+
+	var res ShiftedBatchOpeningProof
+	var err error
+
+	if len(digests) != len(polynomials) {
+		return res, ErrInvalidNbDigests
+	}
+	if len(shifted) == 0 {
+		return res, ErrInvalidShiftedIndex
+	}
+	shiftedPolynomials := make([][]fr.Element, len(shifted))
+	shiftedDigests := make([]Digest, len(shifted))
+	for i, j := range shifted {
+		if j < 0 || j >= len(polynomials) {
+			return res, &ecc.IndexedError{Index: i, Err: ErrInvalidShiftedIndex}
+		}
+		shiftedPolynomials[i] = polynomials[j]
+		shiftedDigests[i] = digests[j]
+	}
+
+	res.Proof, err = BatchOpenSinglePoint(polynomials, digests, point, hf, srs)
+	if err != nil {
+		return res, err
+	}
+
+	var shiftedPoint fr.Element
+	shiftedPoint.Mul(&point, &omega)
+	res.ShiftedProof, err = BatchOpenSinglePoint(shiftedPolynomials, shiftedDigests, shiftedPoint, hf, srs)
+	if err != nil {
+		return res, err
+	}
+
+	return res, nil
+}
+
+// BatchVerifyShifted verifies an opening proof created by BatchOpenShifted.
+// Both batch proofs are folded, then verified with a single pairing check.
+func BatchVerifyShifted(digests []Digest, shifted []int, proof *ShiftedBatchOpeningProof, point, omega fr.Element, hf hash.Hash, srs *SRS) error {
+
+	if len(shifted) == 0 {
+		return ErrInvalidShiftedIndex
+	}
+	shiftedDigests := make([]Digest, len(shifted))
+	for i, j := range shifted {
+		if j < 0 || j >= len(digests) {
+			return &ecc.IndexedError{Index: i, Err: ErrInvalidShiftedIndex}
+		}
+		shiftedDigests[i] = digests[j]
+	}
+
+	// fold the proofs at z and ωz
+	var shiftedPoint fr.Element
+	shiftedPoint.Mul(&point, &omega)
+	foldedProof, foldedDigest, err := FoldProof(digests, &proof.Proof, point, hf)
+	if err != nil {
+		return err
+	}
+	foldedShiftedProof, foldedShiftedDigest, err := FoldProof(shiftedDigests, &proof.ShiftedProof, shiftedPoint, hf)
+	if err != nil {
+		return err
+	}
+
+	// check both openings at once
+	return BatchVerifyMultiPoints(
+		[]Digest{foldedDigest, foldedShiftedDigest},
+		[]OpeningProof{foldedProof, foldedShiftedProof},
+		[]fr.Element{point, shiftedPoint},
+		srs,
+	)
+}
+
 // BatchVerifyMultiPoints batch verifies a list of opening proofs at different points.
 // The purpose of the batching is to have only one pairing for verifying several proofs.
 //
@@ -477,8 +939,7 @@ func fold(di []Digest, fai []fr.Element, ci []fr.Element) (Digest, fr.Element, e
 	}
 
 	// fold the digests ∑ᵢ[cᵢ]([fᵢ(α)]G₁)
-	var foldedDigests Digest
-	_, err := foldedDigests.MultiExp(di, ci, ecc.MultiExpConfig{ScalarsMont: true})
+	foldedDigests, err := LinearCombination(di, ci)
 	if err != nil {
 		return foldedDigests, foldedEvaluations, err
 	}
@@ -529,3 +990,50 @@ func dividePolyByXminusA(f []fr.Element, fa, a fr.Element) []fr.Element {
 	// the result is of degree deg(f)-1
 	return f[1:]
 }
+
+// dividePolyByXminusALagrange computes (f-f(a))/(x-a), in Lagrange form, in canonical order.
+// f is given by its evaluations on the domain, in canonical order.
+//
+// If a = ωᵐ is in the domain, the m-th evaluation of the quotient is
+// -∑_{j≠m} ωʲ⁻ᵐ(fⱼ-fₘ)/(ωʲ-ωᵐ).
+func dividePolyByXminusALagrange(f []fr.Element, fa, a fr.Element, domain *fft.Domain) []fr.Element {
+
+	n := len(f)
+	res := make([]fr.Element, n)
+
+	// ωⁱ-a
+	omegas := make([]fr.Element, n)
+	omegas[0].SetOne()
+	for i := 1; i < n; i++ {
+		omegas[i].Mul(&omegas[i-1], &domain.Generator)
+	}
+	m := -1
+	for i := 0; i < n; i++ {
+		res[i].Sub(&omegas[i], &a)
+		if res[i].IsZero() {
+			m = i
+		}
+	}
+
+	// (fᵢ-f(a))/(ωⁱ-a), the zero denominator (if any) is left untouched by BatchInvert
+	den := fr.BatchInvert(res)
+	for i := 0; i < n; i++ {
+		res[i].Sub(&f[i], &fa).
+			Mul(&res[i], &den[i])
+	}
+
+	if m != -1 {
+		// ωʲ⁻ᵐ = ωʲ * ω⁻ᵐ = ωʲ * ωⁿ⁻ᵐ
+		var acc, tmp fr.Element
+		for j := 0; j < n; j++ {
+			if j == m {
+				continue
+			}
+			tmp.Mul(&res[j], &omegas[(j-m+n)%n])
+			acc.Add(&acc, &tmp)
+		}
+		res[m].Neg(&acc)
+	}
+
+	return res
+}
@@ -0,0 +1,23 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+// Package logup provides an API to build lookup proofs based on logarithmic
+// derivatives (logUp, cf https://eprint.iacr.org/2022/1530.pdf).
+//
+// Compared to plookup, the table does not need to be sorted and the multiplicities
+// of the looked up values are handled natively: f ⊂ t if and only if there exists
+// m such that ∑ᵢ 1/(β-fᵢ) = ∑ⱼ mⱼ/(β-tⱼ).
+package logup
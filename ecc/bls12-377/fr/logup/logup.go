@@ -0,0 +1,518 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package logup
+
+import (
+	"crypto/sha256"
+	"errors"
+	"math/big"
+	"math/bits"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-377"
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr/fft"
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr/kzg"
+	fiatshamir "github.com/consensys/gnark-crypto/fiat-shamir"
+)
+
+var (
+	ErrNotInTable         = errors.New("some value in the vector is not in the lookup table")
+	ErrEmptyVector        = errors.New("f and t should not be empty")
+	ErrLookupVerification = errors.New("logup verification failed")
+	ErrGenerator          = errors.New("wrong generator")
+)
+
+// Proof logUp proof that the values of the vector committed in f are in the
+// table committed in t.
+//
+// implements io.ReaderFrom and io.WriterTo
+type Proof struct {
+
+	// size of the polynomials
+	size uint64
+
+	// generator of the fft domain, used for shifting the evaluation point
+	g fr.Element
+
+	// commitments of f, t, and m, the multiplicities of the entries of t in f
+	f, t, m kzg.Digest
+
+	// commitments of a = 1/(β-f), b = m/(β-t), and z, the accumulation
+	// polynomial of a-b
+	a, b, z kzg.Digest
+
+	// commitment to the quotient polynomial
+	q kzg.Digest
+
+	// opening proofs of f, t, m, a, b, z, q (in that order)
+	batchedProof kzg.BatchOpeningProof
+
+	// shifted opening proof of z
+	shiftedProof kzg.OpeningProof
+}
+
+// Size returns the size of the polynomials of the proof
+func (proof *Proof) Size() uint64 {
+	return proof.size
+}
+
+// Generator returns the generator of the fft domain of the proof
+func (proof *Proof) Generator() fr.Element {
+	return proof.g
+}
+
+// Commitments returns the commitments of f, t, m, a, b, z, q (in that order)
+func (proof *Proof) Commitments() []kzg.Digest {
+	return []kzg.Digest{proof.f, proof.t, proof.m, proof.a, proof.b, proof.z, proof.q}
+}
+
+// BatchedProof returns the opening proofs of f, t, m, a, b, z, q
+func (proof *Proof) BatchedProof() kzg.BatchOpeningProof {
+	return proof.batchedProof
+}
+
+// ShiftedProof returns the shifted opening proof of z
+func (proof *Proof) ShiftedProof() kzg.OpeningProof {
+	return proof.shiftedProof
+}
+
+// computeMultiplicities returns m, such that m[j] is the number of occurrences of t[j] in f.
+// If a value appears several times in t, only its first occurrence is counted.
+func computeMultiplicities(f, t []fr.Element) ([]fr.Element, error) {
+
+	index := make(map[fr.Element]int, len(t))
+	for j := len(t) - 1; j >= 0; j-- {
+		index[t[j]] = j
+	}
+
+	var one fr.Element
+	one.SetOne()
+	m := make([]fr.Element, len(t))
+	for i := 0; i < len(f); i++ {
+		j, ok := index[f[i]]
+		if !ok {
+			return nil, ErrNotInTable
+		}
+		m[j].Add(&m[j], &one)
+	}
+
+	return m, nil
+}
+
+// evaluateAccumulationPolynomial returns a = 1/(β-f), b = m/(β-t) and z, the running sum
+// of a-b, in Lagrange basis. z starts at 0, and wraps around to 0 if ∑ᵢaᵢ = ∑ᵢbᵢ.
+func evaluateAccumulationPolynomial(lf, lt, lm []fr.Element, beta fr.Element) (la, lb, lz []fr.Element) {
+
+	s := len(lf)
+	la = make([]fr.Element, s)
+	lb = make([]fr.Element, s)
+	lz = make([]fr.Element, s)
+
+	for i := 0; i < s; i++ {
+		la[i].Sub(&beta, &lf[i])
+		lb[i].Sub(&beta, &lt[i])
+	}
+	la = fr.BatchInvert(la)
+	lb = fr.BatchInvert(lb)
+
+	var t fr.Element
+	for i := 0; i < s; i++ {
+		lb[i].Mul(&lb[i], &lm[i])
+		if i < s-1 {
+			t.Sub(&la[i], &lb[i])
+			lz[i+1].Add(&lz[i], &t)
+		}
+	}
+
+	return la, lb, lz
+}
+
+// evaluateNumBitReversed computes the numerator of the quotient, folded with alpha:
+//
+// (a(β-f)-1) + α(b(β-t)-m) + α²(z(gX)-z-a+b) + α³L₀z
+//
+// on the coset of the domain. All the inputs are evaluations on the coset, in bit reversed order,
+// and so is the result.
+func evaluateNumBitReversed(_lf, _lt, _lm, _la, _lb, _lz []fr.Element, beta, alpha fr.Element, d *fft.Domain) []fr.Element {
+
+	s := len(_lf)
+	res := make([]fr.Element, s)
+
+	// L₀ on the coset: (uⁿ-1)/(n(uωⁱ-1))
+	var un, one, acc fr.Element
+	one.SetOne()
+	un.Exp(d.FrMultiplicativeGen, big.NewInt(int64(d.Cardinality))).
+		Sub(&un, &one)
+	l0 := make([]fr.Element, s)
+	acc.Set(&d.FrMultiplicativeGen)
+	for i := 0; i < s; i++ {
+		l0[i].Sub(&acc, &one)
+		acc.Mul(&acc, &d.Generator)
+	}
+	l0 = fr.BatchInvert(l0)
+	un.Mul(&un, &d.CardinalityInv)
+
+	var c1, c2, c3, c4, t fr.Element
+	nn := uint64(64 - bits.TrailingZeros64(uint64(s)))
+	for i := 0; i < s; i++ {
+		_i := int(bits.Reverse64(uint64(i)) >> nn)
+		_ii := int(bits.Reverse64(uint64((i+1)%s)) >> nn)
+
+		// a(β-f)-1
+		c1.Sub(&beta, &_lf[_i]).
+			Mul(&c1, &_la[_i]).
+			Sub(&c1, &one)
+
+		// b(β-t)-m
+		c2.Sub(&beta, &_lt[_i]).
+			Mul(&c2, &_lb[_i]).
+			Sub(&c2, &_lm[_i])
+
+		// z(gX)-z-a+b
+		c3.Sub(&_lz[_ii], &_lz[_i]).
+			Sub(&c3, &_la[_i]).
+			Add(&c3, &_lb[_i])
+
+		// L₀z
+		t.Mul(&l0[i], &un)
+		c4.Mul(&t, &_lz[_i])
+
+		res[_i].Mul(&c4, &alpha).
+			Add(&res[_i], &c3).
+			Mul(&res[_i], &alpha).
+			Add(&res[_i], &c2).
+			Mul(&res[_i], &alpha).
+			Add(&res[_i], &c1)
+	}
+
+	return res
+}
+
+// Prove generates a proof that the values in f are in t.
+//
+// f and t are padded to the same size, a power of 2, using their last element.
+// Contrary to plookup, t does not need to be sorted: the commitment to t in the
+// proof is the commitment to t padded, in the order in which it is given.
+func Prove(srs *kzg.SRS, f, t []fr.Element) (Proof, error) {
+
+	// res
+	var proof Proof
+	var err error
+
+	// size checking
+	if len(f) == 0 || len(t) == 0 {
+		return proof, ErrEmptyVector
+	}
+
+	// create the domain
+	size := len(f)
+	if len(t) > size {
+		size = len(t)
+	}
+	if size < 2 {
+		size = 2
+	}
+	d := fft.NewDomain(uint64(size))
+	s := int(d.Cardinality)
+	proof.size = d.Cardinality
+	proof.g.Set(&d.Generator)
+
+	// hash function for Fiat Shamir
+	hFunc := sha256.New()
+
+	// transcript to derive the challenge
+	fs := fiatshamir.NewTranscript(hFunc, "beta", "alpha", "zeta")
+
+	// resize f and t
+	lf := make([]fr.Element, s)
+	lt := make([]fr.Element, s)
+	copy(lf, f)
+	copy(lt, t)
+	for i := len(f); i < s; i++ {
+		lf[i] = f[len(f)-1]
+	}
+	for i := len(t); i < s; i++ {
+		lt[i] = t[len(t)-1]
+	}
+
+	// compute the multiplicities
+	lm, err := computeMultiplicities(lf, lt)
+	if err != nil {
+		return proof, err
+	}
+
+	// toCanonical returns a copy of l in canonical basis
+	toCanonical := func(l []fr.Element) []fr.Element {
+		c := make([]fr.Element, s)
+		copy(c, l)
+		d.FFTInverse(c, fft.DIF)
+		fft.BitReverse(c)
+		return c
+	}
+
+	// commit f, t, m
+	cf := toCanonical(lf)
+	ct := toCanonical(lt)
+	cm := toCanonical(lm)
+	proof.f, err = kzg.Commit(cf, srs)
+	if err != nil {
+		return proof, err
+	}
+	proof.t, err = kzg.Commit(ct, srs)
+	if err != nil {
+		return proof, err
+	}
+	proof.m, err = kzg.Commit(cm, srs)
+	if err != nil {
+		return proof, err
+	}
+
+	// derive challenge for a, b, z
+	beta, err := deriveRandomness(&fs, "beta", &proof.f, &proof.t, &proof.m)
+	if err != nil {
+		return proof, err
+	}
+
+	// compute a, b, z and commit them
+	la, lb, lz := evaluateAccumulationPolynomial(lf, lt, lm, beta)
+	ca := toCanonical(la)
+	cb := toCanonical(lb)
+	cz := toCanonical(lz)
+	proof.a, err = kzg.Commit(ca, srs)
+	if err != nil {
+		return proof, err
+	}
+	proof.b, err = kzg.Commit(cb, srs)
+	if err != nil {
+		return proof, err
+	}
+	proof.z, err = kzg.Commit(cz, srs)
+	if err != nil {
+		return proof, err
+	}
+
+	// derive challenge used for the folding
+	alpha, err := deriveRandomness(&fs, "alpha", &proof.a, &proof.b, &proof.z)
+	if err != nil {
+		return proof, err
+	}
+
+	// evaluate the polynomials on the coset
+	toCoset := func(c []fr.Element) []fr.Element {
+		l := make([]fr.Element, s)
+		copy(l, c)
+		d.FFT(l, fft.DIF, true)
+		return l
+	}
+	num := evaluateNumBitReversed(toCoset(cf), toCoset(ct), toCoset(cm), toCoset(ca), toCoset(cb), toCoset(cz), beta, alpha, d)
+
+	// divide the numerator by xⁿ-1
+	var tn, one fr.Element
+	one.SetOne()
+	tn.Exp(d.FrMultiplicativeGen, big.NewInt(int64(d.Cardinality))).Sub(&tn, &one).Inverse(&tn)
+	for i := 0; i < s; i++ {
+		num[i].Mul(&num[i], &tn)
+	}
+
+	// get the quotient and commit it
+	d.FFTInverse(num, fft.DIT, true)
+	cq := num
+	proof.q, err = kzg.Commit(cq, srs)
+	if err != nil {
+		return proof, err
+	}
+
+	// derive the evaluation challenge
+	zeta, err := deriveRandomness(&fs, "zeta", &proof.q)
+	if err != nil {
+		return proof, err
+	}
+
+	// compute the opening proofs
+	proof.batchedProof, err = kzg.BatchOpenSinglePoint(
+		[][]fr.Element{
+			cf,
+			ct,
+			cm,
+			ca,
+			cb,
+			cz,
+			cq,
+		},
+		[]kzg.Digest{
+			proof.f,
+			proof.t,
+			proof.m,
+			proof.a,
+			proof.b,
+			proof.z,
+			proof.q,
+		},
+		zeta,
+		hFunc,
+		srs,
+	)
+	if err != nil {
+		return proof, err
+	}
+
+	var shiftedZeta fr.Element
+	shiftedZeta.Mul(&zeta, &d.Generator)
+	proof.shiftedProof, err = kzg.Open(
+		cz,
+		shiftedZeta,
+		srs,
+	)
+	if err != nil {
+		return proof, err
+	}
+
+	// done
+	return proof, nil
+}
+
+// Verify verifies a logUp proof.
+func Verify(srs *kzg.SRS, proof Proof) error {
+
+	// hash function that is used for Fiat Shamir
+	hFunc := sha256.New()
+
+	// transcript to derive the challenge
+	fs := fiatshamir.NewTranscript(hFunc, "beta", "alpha", "zeta")
+
+	// derive the challenges
+	beta, err := deriveRandomness(&fs, "beta", &proof.f, &proof.t, &proof.m)
+	if err != nil {
+		return err
+	}
+
+	alpha, err := deriveRandomness(&fs, "alpha", &proof.a, &proof.b, &proof.z)
+	if err != nil {
+		return err
+	}
+
+	zeta, err := deriveRandomness(&fs, "zeta", &proof.q)
+	if err != nil {
+		return err
+	}
+
+	if len(proof.batchedProof.ClaimedValues) != 7 {
+		return kzg.ErrInvalidNbDigests
+	}
+	f := proof.batchedProof.ClaimedValues[0]
+	t := proof.batchedProof.ClaimedValues[1]
+	m := proof.batchedProof.ClaimedValues[2]
+	a := proof.batchedProof.ClaimedValues[3]
+	b := proof.batchedProof.ClaimedValues[4]
+	z := proof.batchedProof.ClaimedValues[5]
+	q := proof.batchedProof.ClaimedValues[6]
+	zs := proof.shiftedProof.ClaimedValue
+
+	// L₀(ζ) = (ζⁿ-1)/(n(ζ-1))
+	var zn, l0, one, n fr.Element
+	one.SetOne()
+	zn.Exp(zeta, new(big.Int).SetUint64(proof.size)).
+		Sub(&zn, &one)
+	n.SetUint64(proof.size)
+	l0.Sub(&zeta, &one).
+		Mul(&l0, &n)
+	l0.Div(&zn, &l0)
+
+	// (a(β-f)-1) + α(b(β-t)-m) + α²(z(gζ)-z-a+b) + α³L₀z ==? q(ζ)(ζⁿ-1)
+	var lhs, rhs, c1, c2, c3, c4 fr.Element
+	c1.Sub(&beta, &f).
+		Mul(&c1, &a).
+		Sub(&c1, &one)
+	c2.Sub(&beta, &t).
+		Mul(&c2, &b).
+		Sub(&c2, &m)
+	c3.Sub(&zs, &z).
+		Sub(&c3, &a).
+		Add(&c3, &b)
+	c4.Mul(&l0, &z)
+	lhs.Mul(&c4, &alpha).
+		Add(&lhs, &c3).
+		Mul(&lhs, &alpha).
+		Add(&lhs, &c2).
+		Mul(&lhs, &alpha).
+		Add(&lhs, &c1)
+	rhs.Mul(&q, &zn)
+	if !lhs.Equal(&rhs) {
+		return ErrLookupVerification
+	}
+
+	// check the opening proofs
+	err = kzg.BatchVerifySinglePoint(
+		[]kzg.Digest{
+			proof.f,
+			proof.t,
+			proof.m,
+			proof.a,
+			proof.b,
+			proof.z,
+			proof.q,
+		},
+		&proof.batchedProof,
+		zeta,
+		hFunc,
+		srs,
+	)
+	if err != nil {
+		return err
+	}
+
+	var shiftedZeta fr.Element
+	shiftedZeta.Mul(&zeta, &proof.g)
+	err = kzg.Verify(&proof.z, &proof.shiftedProof, shiftedZeta, srs)
+	if err != nil {
+		return err
+	}
+
+	// check the generator is correct
+	var checkOrder fr.Element
+	checkOrder.Exp(proof.g, new(big.Int).SetUint64(proof.size/2))
+	if checkOrder.Equal(&one) {
+		return ErrGenerator
+	}
+	checkOrder.Square(&checkOrder)
+	if !checkOrder.Equal(&one) {
+		return ErrGenerator
+	}
+
+	return nil
+}
+
+// TODO put that in fiat-shamir package
+func deriveRandomness(fs *fiatshamir.Transcript, challenge string, points ...*bls12377.G1Affine) (fr.Element, error) {
+
+	var buf [bls12377.SizeOfG1AffineUncompressed]byte
+	var r fr.Element
+
+	for _, p := range points {
+		buf = p.RawBytes()
+		if err := fs.Bind(challenge, buf[:]); err != nil {
+			return r, err
+		}
+	}
+
+	b, err := fs.ComputeChallenge(challenge)
+	if err != nil {
+		return r, err
+	}
+	r.SetBytes(b)
+	return r, nil
+}
@@ -0,0 +1,148 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package logup
+
+import (
+	"bytes"
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr/kzg"
+)
+
+func TestProof(t *testing.T) {
+
+	srs, err := kzg.NewSRS(64, big.NewInt(13))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// f is bigger than t, and some values are looked up several times
+	table := make([]fr.Element, 5)
+	f := make([]fr.Element, 12)
+	for i := 0; i < 5; i++ {
+		table[i].SetUint64(uint64(3*i + 2))
+	}
+	for i := 0; i < 12; i++ {
+		f[i].Set(&table[(7*i)%5])
+	}
+
+	// correct proof
+	{
+		proof, err := Prove(srs, f, table)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		err = Verify(srs, proof)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// value not in the table
+	{
+		f[0].SetUint64(1)
+		_, err := Prove(srs, f, table)
+		if err != ErrNotInTable {
+			t.Fatal("expected ErrNotInTable")
+		}
+		f[0].Set(&table[0])
+	}
+
+	// wrong proof
+	{
+		proof, err := Prove(srs, f, table)
+		if err != nil {
+			t.Fatal(err)
+		}
+		proof.batchedProof.ClaimedValues[2].SetRandom()
+
+		err = Verify(srs, proof)
+		if err == nil {
+			t.Fatal("verifying wrong proof should have failed")
+		}
+	}
+
+}
+
+func TestSerialization(t *testing.T) {
+
+	srs, err := kzg.NewSRS(64, big.NewInt(13))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	table := make([]fr.Element, 8)
+	f := make([]fr.Element, 8)
+	for i := 0; i < 8; i++ {
+		table[i].SetUint64(uint64(i))
+		f[i].SetUint64(uint64(i / 2))
+	}
+
+	proof, err := Prove(srs, f, table)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	written, err := proof.WriteTo(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if written != int64(proof.SizeInBytes()) {
+		t.Fatal("SizeInBytes doesn't match the number of bytes written")
+	}
+
+	var reconstructed Proof
+	read, err := reconstructed.ReadFrom(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if written != read {
+		t.Fatal("didn't read as many bytes as we wrote")
+	}
+	if !reflect.DeepEqual(proof, reconstructed) {
+		t.Fatal("Proof.ReadFrom(WriteTo()) failed")
+	}
+
+	err = Verify(srs, reconstructed)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func BenchmarkProver(b *testing.B) {
+
+	srsSize := 1 << 15
+	polySize := 1 << 14
+
+	srs, _ := kzg.NewSRS(uint64(srsSize), big.NewInt(13))
+	f := make([]fr.Element, polySize)
+	table := make([]fr.Element, polySize)
+	for i := 0; i < polySize; i++ {
+		table[i].SetUint64(uint64(i))
+		f[i].SetUint64(uint64((8 * i) % polySize))
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		Prove(srs, f, table)
+	}
+}
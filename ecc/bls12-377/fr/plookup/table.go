@@ -19,21 +19,26 @@ package plookup
 import (
 	"crypto/sha256"
 	"errors"
+	"fmt"
 	"math/big"
 	"sort"
+	"sync"
 
+	"github.com/consensys/gnark-crypto/ecc"
 	bls12377 "github.com/consensys/gnark-crypto/ecc/bls12-377"
 	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
 	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr/fft"
 	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr/kzg"
 	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr/permutation"
 	fiatshamir "github.com/consensys/gnark-crypto/fiat-shamir"
+	"github.com/consensys/gnark-crypto/internal/parallel"
 )
 
 var (
 	ErrIncompatibleSize = errors.New("the tables in f and t are not of the same size")
-	ErrFoldedCommitment = errors.New("the folded commitment is malformed")
+	ErrFoldedCommitment = fmt.Errorf("the folded commitment is malformed: %w", ecc.ErrCommitmentMismatch)
 	ErrNumberDigests    = errors.New("proof.ts and proof.fs are not of the same length")
+	ErrTableSelector    = errors.New("the table selector does not match any table")
 )
 
 // ProofLookupTables proofs that a list of tables
@@ -52,6 +57,32 @@ type ProofLookupTables struct {
 	permutationProof permutation.Proof
 }
 
+// TableCommitments returns the commitments to the rows of f and of t
+func (proof *ProofLookupTables) TableCommitments() (fs, ts []kzg.Digest) {
+	fs = make([]kzg.Digest, len(proof.fs))
+	ts = make([]kzg.Digest, len(proof.ts))
+	copy(fs, proof.fs)
+	copy(ts, proof.ts)
+	return
+}
+
+// FoldedProof returns the lookup proof for the folded f and t
+func (proof *ProofLookupTables) FoldedProof() ProofLookupVector {
+	return proof.foldedProof
+}
+
+// PermutationProof returns the proof that the folded ts correspond to t in the folded proof
+func (proof *ProofLookupTables) PermutationProof() permutation.Proof {
+	return proof.permutationProof
+}
+
+// SizeInBytes returns the size of the proof, with its points in compressed form
+func (proof *ProofLookupTables) SizeInBytes() int {
+	// the commitments to the rows are prefixed by their number, on 4 bytes
+	return 8 + (len(proof.fs)+len(proof.ts))*bls12377.SizeOfG1AffineCompressed +
+		proof.foldedProof.SizeInBytes() + proof.permutationProof.SizeInBytes()
+}
+
 // ProveLookupTables generates a proof that f, seen as a multi dimensional table,
 // consists of vectors that are in t. In other words for each i, f[:][i] must be one
 // of the t[:][j].
@@ -104,34 +135,30 @@ func ProveLookupTables(srs *kzg.SRS, f, t []Table) (ProofLookupTables, error) {
 	lts := make([][]fr.Element, nbRows)
 	cts := make([][]fr.Element, nbRows)
 
-	for i := 0; i < nbRows; i++ {
-
-		cfs[i] = make([]fr.Element, nbColumns)
-		lfs[i] = make([]fr.Element, nbColumns)
-		copy(cfs[i], f[i])
-		copy(lfs[i], f[i])
-		for j := len(f[i]); j < int(nbColumns); j++ {
-			cfs[i][j] = f[i][len(f[i])-1]
-			lfs[i][j] = f[i][len(f[i])-1]
+	// the rows of f and t are interpolated and committed in parallel
+	errs := make([]error, 2*nbRows)
+	var wg sync.WaitGroup
+	wg.Add(2 * nbRows)
+	interpolateAndCommit := func(src []fr.Element, l, c *[]fr.Element, digest *kzg.Digest, err *error) {
+		defer wg.Done()
+		*c = make([]fr.Element, nbColumns)
+		*l = make([]fr.Element, nbColumns)
+		copy(*c, src)
+		copy(*l, src)
+		for j := len(src); j < int(nbColumns); j++ {
+			(*c)[j] = src[len(src)-1]
+			(*l)[j] = src[len(src)-1]
 		}
-		d.FFTInverse(cfs[i], fft.DIF)
-		fft.BitReverse(cfs[i])
-		proof.fs[i], err = kzg.Commit(cfs[i], srs)
-		if err != nil {
-			return proof, err
-		}
-
-		cts[i] = make([]fr.Element, nbColumns)
-		lts[i] = make([]fr.Element, nbColumns)
-		copy(cts[i], t[i])
-		copy(lts[i], t[i])
-		for j := len(t[i]); j < int(d.Cardinality); j++ {
-			cts[i][j] = t[i][len(t[i])-1]
-			lts[i][j] = t[i][len(t[i])-1]
-		}
-		d.FFTInverse(cts[i], fft.DIF)
-		fft.BitReverse(cts[i])
-		proof.ts[i], err = kzg.Commit(cts[i], srs)
+		d.FFTInverse(*c, fft.DIF)
+		fft.BitReverse(*c)
+		*digest, *err = kzg.Commit(*c, srs)
+	}
+	for i := 0; i < nbRows; i++ {
+		go interpolateAndCommit(f[i], &lfs[i], &cfs[i], &proof.fs[i], &errs[2*i])
+		go interpolateAndCommit(t[i], &lts[i], &cts[i], &proof.ts[i], &errs[2*i+1])
+	}
+	wg.Wait()
+	for _, err := range errs {
 		if err != nil {
 			return proof, err
 		}
@@ -151,14 +178,16 @@ func ProveLookupTables(srs *kzg.SRS, f, t []Table) (ProofLookupTables, error) {
 	}
 	foldedf := make(Table, nbColumns)
 	foldedt := make(Table, nbColumns)
-	for i := 0; i < int(nbColumns); i++ {
-		for j := nbRows - 1; j >= 0; j-- {
-			foldedf[i].Mul(&foldedf[i], &lambda).
-				Add(&foldedf[i], &lfs[j][i])
-			foldedt[i].Mul(&foldedt[i], &lambda).
-				Add(&foldedt[i], &lts[j][i])
+	parallel.Execute(int(nbColumns), func(start, end int) {
+		for i := start; i < end; i++ {
+			for j := nbRows - 1; j >= 0; j-- {
+				foldedf[i].Mul(&foldedf[i], &lambda).
+					Add(&foldedf[i], &lfs[j][i])
+				foldedt[i].Mul(&foldedt[i], &lambda).
+					Add(&foldedt[i], &lts[j][i])
+			}
 		}
-	}
+	})
 
 	// generate a proof of permutation of the foldedt and sort(foldedt)
 	foldedtSorted := make(Table, nbColumns)
@@ -230,6 +259,63 @@ func VerifyLookupTables(srs *kzg.SRS, proof ProofLookupTables) error {
 	return VerifyLookupVector(srs, proof.foldedProof)
 }
 
+// ProveLookupMultiTables generates a proof that for each i, f[i] is in the table
+// tables[selector[i]]. The tables may be of different sizes.
+//
+// The tables are concatenated in a single table with two columns, the first one
+// containing the values and the second one the index of the table they come from,
+// and f is extended with the selector column. The resulting proof is a regular
+// ProofLookupTables on those two-columns tables: ts[0] is the commitment to the
+// concatenated values and ts[1] the commitment to the concatenated table indexes.
+func ProveLookupMultiTables(srs *kzg.SRS, f Table, selector []int, tables []Table) (ProofLookupTables, error) {
+
+	if len(f) != len(selector) || len(tables) == 0 {
+		return ProofLookupTables{}, ErrIncompatibleSize
+	}
+
+	// f extended with the selector column
+	tags := make(Table, len(selector))
+	for i := 0; i < len(selector); i++ {
+		if selector[i] < 0 || selector[i] >= len(tables) {
+			return ProofLookupTables{}, ErrTableSelector
+		}
+		tags[i].SetUint64(uint64(selector[i]))
+	}
+
+	// concatenation of the tables, with their index
+	values, indexes := concatenateTables(tables)
+
+	return ProveLookupTables(srs, []Table{f, tags}, []Table{values, indexes})
+}
+
+// VerifyLookupMultiTables verifies that a proof generated by ProveLookupMultiTables is correct.
+func VerifyLookupMultiTables(srs *kzg.SRS, proof ProofLookupTables) error {
+	if len(proof.fs) != 2 {
+		return ErrNumberDigests
+	}
+	return VerifyLookupTables(srs, proof)
+}
+
+// concatenateTables returns the concatenation of the tables, and for each entry
+// the index of the table it comes from.
+func concatenateTables(tables []Table) (Table, Table) {
+	size := 0
+	for i := 0; i < len(tables); i++ {
+		size += len(tables[i])
+	}
+	values := make(Table, 0, size)
+	indexes := make(Table, size)
+	offset := 0
+	for i := 0; i < len(tables); i++ {
+		values = append(values, tables[i]...)
+		for j := 0; j < len(tables[i]); j++ {
+			indexes[offset+j].SetUint64(uint64(i))
+		}
+		offset += len(tables[i])
+	}
+	return values, indexes
+}
+
 // TODO put that in fiat-shamir package
 func deriveRandomness(fs *fiatshamir.Transcript, challenge string, points ...*bls12377.G1Affine) (fr.Element, error) {
 
@@ -0,0 +1,263 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package fr
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+
+	"github.com/consensys/gnark-crypto/ecc"
+)
+
+var (
+	ErrVectorHeader     = errors.New("invalid vector header")
+	ErrVectorCurve      = errors.New("the vector is not on the scalar field of bls12-377")
+	ErrVectorCompressed = errors.New("the vector is compressed, a decompressor is required")
+)
+
+const (
+	vectorVersion      = 1
+	vectorHeaderSize   = 16
+	vectorLittleEndian = 1 << 0
+	vectorCompressed   = 1 << 1
+	vectorBaseField    = 1 << 2
+	vectorChunkSize    = 1 << 12 // number of elements converted at once
+)
+
+var vectorMagic = [4]byte{'g', 'v', 'e', 'c'}
+
+// vectorField flags the field of the elements, so that vectors on fp and fr are not mixed up
+const vectorField = 0
+
+// Vector is a slice of field elements, which can be streamed to and from a compact binary
+// container, for instance to store a witness or the evaluations of a polynomial:
+//
+//	magic    [4]byte "gvec"
+//	version  uint8
+//	curve    uint16  ecc.ID, big-endian
+//	flags    uint8   bit 0: elements are little-endian, bit 1: payload is compressed,
+//	                 bit 2: elements are on the base field fp (instead of the scalar field fr)
+//	count    uint64  number of elements, big-endian
+//	payload  count * Bytes, the elements in regular form
+//
+// The payload is converted by chunks, so that large vectors are written and read without
+// holding a copy of their encoding in memory.
+type Vector []Element
+
+// VectorOption configures the encoding or decoding of a Vector
+type VectorOption func(*vectorConfig)
+
+type vectorConfig struct {
+	order      binary.ByteOrder
+	compress   func(io.Writer) (io.WriteCloser, error)
+	decompress func(io.Reader) (io.Reader, error)
+}
+
+// WithByteOrder sets the byte order of the elements of the payload, binary.BigEndian
+// (default) or binary.LittleEndian. It is recorded in the header, and ignored when reading.
+func WithByteOrder(order binary.ByteOrder) VectorOption {
+	return func(cfg *vectorConfig) {
+		cfg.order = order
+	}
+}
+
+// WithCompression compresses the payload with the writer returned by compress, which is
+// closed once the payload is written. For instance, with zstd:
+//
+//	fr.WithCompression(func(w io.Writer) (io.WriteCloser, error) { return zstd.NewWriter(w) })
+func WithCompression(compress func(io.Writer) (io.WriteCloser, error)) VectorOption {
+	return func(cfg *vectorConfig) {
+		cfg.compress = compress
+	}
+}
+
+// WithDecompression sets the decompressor used to read a compressed payload.
+// It is not used if the payload is not compressed.
+func WithDecompression(decompress func(io.Reader) (io.Reader, error)) VectorOption {
+	return func(cfg *vectorConfig) {
+		cfg.decompress = decompress
+	}
+}
+
+// WriteTo writes the vector to w, with big-endian elements and no compression
+//
+// implements io.WriterTo
+func (v Vector) WriteTo(w io.Writer) (int64, error) {
+	return v.WriteToWithOptions(w)
+}
+
+// ReadFrom reads a vector from r; it fails with ErrVectorCompressed if the payload is compressed
+//
+// implements io.ReaderFrom
+func (v *Vector) ReadFrom(r io.Reader) (int64, error) {
+	return v.ReadFromWithOptions(r)
+}
+
+// WriteToWithOptions writes the vector to w, and returns the number of bytes written
+// (after compression).
+func (v Vector) WriteToWithOptions(w io.Writer, opts ...VectorOption) (int64, error) {
+	cfg := newVectorConfig(opts)
+
+	var header [vectorHeaderSize]byte
+	copy(header[:4], vectorMagic[:])
+	header[4] = vectorVersion
+	binary.BigEndian.PutUint16(header[5:7], uint16(ecc.BLS12_377))
+	header[7] = vectorField
+	if cfg.order == binary.LittleEndian {
+		header[7] |= vectorLittleEndian
+	}
+	if cfg.compress != nil {
+		header[7] |= vectorCompressed
+	}
+	binary.BigEndian.PutUint64(header[8:16], uint64(len(v)))
+
+	cw := &countingWriter{w: w}
+	if _, err := cw.Write(header[:]); err != nil {
+		return cw.n, err
+	}
+
+	var payload io.Writer = cw
+	var closer io.Closer
+	if cfg.compress != nil {
+		zw, err := cfg.compress(cw)
+		if err != nil {
+			return cw.n, err
+		}
+		payload, closer = zw, zw
+	}
+
+	chunkSize := vectorChunkSize
+	if len(v) < chunkSize {
+		chunkSize = len(v)
+	}
+	buf := make([]byte, 0, chunkSize*Bytes)
+	for start := 0; start < len(v); start += chunkSize {
+		end := start + chunkSize
+		if end > len(v) {
+			end = len(v)
+		}
+		buf = SliceToBytes(buf[:0], v[start:end], cfg.order)
+		if _, err := payload.Write(buf); err != nil {
+			return cw.n, err
+		}
+	}
+
+	if closer != nil {
+		if err := closer.Close(); err != nil {
+			return cw.n, err
+		}
+	}
+	return cw.n, nil
+}
+
+// ReadFromWithOptions reads a vector from r, reusing the capacity of v, and returns the
+// number of bytes read. If the payload is compressed, this includes the bytes the
+// decompressor may have read ahead.
+func (v *Vector) ReadFromWithOptions(r io.Reader, opts ...VectorOption) (int64, error) {
+	cfg := newVectorConfig(opts)
+	cr := &countingReader{r: r}
+
+	var header [vectorHeaderSize]byte
+	if _, err := io.ReadFull(cr, header[:]); err != nil {
+		return cr.n, err
+	}
+	if !bytes.Equal(header[:4], vectorMagic[:]) || header[4] != vectorVersion {
+		return cr.n, ErrVectorHeader
+	}
+	if ecc.ID(binary.BigEndian.Uint16(header[5:7])) != ecc.BLS12_377 {
+		return cr.n, ErrVectorCurve
+	}
+	flags := header[7]
+	if flags&^(vectorLittleEndian|vectorCompressed|vectorBaseField) != 0 {
+		return cr.n, ErrVectorHeader
+	}
+	if flags&vectorBaseField != vectorField {
+		return cr.n, ErrVectorCurve
+	}
+	var order binary.ByteOrder = binary.BigEndian
+	if flags&vectorLittleEndian != 0 {
+		order = binary.LittleEndian
+	}
+	count := binary.BigEndian.Uint64(header[8:16])
+
+	var payload io.Reader = cr
+	if flags&vectorCompressed != 0 {
+		if cfg.decompress == nil {
+			return cr.n, ErrVectorCompressed
+		}
+		zr, err := cfg.decompress(cr)
+		if err != nil {
+			return cr.n, err
+		}
+		payload = zr
+	}
+
+	// the vector grows with the data actually read, so that a corrupted count
+	// doesn't trigger a huge allocation
+	chunkSize := uint64(vectorChunkSize)
+	if count < chunkSize {
+		chunkSize = count
+	}
+	res := (*v)[:0]
+	buf := make([]byte, chunkSize*Bytes)
+	for remaining := count; remaining > 0; {
+		n := chunkSize
+		if remaining < n {
+			n = remaining
+		}
+		if _, err := io.ReadFull(payload, buf[:n*Bytes]); err != nil {
+			return cr.n, err
+		}
+		res, _ = BytesToSlice(res, buf[:n*Bytes], order)
+		remaining -= n
+	}
+	*v = res
+
+	return cr.n, nil
+}
+
+func newVectorConfig(opts []VectorOption) vectorConfig {
+	cfg := vectorConfig{order: binary.BigEndian}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return cfg
+}
+
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (cw *countingWriter) Write(p []byte) (int, error) {
+	n, err := cw.w.Write(p)
+	cw.n += int64(n)
+	return n, err
+}
+
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (cr *countingReader) Read(p []byte) (int, error) {
+	n, err := cr.r.Read(p)
+	cr.n += int64(n)
+	return n, err
+}
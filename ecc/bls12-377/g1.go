@@ -18,7 +18,6 @@ package bls12377
 
 import (
 	"math/big"
-	"runtime"
 
 	"github.com/consensys/gnark-crypto/ecc"
 	"github.com/consensys/gnark-crypto/ecc/bls12-377/fp"
@@ -59,6 +58,14 @@ func (p *G1Affine) ScalarMultiplication(a *G1Affine, s *big.Int) *G1Affine {
 	return p
 }
 
+// ScalarMultiplicationBase computes and returns p = g ⋅ s where g is the prime subgroup generator
+func (p *G1Affine) ScalarMultiplicationBase(s *big.Int) *G1Affine {
+	var _p G1Jac
+	_p.ScalarMultiplicationBase(s)
+	p.FromJacobian(&_p)
+	return p
+}
+
 // ScalarMultiplicationAffine computes and returns p = a ⋅ s
 // Takes an affine point and returns a Jacobian point (useful for KZG)
 func (p *G1Jac) ScalarMultiplicationAffine(a *G1Affine, s *big.Int) *G1Jac {
@@ -331,12 +338,49 @@ func (p *G1Jac) DoubleAssign() *G1Jac {
 	return p
 }
 
+// AddComplete sets p to a+b using the exception-free complete addition formulas of
+// Renes-Costello-Batina (https://eprint.iacr.org/2015/1060.pdf), and returns p.
+//
+// Unlike AddAssign, it has no exceptional case: a and b may be equal, opposite or the point
+// at infinity, and the sequence of field operations does not depend on them.
+func (p *G1Jac) AddComplete(a, b *G1Jac) *G1Jac {
+	var b3 fp.Element
+	g1B3(&b3)
+	var _a, _b g1ProjCT
+	_a.fromJacobian(a)
+	_b.fromJacobian(b)
+	_a.addComplete(&_a, &_b, &b3)
+	return p.fromProjCT(&_a)
+}
+
+// AddAssignComplete sets p to p+a using complete addition formulas, and returns p.
+// See AddComplete.
+func (p *G1Jac) AddAssignComplete(a *G1Jac) *G1Jac {
+	return p.AddComplete(p, a)
+}
+
+// DoubleComplete sets p to 2a using complete doubling formulas, and returns p.
+// See AddComplete.
+func (p *G1Jac) DoubleComplete(a *G1Jac) *G1Jac {
+	var b3 fp.Element
+	g1B3(&b3)
+	var _a g1ProjCT
+	_a.fromJacobian(a)
+	_a.doubleComplete(&_a, &b3)
+	return p.fromProjCT(&_a)
+}
+
 // ScalarMultiplication computes and returns p = a ⋅ s
 // see https://www.iacr.org/archive/crypto2001/21390189.pdf
 func (p *G1Jac) ScalarMultiplication(a *G1Jac, s *big.Int) *G1Jac {
 	return p.mulGLV(a, s)
 }
 
+// ScalarMultiplicationBase computes and returns p = g ⋅ s where g is the prime subgroup generator
+func (p *G1Jac) ScalarMultiplicationBase(s *big.Int) *G1Jac {
+	return p.ScalarMultiplication(&g1Gen, s)
+}
+
 // String returns canonical representation of the point in affine coordinates
 func (p *G1Jac) String() string {
 	_p := G1Affine{}
@@ -420,6 +464,182 @@ func (p *G1Jac) mulWindowed(a *G1Jac, s *big.Int) *G1Jac {
 
 }
 
+// ScalarMultiplicationCT computes and returns p = a ⋅ s, in constant time with respect to s.
+//
+// It uses a Montgomery ladder over all the bits of s mod r, with the complete addition and
+// doubling formulas of Renes-Costello-Batina (https://eprint.iacr.org/2015/1060.pdf), so that
+// the sequence of field operations does not depend on s. It is slower than ScalarMultiplication,
+// and meant for secret scalars (e.g. private keys). The reduction of s mod r, if s ≥ r or s < 0,
+// is done with math/big and is not constant time.
+func (p *G1Affine) ScalarMultiplicationCT(a *G1Affine, s *big.Int) *G1Affine {
+	var _p G1Jac
+	_p.FromAffine(a)
+	_p.ScalarMultiplicationCT(&_p, s)
+	p.FromJacobian(&_p)
+	return p
+}
+
+// ScalarMultiplicationCT computes and returns p = a ⋅ s, in constant time with respect to s.
+// See G1Affine.ScalarMultiplicationCT.
+func (p *G1Jac) ScalarMultiplicationCT(a *G1Jac, s *big.Int) *G1Jac {
+	var k fr.Element
+	k.SetBigInt(s).FromMont()
+
+	// R₀ = 0, R₁ = a; R₁ - R₀ = a is invariant
+	var r0, r1, t g1ProjCT
+	r0.y.SetOne()
+	r1.fromJacobian(a)
+
+	var b3 fp.Element
+	g1B3(&b3)
+
+	for i := fr.Bits - 1; i >= 0; i-- {
+		bit := int(k[i/64]>>(i%64)) & 1
+
+		// (R₀, R₁) ← (2R₀, R₀+R₁) if bit == 0, (R₀+R₁, 2R₁) otherwise
+		t.selectCT(bit, &r0, &r1)
+		r1.selectCT(bit, &r1, &r0)
+		r0.Set(&t)
+		r1.addComplete(&r0, &r1, &b3)
+		r0.doubleComplete(&r0, &b3)
+		t.selectCT(bit, &r0, &r1)
+		r1.selectCT(bit, &r1, &r0)
+		r0.Set(&t)
+	}
+
+	return p.fromProjCT(&r0)
+}
+
+// g1B3 sets b3 to 3b, where b is the curve coefficient used by the complete formulas
+func g1B3(b3 *fp.Element) {
+	b3.Double(&bCurveCoeff).Add(b3, &bCurveCoeff)
+}
+
+// g1ProjCT point in homogeneous projective coordinates (x=X/Z, y=Y/Z), on which the
+// complete formulas are used. The point at infinity is (0:1:0).
+type g1ProjCT struct {
+	x, y, z fp.Element
+}
+
+// Set sets p to a
+func (p *g1ProjCT) Set(a *g1ProjCT) *g1ProjCT {
+	p.x, p.y, p.z = a.x, a.y, a.z
+	return p
+}
+
+// fromJacobian sets p to (X⋅Z : Y : Z³)
+func (p *g1ProjCT) fromJacobian(a *G1Jac) *g1ProjCT {
+	var zz fp.Element
+	zz.Square(&a.Z)
+	p.x.Mul(&a.X, &a.Z)
+	p.y.Set(&a.Y)
+	p.z.Mul(&zz, &a.Z)
+	return p
+}
+
+// fromProjCT sets p to (X⋅Z, Y⋅Z², Z). The point at infinity (0:1:0) is mapped to (1, 1, 0),
+// without branching on Z.
+func (p *G1Jac) fromProjCT(a *g1ProjCT) *G1Jac {
+	var zz fp.Element
+	zz.Square(&a.z)
+	p.X.Mul(&a.x, &a.z)
+	p.Y.Mul(&a.y, &zz)
+	p.Z.Set(&a.z)
+
+	// if Z = 0, X = Y = 0: add 1 to both
+	var zero, one, inf fp.Element
+	one.SetOne()
+	c := g1IsZeroCT(&a.z)
+	inf.Select(c, &zero, &one)
+	p.X.Add(&p.X, &inf)
+	p.Y.Add(&p.Y, &inf)
+	return p
+}
+
+// g1IsZeroCT returns 1 if z == 0, 0 otherwise, without branching
+func g1IsZeroCT(z *fp.Element) int {
+	var acc uint64
+	for _, w := range z {
+		acc |= w
+	}
+	return int(((acc | -acc) >> 63) ^ 1)
+}
+
+// selectCT sets p to a if c == 0, to b otherwise, in constant time
+func (p *g1ProjCT) selectCT(c int, a, b *g1ProjCT) *g1ProjCT {
+	p.x.Select(c, &a.x, &b.x)
+	p.y.Select(c, &a.y, &b.y)
+	p.z.Select(c, &a.z, &b.z)
+	return p
+}
+
+// addComplete sets p to a+b, for any a, b (including the point at infinity and a == b)
+// https://eprint.iacr.org/2015/1060.pdf, algorithm 7, where b3 = 3b
+func (p *g1ProjCT) addComplete(a, b *g1ProjCT, b3 *fp.Element) *g1ProjCT {
+	var t0, t1, t2, t3, t4, x3, y3, z3 fp.Element
+	t0.Mul(&a.x, &b.x)
+	t1.Mul(&a.y, &b.y)
+	t2.Mul(&a.z, &b.z)
+	t3.Add(&a.x, &a.y)
+	t4.Add(&b.x, &b.y)
+	t3.Mul(&t3, &t4)
+	t4.Add(&t0, &t1)
+	t3.Sub(&t3, &t4)
+	t4.Add(&a.y, &a.z)
+	x3.Add(&b.y, &b.z)
+	t4.Mul(&t4, &x3)
+	x3.Add(&t1, &t2)
+	t4.Sub(&t4, &x3)
+	x3.Add(&a.x, &a.z)
+	y3.Add(&b.x, &b.z)
+	x3.Mul(&x3, &y3)
+	y3.Add(&t0, &t2)
+	y3.Sub(&x3, &y3)
+	x3.Double(&t0)
+	t0.Add(&x3, &t0)
+	t2.Mul(b3, &t2)
+	z3.Add(&t1, &t2)
+	t1.Sub(&t1, &t2)
+	y3.Mul(b3, &y3)
+	x3.Mul(&t4, &y3)
+	t2.Mul(&t3, &t1)
+	x3.Sub(&t2, &x3)
+	y3.Mul(&y3, &t0)
+	t1.Mul(&t1, &z3)
+	y3.Add(&t1, &y3)
+	t0.Mul(&t0, &t3)
+	z3.Mul(&z3, &t4)
+	z3.Add(&z3, &t0)
+
+	p.x, p.y, p.z = x3, y3, z3
+	return p
+}
+
+// doubleComplete sets p to 2a, for any a (including the point at infinity)
+// https://eprint.iacr.org/2015/1060.pdf, algorithm 9, where b3 = 3b
+func (p *g1ProjCT) doubleComplete(a *g1ProjCT, b3 *fp.Element) *g1ProjCT {
+	var t0, t1, t2, x3, y3, z3 fp.Element
+	t0.Square(&a.y)
+	z3.Double(&t0).Double(&z3).Double(&z3)
+	t1.Mul(&a.y, &a.z)
+	t2.Square(&a.z)
+	t2.Mul(b3, &t2)
+	x3.Mul(&t2, &z3)
+	y3.Add(&t0, &t2)
+	z3.Mul(&t1, &z3)
+	t1.Double(&t2)
+	t2.Add(&t1, &t2)
+	t0.Sub(&t0, &t2)
+	y3.Mul(&t0, &y3)
+	y3.Add(&x3, &y3)
+	t1.Mul(&a.x, &a.y)
+	x3.Mul(&t0, &t1)
+	x3.Double(&x3)
+
+	p.x, p.y, p.z = x3, y3, z3
+	return p
+}
+
 // ϕ assigns p to ϕ(a) where ϕ: (x,y) → (w x,y), and returns p
 // where w is a third root of unity in 𝔽p
 func (p *G1Jac) phi(a *G1Jac) *G1Jac {
@@ -912,7 +1132,7 @@ func BatchScalarMultiplicationG1(base *G1Affine, scalars []fr.Element) []G1Affin
 		baseTable[i].AddMixed(base)
 	}
 
-	pScalars, _ := partitionScalars(scalars, c, false, runtime.NumCPU())
+	pScalars, _ := partitionScalars(scalars, c, false, parallel.MaxProcs())
 
 	// compute offset and word selector / shift to select the right bits of our windows
 	selectors := make([]selector, nbChunks)
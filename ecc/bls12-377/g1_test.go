@@ -283,6 +283,39 @@ func TestG1AffineOps(t *testing.T) {
 		GenFp(),
 	))
 
+	properties.Property("[BLS12-377] [Jacobian] AddComplete should output the same result as AddAssign", prop.ForAll(
+		func(a, b fp.Element) bool {
+			fop1 := fuzzG1Jac(&g1Gen, a)
+			fop2 := fuzzG1Jac(&g1Gen, b)
+			var p1, p2, expected, res G1Jac
+			p1.Set(&fop1).DoubleAssign()
+			p2.Set(&fop2).AddAssign(&p1)
+			expected.Set(&p1).AddAssign(&p2)
+			res.AddComplete(&p1, &p2)
+			return res.Equal(&expected)
+		},
+		GenFp(),
+		GenFp(),
+	))
+
+	properties.Property("[BLS12-377] [Jacobian] AddComplete should handle equal, opposite and infinity inputs", prop.ForAll(
+		func(a fp.Element) bool {
+			fop1 := fuzzG1Jac(&g1Gen, a)
+			var neg, double, sum, zero, inf1, inf2, dc G1Jac
+			neg.Neg(&fop1)
+			double.Double(&g1Gen)
+			sum.AddComplete(&fop1, &g1Gen)
+			zero.AddComplete(&fop1, &neg)
+			inf1.AddComplete(&fop1, &g1Infinity)
+			inf2.Set(&g1Infinity).AddAssignComplete(&fop1)
+			dc.DoubleComplete(&g1Infinity)
+			return sum.Equal(&double) && zero.Equal(&g1Infinity) && inf1.Equal(&g1Gen) &&
+				inf2.Equal(&g1Gen) && dc.Equal(&g1Infinity) &&
+				dc.DoubleComplete(&fop1).Equal(&double)
+		},
+		GenFp(),
+	))
+
 	properties.Property("[BLS12-377] [Jacobian Extended] addMixed (-G) should equal subMixed(G)", prop.ForAll(
 		func(a fp.Element) bool {
 			fop1 := fuzzG1Jac(&g1Gen, a)
@@ -393,6 +426,64 @@ func TestG1AffineOps(t *testing.T) {
 		genScalar,
 	))
 
+	properties.Property("[BLS12-377] scalar multiplication of the base point should output the same result as scalar multiplication", prop.ForAll(
+		func(s fr.Element) bool {
+
+			var r big.Int
+			var op1, op2 G1Jac
+			var aff1, aff2 G1Affine
+			s.ToBigIntRegular(&r)
+			op1.ScalarMultiplicationBase(&r)
+			op2.ScalarMultiplication(&g1Gen, &r)
+			aff1.ScalarMultiplicationBase(&r)
+			aff2.FromJacobian(&op2)
+			return op1.Equal(&op2) && aff1.Equal(&aff2)
+
+		},
+		genScalar,
+	))
+
+	properties.Property("[BLS12-377] constant time scalar multiplication should output the same result as double and add", prop.ForAll(
+		func(s fr.Element) bool {
+
+			var r big.Int
+			var op1, op2, a G1Jac
+			s.ToBigIntRegular(&r)
+			a.mulWindowed(&g1Gen, &r)
+			op1.mulWindowed(&a, &r)
+			op2.ScalarMultiplicationCT(&a, &r)
+			return op1.Equal(&op2) && !op1.Equal(&g1Infinity)
+
+		},
+		genScalar,
+	))
+
+	properties.Property("[BLS12-377] constant time scalar multiplication should handle 0, r, negative scalars and the point at infinity", prop.ForAll(
+		func(s fr.Element) bool {
+
+			var scalar, neg big.Int
+			var op1, op2, op3, op4, expected G1Jac
+			s.ToBigIntRegular(&scalar)
+			neg.Neg(&scalar)
+			op1.ScalarMultiplicationCT(&g1Gen, big.NewInt(0))
+			op2.ScalarMultiplicationCT(&g1Gen, fr.Modulus())
+			op3.ScalarMultiplicationCT(&g1Infinity, &scalar)
+			op4.ScalarMultiplicationCT(&g1Gen, &neg)
+			expected.mulWindowed(&g1Gen, &scalar).Neg(&expected)
+
+			var aff G1Affine
+			aff.FromJacobian(&g1Gen)
+			aff.ScalarMultiplicationCT(&aff, &scalar)
+			var affExpected G1Affine
+			affExpected.FromJacobian(&expected)
+			affExpected.Neg(&affExpected)
+
+			return op1.Equal(&g1Infinity) && op2.Equal(&g1Infinity) && op3.Equal(&g1Infinity) &&
+				op4.Equal(&expected) && aff.Equal(&affExpected)
+		},
+		genScalar,
+	))
+
 	properties.TestingRun(t, gopter.ConsoleReporter(false))
 }
 
@@ -551,6 +642,14 @@ func BenchmarkG1JacScalarMultiplication(b *testing.B) {
 		}
 	})
 
+	var ct G1Jac
+	b.Run("constant time", func(b *testing.B) {
+		b.ResetTimer()
+		for j := 0; j < b.N; j++ {
+			ct.ScalarMultiplicationCT(&g1Gen, &scalar)
+		}
+	})
+
 }
 
 func BenchmarkG1AffineCofactorClearing(b *testing.B) {
@@ -18,7 +18,6 @@ package bls12377
 
 import (
 	"math/big"
-	"runtime"
 
 	"github.com/consensys/gnark-crypto/ecc"
 	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
@@ -64,6 +63,14 @@ func (p *G2Affine) ScalarMultiplication(a *G2Affine, s *big.Int) *G2Affine {
 	return p
 }
 
+// ScalarMultiplicationBase computes and returns p = g ⋅ s where g is the prime subgroup generator
+func (p *G2Affine) ScalarMultiplicationBase(s *big.Int) *G2Affine {
+	var _p G2Jac
+	_p.ScalarMultiplicationBase(s)
+	p.FromJacobian(&_p)
+	return p
+}
+
 // Add adds two point in affine coordinates.
 // This should rarely be used as it is very inefficient compared to Jacobian
 func (p *G2Affine) Add(a, b *G2Affine) *G2Affine {
@@ -328,12 +335,49 @@ func (p *G2Jac) DoubleAssign() *G2Jac {
 	return p
 }
 
+// AddComplete sets p to a+b using the exception-free complete addition formulas of
+// Renes-Costello-Batina (https://eprint.iacr.org/2015/1060.pdf), and returns p.
+//
+// Unlike AddAssign, it has no exceptional case: a and b may be equal, opposite or the point
+// at infinity, and the sequence of field operations does not depend on them.
+func (p *G2Jac) AddComplete(a, b *G2Jac) *G2Jac {
+	var b3 fptower.E2
+	g2B3(&b3)
+	var _a, _b g2ProjCT
+	_a.fromJacobian(a)
+	_b.fromJacobian(b)
+	_a.addComplete(&_a, &_b, &b3)
+	return p.fromProjCT(&_a)
+}
+
+// AddAssignComplete sets p to p+a using complete addition formulas, and returns p.
+// See AddComplete.
+func (p *G2Jac) AddAssignComplete(a *G2Jac) *G2Jac {
+	return p.AddComplete(p, a)
+}
+
+// DoubleComplete sets p to 2a using complete doubling formulas, and returns p.
+// See AddComplete.
+func (p *G2Jac) DoubleComplete(a *G2Jac) *G2Jac {
+	var b3 fptower.E2
+	g2B3(&b3)
+	var _a g2ProjCT
+	_a.fromJacobian(a)
+	_a.doubleComplete(&_a, &b3)
+	return p.fromProjCT(&_a)
+}
+
 // ScalarMultiplication computes and returns p = a ⋅ s
 // see https://www.iacr.org/archive/crypto2001/21390189.pdf
 func (p *G2Jac) ScalarMultiplication(a *G2Jac, s *big.Int) *G2Jac {
 	return p.mulGLV(a, s)
 }
 
+// ScalarMultiplicationBase computes and returns p = g ⋅ s where g is the prime subgroup generator
+func (p *G2Jac) ScalarMultiplicationBase(s *big.Int) *G2Jac {
+	return p.ScalarMultiplication(&g2Gen, s)
+}
+
 // String returns canonical representation of the point in affine coordinates
 func (p *G2Jac) String() string {
 	_p := G2Affine{}
@@ -410,6 +454,188 @@ func (p *G2Jac) mulWindowed(a *G2Jac, s *big.Int) *G2Jac {
 
 }
 
+// ScalarMultiplicationCT computes and returns p = a ⋅ s, in constant time with respect to s.
+//
+// It uses a Montgomery ladder over all the bits of s mod r, with the complete addition and
+// doubling formulas of Renes-Costello-Batina (https://eprint.iacr.org/2015/1060.pdf), so that
+// the sequence of field operations does not depend on s. It is slower than ScalarMultiplication,
+// and meant for secret scalars (e.g. private keys). The reduction of s mod r, if s ≥ r or s < 0,
+// is done with math/big and is not constant time.
+func (p *G2Affine) ScalarMultiplicationCT(a *G2Affine, s *big.Int) *G2Affine {
+	var _p G2Jac
+	_p.FromAffine(a)
+	_p.ScalarMultiplicationCT(&_p, s)
+	p.FromJacobian(&_p)
+	return p
+}
+
+// ScalarMultiplicationCT computes and returns p = a ⋅ s, in constant time with respect to s.
+// See G2Affine.ScalarMultiplicationCT.
+func (p *G2Jac) ScalarMultiplicationCT(a *G2Jac, s *big.Int) *G2Jac {
+	var k fr.Element
+	k.SetBigInt(s).FromMont()
+
+	// R₀ = 0, R₁ = a; R₁ - R₀ = a is invariant
+	var r0, r1, t g2ProjCT
+	r0.y.SetOne()
+	r1.fromJacobian(a)
+
+	var b3 fptower.E2
+	g2B3(&b3)
+
+	for i := fr.Bits - 1; i >= 0; i-- {
+		bit := int(k[i/64]>>(i%64)) & 1
+
+		// (R₀, R₁) ← (2R₀, R₀+R₁) if bit == 0, (R₀+R₁, 2R₁) otherwise
+		t.selectCT(bit, &r0, &r1)
+		r1.selectCT(bit, &r1, &r0)
+		r0.Set(&t)
+		r1.addComplete(&r0, &r1, &b3)
+		r0.doubleComplete(&r0, &b3)
+		t.selectCT(bit, &r0, &r1)
+		r1.selectCT(bit, &r1, &r0)
+		r0.Set(&t)
+	}
+
+	return p.fromProjCT(&r0)
+}
+
+// g2B3 sets b3 to 3b, where b is the curve coefficient used by the complete formulas
+func g2B3(b3 *fptower.E2) {
+	b3.Double(&bTwistCurveCoeff).Add(b3, &bTwistCurveCoeff)
+}
+
+// g2ProjCT point in homogeneous projective coordinates (x=X/Z, y=Y/Z), on which the
+// complete formulas are used. The point at infinity is (0:1:0).
+type g2ProjCT struct {
+	x, y, z fptower.E2
+}
+
+// Set sets p to a
+func (p *g2ProjCT) Set(a *g2ProjCT) *g2ProjCT {
+	p.x, p.y, p.z = a.x, a.y, a.z
+	return p
+}
+
+// fromJacobian sets p to (X⋅Z : Y : Z³)
+func (p *g2ProjCT) fromJacobian(a *G2Jac) *g2ProjCT {
+	var zz fptower.E2
+	zz.Square(&a.Z)
+	p.x.Mul(&a.X, &a.Z)
+	p.y.Set(&a.Y)
+	p.z.Mul(&zz, &a.Z)
+	return p
+}
+
+// fromProjCT sets p to (X⋅Z, Y⋅Z², Z). The point at infinity (0:1:0) is mapped to (1, 1, 0),
+// without branching on Z.
+func (p *G2Jac) fromProjCT(a *g2ProjCT) *G2Jac {
+	var zz fptower.E2
+	zz.Square(&a.z)
+	p.X.Mul(&a.x, &a.z)
+	p.Y.Mul(&a.y, &zz)
+	p.Z.Set(&a.z)
+
+	// if Z = 0, X = Y = 0: add 1 to both
+	var zero, one, inf fptower.E2
+	one.SetOne()
+	c := g2IsZeroCT(&a.z)
+	inf.A0.Select(c, &zero.A0, &one.A0)
+	p.X.Add(&p.X, &inf)
+	p.Y.Add(&p.Y, &inf)
+	return p
+}
+
+// g2IsZeroCT returns 1 if z == 0, 0 otherwise, without branching
+func g2IsZeroCT(z *fptower.E2) int {
+	var acc uint64
+	for _, w := range z.A0 {
+		acc |= w
+	}
+	for _, w := range z.A1 {
+		acc |= w
+	}
+	return int(((acc | -acc) >> 63) ^ 1)
+}
+
+// selectCT sets p to a if c == 0, to b otherwise, in constant time
+func (p *g2ProjCT) selectCT(c int, a, b *g2ProjCT) *g2ProjCT {
+	p.x.A0.Select(c, &a.x.A0, &b.x.A0)
+	p.x.A1.Select(c, &a.x.A1, &b.x.A1)
+	p.y.A0.Select(c, &a.y.A0, &b.y.A0)
+	p.y.A1.Select(c, &a.y.A1, &b.y.A1)
+	p.z.A0.Select(c, &a.z.A0, &b.z.A0)
+	p.z.A1.Select(c, &a.z.A1, &b.z.A1)
+	return p
+}
+
+// addComplete sets p to a+b, for any a, b (including the point at infinity and a == b)
+// https://eprint.iacr.org/2015/1060.pdf, algorithm 7, where b3 = 3b
+func (p *g2ProjCT) addComplete(a, b *g2ProjCT, b3 *fptower.E2) *g2ProjCT {
+	var t0, t1, t2, t3, t4, x3, y3, z3 fptower.E2
+	t0.Mul(&a.x, &b.x)
+	t1.Mul(&a.y, &b.y)
+	t2.Mul(&a.z, &b.z)
+	t3.Add(&a.x, &a.y)
+	t4.Add(&b.x, &b.y)
+	t3.Mul(&t3, &t4)
+	t4.Add(&t0, &t1)
+	t3.Sub(&t3, &t4)
+	t4.Add(&a.y, &a.z)
+	x3.Add(&b.y, &b.z)
+	t4.Mul(&t4, &x3)
+	x3.Add(&t1, &t2)
+	t4.Sub(&t4, &x3)
+	x3.Add(&a.x, &a.z)
+	y3.Add(&b.x, &b.z)
+	x3.Mul(&x3, &y3)
+	y3.Add(&t0, &t2)
+	y3.Sub(&x3, &y3)
+	x3.Double(&t0)
+	t0.Add(&x3, &t0)
+	t2.Mul(b3, &t2)
+	z3.Add(&t1, &t2)
+	t1.Sub(&t1, &t2)
+	y3.Mul(b3, &y3)
+	x3.Mul(&t4, &y3)
+	t2.Mul(&t3, &t1)
+	x3.Sub(&t2, &x3)
+	y3.Mul(&y3, &t0)
+	t1.Mul(&t1, &z3)
+	y3.Add(&t1, &y3)
+	t0.Mul(&t0, &t3)
+	z3.Mul(&z3, &t4)
+	z3.Add(&z3, &t0)
+
+	p.x, p.y, p.z = x3, y3, z3
+	return p
+}
+
+// doubleComplete sets p to 2a, for any a (including the point at infinity)
+// https://eprint.iacr.org/2015/1060.pdf, algorithm 9, where b3 = 3b
+func (p *g2ProjCT) doubleComplete(a *g2ProjCT, b3 *fptower.E2) *g2ProjCT {
+	var t0, t1, t2, x3, y3, z3 fptower.E2
+	t0.Square(&a.y)
+	z3.Double(&t0).Double(&z3).Double(&z3)
+	t1.Mul(&a.y, &a.z)
+	t2.Square(&a.z)
+	t2.Mul(b3, &t2)
+	x3.Mul(&t2, &z3)
+	y3.Add(&t0, &t2)
+	z3.Mul(&t1, &z3)
+	t1.Double(&t2)
+	t2.Add(&t1, &t2)
+	t0.Sub(&t0, &t2)
+	y3.Mul(&t0, &y3)
+	y3.Add(&x3, &y3)
+	t1.Mul(&a.x, &a.y)
+	x3.Mul(&t0, &t1)
+	x3.Double(&x3)
+
+	p.x, p.y, p.z = x3, y3, z3
+	return p
+}
+
 // ψ(p) = u o π o u⁻¹ where u:E'→E iso from the twist to E
 func (p *G2Jac) psi(a *G2Jac) *G2Jac {
 	p.Set(a)
@@ -910,7 +1136,7 @@ func BatchScalarMultiplicationG2(base *G2Affine, scalars []fr.Element) []G2Affin
 		baseTable[i].AddMixed(base)
 	}
 
-	pScalars, _ := partitionScalars(scalars, c, false, runtime.NumCPU())
+	pScalars, _ := partitionScalars(scalars, c, false, parallel.MaxProcs())
 
 	// compute offset and word selector / shift to select the right bits of our windows
 	selectors := make([]selector, nbChunks)
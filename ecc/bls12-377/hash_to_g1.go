@@ -25,50 +25,87 @@ import (
 
 //Note: This only works for simple extensions
 
+// coefficients of the rational maps of the isogeny E' → E, in increasing degree.
+// The denominators are monic, their leading coefficient is omitted.
+var (
+	g1IsogenyXNumeratorMap = []fp.Element{
+		{9381318728011785451, 8795417190580748876, 15171640721257608922, 11815547924113428908, 15499908520243100994, 75408755324413256},
+		{12414498063752772717, 9915153185132073893, 5598625970987438951, 3342254783599619135, 3349592178919125510, 9993871847068096},
+		{4662210776746950618, 10687085762534440940, 7484820859645808636, 2221301482234255553, 10609677459585442106, 9950135580589350},
+	}
+	g1IsogenyXDenominatorMap = []fp.Element{
+		{12764504107591987636, 2767124593109192342, 3947759810240204190, 13369019134398476541, 13398368715676502040, 39975487388272384},
+	}
+	g1IsogenyYNumeratorMap = []fp.Element{
+		{13844135623281082635, 637899392157745290, 5176720401210677272, 4780940929980393029, 13803251044890140836, 51447363642369244},
+		{512010462697120695, 609509684909242946, 13763343875136563934, 2839514380057330869, 15407015190976871917, 114223893455203604},
+		{14191436515319700132, 6479619458373647736, 9513056055282499867, 15178407828209519654, 12166396751953702822, 75539964123849493},
+		{2331105388373475309, 5343542881267220470, 12965782466677680126, 1110650741117127776, 5304838729792721053, 4975067790294675},
+	}
+	g1IsogenyYDenominatorMap = []fp.Element{
+		{8694832399336342723, 13482963304561246841, 6984108042366343277, 8355250559073919616, 16937021447778317421, 44890599540624877},
+		{1100361703846424922, 5005767817281133373, 917019320419705433, 14251746270386956490, 5522097789867984932, 4443041874334878},
+		{1400024175356859676, 8301373779327577028, 11843279430720612570, 3213569255776326391, 3301617999610402890, 119926462164817154},
+	}
+)
+
+// coefficients A', B' of the curve E': y² = x³ + A'x + B' isogenous to E, on which the SSWU map is computed,
+// and the non-square Z of the map
+var (
+	g1SSWUIsoCurveCoeffA = fp.Element{17252667382019449424, 8408110001211059699, 18415587021986261264, 10797086888535946954, 9462758283094809199, 54995354010328751}
+	g1SSWUIsoCurveCoeffB = fp.Element{11130294635325289193, 6502679372128844082, 15863297759487624914, 16270683149854112145, 3560014356538878812, 27923742146399959}
+	g1SSWUZ              = fp.Element{9871116327010172167, 9167007004823125620, 18338974479346628539, 5649234265355377548, 13442091487463296847, 77904398905292312}
+)
+
+// G1SSWUConstants returns the coefficients A', B' of the curve E': y² = x³ + A'x + B' isogenous to
+// the curve of G1, and the constant Z of the simplified SWU map to E'.
+// https://www.rfc-editor.org/rfc/rfc9380.html#name-simplified-swu-for-ab-0
+func G1SSWUConstants() (a, b, z fp.Element) {
+	return g1SSWUIsoCurveCoeffA, g1SSWUIsoCurveCoeffB, g1SSWUZ
+}
+
+// G1IsogenyCoefficients returns the coefficients of the rational maps of the 2-isogeny
+// (x, y) ↦ (xNum(x)/xDen(x), y·yNum(x)/yDen(x)) from E' to the curve of G1, in increasing degree.
+// The denominators are monic, their leading coefficient 1 is omitted.
+// The slices are copies, they can be modified freely.
+func G1IsogenyCoefficients() (xNum, xDen, yNum, yDen []fp.Element) {
+	xNum = append([]fp.Element(nil), g1IsogenyXNumeratorMap...)
+	xDen = append([]fp.Element(nil), g1IsogenyXDenominatorMap...)
+	yNum = append([]fp.Element(nil), g1IsogenyYNumeratorMap...)
+	yDen = append([]fp.Element(nil), g1IsogenyYDenominatorMap...)
+	return
+}
+
+// G1EvalSSWU evaluates the simplified SWU map at u. The result is a point of E', not of
+// the curve of G1; MapToG1(u) is G1EvalIsogeny(G1EvalSSWU(u)) with the cofactor cleared.
+func G1EvalSSWU(u *fp.Element) G1Affine {
+	return mapToCurve1(u)
+}
+
+// G1EvalIsogeny evaluates the isogeny E' → E at p, a point of E'
+func G1EvalIsogeny(p *G1Affine) G1Affine {
+	res := *p
+	g1Isogeny(&res)
+	return res
+}
+
 func g1IsogenyXNumerator(dst *fp.Element, x *fp.Element) {
-	g1EvalPolynomial(dst,
-		false,
-		[]fp.Element{
-			{9381318728011785451, 8795417190580748876, 15171640721257608922, 11815547924113428908, 15499908520243100994, 75408755324413256},
-			{12414498063752772717, 9915153185132073893, 5598625970987438951, 3342254783599619135, 3349592178919125510, 9993871847068096},
-			{4662210776746950618, 10687085762534440940, 7484820859645808636, 2221301482234255553, 10609677459585442106, 9950135580589350},
-		},
-		x)
+	g1EvalPolynomial(dst, false, g1IsogenyXNumeratorMap, x)
 }
 
 func g1IsogenyXDenominator(dst *fp.Element, x *fp.Element) {
-	g1EvalPolynomial(dst,
-		true,
-		[]fp.Element{
-			{12764504107591987636, 2767124593109192342, 3947759810240204190, 13369019134398476541, 13398368715676502040, 39975487388272384},
-		},
-		x)
+	g1EvalPolynomial(dst, true, g1IsogenyXDenominatorMap, x)
 }
 
 func g1IsogenyYNumerator(dst *fp.Element, x *fp.Element, y *fp.Element) {
 	var _dst fp.Element
-	g1EvalPolynomial(&_dst,
-		false,
-		[]fp.Element{
-			{13844135623281082635, 637899392157745290, 5176720401210677272, 4780940929980393029, 13803251044890140836, 51447363642369244},
-			{512010462697120695, 609509684909242946, 13763343875136563934, 2839514380057330869, 15407015190976871917, 114223893455203604},
-			{14191436515319700132, 6479619458373647736, 9513056055282499867, 15178407828209519654, 12166396751953702822, 75539964123849493},
-			{2331105388373475309, 5343542881267220470, 12965782466677680126, 1110650741117127776, 5304838729792721053, 4975067790294675},
-		},
-		x)
+	g1EvalPolynomial(&_dst, false, g1IsogenyYNumeratorMap, x)
 
 	dst.Mul(&_dst, y)
 }
 
 func g1IsogenyYDenominator(dst *fp.Element, x *fp.Element) {
-	g1EvalPolynomial(dst,
-		true,
-		[]fp.Element{
-			{8694832399336342723, 13482963304561246841, 6984108042366343277, 8355250559073919616, 16937021447778317421, 44890599540624877},
-			{1100361703846424922, 5005767817281133373, 917019320419705433, 14251746270386956490, 5522097789867984932, 4443041874334878},
-			{1400024175356859676, 8301373779327577028, 11843279430720612570, 3213569255776326391, 3301617999610402890, 119926462164817154},
-		},
-		x)
+	g1EvalPolynomial(dst, true, g1IsogenyYDenominatorMap, x)
 }
 
 func g1Isogeny(p *G1Affine) {
@@ -171,9 +208,6 @@ func g1MulByZ(z *fp.Element, x *fp.Element) {
 // No cofactor clearing or isogeny
 func mapToCurve1(u *fp.Element) G1Affine {
 
-	var sswuIsoCurveCoeffA = fp.Element{17252667382019449424, 8408110001211059699, 18415587021986261264, 10797086888535946954, 9462758283094809199, 54995354010328751}
-	var sswuIsoCurveCoeffB = fp.Element{11130294635325289193, 6502679372128844082, 15863297759487624914, 16270683149854112145, 3560014356538878812, 27923742146399959}
-
 	var tv1 fp.Element
 	tv1.Square(u) // 1.  tv1 = u²
 
@@ -187,17 +221,17 @@ func mapToCurve1(u *fp.Element) G1Affine {
 	var tv3 fp.Element
 	var tv4 fp.Element
 	tv4.SetOne()
-	tv3.Add(&tv2, &tv4)                // 5.  tv3 = tv2 + 1
-	tv3.Mul(&tv3, &sswuIsoCurveCoeffB) // 6.  tv3 = B * tv3
+	tv3.Add(&tv2, &tv4)                  // 5.  tv3 = tv2 + 1
+	tv3.Mul(&tv3, &g1SSWUIsoCurveCoeffB) // 6.  tv3 = B * tv3
 
 	tv2NZero := g1NotZero(&tv2)
 
 	// tv4 = Z
-	tv4 = fp.Element{9871116327010172167, 9167007004823125620, 18338974479346628539, 5649234265355377548, 13442091487463296847, 77904398905292312}
+	tv4 = g1SSWUZ
 
 	tv2.Neg(&tv2)
 	tv4.Select(int(tv2NZero), &tv4, &tv2) // 7.  tv4 = CMOV(Z, -tv2, tv2 != 0)
-	tv4.Mul(&tv4, &sswuIsoCurveCoeffA)    // 8.  tv4 = A * tv4
+	tv4.Mul(&tv4, &g1SSWUIsoCurveCoeffA)  // 8.  tv4 = A * tv4
 
 	tv2.Square(&tv3) // 9.  tv2 = tv3²
 
@@ -205,14 +239,14 @@ func mapToCurve1(u *fp.Element) G1Affine {
 	tv6.Square(&tv4) // 10. tv6 = tv4²
 
 	var tv5 fp.Element
-	tv5.Mul(&tv6, &sswuIsoCurveCoeffA) // 11. tv5 = A * tv6
+	tv5.Mul(&tv6, &g1SSWUIsoCurveCoeffA) // 11. tv5 = A * tv6
 
 	tv2.Add(&tv2, &tv5) // 12. tv2 = tv2 + tv5
 	tv2.Mul(&tv2, &tv3) // 13. tv2 = tv2 * tv3
 	tv6.Mul(&tv6, &tv4) // 14. tv6 = tv6 * tv4
 
-	tv5.Mul(&tv6, &sswuIsoCurveCoeffB) // 15. tv5 = B * tv6
-	tv2.Add(&tv2, &tv5)                // 16. tv2 = tv2 + tv5
+	tv5.Mul(&tv6, &g1SSWUIsoCurveCoeffB) // 15. tv5 = B * tv6
+	tv2.Add(&tv2, &tv5)                  // 16. tv2 = tv2 + tv5
 
 	var x fp.Element
 	x.Mul(&tv1, &tv3) // 17.   x = tv1 * tv3
@@ -280,11 +314,8 @@ func hashToFp(msg, dst []byte, count int) ([]fp.Element, error) {
 // https://www.ietf.org/archive/id/draft-irtf-cfrg-hash-to-curve-16.html#name-the-sgn0-function
 // The sign of an element is not obviously related to that of its Montgomery form
 func g1Sgn0(z *fp.Element) uint64 {
-
-	nonMont := *z
-	nonMont.FromMont()
 	// m == 1
-	return nonMont[0] % 2
+	return z.Sign0()
 
 }
 
@@ -297,24 +328,36 @@ func MapToG1(u fp.Element) G1Affine {
 	return res
 }
 
+// G1HashTrace holds the intermediate values of HashToG1 and EncodeToG1,
+// named as in the test vectors of RFC 9380 (appendix J), to compare an implementation
+// or debug the handling of a DST against them.
+type G1HashTrace struct {
+	// U is the output of hash_to_field: one element for EncodeToG1, two for HashToG1
+	U []fp.Element
+
+	// Q[i] is map_to_curve(U[i]), after the isogeny, before clearing the cofactor
+	Q []G1Affine
+}
+
 // EncodeToG1 hashes a message to a point on the G1 curve using the SSWU map.
 // It is faster than HashToG1, but the result is not uniformly distributed. Unsuitable as a random oracle.
 // dst stands for "domain separation tag", a string unique to the construction using the hash function
 //https://www.ietf.org/archive/id/draft-irtf-cfrg-hash-to-curve-16.html#roadmap
 func EncodeToG1(msg, dst []byte) (G1Affine, error) {
+	res, _, err := EncodeToG1Trace(msg, dst)
+	return res, err
+}
 
-	var res G1Affine
-	u, err := hashToFp(msg, dst, 1)
+// EncodeToG1Trace is EncodeToG1, which also returns its intermediate values.
+func EncodeToG1Trace(msg, dst []byte) (G1Affine, G1HashTrace, error) {
+	trace, err := g1HashToCurveTrace(msg, dst, 1)
 	if err != nil {
-		return res, err
+		return G1Affine{}, trace, err
 	}
 
-	res = mapToCurve1(&u[0])
-
-	//this is in an isogenous curve
-	g1Isogeny(&res)
+	res := trace.Q[0]
 	res.ClearCofactor(&res)
-	return res, nil
+	return res, trace, nil
 }
 
 // HashToG1 hashes a message to a point on the G1 curve using the SSWU map.
@@ -322,26 +365,45 @@ func EncodeToG1(msg, dst []byte) (G1Affine, error) {
 // dst stands for "domain separation tag", a string unique to the construction using the hash function
 //https://www.ietf.org/archive/id/draft-irtf-cfrg-hash-to-curve-16.html#roadmap
 func HashToG1(msg, dst []byte) (G1Affine, error) {
-	u, err := hashToFp(msg, dst, 2*1)
+	res, _, err := HashToG1Trace(msg, dst)
+	return res, err
+}
+
+// HashToG1Trace is HashToG1, which also returns its intermediate values.
+func HashToG1Trace(msg, dst []byte) (G1Affine, G1HashTrace, error) {
+	trace, err := g1HashToCurveTrace(msg, dst, 2)
 	if err != nil {
-		return G1Affine{}, err
+		return G1Affine{}, trace, err
 	}
-
-	Q0 := mapToCurve1(&u[0])
-	Q1 := mapToCurve1(&u[1])
-
 	//TODO (perf): Add in E' first, then apply isogeny
-	g1Isogeny(&Q0)
-	g1Isogeny(&Q1)
-
 	var _Q0, _Q1 G1Jac
-	_Q0.FromAffine(&Q0)
-	_Q1.FromAffine(&Q1).AddAssign(&_Q0)
+	_Q0.FromAffine(&trace.Q[0])
+	_Q1.FromAffine(&trace.Q[1]).AddAssign(&_Q0)
 
 	_Q1.ClearCofactor(&_Q1)
 
-	Q1.FromJacobian(&_Q1)
-	return Q1, nil
+	var res G1Affine
+	res.FromJacobian(&_Q1)
+	return res, trace, nil
+}
+
+// g1HashToCurveTrace hashes msg to count elements of the base field of G1, and maps them to the curve
+func g1HashToCurveTrace(msg, dst []byte, count int) (G1HashTrace, error) {
+	var trace G1HashTrace
+	u, err := hashToFp(msg, dst, count*1)
+	if err != nil {
+		return trace, err
+	}
+
+	trace.U = make([]fp.Element, count)
+	trace.Q = make([]G1Affine, count)
+	for i := 0; i < count; i++ {
+		trace.U[i] = u[i]
+		trace.Q[i] = mapToCurve1(&trace.U[i])
+		//this is in an isogenous curve
+		g1Isogeny(&trace.Q[i])
+	}
+	return trace, nil
 }
 
 func g1NotZero(x *fp.Element) uint64 {
@@ -137,6 +137,77 @@ func TestMapToCurve2(t *testing.T) {
 	}
 }
 
+func TestG2SSWUConstants(t *testing.T) {
+	t.Parallel()
+
+	a, b, z := G2SSWUConstants()
+
+	var A, B fptower.E2
+
+	A.SetString(
+		"203567575243095400658685394654545117908398249146024925306257919445062693445414588103741379252427065422417496933054",
+		"69357795553467368835766998649443114298653120475771922004522583893765862042427351483161253261358624703462995261783",
+	)
+
+	B.SetString(
+		"249039961697346248294162904170316935273494032138504221215795383014884687447192317932476994472315647695087734549420",
+		"806998283981877041862626354975415285020485827233942100233224759047656510577433749137260740227904569833498998565",
+	)
+
+	if !a.Equal(&A) || !b.Equal(&B) {
+		t.Fatal("unexpected coefficients of E'")
+	}
+
+	var x, expected, seen fptower.E2
+	x.SetRandom()
+	expected.Mul(&x, &z)
+	g2MulByZ(&seen, &x)
+	if !seen.Equal(&expected) {
+		t.Fatal("unexpected Z")
+	}
+}
+
+func TestG2EvalIsogeny(t *testing.T) {
+	t.Parallel()
+
+	xNum, xDen, yNum, yDen := G2IsogenyCoefficients()
+	// evaluates the polynomial of coefficients c at x, with an implicit leading coefficient 1 if monic
+	eval := func(c []fptower.E2, x *fptower.E2, monic bool) fptower.E2 {
+		var res, xi fptower.E2
+		xi.SetOne()
+		for i := range c {
+			var tmp fptower.E2
+			tmp.Mul(&c[i], &xi)
+			res.Add(&res, &tmp)
+			xi.Mul(&xi, x)
+		}
+		if monic {
+			res.Add(&res, &xi)
+		}
+		return res
+	}
+
+	for i := 0; i < 10; i++ {
+		var u fptower.E2
+		u.SetRandom()
+		p := G2EvalSSWU(&u)
+		if !isOnE2Prime(p) {
+			t.Fatal("SSWU output not on E'")
+		}
+
+		var expected G2Affine
+		num, den := eval(xNum, &p.X, false), eval(xDen, &p.X, true)
+		expected.X.Div(&num, &den)
+		num, den = eval(yNum, &p.X, false), eval(yDen, &p.X, true)
+		expected.Y.Div(&num, &den).Mul(&expected.Y, &p.Y)
+
+		seen := G2EvalIsogeny(&p)
+		if !seen.Equal(&expected) || !seen.IsOnCurve() {
+			t.Fatal("unexpected image by the isogeny")
+		}
+	}
+}
+
 func TestMapToG2(t *testing.T) {
 	t.Parallel()
 	parameters := gopter.DefaultTestParameters()
@@ -190,6 +261,40 @@ func TestHashToG2(t *testing.T) {
 	}
 }
 
+func TestEncodeToG2Trace(t *testing.T) {
+	t.Parallel()
+	for _, c := range encodeToG2Vector.cases {
+		p, trace, err := EncodeToG2Trace([]byte(c.msg), encodeToG2Vector.dst)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(trace.U) != 1 || len(trace.Q) != 1 {
+			t.Fatal("expected one field element and one point in the trace")
+		}
+		g2TestMatchCoord(t, "u", c.msg, c.u, trace.U[0])
+		g2TestMatchPoint(t, "Q", c.msg, c.Q, &trace.Q[0])
+		g2TestMatchPoint(t, "P", c.msg, c.P, &p)
+	}
+}
+
+func TestHashToG2Trace(t *testing.T) {
+	t.Parallel()
+	for _, c := range hashToG2Vector.cases {
+		p, trace, err := HashToG2Trace([]byte(c.msg), hashToG2Vector.dst)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(trace.U) != 2 || len(trace.Q) != 2 {
+			t.Fatal("expected two field elements and two points in the trace")
+		}
+		g2TestMatchCoord(t, "u0", c.msg, c.u0, trace.U[0])
+		g2TestMatchCoord(t, "u1", c.msg, c.u1, trace.U[1])
+		g2TestMatchPoint(t, "Q0", c.msg, c.Q0, &trace.Q[0])
+		g2TestMatchPoint(t, "Q1", c.msg, c.Q1, &trace.Q[1])
+		g2TestMatchPoint(t, "P", c.msg, c.P, &p)
+	}
+}
+
 func BenchmarkEncodeToG2(b *testing.B) {
 	const size = 54
 	bytes := make([]byte, size)
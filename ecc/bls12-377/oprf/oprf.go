@@ -0,0 +1,195 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package oprf
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-377"
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/sigma"
+)
+
+var (
+	ErrInvalidInput   = errors.New("input hashes to the point at infinity")
+	ErrInvalidElement = errors.New("element is not a point of the prime order subgroup")
+	ErrInvalidProof   = errors.New("invalid evaluation proof")
+)
+
+// Mode OPRF protocol variant
+type Mode uint8
+
+const (
+	ModeOPRF  Mode = 0x00
+	ModeVOPRF Mode = 0x01
+)
+
+const identifier = "bls12-377-G1-SHA256"
+
+// contextString returns "OPRFV1-" || I2OSP(mode, 1) || "-" || identifier
+func (m Mode) contextString() []byte {
+	res := []byte("OPRFV1-")
+	res = append(res, byte(m))
+	res = append(res, '-')
+	return append(res, identifier...)
+}
+
+// PublicKey server public key, A = kG
+type PublicKey struct {
+	A bls12377.G1Affine
+}
+
+// PrivateKey server private key
+type PrivateKey struct {
+	PublicKey PublicKey // copy of the associated public key
+	scalar    fr.Element
+}
+
+// GenerateKey generates a server key pair
+func GenerateKey() (*PrivateKey, error) {
+	var priv PrivateKey
+	for priv.scalar.IsZero() {
+		if _, err := priv.scalar.SetRandom(); err != nil {
+			return nil, err
+		}
+	}
+	var b big.Int
+	_, _, g, _ := bls12377.Generators()
+	priv.PublicKey.A.ScalarMultiplicationCT(&g, priv.scalar.ToBigIntRegular(&b))
+	return &priv, nil
+}
+
+// Blind returns the blinded element r·H₁(input) and the blind r, to be kept by
+// the client until Finalize.
+func Blind(mode Mode, input []byte) (blind fr.Element, blinded bls12377.G1Affine, err error) {
+	p, err := hashToGroup(mode, input)
+	if err != nil {
+		return
+	}
+	for blind.IsZero() {
+		if _, err = blind.SetRandom(); err != nil {
+			return
+		}
+	}
+	var b big.Int
+	blinded.ScalarMultiplicationCT(&p, blind.ToBigIntRegular(&b))
+	return
+}
+
+// BlindEvaluate returns k·blinded. blinded is chosen by the client, so the multiplication by
+// the private key k is done in constant time.
+func (priv *PrivateKey) BlindEvaluate(blinded *bls12377.G1Affine) (bls12377.G1Affine, error) {
+	var res bls12377.G1Affine
+	if !isValid(blinded) {
+		return res, ErrInvalidElement
+	}
+	var b big.Int
+	res.ScalarMultiplicationCT(blinded, priv.scalar.ToBigIntRegular(&b))
+	return res, nil
+}
+
+// BlindEvaluateVerifiable returns k·blinded and a proof that the discrete
+// logarithm of the result in base blinded is the one of the public key.
+func (priv *PrivateKey) BlindEvaluateVerifiable(blinded *bls12377.G1Affine) (bls12377.G1Affine, sigma.Proof, error) {
+	evaluated, err := priv.BlindEvaluate(blinded)
+	if err != nil {
+		return evaluated, sigma.Proof{}, err
+	}
+	statement := dleqStatement(&priv.PublicKey, blinded, &evaluated)
+	proof, err := sigma.Prove(&statement, []fr.Element{priv.scalar}, ModeVOPRF.contextString())
+	return evaluated, proof, err
+}
+
+// Finalize unblinds the evaluated element and returns the output of the PRF on input.
+func Finalize(input []byte, blind *fr.Element, evaluated *bls12377.G1Affine) ([]byte, error) {
+	if !isValid(evaluated) {
+		return nil, ErrInvalidElement
+	}
+	var inv fr.Element
+	var b big.Int
+	inv.Inverse(blind)
+	var unblinded bls12377.G1Affine
+	unblinded.ScalarMultiplicationCT(evaluated, inv.ToBigIntRegular(&b))
+	return finalizeHash(input, &unblinded), nil
+}
+
+// VerifiableFinalize checks the evaluation proof of the server, then unblinds the
+// evaluated element and returns the output of the PRF on input.
+func VerifiableFinalize(pub *PublicKey, input []byte, blind *fr.Element, blinded, evaluated *bls12377.G1Affine, proof *sigma.Proof) ([]byte, error) {
+	if !isValid(&pub.A) || !isValid(evaluated) {
+		return nil, ErrInvalidElement
+	}
+	statement := dleqStatement(pub, blinded, evaluated)
+	if err := sigma.Verify(&statement, proof, ModeVOPRF.contextString()); err != nil {
+		return nil, ErrInvalidProof
+	}
+	return Finalize(input, blind, evaluated)
+}
+
+// Evaluate returns the output of the PRF on input, computed directly with the
+// private key. It matches the output of the client in the given mode.
+func (priv *PrivateKey) Evaluate(mode Mode, input []byte) ([]byte, error) {
+	p, err := hashToGroup(mode, input)
+	if err != nil {
+		return nil, err
+	}
+	var b big.Int
+	p.ScalarMultiplicationCT(&p, priv.scalar.ToBigIntRegular(&b))
+	return finalizeHash(input, &p), nil
+}
+
+// dleqStatement returns the statement log_G(A) = log_blinded(evaluated)
+func dleqStatement(pub *PublicKey, blinded, evaluated *bls12377.G1Affine) sigma.Statement {
+	_, _, g, _ := bls12377.Generators()
+	return sigma.NewDLEQ(g, pub.A, *blinded, *evaluated)
+}
+
+// hashToGroup hashes input to G1 with the DST "HashToGroup-" || contextString
+func hashToGroup(mode Mode, input []byte) (bls12377.G1Affine, error) {
+	dst := append([]byte("HashToGroup-"), mode.contextString()...)
+	p, err := bls12377.HashToG1(input, dst)
+	if err != nil {
+		return p, err
+	}
+	if p.IsInfinity() {
+		return p, ErrInvalidInput
+	}
+	return p, nil
+}
+
+// finalizeHash returns H(I2OSP(len(input), 2) || input || I2OSP(len(N), 2) || N || "Finalize")
+func finalizeHash(input []byte, unblinded *bls12377.G1Affine) []byte {
+	n := unblinded.Bytes()
+	var l [2]byte
+	h := sha256.New()
+	binary.BigEndian.PutUint16(l[:], uint16(len(input)))
+	h.Write(l[:])
+	h.Write(input)
+	binary.BigEndian.PutUint16(l[:], uint16(len(n)))
+	h.Write(l[:])
+	h.Write(n[:])
+	h.Write([]byte("Finalize"))
+	return h.Sum(nil)
+}
+
+// isValid returns true if p is a point of the prime order subgroup, different from the point at infinity
+func isValid(p *bls12377.G1Affine) bool {
+	return !p.IsInfinity() && p.IsOnCurve() && p.IsInSubGroup()
+}
@@ -0,0 +1,120 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package oprf
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestOPRF(t *testing.T) {
+
+	priv, err := GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	input := []byte("input")
+
+	blind, blinded, err := Blind(ModeOPRF, input)
+	if err != nil {
+		t.Fatal(err)
+	}
+	evaluated, err := priv.BlindEvaluate(&blinded)
+	if err != nil {
+		t.Fatal(err)
+	}
+	output, err := Finalize(input, &blind, &evaluated)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected, err := priv.Evaluate(ModeOPRF, input)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(output, expected) {
+		t.Fatal("client and server outputs differ")
+	}
+
+	// the output doesn't depend on the blind
+	blind, blinded, err = Blind(ModeOPRF, input)
+	if err != nil {
+		t.Fatal(err)
+	}
+	evaluated, err = priv.BlindEvaluate(&blinded)
+	if err != nil {
+		t.Fatal(err)
+	}
+	output, err = Finalize(input, &blind, &evaluated)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(output, expected) {
+		t.Fatal("output depends on the blind")
+	}
+
+	// different modes are domain separated
+	other, err := priv.Evaluate(ModeVOPRF, input)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Equal(other, expected) {
+		t.Fatal("OPRF and VOPRF outputs should differ")
+	}
+}
+
+func TestVOPRF(t *testing.T) {
+
+	priv, err := GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	input := []byte("input")
+
+	blind, blinded, err := Blind(ModeVOPRF, input)
+	if err != nil {
+		t.Fatal(err)
+	}
+	evaluated, proof, err := priv.BlindEvaluateVerifiable(&blinded)
+	if err != nil {
+		t.Fatal(err)
+	}
+	output, err := VerifiableFinalize(&priv.PublicKey, input, &blind, &blinded, &evaluated, &proof)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected, err := priv.Evaluate(ModeVOPRF, input)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(output, expected) {
+		t.Fatal("client and server outputs differ")
+	}
+
+	// evaluation with another key is detected
+	other, err := GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	evaluated, _, err = other.BlindEvaluateVerifiable(&blinded)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = VerifiableFinalize(&priv.PublicKey, input, &blind, &blinded, &evaluated, &proof); err != ErrInvalidProof {
+		t.Fatal("expected ErrInvalidProof")
+	}
+}
@@ -0,0 +1,227 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package bls12377
+
+import (
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fp"
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
+	"github.com/consensys/gnark-crypto/ecc/group"
+)
+
+func init() {
+	ecc.Register(ecc.Curve{
+		ID:           ecc.BLS12_377,
+		Fr:           frField{},
+		Fp:           fpField{},
+		G1:           G1Group(),
+		G2:           G2Group(),
+		HashToG1:     hashToG1Point,
+		HashToG2:     hashToG2Point,
+		Pair:         pairPoints,
+		PairingCheck: pairingCheckPoints,
+	})
+}
+
+func hashToG1Point(msg, dst []byte) (group.Point, error) {
+	p, err := HashToG1(msg, dst)
+	if err != nil {
+		return nil, err
+	}
+	res := new(g1Point)
+	res.p.FromAffine(&p)
+	return res, nil
+}
+
+func hashToG2Point(msg, dst []byte) (group.Point, error) {
+	p, err := HashToG2(msg, dst)
+	if err != nil {
+		return nil, err
+	}
+	res := new(g2Point)
+	res.p.FromAffine(&p)
+	return res, nil
+}
+
+// toAffines converts points of G1 and G2 to affine coordinates
+func toAffines(P, Q []group.Point) ([]G1Affine, []G2Affine) {
+	_P := make([]G1Affine, len(P))
+	for i := range P {
+		_P[i].FromJacobian(&P[i].(*g1Point).p)
+	}
+	_Q := make([]G2Affine, len(Q))
+	for i := range Q {
+		_Q[i].FromJacobian(&Q[i].(*g2Point).p)
+	}
+	return _P, _Q
+}
+
+func pairPoints(P, Q []group.Point) (ecc.GT, error) {
+	_P, _Q := toAffines(P, Q)
+	res, err := Pair(_P, _Q)
+	if err != nil {
+		return nil, err
+	}
+	return &gtElement{e: res}, nil
+}
+
+func pairingCheckPoints(P, Q []group.Point) (bool, error) {
+	_P, _Q := toAffines(P, Q)
+	return PairingCheck(_P, _Q)
+}
+
+// gtElement implements ecc.GT
+type gtElement struct {
+	e GT
+}
+
+func (z *gtElement) Equal(a ecc.GT) bool {
+	return z.e.Equal(&a.(*gtElement).e)
+}
+
+func (z *gtElement) IsOne() bool {
+	var one GT
+	one.SetOne()
+	return z.e.Equal(&one)
+}
+
+func (z *gtElement) Bytes() []byte {
+	b := z.e.Bytes()
+	return b[:]
+}
+
+// frField implements ecc.Field, its elements are the scalars of G1 and G2
+type frField struct{}
+
+func (frField) String() string {
+	return "bls12-377/fr"
+}
+
+func (frField) Modulus() *big.Int {
+	return fr.Modulus()
+}
+
+func (frField) NewElement() group.Scalar {
+	return new(frScalar)
+}
+
+func (frField) ElementSize() int {
+	return fr.Bytes
+}
+
+// fpField implements ecc.Field
+type fpField struct{}
+
+func (fpField) String() string {
+	return "bls12-377/fp"
+}
+
+func (fpField) Modulus() *big.Int {
+	return fp.Modulus()
+}
+
+func (fpField) NewElement() group.Scalar {
+	return new(fpElement)
+}
+
+func (fpField) ElementSize() int {
+	return fp.Bytes
+}
+
+// fpElement implements group.Scalar for the elements of fp
+type fpElement struct {
+	e fp.Element
+}
+
+func (z *fpElement) Set(a group.Scalar) group.Scalar {
+	z.e.Set(&a.(*fpElement).e)
+	return z
+}
+
+func (z *fpElement) SetUint64(v uint64) group.Scalar {
+	z.e.SetUint64(v)
+	return z
+}
+
+func (z *fpElement) SetBigInt(v *big.Int) group.Scalar {
+	z.e.SetBigInt(v)
+	return z
+}
+
+func (z *fpElement) SetRandom() (group.Scalar, error) {
+	if _, err := z.e.SetRandom(); err != nil {
+		return nil, err
+	}
+	return z, nil
+}
+
+func (z *fpElement) Add(a, b group.Scalar) group.Scalar {
+	z.e.Add(&a.(*fpElement).e, &b.(*fpElement).e)
+	return z
+}
+
+func (z *fpElement) Sub(a, b group.Scalar) group.Scalar {
+	z.e.Sub(&a.(*fpElement).e, &b.(*fpElement).e)
+	return z
+}
+
+func (z *fpElement) Mul(a, b group.Scalar) group.Scalar {
+	z.e.Mul(&a.(*fpElement).e, &b.(*fpElement).e)
+	return z
+}
+
+func (z *fpElement) Neg(a group.Scalar) group.Scalar {
+	z.e.Neg(&a.(*fpElement).e)
+	return z
+}
+
+func (z *fpElement) Inverse(a group.Scalar) group.Scalar {
+	z.e.Inverse(&a.(*fpElement).e)
+	return z
+}
+
+func (z *fpElement) Equal(a group.Scalar) bool {
+	return z.e.Equal(&a.(*fpElement).e)
+}
+
+func (z *fpElement) IsZero() bool {
+	return z.e.IsZero()
+}
+
+func (z *fpElement) BigInt(res *big.Int) *big.Int {
+	return z.e.ToBigIntRegular(res)
+}
+
+func (z *fpElement) Bytes() []byte {
+	b := z.e.Bytes()
+	return b[:]
+}
+
+func (z *fpElement) SetBytes(buf []byte) error {
+	if len(buf) != fp.Bytes {
+		return group.ErrInvalidScalar
+	}
+	var v big.Int
+	v.SetBytes(buf)
+	if v.Cmp(fp.Modulus()) >= 0 {
+		return group.ErrInvalidScalar
+	}
+	z.e.SetBigInt(&v)
+	return nil
+}
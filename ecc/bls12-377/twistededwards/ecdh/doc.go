@@ -0,0 +1,26 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+// Package ecdh provides Diffie-Hellman key exchange on bls12-377's twistededwards curve.
+//
+// Public keys are validated before use: they must be on the curve, in the prime
+// order subgroup and different from the identity, which rules out the points of
+// small order. Shared secrets can be turned into symmetric keys with HKDF-SHA256
+// (see DeriveKey).
+//
+// The exchange can also be run on the u-coordinates of the Montgomery form of the
+// curve, in the style of X25519, with the x-only ladder (see SharedSecretU).
+package ecdh
@@ -0,0 +1,174 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package ecdh
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"errors"
+	"io"
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/twistededwards"
+	"golang.org/x/crypto/hkdf"
+)
+
+var (
+	ErrInvalidPublicKey = errors.New("public key is not a point of the prime order subgroup")
+	ErrInvalidScalar    = errors.New("secret scalar should be in [1, order)")
+)
+
+// PublicKey Diffie-Hellman public key, A = s*G
+type PublicKey struct {
+	A twistededwards.PointAffine
+}
+
+// PrivateKey Diffie-Hellman private key
+type PrivateKey struct {
+	PublicKey PublicKey // copy of the associated public key
+	scalar    big.Int   // secret scalar
+}
+
+// GenerateKey generates a public and private key pair, the secret scalar
+// is sampled uniformly in [1, order).
+func GenerateKey(r io.Reader) (*PrivateKey, error) {
+	c := twistededwards.GetEdwardsCurve()
+	var orderMinusOne big.Int
+	orderMinusOne.Sub(&c.Order, big.NewInt(1))
+	s, err := rand.Int(r, &orderMinusOne)
+	if err != nil {
+		return nil, err
+	}
+	return NewPrivateKey(s.Add(s, big.NewInt(1)))
+}
+
+// NewPrivateKey returns the key pair associated to the secret scalar s.
+func NewPrivateKey(s *big.Int) (*PrivateKey, error) {
+	c := twistededwards.GetEdwardsCurve()
+	if s.Sign() <= 0 || s.Cmp(&c.Order) >= 0 {
+		return nil, ErrInvalidScalar
+	}
+	var priv PrivateKey
+	priv.scalar.Set(s)
+	priv.PublicKey.A.ScalarMultiplication(&c.Base, &priv.scalar)
+	return &priv, nil
+}
+
+// Bytes returns the compressed public key
+func (pub *PublicKey) Bytes() []byte {
+	b := pub.A.Bytes()
+	return b[:]
+}
+
+// SetBytes sets pub from its compressed form, and validates it.
+// It returns the number of bytes read.
+func (pub *PublicKey) SetBytes(buf []byte) (int, error) {
+	n, err := pub.A.SetBytes(buf)
+	if err != nil {
+		return n, err
+	}
+	return n, pub.Validate()
+}
+
+// Validate returns ErrInvalidPublicKey if pub is not a point of the
+// prime order subgroup, or is the identity. In particular, points of
+// small order are rejected.
+func (pub *PublicKey) Validate() error {
+	if pub.A.IsZero() || !pub.A.IsInSubGroup() {
+		return ErrInvalidPublicKey
+	}
+	return nil
+}
+
+// SharedPoint returns s*B, where s is the secret scalar of priv and B the public key
+// of the peer, after validating the latter.
+func (priv *PrivateKey) SharedPoint(peer *PublicKey) (twistededwards.PointAffine, error) {
+	var res twistededwards.PointAffine
+	if err := peer.Validate(); err != nil {
+		return res, err
+	}
+	res.ScalarMultiplication(&peer.A, &priv.scalar)
+	return res, nil
+}
+
+// SharedSecret returns the compressed encoding of the shared point.
+func (priv *PrivateKey) SharedSecret(peer *PublicKey) ([]byte, error) {
+	p, err := priv.SharedPoint(peer)
+	if err != nil {
+		return nil, err
+	}
+	b := p.Bytes()
+	return b[:], nil
+}
+
+// DeriveKey derives a symmetric key of size bytes from the shared secret with
+// HKDF-SHA256. The salt is the concatenation of the two public keys, in a canonical
+// order so both parties derive the same key, and info is the application context.
+func (priv *PrivateKey) DeriveKey(peer *PublicKey, info []byte, size int) ([]byte, error) {
+	secret, err := priv.SharedSecret(peer)
+	if err != nil {
+		return nil, err
+	}
+	a, b := priv.PublicKey.Bytes(), peer.Bytes()
+	if string(a) > string(b) {
+		a, b = b, a
+	}
+	salt := append(append(make([]byte, 0, len(a)+len(b)), a...), b...)
+
+	res := make([]byte, size)
+	if _, err := io.ReadFull(hkdf.New(sha256.New, secret, salt, info), res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// BytesU returns the u-coordinate of the public key on the Montgomery form of the
+// curve, in big endian. It is the public key of the x-only exchange, see SharedSecretU.
+func (pub *PublicKey) BytesU() []byte {
+	u := pub.A.MontgomeryU()
+	b := u.Bytes()
+	return b[:]
+}
+
+// SharedSecretU returns the u-coordinate of s*B on the Montgomery form of the curve, in
+// big endian, computed with the x-only ladder from the u-coordinate of the peer's public
+// key (see PublicKey.BytesU). It is the u-coordinate of SharedPoint.
+//
+// peerU must be the canonical encoding of the u-coordinate of a point of the prime order
+// subgroup, other than the identity, which is checked with a second ladder.
+func (priv *PrivateKey) SharedSecretU(peerU []byte) ([]byte, error) {
+	if len(peerU) != fr.Bytes || new(big.Int).SetBytes(peerU).Cmp(fr.Modulus()) >= 0 {
+		return nil, ErrInvalidPublicKey
+	}
+	var u fr.Element
+	u.SetBytes(peerU)
+
+	// u = 0 is the identity or the point of order 2, [r]P = 0 rules out the
+	// points with a small order component and the points of the twist
+	c := twistededwards.GetEdwardsCurve()
+	if u.IsZero() {
+		return nil, ErrInvalidPublicKey
+	}
+	if _, isInfinity := twistededwards.ScalarMultiplicationU(&u, &c.Order); !isInfinity {
+		return nil, ErrInvalidPublicKey
+	}
+
+	res, _ := twistededwards.ScalarMultiplicationU(&u, &priv.scalar)
+	b := res.Bytes()
+	return b[:], nil
+}
@@ -0,0 +1,488 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package twistededwards
+
+import (
+	"errors"
+	"io"
+	"math/big"
+	"sync"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
+)
+
+// SizeOfUniformEncoding is the size in bytes of the uniform encoding of a point.
+// The 16 extra bytes make the encoding of a field element statistically close
+// (2⁻¹²⁸) to a uniform byte string.
+const SizeOfUniformEncoding = fr.Bytes + 16
+
+var (
+	ErrNotInSubGroup      = errors.New("point is not in the prime order subgroup")
+	ErrInvalidUniformSize = errors.New("invalid uniform encoding size")
+	ErrNoUniformEncoding  = errors.New("point has no uniform encoding")
+)
+
+// MapToCurve maps u to a point of the curve using Elligator 2
+// (https://datatracker.ietf.org/doc/html/rfc9380#section-6.7.1), through the
+// birationally equivalent Montgomery curve Kt² = s³ + Js² + s, J = 2(a+d)/(a-d), K = 4/(a-d).
+//
+// The map runs in constant time, and is injective on the u such that sgn0(u) = 0,
+// u and -u being mapped to the same point. The output is on the full curve, not
+// necessarily in the prime order subgroup.
+func MapToCurve(u *fr.Element) PointAffine {
+	ellOnce.Do(initElligatorParams)
+	q := elligator(u)
+	return q.toEdwardsCT()
+}
+
+// MapToCurveInverse returns the u such that sgn0(u) = 0 and MapToCurve(u) = p,
+// and false if p has no preimage, which is the case for about half of the points
+// of the curve. It runs in constant time.
+func MapToCurveInverse(p *PointAffine) (fr.Element, bool) {
+	ellOnce.Do(initElligatorParams)
+
+	var one, num, den fr.Element
+	var q montPoint
+	one.SetOne()
+
+	// (s, t) = ((1+y)/(1-y), s/x)
+	num.Add(&one, &p.Y)
+	den.Sub(&one, &p.Y)
+	invCT(&den, &den)
+	q.x.Mul(&num, &den)
+	invCT(&q.y, &p.X)
+	q.y.Mul(&q.y, &q.x)
+	q.x.Mul(&q.x, &ell.kInv)
+	q.y.Mul(&q.y, &ell.kInv)
+
+	u, ok := elligatorInverse(&q)
+
+	// exceptional points (x = 0, y = ±1, ...) are caught by checking the forward map
+	r := elligator(&u)
+	e := r.toEdwardsCT()
+	return u, ok && e.Equal(p)
+}
+
+// EncodeUniform returns an encoding of p, which must be in the prime order subgroup,
+// that is indistinguishable from a uniformly random string of SizeOfUniformEncoding bytes.
+//
+// A point of small order T is chosen uniformly among those for which p + T has a
+// preimage u by MapToCurve; the encoding is then a random representative of ±u
+// modulo the field order, on SizeOfUniformEncoding bytes. randReader is used for all
+// random choices.
+//
+// ErrNoUniformEncoding is returned if there is no such T, which happens for a fraction
+// of the points on curves whose points of order 2 are not all on the twisted Edwards
+// curve (e.g. bandersnatch); protocols then usually sample a new ephemeral key.
+// Unlike MapToCurve, EncodeUniform does not run in constant time.
+func EncodeUniform(p *PointAffine, randReader io.Reader) ([]byte, error) {
+	ellOnce.Do(initElligatorParams)
+
+	if !p.IsOnCurve() {
+		return nil, ErrNotInSubGroup
+	}
+	var mp, q montPoint
+	mp.fromEdwards(p)
+	if !q.scalarMul(&mp, &curveParams.Order).infinity {
+		return nil, ErrNotInSubGroup
+	}
+
+	// p + T has a preimage for about half of the T, we pick one uniformly among them
+	var candidates []fr.Element
+	for i := range ell.torsion {
+		q.add(&mp, &ell.torsion[i])
+		if u, ok := elligatorInverse(&q); ok {
+			candidates = append(candidates, u)
+		}
+	}
+	if len(candidates) == 0 {
+		return nil, ErrNoUniformEncoding
+	}
+	r, err := randInt(randReader, big.NewInt(int64(2*len(candidates))))
+	if err != nil {
+		return nil, err
+	}
+
+	// u or -u, plus a random multiple of the field order
+	u := candidates[r.Uint64()/2]
+	if r.Bit(0) == 1 {
+		u.Neg(&u)
+	}
+	var v, k big.Int
+	u.ToBigIntRegular(&v)
+	k.Lsh(big.NewInt(1), 8*SizeOfUniformEncoding)
+	k.Sub(&k, &v).Div(&k, fr.Modulus())
+	if r, err = randInt(randReader, &k); err != nil {
+		return nil, err
+	}
+	r.Mul(r, fr.Modulus()).Add(r, &v)
+	return r.FillBytes(make([]byte, SizeOfUniformEncoding)), nil
+}
+
+// DecodeUniform returns the point encoded by EncodeUniform. Any string of
+// SizeOfUniformEncoding bytes decodes to a point of the prime order subgroup.
+func DecodeUniform(buf []byte) (PointAffine, error) {
+	ellOnce.Do(initElligatorParams)
+
+	var p PointAffine
+	if len(buf) != SizeOfUniformEncoding {
+		return p, ErrInvalidUniformSize
+	}
+	var v big.Int
+	var u fr.Element
+	v.SetBytes(buf)
+	u.SetBigInt(&v)
+	q := elligator(&u)
+
+	// clear the small order component: p ← [h⋅(h⁻¹ mod n)]q
+	q.scalarMul(&q, &ell.cofactor)
+	p, _ = q.toEdwards() // [h]q is in the prime order subgroup, hence affine
+	if !q.infinity {
+		p.ScalarMultiplication(&p, &ell.cofactorInv)
+	}
+	return p, nil
+}
+
+// montPoint is a point of the curve y² = x³ + (J/K)x² + x/K², isomorphic to the
+// Montgomery curve Kt² = s³ + Js² + s with (s, t) = (K⋅x, K⋅y). Unlike on the
+// twisted Edwards curve, all the points of small order are affine in this model.
+type montPoint struct {
+	x, y     fr.Element
+	infinity bool
+}
+
+// elligator returns the image of u by the Elligator 2 map, in constant time
+// https://datatracker.ietf.org/doc/html/rfc9380#appendix-G.2.1
+func elligator(u *fr.Element) montPoint {
+	var one, tv1, x1, x2, gx1, gx2, y2, minusY fr.Element
+	var q montPoint
+	one.SetOne()
+
+	tv1.Square(u).Mul(&tv1, &ell.z)
+	tv1.Add(&tv1, &one)
+	e1 := boolToInt(tv1.IsZero()) // Z⋅u² == -1
+	tv1.Sub(&tv1, &one)
+	tv1.Select(e1, &tv1, &ell.zero)
+	x1.Add(&tv1, &one)
+	invCT(&x1, &x1)
+	x1.Mul(&x1, &ell.c1).Neg(&x1) // x1 = -(J/K) / (1 + Z⋅u²)
+	gx1.Add(&x1, &ell.c1).Mul(&gx1, &x1).Add(&gx1, &ell.c2).Mul(&gx1, &x1)
+	x2.Add(&x1, &ell.c1).Neg(&x2)
+	gx2.Mul(&tv1, &gx1)
+	e2 := isSquareCT(&gx1)
+	q.x.Select(e2, &x2, &x1)
+	y2.Select(e2, &gx2, &gx1)
+	sqrtCT(&q.y, &y2)
+	e3 := sgn0(&q.y)
+	minusY.Neg(&q.y)
+	q.y.Select(e2^e3, &q.y, &minusY)
+	return q
+}
+
+// elligatorInverse returns the u such that sgn0(u) = 0 and elligator(u) = q,
+// and false if q has no preimage, in constant time
+func elligatorInverse(q *montPoint) (fr.Element, bool) {
+	// if sgn0(y) = 1, x = x1 and u² = -(x + J/K) / (Z⋅x),
+	// otherwise x = x2 and u² = -x / (Z⋅(x + J/K))
+	var xA, n, d, u, minusU fr.Element
+	xA.Add(&q.x, &ell.c1)
+	e := sgn0(&q.y)
+	n.Select(e, &q.x, &xA)
+	d.Select(e, &xA, &q.x)
+	d.Mul(&d, &ell.z)
+	invCT(&d, &d)
+	n.Mul(&n, &d).Neg(&n)
+	ok := isSquareCT(&n)
+	sqrtCT(&u, &n)
+	minusU.Neg(&u)
+	u.Select(sgn0(&u), &u, &minusU)
+
+	r := elligator(&u)
+	ok &= boolToInt(r.x.Equal(&q.x)) & boolToInt(r.y.Equal(&q.y))
+	ok &= 1 - boolToInt(q.infinity)
+	return u, ok == 1
+}
+
+// toEdwardsCT returns (s/t, (s-1)/(s+1)), or (0, 1) if t = 0 or s = -1, in constant time
+// https://datatracker.ietf.org/doc/html/rfc9380#section-6.8.2
+func (q *montPoint) toEdwardsCT() PointAffine {
+	var one, s, t, sm1, sp1 fr.Element
+	one.SetOne()
+	s.Mul(&q.x, &ell.k)
+	t.Mul(&q.y, &ell.k)
+	sm1.Sub(&s, &one)
+	sp1.Add(&s, &one)
+	e := boolToInt(t.IsZero()) | boolToInt(sp1.IsZero())
+
+	var p PointAffine
+	invCT(&t, &t)
+	invCT(&sp1, &sp1)
+	p.X.Mul(&s, &t)
+	p.Y.Mul(&sm1, &sp1)
+	p.X.Select(e, &p.X, &ell.zero)
+	p.Y.Select(e, &p.Y, &one)
+	return p
+}
+
+// toEdwards returns the image of q on the twisted Edwards curve, and false if it
+// is a point at infinity of the twisted Edwards curve
+func (q *montPoint) toEdwards() (PointAffine, bool) {
+	var p PointAffine
+	if q.infinity {
+		p.setInfinity()
+		return p, true
+	}
+	if q.x.IsZero() && q.y.IsZero() {
+		p.Y.SetOne().Neg(&p.Y)
+		return p, true
+	}
+	var one, s, sp1 fr.Element
+	one.SetOne()
+	s.Mul(&q.x, &ell.k)
+	sp1.Add(&s, &one)
+	if q.y.IsZero() || sp1.IsZero() {
+		return p, false
+	}
+	p.X.Mul(&q.y, &ell.k).Div(&s, &p.X)
+	p.Y.Sub(&s, &one).Div(&p.Y, &sp1)
+	return p, true
+}
+
+// fromEdwards sets q to the image of p, which must be on the curve
+func (q *montPoint) fromEdwards(p *PointAffine) *montPoint {
+	q.infinity = p.IsZero()
+	if q.infinity {
+		return q
+	}
+	if p.X.IsZero() {
+		// (0, -1)
+		q.x.SetZero()
+		q.y.SetZero()
+		return q
+	}
+	var one, num fr.Element
+	one.SetOne()
+	num.Add(&one, &p.Y)
+	q.x.Sub(&one, &p.Y)
+	q.x.Div(&num, &q.x)
+	q.y.Div(&q.x, &p.X)
+	q.x.Mul(&q.x, &ell.kInv)
+	q.y.Mul(&q.y, &ell.kInv)
+	return q
+}
+
+func (q *montPoint) equal(a *montPoint) bool {
+	if q.infinity || a.infinity {
+		return q.infinity == a.infinity
+	}
+	return q.x.Equal(&a.x) && q.y.Equal(&a.y)
+}
+
+// add sets q to a+b with the chord and tangent law on y² = x³ + (J/K)x² + x/K²
+func (q *montPoint) add(a, b *montPoint) *montPoint {
+	if a.infinity {
+		*q = *b
+		return q
+	}
+	if b.infinity {
+		*q = *a
+		return q
+	}
+	var l, t, x fr.Element
+	if a.x.Equal(&b.x) {
+		t.Add(&a.y, &b.y)
+		if t.IsZero() {
+			q.infinity = true
+			return q
+		}
+		// λ = (3x² + 2(J/K)x + 1/K²) / 2y
+		l.Square(&a.x)
+		t.Double(&l).Add(&t, &l)
+		l.Mul(&a.x, &ell.c1).Double(&l)
+		t.Add(&t, &l).Add(&t, &ell.c2)
+		l.Double(&a.y)
+		l.Div(&t, &l)
+	} else {
+		// λ = (y₂ - y₁) / (x₂ - x₁)
+		t.Sub(&b.y, &a.y)
+		l.Sub(&b.x, &a.x)
+		l.Div(&t, &l)
+	}
+	// x₃ = λ² - J/K - x₁ - x₂, y₃ = λ(x₁ - x₃) - y₁
+	x.Square(&l).Sub(&x, &ell.c1).Sub(&x, &a.x).Sub(&x, &b.x)
+	t.Sub(&a.x, &x).Mul(&t, &l).Sub(&t, &a.y)
+	q.x, q.y, q.infinity = x, t, false
+	return q
+}
+
+// scalarMul sets q to [s]a with a double-and-add
+func (q *montPoint) scalarMul(a *montPoint, s *big.Int) *montPoint {
+	var res montPoint
+	base := *a
+	res.infinity = true
+	for i := s.BitLen() - 1; i >= 0; i-- {
+		res.add(&res, &res)
+		if s.Bit(i) == 1 {
+			res.add(&res, &base)
+		}
+	}
+	*q = res
+	return q
+}
+
+var (
+	ellOnce sync.Once
+	ell     elligatorParams
+)
+
+type elligatorParams struct {
+	zero, z, k, kInv, c1, c2 fr.Element // 0, Z non square, K, 1/K, J/K, 1/K²
+	legendreExp              big.Int    // (q-1)/2
+
+	// constants of the constant time square root, https://datatracker.ietf.org/doc/html/rfc9380#appendix-I.4
+	sqrtC1 uint64
+	sqrtC3 big.Int
+	sqrtC6 fr.Element
+
+	cofactor, cofactorInv big.Int
+	torsion               []montPoint // points of order dividing the cofactor
+}
+
+func initElligatorParams() {
+	initOnce.Do(initCurveParams)
+	q := fr.Modulus()
+
+	ell.legendreExp.Rsh(q, 1)
+
+	// Z: first non square among 1, -1, 2, -2, ...
+	for ctr := uint64(1); ; ctr++ {
+		ell.z.SetUint64(ctr)
+		if ell.z.Legendre() == -1 {
+			break
+		}
+		ell.z.Neg(&ell.z)
+		if ell.z.Legendre() == -1 {
+			break
+		}
+	}
+
+	// K = 4/(a-d), J = 2(a+d)/(a-d)
+	var aMinusD, j fr.Element
+	aMinusD.Sub(&curveParams.A, &curveParams.D)
+	ell.kInv.SetUint64(4).Inverse(&ell.kInv).Mul(&ell.kInv, &aMinusD)
+	ell.k.Inverse(&ell.kInv)
+	j.Add(&curveParams.A, &curveParams.D).Double(&j).Div(&j, &aMinusD)
+	ell.c1.Mul(&j, &ell.kInv)
+	ell.c2.Square(&ell.kInv)
+
+	// square root constants
+	var c2, qm1 big.Int
+	qm1.Sub(q, big.NewInt(1))
+	ell.sqrtC1 = uint64(qm1.TrailingZeroBits())
+	c2.Rsh(&qm1, uint(ell.sqrtC1))
+	ell.sqrtC3.Sub(&c2, big.NewInt(1)).Rsh(&ell.sqrtC3, 1)
+	ell.sqrtC6.Exp(ell.z, &c2)
+
+	curveParams.Cofactor.ToBigIntRegular(&ell.cofactor)
+	ell.cofactorInv.ModInverse(&ell.cofactor, &curveParams.Order)
+
+	// the points of order dividing h are [n]P for P on the curve, we collect
+	// them until the subgroup they generate has h elements
+	ell.torsion = []montPoint{{infinity: true}}
+	for i := uint64(1); len(ell.torsion) < int(ell.cofactor.Uint64()); i++ {
+		var u fr.Element
+		u.SetUint64(i)
+		p := elligator(&u)
+		p.scalarMul(&p, &curveParams.Order)
+		for _, t := range ell.torsion {
+			var s montPoint
+			s.add(&p, &t)
+			if !containsPoint(ell.torsion, &s) {
+				ell.torsion = append(ell.torsion, s)
+			}
+		}
+	}
+}
+
+func containsPoint(points []montPoint, p *montPoint) bool {
+	for i := range points {
+		if points[i].equal(p) {
+			return true
+		}
+	}
+	return false
+}
+
+// invCT sets z to x⁻¹, or 0 if x = 0, in constant time
+func invCT(z, x *fr.Element) {
+	var e big.Int
+	e.Sub(fr.Modulus(), big.NewInt(2))
+	z.Exp(*x, &e)
+}
+
+// isSquareCT returns 1 if x is a square (including 0), 0 otherwise, in constant time
+func isSquareCT(x *fr.Element) int {
+	var l fr.Element
+	l.Exp(*x, &ell.legendreExp)
+	return boolToInt(l.IsOne()) | boolToInt(l.IsZero())
+}
+
+// sqrtCT sets z to a square root of x, which must be a square, in constant time
+// https://datatracker.ietf.org/doc/html/rfc9380#appendix-I.4 with v = 1
+func sqrtCT(z, x *fr.Element) {
+	var tv1, tv2, tv3, tv4, tv5 fr.Element
+	tv1.Set(&ell.sqrtC6)
+	tv2.Exp(*x, &ell.sqrtC3)
+	tv3.Mul(&tv2, x)    // x^((c2+1)/2)
+	tv4.Mul(&tv3, &tv2) // x^c2
+
+	for i := ell.sqrtC1; i >= 2; i-- {
+		var e big.Int
+		e.Lsh(big.NewInt(1), uint(i-2))
+		tv5.Exp(tv4, &e)
+		e1 := boolToInt(tv5.IsOne())
+		tv2.Mul(&tv3, &tv1)
+		tv1.Square(&tv1)
+		tv5.Mul(&tv4, &tv1)
+		tv3.Select(e1, &tv2, &tv3)
+		tv4.Select(e1, &tv5, &tv4)
+	}
+	z.Set(&tv3)
+}
+
+// sgn0 returns the parity of x, https://datatracker.ietf.org/doc/html/rfc9380#section-4.1
+func sgn0(x *fr.Element) int {
+	return int(x.Sign0())
+}
+
+func boolToInt(b bool) int {
+	var r int
+	if b {
+		r = 1
+	}
+	return r
+}
+
+// randInt returns a uniform integer in [0, max)
+func randInt(r io.Reader, max *big.Int) (*big.Int, error) {
+	var v big.Int
+	buf := make([]byte, (max.BitLen()+7)/8+16)
+	if _, err := io.ReadFull(r, buf); err != nil {
+		return nil, err
+	}
+	return v.SetBytes(buf).Mod(&v, max), nil
+}
@@ -0,0 +1,282 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package twistededwards
+
+import (
+	"errors"
+	"math/big"
+	"math/bits"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
+	"github.com/consensys/gnark-crypto/internal/parallel"
+)
+
+// maxC largest window size of the bucket method
+const maxC = 16
+
+// MultiExp computes the multi-exponentiation ∑ scalars[i]⋅points[i] and stores the result in p.
+//
+// It uses the bucket method (Pippenger), the windows being processed in parallel with
+// config.NbTasks go routines. The scalars are reduced modulo the order of the prime order
+// subgroup, so the points must be in the subgroup. config.ScalarsMont and config.Arena
+// are ignored.
+func (p *PointAffine) MultiExp(points []PointAffine, scalars []big.Int, config ecc.MultiExpConfig) (*PointAffine, error) {
+	var _p PointProj
+	if _, err := _p.MultiExp(points, scalars, config); err != nil {
+		return nil, err
+	}
+	p.FromProj(&_p)
+	return p, nil
+}
+
+// MultiExp computes the multi-exponentiation ∑ scalars[i]⋅points[i] and stores the result in p.
+// See PointAffine.MultiExp.
+func (p *PointProj) MultiExp(points []PointAffine, scalars []big.Int, config ecc.MultiExpConfig) (*PointProj, error) {
+	if len(points) != len(scalars) {
+		return nil, errors.New("len(points) != len(scalars)")
+	}
+	nbTasks := config.NbTasks
+	if nbTasks <= 0 {
+		nbTasks = parallel.MaxProcs()
+	} else if nbTasks > 1024 {
+		return nil, errors.New("invalid config: config.NbTasks > 1024")
+	}
+
+	curve := GetEdwardsCurve()
+	nbBits := curve.Order.BitLen()
+	c := int(config.C)
+	if c == 0 {
+		c = bestC(len(points), nbBits)
+	} else if c > maxC {
+		return nil, errors.New("invalid config: config.C > 16")
+	}
+
+	// reduced scalars, as little endian words
+	words := make([][]big.Word, len(scalars))
+	parallel.Execute(len(scalars), func(start, end int) {
+		for i := start; i < end; i++ {
+			if scalars[i].Sign() >= 0 && scalars[i].Cmp(&curve.Order) < 0 {
+				words[i] = scalars[i].Bits()
+			} else {
+				words[i] = new(big.Int).Mod(&scalars[i], &curve.Order).Bits()
+			}
+		}
+	}, nbTasks)
+
+	nbWindows := (nbBits + c - 1) / c
+	windows := make([]PointProj, nbWindows)
+	parallel.Execute(nbWindows, func(start, end int) {
+		buckets := make([]PointProj, (1<<c)-1)
+		for j := start; j < end; j++ {
+			msmWindow(&windows[j], buckets, points, words, j*c, c)
+		}
+	}, nbTasks)
+
+	// ∑ 2^{jc}⋅windows[j]
+	p.Set(&windows[nbWindows-1])
+	for j := nbWindows - 2; j >= 0; j-- {
+		for k := 0; k < c; k++ {
+			p.Double(p)
+		}
+		p.Add(p, &windows[j])
+	}
+	return p, nil
+}
+
+// msmWindow sets res to ∑ dᵢ⋅points[i], dᵢ being the c bits of the i-th scalar starting at bit start
+func msmWindow(res *PointProj, buckets []PointProj, points []PointAffine, words [][]big.Word, start, c int) {
+	empty := make([]bool, len(buckets))
+	for k := range buckets {
+		empty[k] = true
+	}
+	for i := range points {
+		d := window(words[i], start, c)
+		if d == 0 {
+			continue
+		}
+		if empty[d-1] {
+			buckets[d-1].FromAffine(&points[i])
+			empty[d-1] = false
+		} else {
+			buckets[d-1].MixedAdd(&buckets[d-1], &points[i])
+		}
+	}
+
+	// ∑ k⋅buckets[k-1], with running sums
+	var sum PointProj
+	sum.setInfinity()
+	res.setInfinity()
+	for k := len(buckets) - 1; k >= 0; k-- {
+		if !empty[k] {
+			sum.Add(&sum, &buckets[k])
+		}
+		res.Add(res, &sum)
+	}
+}
+
+// window returns the c bits of the integer with little endian words w, starting at bit start
+func window(w []big.Word, start, c int) uint64 {
+	i, shift := start/bits.UintSize, start%bits.UintSize
+	if i >= len(w) {
+		return 0
+	}
+	d := uint64(w[i]) >> shift
+	if shift+c > bits.UintSize && i+1 < len(w) {
+		d |= uint64(w[i+1]) << (bits.UintSize - shift)
+	}
+	return d & ((1 << c) - 1)
+}
+
+// bestC returns the window size minimizing the number of additions of the bucket
+// method, ⌈nbBits/c⌉⋅(nbPoints + 2^{c+1})
+func bestC(nbPoints, nbBits int) int {
+	best, bestCost := 1, -1
+	for c := 1; c <= maxC; c++ {
+		cost := ((nbBits + c - 1) / c) * (nbPoints + (1 << (c + 1)))
+		if bestCost < 0 || cost < bestCost {
+			best, bestCost = c, cost
+		}
+	}
+	return best
+}
+
+// BatchFromProj converts points in projective coordinates to affine coordinates,
+// with a single field inversion
+func BatchFromProj(points []PointProj) []PointAffine {
+	zs := make([]fr.Element, len(points))
+	for i := range points {
+		zs[i] = points[i].Z
+	}
+	zs = fr.BatchInvert(zs)
+	res := make([]PointAffine, len(points))
+	parallel.Execute(len(points), func(start, end int) {
+		for i := start; i < end; i++ {
+			res[i].X.Mul(&points[i].X, &zs[i])
+			res[i].Y.Mul(&points[i].Y, &zs[i])
+		}
+	})
+	return res
+}
+
+// BatchFromExtended converts points in extended coordinates to affine coordinates,
+// with a single field inversion
+func BatchFromExtended(points []PointExtended) []PointAffine {
+	zs := make([]fr.Element, len(points))
+	for i := range points {
+		zs[i] = points[i].Z
+	}
+	zs = fr.BatchInvert(zs)
+	res := make([]PointAffine, len(points))
+	parallel.Execute(len(points), func(start, end int) {
+		for i := start; i < end; i++ {
+			res[i].X.Mul(&points[i].X, &zs[i])
+			res[i].Y.Mul(&points[i].Y, &zs[i])
+		}
+	})
+	return res
+}
+
+// BatchAdd returns the sums a[i] + b[i] in affine coordinates, the denominators of the
+// affine addition formulas being inverted together
+//
+//	x₃ = (x₁y₂ + y₁x₂)/(1 + d⋅x₁x₂y₁y₂), y₃ = (y₁y₂ - a⋅x₁x₂)/(1 - d⋅x₁x₂y₁y₂)
+func BatchAdd(a, b []PointAffine) []PointAffine {
+	if len(a) != len(b) {
+		panic("BatchAdd: len(a) != len(b)")
+	}
+	n := len(a)
+	curve := GetEdwardsCurve()
+
+	// denominators, then their inverses
+	den := make([]fr.Element, 2*n)
+	xx := make([]fr.Element, n)
+	yy := make([]fr.Element, n)
+	var one fr.Element
+	one.SetOne()
+	parallel.Execute(n, func(start, end int) {
+		var dt fr.Element
+		for i := start; i < end; i++ {
+			xx[i].Mul(&a[i].X, &b[i].X)
+			yy[i].Mul(&a[i].Y, &b[i].Y)
+			dt.Mul(&xx[i], &yy[i]).Mul(&dt, &curve.D)
+			den[2*i].Add(&one, &dt)
+			den[2*i+1].Sub(&one, &dt)
+		}
+	})
+	den = fr.BatchInvert(den)
+
+	res := make([]PointAffine, n)
+	parallel.Execute(n, func(start, end int) {
+		var t fr.Element
+		for i := start; i < end; i++ {
+			res[i].X.Mul(&a[i].X, &b[i].Y)
+			t.Mul(&a[i].Y, &b[i].X)
+			res[i].X.Add(&res[i].X, &t).Mul(&res[i].X, &den[2*i])
+
+			t.Set(&xx[i])
+			mulByA(&t)
+			res[i].Y.Sub(&yy[i], &t).Mul(&res[i].Y, &den[2*i+1])
+		}
+	})
+	return res
+}
+
+// IsInSubGroup returns true if p is on the curve and in the prime order subgroup
+func (p *PointAffine) IsInSubGroup() bool {
+	if !p.IsOnCurve() {
+		return false
+	}
+	// [r]p = 0, with a double-and-add: the scalar multiplication reduces
+	// the scalar modulo r when it uses the endomorphism
+	curve := GetEdwardsCurve()
+	var res PointProj
+	res.setInfinity()
+	for i := curve.Order.BitLen() - 1; i >= 0; i-- {
+		res.Double(&res)
+		if curve.Order.Bit(i) == 1 {
+			res.MixedAdd(&res, p)
+		}
+	}
+	return res.IsZero()
+}
+
+// BatchIsOnCurve returns true if all the points are on the curve, the checks being run in parallel
+func BatchIsOnCurve(points []PointAffine) bool {
+	return batchCheck(points, (*PointAffine).IsOnCurve)
+}
+
+// BatchIsInSubGroup returns true if all the points are on the curve and in the prime order
+// subgroup, the checks being run in parallel
+func BatchIsInSubGroup(points []PointAffine) bool {
+	return batchCheck(points, (*PointAffine).IsInSubGroup)
+}
+
+func batchCheck(points []PointAffine, check func(*PointAffine) bool) bool {
+	failures := make([]bool, len(points))
+	parallel.Execute(len(points), func(start, end int) {
+		for i := start; i < end; i++ {
+			failures[i] = !check(&points[i])
+		}
+	})
+	for _, f := range failures {
+		if f {
+			return false
+		}
+	}
+	return true
+}
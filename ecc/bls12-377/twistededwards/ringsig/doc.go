@@ -0,0 +1,27 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+// Package ringsig provides linkable ring signatures (LSAG) on bls12-377's twistededwards curve.
+//
+// A ring signature proves that the signer holds the private key of one of the
+// public keys of a ring, without revealing which one. Each signature carries a
+// key image I = x·Hₚ(P), which only depends on the signer's key pair: two
+// signatures with the same key image were produced by the same key (see Linked).
+//
+// # See also
+//
+// https://eprint.iacr.org/2004/027.pdf
+package ringsig
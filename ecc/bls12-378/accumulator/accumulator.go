@@ -0,0 +1,377 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package accumulator
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bls12-378"
+	"github.com/consensys/gnark-crypto/ecc/bls12-378/fr"
+)
+
+var (
+	ErrMember         = errors.New("the element is in the accumulator")
+	ErrNotMember      = errors.New("the element is not in the accumulator")
+	ErrInvalidElement = errors.New("the element can't be accumulated")
+	ErrInvalidWitness = errors.New("invalid witness")
+	ErrInvalidUpdate  = errors.New("invalid update")
+	ErrRevoked        = errors.New("the element of the witness was deleted")
+)
+
+// PublicKey manager public key, [s]G₂
+type PublicKey struct {
+	SG2 bls12378.G2Affine
+}
+
+// PrivateKey manager private key, the trapdoor s
+type PrivateKey struct {
+	PublicKey PublicKey // copy of the associated public key
+	scalar    fr.Element
+}
+
+// GenerateKey generates a manager key pair
+func GenerateKey() (*PrivateKey, error) {
+	var priv PrivateKey
+	for priv.scalar.IsZero() {
+		if _, err := priv.scalar.SetRandom(); err != nil {
+			return nil, err
+		}
+	}
+	var b big.Int
+	_, _, _, g2 := bls12378.Generators()
+	priv.PublicKey.SG2.ScalarMultiplication(&g2, priv.scalar.ToBigIntRegular(&b))
+	return &priv, nil
+}
+
+// Accumulator is the state of the manager: the accumulated set X and the
+// accumulator value V = [∏ₓ(s+x)]G₁.
+type Accumulator struct {
+	priv  *PrivateKey
+	set   map[fr.Element]struct{}
+	prod  fr.Element // ∏ₓ(s+x)
+	value bls12378.G1Affine
+}
+
+// Update describes the change of the accumulator value when elements are added or
+// deleted, so that witness holders can update their witnesses.
+type Update struct {
+	// Added is true if the elements were added, false if they were deleted
+	Added bool
+
+	// Elements added or deleted, in order
+	Elements []fr.Element
+
+	// Values[0] is the accumulator value before the update, and Values[i+1]
+	// the value after Elements[i] was processed
+	Values []bls12378.G1Affine
+}
+
+// MembershipWitness proves that Element is in the accumulator
+type MembershipWitness struct {
+	Element fr.Element
+	W       bls12378.G1Affine // [1/(s+y)]V
+}
+
+// NonMembershipWitness proves that Element is not in the accumulator
+type NonMembershipWitness struct {
+	Element fr.Element
+	W       bls12378.G1Affine // [(∏ₓ(s+x) - R)/(s+y)]G₁
+	R       fr.Element        // ∏ₓ(x-y)
+}
+
+// NewAccumulator returns the accumulator of the given (distinct) elements
+func (priv *PrivateKey) NewAccumulator(elements ...fr.Element) (*Accumulator, error) {
+	_, _, g1, _ := bls12378.Generators()
+	acc := &Accumulator{
+		priv:  priv,
+		set:   make(map[fr.Element]struct{}, len(elements)),
+		prod:  fr.One(),
+		value: g1,
+	}
+	if _, err := acc.Add(elements...); err != nil {
+		return nil, err
+	}
+	return acc, nil
+}
+
+// Value returns the accumulator value V
+func (acc *Accumulator) Value() bls12378.G1Affine {
+	return acc.value
+}
+
+// Len returns the number of accumulated elements
+func (acc *Accumulator) Len() int {
+	return len(acc.set)
+}
+
+// Contains returns true if x is accumulated
+func (acc *Accumulator) Contains(x *fr.Element) bool {
+	_, ok := acc.set[*x]
+	return ok
+}
+
+// Add accumulates the elements, which must not be accumulated yet, and returns the
+// corresponding update. The new values are computed with one batch scalar multiplication.
+func (acc *Accumulator) Add(elements ...fr.Element) (Update, error) {
+	// s+y for each element
+	factors := make([]fr.Element, len(elements))
+	added := make(map[fr.Element]struct{}, len(elements))
+	for i := range elements {
+		if _, ok := acc.set[elements[i]]; ok {
+			return Update{}, ErrMember
+		}
+		if _, ok := added[elements[i]]; ok {
+			return Update{}, ErrMember
+		}
+		added[elements[i]] = struct{}{}
+		factors[i].Add(&acc.priv.scalar, &elements[i])
+		if factors[i].IsZero() {
+			return Update{}, ErrInvalidElement
+		}
+	}
+
+	update := acc.update(true, elements, factors)
+	for k := range added {
+		acc.set[k] = struct{}{}
+	}
+	return update, nil
+}
+
+// Delete removes the elements, which must be accumulated, and returns the
+// corresponding update. The new values are computed with one batch scalar multiplication.
+func (acc *Accumulator) Delete(elements ...fr.Element) (Update, error) {
+	deleted := make(map[fr.Element]struct{}, len(elements))
+	factors := make([]fr.Element, len(elements))
+	for i := range elements {
+		if _, ok := acc.set[elements[i]]; !ok {
+			return Update{}, ErrNotMember
+		}
+		if _, ok := deleted[elements[i]]; ok {
+			return Update{}, ErrNotMember
+		}
+		deleted[elements[i]] = struct{}{}
+		factors[i].Add(&acc.priv.scalar, &elements[i])
+	}
+
+	// 1/(s+y) for each element
+	factors = fr.BatchInvert(factors)
+	update := acc.update(false, elements, factors)
+	for k := range deleted {
+		delete(acc.set, k)
+	}
+	return update, nil
+}
+
+// update multiplies the product of the accumulator by each factor in turn, and returns
+// the update listing the intermediate values
+func (acc *Accumulator) update(added bool, elements, factors []fr.Element) Update {
+	u := Update{
+		Added:    added,
+		Elements: make([]fr.Element, len(elements)),
+		Values:   make([]bls12378.G1Affine, len(elements)+1),
+	}
+	copy(u.Elements, elements)
+	u.Values[0] = acc.value
+	if len(elements) == 0 {
+		return u
+	}
+
+	// batch scalar multiplication expects scalars in regular form
+	prods := make([]fr.Element, len(factors))
+	for i := range factors {
+		acc.prod.Mul(&acc.prod, &factors[i])
+		prods[i] = acc.prod
+		prods[i].FromMont()
+	}
+	_, _, g1, _ := bls12378.Generators()
+	copy(u.Values[1:], bls12378.BatchScalarMultiplicationG1(&g1, prods))
+	acc.value = u.Values[len(elements)]
+
+	return u
+}
+
+// MembershipWitness returns a witness that x is accumulated
+func (acc *Accumulator) MembershipWitness(x fr.Element) (MembershipWitness, error) {
+	if !acc.Contains(&x) {
+		return MembershipWitness{}, ErrNotMember
+	}
+
+	// [∏ₓ(s+x)/(s+y)]G₁
+	var e fr.Element
+	e.Add(&acc.priv.scalar, &x).
+		Inverse(&e).
+		Mul(&e, &acc.prod)
+
+	res := MembershipWitness{Element: x}
+	var b big.Int
+	_, _, g1, _ := bls12378.Generators()
+	res.W.ScalarMultiplication(&g1, e.ToBigIntRegular(&b))
+	return res, nil
+}
+
+// NonMembershipWitness returns a witness that x is not accumulated
+func (acc *Accumulator) NonMembershipWitness(x fr.Element) (NonMembershipWitness, error) {
+	if acc.Contains(&x) {
+		return NonMembershipWitness{}, ErrMember
+	}
+
+	res := NonMembershipWitness{Element: x}
+
+	// r = ∏ₓ(x-y), non zero as y ∉ X
+	res.R.SetOne()
+	var d fr.Element
+	for k := range acc.set {
+		d.Sub(&k, &x)
+		res.R.Mul(&res.R, &d)
+	}
+
+	// [(∏ₓ(s+x) - r)/(s+y)]G₁
+	var e fr.Element
+	e.Add(&acc.priv.scalar, &x)
+	if e.IsZero() {
+		return NonMembershipWitness{}, ErrInvalidElement
+	}
+	e.Inverse(&e)
+	d.Sub(&acc.prod, &res.R)
+	e.Mul(&e, &d)
+
+	var b big.Int
+	_, _, g1, _ := bls12378.Generators()
+	res.W.ScalarMultiplication(&g1, e.ToBigIntRegular(&b))
+	return res, nil
+}
+
+// Update updates the witness after the accumulator update u.
+// It fails with ErrRevoked if the element of the witness was deleted.
+func (w *MembershipWitness) Update(u *Update) error {
+	_, err := updateWitness(&w.W, &w.Element, u, ErrRevoked)
+	return err
+}
+
+// Update updates the witness after the accumulator update u.
+// It fails with ErrMember if the element of the witness was added.
+func (w *NonMembershipWitness) Update(u *Update) error {
+	scale, err := updateWitness(&w.W, &w.Element, u, ErrMember)
+	if err != nil {
+		return err
+	}
+	w.R.Mul(&w.R, &scale)
+	return nil
+}
+
+// updateWitness applies u to the witness point w of y, and returns the factor by which
+// the r value of a non-membership witness must be multiplied.
+//
+// With dᵢ = yᵢ-y, adding yᵢ maps W to Vᵢ₋₁ + [dᵢ]W, and deleting yᵢ maps W to [1/dᵢ](W - Vᵢ),
+// for both kinds of witnesses. All the steps are folded in a single multi-scalar multiplication.
+func updateWitness(w *bls12378.G1Affine, y *fr.Element, u *Update, errZero error) (fr.Element, error) {
+	k := len(u.Elements)
+	if len(u.Values) != k+1 {
+		return fr.Element{}, ErrInvalidUpdate
+	}
+	if k == 0 {
+		return fr.One(), nil
+	}
+
+	d := make([]fr.Element, k)
+	for i := range d {
+		d[i].Sub(&u.Elements[i], y)
+		if d[i].IsZero() {
+			return fr.Element{}, errZero
+		}
+	}
+
+	// points = [W, V₀, …, Vₖ]
+	points := make([]bls12378.G1Affine, k+2)
+	points[0] = *w
+	copy(points[1:], u.Values)
+	scalars := make([]fr.Element, k+2)
+
+	c := fr.One()
+	if u.Added {
+		// Wₖ = [∏ᵢdᵢ]W + ∑ⱼ [∏ᵢ₌ⱼ₊₁ dᵢ]Vⱼ₋₁
+		for j := k; j >= 1; j-- {
+			scalars[j] = c
+			c.Mul(&c, &d[j-1])
+		}
+	} else {
+		// Wₖ = [1/∏ᵢdᵢ]W - ∑ⱼ [1/∏ᵢ₌ⱼ dᵢ]Vⱼ
+		d = fr.BatchInvert(d)
+		for j := k; j >= 1; j-- {
+			c.Mul(&c, &d[j-1])
+			scalars[j+1].Neg(&c)
+		}
+	}
+	scalars[0] = c
+
+	if _, err := w.MultiExp(points, scalars, ecc.MultiExpConfig{ScalarsMont: true}); err != nil {
+		return fr.Element{}, err
+	}
+	return c, nil
+}
+
+// Verify checks the membership witness against the accumulator value v, that is
+// e(W, [s+y]G₂) = e(V, G₂).
+func (pub *PublicKey) Verify(v *bls12378.G1Affine, w *MembershipWitness) error {
+	if !w.W.IsInSubGroup() {
+		return ErrInvalidWitness
+	}
+	var negV bls12378.G1Affine
+	negV.Neg(v)
+	return pub.pairingCheck(&w.Element, &w.W, &negV)
+}
+
+// VerifyNonMembership checks the non-membership witness against the accumulator value v,
+// that is r ≠ 0 and e(W, [s+y]G₂)⋅e([r]G₁, G₂) = e(V, G₂).
+func (pub *PublicKey) VerifyNonMembership(v *bls12378.G1Affine, w *NonMembershipWitness) error {
+	if w.R.IsZero() || !w.W.IsInSubGroup() {
+		return ErrInvalidWitness
+	}
+
+	// [r]G₁ - V
+	var b big.Int
+	_, _, g1, _ := bls12378.Generators()
+	var rhs bls12378.G1Affine
+	rhs.ScalarMultiplication(&g1, w.R.ToBigIntRegular(&b)).
+		Sub(&rhs, v)
+
+	return pub.pairingCheck(&w.Element, &w.W, &rhs)
+}
+
+// pairingCheck checks e(w, [s+y]G₂)⋅e(p, G₂) = 1
+func (pub *PublicKey) pairingCheck(y *fr.Element, w, p *bls12378.G1Affine) error {
+	// [s+y]G₂
+	var b big.Int
+	_, _, _, g2 := bls12378.Generators()
+	var q bls12378.G2Affine
+	q.ScalarMultiplication(&g2, y.ToBigIntRegular(&b)).
+		Add(&q, &pub.SG2)
+
+	ok, err := bls12378.PairingCheck(
+		[]bls12378.G1Affine{*w, *p},
+		[]bls12378.G2Affine{q, g2},
+	)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrInvalidWitness
+	}
+	return nil
+}
@@ -0,0 +1,96 @@
+//go:build dudect
+// +build dudect
+
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package bls12378
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-378/fp"
+	"github.com/consensys/gnark-crypto/ecc/bls12-378/fr"
+	"github.com/consensys/gnark-crypto/internal/dudect"
+)
+
+// the timing tests compare a fixed input (class 0) with random inputs (class 1); a random
+// input is drawn for both classes, so that the preparation of the inputs does not bias the measurements
+
+func TestTimingG1ScalarMultiplicationCT(t *testing.T) {
+	var fixed, s big.Int
+	fixed.Lsh(big.NewInt(1), fr.Bits-2)
+	var p G1Jac
+	prepare := func(class int) {
+		var r fr.Element
+		r.SetRandom()
+		r.ToBigIntRegular(&s)
+		if class == 0 {
+			s.Set(&fixed)
+		}
+	}
+	dudect.Assert(t, dudect.Config{Samples: 10000}, prepare, func() {
+		p.ScalarMultiplicationCT(&g1Gen, &s)
+	})
+}
+
+func TestTimingG2ScalarMultiplicationCT(t *testing.T) {
+	var fixed, s big.Int
+	fixed.Lsh(big.NewInt(1), fr.Bits-2)
+	var p G2Jac
+	prepare := func(class int) {
+		var r fr.Element
+		r.SetRandom()
+		r.ToBigIntRegular(&s)
+		if class == 0 {
+			s.Set(&fixed)
+		}
+	}
+	dudect.Assert(t, dudect.Config{Samples: 5000}, prepare, func() {
+		p.ScalarMultiplicationCT(&g2Gen, &s)
+	})
+}
+
+func TestTimingFpSelect(t *testing.T) {
+	var x0, x1, z fp.Element
+	x0.SetRandom()
+	x1.SetRandom()
+	var c int
+	prepare := func(class int) {
+		var r fp.Element
+		r.SetRandom()
+		c = int(r.Bit(0)) * class
+	}
+	dudect.Assert(t, dudect.Config{Samples: 100000, Inner: 100}, prepare, func() {
+		z.Select(c, &x0, &x1)
+	})
+}
+
+func TestTimingFrSelect(t *testing.T) {
+	var x0, x1, z fr.Element
+	x0.SetRandom()
+	x1.SetRandom()
+	var c int
+	prepare := func(class int) {
+		var r fr.Element
+		r.SetRandom()
+		c = int(r.Bit(0)) * class
+	}
+	dudect.Assert(t, dudect.Config{Samples: 100000, Inner: 100}, prepare, func() {
+		z.Select(c, &x0, &x1)
+	})
+}
@@ -0,0 +1,107 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package ecdh
+
+import (
+	"bytes"
+	"crypto/rand"
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-378/fr"
+)
+
+func TestKeyExchange(t *testing.T) {
+
+	alice, err := GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	bob, err := GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	secretAlice, err := alice.SharedSecret(&bob.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	secretBob, err := bob.SharedSecret(&alice.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(secretAlice, secretBob) {
+		t.Fatal("shared secrets differ")
+	}
+
+	keyAlice, err := alice.DeriveKey(&bob.PublicKey, []byte("test"), 32)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyBob, err := bob.DeriveKey(&alice.PublicKey, []byte("test"), 32)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(keyAlice, keyBob) {
+		t.Fatal("derived keys differ")
+	}
+	keyBob, err = bob.DeriveKey(&alice.PublicKey, []byte("other context"), 32)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Equal(keyAlice, keyBob) {
+		t.Fatal("derived keys should depend on the context")
+	}
+
+	// round trip through the compressed form
+	var pub PublicKey
+	if _, err = pub.SetBytes(alice.PublicKey.Bytes()); err != nil {
+		t.Fatal(err)
+	}
+	if !pub.A.Equal(&alice.PublicKey.A) {
+		t.Fatal("SetBytes(Bytes()) failed")
+	}
+}
+
+func TestInvalidKeys(t *testing.T) {
+
+	alice, err := GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// point at infinity
+	var pub PublicKey
+	if _, err = alice.SharedSecret(&pub); err != ErrInvalidPublicKey {
+		t.Fatal("expected ErrInvalidPublicKey")
+	}
+
+	// point not on the curve
+	pub.A.Set(&alice.PublicKey.A)
+	pub.A.Y.Double(&pub.A.Y)
+	if _, err = alice.SharedSecret(&pub); err != ErrInvalidPublicKey {
+		t.Fatal("expected ErrInvalidPublicKey")
+	}
+
+	// scalars out of range
+	if _, err = NewPrivateKey(big.NewInt(0)); err != ErrInvalidScalar {
+		t.Fatal("expected ErrInvalidScalar")
+	}
+	if _, err = NewPrivateKey(fr.Modulus()); err != ErrInvalidScalar {
+		t.Fatal("expected ErrInvalidScalar")
+	}
+}
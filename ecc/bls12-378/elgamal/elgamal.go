@@ -0,0 +1,265 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package elgamal
+
+import (
+	"crypto/rand"
+	"errors"
+	"io"
+	"math"
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-378"
+	"github.com/consensys/gnark-crypto/ecc/bls12-378/fr"
+)
+
+var (
+	ErrInvalidPublicKey    = errors.New("public key is not a point of the prime order subgroup")
+	ErrPlaintextOutOfRange = errors.New("plaintext is out of the range of the decryption table")
+	ErrInvalidCiphertext   = errors.New("invalid ciphertext")
+	ErrRandomnessReuse     = errors.New("randomness re-use detected")
+	ErrInvalidTableSize    = errors.New("decryption table size should be positive")
+)
+
+const sizeCiphertext = 2 * bls12378.SizeOfG1AffineCompressed
+
+// PublicKey ElGamal public key, H = xG
+type PublicKey struct {
+	H bls12378.G1Affine
+}
+
+// PrivateKey ElGamal private key
+type PrivateKey struct {
+	PublicKey PublicKey // copy of the associated public key
+	scalar    big.Int   // secret scalar x
+}
+
+// Ciphertext exponential ElGamal ciphertext (C1, C2) = (rG, mG+rH)
+type Ciphertext struct {
+	C1, C2 bls12378.G1Affine
+}
+
+// GenerateKey generates a public and private key pair
+func GenerateKey(r io.Reader) (*PrivateKey, error) {
+	s, err := randomScalar(r)
+	if err != nil {
+		return nil, err
+	}
+	var priv PrivateKey
+	_, _, g, _ := bls12378.Generators()
+	priv.scalar.Set(s)
+	priv.PublicKey.H.ScalarMultiplicationCT(&g, &priv.scalar)
+	return &priv, nil
+}
+
+// Validate returns ErrInvalidPublicKey if pub is not a point of the prime
+// order subgroup, or is the point at infinity.
+func (pub *PublicKey) Validate() error {
+	if pub.H.IsInfinity() || !pub.H.IsOnCurve() || !pub.H.IsInSubGroup() {
+		return ErrInvalidPublicKey
+	}
+	return nil
+}
+
+// Encrypt encrypts m for pub. The randomness is sampled from r for each call and
+// never exposed, so that it cannot be re-used across encryptions.
+func (pub *PublicKey) Encrypt(r io.Reader, m *big.Int) (Ciphertext, error) {
+	var res Ciphertext
+	if err := pub.Validate(); err != nil {
+		return res, err
+	}
+	k, err := randomScalar(r)
+	if err != nil {
+		return res, err
+	}
+
+	// C1 = kG, C2 = mG + kH, in constant time with respect to the secrets k and m
+	_, _, g, _ := bls12378.Generators()
+	var mm big.Int
+	mm.Mod(m, fr.Modulus())
+	var c1, c2, tmp bls12378.G1Jac
+	c1.FromAffine(&g)
+	c2.ScalarMultiplicationCT(&c1, &mm)
+	c1.ScalarMultiplicationCT(&c1, k)
+	tmp.FromAffine(&pub.H)
+	tmp.ScalarMultiplicationCT(&tmp, k)
+	c2.AddAssign(&tmp)
+	res.C1.FromJacobian(&c1)
+	res.C2.FromJacobian(&c2)
+
+	return res, nil
+}
+
+// Rerandomize returns a fresh encryption of the same message as c, by adding an
+// encryption of 0 to it.
+func (pub *PublicKey) Rerandomize(r io.Reader, c *Ciphertext) (Ciphertext, error) {
+	var res Ciphertext
+	zero, err := pub.Encrypt(r, big.NewInt(0))
+	if err != nil {
+		return res, err
+	}
+	res.Add(c, &zero)
+	return res, nil
+}
+
+// Add sets c to the encryption of the sum of the messages of a and b and returns c.
+//
+// The randomness of the result is the sum of the randomness of a and b.
+func (c *Ciphertext) Add(a, b *Ciphertext) *Ciphertext {
+	c.C1.Add(&a.C1, &b.C1)
+	c.C2.Add(&a.C2, &b.C2)
+	return c
+}
+
+// Sub sets c to the encryption of the difference of the messages of a and b and returns c.
+//
+// If a and b were encrypted with the same randomness, c.C1 is the point at
+// infinity and the result is not hiding; use CheckedSub to detect it.
+func (c *Ciphertext) Sub(a, b *Ciphertext) *Ciphertext {
+	c.C1.Sub(&a.C1, &b.C1)
+	c.C2.Sub(&a.C2, &b.C2)
+	return c
+}
+
+// CheckedSub is Sub, but returns ErrRandomnessReuse if a and b share the same
+// randomness, which would reveal the difference of the messages in the clear.
+func (c *Ciphertext) CheckedSub(a, b *Ciphertext) error {
+	if a.C1.Equal(&b.C1) {
+		return ErrRandomnessReuse
+	}
+	c.Sub(a, b)
+	return nil
+}
+
+// ScalarMultiplication sets c to the encryption of s times the message of a and returns c.
+func (c *Ciphertext) ScalarMultiplication(a *Ciphertext, s *big.Int) *Ciphertext {
+	c.C1.ScalarMultiplication(&a.C1, s)
+	c.C2.ScalarMultiplication(&a.C2, s)
+	return c
+}
+
+// Bytes returns the compressed encoding of the ciphertext
+func (c *Ciphertext) Bytes() []byte {
+	res := make([]byte, 0, sizeCiphertext)
+	b := c.C1.Bytes()
+	res = append(res, b[:]...)
+	b = c.C2.Bytes()
+	return append(res, b[:]...)
+}
+
+// SetBytes sets c from its compressed encoding, checking that both points are in
+// the prime order subgroup. It returns the number of bytes read.
+func (c *Ciphertext) SetBytes(buf []byte) (int, error) {
+	if len(buf) < sizeCiphertext {
+		return 0, ErrInvalidCiphertext
+	}
+	n, err := c.C1.SetBytes(buf)
+	if err != nil {
+		return n, err
+	}
+	m, err := c.C2.SetBytes(buf[n:])
+	return n + m, err
+}
+
+// DecryptToPoint returns mG, where m is the message encrypted in c. The multiplication by the
+// private key is done in constant time.
+func (priv *PrivateKey) DecryptToPoint(c *Ciphertext) bls12378.G1Affine {
+	var res bls12378.G1Affine
+	res.ScalarMultiplicationCT(&c.C1, &priv.scalar)
+	res.Sub(&c.C2, &res)
+	return res
+}
+
+// Decrypt returns the message encrypted in c, using table to solve the discrete
+// logarithm. It returns ErrPlaintextOutOfRange if the message is not in [0, table.Max()).
+func (priv *PrivateKey) Decrypt(c *Ciphertext, table *DecryptionTable) (uint64, error) {
+	p := priv.DecryptToPoint(c)
+	return table.discreteLog(&p)
+}
+
+// DecryptionTable precomputed baby steps to decrypt messages in [0, m²),
+// with m the number of baby steps.
+type DecryptionTable struct {
+	m         uint64
+	babySteps map[[bls12378.SizeOfG1AffineCompressed]byte]uint64
+
+	// giantStep = -mG
+	giantStep bls12378.G1Jac
+}
+
+// NewDecryptionTable returns a table to decrypt messages in [0, max). It holds
+// about √max points.
+func NewDecryptionTable(max uint64) (*DecryptionTable, error) {
+	if max == 0 {
+		return nil, ErrInvalidTableSize
+	}
+	m := uint64(math.Ceil(math.Sqrt(float64(max))))
+	for m*m < max {
+		m++
+	}
+
+	var res DecryptionTable
+	res.m = m
+	res.babySteps = make(map[[bls12378.SizeOfG1AffineCompressed]byte]uint64, m)
+
+	// jG for j in [0, m)
+	g, _, gAff, _ := bls12378.Generators()
+	points := make([]bls12378.G1Jac, m)
+	points[0].FromAffine(&bls12378.G1Affine{})
+	for j := uint64(1); j < m; j++ {
+		points[j].Set(&points[j-1]).AddMixed(&gAff)
+	}
+	affine := bls12378.BatchJacobianToAffineG1(points)
+	for j := range affine {
+		res.babySteps[affine[j].Bytes()] = uint64(j)
+	}
+
+	res.giantStep.ScalarMultiplication(&g, new(big.Int).SetUint64(m)).Neg(&res.giantStep)
+
+	return &res, nil
+}
+
+// Max returns the bound on the messages the table can decrypt
+func (t *DecryptionTable) Max() uint64 {
+	return t.m * t.m
+}
+
+// discreteLog returns x in [0, m²) such that xG = p
+func (t *DecryptionTable) discreteLog(p *bls12378.G1Affine) (uint64, error) {
+	var cur bls12378.G1Jac
+	var curAff bls12378.G1Affine
+	cur.FromAffine(p)
+	for i := uint64(0); i < t.m; i++ {
+		curAff.FromJacobian(&cur)
+		if j, ok := t.babySteps[curAff.Bytes()]; ok {
+			return i*t.m + j, nil
+		}
+		cur.AddAssign(&t.giantStep)
+	}
+	return 0, ErrPlaintextOutOfRange
+}
+
+// randomScalar returns a scalar sampled uniformly in [1, r)
+func randomScalar(r io.Reader) (*big.Int, error) {
+	var rMinusOne big.Int
+	rMinusOne.Sub(fr.Modulus(), big.NewInt(1))
+	s, err := rand.Int(r, &rMinusOne)
+	if err != nil {
+		return nil, err
+	}
+	return s.Add(s, big.NewInt(1)), nil
+}
@@ -0,0 +1,25 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+// Package ecfft provides the elliptic curve fast Fourier transform (ECFFT) over fp.
+//
+// The multiplicative group of fp has a small 2-adic subgroup, so the radix-2 FFT of fr/fft can't
+// be used on it. The ECFFT replaces the roots of unity by the x-coordinates of a coset of a subgroup
+// of order n = 2ᵏ of an auxiliary curve over fp, and the squaring map by a chain of 2-isogenies.
+// It evaluates and extends polynomials on this domain in quasi-linear time.
+//
+// See https://arxiv.org/abs/2107.08473 (Ben-Sasson, Carmon, Kopparty, Levit).
+package ecfft
@@ -0,0 +1,175 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package ecfft
+
+import (
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-378/fp"
+)
+
+const testLogSize = 6
+
+func randomPolynomial(size int) []fp.Element {
+	p := make([]fp.Element, size)
+	for i := range p {
+		p[i].SetRandom()
+	}
+	return p
+}
+
+func eval(p []fp.Element, x fp.Element) fp.Element {
+	var res fp.Element
+	for i := len(p) - 1; i >= 0; i-- {
+		res.Mul(&res, &x).Add(&res, &p[i])
+	}
+	return res
+}
+
+func newTestTree(t *testing.T) *Tree {
+	params, err := SearchParameters(testLogSize)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tree, err := NewTree(params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return tree
+}
+
+func TestExtend(t *testing.T) {
+	t.Parallel()
+	tree := newTestTree(t)
+	domain := tree.Domain()
+	n := tree.Size()
+
+	p := randomPolynomial(n / 2)
+	evens := make([]fp.Element, n/2)
+	for j := range evens {
+		evens[j] = eval(p, domain[2*j])
+	}
+	odds, err := tree.Extend(evens)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for j := range odds {
+		if expected := eval(p, domain[2*j+1]); !odds[j].Equal(&expected) {
+			t.Fatal("wrong extension")
+		}
+	}
+
+	if _, err := tree.Extend(evens[1:]); err != ErrInvalidSize {
+		t.Fatalf("expected %v, got %v", ErrInvalidSize, err)
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	t.Parallel()
+	tree := newTestTree(t)
+	domain := tree.Domain()
+	n := tree.Size()
+
+	// the polynomial may have less than n coefficients
+	for _, size := range []int{n, n - 5, 1} {
+		p := randomPolynomial(size)
+		evaluations, err := tree.Evaluate(p)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for j := range evaluations {
+			if expected := eval(p, domain[j]); !evaluations[j].Equal(&expected) {
+				t.Fatal("wrong evaluation")
+			}
+		}
+
+		var z fp.Element
+		z.SetRandom()
+		res, err := tree.EvaluateAt(evaluations, z)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if expected := eval(p, z); !res.Equal(&expected) {
+			t.Fatal("wrong evaluation outside of the domain")
+		}
+		res, err = tree.EvaluateAt(evaluations, domain[3])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !res.Equal(&evaluations[3]) {
+			t.Fatal("wrong evaluation on the domain")
+		}
+	}
+
+	if _, err := tree.Evaluate(make([]fp.Element, n+1)); err != ErrInvalidSize {
+		t.Fatalf("expected %v, got %v", ErrInvalidSize, err)
+	}
+}
+
+func TestNewTreeErrors(t *testing.T) {
+	t.Parallel()
+	params, err := SearchParameters(testLogSize)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wrongSize := params
+	wrongSize.LogSize = 0
+	if _, err := NewTree(wrongSize); err != ErrInvalidLogSize {
+		t.Fatalf("expected %v, got %v", ErrInvalidLogSize, err)
+	}
+
+	// the generator has order 2^testLogSize, not 2^(testLogSize+1) nor 2^(testLogSize-1)
+	for _, logSize := range []int{testLogSize - 1, testLogSize + 1} {
+		wrongOrder := params
+		wrongOrder.LogSize = logSize
+		if _, err := NewTree(wrongOrder); err != ErrInvalidGenerator {
+			t.Fatalf("expected %v, got %v", ErrInvalidGenerator, err)
+		}
+	}
+
+	notOnCurve := params
+	notOnCurve.Offset.Y.Double(&notOnCurve.Offset.Y)
+	if _, err := NewTree(notOnCurve); err != ErrPointNotOnCurve {
+		t.Fatalf("expected %v, got %v", ErrPointNotOnCurve, err)
+	}
+
+	// the offset is in ⟨generator⟩
+	inSubgroup := params
+	inSubgroup.Offset = params.Curve.double(&params.Generator)
+	if _, err := NewTree(inSubgroup); err != ErrInvalidOffset {
+		t.Fatalf("expected %v, got %v", ErrInvalidOffset, err)
+	}
+}
+
+func BenchmarkExtend(b *testing.B) {
+	const logSize = 10
+	params, err := SearchParameters(logSize)
+	if err != nil {
+		b.Fatal(err)
+	}
+	tree, err := NewTree(params)
+	if err != nil {
+		b.Fatal(err)
+	}
+	evens := randomPolynomial(tree.Size() / 2)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = tree.Extend(evens)
+	}
+}
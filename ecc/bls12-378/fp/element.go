@@ -20,10 +20,12 @@ import (
 	"crypto/rand"
 	"encoding/binary"
 	"errors"
+	"fmt"
 	"io"
 	"math/big"
 	"math/bits"
 	"reflect"
+	"runtime"
 	"strconv"
 	"strings"
 	"sync"
@@ -82,6 +84,36 @@ func Modulus() *big.Int {
 // used for Montgomery reduction
 const qInvNeg uint64 = 11045256207009841151
 
+// QInvNeg = - q⁻¹ mod 2⁶⁴, the constant used for Montgomery reduction
+const QInvNeg = qInvNeg
+
+// TwoAdicity is the largest s such that 2ˢ divides q-1, i.e. q-1 = 2ˢ⋅t with t odd;
+// 2^TwoAdicity is the largest power of 2 order of a root of unity in the field
+const TwoAdicity = 41
+
+// QuadraticNonResidue is the smallest positive integer which is not a square modulo q
+const QuadraticNonResidue = 5
+
+// OddPart returns t, the odd part of q-1 = 2ˢ⋅t where s = TwoAdicity
+func OddPart() *big.Int {
+	t := new(big.Int).Sub(&_modulus, big.NewInt(1))
+	return t.Rsh(t, TwoAdicity)
+}
+
+// MontgomeryR returns R mod q, where R = 2^(64⋅Limbs) is the Montgomery radix.
+// It is the Montgomery form of 1.
+func MontgomeryR() *big.Int {
+	r := new(big.Int).Lsh(big.NewInt(1), Limbs*64)
+	return r.Mod(r, &_modulus)
+}
+
+// MontgomeryRSquare returns R² mod q, used to convert an integer to Montgomery form
+func MontgomeryRSquare() *big.Int {
+	r := MontgomeryR()
+	r.Mul(r, r)
+	return r.Mod(r, &_modulus)
+}
+
 var bigIntPool = sync.Pool{
 	New: func() interface{} {
 		return new(big.Int)
@@ -137,7 +169,7 @@ func (z *Element) Set(x *Element) *Element {
 }
 
 // SetInterface converts provided interface into Element
-// returns an error if provided type is not supported
+// returns an error wrapping ErrInvalidEncoding if provided type is not supported
 // supported types:
 //  Element
 //  *Element
@@ -149,7 +181,7 @@ func (z *Element) Set(x *Element) *Element {
 //  []byte
 func (z *Element) SetInterface(i1 interface{}) (*Element, error) {
 	if i1 == nil {
-		return nil, errors.New("can't set fp.Element with <nil>")
+		return nil, fmt.Errorf("%w: can't set fp.Element with <nil>", ErrInvalidEncoding)
 	}
 
 	switch c1 := i1.(type) {
@@ -157,7 +189,7 @@ func (z *Element) SetInterface(i1 interface{}) (*Element, error) {
 		return z.Set(&c1), nil
 	case *Element:
 		if c1 == nil {
-			return nil, errors.New("can't set fp.Element with <nil>")
+			return nil, fmt.Errorf("%w: can't set fp.Element with <nil>", ErrInvalidEncoding)
 		}
 		return z.Set(c1), nil
 	case uint8:
@@ -184,7 +216,7 @@ func (z *Element) SetInterface(i1 interface{}) (*Element, error) {
 		return z.SetString(c1)
 	case *big.Int:
 		if c1 == nil {
-			return nil, errors.New("can't set fp.Element with <nil>")
+			return nil, fmt.Errorf("%w: can't set fp.Element with <nil>", ErrInvalidEncoding)
 		}
 		return z.SetBigInt(c1), nil
 	case big.Int:
@@ -192,7 +224,7 @@ func (z *Element) SetInterface(i1 interface{}) (*Element, error) {
 	case []byte:
 		return z.SetBytes(c1), nil
 	default:
-		return nil, errors.New("can't set fp.Element from type " + reflect.TypeOf(i1).String())
+		return nil, fmt.Errorf("%w: can't set fp.Element from type %s", ErrInvalidEncoding, reflect.TypeOf(i1).String())
 	}
 }
 
@@ -324,7 +356,15 @@ func (z *Element) Cmp(x *Element) int {
 
 // LexicographicallyLargest returns true if this element is strictly lexicographically
 // larger than its negation, false otherwise
+//
+// It is kept for consistency with the extension field types, see IsLexLargerThanHalf.
 func (z *Element) LexicographicallyLargest() bool {
+	return z.IsLexLargerThanHalf()
+}
+
+// IsLexLargerThanHalf returns true if z > (q-1)/2 in regular form, that is, if
+// z is strictly lexicographically larger than its negation; false otherwise
+func (z *Element) IsLexLargerThanHalf() bool {
 	// adapted from github.com/zkcrypto/bls12_381
 	// we check if the element is larger than (q-1) / 2
 	// if z - (((q -1) / 2) + 1) have no underflow, then z > (q-1) / 2
@@ -343,6 +383,15 @@ func (z *Element) LexicographicallyLargest() bool {
 	return b == 0
 }
 
+// Sign0 returns the parity of z in regular form (0 or 1), as the sgn0 function of
+// https://datatracker.ietf.org/doc/html/rfc9380#section-4.1 for a prime field.
+// The sign of an element is not obviously related to that of its Montgomery form.
+func (z *Element) Sign0() uint64 {
+	_z := *z
+	_z.FromMont()
+	return _z[0] & 1
+}
+
 // SetRandom sets z to a uniform random value in [0, q).
 //
 // This might error only if reading from crypto/rand.Reader errors,
@@ -409,16 +458,16 @@ func One() Element {
 func (z *Element) Halve() {
 	var carry uint64
 
-	if z[0]&1 == 1 {
-		// z = z + q
-		z[0], carry = bits.Add64(z[0], q0, 0)
-		z[1], carry = bits.Add64(z[1], q1, carry)
-		z[2], carry = bits.Add64(z[2], q2, carry)
-		z[3], carry = bits.Add64(z[3], q3, carry)
-		z[4], carry = bits.Add64(z[4], q4, carry)
-		z[5], _ = bits.Add64(z[5], q5, carry)
+	// if z is odd, we add q; the mask avoids branching on the parity of z
+	mask := -(z[0] & 1)
+	// z = z + (q & mask)
+	z[0], carry = bits.Add64(z[0], q0&mask, 0)
+	z[1], carry = bits.Add64(z[1], q1&mask, carry)
+	z[2], carry = bits.Add64(z[2], q2&mask, carry)
+	z[3], carry = bits.Add64(z[3], q3&mask, carry)
+	z[4], carry = bits.Add64(z[4], q4&mask, carry)
+	z[5], _ = bits.Add64(z[5], q5&mask, carry)
 
-	}
 	// z = z >> 1
 	z[0] = z[0]>>1 | z[1]<<63
 	z[1] = z[1]>>1 | z[2]<<63
@@ -482,8 +531,13 @@ func (z *Element) Mul(x, y *Element) *Element {
 //
 // x must be strictly inferior to q
 func (z *Element) Square(x *Element) *Element {
-	// see Mul for algorithm documentation
-	mul(z, x, x)
+	// Implements CIOS squaring (see Mul), with y = 2x precomputed.
+	//
+	// Since x[i]*x[j] == x[j]*x[i], round i only multiplies x[i] by
+	// (x[i], 2x[i+1], ..., 2x[N-1]), which saves N(N-1)/2 word multiplications
+	// compared to mul(z, x, x). The intermediate results fit on N words
+	// since the most significant word of q is smaller than 2^62.
+	square(z, x)
 	return z
 }
 
@@ -506,14 +560,21 @@ func (z *Element) Add(x, y *Element) *Element {
 	z[5], _ = bits.Add64(x[5], y[5], carry)
 
 	// if z >= q → z -= q
-	if !z.smallerThanModulus() {
-		var b uint64
-		z[0], b = bits.Sub64(z[0], q0, 0)
-		z[1], b = bits.Sub64(z[1], q1, b)
-		z[2], b = bits.Sub64(z[2], q2, b)
-		z[3], b = bits.Sub64(z[3], q3, b)
-		z[4], b = bits.Sub64(z[4], q4, b)
-		z[5], _ = bits.Sub64(z[5], q5, b)
+	// this is done without branching on the value of z
+	{
+		r0, b := bits.Sub64(z[0], q0, 0)
+		r1, b := bits.Sub64(z[1], q1, b)
+		r2, b := bits.Sub64(z[2], q2, b)
+		r3, b := bits.Sub64(z[3], q3, b)
+		r4, b := bits.Sub64(z[4], q4, b)
+		r5, b := bits.Sub64(z[5], q5, b)
+		mask := b - 1 // all ones if z >= q
+		z[0] ^= mask & (z[0] ^ r0)
+		z[1] ^= mask & (z[1] ^ r1)
+		z[2] ^= mask & (z[2] ^ r2)
+		z[3] ^= mask & (z[3] ^ r3)
+		z[4] ^= mask & (z[4] ^ r4)
+		z[5] ^= mask & (z[5] ^ r5)
 	}
 	return z
 }
@@ -530,14 +591,21 @@ func (z *Element) Double(x *Element) *Element {
 	z[5], _ = bits.Add64(x[5], x[5], carry)
 
 	// if z >= q → z -= q
-	if !z.smallerThanModulus() {
-		var b uint64
-		z[0], b = bits.Sub64(z[0], q0, 0)
-		z[1], b = bits.Sub64(z[1], q1, b)
-		z[2], b = bits.Sub64(z[2], q2, b)
-		z[3], b = bits.Sub64(z[3], q3, b)
-		z[4], b = bits.Sub64(z[4], q4, b)
-		z[5], _ = bits.Sub64(z[5], q5, b)
+	// this is done without branching on the value of z
+	{
+		r0, b := bits.Sub64(z[0], q0, 0)
+		r1, b := bits.Sub64(z[1], q1, b)
+		r2, b := bits.Sub64(z[2], q2, b)
+		r3, b := bits.Sub64(z[3], q3, b)
+		r4, b := bits.Sub64(z[4], q4, b)
+		r5, b := bits.Sub64(z[5], q5, b)
+		mask := b - 1 // all ones if z >= q
+		z[0] ^= mask & (z[0] ^ r0)
+		z[1] ^= mask & (z[1] ^ r1)
+		z[2] ^= mask & (z[2] ^ r2)
+		z[3] ^= mask & (z[3] ^ r3)
+		z[4] ^= mask & (z[4] ^ r4)
+		z[5] ^= mask & (z[5] ^ r5)
 	}
 	return z
 }
@@ -551,24 +619,24 @@ func (z *Element) Sub(x, y *Element) *Element {
 	z[3], b = bits.Sub64(x[3], y[3], b)
 	z[4], b = bits.Sub64(x[4], y[4], b)
 	z[5], b = bits.Sub64(x[5], y[5], b)
-	if b != 0 {
-		var c uint64
-		z[0], c = bits.Add64(z[0], q0, 0)
-		z[1], c = bits.Add64(z[1], q1, c)
-		z[2], c = bits.Add64(z[2], q2, c)
-		z[3], c = bits.Add64(z[3], q3, c)
-		z[4], c = bits.Add64(z[4], q4, c)
-		z[5], _ = bits.Add64(z[5], q5, c)
-	}
+
+	// if we borrowed, z += q; the mask avoids branching on the borrow
+	mask := -b
+	var c uint64
+	z[0], c = bits.Add64(z[0], q0&mask, 0)
+	z[1], c = bits.Add64(z[1], q1&mask, c)
+	z[2], c = bits.Add64(z[2], q2&mask, c)
+	z[3], c = bits.Add64(z[3], q3&mask, c)
+	z[4], c = bits.Add64(z[4], q4&mask, c)
+	z[5], _ = bits.Add64(z[5], q5&mask, c)
 	return z
 }
 
 // Neg z = q - x
 func (z *Element) Neg(x *Element) *Element {
-	if x.IsZero() {
-		z.SetZero()
-		return z
-	}
+	// mask is 0 if x == 0, all ones otherwise, so that -0 = 0 without branching
+	nz := x[0] | x[1] | x[2] | x[3] | x[4] | x[5]
+	mask := uint64(int64(nz|-nz) >> 63)
 	var borrow uint64
 	z[0], borrow = bits.Sub64(q0, x[0], 0)
 	z[1], borrow = bits.Sub64(q1, x[1], borrow)
@@ -576,6 +644,12 @@ func (z *Element) Neg(x *Element) *Element {
 	z[3], borrow = bits.Sub64(q3, x[3], borrow)
 	z[4], borrow = bits.Sub64(q4, x[4], borrow)
 	z[5], _ = bits.Sub64(q5, x[5], borrow)
+	z[0] &= mask
+	z[1] &= mask
+	z[2] &= mask
+	z[3] &= mask
+	z[4] &= mask
+	z[5] &= mask
 	return z
 }
 
@@ -701,18 +775,143 @@ func _mulGeneric(z, x, y *Element) {
 	}
 
 	// if z >= q → z -= q
-	if !z.smallerThanModulus() {
-		var b uint64
-		z[0], b = bits.Sub64(z[0], q0, 0)
-		z[1], b = bits.Sub64(z[1], q1, b)
-		z[2], b = bits.Sub64(z[2], q2, b)
-		z[3], b = bits.Sub64(z[3], q3, b)
-		z[4], b = bits.Sub64(z[4], q4, b)
-		z[5], _ = bits.Sub64(z[5], q5, b)
+	// this is done without branching on the value of z
+	{
+		r0, b := bits.Sub64(z[0], q0, 0)
+		r1, b := bits.Sub64(z[1], q1, b)
+		r2, b := bits.Sub64(z[2], q2, b)
+		r3, b := bits.Sub64(z[3], q3, b)
+		r4, b := bits.Sub64(z[4], q4, b)
+		r5, b := bits.Sub64(z[5], q5, b)
+		mask := b - 1 // all ones if z >= q
+		z[0] ^= mask & (z[0] ^ r0)
+		z[1] ^= mask & (z[1] ^ r1)
+		z[2] ^= mask & (z[2] ^ r2)
+		z[3] ^= mask & (z[3] ^ r3)
+		z[4] ^= mask & (z[4] ^ r4)
+		z[5] ^= mask & (z[5] ^ r5)
 	}
 
 }
 
+func _squareGeneric(z, x *Element) {
+	// see Square for algorithm documentation
+
+	var t [6]uint64
+	var c [3]uint64
+
+	// y = 2x, which fits on 6 words
+	y2 := x[2]<<1 | x[1]>>63
+	y3 := x[3]<<1 | x[2]>>63
+	y4 := x[4]<<1 | x[3]>>63
+	y5 := x[5]<<1 | x[4]>>63
+	{
+		// round 0: x[0] * (x[0], x[1]<<1, y2, y3, y4, y5)
+		v := x[0]
+		c[1], c[0] = bits.Mul64(v, v)
+		m := c[0] * qInvNeg
+		c[2] = madd0(m, q0, c[0])
+		c[1], c[0] = madd1(v, x[1]<<1, c[1])
+		c[2], t[0] = madd2(m, q1, c[2], c[0])
+		c[1], c[0] = madd1(v, y2, c[1])
+		c[2], t[1] = madd2(m, q2, c[2], c[0])
+		c[1], c[0] = madd1(v, y3, c[1])
+		c[2], t[2] = madd2(m, q3, c[2], c[0])
+		c[1], c[0] = madd1(v, y4, c[1])
+		c[2], t[3] = madd2(m, q4, c[2], c[0])
+		c[1], c[0] = madd1(v, y5, c[1])
+		t[5], t[4] = madd3(m, q5, c[0], c[2], c[1])
+	}
+	{
+		// round 1: x[1] * (x[1], x[2]<<1, y3, y4, y5)
+		v := x[1]
+		m := t[0] * qInvNeg
+		c[2] = madd0(m, q0, t[0])
+		c[1], c[0] = madd1(v, v, t[1])
+		c[2], t[0] = madd2(m, q1, c[2], c[0])
+		c[1], c[0] = madd2(v, x[2]<<1, c[1], t[2])
+		c[2], t[1] = madd2(m, q2, c[2], c[0])
+		c[1], c[0] = madd2(v, y3, c[1], t[3])
+		c[2], t[2] = madd2(m, q3, c[2], c[0])
+		c[1], c[0] = madd2(v, y4, c[1], t[4])
+		c[2], t[3] = madd2(m, q4, c[2], c[0])
+		c[1], c[0] = madd2(v, y5, c[1], t[5])
+		t[5], t[4] = madd3(m, q5, c[0], c[2], c[1])
+	}
+	{
+		// round 2: x[2] * (x[2], x[3]<<1, y4, y5)
+		v := x[2]
+		m := t[0] * qInvNeg
+		c[2] = madd0(m, q0, t[0])
+		c[2], t[0] = madd2(m, q1, c[2], t[1])
+		c[1], c[0] = madd1(v, v, t[2])
+		c[2], t[1] = madd2(m, q2, c[2], c[0])
+		c[1], c[0] = madd2(v, x[3]<<1, c[1], t[3])
+		c[2], t[2] = madd2(m, q3, c[2], c[0])
+		c[1], c[0] = madd2(v, y4, c[1], t[4])
+		c[2], t[3] = madd2(m, q4, c[2], c[0])
+		c[1], c[0] = madd2(v, y5, c[1], t[5])
+		t[5], t[4] = madd3(m, q5, c[0], c[2], c[1])
+	}
+	{
+		// round 3: x[3] * (x[3], x[4]<<1, y5)
+		v := x[3]
+		m := t[0] * qInvNeg
+		c[2] = madd0(m, q0, t[0])
+		c[2], t[0] = madd2(m, q1, c[2], t[1])
+		c[2], t[1] = madd2(m, q2, c[2], t[2])
+		c[1], c[0] = madd1(v, v, t[3])
+		c[2], t[2] = madd2(m, q3, c[2], c[0])
+		c[1], c[0] = madd2(v, x[4]<<1, c[1], t[4])
+		c[2], t[3] = madd2(m, q4, c[2], c[0])
+		c[1], c[0] = madd2(v, y5, c[1], t[5])
+		t[5], t[4] = madd3(m, q5, c[0], c[2], c[1])
+	}
+	{
+		// round 4: x[4] * (x[4], x[5]<<1)
+		v := x[4]
+		m := t[0] * qInvNeg
+		c[2] = madd0(m, q0, t[0])
+		c[2], t[0] = madd2(m, q1, c[2], t[1])
+		c[2], t[1] = madd2(m, q2, c[2], t[2])
+		c[2], t[2] = madd2(m, q3, c[2], t[3])
+		c[1], c[0] = madd1(v, v, t[4])
+		c[2], t[3] = madd2(m, q4, c[2], c[0])
+		c[1], c[0] = madd2(v, x[5]<<1, c[1], t[5])
+		t[5], t[4] = madd3(m, q5, c[0], c[2], c[1])
+	}
+	{
+		// round 5: x[5] * (x[5])
+		v := x[5]
+		m := t[0] * qInvNeg
+		c[2] = madd0(m, q0, t[0])
+		c[2], z[0] = madd2(m, q1, c[2], t[1])
+		c[2], z[1] = madd2(m, q2, c[2], t[2])
+		c[2], z[2] = madd2(m, q3, c[2], t[3])
+		c[2], z[3] = madd2(m, q4, c[2], t[4])
+		c[1], c[0] = madd1(v, v, t[5])
+		z[5], z[4] = madd3(m, q5, c[0], c[2], c[1])
+	}
+
+	// if z >= q → z -= q
+	// this is done without branching on the value of z
+	{
+		r0, b := bits.Sub64(z[0], q0, 0)
+		r1, b := bits.Sub64(z[1], q1, b)
+		r2, b := bits.Sub64(z[2], q2, b)
+		r3, b := bits.Sub64(z[3], q3, b)
+		r4, b := bits.Sub64(z[4], q4, b)
+		r5, b := bits.Sub64(z[5], q5, b)
+		mask := b - 1 // all ones if z >= q
+		z[0] ^= mask & (z[0] ^ r0)
+		z[1] ^= mask & (z[1] ^ r1)
+		z[2] ^= mask & (z[2] ^ r2)
+		z[3] ^= mask & (z[3] ^ r3)
+		z[4] ^= mask & (z[4] ^ r4)
+		z[5] ^= mask & (z[5] ^ r5)
+	}
+
+}
 func _fromMontGeneric(z *Element) {
 	// the following lines implement z = z * 1
 	// with a modified CIOS montgomery multiplication
@@ -785,28 +984,42 @@ func _fromMontGeneric(z *Element) {
 	}
 
 	// if z >= q → z -= q
-	if !z.smallerThanModulus() {
-		var b uint64
-		z[0], b = bits.Sub64(z[0], q0, 0)
-		z[1], b = bits.Sub64(z[1], q1, b)
-		z[2], b = bits.Sub64(z[2], q2, b)
-		z[3], b = bits.Sub64(z[3], q3, b)
-		z[4], b = bits.Sub64(z[4], q4, b)
-		z[5], _ = bits.Sub64(z[5], q5, b)
+	// this is done without branching on the value of z
+	{
+		r0, b := bits.Sub64(z[0], q0, 0)
+		r1, b := bits.Sub64(z[1], q1, b)
+		r2, b := bits.Sub64(z[2], q2, b)
+		r3, b := bits.Sub64(z[3], q3, b)
+		r4, b := bits.Sub64(z[4], q4, b)
+		r5, b := bits.Sub64(z[5], q5, b)
+		mask := b - 1 // all ones if z >= q
+		z[0] ^= mask & (z[0] ^ r0)
+		z[1] ^= mask & (z[1] ^ r1)
+		z[2] ^= mask & (z[2] ^ r2)
+		z[3] ^= mask & (z[3] ^ r3)
+		z[4] ^= mask & (z[4] ^ r4)
+		z[5] ^= mask & (z[5] ^ r5)
 	}
 }
 
 func _reduceGeneric(z *Element) {
 
 	// if z >= q → z -= q
-	if !z.smallerThanModulus() {
-		var b uint64
-		z[0], b = bits.Sub64(z[0], q0, 0)
-		z[1], b = bits.Sub64(z[1], q1, b)
-		z[2], b = bits.Sub64(z[2], q2, b)
-		z[3], b = bits.Sub64(z[3], q3, b)
-		z[4], b = bits.Sub64(z[4], q4, b)
-		z[5], _ = bits.Sub64(z[5], q5, b)
+	// this is done without branching on the value of z
+	{
+		r0, b := bits.Sub64(z[0], q0, 0)
+		r1, b := bits.Sub64(z[1], q1, b)
+		r2, b := bits.Sub64(z[2], q2, b)
+		r3, b := bits.Sub64(z[3], q3, b)
+		r4, b := bits.Sub64(z[4], q4, b)
+		r5, b := bits.Sub64(z[5], q5, b)
+		mask := b - 1 // all ones if z >= q
+		z[0] ^= mask & (z[0] ^ r0)
+		z[1] ^= mask & (z[1] ^ r1)
+		z[2] ^= mask & (z[2] ^ r2)
+		z[3] ^= mask & (z[3] ^ r3)
+		z[4] ^= mask & (z[4] ^ r4)
+		z[5] ^= mask & (z[5] ^ r5)
 	}
 }
 
@@ -901,6 +1114,81 @@ func (z *Element) Exp(x Element, k *big.Int) *Element {
 	return z
 }
 
+// ExpUint64 z = xᵉ (mod q)
+func (z *Element) ExpUint64(x Element, e uint64) *Element {
+	var ew [1]uint64
+	ew[0] = e
+	return z.expWindowed(&x, ew[:])
+}
+
+// ExpElement z = xᵉ (mod q), where e is interpreted as its canonical (regular form) integer value
+func (z *Element) ExpElement(x Element, e *Element) *Element {
+	ew := e.ToRegular()
+	return z.expWindowed(&x, ew[:])
+}
+
+// Cube z = x³ (mod q)
+func (z *Element) Cube(x *Element) *Element {
+	var x2 Element
+	x2.Square(x)
+	return z.Mul(&x2, x)
+}
+
+// Pow5 z = x⁵ (mod q)
+//
+// It uses the addition chain 1 → 2 → 4 → 5 (2 squarings, 1 multiplication),
+// which is optimal for this exponent.
+func (z *Element) Pow5(x *Element) *Element {
+	var x4 Element
+	x4.Square(x).Square(&x4)
+	return z.Mul(&x4, x)
+}
+
+// Pow7 z = x⁷ (mod q)
+//
+// It uses the addition chain 1 → 2 → 3 → 6 → 7 (2 squarings, 2 multiplications),
+// which is optimal for this exponent.
+func (z *Element) Pow7(x *Element) *Element {
+	var t Element
+	t.Square(x).Mul(&t, x).Square(&t)
+	return z.Mul(&t, x)
+}
+
+// expWindowed sets z = xᵉ (mod q), where e is given as little-endian 64-bit words,
+// using a square-and-multiply over fixed windows of 4 bits.
+// It does not allocate.
+func (z *Element) expWindowed(x *Element, e []uint64) *Element {
+	const c = 4
+	const mask = 1<<c - 1
+
+	// table[i] = xⁱ
+	var table [1 << c]Element
+	table[0].SetOne()
+	table[1].Set(x)
+	for i := 2; i < len(table); i++ {
+		table[i].Mul(&table[i-1], x)
+	}
+
+	var res Element
+	res.SetOne()
+	started := false
+	for i := len(e) - 1; i >= 0; i-- {
+		for j := 64 - c; j >= 0; j -= c {
+			if started {
+				for k := 0; k < c; k++ {
+					res.Square(&res)
+				}
+			}
+			if w := (e[i] >> uint(j)) & mask; w != 0 {
+				res.Mul(&res, &table[w])
+				started = true
+			}
+		}
+	}
+
+	return z.Set(&res)
+}
+
 // rSquare where r is the Montgommery constant
 // see section 2.3.2 of Tolga Acar's thesis
 // https://www.microsoft.com/en-us/research/wp-content/uploads/1998/06/97Acar.pdf
@@ -924,6 +1212,43 @@ func (z Element) ToRegular() Element {
 	return *z.FromMont()
 }
 
+// NewElementFromLimbs returns the element whose regular form is given by the
+// little-endian 64-bit limbs; the value is reduced modulo q if needed.
+//
+// This is the inverse of LimbsRegular, for interoperability with libraries that
+// don't use the Montgomery representation.
+func NewElementFromLimbs(regular [Limbs]uint64) Element {
+	z := Element(regular)
+	if !z.smallerThanModulus() {
+		// slow path, reduce through big.Int
+		var b [Limbs * 8]byte
+		for i := 0; i < Limbs; i++ {
+			binary.BigEndian.PutUint64(b[(Limbs-1-i)*8:], regular[i])
+		}
+		z.SetBytes(b[:])
+		return z
+	}
+	z.ToMont()
+	return z
+}
+
+// LimbsRegular returns the little-endian 64-bit limbs of z in regular form
+// (doesn't mutate z)
+func (z *Element) LimbsRegular() [Limbs]uint64 {
+	return [Limbs]uint64(z.ToRegular())
+}
+
+// UnsafeNewElementFromMontLimbs returns the element whose Montgomery form is given by
+// the little-endian 64-bit limbs, without any conversion. It is meant to exchange elements
+// with external implementations (e.g. GPU or FPGA accelerators) which use the same
+// Montgomery representation, with R = 2^(64*Limbs).
+//
+// This is unsafe: the limbs are not checked to be smaller than q, and the arithmetic
+// on a non-reduced element is undefined.
+func UnsafeNewElementFromMontLimbs(mont [Limbs]uint64) Element {
+	return Element(mont)
+}
+
 // String returns the decimal representation of z as generated by
 // z.Text(10).
 func (z *Element) String() string {
@@ -1018,6 +1343,127 @@ func (z *Element) SetBytes(e []byte) *Element {
 	return z
 }
 
+// SetBytesCanonical sets z to the value encoded in e, the big-endian encoding on exactly Bytes bytes
+// of an integer smaller than q (as returned by Bytes).
+//
+// Unlike SetBytes, it doesn't reduce e modulo q: an error wrapping ErrInvalidEncoding is returned
+// if len(e) != Bytes, and ErrAboveModulus if e encodes a value larger or equal to q. In both cases,
+// z is not modified. It doesn't allocate.
+func (z *Element) SetBytesCanonical(e []byte) error {
+	if len(e) != Bytes {
+		return fmt.Errorf("%w: expected %d bytes, got %d", ErrInvalidEncoding, Bytes, len(e))
+	}
+	var v Element
+	v[0] = binary.BigEndian.Uint64(e[40:48])
+	v[1] = binary.BigEndian.Uint64(e[32:40])
+	v[2] = binary.BigEndian.Uint64(e[24:32])
+	v[3] = binary.BigEndian.Uint64(e[16:24])
+	v[4] = binary.BigEndian.Uint64(e[8:16])
+	v[5] = binary.BigEndian.Uint64(e[0:8])
+	if !v.smallerThanModulus() {
+		return ErrAboveModulus
+	}
+	*z = v
+	z.ToMont()
+	return nil
+}
+
+// SetBytesWide interprets e as the bytes of a big-endian unsigned integer of at most
+// 2*Bytes bytes, sets z to that value reduced modulo q, and returns z.
+//
+// It is meant to map uniformly random bytes (e.g. a hash output twice as long as q)
+// to the field with a negligible bias; the reduction doesn't allocate.
+// It panics if len(e) > 2*Bytes.
+func (z *Element) SetBytesWide(e []byte) *Element {
+	if len(e) > 2*Bytes {
+		panic("SetBytesWide: input is longer than 2*Bytes")
+	}
+
+	// u = e, as little-endian words
+	var u ElementUnreduced
+	i := 0
+	for ; 8*(i+1) <= len(e); i++ {
+		u[i] = binary.BigEndian.Uint64(e[len(e)-8*(i+1):])
+	}
+	for _, b := range e[:len(e)-8*i] {
+		u[i] = u[i]<<8 | uint64(b)
+	}
+
+	// u is reduced as a product of two elements in Montgomery form: z = u⋅R⁻²,
+	// then z = u⋅R⁻²⋅R⋅R
+	u.Reduce(z)
+	return z.Mul(z, &rSquare).Mul(z, &rSquare)
+}
+
+// SliceToBytes appends the encodings of the elements of a, in regular form, to dst and
+// returns the extended buffer. Each element is encoded on Bytes bytes, in the given byte
+// order: binary.BigEndian (as Bytes()) or binary.LittleEndian.
+//
+// The elements are converted in a single pass; if dst has enough capacity, no allocation
+// is made.
+func SliceToBytes(dst []byte, a []Element, order binary.ByteOrder) []byte {
+	n := len(dst)
+	size := len(a) * Bytes
+	if cap(dst)-n < size {
+		res := make([]byte, n, n+size)
+		copy(res, dst)
+		dst = res
+	}
+	dst = dst[:n+size]
+	bigEndian := isBigEndian(order)
+	for i := range a {
+		limbs := a[i].LimbsRegular()
+		b := dst[n+i*Bytes : n+(i+1)*Bytes]
+		for j := 0; j < Limbs; j++ {
+			if bigEndian {
+				order.PutUint64(b[(Limbs-1-j)*8:], limbs[j])
+			} else {
+				order.PutUint64(b[j*8:], limbs[j])
+			}
+		}
+	}
+	return dst
+}
+
+// BytesToSlice decodes the elements encoded in b, as SliceToBytes does with the same byte
+// order, appends them to dst and returns the extended slice. Non-canonical encodings are
+// reduced modulo q, as in SetBytes.
+//
+// If dst has enough capacity, no allocation is made.
+func BytesToSlice(dst []Element, b []byte, order binary.ByteOrder) ([]Element, error) {
+	if len(b)%Bytes != 0 {
+		return dst, errors.New("invalid buffer length: not a multiple of fp.Bytes")
+	}
+	n := len(dst)
+	nbElements := len(b) / Bytes
+	if cap(dst)-n < nbElements {
+		res := make([]Element, n, n+nbElements)
+		copy(res, dst)
+		dst = res
+	}
+	dst = dst[:n+nbElements]
+	bigEndian := isBigEndian(order)
+	var limbs [Limbs]uint64
+	for i := 0; i < nbElements; i++ {
+		e := b[i*Bytes : (i+1)*Bytes]
+		for j := 0; j < Limbs; j++ {
+			if bigEndian {
+				limbs[j] = order.Uint64(e[(Limbs-1-j)*8:])
+			} else {
+				limbs[j] = order.Uint64(e[j*8:])
+			}
+		}
+		dst[n+i] = NewElementFromLimbs(limbs)
+	}
+	return dst, nil
+}
+
+// isBigEndian returns true if order puts the most significant byte first
+func isBigEndian(order binary.ByteOrder) bool {
+	// note: comparing the value doesn't allocate, unlike testing order on a buffer
+	return order == binary.BigEndian
+}
+
 // SetBigInt sets z to v and returns z
 func (z *Element) SetBigInt(v *big.Int) *Element {
 	z.SetZero()
@@ -1070,6 +1516,54 @@ func (z *Element) setBigInt(v *big.Int) *Element {
 	return z.ToMont()
 }
 
+// minParallelConversion is the minimum slice length for which BatchFromBigIntSlice
+// splits the work across goroutines
+const minParallelConversion = 1 << 12
+
+// BatchFromBigIntSlice returns the field elements corresponding to v, reduced modulo q.
+//
+// Large slices are converted in parallel; the only allocation is the returned slice
+// (inputs outside [0, q) go through the big.Int pool of SetBigInt).
+func BatchFromBigIntSlice(v []*big.Int) []Element {
+	res := make([]Element, len(v))
+	convert := func(start, end int) {
+		for i := start; i < end; i++ {
+			res[i].SetBigInt(v[i])
+		}
+	}
+
+	n := len(v)
+	nbTasks := runtime.NumCPU()
+	if n < minParallelConversion || nbTasks < 2 {
+		convert(0, n)
+		return res
+	}
+
+	chunkSize := (n + nbTasks - 1) / nbTasks
+	var wg sync.WaitGroup
+	for start := 0; start < n; start += chunkSize {
+		end := start + chunkSize
+		if end > n {
+			end = n
+		}
+		wg.Add(1)
+		go func(start, end int) {
+			convert(start, end)
+			wg.Done()
+		}(start, end)
+	}
+	wg.Wait()
+
+	return res
+}
+
+var (
+	// ErrInvalidEncoding is returned (wrapped) when a value can't be parsed as a field element
+	ErrInvalidEncoding = errors.New("fp: invalid encoding")
+	// ErrAboveModulus is returned (wrapped) by checked setters when a value is not smaller than q
+	ErrAboveModulus = errors.New("fp: value is not smaller than the modulus")
+)
+
 // SetString creates a big.Int with number and calls SetBigInt on z
 //
 // The number prefix determines the actual base: A prefix of
@@ -1083,24 +1577,47 @@ func (z *Element) setBigInt(v *big.Int) *Element {
 // An underscore character ''_'' may appear between a base
 // prefix and an adjacent digit, and between successive digits; such
 // underscores do not change the value of the number.
-// Incorrect placement of underscores is reported as a panic if there
-// are no other errors.
+// Incorrect placement of underscores is reported as an error.
+//
+// The number is reduced modulo q; negative numbers are accepted. To parse untrusted
+// input which must be canonical, use SetStringChecked instead.
 //
-// If the number is invalid this method leaves z unchanged and returns nil, error.
+// If the number is invalid this method leaves z unchanged and returns nil and an
+// error wrapping ErrInvalidEncoding.
 func (z *Element) SetString(number string) (*Element, error) {
 	// get temporary big int from the pool
 	vv := bigIntPool.Get().(*big.Int)
+	defer bigIntPool.Put(vv)
 
 	if _, ok := vv.SetString(number, 0); !ok {
-		return nil, errors.New("Element.SetString failed -> can't parse number into a big.Int " + number)
+		return nil, fmt.Errorf("%w: can't parse %q into a big.Int", ErrInvalidEncoding, number)
 	}
 
-	z.SetBigInt(vv)
+	return z.SetBigInt(vv), nil
+}
 
-	// release object into pool
-	bigIntPool.Put(vv)
+// SetStringChecked is as SetString, but only accepts the canonical representation of an
+// element, that is, a non-negative number smaller than q.
+//
+// If the number is invalid or negative, it returns nil and an error wrapping ErrInvalidEncoding;
+// if it is larger than or equal to q, an error wrapping ErrAboveModulus. In both cases z is
+// left unchanged.
+func (z *Element) SetStringChecked(number string) (*Element, error) {
+	// get temporary big int from the pool
+	vv := bigIntPool.Get().(*big.Int)
+	defer bigIntPool.Put(vv)
+
+	if _, ok := vv.SetString(number, 0); !ok {
+		return nil, fmt.Errorf("%w: can't parse %q into a big.Int", ErrInvalidEncoding, number)
+	}
+	if vv.Sign() < 0 {
+		return nil, fmt.Errorf("%w: negative number %q", ErrInvalidEncoding, number)
+	}
+	if vv.Cmp(&_modulus) >= 0 {
+		return nil, fmt.Errorf("%w: %q", ErrAboveModulus, number)
+	}
 
-	return z, nil
+	return z.setBigInt(vv), nil
 }
 
 // MarshalJSON returns json encoding of z (z.Text(10))
@@ -1126,7 +1643,7 @@ func (z *Element) MarshalJSON() ([]byte, error) {
 func (z *Element) UnmarshalJSON(data []byte) error {
 	s := string(data)
 	if len(s) > Bits*3 {
-		return errors.New("value too large (max = Element.Bits * 3)")
+		return fmt.Errorf("%w: value too large (max = Element.Bits * 3)", ErrInvalidEncoding)
 	}
 
 	// we accept numbers and strings, remove leading and trailing quotes if any
@@ -1139,15 +1656,13 @@ func (z *Element) UnmarshalJSON(data []byte) error {
 
 	// get temporary big int from the pool
 	vv := bigIntPool.Get().(*big.Int)
+	defer bigIntPool.Put(vv)
 
 	if _, ok := vv.SetString(s, 0); !ok {
-		return errors.New("can't parse into a big.Int: " + s)
+		return fmt.Errorf("%w: can't parse into a big.Int: %s", ErrInvalidEncoding, s)
 	}
 
 	z.SetBigInt(vv)
-
-	// release object into pool
-	bigIntPool.Put(vv)
 	return nil
 }
 
@@ -1155,7 +1670,7 @@ func (z *Element) UnmarshalJSON(data []byte) error {
 func (z *Element) Legendre() int {
 	var l Element
 	// z^((q-1)/2)
-	l.expByLegendreExp(*z)
+	l.ExpByLegendreExp(*z)
 
 	if l.IsZero() {
 		return 0
@@ -1178,7 +1693,7 @@ func (z *Element) Sqrt(x *Element) *Element {
 
 	var y, b, t, w Element
 	// w = x^((s-1)/2))
-	w.expBySqrtExp(*x)
+	w.ExpBySqrtExp(*x)
 
 	// y = x^((s+1)/2)) = w * x
 	y.Mul(x, &w)
@@ -1557,14 +2072,21 @@ func (z *Element) montReduceSigned(x *Element, xHi uint64) {
 	}
 
 	// if z >= q → z -= q
-	if !z.smallerThanModulus() {
-		var b uint64
-		z[0], b = bits.Sub64(z[0], q0, 0)
-		z[1], b = bits.Sub64(z[1], q1, b)
-		z[2], b = bits.Sub64(z[2], q2, b)
-		z[3], b = bits.Sub64(z[3], q3, b)
-		z[4], b = bits.Sub64(z[4], q4, b)
-		z[5], _ = bits.Sub64(z[5], q5, b)
+	// this is done without branching on the value of z
+	{
+		r0, b := bits.Sub64(z[0], q0, 0)
+		r1, b := bits.Sub64(z[1], q1, b)
+		r2, b := bits.Sub64(z[2], q2, b)
+		r3, b := bits.Sub64(z[3], q3, b)
+		r4, b := bits.Sub64(z[4], q4, b)
+		r5, b := bits.Sub64(z[5], q5, b)
+		mask := b - 1 // all ones if z >= q
+		z[0] ^= mask & (z[0] ^ r0)
+		z[1] ^= mask & (z[1] ^ r1)
+		z[2] ^= mask & (z[2] ^ r2)
+		z[3] ^= mask & (z[3] ^ r3)
+		z[4] ^= mask & (z[4] ^ r4)
+		z[5] ^= mask & (z[5] ^ r5)
 	}
 	// </standard SOS>
 
@@ -1667,3 +2189,448 @@ func (z *Element) linearCombNonModular(x *Element, xC int64, y *Element, yC int6
 
 	return yHi
 }
+
+// ToWNAF returns the width-w non-adjacent form of z (in regular form), least significant digit first.
+//
+// The result satisfies z = ∑ dᵢ2ⁱ, where each digit dᵢ is either 0 or odd with |dᵢ| < 2ʷ⁻¹,
+// and at most one of any w consecutive digits is non-zero. w must be in [2, 8].
+func (z *Element) ToWNAF(w uint) []int8 {
+	if w < 2 || w > 8 {
+		panic("ToWNAF: window size must be in [2, 8]")
+	}
+
+	var k big.Int
+	z.ToBigIntRegular(&k)
+
+	res := make([]int8, 0, k.BitLen()+1)
+	mask := big.Word(1)<<w - 1
+	half := int64(1) << (w - 1)
+
+	var d big.Int
+	for k.Sign() > 0 {
+		var digit int64
+		if k.Bit(0) == 1 {
+			// digit = k mods 2ʷ
+			digit = int64(k.Bits()[0] & mask)
+			if digit >= half {
+				digit -= int64(1) << w
+			}
+			k.Sub(&k, d.SetInt64(digit))
+		}
+		res = append(res, int8(digit))
+		k.Rsh(&k, 1)
+	}
+
+	return res
+}
+
+// ToFixedWindow returns the digits of z (in regular form) in base 2ᶜ, least significant digit first.
+//
+// The result has ⌈Bits/c⌉ digits and satisfies z = ∑ dᵢ2ⁱᶜ with 0 ≤ dᵢ < 2ᶜ. c must be in [1, 16].
+func (z *Element) ToFixedWindow(c uint) []uint16 {
+	if c == 0 || c > 16 {
+		panic("ToFixedWindow: window size must be in [1, 16]")
+	}
+
+	zz := z.ToRegular()
+	res := make([]uint16, (Bits+int(c)-1)/int(c))
+	mask := uint64(1)<<c - 1
+
+	for i := range res {
+		start := uint(i) * c
+		w, s := start/64, start%64
+		digit := zz[w] >> s
+		if s+c > 64 && w+1 < Limbs {
+			digit |= zz[w+1] << (64 - s)
+		}
+		res[i] = uint16(digit & mask)
+	}
+
+	return res
+}
+
+// ToSignedFixedWindow returns the signed digits of z (in regular form) in base 2ᶜ, least significant digit first.
+//
+// The result has ⌈Bits/c⌉+1 digits and satisfies z = ∑ dᵢ2ⁱᶜ with -2ᶜ⁻¹ ≤ dᵢ < 2ᶜ⁻¹. c must be in [2, 16].
+func (z *Element) ToSignedFixedWindow(c uint) []int32 {
+	if c < 2 || c > 16 {
+		panic("ToSignedFixedWindow: window size must be in [2, 16]")
+	}
+	digits := z.ToFixedWindow(c)
+	res := make([]int32, len(digits)+1)
+
+	max := int32(1) << (c - 1)
+	var carry int32
+	for i, digit := range digits {
+		d := int32(digit) + carry
+		carry = 0
+		if d >= max {
+			d -= int32(1) << c
+			carry = 1
+		}
+		res[i] = d
+	}
+	res[len(digits)] = carry
+
+	return res
+}
+
+// ElementUnreduced is an accumulator for sums of products of Element, performing
+// a single Montgomery reduction when the final value is needed.
+//
+// It stores the full (unreduced) integer value of ∑ aᵢ⋅bᵢ + ∑ xⱼ⋅R on 2⋅Limbs+1 words,
+// which is enough for 2⁶⁴ accumulations.
+type ElementUnreduced [2*Limbs + 1]uint64
+
+// SetZero sets z to 0 and returns z
+func (z *ElementUnreduced) SetZero() *ElementUnreduced {
+	*z = ElementUnreduced{}
+	return z
+}
+
+// MulAcc z = z + x * y (unreduced) and returns z
+func (z *ElementUnreduced) MulAcc(x, y *Element) *ElementUnreduced {
+	// t = x * y (schoolbook, fits on 2⋅Limbs words)
+	var t [2 * Limbs]uint64
+	var c, hi, lo, cc uint64
+	c = 0
+	hi, lo = bits.Mul64(x[0], y[0])
+	lo, cc = bits.Add64(lo, t[0], 0)
+	hi += cc
+	t[0], cc = bits.Add64(lo, c, 0)
+	c = hi + cc
+	hi, lo = bits.Mul64(x[0], y[1])
+	lo, cc = bits.Add64(lo, t[1], 0)
+	hi += cc
+	t[1], cc = bits.Add64(lo, c, 0)
+	c = hi + cc
+	hi, lo = bits.Mul64(x[0], y[2])
+	lo, cc = bits.Add64(lo, t[2], 0)
+	hi += cc
+	t[2], cc = bits.Add64(lo, c, 0)
+	c = hi + cc
+	hi, lo = bits.Mul64(x[0], y[3])
+	lo, cc = bits.Add64(lo, t[3], 0)
+	hi += cc
+	t[3], cc = bits.Add64(lo, c, 0)
+	c = hi + cc
+	hi, lo = bits.Mul64(x[0], y[4])
+	lo, cc = bits.Add64(lo, t[4], 0)
+	hi += cc
+	t[4], cc = bits.Add64(lo, c, 0)
+	c = hi + cc
+	hi, lo = bits.Mul64(x[0], y[5])
+	lo, cc = bits.Add64(lo, t[5], 0)
+	hi += cc
+	t[5], cc = bits.Add64(lo, c, 0)
+	c = hi + cc
+	t[6] = c
+	c = 0
+	hi, lo = bits.Mul64(x[1], y[0])
+	lo, cc = bits.Add64(lo, t[1], 0)
+	hi += cc
+	t[1], cc = bits.Add64(lo, c, 0)
+	c = hi + cc
+	hi, lo = bits.Mul64(x[1], y[1])
+	lo, cc = bits.Add64(lo, t[2], 0)
+	hi += cc
+	t[2], cc = bits.Add64(lo, c, 0)
+	c = hi + cc
+	hi, lo = bits.Mul64(x[1], y[2])
+	lo, cc = bits.Add64(lo, t[3], 0)
+	hi += cc
+	t[3], cc = bits.Add64(lo, c, 0)
+	c = hi + cc
+	hi, lo = bits.Mul64(x[1], y[3])
+	lo, cc = bits.Add64(lo, t[4], 0)
+	hi += cc
+	t[4], cc = bits.Add64(lo, c, 0)
+	c = hi + cc
+	hi, lo = bits.Mul64(x[1], y[4])
+	lo, cc = bits.Add64(lo, t[5], 0)
+	hi += cc
+	t[5], cc = bits.Add64(lo, c, 0)
+	c = hi + cc
+	hi, lo = bits.Mul64(x[1], y[5])
+	lo, cc = bits.Add64(lo, t[6], 0)
+	hi += cc
+	t[6], cc = bits.Add64(lo, c, 0)
+	c = hi + cc
+	t[7] = c
+	c = 0
+	hi, lo = bits.Mul64(x[2], y[0])
+	lo, cc = bits.Add64(lo, t[2], 0)
+	hi += cc
+	t[2], cc = bits.Add64(lo, c, 0)
+	c = hi + cc
+	hi, lo = bits.Mul64(x[2], y[1])
+	lo, cc = bits.Add64(lo, t[3], 0)
+	hi += cc
+	t[3], cc = bits.Add64(lo, c, 0)
+	c = hi + cc
+	hi, lo = bits.Mul64(x[2], y[2])
+	lo, cc = bits.Add64(lo, t[4], 0)
+	hi += cc
+	t[4], cc = bits.Add64(lo, c, 0)
+	c = hi + cc
+	hi, lo = bits.Mul64(x[2], y[3])
+	lo, cc = bits.Add64(lo, t[5], 0)
+	hi += cc
+	t[5], cc = bits.Add64(lo, c, 0)
+	c = hi + cc
+	hi, lo = bits.Mul64(x[2], y[4])
+	lo, cc = bits.Add64(lo, t[6], 0)
+	hi += cc
+	t[6], cc = bits.Add64(lo, c, 0)
+	c = hi + cc
+	hi, lo = bits.Mul64(x[2], y[5])
+	lo, cc = bits.Add64(lo, t[7], 0)
+	hi += cc
+	t[7], cc = bits.Add64(lo, c, 0)
+	c = hi + cc
+	t[8] = c
+	c = 0
+	hi, lo = bits.Mul64(x[3], y[0])
+	lo, cc = bits.Add64(lo, t[3], 0)
+	hi += cc
+	t[3], cc = bits.Add64(lo, c, 0)
+	c = hi + cc
+	hi, lo = bits.Mul64(x[3], y[1])
+	lo, cc = bits.Add64(lo, t[4], 0)
+	hi += cc
+	t[4], cc = bits.Add64(lo, c, 0)
+	c = hi + cc
+	hi, lo = bits.Mul64(x[3], y[2])
+	lo, cc = bits.Add64(lo, t[5], 0)
+	hi += cc
+	t[5], cc = bits.Add64(lo, c, 0)
+	c = hi + cc
+	hi, lo = bits.Mul64(x[3], y[3])
+	lo, cc = bits.Add64(lo, t[6], 0)
+	hi += cc
+	t[6], cc = bits.Add64(lo, c, 0)
+	c = hi + cc
+	hi, lo = bits.Mul64(x[3], y[4])
+	lo, cc = bits.Add64(lo, t[7], 0)
+	hi += cc
+	t[7], cc = bits.Add64(lo, c, 0)
+	c = hi + cc
+	hi, lo = bits.Mul64(x[3], y[5])
+	lo, cc = bits.Add64(lo, t[8], 0)
+	hi += cc
+	t[8], cc = bits.Add64(lo, c, 0)
+	c = hi + cc
+	t[9] = c
+	c = 0
+	hi, lo = bits.Mul64(x[4], y[0])
+	lo, cc = bits.Add64(lo, t[4], 0)
+	hi += cc
+	t[4], cc = bits.Add64(lo, c, 0)
+	c = hi + cc
+	hi, lo = bits.Mul64(x[4], y[1])
+	lo, cc = bits.Add64(lo, t[5], 0)
+	hi += cc
+	t[5], cc = bits.Add64(lo, c, 0)
+	c = hi + cc
+	hi, lo = bits.Mul64(x[4], y[2])
+	lo, cc = bits.Add64(lo, t[6], 0)
+	hi += cc
+	t[6], cc = bits.Add64(lo, c, 0)
+	c = hi + cc
+	hi, lo = bits.Mul64(x[4], y[3])
+	lo, cc = bits.Add64(lo, t[7], 0)
+	hi += cc
+	t[7], cc = bits.Add64(lo, c, 0)
+	c = hi + cc
+	hi, lo = bits.Mul64(x[4], y[4])
+	lo, cc = bits.Add64(lo, t[8], 0)
+	hi += cc
+	t[8], cc = bits.Add64(lo, c, 0)
+	c = hi + cc
+	hi, lo = bits.Mul64(x[4], y[5])
+	lo, cc = bits.Add64(lo, t[9], 0)
+	hi += cc
+	t[9], cc = bits.Add64(lo, c, 0)
+	c = hi + cc
+	t[10] = c
+	c = 0
+	hi, lo = bits.Mul64(x[5], y[0])
+	lo, cc = bits.Add64(lo, t[5], 0)
+	hi += cc
+	t[5], cc = bits.Add64(lo, c, 0)
+	c = hi + cc
+	hi, lo = bits.Mul64(x[5], y[1])
+	lo, cc = bits.Add64(lo, t[6], 0)
+	hi += cc
+	t[6], cc = bits.Add64(lo, c, 0)
+	c = hi + cc
+	hi, lo = bits.Mul64(x[5], y[2])
+	lo, cc = bits.Add64(lo, t[7], 0)
+	hi += cc
+	t[7], cc = bits.Add64(lo, c, 0)
+	c = hi + cc
+	hi, lo = bits.Mul64(x[5], y[3])
+	lo, cc = bits.Add64(lo, t[8], 0)
+	hi += cc
+	t[8], cc = bits.Add64(lo, c, 0)
+	c = hi + cc
+	hi, lo = bits.Mul64(x[5], y[4])
+	lo, cc = bits.Add64(lo, t[9], 0)
+	hi += cc
+	t[9], cc = bits.Add64(lo, c, 0)
+	c = hi + cc
+	hi, lo = bits.Mul64(x[5], y[5])
+	lo, cc = bits.Add64(lo, t[10], 0)
+	hi += cc
+	t[10], cc = bits.Add64(lo, c, 0)
+	c = hi + cc
+	t[11] = c
+
+	// z = z + t
+	z[0], c = bits.Add64(z[0], t[0], 0)
+	z[1], c = bits.Add64(z[1], t[1], c)
+	z[2], c = bits.Add64(z[2], t[2], c)
+	z[3], c = bits.Add64(z[3], t[3], c)
+	z[4], c = bits.Add64(z[4], t[4], c)
+	z[5], c = bits.Add64(z[5], t[5], c)
+	z[6], c = bits.Add64(z[6], t[6], c)
+	z[7], c = bits.Add64(z[7], t[7], c)
+	z[8], c = bits.Add64(z[8], t[8], c)
+	z[9], c = bits.Add64(z[9], t[9], c)
+	z[10], c = bits.Add64(z[10], t[10], c)
+	z[11], c = bits.Add64(z[11], t[11], c)
+	z[2*Limbs] += c
+
+	return z
+}
+
+// Add z = z + x (unreduced) and returns z
+func (z *ElementUnreduced) Add(x *Element) *ElementUnreduced {
+	// x is in Montgomery form; to be consistent with the products accumulated
+	// by MulAcc, we add x⋅R, i.e. x shifted by Limbs words.
+	var carry uint64
+	for i := 0; i < Limbs; i++ {
+		z[Limbs+i], carry = bits.Add64(z[Limbs+i], x[i], carry)
+	}
+	z[2*Limbs] += carry
+	return z
+}
+
+// Reduce sets res to the value accumulated in z, reduced modulo q, and returns res
+func (z *ElementUnreduced) Reduce(res *Element) *Element {
+	t := *z
+
+	// Montgomery reduction: t = (t + m⋅q) / R, with m such that the Limbs low words vanish
+	for i := 0; i < Limbs; i++ {
+		m := t[i] * qInvNeg
+		var carry uint64
+		for j := 0; j < Limbs; j++ {
+			hi, lo := bits.Mul64(m, qElement[j])
+			var c uint64
+			lo, c = bits.Add64(lo, t[i+j], 0)
+			hi += c
+			lo, c = bits.Add64(lo, carry, 0)
+			hi += c
+			t[i+j] = lo
+			carry = hi
+		}
+		for k := i + Limbs; carry != 0 && k < len(t); k++ {
+			t[k], carry = bits.Add64(t[k], carry, 0)
+		}
+	}
+
+	// the result is now lo + h⋅R, with lo < R, which may still be larger than q.
+	var lo, h Element
+	copy(lo[:], t[Limbs:2*Limbs])
+	fromMont(&lo)
+	lo.Mul(&lo, &rSquare)
+	h.SetUint64(t[2*Limbs])
+
+	return res.Add(&lo, &h)
+}
+
+// Dot returns ∑ aᵢ⋅bᵢ, using a single reduction.
+//
+// It panics if len(a) != len(b).
+func Dot(a, b []Element) Element {
+	if len(a) != len(b) {
+		panic("Dot: vectors don't have the same length")
+	}
+	var acc ElementUnreduced
+	for i := 0; i < len(a); i++ {
+		acc.MulAcc(&a[i], &b[i])
+	}
+	var res Element
+	acc.Reduce(&res)
+	return res
+}
+
+// minParallelReduction is the minimum slice length for which Sum and Product
+// split the work across goroutines
+const minParallelReduction = 1 << 12
+
+// Sum returns ∑ aᵢ, or 0 if a is empty.
+//
+// For large slices, partial sums are computed in parallel and combined in a tree.
+func Sum(a []Element) Element {
+	return reduceParallel(len(a), func(start, end int) Element {
+		var res Element
+		for i := start; i < end; i++ {
+			res.Add(&res, &a[i])
+		}
+		return res
+	}, func(x, y *Element) {
+		x.Add(x, y)
+	})
+}
+
+// Product returns ∏ aᵢ, or 1 if a is empty.
+//
+// For large slices, partial products are computed in parallel and combined in a tree.
+func Product(a []Element) Element {
+	return reduceParallel(len(a), func(start, end int) Element {
+		res := One()
+		for i := start; i < end; i++ {
+			res.Mul(&res, &a[i])
+		}
+		return res
+	}, func(x, y *Element) {
+		x.Mul(x, y)
+	})
+}
+
+// reduceParallel splits [0, n) in chunks, reduces each chunk concurrently with
+// reduceChunk, then merges the partial results pairwise with combine.
+func reduceParallel(n int, reduceChunk func(start, end int) Element, combine func(x, y *Element)) Element {
+	nbTasks := runtime.NumCPU()
+	if n < minParallelReduction || nbTasks < 2 {
+		return reduceChunk(0, n)
+	}
+
+	chunkSize := (n + nbTasks - 1) / nbTasks
+	nbTasks = (n + chunkSize - 1) / chunkSize
+
+	partials := make([]Element, nbTasks)
+	var wg sync.WaitGroup
+	wg.Add(nbTasks)
+	for i := 0; i < nbTasks; i++ {
+		start := i * chunkSize
+		end := start + chunkSize
+		if end > n {
+			end = n
+		}
+		go func(i, start, end int) {
+			partials[i] = reduceChunk(start, end)
+			wg.Done()
+		}(i, start, end)
+	}
+	wg.Wait()
+
+	for step := 1; step < nbTasks; step <<= 1 {
+		for i := 0; i+step < nbTasks; i += step << 1 {
+			combine(&partials[i], &partials[i+step])
+		}
+	}
+
+	return partials[0]
+}
@@ -1,4 +1,5 @@
-// +build amd64_adx
+//go:build amd64_adx && !purego
+// +build amd64_adx,!purego
 
 // Copyright 2020 ConsenSys Software Inc.
 //
@@ -319,6 +320,254 @@ TEXT ·mul(SB), NOSPLIT, $0-24
 	MOVQ BX, 24(AX)
 	RET
 
+// square(res, x *Element)
+TEXT ·square(SB), NOSPLIT, $0-16
+
+	// the algorithm is described in the Element.Square declaration (.go)
+	// this is the CIOS multiplication of x by x (see mul), where round i
+	// only computes the products of x[i] with x[i], 2x[i+1], ..., 2x[N-1]
+
+	MOVQ x+8(FP), R14
+
+	// y = 2x
+	MOVQ 0(R14), AX
+	ADDQ AX, AX
+	MOVQ 8(R14), AX
+	ADCQ AX, AX
+	MOVQ 16(R14), AX
+	ADCQ AX, AX
+	MOVQ AX, R9
+	MOVQ 24(R14), AX
+	ADCQ AX, AX
+	MOVQ AX, R10
+
+	// A -> BP
+	// t[0] -> R13
+	// t[1] -> CX
+	// t[2] -> BX
+	// t[3] -> SI
+	MOVQ 8(R14), R8
+	ADDQ R8, R8
+
+	// clear the flags
+	XORQ AX, AX
+	MOVQ 0(R14), DX
+
+	// (A,t[0])  := x[0]*x[0] + A
+	MULXQ DX, R13, CX
+
+	// (A,t[1])  := 2x[1]*x[0] + A
+	MULXQ R8, AX, BX
+	ADOXQ AX, CX
+
+	// (A,t[2])  := 2x[2]*x[0] + A
+	MULXQ R9, AX, SI
+	ADOXQ AX, BX
+
+	// (A,t[3])  := 2x[3]*x[0] + A
+	MULXQ R10, AX, BP
+	ADOXQ AX, SI
+
+	// A += carries from ADCXQ and ADOXQ
+	MOVQ  $0, AX
+	ADOXQ AX, BP
+
+	// m := t[0]*q'[0] mod W
+	MOVQ  qInv0<>(SB), DX
+	IMULQ R13, DX
+
+	// clear the flags
+	XORQ AX, AX
+
+	// C,_ := t[0] + m*q[0]
+	MULXQ q<>+0(SB), AX, DI
+	ADCXQ R13, AX
+	MOVQ  DI, R13
+
+	// (C,t[0]) := t[1] + m*q[1] + C
+	ADCXQ CX, R13
+	MULXQ q<>+8(SB), AX, CX
+	ADOXQ AX, R13
+
+	// (C,t[1]) := t[2] + m*q[2] + C
+	ADCXQ BX, CX
+	MULXQ q<>+16(SB), AX, BX
+	ADOXQ AX, CX
+
+	// (C,t[2]) := t[3] + m*q[3] + C
+	ADCXQ SI, BX
+	MULXQ q<>+24(SB), AX, SI
+	ADOXQ AX, BX
+
+	// t[3] = C + A
+	MOVQ  $0, AX
+	ADCXQ AX, SI
+	ADOXQ BP, SI
+	MOVQ  16(R14), R8
+	ADDQ  R8, R8
+
+	// clear the flags
+	XORQ AX, AX
+	MOVQ 8(R14), DX
+
+	// (A,t[1])  := t[1] + x[1]*x[1]
+	MULXQ DX, AX, BP
+	ADOXQ AX, CX
+
+	// (A,t[2])  := t[2] + 2x[2]*x[1] + A
+	ADCXQ BP, BX
+	MULXQ R8, AX, BP
+	ADOXQ AX, BX
+
+	// (A,t[3])  := t[3] + 2x[3]*x[1] + A
+	ADCXQ BP, SI
+	MULXQ R10, AX, BP
+	ADOXQ AX, SI
+
+	// A += carries from ADCXQ and ADOXQ
+	MOVQ  $0, AX
+	ADCXQ AX, BP
+	ADOXQ AX, BP
+
+	// m := t[0]*q'[0] mod W
+	MOVQ  qInv0<>(SB), DX
+	IMULQ R13, DX
+
+	// clear the flags
+	XORQ AX, AX
+
+	// C,_ := t[0] + m*q[0]
+	MULXQ q<>+0(SB), AX, DI
+	ADCXQ R13, AX
+	MOVQ  DI, R13
+
+	// (C,t[0]) := t[1] + m*q[1] + C
+	ADCXQ CX, R13
+	MULXQ q<>+8(SB), AX, CX
+	ADOXQ AX, R13
+
+	// (C,t[1]) := t[2] + m*q[2] + C
+	ADCXQ BX, CX
+	MULXQ q<>+16(SB), AX, BX
+	ADOXQ AX, CX
+
+	// (C,t[2]) := t[3] + m*q[3] + C
+	ADCXQ SI, BX
+	MULXQ q<>+24(SB), AX, SI
+	ADOXQ AX, BX
+
+	// t[3] = C + A
+	MOVQ  $0, AX
+	ADCXQ AX, SI
+	ADOXQ BP, SI
+	MOVQ  24(R14), R8
+	ADDQ  R8, R8
+
+	// clear the flags
+	XORQ AX, AX
+	MOVQ 16(R14), DX
+
+	// (A,t[2])  := t[2] + x[2]*x[2]
+	MULXQ DX, AX, BP
+	ADOXQ AX, BX
+
+	// (A,t[3])  := t[3] + 2x[3]*x[2] + A
+	ADCXQ BP, SI
+	MULXQ R8, AX, BP
+	ADOXQ AX, SI
+
+	// A += carries from ADCXQ and ADOXQ
+	MOVQ  $0, AX
+	ADCXQ AX, BP
+	ADOXQ AX, BP
+
+	// m := t[0]*q'[0] mod W
+	MOVQ  qInv0<>(SB), DX
+	IMULQ R13, DX
+
+	// clear the flags
+	XORQ AX, AX
+
+	// C,_ := t[0] + m*q[0]
+	MULXQ q<>+0(SB), AX, DI
+	ADCXQ R13, AX
+	MOVQ  DI, R13
+
+	// (C,t[0]) := t[1] + m*q[1] + C
+	ADCXQ CX, R13
+	MULXQ q<>+8(SB), AX, CX
+	ADOXQ AX, R13
+
+	// (C,t[1]) := t[2] + m*q[2] + C
+	ADCXQ BX, CX
+	MULXQ q<>+16(SB), AX, BX
+	ADOXQ AX, CX
+
+	// (C,t[2]) := t[3] + m*q[3] + C
+	ADCXQ SI, BX
+	MULXQ q<>+24(SB), AX, SI
+	ADOXQ AX, BX
+
+	// t[3] = C + A
+	MOVQ  $0, AX
+	ADCXQ AX, SI
+	ADOXQ BP, SI
+
+	// clear the flags
+	XORQ AX, AX
+	MOVQ 24(R14), DX
+
+	// (A,t[3])  := t[3] + x[3]*x[3]
+	MULXQ DX, AX, BP
+	ADOXQ AX, SI
+
+	// A += carries from ADCXQ and ADOXQ
+	MOVQ  $0, AX
+	ADCXQ AX, BP
+	ADOXQ AX, BP
+
+	// m := t[0]*q'[0] mod W
+	MOVQ  qInv0<>(SB), DX
+	IMULQ R13, DX
+
+	// clear the flags
+	XORQ AX, AX
+
+	// C,_ := t[0] + m*q[0]
+	MULXQ q<>+0(SB), AX, DI
+	ADCXQ R13, AX
+	MOVQ  DI, R13
+
+	// (C,t[0]) := t[1] + m*q[1] + C
+	ADCXQ CX, R13
+	MULXQ q<>+8(SB), AX, CX
+	ADOXQ AX, R13
+
+	// (C,t[1]) := t[2] + m*q[2] + C
+	ADCXQ BX, CX
+	MULXQ q<>+16(SB), AX, BX
+	ADOXQ AX, CX
+
+	// (C,t[2]) := t[3] + m*q[3] + C
+	ADCXQ SI, BX
+	MULXQ q<>+24(SB), AX, SI
+	ADOXQ AX, BX
+
+	// t[3] = C + A
+	MOVQ  $0, AX
+	ADCXQ AX, SI
+	ADOXQ BP, SI
+
+	// reduce element(R13,CX,BX,SI) using temp registers (R11,R12,R9,R10)
+	REDUCE(R13,CX,BX,SI,R11,R12,R9,R10)
+
+	MOVQ res+0(FP), AX
+	MOVQ R13, 0(AX)
+	MOVQ CX, 8(AX)
+	MOVQ BX, 16(AX)
+	MOVQ SI, 24(AX)
+	RET
+
 TEXT ·fromMont(SB), NOSPLIT, $0-8
 
 	// the algorithm is described here
@@ -21,7 +21,6 @@ import (
 	"io"
 	"math/big"
 	"math/bits"
-	"runtime"
 	"sync"
 
 	"github.com/consensys/gnark-crypto/ecc/bls12-378/fr"
@@ -29,6 +28,7 @@ import (
 	curve "github.com/consensys/gnark-crypto/ecc/bls12-378"
 
 	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/internal/parallel"
 )
 
 // Domain with a power of 2 cardinality
@@ -69,24 +69,15 @@ func NewDomain(m uint64) *Domain {
 	x := ecc.NextPowerOfTwo(m)
 	domain.Cardinality = uint64(x)
 
-	// generator of the largest 2-adic subgroup
-	var rootOfUnity fr.Element
-
-	rootOfUnity.SetString("4045585818372166415418670827807793147093034396422209590578257013290761627990")
-	const maxOrderRoot uint64 = 42
-	domain.FrMultiplicativeGen.SetUint64(22)
-
+	domain.FrMultiplicativeGen = fr.MultiplicativeGenerator()
 	domain.FrMultiplicativeGenInv.Inverse(&domain.FrMultiplicativeGen)
 
 	// find generator for Z/2^(log(m))Z
-	logx := uint64(bits.TrailingZeros64(x))
-	if logx > maxOrderRoot {
+	var err error
+	domain.Generator, err = fr.RootOfUnity(x)
+	if err != nil {
 		panic(fmt.Sprintf("m (%d) is too big: the required root of unity does not exist", m))
 	}
-
-	// Generator = FinerGenerator^2 has order x
-	expo := uint64(1 << (maxOrderRoot - logx))
-	domain.Generator.Exp(rootOfUnity, big.NewInt(int64(expo))) // order x
 	domain.GeneratorInv.Inverse(&domain.Generator)
 	domain.CardinalityInv.SetUint64(uint64(x)).Inverse(&domain.CardinalityInv)
 
@@ -160,8 +151,8 @@ func precomputeExpTable(w fr.Element, table []fr.Element) {
 
 	// see if it makes sense to parallelize exp tables pre-computation
 	interval := 0
-	if runtime.NumCPU() >= 4 {
-		interval = (n - 1) / (runtime.NumCPU() / 4)
+	if parallel.MaxProcs() >= 4 {
+		interval = (n - 1) / (parallel.MaxProcs() / 4)
 	}
 
 	// this ratio roughly correspond to the number of multiplication one can do in place of a Exp operation
@@ -238,3 +229,115 @@ func (d *Domain) ReadFrom(r io.Reader) (int64, error) {
 
 	return dec.BytesRead(), nil
 }
+
+// EvaluateLagrangePolynomial returns Lᵢ(x), where Lᵢ is the i-th Lagrange polynomial
+// of the domain, that is the polynomial of degree < n such that Lᵢ(ωʲ) = δᵢⱼ.
+//
+// Lᵢ(x) = ωⁱ(xⁿ-1) / (n(x-ωⁱ))
+func (d *Domain) EvaluateLagrangePolynomial(i uint64, x fr.Element) fr.Element {
+
+	var omegaI, den, res fr.Element
+	omegaI.Exp(d.Generator, new(big.Int).SetUint64(i%d.Cardinality))
+
+	// x = ωⁱ
+	den.Sub(&x, &omegaI)
+	if den.IsZero() {
+		res.SetOne()
+		return res
+	}
+
+	// x = ωʲ, j ≠ i
+	res = d.evaluateVanishingPolynomial(x)
+	if res.IsZero() {
+		return res
+	}
+
+	den.Inverse(&den)
+	res.Mul(&res, &den).
+		Mul(&res, &omegaI).
+		Mul(&res, &d.CardinalityInv)
+
+	return res
+}
+
+// BarycentricEvaluation returns p(x), where p is the polynomial of degree < n given by
+// its evaluations on the domain, in canonical order: evaluations[i] = p(ωⁱ).
+//
+// It uses the barycentric formula p(x) = (xⁿ-1)/n ∑ᵢ ωⁱpᵢ/(x-ωⁱ), so no FFT is needed.
+// len(evaluations) must be equal to the cardinality of the domain.
+func (d *Domain) BarycentricEvaluation(evaluations []fr.Element, x fr.Element) fr.Element {
+	return d.BarycentricEvaluationWithArena(evaluations, x, nil)
+}
+
+// BarycentricEvaluationWithArena is BarycentricEvaluation, with its 2n temporary
+// elements taken from arena (see ecc.Arena).
+func (d *Domain) BarycentricEvaluationWithArena(evaluations []fr.Element, x fr.Element, arena *ecc.Arena) fr.Element {
+
+	if uint64(len(evaluations)) != d.Cardinality {
+		panic("the number of evaluations must match the domain cardinality")
+	}
+
+	var res fr.Element
+
+	// x ∈ domain, the evaluation is read from the vector
+	zx := d.evaluateVanishingPolynomial(x)
+	if zx.IsZero() {
+		var omegaI fr.Element
+		omegaI.SetOne()
+		for i := 0; i < len(evaluations); i++ {
+			if omegaI.Equal(&x) {
+				return evaluations[i]
+			}
+			omegaI.Mul(&omegaI, &d.Generator)
+		}
+	}
+
+	// x-ωⁱ
+	den := fr.MakeFromArena(arena, int(d.Cardinality))
+	var omegaI fr.Element
+	omegaI.SetOne()
+	for i := 0; i < len(den); i++ {
+		den[i].Sub(&x, &omegaI)
+		omegaI.Mul(&omegaI, &d.Generator)
+	}
+
+	// 1/(x-ωⁱ), with Montgomery batch inversion (x ∉ domain, so there is no zero)
+	inv := fr.MakeFromArena(arena, int(d.Cardinality))
+	acc := fr.One()
+	for i := 0; i < len(den); i++ {
+		inv[i] = acc
+		acc.Mul(&acc, &den[i])
+	}
+	acc.Inverse(&acc)
+	for i := len(den) - 1; i >= 0; i-- {
+		inv[i].Mul(&inv[i], &acc)
+		acc.Mul(&acc, &den[i])
+	}
+
+	// ∑ᵢ ωⁱpᵢ/(x-ωⁱ)
+	var tmp fr.Element
+	omegaI.SetOne()
+	for i := 0; i < len(evaluations); i++ {
+		tmp.Mul(&omegaI, &evaluations[i]).
+			Mul(&tmp, &inv[i])
+		res.Add(&res, &tmp)
+		omegaI.Mul(&omegaI, &d.Generator)
+	}
+
+	res.Mul(&res, &zx).
+		Mul(&res, &d.CardinalityInv)
+
+	return res
+}
+
+// evaluateVanishingPolynomial returns xⁿ-1, where n is the cardinality of the domain
+func (d *Domain) evaluateVanishingPolynomial(x fr.Element) fr.Element {
+	var res, one fr.Element
+	one.SetOne()
+	res.Set(&x)
+	for i := uint64(1); i < d.Cardinality; i <<= 1 {
+		res.Square(&res)
+	}
+	res.Sub(&res, &one)
+	return res
+}
@@ -0,0 +1,143 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package hashutils
+
+import (
+	"encoding/binary"
+	"hash"
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-378/fr"
+	fiatshamir "github.com/consensys/gnark-crypto/fiat-shamir"
+)
+
+// WideSize is the number of bytes reduced modulo r to obtain an fr element,
+// i.e. the size of r plus 128 bits of security margin
+const WideSize = fr.Bytes + 16
+
+// HashToFr hashes data to a uniformly distributed fr element.
+//
+// The hash function h is called in counter mode on the length-prefixed data
+// until WideSize bytes are produced, which are then reduced modulo r:
+//
+//	H(0 || len(data₀) || data₀ || …) || H(1 || len(data₀) || data₀ || …) || …
+//
+// The length prefixes make the encoding of data injective, e.g. ("ab", "c") and
+// ("a", "bc") hash to different elements.
+func HashToFr(h func() hash.Hash, data ...[]byte) fr.Element {
+	return WideReduce(Expand(h, WideSize, data...))
+}
+
+// Expand returns n bytes of output of the hash function h in counter mode,
+// over the length-prefixed data (see HashToFr).
+func Expand(h func() hash.Hash, n int, data ...[]byte) []byte {
+	res := make([]byte, 0, n)
+	hf := h()
+	var buf [8]byte
+	for counter := uint32(0); len(res) < n; counter++ {
+		hf.Reset()
+		binary.BigEndian.PutUint32(buf[:4], counter)
+		hf.Write(buf[:4])
+		for _, d := range data {
+			binary.BigEndian.PutUint64(buf[:], uint64(len(d)))
+			hf.Write(buf[:])
+			hf.Write(d)
+		}
+		res = hf.Sum(res)
+	}
+	return res[:n]
+}
+
+// WideReduce returns the big endian integer b reduced modulo r.
+//
+// To get a uniformly distributed element, b should be uniformly distributed and
+// at least WideSize bytes long.
+func WideReduce(b []byte) fr.Element {
+	var v big.Int
+	v.SetBytes(b).Mod(&v, fr.Modulus())
+	var res fr.Element
+	res.SetBigInt(&v)
+	return res
+}
+
+// PackElements encodes elems for absorption in a hash function or a transcript:
+// the number of elements as a big endian uint64, followed by each element in
+// canonical big endian form on fr.Bytes bytes.
+//
+// The encoding is injective and only depends on the values, not on their
+// Montgomery representation, so that it can be reproduced in other implementations.
+func PackElements(elems ...fr.Element) []byte {
+	res := make([]byte, 8, 8+len(elems)*fr.Bytes)
+	binary.BigEndian.PutUint64(res, uint64(len(elems)))
+	for i := range elems {
+		b := elems[i].Bytes()
+		res = append(res, b[:]...)
+	}
+	return res
+}
+
+// Marshaler is implemented by the curve points, whose Marshal method returns
+// their compressed encoding
+type Marshaler interface {
+	Marshal() []byte
+}
+
+// PackPoints encodes points for absorption in a hash function or a transcript:
+// the number of points as a big endian uint64, followed by the compressed
+// encoding of each point.
+//
+// Since the encoding of the points of a group has a fixed size, the result is
+// injective for a given sequence of groups.
+func PackPoints(points ...Marshaler) []byte {
+	res := make([]byte, 8)
+	binary.BigEndian.PutUint64(res, uint64(len(points)))
+	for _, p := range points {
+		res = append(res, p.Marshal()...)
+	}
+	return res
+}
+
+// WriteElements writes PackElements(elems...) to h, e.g. a BLAKE3 or BLAKE2s hash function
+func WriteElements(h hash.Hash, elems ...fr.Element) {
+	h.Write(PackElements(elems...))
+}
+
+// WritePoints writes PackPoints(points...) to h
+func WritePoints(h hash.Hash, points ...Marshaler) {
+	h.Write(PackPoints(points...))
+}
+
+// BindElements binds the challenge challengeID of the transcript to PackElements(elems...)
+func BindElements(fs *fiatshamir.Transcript, challengeID string, elems ...fr.Element) error {
+	return fs.Bind(challengeID, PackElements(elems...))
+}
+
+// BindPoints binds the challenge challengeID of the transcript to PackPoints(points...)
+func BindPoints(fs *fiatshamir.Transcript, challengeID string, points ...Marshaler) error {
+	return fs.Bind(challengeID, PackPoints(points...))
+}
+
+// ChallengeToFr computes the challenge challengeID of the transcript and maps it
+// to an fr element. The challenge is expanded with HashToFr, so that the result is
+// uniform even if the digest is not much larger than r.
+func ChallengeToFr(fs *fiatshamir.Transcript, h func() hash.Hash, challengeID string) (fr.Element, error) {
+	c, err := fs.ComputeChallenge(challengeID)
+	if err != nil {
+		return fr.Element{}, err
+	}
+	return HashToFr(h, c), nil
+}
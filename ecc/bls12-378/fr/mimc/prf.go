@@ -0,0 +1,70 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package mimc
+
+import (
+	"github.com/consensys/gnark-crypto/ecc/bls12-378/fr"
+)
+
+// PRF is a pseudo random function 𝔽_r → 𝔽_r keyed by a field element, for instance
+// to derive nullifiers or nonces from a secret key in privacy protocols.
+//
+// It is the MiMC block cipher in Miyaguchi–Preneel mode (see Compress), keyed by
+// a subkey derived from the key and a tag:
+//
+//	k_tag = Compress(key, H(tag))
+//	PRF(x) = Compress(k_tag, x) = MiMC_k_tag(x) + k_tag + x
+//
+// where H(tag) is the MiMC hash of tag (with PaddingLength and PackingShort). Instances
+// with different tags behave as independent functions.
+type PRF struct {
+	key fr.Element // subkey k_tag
+}
+
+// NewPRF returns the PRF keyed by key, for the domain tag
+func NewPRF(key fr.Element, tag []byte) *PRF {
+	return &PRF{key: DeriveKey(key, tag)}
+}
+
+// DeriveKey returns the subkey k_tag = Compress(key, H(tag)), which can be used
+// as the key of another PRF to build a hierarchy of keys.
+func DeriveKey(key fr.Element, tag []byte) fr.Element {
+	h := NewMiMC(WithPadding(PaddingLength), WithPacking(PackingShort)).(*digest)
+	_, _ = h.Write(tag)
+	return Compress(key, h.checksum())
+}
+
+// Eval returns PRF(x)
+func (p *PRF) Eval(x fr.Element) fr.Element {
+	return Compress(p.key, x)
+}
+
+// EvalCounter returns PRF(counter), e.g. the nonce of index counter
+func (p *PRF) EvalCounter(counter uint64) fr.Element {
+	var x fr.Element
+	x.SetUint64(counter)
+	return p.Eval(x)
+}
+
+// EvalBatch returns PRF(start), PRF(start+1), ..., PRF(start+n-1)
+func (p *PRF) EvalBatch(start uint64, n int) fr.Vector {
+	res := make(fr.Vector, n)
+	for i := range res {
+		res[i] = p.EvalCounter(start + uint64(i))
+	}
+	return res
+}
@@ -0,0 +1,69 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package mimc
+
+import (
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-378/fr"
+)
+
+func TestPRF(t *testing.T) {
+	var key fr.Element
+	if _, err := key.SetRandom(); err != nil {
+		t.Fatal(err)
+	}
+
+	nonces := NewPRF(key, []byte("nonce"))
+	nullifiers := NewPRF(key, []byte("nullifier"))
+
+	// deterministic, and consistent with the definition
+	batch := nonces.EvalBatch(3, 4)
+	for i := range batch {
+		x := NewPRF(key, []byte("nonce")).EvalCounter(3 + uint64(i))
+		if !batch[i].Equal(&x) {
+			t.Fatal("EvalBatch differs from EvalCounter")
+		}
+		subkey := DeriveKey(key, []byte("nonce"))
+		var c fr.Element
+		c.SetUint64(3 + uint64(i))
+		if y := Compress(subkey, c); !batch[i].Equal(&y) {
+			t.Fatal("PRF(x) != Compress(k_tag, x)")
+		}
+	}
+	if batch[0].Equal(&batch[1]) {
+		t.Fatal("outputs for different inputs should differ")
+	}
+
+	// independent domains
+	a, b := nonces.EvalCounter(0), nullifiers.EvalCounter(0)
+	if a.Equal(&b) {
+		t.Fatal("outputs for different tags should differ")
+	}
+	a, b = NewPRF(key, nil).EvalCounter(0), NewPRF(key, []byte{0}).EvalCounter(0)
+	if a.Equal(&b) {
+		t.Fatal("outputs for different tags should differ")
+	}
+
+	// different keys
+	var other fr.Element
+	other.Add(&key, new(fr.Element).SetOne())
+	a, b = nonces.EvalCounter(0), NewPRF(other, []byte("nonce")).EvalCounter(0)
+	if a.Equal(&b) {
+		t.Fatal("outputs for different keys should differ")
+	}
+}
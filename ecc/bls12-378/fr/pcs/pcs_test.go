@@ -0,0 +1,156 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package pcs
+
+import (
+	"crypto/sha256"
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-378/fr"
+	"github.com/consensys/gnark-crypto/ecc/bls12-378/fr/kzg"
+)
+
+const testSize = 32
+
+func backends(tb testing.TB) map[string]PolynomialCommitment {
+	srs, err := kzg.NewSRS(testSize, big.NewInt(42))
+	if err != nil {
+		tb.Fatal(err)
+	}
+	ipa, err := NewIPA(testSize)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	fri, err := NewFRI(testSize, sha256.New())
+	if err != nil {
+		tb.Fatal(err)
+	}
+	return map[string]PolynomialCommitment{
+		"kzg": NewKZG(srs),
+		"ipa": ipa,
+		"fri": fri,
+	}
+}
+
+func randomPolynomial(size int) []fr.Element {
+	p := make([]fr.Element, size)
+	for i := range p {
+		p[i].SetRandom()
+	}
+	return p
+}
+
+func TestPolynomialCommitment(t *testing.T) {
+	all := backends(t)
+	for name, s := range all {
+		name, s := name, s
+		t.Run(name, func(t *testing.T) {
+			p := randomPolynomial(testSize - 3)
+			var point fr.Element
+			point.SetRandom()
+
+			digest, err := s.Commit(p)
+			if err != nil {
+				t.Fatal(err)
+			}
+			proof, err := s.Open(p, point)
+			if err != nil {
+				t.Fatal(err)
+			}
+			expected := evaluate(p, point)
+			if claimed := proof.ClaimedValue(); !claimed.Equal(&expected) {
+				t.Fatal("wrong claimed value")
+			}
+			if err := s.Verify(digest, proof, point); err != nil {
+				t.Fatal(err)
+			}
+
+			// wrong point
+			var other fr.Element
+			other.SetRandom()
+			if err := s.Verify(digest, proof, other); err == nil {
+				t.Fatal("verifying the opening at another point should fail")
+			}
+
+			// wrong polynomial
+			otherDigest, err := s.Commit(randomPolynomial(testSize))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := s.Verify(otherDigest, proof, point); err == nil {
+				t.Fatal("verifying the opening against another digest should fail")
+			}
+
+			// batch
+			digests := make([]Digest, 3)
+			proofs := make([]OpeningProof, 3)
+			points := make([]fr.Element, 3)
+			for i := range digests {
+				q := randomPolynomial(testSize / (i + 1))
+				points[i].SetRandom()
+				if digests[i], err = s.Commit(q); err != nil {
+					t.Fatal(err)
+				}
+				if proofs[i], err = s.Open(q, points[i]); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if err := s.BatchVerify(digests, proofs, points); err != nil {
+				t.Fatal(err)
+			}
+			points[1].SetRandom()
+			if err := s.BatchVerify(digests, proofs, points); err == nil {
+				t.Fatal("verifying a batch with a wrong point should fail")
+			}
+			if err := s.BatchVerify(digests, proofs[:2], points); err != ErrInvalidNbDigests {
+				t.Fatal("verifying a batch of mismatching sizes should fail")
+			}
+
+			if _, err := s.Commit(randomPolynomial(2 * testSize)); err != ErrInvalidPolynomialSize {
+				t.Fatal("committing to a polynomial larger than the setup should fail")
+			}
+
+			// digests from another backend
+			for otherName, o := range all {
+				if otherName == name {
+					continue
+				}
+				d, err := o.Commit(p)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if err := s.Verify(d, proof, point); err != ErrBackendMismatch {
+					t.Fatal("verifying a digest of another backend should fail")
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkOpen(b *testing.B) {
+	for name, s := range backends(b) {
+		p := randomPolynomial(testSize)
+		var point fr.Element
+		point.SetRandom()
+		b.Run(name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				_, _ = s.Open(p, point)
+			}
+		})
+	}
+}
@@ -17,6 +17,7 @@
 package polynomial
 
 import (
+	"bytes"
 	"math/big"
 	"testing"
 
@@ -206,3 +207,25 @@ func TestPolynomialAdd(t *testing.T) {
 		t.Fatal("side effect, _f2 should not have been modified")
 	}
 }
+func TestPolynomialSerialization(t *testing.T) {
+
+	p := make(Polynomial, 20)
+	for i := range p {
+		p[i].SetRandom()
+	}
+
+	var buf bytes.Buffer
+	written, err := p.WriteTo(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var res Polynomial
+	read, err := res.ReadFrom(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if read != written || !res.Equal(p) {
+		t.Fatal("ReadFrom(WriteTo()) failed")
+	}
+}
@@ -0,0 +1,405 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package shplonk
+
+import (
+	"errors"
+	"fmt"
+	"hash"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	bls12378 "github.com/consensys/gnark-crypto/ecc/bls12-378"
+	"github.com/consensys/gnark-crypto/ecc/bls12-378/fr"
+	"github.com/consensys/gnark-crypto/ecc/bls12-378/fr/kzg"
+	fiatshamir "github.com/consensys/gnark-crypto/fiat-shamir"
+)
+
+var (
+	ErrInvalidNumberOfPoints  = errors.New("number of digests should be equal to the number of point sets")
+	ErrEmptyPointSet          = errors.New("each polynomial should be opened on at least one point")
+	ErrDuplicatedPoint        = errors.New("the points of a set should be distinct")
+	ErrInvalidNbClaimedValues = errors.New("number of claimed values should be equal to the number of points")
+	ErrVerifyOpeningProof     = fmt.Errorf("can't verify shplonk batch opening proof: %w", ecc.ErrPairingCheckFailed)
+)
+
+// OpeningProof KZG proof for opening (fᵢ)_{i} at a different set of points (Sᵢ)_{i}.
+type OpeningProof struct {
+
+	// W = [h(α)]G₁, where h = ∑ᵢγⁱ(fᵢ-rᵢ)/Z_{Sᵢ}, rᵢ interpolating fᵢ on Sᵢ
+	W bls12378.G1Affine
+
+	// W' = [(L-L(z))/(X-z)(α)]G₁, where L = ∑ᵢγⁱZ_{T\Sᵢ}(z)(fᵢ-rᵢ(z)) - Z_T(z)h
+	WPrime bls12378.G1Affine
+
+	// ClaimedValues[i][j] = fᵢ(Sᵢ[j])
+	ClaimedValues [][]fr.Element
+}
+
+// BatchOpen opens the list of polynomials on their sets of points, with a single proof.
+//
+// * polynomials list of polynomials in canonical form, of size at most len(srs.G1)
+// * digests list of commitments of the polynomials, used to derive the challenges
+// * points list of sets of points, polynomials[i] is opened on points[i]
+// * dataTranscript extra data that might be needed to derive the challenges
+func BatchOpen(polynomials [][]fr.Element, digests []kzg.Digest, points [][]fr.Element, hf hash.Hash, srs *kzg.SRS, dataTranscript ...[]byte) (OpeningProof, error) {
+
+	var res OpeningProof
+
+	if len(polynomials) != len(digests) {
+		return res, kzg.ErrInvalidNbDigests
+	}
+	if len(polynomials) != len(points) {
+		return res, ErrInvalidNumberOfPoints
+	}
+	maxSize := 2
+	for i := range polynomials {
+		if len(polynomials[i]) == 0 || len(polynomials[i]) > len(srs.G1) {
+			return res, kzg.ErrInvalidPolynomialSize
+		}
+		if len(polynomials[i]) > maxSize {
+			maxSize = len(polynomials[i])
+		}
+	}
+	if err := checkPointSets(points); err != nil {
+		return res, err
+	}
+
+	// compute the claimed values fᵢ(Sᵢ[j])
+	res.ClaimedValues = make([][]fr.Element, len(polynomials))
+	for i := range polynomials {
+		res.ClaimedValues[i] = make([]fr.Element, len(points[i]))
+		for j := range points[i] {
+			res.ClaimedValues[i][j] = eval(polynomials[i], points[i][j])
+		}
+	}
+
+	fs := fiatshamir.NewTranscript(hf, "gamma", "z")
+	gamma, err := deriveChallenge("gamma", digests, points, res.ClaimedValues, &fs, dataTranscript...)
+	if err != nil {
+		return res, err
+	}
+
+	// h = ∑ᵢγⁱqᵢ, where qᵢ = (fᵢ-rᵢ)/Z_{Sᵢ} is the quotient of the euclidean division of fᵢ by Z_{Sᵢ}
+	quotients := make([][]fr.Element, len(polynomials))
+	hSize := 1
+	for i := range polynomials {
+		quotients[i] = quotientByVanishing(polynomials[i], points[i])
+		if len(quotients[i]) > hSize {
+			hSize = len(quotients[i])
+		}
+	}
+	h := make([]fr.Element, hSize)
+	var gammai, tmp fr.Element
+	gammai.SetOne()
+	for _, q := range quotients {
+		for j := range q {
+			tmp.Mul(&q[j], &gammai)
+			h[j].Add(&h[j], &tmp)
+		}
+		gammai.Mul(&gammai, &gamma)
+	}
+	res.W, err = kzg.Commit(h, srs)
+	if err != nil {
+		return res, err
+	}
+
+	// derive z, binded to W
+	if err := fs.Bind("z", res.W.Marshal()); err != nil {
+		return res, err
+	}
+	z, err := challenge(&fs, "z")
+	if err != nil {
+		return res, err
+	}
+
+	// L = ∑ᵢγⁱZ_{T\Sᵢ}(z)fᵢ - Z_T(z)h, which is equal to the polynomial L of the protocol,
+	// up to a constant: its quotient by X-z is the same
+	zT, zTMinusS := vanishingAt(points, z)
+	l := make([]fr.Element, maxSize)
+	for i := range h {
+		l[i].Mul(&h[i], &zT).Neg(&l[i])
+	}
+	gammai.SetOne()
+	var c fr.Element
+	for i := range polynomials {
+		c.Mul(&gammai, &zTMinusS[i])
+		for j := range polynomials[i] {
+			tmp.Mul(&polynomials[i][j], &c)
+			l[j].Add(&l[j], &tmp)
+		}
+		gammai.Mul(&gammai, &gamma)
+	}
+	lz := eval(l, z)
+	res.WPrime, err = kzg.Commit(dividePolyByXminusA(l, lz, z), srs)
+	if err != nil {
+		return res, err
+	}
+
+	return res, nil
+}
+
+// BatchVerify verifies a shplonk opening proof of the digests on their sets of points,
+// with one pairing check.
+//
+// * digests list of commitments of the opened polynomials
+// * points list of sets of points, digests[i] is opened on points[i]
+// * dataTranscript extra data that might be needed to derive the challenges
+func BatchVerify(proof OpeningProof, digests []kzg.Digest, points [][]fr.Element, hf hash.Hash, srs *kzg.SRS, dataTranscript ...[]byte) error {
+
+	if len(digests) != len(points) {
+		return ErrInvalidNumberOfPoints
+	}
+	if len(digests) != len(proof.ClaimedValues) {
+		return kzg.ErrInvalidNbDigests
+	}
+	for i := range points {
+		if len(points[i]) != len(proof.ClaimedValues[i]) {
+			return ErrInvalidNbClaimedValues
+		}
+	}
+	if err := checkPointSets(points); err != nil {
+		return err
+	}
+
+	fs := fiatshamir.NewTranscript(hf, "gamma", "z")
+	gamma, err := deriveChallenge("gamma", digests, points, proof.ClaimedValues, &fs, dataTranscript...)
+	if err != nil {
+		return err
+	}
+	if err := fs.Bind("z", proof.W.Marshal()); err != nil {
+		return err
+	}
+	z, err := challenge(&fs, "z")
+	if err != nil {
+		return err
+	}
+
+	// F = ∑ᵢγⁱZ_{T\Sᵢ}(z)[fᵢ(α)]G₁ - [∑ᵢγⁱZ_{T\Sᵢ}(z)rᵢ(z)]G₁ - Z_T(z)W + zW'
+	// is the commitment of L + zW'(X), that is of XW'(X), so we check
+	// e(F, G₂).e(-W', [α]G₂) ==? 1
+	zT, zTMinusS := vanishingAt(points, z)
+	bases := make([]bls12378.G1Affine, 0, len(digests)+3)
+	scalars := make([]fr.Element, 0, len(digests)+3)
+	bases = append(bases, digests...)
+	var gammai, c, ri, sumR fr.Element
+	gammai.SetOne()
+	for i := range digests {
+		c.Mul(&gammai, &zTMinusS[i])
+		scalars = append(scalars, c)
+		ri, err = interpolateAt(points[i], proof.ClaimedValues[i], z)
+		if err != nil {
+			return err
+		}
+		ri.Mul(&ri, &c)
+		sumR.Add(&sumR, &ri)
+		gammai.Mul(&gammai, &gamma)
+	}
+	sumR.Neg(&sumR)
+	zT.Neg(&zT)
+	bases = append(bases, srs.G1[0], proof.W, proof.WPrime)
+	scalars = append(scalars, sumR, zT, z)
+
+	var f bls12378.G1Affine
+	if _, err := f.MultiExp(bases, scalars, ecc.MultiExpConfig{ScalarsMont: true}); err != nil {
+		return err
+	}
+
+	var negWPrime bls12378.G1Affine
+	negWPrime.Neg(&proof.WPrime)
+	check, err := bls12378.PairingCheck(
+		[]bls12378.G1Affine{f, negWPrime},
+		[]bls12378.G2Affine{srs.G2[0], srs.G2[1]},
+	)
+	if err != nil {
+		return err
+	}
+	if !check {
+		return ErrVerifyOpeningProof
+	}
+	return nil
+}
+
+// checkPointSets checks that the sets of points are not empty, and made of distinct points
+func checkPointSets(points [][]fr.Element) error {
+	for i := range points {
+		if len(points[i]) == 0 {
+			return ErrEmptyPointSet
+		}
+		seen := make(map[fr.Element]struct{}, len(points[i]))
+		for j := range points[i] {
+			if _, ok := seen[points[i][j]]; ok {
+				return ErrDuplicatedPoint
+			}
+			seen[points[i][j]] = struct{}{}
+		}
+	}
+	return nil
+}
+
+// vanishingAt returns Z_T(z) and the list of Z_{T\Sᵢ}(z), where T is the union of the sets of points
+func vanishingAt(points [][]fr.Element, z fr.Element) (fr.Element, []fr.Element) {
+	var t []fr.Element
+	inT := make(map[fr.Element]struct{})
+	for i := range points {
+		for j := range points[i] {
+			if _, ok := inT[points[i][j]]; !ok {
+				inT[points[i][j]] = struct{}{}
+				t = append(t, points[i][j])
+			}
+		}
+	}
+
+	var zT, tmp fr.Element
+	zT.SetOne()
+	for i := range t {
+		tmp.Sub(&z, &t[i])
+		zT.Mul(&zT, &tmp)
+	}
+
+	// Z_{T\Sᵢ}(z) is computed directly instead of Z_T(z)/Z_{Sᵢ}(z), which doesn't work if z is in Sᵢ
+	zTMinusS := make([]fr.Element, len(points))
+	for i := range points {
+		inS := make(map[fr.Element]struct{}, len(points[i]))
+		for j := range points[i] {
+			inS[points[i][j]] = struct{}{}
+		}
+		zTMinusS[i].SetOne()
+		for j := range t {
+			if _, ok := inS[t[j]]; !ok {
+				tmp.Sub(&z, &t[j])
+				zTMinusS[i].Mul(&zTMinusS[i], &tmp)
+			}
+		}
+	}
+
+	return zT, zTMinusS
+}
+
+// interpolateAt returns r(z), where r is the polynomial of degree < len(points)
+// such that r(points[i]) = values[i]
+func interpolateAt(points, values []fr.Element, z fr.Element) (fr.Element, error) {
+	var res, num, den, tmp fr.Element
+	for i := range points {
+		num.SetOne()
+		den.SetOne()
+		for j := range points {
+			if j == i {
+				continue
+			}
+			tmp.Sub(&z, &points[j])
+			num.Mul(&num, &tmp)
+			tmp.Sub(&points[i], &points[j])
+			den.Mul(&den, &tmp)
+		}
+		if den.IsZero() {
+			return fr.Element{}, ErrDuplicatedPoint
+		}
+		den.Inverse(&den)
+		tmp.Mul(&num, &den).Mul(&tmp, &values[i])
+		res.Add(&res, &tmp)
+	}
+	return res, nil
+}
+
+// quotientByVanishing returns the quotient of the euclidean division of f by ∏ᵢ(X-points[i]).
+// The result is empty if deg(f) < len(points).
+func quotientByVanishing(f []fr.Element, points []fr.Element) []fr.Element {
+	q := make([]fr.Element, len(f))
+	copy(q, f)
+
+	// divide by each X-a with syntetic division, dropping the remainders:
+	// f = (X-a)g + c, g = (X-b)g' + c' gives f = (X-a)(X-b)g' + c'(X-a) + c
+	var t fr.Element
+	for i := range points {
+		if len(q) == 0 {
+			break
+		}
+		for j := len(q) - 2; j >= 0; j-- {
+			t.Mul(&q[j+1], &points[i])
+			q[j].Add(&q[j], &t)
+		}
+		q = q[1:]
+	}
+	return q
+}
+
+// dividePolyByXminusA computes (f-f(a))/(x-a), in canonical basis, in regular form
+// f memory is re-used for the result
+func dividePolyByXminusA(f []fr.Element, fa, a fr.Element) []fr.Element {
+
+	// first we compute f-f(a)
+	f[0].Sub(&f[0], &fa)
+
+	// now we use syntetic division to divide by x-a
+	var t fr.Element
+	for i := len(f) - 2; i >= 0; i-- {
+		t.Mul(&f[i+1], &a)
+
+		f[i].Add(&f[i], &t)
+	}
+
+	// the result is of degree deg(f)-1
+	return f[1:]
+}
+
+// eval returns p(point) where p is interpreted as a polynomial
+// ∑_{i<len(p)}p[i]Xⁱ
+func eval(p []fr.Element, point fr.Element) fr.Element {
+	var res fr.Element
+	n := len(p)
+	res.Set(&p[n-1])
+	for i := n - 2; i >= 0; i-- {
+		res.Mul(&res, &point).Add(&res, &p[i])
+	}
+	return res
+}
+
+// deriveChallenge binds the digests, the points and the claimed values, and the extra data,
+// and derives the challenge name
+func deriveChallenge(name string, digests []kzg.Digest, points, claimedValues [][]fr.Element, fs *fiatshamir.Transcript, dataTranscript ...[]byte) (fr.Element, error) {
+	for i := range digests {
+		if err := fs.Bind(name, digests[i].Marshal()); err != nil {
+			return fr.Element{}, err
+		}
+	}
+	for _, s := range [][][]fr.Element{points, claimedValues} {
+		for i := range s {
+			for j := range s[i] {
+				if err := fs.Bind(name, s[i][j].Marshal()); err != nil {
+					return fr.Element{}, err
+				}
+			}
+		}
+	}
+	for i := range dataTranscript {
+		if err := fs.Bind(name, dataTranscript[i]); err != nil {
+			return fr.Element{}, err
+		}
+	}
+	return challenge(fs, name)
+}
+
+// challenge computes the challenge name and converts it to an fr.Element
+func challenge(fs *fiatshamir.Transcript, name string) (fr.Element, error) {
+	b, err := fs.ComputeChallenge(name)
+	if err != nil {
+		return fr.Element{}, err
+	}
+	var res fr.Element
+	res.SetBytes(b)
+	return res, nil
+}
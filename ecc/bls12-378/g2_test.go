@@ -21,6 +21,7 @@ import (
 	"math/big"
 	"testing"
 
+	"github.com/consensys/gnark-crypto/ecc/bls12-378/fp"
 	"github.com/consensys/gnark-crypto/ecc/bls12-378/internal/fptower"
 
 	"github.com/consensys/gnark-crypto/ecc/bls12-378/fr"
@@ -284,6 +285,39 @@ func TestG2AffineOps(t *testing.T) {
 		GenE2(),
 	))
 
+	properties.Property("[BLS12-378] [Jacobian] AddComplete should output the same result as AddAssign", prop.ForAll(
+		func(a, b fptower.E2) bool {
+			fop1 := fuzzG2Jac(&g2Gen, a)
+			fop2 := fuzzG2Jac(&g2Gen, b)
+			var p1, p2, expected, res G2Jac
+			p1.Set(&fop1).DoubleAssign()
+			p2.Set(&fop2).AddAssign(&p1)
+			expected.Set(&p1).AddAssign(&p2)
+			res.AddComplete(&p1, &p2)
+			return res.Equal(&expected)
+		},
+		GenE2(),
+		GenE2(),
+	))
+
+	properties.Property("[BLS12-378] [Jacobian] AddComplete should handle equal, opposite and infinity inputs", prop.ForAll(
+		func(a fptower.E2) bool {
+			fop1 := fuzzG2Jac(&g2Gen, a)
+			var neg, double, sum, zero, inf1, inf2, dc G2Jac
+			neg.Neg(&fop1)
+			double.Double(&g2Gen)
+			sum.AddComplete(&fop1, &g2Gen)
+			zero.AddComplete(&fop1, &neg)
+			inf1.AddComplete(&fop1, &g2Infinity)
+			inf2.Set(&g2Infinity).AddAssignComplete(&fop1)
+			dc.DoubleComplete(&g2Infinity)
+			return sum.Equal(&double) && zero.Equal(&g2Infinity) && inf1.Equal(&g2Gen) &&
+				inf2.Equal(&g2Gen) && dc.Equal(&g2Infinity) &&
+				dc.DoubleComplete(&fop1).Equal(&double)
+		},
+		GenE2(),
+	))
+
 	properties.Property("[BLS12-378] [Jacobian Extended] addMixed (-G) should equal subMixed(G)", prop.ForAll(
 		func(a fptower.E2) bool {
 			fop1 := fuzzG2Jac(&g2Gen, a)
@@ -402,6 +436,64 @@ func TestG2AffineOps(t *testing.T) {
 		genScalar,
 	))
 
+	properties.Property("[BLS12-378] scalar multiplication of the base point should output the same result as scalar multiplication", prop.ForAll(
+		func(s fr.Element) bool {
+
+			var r big.Int
+			var op1, op2 G2Jac
+			var aff1, aff2 G2Affine
+			s.ToBigIntRegular(&r)
+			op1.ScalarMultiplicationBase(&r)
+			op2.ScalarMultiplication(&g2Gen, &r)
+			aff1.ScalarMultiplicationBase(&r)
+			aff2.FromJacobian(&op2)
+			return op1.Equal(&op2) && aff1.Equal(&aff2)
+
+		},
+		genScalar,
+	))
+
+	properties.Property("[BLS12-378] constant time scalar multiplication should output the same result as double and add", prop.ForAll(
+		func(s fr.Element) bool {
+
+			var r big.Int
+			var op1, op2, a G2Jac
+			s.ToBigIntRegular(&r)
+			a.mulWindowed(&g2Gen, &r)
+			op1.mulWindowed(&a, &r)
+			op2.ScalarMultiplicationCT(&a, &r)
+			return op1.Equal(&op2) && !op1.Equal(&g2Infinity)
+
+		},
+		genScalar,
+	))
+
+	properties.Property("[BLS12-378] constant time scalar multiplication should handle 0, r, negative scalars and the point at infinity", prop.ForAll(
+		func(s fr.Element) bool {
+
+			var scalar, neg big.Int
+			var op1, op2, op3, op4, expected G2Jac
+			s.ToBigIntRegular(&scalar)
+			neg.Neg(&scalar)
+			op1.ScalarMultiplicationCT(&g2Gen, big.NewInt(0))
+			op2.ScalarMultiplicationCT(&g2Gen, fr.Modulus())
+			op3.ScalarMultiplicationCT(&g2Infinity, &scalar)
+			op4.ScalarMultiplicationCT(&g2Gen, &neg)
+			expected.mulWindowed(&g2Gen, &scalar).Neg(&expected)
+
+			var aff G2Affine
+			aff.FromJacobian(&g2Gen)
+			aff.ScalarMultiplicationCT(&aff, &scalar)
+			var affExpected G2Affine
+			affExpected.FromJacobian(&expected)
+			affExpected.Neg(&affExpected)
+
+			return op1.Equal(&g2Infinity) && op2.Equal(&g2Infinity) && op3.Equal(&g2Infinity) &&
+				op4.Equal(&expected) && aff.Equal(&affExpected)
+		},
+		genScalar,
+	))
+
 	properties.TestingRun(t, gopter.ConsoleReporter(false))
 }
 
@@ -441,6 +533,103 @@ func TestG2AffineCofactorCleaning(t *testing.T) {
 
 }
 
+func TestG2AffineClearCofactorNaive(t *testing.T) {
+	t.Parallel()
+
+	// ClearCofactor computes h(ψ)P (Budroni–Pintore), which is [h(λ)]P on G2, λ = p mod r
+	// being the eigenvalue of ψ. For a random point P, [c]P is in G2 with c the cofactor,
+	// so we check h(ψ)P = [h(λ)/c]([c]P).
+	p, r := fp.Modulus(), fr.Modulus()
+
+	// x₀ is the signed seed of the curve, such that r divides #E(𝔽p) = p+1-t with t = x₀+1
+	var x0, tmp big.Int
+	x0.Set(&xGen)
+	if tmp.Sub(p, &x0).Mod(&tmp, r).Sign() != 0 {
+		x0.Neg(&x0)
+	}
+
+	// c = #E'(𝔽q)/r, where E' is the sextic twist over 𝔽q
+	var q, tq, f big.Int
+	q.Set(p)
+	tq.Add(&x0, big.NewInt(1))
+	for i := 0; i < 1; i++ {
+		// t_{q²} = t_q² - 2q
+		tmp.Lsh(&q, 1)
+		tq.Mul(&tq, &tq).Sub(&tq, &tmp)
+		q.Mul(&q, &q)
+	}
+	// t_q² - 4q = -3f²
+	f.Lsh(&q, 2)
+	tmp.Mul(&tq, &tq)
+	f.Sub(&f, &tmp).Div(&f, big.NewInt(3)).Sqrt(&f)
+	var c big.Int
+	found := false
+	for _, s := range []int64{1, -1} {
+		for _, sf := range []int64{3, -3} {
+			var n, u big.Int
+			n.Mul(&tq, big.NewInt(s))
+			u.Mul(&f, big.NewInt(sf))
+			n.Add(&n, &u).Rsh(&n, 1)
+			n.Sub(&q, &n).Add(&n, big.NewInt(1))
+			if !found && tmp.Mod(&n, r).Sign() == 0 {
+				c.Div(&n, r)
+				found = true
+			}
+		}
+	}
+	if !found {
+		t.Fatal("couldn't compute the order of the twist")
+	}
+
+	// h(λ) = (x₀²-x₀-1) + (x₀-1)λ + 2λ²
+	var lambda, h, xMinusOne, coeff, lambdai big.Int
+	lambda.Mod(p, r)
+	xMinusOne.Sub(&x0, big.NewInt(1))
+	coeffs := make([]big.Int, 3)
+	coeffs[1].Set(&xMinusOne)
+	coeffs[0].Mul(&coeffs[1], &x0).Sub(&coeffs[0], big.NewInt(1))
+	coeffs[2].SetInt64(2)
+	lambdai.SetInt64(1)
+	for i := range coeffs {
+		coeff.Mul(&coeffs[i], &lambdai)
+		h.Add(&h, &coeff)
+		lambdai.Mul(&lambdai, &lambda)
+	}
+
+	// k = h(λ)/c mod r
+	var k big.Int
+	k.ModInverse(tmp.Mod(&c, r), r)
+	k.Mul(&k, &h).Mod(&k, r)
+
+	for i := 0; i < 5; i++ {
+		// random point on the twist
+		var a, x, y fptower.E2
+		for {
+			a.SetRandom()
+			x.Square(&a).Mul(&x, &a).Add(&x, &bTwistCurveCoeff)
+			if x.Legendre() == 1 {
+				break
+			}
+		}
+		y.Sqrt(&x)
+		var point, cleared, naive G2Jac
+		point.X.Set(&a)
+		point.Y.Set(&y)
+		point.Z.SetOne()
+
+		cleared.ClearCofactor(&point)
+		// not ScalarMultiplication, which uses the GLV decomposition, only valid in G2
+		naive.mulWindowed(&point, &c)
+		if !naive.IsInSubGroup() {
+			t.Fatal("[c]P should be in G2")
+		}
+		naive.ScalarMultiplication(&naive, &k)
+		if !cleared.Equal(&naive) {
+			t.Fatal("ClearCofactor(P) != [h(λ)/c]([c]P)")
+		}
+	}
+}
+
 func TestG2AffineBatchScalarMultiplication(t *testing.T) {
 
 	parameters := gopter.DefaultTestParameters()
@@ -557,6 +746,14 @@ func BenchmarkG2JacScalarMultiplication(b *testing.B) {
 		}
 	})
 
+	var ct G2Jac
+	b.Run("constant time", func(b *testing.B) {
+		b.ResetTimer()
+		for j := 0; j < b.N; j++ {
+			ct.ScalarMultiplicationCT(&g2Gen, &scalar)
+		}
+	})
+
 }
 
 func BenchmarkG2AffineCofactorClearing(b *testing.B) {
@@ -0,0 +1,92 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package bls12378
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-378/fr"
+	"github.com/leanovate/gopter"
+	"github.com/leanovate/gopter/prop"
+)
+
+func TestSplitScalarGLV(t *testing.T) {
+	t.Parallel()
+	parameters := gopter.DefaultTestParameters()
+	if testing.Short() {
+		parameters.MinSuccessfulTests = nbFuzzShort
+	} else {
+		parameters.MinSuccessfulTests = nbFuzz
+	}
+
+	properties := gopter.NewProperties(parameters)
+
+	lambda := GLVLambda()
+
+	properties.Property("[GLV] k₁ + k₂⋅λ should be equal to s mod r", prop.ForAll(
+		func(a fr.Element) bool {
+			var s, acc big.Int
+			a.ToBigIntRegular(&s)
+			k := SplitScalarGLV(&s)
+			acc.Mul(&k[1], lambda).Add(&acc, &k[0]).Sub(&acc, &s).Mod(&acc, fr.Modulus())
+			return acc.Sign() == 0
+		},
+		GenFr(),
+	))
+
+	properties.Property("[GLV] k₁ and k₂ should be about half the size of r", prop.ForAll(
+		func(a fr.Element) bool {
+			var s big.Int
+			a.ToBigIntRegular(&s)
+			k := SplitScalarGLV(&s)
+			bound := (fr.Bits+1)/2 + 2
+			return k[0].BitLen() <= bound && k[1].BitLen() <= bound
+		},
+		GenFr(),
+	))
+
+	properties.Property("[GLV] [k₁]P + [k₂]ϕ(P) should be equal to [s]P", prop.ForAll(
+		func(a fr.Element) bool {
+			var s big.Int
+			a.ToBigIntRegular(&s)
+			k := SplitScalarGLV(&s)
+
+			var p1, p2, gen, phiGen, expected G1Jac
+			gen.Set(&g1Gen)
+			phiGen.phi(&g1Gen)
+			// mulWindowed ignores the sign of the scalar
+			if k[0].Sign() == -1 {
+				k[0].Neg(&k[0])
+				gen.Neg(&gen)
+			}
+			if k[1].Sign() == -1 {
+				k[1].Neg(&k[1])
+				phiGen.Neg(&phiGen)
+			}
+			p1.mulWindowed(&gen, &k[0])
+			p2.mulWindowed(&phiGen, &k[1])
+			p1.AddAssign(&p2)
+			expected.mulWindowed(&g1Gen, &s)
+
+			return p1.Equal(&expected)
+		},
+		GenFr(),
+	))
+
+	properties.TestingRun(t, gopter.ConsoleReporter(false))
+}
@@ -25,50 +25,87 @@ import (
 
 //Note: This only works for simple extensions
 
+// coefficients of the rational maps of the isogeny E' → E, in increasing degree.
+// The denominators are monic, their leading coefficient is omitted.
+var (
+	g1IsogenyXNumeratorMap = []fp.Element{
+		{6470543203100547353, 14665988170059032885, 1939515187828768580, 3603560708219821999, 1095026321559208988, 183854362073986052},
+		{14854739450145697573, 10725610731781000990, 8384146146676813741, 3792524792234600218, 10980132992245118504, 127811114313784269},
+		{7743341424938057712, 1621446876320497654, 9161388112343345155, 13809747965729688982, 14072110284352226775, 77964074263176954},
+	}
+	g1IsogenyXDenominatorMap = []fp.Element{
+		{11480213446153845907, 9569059723295472762, 4133212223950692662, 5656914875334883651, 989021686692303102, 227886835744873894},
+	}
+	g1IsogenyYNumeratorMap = []fp.Element{
+		{4780610586872381554, 18423150125994475588, 13123772012819260137, 14591853195646969647, 5804992647820306014, 20966723178287685},
+		{2213410472918458692, 9774999437004642556, 13319410716190829459, 14774058963459238058, 12357489533274646735, 248758148590692830},
+		{16759481071713625783, 8645096532612011693, 7097905075491715268, 932195041550141716, 4227816384078368107, 50037860715544812},
+		{3871670712469028856, 10034095475015024635, 4580694056171672577, 16128246019719620299, 7036055142176113387, 38982037131588477},
+	}
+	g1IsogenyYDenominatorMap = []fp.Element{
+		{17641076928456688137, 8306475833976684855, 8359419817241119003, 12641605213272639883, 9863039736160487870, 55368217170706106},
+		{17969127027499495035, 2884196005202455728, 15703691879418613809, 10094567750702434230, 12004334191193297464, 175264043429118194},
+		{12350127924441855415, 17380644983358010734, 8933124167467608227, 16391120112507168124, 9337764864048325557, 116945264214095313},
+	}
+)
+
+// coefficients A', B' of the curve E': y² = x³ + A'x + B' isogenous to E, on which the SSWU map is computed,
+// and the non-square Z of the map
+var (
+	g1SSWUIsoCurveCoeffA = fp.Element{15314533651602404840, 3999629397495592995, 17991228730268553058, 13253234862282888158, 4784493033884022421, 276795783356562829}
+	g1SSWUIsoCurveCoeffB = fp.Element{10499526804702755432, 6768914877862902950, 8287496811509120276, 9263962031121981469, 5075273437274786541, 60255618913255595}
+	g1SSWUZ              = fp.Element{5249763402351377716, 3384457438931451475, 13367120442609335946, 13855353052415766542, 11761008755492169078, 30127809456627797}
+)
+
+// G1SSWUConstants returns the coefficients A', B' of the curve E': y² = x³ + A'x + B' isogenous to
+// the curve of G1, and the constant Z of the simplified SWU map to E'.
+// https://www.rfc-editor.org/rfc/rfc9380.html#name-simplified-swu-for-ab-0
+func G1SSWUConstants() (a, b, z fp.Element) {
+	return g1SSWUIsoCurveCoeffA, g1SSWUIsoCurveCoeffB, g1SSWUZ
+}
+
+// G1IsogenyCoefficients returns the coefficients of the rational maps of the 2-isogeny
+// (x, y) ↦ (xNum(x)/xDen(x), y·yNum(x)/yDen(x)) from E' to the curve of G1, in increasing degree.
+// The denominators are monic, their leading coefficient 1 is omitted.
+// The slices are copies, they can be modified freely.
+func G1IsogenyCoefficients() (xNum, xDen, yNum, yDen []fp.Element) {
+	xNum = append([]fp.Element(nil), g1IsogenyXNumeratorMap...)
+	xDen = append([]fp.Element(nil), g1IsogenyXDenominatorMap...)
+	yNum = append([]fp.Element(nil), g1IsogenyYNumeratorMap...)
+	yDen = append([]fp.Element(nil), g1IsogenyYDenominatorMap...)
+	return
+}
+
+// G1EvalSSWU evaluates the simplified SWU map at u. The result is a point of E', not of
+// the curve of G1; MapToG1(u) is G1EvalIsogeny(G1EvalSSWU(u)) with the cofactor cleared.
+func G1EvalSSWU(u *fp.Element) G1Affine {
+	return mapToCurve1(u)
+}
+
+// G1EvalIsogeny evaluates the isogeny E' → E at p, a point of E'
+func G1EvalIsogeny(p *G1Affine) G1Affine {
+	res := *p
+	g1Isogeny(&res)
+	return res
+}
+
 func g1IsogenyXNumerator(dst *fp.Element, x *fp.Element) {
-	g1EvalPolynomial(dst,
-		false,
-		[]fp.Element{
-			{6470543203100547353, 14665988170059032885, 1939515187828768580, 3603560708219821999, 1095026321559208988, 183854362073986052},
-			{14854739450145697573, 10725610731781000990, 8384146146676813741, 3792524792234600218, 10980132992245118504, 127811114313784269},
-			{7743341424938057712, 1621446876320497654, 9161388112343345155, 13809747965729688982, 14072110284352226775, 77964074263176954},
-		},
-		x)
+	g1EvalPolynomial(dst, false, g1IsogenyXNumeratorMap, x)
 }
 
 func g1IsogenyXDenominator(dst *fp.Element, x *fp.Element) {
-	g1EvalPolynomial(dst,
-		true,
-		[]fp.Element{
-			{11480213446153845907, 9569059723295472762, 4133212223950692662, 5656914875334883651, 989021686692303102, 227886835744873894},
-		},
-		x)
+	g1EvalPolynomial(dst, true, g1IsogenyXDenominatorMap, x)
 }
 
 func g1IsogenyYNumerator(dst *fp.Element, x *fp.Element, y *fp.Element) {
 	var _dst fp.Element
-	g1EvalPolynomial(&_dst,
-		false,
-		[]fp.Element{
-			{4780610586872381554, 18423150125994475588, 13123772012819260137, 14591853195646969647, 5804992647820306014, 20966723178287685},
-			{2213410472918458692, 9774999437004642556, 13319410716190829459, 14774058963459238058, 12357489533274646735, 248758148590692830},
-			{16759481071713625783, 8645096532612011693, 7097905075491715268, 932195041550141716, 4227816384078368107, 50037860715544812},
-			{3871670712469028856, 10034095475015024635, 4580694056171672577, 16128246019719620299, 7036055142176113387, 38982037131588477},
-		},
-		x)
+	g1EvalPolynomial(&_dst, false, g1IsogenyYNumeratorMap, x)
 
 	dst.Mul(&_dst, y)
 }
 
 func g1IsogenyYDenominator(dst *fp.Element, x *fp.Element) {
-	g1EvalPolynomial(dst,
-		true,
-		[]fp.Element{
-			{17641076928456688137, 8306475833976684855, 8359419817241119003, 12641605213272639883, 9863039736160487870, 55368217170706106},
-			{17969127027499495035, 2884196005202455728, 15703691879418613809, 10094567750702434230, 12004334191193297464, 175264043429118194},
-			{12350127924441855415, 17380644983358010734, 8933124167467608227, 16391120112507168124, 9337764864048325557, 116945264214095313},
-		},
-		x)
+	g1EvalPolynomial(dst, true, g1IsogenyYDenominatorMap, x)
 }
 
 func g1Isogeny(p *G1Affine) {
@@ -173,9 +210,6 @@ func g1MulByZ(z *fp.Element, x *fp.Element) {
 // No cofactor clearing or isogeny
 func mapToCurve1(u *fp.Element) G1Affine {
 
-	var sswuIsoCurveCoeffA = fp.Element{15314533651602404840, 3999629397495592995, 17991228730268553058, 13253234862282888158, 4784493033884022421, 276795783356562829}
-	var sswuIsoCurveCoeffB = fp.Element{10499526804702755432, 6768914877862902950, 8287496811509120276, 9263962031121981469, 5075273437274786541, 60255618913255595}
-
 	var tv1 fp.Element
 	tv1.Square(u) // 1.  tv1 = u²
 
@@ -189,17 +223,17 @@ func mapToCurve1(u *fp.Element) G1Affine {
 	var tv3 fp.Element
 	var tv4 fp.Element
 	tv4.SetOne()
-	tv3.Add(&tv2, &tv4)                // 5.  tv3 = tv2 + 1
-	tv3.Mul(&tv3, &sswuIsoCurveCoeffB) // 6.  tv3 = B * tv3
+	tv3.Add(&tv2, &tv4)                  // 5.  tv3 = tv2 + 1
+	tv3.Mul(&tv3, &g1SSWUIsoCurveCoeffB) // 6.  tv3 = B * tv3
 
 	tv2NZero := g1NotZero(&tv2)
 
 	// tv4 = Z
-	tv4 = fp.Element{5249763402351377716, 3384457438931451475, 13367120442609335946, 13855353052415766542, 11761008755492169078, 30127809456627797}
+	tv4 = g1SSWUZ
 
 	tv2.Neg(&tv2)
 	tv4.Select(int(tv2NZero), &tv4, &tv2) // 7.  tv4 = CMOV(Z, -tv2, tv2 != 0)
-	tv4.Mul(&tv4, &sswuIsoCurveCoeffA)    // 8.  tv4 = A * tv4
+	tv4.Mul(&tv4, &g1SSWUIsoCurveCoeffA)  // 8.  tv4 = A * tv4
 
 	tv2.Square(&tv3) // 9.  tv2 = tv3²
 
@@ -207,14 +241,14 @@ func mapToCurve1(u *fp.Element) G1Affine {
 	tv6.Square(&tv4) // 10. tv6 = tv4²
 
 	var tv5 fp.Element
-	tv5.Mul(&tv6, &sswuIsoCurveCoeffA) // 11. tv5 = A * tv6
+	tv5.Mul(&tv6, &g1SSWUIsoCurveCoeffA) // 11. tv5 = A * tv6
 
 	tv2.Add(&tv2, &tv5) // 12. tv2 = tv2 + tv5
 	tv2.Mul(&tv2, &tv3) // 13. tv2 = tv2 * tv3
 	tv6.Mul(&tv6, &tv4) // 14. tv6 = tv6 * tv4
 
-	tv5.Mul(&tv6, &sswuIsoCurveCoeffB) // 15. tv5 = B * tv6
-	tv2.Add(&tv2, &tv5)                // 16. tv2 = tv2 + tv5
+	tv5.Mul(&tv6, &g1SSWUIsoCurveCoeffB) // 15. tv5 = B * tv6
+	tv2.Add(&tv2, &tv5)                  // 16. tv2 = tv2 + tv5
 
 	var x fp.Element
 	x.Mul(&tv1, &tv3) // 17.   x = tv1 * tv3
@@ -282,11 +316,8 @@ func hashToFp(msg, dst []byte, count int) ([]fp.Element, error) {
 // https://www.ietf.org/archive/id/draft-irtf-cfrg-hash-to-curve-16.html#name-the-sgn0-function
 // The sign of an element is not obviously related to that of its Montgomery form
 func g1Sgn0(z *fp.Element) uint64 {
-
-	nonMont := *z
-	nonMont.FromMont()
 	// m == 1
-	return nonMont[0] % 2
+	return z.Sign0()
 
 }
 
@@ -299,24 +330,36 @@ func MapToG1(u fp.Element) G1Affine {
 	return res
 }
 
+// G1HashTrace holds the intermediate values of HashToG1 and EncodeToG1,
+// named as in the test vectors of RFC 9380 (appendix J), to compare an implementation
+// or debug the handling of a DST against them.
+type G1HashTrace struct {
+	// U is the output of hash_to_field: one element for EncodeToG1, two for HashToG1
+	U []fp.Element
+
+	// Q[i] is map_to_curve(U[i]), after the isogeny, before clearing the cofactor
+	Q []G1Affine
+}
+
 // EncodeToG1 hashes a message to a point on the G1 curve using the SSWU map.
 // It is faster than HashToG1, but the result is not uniformly distributed. Unsuitable as a random oracle.
 // dst stands for "domain separation tag", a string unique to the construction using the hash function
 //https://www.ietf.org/archive/id/draft-irtf-cfrg-hash-to-curve-16.html#roadmap
 func EncodeToG1(msg, dst []byte) (G1Affine, error) {
+	res, _, err := EncodeToG1Trace(msg, dst)
+	return res, err
+}
 
-	var res G1Affine
-	u, err := hashToFp(msg, dst, 1)
+// EncodeToG1Trace is EncodeToG1, which also returns its intermediate values.
+func EncodeToG1Trace(msg, dst []byte) (G1Affine, G1HashTrace, error) {
+	trace, err := g1HashToCurveTrace(msg, dst, 1)
 	if err != nil {
-		return res, err
+		return G1Affine{}, trace, err
 	}
 
-	res = mapToCurve1(&u[0])
-
-	//this is in an isogenous curve
-	g1Isogeny(&res)
+	res := trace.Q[0]
 	res.ClearCofactor(&res)
-	return res, nil
+	return res, trace, nil
 }
 
 // HashToG1 hashes a message to a point on the G1 curve using the SSWU map.
@@ -324,26 +367,45 @@ func EncodeToG1(msg, dst []byte) (G1Affine, error) {
 // dst stands for "domain separation tag", a string unique to the construction using the hash function
 //https://www.ietf.org/archive/id/draft-irtf-cfrg-hash-to-curve-16.html#roadmap
 func HashToG1(msg, dst []byte) (G1Affine, error) {
-	u, err := hashToFp(msg, dst, 2*1)
+	res, _, err := HashToG1Trace(msg, dst)
+	return res, err
+}
+
+// HashToG1Trace is HashToG1, which also returns its intermediate values.
+func HashToG1Trace(msg, dst []byte) (G1Affine, G1HashTrace, error) {
+	trace, err := g1HashToCurveTrace(msg, dst, 2)
 	if err != nil {
-		return G1Affine{}, err
+		return G1Affine{}, trace, err
 	}
-
-	Q0 := mapToCurve1(&u[0])
-	Q1 := mapToCurve1(&u[1])
-
 	//TODO (perf): Add in E' first, then apply isogeny
-	g1Isogeny(&Q0)
-	g1Isogeny(&Q1)
-
 	var _Q0, _Q1 G1Jac
-	_Q0.FromAffine(&Q0)
-	_Q1.FromAffine(&Q1).AddAssign(&_Q0)
+	_Q0.FromAffine(&trace.Q[0])
+	_Q1.FromAffine(&trace.Q[1]).AddAssign(&_Q0)
 
 	_Q1.ClearCofactor(&_Q1)
 
-	Q1.FromJacobian(&_Q1)
-	return Q1, nil
+	var res G1Affine
+	res.FromJacobian(&_Q1)
+	return res, trace, nil
+}
+
+// g1HashToCurveTrace hashes msg to count elements of the base field of G1, and maps them to the curve
+func g1HashToCurveTrace(msg, dst []byte, count int) (G1HashTrace, error) {
+	var trace G1HashTrace
+	u, err := hashToFp(msg, dst, count*1)
+	if err != nil {
+		return trace, err
+	}
+
+	trace.U = make([]fp.Element, count)
+	trace.Q = make([]G1Affine, count)
+	for i := 0; i < count; i++ {
+		trace.U[i] = u[i]
+		trace.Q[i] = mapToCurve1(&trace.U[i])
+		//this is in an isogenous curve
+		g1Isogeny(&trace.Q[i])
+	}
+	return trace, nil
 }
 
 func g1NotZero(x *fp.Element) uint64 {
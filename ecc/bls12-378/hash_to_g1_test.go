@@ -135,6 +135,75 @@ func TestMapToCurve1(t *testing.T) {
 	}
 }
 
+func TestG1SSWUConstants(t *testing.T) {
+	t.Parallel()
+
+	a, b, z := G1SSWUConstants()
+
+	var A, B fp.Element
+
+	A.SetString(
+		"605248206075306169267378178265213465432939015833023814077444651494632463253805259225148550772266952686222752874482",
+	)
+
+	B.SetString(
+		"22",
+	)
+
+	if !a.Equal(&A) || !b.Equal(&B) {
+		t.Fatal("unexpected coefficients of E'")
+	}
+
+	var x, expected, seen fp.Element
+	x.SetRandom()
+	expected.Mul(&x, &z)
+	g1MulByZ(&seen, &x)
+	if !seen.Equal(&expected) {
+		t.Fatal("unexpected Z")
+	}
+}
+
+func TestG1EvalIsogeny(t *testing.T) {
+	t.Parallel()
+
+	xNum, xDen, yNum, yDen := G1IsogenyCoefficients()
+	// evaluates the polynomial of coefficients c at x, with an implicit leading coefficient 1 if monic
+	eval := func(c []fp.Element, x *fp.Element, monic bool) fp.Element {
+		var res, xi fp.Element
+		xi.SetOne()
+		for i := range c {
+			var tmp fp.Element
+			tmp.Mul(&c[i], &xi)
+			res.Add(&res, &tmp)
+			xi.Mul(&xi, x)
+		}
+		if monic {
+			res.Add(&res, &xi)
+		}
+		return res
+	}
+
+	for i := 0; i < 10; i++ {
+		var u fp.Element
+		u.SetRandom()
+		p := G1EvalSSWU(&u)
+		if !isOnE1Prime(p) {
+			t.Fatal("SSWU output not on E'")
+		}
+
+		var expected G1Affine
+		num, den := eval(xNum, &p.X, false), eval(xDen, &p.X, true)
+		expected.X.Div(&num, &den)
+		num, den = eval(yNum, &p.X, false), eval(yDen, &p.X, true)
+		expected.Y.Div(&num, &den).Mul(&expected.Y, &p.Y)
+
+		seen := G1EvalIsogeny(&p)
+		if !seen.Equal(&expected) || !seen.IsOnCurve() {
+			t.Fatal("unexpected image by the isogeny")
+		}
+	}
+}
+
 func TestMapToG1(t *testing.T) {
 	t.Parallel()
 	parameters := gopter.DefaultTestParameters()
@@ -188,6 +257,40 @@ func TestHashToG1(t *testing.T) {
 	}
 }
 
+func TestEncodeToG1Trace(t *testing.T) {
+	t.Parallel()
+	for _, c := range encodeToG1Vector.cases {
+		p, trace, err := EncodeToG1Trace([]byte(c.msg), encodeToG1Vector.dst)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(trace.U) != 1 || len(trace.Q) != 1 {
+			t.Fatal("expected one field element and one point in the trace")
+		}
+		g1TestMatchCoord(t, "u", c.msg, c.u, trace.U[0])
+		g1TestMatchPoint(t, "Q", c.msg, c.Q, &trace.Q[0])
+		g1TestMatchPoint(t, "P", c.msg, c.P, &p)
+	}
+}
+
+func TestHashToG1Trace(t *testing.T) {
+	t.Parallel()
+	for _, c := range hashToG1Vector.cases {
+		p, trace, err := HashToG1Trace([]byte(c.msg), hashToG1Vector.dst)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(trace.U) != 2 || len(trace.Q) != 2 {
+			t.Fatal("expected two field elements and two points in the trace")
+		}
+		g1TestMatchCoord(t, "u0", c.msg, c.u0, trace.U[0])
+		g1TestMatchCoord(t, "u1", c.msg, c.u1, trace.U[1])
+		g1TestMatchPoint(t, "Q0", c.msg, c.Q0, &trace.Q[0])
+		g1TestMatchPoint(t, "Q1", c.msg, c.Q1, &trace.Q[1])
+		g1TestMatchPoint(t, "P", c.msg, c.P, &p)
+	}
+}
+
 func BenchmarkEncodeToG1(b *testing.B) {
 	const size = 54
 	bytes := make([]byte, size)
@@ -18,6 +18,7 @@ package bls12378
 
 import (
 	"bytes"
+	"errors"
 	"io"
 	"math/big"
 	"math/rand"
@@ -26,6 +27,7 @@ import (
 	"github.com/leanovate/gopter"
 	"github.com/leanovate/gopter/prop"
 
+	"github.com/consensys/gnark-crypto/ecc"
 	"github.com/consensys/gnark-crypto/ecc/bls12-378/fp"
 	"github.com/consensys/gnark-crypto/ecc/bls12-378/fr"
 	"github.com/consensys/gnark-crypto/ecc/bls12-378/internal/fptower"
@@ -301,6 +303,188 @@ func TestG1AffineSerialization(t *testing.T) {
 	properties.TestingRun(t, gopter.ConsoleReporter(false))
 }
 
+func TestG1AffineSetBytesChecked(t *testing.T) {
+	// not parallel, as it measures allocations
+	var p, q G1Affine
+	var s big.Int
+	s.SetUint64(rand.Uint64()) //#nosec G404 weak rng is fine here
+	p.ScalarMultiplication(&g1GenAff, &s)
+
+	// round trips, for p and the infinity point
+	var inf G1Affine
+	for _, a := range []*G1Affine{&p, &inf} {
+		compressed, raw := a.Bytes(), a.RawBytes()
+		for _, buf := range [][]byte{compressed[:], raw[:]} {
+			q.X.SetOne()
+			if err := q.SetBytesChecked(buf); err != nil {
+				t.Fatal(err)
+			}
+			if !q.Equal(a) {
+				t.Fatal("SetBytesChecked should match Bytes and RawBytes")
+			}
+		}
+	}
+
+	expectErr := func(buf []byte, target error) {
+		t.Helper()
+		if err := q.SetBytesChecked(buf); !errors.Is(err, target) {
+			t.Fatalf("expected %v, got %v", target, err)
+		}
+	}
+
+	// wrong lengths
+	compressed, raw := p.Bytes(), p.RawBytes()
+	expectErr(nil, io.ErrShortBuffer)
+	expectErr(compressed[:SizeOfG1AffineCompressed-1], io.ErrShortBuffer)
+	expectErr(raw[:SizeOfG1AffineCompressed], io.ErrShortBuffer)
+	expectErr(append(compressed[:], 0), ecc.ErrNonCanonicalEncoding)
+
+	// the first coordinate is larger than the modulus
+	{
+		buf := compressed
+		for i := range buf {
+			buf[i] = 0xff
+		}
+		buf[0] = mCompressedSmallest | ^mMask
+		expectErr(buf[:], ecc.ErrNonCanonicalEncoding)
+	}
+
+	// the infinity point must be encoded with zeroes
+	{
+		buf := inf.Bytes()
+		buf[len(buf)-1] = 1
+		expectErr(buf[:], ecc.ErrNonCanonicalEncoding)
+	}
+	{
+		buf := p.Bytes()
+		buf[0] |= mMask
+		expectErr(buf[:], ecc.ErrNonCanonicalEncoding)
+	}
+
+	// uncompressed point which is not on the curve
+	{
+		r := p
+		r.Y.Double(&r.Y)
+		buf := r.RawBytes()
+		expectErr(buf[:], ecc.ErrPointNotOnCurve)
+	}
+
+	// compressed x coordinate for which the curve equation has no solution,
+	// and point on the curve which is not in the subgroup (if the cofactor is not 1)
+	foundOffCurve := false
+	for i := 0; i < 100; i++ {
+		var r G1Affine
+		var YSquared fp.Element
+		if _, err := r.X.SetRandom(); err != nil {
+			t.Fatal(err)
+		}
+		YSquared.Square(&r.X).Mul(&YSquared, &r.X)
+		YSquared.Add(&YSquared, &bCurveCoeff)
+		if YSquared.Legendre() == -1 {
+			if !foundOffCurve {
+				buf := r.Bytes()
+				expectErr(buf[:], ecc.ErrPointNotOnCurve)
+				foundOffCurve = true
+			}
+			continue
+		}
+		r.Y.Sqrt(&YSquared)
+		if !r.IsInSubGroup() {
+			buf := r.Bytes()
+			expectErr(buf[:], ecc.ErrPointNotInSubGroup)
+			rawBuf := r.RawBytes()
+			expectErr(rawBuf[:], ecc.ErrPointNotInSubGroup)
+			break
+		}
+	}
+
+	// encoding doesn't allocate
+	allocs := testing.AllocsPerRun(10, func() {
+		compressed, raw = p.Bytes(), p.RawBytes()
+	})
+	if allocs != 0 {
+		t.Fatalf("Bytes and RawBytes should not allocate, got %v allocations", allocs)
+	}
+}
+
+func TestUnmarshalBatchG1Affine(t *testing.T) {
+	t.Parallel()
+	const n = 20
+	points := make([]G1Affine, n)
+	var s big.Int
+	for i := 1; i < n; i++ {
+		s.SetUint64(rand.Uint64()) //#nosec G404 weak rng is fine here
+		points[i].ScalarMultiplication(&g1GenAff, &s)
+	}
+
+	var compressed, raw []byte
+	for i := range points {
+		c, r := points[i].Bytes(), points[i].RawBytes()
+		compressed = append(compressed, c[:]...)
+		raw = append(raw, r[:]...)
+	}
+
+	for _, buf := range [][]byte{compressed, raw} {
+		decoded, err := UnmarshalBatchG1Affine(buf, true)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(decoded) != n {
+			t.Fatalf("expected %d points, got %d", n, len(decoded))
+		}
+		for i := range points {
+			if !decoded[i].Equal(&points[i]) {
+				t.Fatalf("point %d doesn't match", i)
+			}
+		}
+	}
+
+	if decoded, err := UnmarshalBatchG1Affine(nil, true); err != nil || len(decoded) != 0 {
+		t.Fatal("decoding an empty buffer should return no points")
+	}
+
+	// the buffer doesn't contain a whole number of points
+	if _, err := UnmarshalBatchG1Affine(compressed[:len(compressed)-1], true); !errors.Is(err, ecc.ErrNonCanonicalEncoding) {
+		t.Fatalf("expected %v, got %v", ecc.ErrNonCanonicalEncoding, err)
+	}
+
+	// the first invalid point is reported
+	expectIndex := func(buf []byte, index int) {
+		t.Helper()
+		_, err := UnmarshalBatchG1Affine(buf, true)
+		var indexedErr *ecc.IndexedError
+		if !errors.As(err, &indexedErr) || indexedErr.Index != index {
+			t.Fatalf("expected an error at index %d, got %v", index, err)
+		}
+	}
+
+	// uncompressed point in a buffer of compressed points
+	{
+		buf := append([]byte{}, compressed...)
+		buf[7*SizeOfG1AffineCompressed] &^= mMask
+		buf[15*SizeOfG1AffineCompressed] &^= mMask
+		expectIndex(buf, 7)
+	}
+
+	// compressed x coordinate for which the curve equation has no solution
+	for {
+		var r G1Affine
+		var YSquared fp.Element
+		if _, err := r.X.SetRandom(); err != nil {
+			t.Fatal(err)
+		}
+		YSquared.Square(&r.X).Mul(&YSquared, &r.X)
+		YSquared.Add(&YSquared, &bCurveCoeff)
+		if YSquared.Legendre() == -1 {
+			buf := append([]byte{}, compressed...)
+			c := r.Bytes()
+			copy(buf[11*SizeOfG1AffineCompressed:], c[:])
+			expectIndex(buf, 11)
+			break
+		}
+	}
+}
+
 func TestG2AffineSerialization(t *testing.T) {
 	t.Parallel()
 	// test round trip serialization of infinity
@@ -394,6 +578,188 @@ func TestG2AffineSerialization(t *testing.T) {
 	properties.TestingRun(t, gopter.ConsoleReporter(false))
 }
 
+func TestG2AffineSetBytesChecked(t *testing.T) {
+	// not parallel, as it measures allocations
+	var p, q G2Affine
+	var s big.Int
+	s.SetUint64(rand.Uint64()) //#nosec G404 weak rng is fine here
+	p.ScalarMultiplication(&g2GenAff, &s)
+
+	// round trips, for p and the infinity point
+	var inf G2Affine
+	for _, a := range []*G2Affine{&p, &inf} {
+		compressed, raw := a.Bytes(), a.RawBytes()
+		for _, buf := range [][]byte{compressed[:], raw[:]} {
+			q.X.SetOne()
+			if err := q.SetBytesChecked(buf); err != nil {
+				t.Fatal(err)
+			}
+			if !q.Equal(a) {
+				t.Fatal("SetBytesChecked should match Bytes and RawBytes")
+			}
+		}
+	}
+
+	expectErr := func(buf []byte, target error) {
+		t.Helper()
+		if err := q.SetBytesChecked(buf); !errors.Is(err, target) {
+			t.Fatalf("expected %v, got %v", target, err)
+		}
+	}
+
+	// wrong lengths
+	compressed, raw := p.Bytes(), p.RawBytes()
+	expectErr(nil, io.ErrShortBuffer)
+	expectErr(compressed[:SizeOfG2AffineCompressed-1], io.ErrShortBuffer)
+	expectErr(raw[:SizeOfG2AffineCompressed], io.ErrShortBuffer)
+	expectErr(append(compressed[:], 0), ecc.ErrNonCanonicalEncoding)
+
+	// the first coordinate is larger than the modulus
+	{
+		buf := compressed
+		for i := range buf {
+			buf[i] = 0xff
+		}
+		buf[0] = mCompressedSmallest | ^mMask
+		expectErr(buf[:], ecc.ErrNonCanonicalEncoding)
+	}
+
+	// the infinity point must be encoded with zeroes
+	{
+		buf := inf.Bytes()
+		buf[len(buf)-1] = 1
+		expectErr(buf[:], ecc.ErrNonCanonicalEncoding)
+	}
+	{
+		buf := p.Bytes()
+		buf[0] |= mMask
+		expectErr(buf[:], ecc.ErrNonCanonicalEncoding)
+	}
+
+	// uncompressed point which is not on the curve
+	{
+		r := p
+		r.Y.Double(&r.Y)
+		buf := r.RawBytes()
+		expectErr(buf[:], ecc.ErrPointNotOnCurve)
+	}
+
+	// compressed x coordinate for which the curve equation has no solution,
+	// and point on the curve which is not in the subgroup (if the cofactor is not 1)
+	foundOffCurve := false
+	for i := 0; i < 100; i++ {
+		var r G2Affine
+		var YSquared fptower.E2
+		if _, err := r.X.SetRandom(); err != nil {
+			t.Fatal(err)
+		}
+		YSquared.Square(&r.X).Mul(&YSquared, &r.X)
+		YSquared.Add(&YSquared, &bTwistCurveCoeff)
+		if YSquared.Legendre() == -1 {
+			if !foundOffCurve {
+				buf := r.Bytes()
+				expectErr(buf[:], ecc.ErrPointNotOnCurve)
+				foundOffCurve = true
+			}
+			continue
+		}
+		r.Y.Sqrt(&YSquared)
+		if !r.IsInSubGroup() {
+			buf := r.Bytes()
+			expectErr(buf[:], ecc.ErrPointNotInSubGroup)
+			rawBuf := r.RawBytes()
+			expectErr(rawBuf[:], ecc.ErrPointNotInSubGroup)
+			break
+		}
+	}
+
+	// encoding doesn't allocate
+	allocs := testing.AllocsPerRun(10, func() {
+		compressed, raw = p.Bytes(), p.RawBytes()
+	})
+	if allocs != 0 {
+		t.Fatalf("Bytes and RawBytes should not allocate, got %v allocations", allocs)
+	}
+}
+
+func TestUnmarshalBatchG2Affine(t *testing.T) {
+	t.Parallel()
+	const n = 20
+	points := make([]G2Affine, n)
+	var s big.Int
+	for i := 1; i < n; i++ {
+		s.SetUint64(rand.Uint64()) //#nosec G404 weak rng is fine here
+		points[i].ScalarMultiplication(&g2GenAff, &s)
+	}
+
+	var compressed, raw []byte
+	for i := range points {
+		c, r := points[i].Bytes(), points[i].RawBytes()
+		compressed = append(compressed, c[:]...)
+		raw = append(raw, r[:]...)
+	}
+
+	for _, buf := range [][]byte{compressed, raw} {
+		decoded, err := UnmarshalBatchG2Affine(buf, true)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(decoded) != n {
+			t.Fatalf("expected %d points, got %d", n, len(decoded))
+		}
+		for i := range points {
+			if !decoded[i].Equal(&points[i]) {
+				t.Fatalf("point %d doesn't match", i)
+			}
+		}
+	}
+
+	if decoded, err := UnmarshalBatchG2Affine(nil, true); err != nil || len(decoded) != 0 {
+		t.Fatal("decoding an empty buffer should return no points")
+	}
+
+	// the buffer doesn't contain a whole number of points
+	if _, err := UnmarshalBatchG2Affine(compressed[:len(compressed)-1], true); !errors.Is(err, ecc.ErrNonCanonicalEncoding) {
+		t.Fatalf("expected %v, got %v", ecc.ErrNonCanonicalEncoding, err)
+	}
+
+	// the first invalid point is reported
+	expectIndex := func(buf []byte, index int) {
+		t.Helper()
+		_, err := UnmarshalBatchG2Affine(buf, true)
+		var indexedErr *ecc.IndexedError
+		if !errors.As(err, &indexedErr) || indexedErr.Index != index {
+			t.Fatalf("expected an error at index %d, got %v", index, err)
+		}
+	}
+
+	// uncompressed point in a buffer of compressed points
+	{
+		buf := append([]byte{}, compressed...)
+		buf[7*SizeOfG2AffineCompressed] &^= mMask
+		buf[15*SizeOfG2AffineCompressed] &^= mMask
+		expectIndex(buf, 7)
+	}
+
+	// compressed x coordinate for which the curve equation has no solution
+	for {
+		var r G2Affine
+		var YSquared fptower.E2
+		if _, err := r.X.SetRandom(); err != nil {
+			t.Fatal(err)
+		}
+		YSquared.Square(&r.X).Mul(&YSquared, &r.X)
+		YSquared.Add(&YSquared, &bTwistCurveCoeff)
+		if YSquared.Legendre() == -1 {
+			buf := append([]byte{}, compressed...)
+			c := r.Bytes()
+			copy(buf[11*SizeOfG2AffineCompressed:], c[:])
+			expectIndex(buf, 11)
+			break
+		}
+	}
+}
+
 // define Gopters generators
 
 // GenFr generates an Fr element
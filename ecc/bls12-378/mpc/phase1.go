@@ -0,0 +1,304 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package mpc
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bls12-378"
+	"github.com/consensys/gnark-crypto/ecc/bls12-378/fr"
+	"github.com/consensys/gnark-crypto/ecc/bls12-378/fr/hashutils"
+	"github.com/consensys/gnark-crypto/ecc/bls12-378/fr/kzg"
+	"github.com/consensys/gnark-crypto/internal/parallel"
+)
+
+var (
+	ErrMinSize          = errors.New("minimum number of powers is 2")
+	ErrSizeMismatch     = errors.New("the contribution and the previous state have different sizes")
+	ErrChallenge        = errors.New("the contribution is not chained to the previous state")
+	ErrGenerators       = errors.New("the first powers are not the generators")
+	ErrDegenerate       = errors.New("the contribution is degenerate")
+	ErrProofOfKnowledge = errors.New("invalid proof of knowledge of the contribution")
+	ErrUpdate           = errors.New("the contribution is not an update of the previous state")
+	ErrPowers           = errors.New("the powers of tau are inconsistent")
+	ErrSubgroup         = errors.New("a point is not in the correct subgroup")
+	ErrBeacon           = errors.New("the contribution is not the expected beacon contribution")
+)
+
+// dstPoK is the domain separation tag used to hash to G2 in the proofs of knowledge
+var dstPoK = []byte("gnark-crypto/mpc: phase 1 proof of knowledge")
+
+// Phase1 is the state of a powers of tau ceremony after a contribution.
+type Phase1 struct {
+	// G1 = [G₁, [τ]G₁, [τ²]G₁, …, [τⁿ⁻¹]G₁]
+	G1 []bls12378.G1Affine
+
+	// G2 = [G₂, [τ]G₂]
+	G2 [2]bls12378.G2Affine
+
+	// PublicKey proves the knowledge of the last contribution
+	PublicKey PublicKey
+
+	// Challenge is the hash of the previous state (empty for the initial state)
+	Challenge []byte
+}
+
+// PublicKey is a proof of knowledge of a contribution x.
+type PublicKey struct {
+	SG  bls12378.G1Affine // [s]G₁, for a random s
+	SXG bls12378.G1Affine // [s⋅x]G₁
+	XR  bls12378.G2Affine // [x]R, where R = HashToG2(SG, SXG, challenge)
+}
+
+// InitPhase1 returns the initial state of a ceremony for n powers of tau, i.e. τ = 1.
+func InitPhase1(n uint64) (*Phase1, error) {
+	if n < 2 {
+		return nil, ErrMinSize
+	}
+
+	_, _, g1, g2 := bls12378.Generators()
+	p := &Phase1{
+		G1: make([]bls12378.G1Affine, n),
+	}
+	for i := range p.G1 {
+		p.G1[i] = g1
+	}
+	p.G2[0] = g2
+	p.G2[1] = g2
+	return p, nil
+}
+
+// Hash returns the hash of the serialized state, which chains the next contribution.
+func (p *Phase1) Hash() []byte {
+	h := sha256.New()
+	if _, err := p.WriteTo(h); err != nil {
+		panic(err)
+	}
+	return h.Sum(nil)
+}
+
+// Contribute updates the state with a random contribution, which is zeroized after use.
+func (p *Phase1) Contribute() error {
+	var x, s fr.Element
+	if _, err := x.SetRandom(); err != nil {
+		return err
+	}
+	if _, err := s.SetRandom(); err != nil {
+		return err
+	}
+	return p.contribute(&x, &s)
+}
+
+// ApplyBeacon updates the state with a contribution derived from the public random beacon,
+// hashed 2^nbIterations times, so that the contribution cannot be chosen by the coordinator.
+func (p *Phase1) ApplyBeacon(beacon []byte, nbIterations int) error {
+	x, s := beaconContribution(beacon, nbIterations)
+	return p.contribute(&x, &s)
+}
+
+// VerifyBeacon checks that p is the result of ApplyBeacon(beacon, nbIterations) on prev.
+func (p *Phase1) VerifyBeacon(prev *Phase1, beacon []byte, nbIterations int) error {
+	var expected Phase1
+	expected.G1 = make([]bls12378.G1Affine, len(prev.G1))
+	copy(expected.G1, prev.G1)
+	expected.G2 = prev.G2
+	expected.PublicKey = prev.PublicKey
+	expected.Challenge = prev.Challenge
+	if err := expected.ApplyBeacon(beacon, nbIterations); err != nil {
+		return err
+	}
+	if !bytes.Equal(expected.Hash(), p.Hash()) {
+		return ErrBeacon
+	}
+	return nil
+}
+
+func beaconContribution(beacon []byte, nbIterations int) (x, s fr.Element) {
+	seed := sha256.Sum256(beacon)
+	for i := 0; i < 1<<nbIterations; i++ {
+		seed = sha256.Sum256(seed[:])
+	}
+	x = hashutils.HashToFr(sha256.New, seed[:], []byte("x"))
+	s = hashutils.HashToFr(sha256.New, seed[:], []byte("s"))
+	return
+}
+
+// contribute multiplies τ by x, using s for the proof of knowledge.
+// x and s are zeroized.
+func (p *Phase1) contribute(x, s *fr.Element) error {
+	defer x.SetZero()
+	defer s.SetZero()
+
+	challenge := p.Hash()
+
+	// proof of knowledge
+	var bx, bs big.Int
+	x.ToBigIntRegular(&bx)
+	s.ToBigIntRegular(&bs)
+	defer bx.SetUint64(0)
+	defer bs.SetUint64(0)
+
+	var pk PublicKey
+	_, _, g1, _ := bls12378.Generators()
+	pk.SG.ScalarMultiplication(&g1, &bs)
+	pk.SXG.ScalarMultiplication(&pk.SG, &bx)
+	r, err := pokBase(&pk.SG, &pk.SXG, challenge)
+	if err != nil {
+		return err
+	}
+	pk.XR.ScalarMultiplication(&r, &bx)
+
+	// update the powers: G1[i] = [xⁱ]G1[i]
+	parallel.Execute(len(p.G1), func(start, end int) {
+		var xi fr.Element
+		var bxi big.Int
+		xi.ExpUint64(*x, uint64(start))
+		for i := start; i < end; i++ {
+			xi.ToBigIntRegular(&bxi)
+			p.G1[i].ScalarMultiplication(&p.G1[i], &bxi)
+			xi.Mul(&xi, x)
+		}
+		xi.SetZero()
+		bxi.SetUint64(0)
+	})
+	p.G2[1].ScalarMultiplication(&p.G2[1], &bx)
+
+	p.PublicKey = pk
+	p.Challenge = challenge
+	return nil
+}
+
+// pokBase returns R = HashToG2(SG, SXG, challenge)
+func pokBase(sg, sxg *bls12378.G1Affine, challenge []byte) (bls12378.G2Affine, error) {
+	var buf bytes.Buffer
+	sgb := sg.Bytes()
+	sxgb := sxg.Bytes()
+	buf.Write(sgb[:])
+	buf.Write(sxgb[:])
+	buf.Write(challenge)
+	return bls12378.HashToG2(buf.Bytes(), dstPoK)
+}
+
+// Verify checks that p is a valid contribution on top of prev.
+func (p *Phase1) Verify(prev *Phase1) error {
+	if len(p.G1) != len(prev.G1) {
+		return ErrSizeMismatch
+	}
+	if !bytes.Equal(p.Challenge, prev.Hash()) {
+		return ErrChallenge
+	}
+
+	_, _, g1, g2 := bls12378.Generators()
+	if !p.G1[0].Equal(&g1) || !p.G2[0].Equal(&g2) {
+		return ErrGenerators
+	}
+	if p.G1[1].IsInfinity() || p.G2[1].IsInfinity() || p.PublicKey.SG.IsInfinity() || p.PublicKey.SXG.IsInfinity() {
+		return ErrDegenerate
+	}
+	if err := p.checkSubgroups(); err != nil {
+		return err
+	}
+
+	// proof of knowledge of x: e(SG, XR) == e(SXG, R)
+	r, err := pokBase(&p.PublicKey.SG, &p.PublicKey.SXG, p.Challenge)
+	if err != nil {
+		return err
+	}
+	if !sameRatio(&p.PublicKey.SG, &p.PublicKey.SXG, &r, &p.PublicKey.XR) {
+		return ErrProofOfKnowledge
+	}
+
+	// τ' = x⋅τ: e(prev.G1[1], XR) == e(G1[1], R)
+	if !sameRatio(&prev.G1[1], &p.G1[1], &r, &p.PublicKey.XR) {
+		return ErrUpdate
+	}
+
+	// [τ]G₂ is consistent with [τ]G₁
+	if !sameRatio(&p.G1[0], &p.G1[1], &p.G2[0], &p.G2[1]) {
+		return ErrPowers
+	}
+
+	// G1 are successive powers of τ, checked on a random linear combination
+	n := len(p.G1)
+	coeffs := make([]fr.Element, n-1)
+	for i := range coeffs {
+		if _, err := coeffs[i].SetRandom(); err != nil {
+			return err
+		}
+	}
+	var l1, l2 bls12378.G1Affine
+	config := ecc.MultiExpConfig{ScalarsMont: true}
+	if _, err := l1.MultiExp(p.G1[:n-1], coeffs, config); err != nil {
+		return err
+	}
+	if _, err := l2.MultiExp(p.G1[1:], coeffs, config); err != nil {
+		return err
+	}
+	if !sameRatio(&l1, &l2, &p.G2[0], &p.G2[1]) {
+		return ErrPowers
+	}
+
+	return nil
+}
+
+func (p *Phase1) checkSubgroups() error {
+	ok := true
+	var chOk = make(chan bool, 1)
+	go func() {
+		res := p.G2[1].IsInSubGroup() && p.PublicKey.XR.IsInSubGroup() &&
+			p.PublicKey.SG.IsInSubGroup() && p.PublicKey.SXG.IsInSubGroup()
+		chOk <- res
+	}()
+	var failed = make([]bool, len(p.G1))
+	parallel.Execute(len(p.G1), func(start, end int) {
+		for i := start; i < end; i++ {
+			failed[i] = !p.G1[i].IsInSubGroup()
+		}
+	})
+	for _, f := range failed {
+		if f {
+			ok = false
+		}
+	}
+	if !<-chOk || !ok {
+		return ErrSubgroup
+	}
+	return nil
+}
+
+// sameRatio returns true if e(a₁, b₂) == e(b₁, a₂), i.e. the discrete logs of
+// (a₁, b₁) and (a₂, b₂) have the same ratio.
+func sameRatio(a1, b1 *bls12378.G1Affine, a2, b2 *bls12378.G2Affine) bool {
+	var nb1 bls12378.G1Affine
+	nb1.Neg(b1)
+	ok, err := bls12378.PairingCheck([]bls12378.G1Affine{*a1, nb1}, []bls12378.G2Affine{*b2, *a2})
+	return err == nil && ok
+}
+
+// SRS returns the powers of tau as a KZG SRS.
+func (p *Phase1) SRS() *kzg.SRS {
+	srs := &kzg.SRS{
+		G1: make([]bls12378.G1Affine, len(p.G1)),
+		G2: p.G2,
+	}
+	copy(srs.G1, p.G1)
+	return srs
+}
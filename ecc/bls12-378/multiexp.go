@@ -22,7 +22,9 @@ import (
 	"github.com/consensys/gnark-crypto/ecc/bls12-378/fr"
 	"github.com/consensys/gnark-crypto/internal/parallel"
 	"math"
-	"runtime"
+	"os"
+	"sync"
+	"time"
 )
 
 // selector stores the index, mask and shifts needed to select bits from a scalar
@@ -162,6 +164,225 @@ func partitionScalars(scalars []fr.Element, c uint64, scalarsMont bool, nbTasks
 	return toReturn, smallValues
 }
 
+// windowSelector selects the digit of a c-bit window of the scalars in the MultiExp.
+//
+// The digit of the window [jc, jc+c) is its value, minus 2^c if its most significant bit
+// is set, plus 1 if the most significant bit of the previous window is set: the digits are
+// in [-2^{c-1}, 2^{c-1}] and ∑ⱼ digitⱼ⋅2^{jc} is the scalar (the most significant bit of the
+// scalars is never set, so the last window doesn't borrow from a next one).
+// Unlike in partitionScalars, where the carry propagates from the least significant window,
+// a digit only depends on the bits of its window and on the bit before it, so that the
+// windows are processed independently, without a partitioned copy of the scalars.
+type windowSelector struct {
+	selector
+	c         uint64
+	msbWindow uint64 // msb of the c-bit window
+
+	carry      bool   // false for the first window
+	carryIndex uint64 // index in the multi-word scalar of the msb of the previous window
+	carryShift uint64 // shift of the msb of the previous window
+}
+
+func newWindowSelector(chunk, c uint64) windowSelector {
+	jc := uint64(chunk * c)
+	s := windowSelector{c: c, msbWindow: 1 << (c - 1)}
+	s.index = jc / 64
+	s.shift = jc - (s.index * 64)
+	s.mask = uint64((1<<c)-1) << s.shift
+	s.multiWordSelect = (64%c) != 0 && s.shift > (64-c) && s.index < (fr.Limbs-1)
+	if s.multiWordSelect {
+		nbBitsHigh := s.shift - uint64(64-c)
+		s.maskHigh = (1 << nbBitsHigh) - 1
+		s.shiftHigh = (c - nbBitsHigh)
+	}
+	if chunk > 0 {
+		s.carry = true
+		s.carryIndex = (jc - 1) / 64
+		s.carryShift = (jc - 1) % 64
+	}
+	return s
+}
+
+// digit returns the digit of the window of scalar, which is in regular form
+func (s *windowSelector) digit(scalar *fr.Element) int {
+	bits := (scalar[s.index] & s.mask) >> s.shift
+	if s.multiWordSelect {
+		bits += (scalar[s.index+1] & s.maskHigh) << s.shiftHigh
+	}
+	digit := int(bits)
+	if bits&s.msbWindow != 0 {
+		digit -= 1 << s.c
+	}
+	if s.carry {
+		digit += int((scalar[s.carryIndex] >> s.carryShift) & 1)
+	}
+	return digit
+}
+
+// countSmallValues returns the number of scalars which meet 0 < scalar < 2^c
+// (in other words, scalars where only the c-least significant bits are non zero)
+// scalarsMont indicates wheter the provided scalars are in montgomery form
+func countSmallValues(scalars []fr.Element, c uint64, scalarsMont bool, nbTasks int) int {
+	mask := uint64((1 << c) - 1)
+
+	// /!\ nbTasks is enough as parallel.Execute is not going to spawn more than nbTasks go routine
+	chSmallValues := make(chan int, nbTasks)
+	parallel.Execute(len(scalars), func(start, end int) {
+		smallValues := 0
+		for i := start; i < end; i++ {
+			scalar := scalars[i]
+			if scalarsMont {
+				scalar.FromMont()
+			}
+			if scalar.FitsOnOneWord() && scalar[0] != 0 && scalar[0]&mask == scalar[0] {
+				smallValues++
+			}
+		}
+		chSmallValues <- smallValues
+	}, nbTasks)
+
+	close(chSmallValues)
+	smallValues := 0
+	for o := range chSmallValues {
+		smallValues += o
+	}
+	return smallValues
+}
+
+// heuristicC returns the window size in implementedCs minimizing the approximate cost of a MultiExp
+func heuristicC(nbPoints int, implementedCs []uint64) uint64 {
+	var C uint64
+	// approximate cost (in group operations)
+	// cost = bits/c * (nbPoints + 2^{c})
+	// this needs to be verified empirically.
+	// for example, on a MBP 2016, for G2 MultiExp > 8M points, hand picking c gives better results
+	min := math.MaxFloat64
+	for _, c := range implementedCs {
+		cc := fr.Limbs * 64 * (nbPoints + (1 << (c)))
+		cost := float64(cc) / float64(c)
+		if cost < min {
+			min = cost
+			C = c
+		}
+	}
+	return C
+}
+
+func isImplementedC(c uint64, implementedCs []uint64) bool {
+	for _, ic := range implementedCs {
+		if ic == c {
+			return true
+		}
+	}
+	return false
+}
+
+// minLogSizeCalibration is the log2 of the smallest MultiExp size benchmarked by the calibration
+const minLogSizeCalibration = 4
+
+// implementedCsG1 are the window sizes for which a msmC method is implemented
+var implementedCsG1 = []uint64{4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 20, 21}
+
+var (
+	multiExpTuningG1     ecc.MultiExpTuning
+	multiExpTuningG1Lock sync.RWMutex
+)
+
+// SetMultiExpTuningG1 sets the window sizes used by the G1 MultiExp,
+// when config.C is not set. Sizes which are not in the tuning use the default heuristic;
+// a nil tuning restores the default heuristic for all sizes.
+func SetMultiExpTuningG1(tuning ecc.MultiExpTuning) error {
+	t := make(ecc.MultiExpTuning, len(tuning))
+	for k, c := range tuning {
+		if !isImplementedC(c, implementedCsG1) {
+			return errors.New("invalid tuning: not an implemented window size")
+		}
+		t[k] = c
+	}
+	multiExpTuningG1Lock.Lock()
+	multiExpTuningG1 = t
+	multiExpTuningG1Lock.Unlock()
+	return nil
+}
+
+// CalibrateMultiExpG1 benchmarks the implemented window sizes on random instances of
+// the G1 MultiExp, with nbTasks go routines (see ecc.MultiExpConfig), for sizes 2ᵏ up
+// to 2^maxLogSize. It returns, for each size, the fastest window size on this machine.
+//
+// The calibration runs a MultiExp of each size for each candidate window, so it takes time:
+// the result is meant to be persisted and set with SetMultiExpTuningG1.
+func CalibrateMultiExpG1(maxLogSize int, nbTasks int) ecc.MultiExpTuning {
+	tuning := make(ecc.MultiExpTuning)
+	if maxLogSize < minLogSizeCalibration {
+		return tuning
+	}
+
+	scalars := make([]fr.Element, 1<<maxLogSize)
+	for i := range scalars {
+		scalars[i].SetRandom()
+	}
+	points := BatchScalarMultiplicationG1(&g1GenAff, scalars)
+	for i := range scalars {
+		scalars[i].SetRandom()
+	}
+
+	var p G1Jac
+	for k := minLogSizeCalibration; k <= maxLogSize; k++ {
+		n := 1 << k
+		best := time.Duration(math.MaxInt64)
+		for _, c := range implementedCsG1 {
+			// the 2^{c-1} buckets would dominate the cost
+			if c > uint64(k+2) {
+				continue
+			}
+			start := time.Now()
+			if _, err := p.MultiExp(points[:n], scalars[:n], ecc.MultiExpConfig{NbTasks: nbTasks, C: c}); err != nil {
+				panic(err)
+			}
+			if d := time.Since(start); d < best {
+				best = d
+				tuning[k] = c
+			}
+		}
+	}
+
+	return tuning
+}
+
+// AutoCalibrateMultiExpG1 sets the G1 MultiExp tuning from the file at path.
+// If the file doesn't exist, the tuning is first computed with CalibrateMultiExpG1
+// (using all CPUs) and saved to path, so that the calibration runs once per machine.
+func AutoCalibrateMultiExpG1(path string, maxLogSize int) error {
+	var tuning ecc.MultiExpTuning
+
+	f, err := os.Open(path)
+	if err == nil {
+		_, err = tuning.ReadFrom(f)
+		f.Close()
+		if err != nil {
+			return err
+		}
+		return SetMultiExpTuningG1(tuning)
+	}
+	if !os.IsNotExist(err) {
+		return err
+	}
+
+	tuning = CalibrateMultiExpG1(maxLogSize, parallel.MaxProcs())
+	f, err = os.Create(path)
+	if err != nil {
+		return err
+	}
+	if _, err = tuning.WriteTo(f); err != nil {
+		f.Close()
+		return err
+	}
+	if err = f.Close(); err != nil {
+		return err
+	}
+	return SetMultiExpTuningG1(tuning)
+}
+
 // MultiExp implements section 4 of https://eprint.iacr.org/2012/549.pdf
 //
 // This call return an error if len(scalars) != len(points) or if provided config is invalid.
@@ -177,6 +398,7 @@ func (p *G1Affine) MultiExp(points []G1Affine, scalars []fr.Element, config ecc.
 // MultiExp implements section 4 of https://eprint.iacr.org/2012/549.pdf
 //
 // This call return an error if len(scalars) != len(points) or if provided config is invalid.
+// The digits of the scalars are extracted on the fly, window by window: no copy of the scalars is made.
 func (p *G1Jac) MultiExp(points []G1Affine, scalars []fr.Element, config ecc.MultiExpConfig) (*G1Jac, error) {
 	// note:
 	// each of the msmCX method is the same, except for the c constant it declares
@@ -188,19 +410,19 @@ func (p *G1Jac) MultiExp(points []G1Affine, scalars []fr.Element, config ecc.Mul
 	// * number of CPUs
 	// * cache friendliness (which depends on the host, G1 or G2... )
 	//	--> for example, on BN254, a G1 point fits into one cache line of 64bytes, but a G2 point don't.
+	// hence c can be set in the config, or calibrated on the host (see CalibrateMultiExpG1).
 
 	// for each msmCX
 	// step 1
-	// we compute, for each scalars over c-bit wide windows, nbChunk digits
-	// if the digit is larger than 2^{c-1}, then, we borrow 2^c from the next window and substract
-	// 2^{c} to the current digit, making it negative.
-	// negative digits will be processed in the next step as adding -G into the bucket instead of G
+	// each c-bit wide window of the scalars is processed by its own go routine, which extracts
+	// the digits of the window (see msmDigit); the digits are in [-2^{c-1}, 2^{c-1}], and
+	// negative digits are processed as adding -G into the bucket instead of G
 	// (computing -G is cheap, and this saves us half of the buckets)
 	// step 2
 	// buckets are declared on the stack
 	// notice that we have 2^{c-1} buckets instead of 2^{c} (see step1)
 	// we use jacobian extended formulas here as they are faster than mixed addition
-	// msmProcessChunk places points into buckets base on their selector and return the weighted bucket sum in given channel
+	// msmProcessChunk places points into buckets base on their digit and return the weighted bucket sum in given channel
 	// step 3
 	// reduce the buckets weigthed sums into our result (msmReduceChunk)
 
@@ -212,35 +434,30 @@ func (p *G1Jac) MultiExp(points []G1Affine, scalars []fr.Element, config ecc.Mul
 
 	// if nbTasks is not set, use all available CPUs
 	if config.NbTasks <= 0 {
-		config.NbTasks = runtime.NumCPU()
+		config.NbTasks = parallel.MaxProcs()
 	} else if config.NbTasks > 1024 {
 		return nil, errors.New("invalid config: config.NbTasks > 1024")
 	}
 
+	// a window size set in the config must be implemented
+	if config.C != 0 && !isImplementedC(config.C, implementedCsG1) {
+		return nil, errors.New("invalid config: config.C is not an implemented window size")
+	}
+
 	// here, we compute the best C for nbPoints
 	// we split recursively until nbChunks(c) >= nbTasks,
 	bestC := func(nbPoints int) uint64 {
-		// implemented msmC methods (the c we use must be in this slice)
-		implementedCs := []uint64{4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 20, 21}
-		var C uint64
-		// approximate cost (in group operations)
-		// cost = bits/c * (nbPoints + 2^{c})
-		// this needs to be verified empirically.
-		// for example, on a MBP 2016, for G2 MultiExp > 8M points, hand picking c gives better results
-		min := math.MaxFloat64
-		for _, c := range implementedCs {
-			cc := fr.Limbs * 64 * (nbPoints + (1 << (c)))
-			cost := float64(cc) / float64(c)
-			if cost < min {
-				min = cost
-				C = c
-			}
+		if config.C != 0 {
+			return config.C
+		}
+		// window size calibrated on this machine, if any
+		multiExpTuningG1Lock.RLock()
+		c, ok := multiExpTuningG1.Window(nbPoints)
+		multiExpTuningG1Lock.RUnlock()
+		if ok {
+			return c
 		}
-		// empirical, needs to be tuned.
-		// if C > 16 && nbPoints < 1 << 23 {
-		// 	C = 16
-		// }
-		return C
+		return heuristicC(nbPoints, implementedCsG1)
 	}
 
 	var C uint64
@@ -259,11 +476,8 @@ func (p *G1Jac) MultiExp(points []G1Affine, scalars []fr.Element, config ecc.Mul
 		}
 	}
 
-	// partition the scalars
-	// note: we do that before the actual chunk processing, as for each c-bit window (starting from LSW)
-	// if it's larger than 2^{c-1}, we have a carry we need to propagate up to the higher window
-	var smallValues int
-	scalars, smallValues = partitionScalars(scalars, C, config.ScalarsMont, config.NbTasks)
+	// count the small values, where only the first window is non zero
+	smallValues := countSmallValues(scalars, C, config.ScalarsMont, config.NbTasks)
 
 	// if we have more than 10% of small values, we split the processing of the first chunk in 2
 	// we may want to do that in msmInnerG1Jac , but that would incur a cost of looping through all scalars one more time
@@ -276,12 +490,12 @@ func (p *G1Jac) MultiExp(points []G1Affine, scalars []fr.Element, config ecc.Mul
 		start := i * nbPoints
 		end := start + nbPoints
 		go func(start, end, i int) {
-			msmInnerG1Jac(&_p[i], int(C), points[start:end], scalars[start:end], splitFirstChunk)
+			msmInnerG1Jac(&_p[i], int(C), points[start:end], scalars[start:end], config.ScalarsMont, splitFirstChunk)
 			chDone <- i
 		}(start, end, i)
 	}
 
-	msmInnerG1Jac(p, int(C), points[(nbSplits-1)*nbPoints:], scalars[(nbSplits-1)*nbPoints:], splitFirstChunk)
+	msmInnerG1Jac(p, int(C), points[(nbSplits-1)*nbPoints:], scalars[(nbSplits-1)*nbPoints:], config.ScalarsMont, splitFirstChunk)
 	for i := 0; i < nbSplits-1; i++ {
 		done := <-chDone
 		p.AddAssign(&_p[done])
@@ -290,54 +504,54 @@ func (p *G1Jac) MultiExp(points []G1Affine, scalars []fr.Element, config ecc.Mul
 	return p, nil
 }
 
-func msmInnerG1Jac(p *G1Jac, c int, points []G1Affine, scalars []fr.Element, splitFirstChunk bool) {
+func msmInnerG1Jac(p *G1Jac, c int, points []G1Affine, scalars []fr.Element, scalarsMont, splitFirstChunk bool) {
 
 	switch c {
 
 	case 4:
-		p.msmC4(points, scalars, splitFirstChunk)
+		p.msmC4(points, scalars, scalarsMont, splitFirstChunk)
 
 	case 5:
-		p.msmC5(points, scalars, splitFirstChunk)
+		p.msmC5(points, scalars, scalarsMont, splitFirstChunk)
 
 	case 6:
-		p.msmC6(points, scalars, splitFirstChunk)
+		p.msmC6(points, scalars, scalarsMont, splitFirstChunk)
 
 	case 7:
-		p.msmC7(points, scalars, splitFirstChunk)
+		p.msmC7(points, scalars, scalarsMont, splitFirstChunk)
 
 	case 8:
-		p.msmC8(points, scalars, splitFirstChunk)
+		p.msmC8(points, scalars, scalarsMont, splitFirstChunk)
 
 	case 9:
-		p.msmC9(points, scalars, splitFirstChunk)
+		p.msmC9(points, scalars, scalarsMont, splitFirstChunk)
 
 	case 10:
-		p.msmC10(points, scalars, splitFirstChunk)
+		p.msmC10(points, scalars, scalarsMont, splitFirstChunk)
 
 	case 11:
-		p.msmC11(points, scalars, splitFirstChunk)
+		p.msmC11(points, scalars, scalarsMont, splitFirstChunk)
 
 	case 12:
-		p.msmC12(points, scalars, splitFirstChunk)
+		p.msmC12(points, scalars, scalarsMont, splitFirstChunk)
 
 	case 13:
-		p.msmC13(points, scalars, splitFirstChunk)
+		p.msmC13(points, scalars, scalarsMont, splitFirstChunk)
 
 	case 14:
-		p.msmC14(points, scalars, splitFirstChunk)
+		p.msmC14(points, scalars, scalarsMont, splitFirstChunk)
 
 	case 15:
-		p.msmC15(points, scalars, splitFirstChunk)
+		p.msmC15(points, scalars, scalarsMont, splitFirstChunk)
 
 	case 16:
-		p.msmC16(points, scalars, splitFirstChunk)
+		p.msmC16(points, scalars, scalarsMont, splitFirstChunk)
 
 	case 20:
-		p.msmC20(points, scalars, splitFirstChunk)
+		p.msmC20(points, scalars, scalarsMont, splitFirstChunk)
 
 	case 21:
-		p.msmC21(points, scalars, splitFirstChunk)
+		p.msmC21(points, scalars, scalarsMont, splitFirstChunk)
 
 	default:
 		panic("not implemented")
@@ -365,45 +579,33 @@ func msmProcessChunkG1Affine(chunk uint64,
 	buckets []g1JacExtended,
 	c uint64,
 	points []G1Affine,
-	scalars []fr.Element) {
-
-	mask := uint64((1 << c) - 1) // low c bits are 1
-	msbWindow := uint64(1 << (c - 1))
+	scalars []fr.Element,
+	scalarsMont bool) {
 
 	for i := 0; i < len(buckets); i++ {
 		buckets[i].setInfinity()
 	}
 
-	jc := uint64(chunk * c)
-	s := selector{}
-	s.index = jc / 64
-	s.shift = jc - (s.index * 64)
-	s.mask = mask << s.shift
-	s.multiWordSelect = (64%c) != 0 && s.shift > (64-c) && s.index < (fr.Limbs-1)
-	if s.multiWordSelect {
-		nbBitsHigh := s.shift - uint64(64-c)
-		s.maskHigh = (1 << nbBitsHigh) - 1
-		s.shiftHigh = (c - nbBitsHigh)
-	}
+	s := newWindowSelector(chunk, c)
 
 	// for each scalars, get the digit corresponding to the chunk we're processing.
 	for i := 0; i < len(scalars); i++ {
-		bits := (scalars[i][s.index] & s.mask) >> s.shift
-		if s.multiWordSelect {
-			bits += (scalars[i][s.index+1] & s.maskHigh) << s.shiftHigh
+		scalar := scalars[i]
+		if scalarsMont {
+			scalar.FromMont()
 		}
+		digit := s.digit(&scalar)
 
-		if bits == 0 {
+		if digit == 0 {
 			continue
 		}
 
-		// if msbWindow bit is set, we need to substract
-		if bits&msbWindow == 0 {
+		if digit > 0 {
 			// add
-			buckets[bits-1].addMixed(&points[i])
+			buckets[digit-1].addMixed(&points[i])
 		} else {
 			// sub
-			buckets[bits & ^msbWindow].subMixed(&points[i])
+			buckets[-digit-1].subMixed(&points[i])
 		}
 	}
 
@@ -424,7 +626,7 @@ func msmProcessChunkG1Affine(chunk uint64,
 
 }
 
-func (p *G1Jac) msmC4(points []G1Affine, scalars []fr.Element, splitFirstChunk bool) *G1Jac {
+func (p *G1Jac) msmC4(points []G1Affine, scalars []fr.Element, scalarsMont, splitFirstChunk bool) *G1Jac {
 	const (
 		c        = 4                   // scalars partitioned into c-bit radixes
 		nbChunks = (fr.Limbs * 64 / c) // number of c-bit radixes in a scalar
@@ -443,7 +645,7 @@ func (p *G1Jac) msmC4(points []G1Affine, scalars []fr.Element, splitFirstChunk b
 
 	processChunk := func(j int, points []G1Affine, scalars []fr.Element, chChunk chan g1JacExtended) {
 		var buckets [1 << (c - 1)]g1JacExtended
-		msmProcessChunkG1Affine(uint64(j), chChunk, buckets[:], c, points, scalars)
+		msmProcessChunkG1Affine(uint64(j), chChunk, buckets[:], c, points, scalars, scalarsMont)
 	}
 
 	for j := int(nbChunks - 1); j > 0; j-- {
@@ -469,7 +671,7 @@ func (p *G1Jac) msmC4(points []G1Affine, scalars []fr.Element, splitFirstChunk b
 	return msmReduceChunkG1Affine(p, c, chChunks[:])
 }
 
-func (p *G1Jac) msmC5(points []G1Affine, scalars []fr.Element, splitFirstChunk bool) *G1Jac {
+func (p *G1Jac) msmC5(points []G1Affine, scalars []fr.Element, scalarsMont, splitFirstChunk bool) *G1Jac {
 	const (
 		c        = 5                   // scalars partitioned into c-bit radixes
 		nbChunks = (fr.Limbs * 64 / c) // number of c-bit radixes in a scalar
@@ -490,12 +692,12 @@ func (p *G1Jac) msmC5(points []G1Affine, scalars []fr.Element, splitFirstChunk b
 	const lastC = (fr.Limbs * 64) - (c * (fr.Limbs * 64 / c))
 	go func(j uint64, points []G1Affine, scalars []fr.Element) {
 		var buckets [1 << (lastC - 1)]g1JacExtended
-		msmProcessChunkG1Affine(j, chChunks[j], buckets[:], c, points, scalars)
+		msmProcessChunkG1Affine(j, chChunks[j], buckets[:], c, points, scalars, scalarsMont)
 	}(uint64(nbChunks), points, scalars)
 
 	processChunk := func(j int, points []G1Affine, scalars []fr.Element, chChunk chan g1JacExtended) {
 		var buckets [1 << (c - 1)]g1JacExtended
-		msmProcessChunkG1Affine(uint64(j), chChunk, buckets[:], c, points, scalars)
+		msmProcessChunkG1Affine(uint64(j), chChunk, buckets[:], c, points, scalars, scalarsMont)
 	}
 
 	for j := int(nbChunks - 1); j > 0; j-- {
@@ -521,7 +723,7 @@ func (p *G1Jac) msmC5(points []G1Affine, scalars []fr.Element, splitFirstChunk b
 	return msmReduceChunkG1Affine(p, c, chChunks[:])
 }
 
-func (p *G1Jac) msmC6(points []G1Affine, scalars []fr.Element, splitFirstChunk bool) *G1Jac {
+func (p *G1Jac) msmC6(points []G1Affine, scalars []fr.Element, scalarsMont, splitFirstChunk bool) *G1Jac {
 	const (
 		c        = 6                   // scalars partitioned into c-bit radixes
 		nbChunks = (fr.Limbs * 64 / c) // number of c-bit radixes in a scalar
@@ -542,12 +744,12 @@ func (p *G1Jac) msmC6(points []G1Affine, scalars []fr.Element, splitFirstChunk b
 	const lastC = (fr.Limbs * 64) - (c * (fr.Limbs * 64 / c))
 	go func(j uint64, points []G1Affine, scalars []fr.Element) {
 		var buckets [1 << (lastC - 1)]g1JacExtended
-		msmProcessChunkG1Affine(j, chChunks[j], buckets[:], c, points, scalars)
+		msmProcessChunkG1Affine(j, chChunks[j], buckets[:], c, points, scalars, scalarsMont)
 	}(uint64(nbChunks), points, scalars)
 
 	processChunk := func(j int, points []G1Affine, scalars []fr.Element, chChunk chan g1JacExtended) {
 		var buckets [1 << (c - 1)]g1JacExtended
-		msmProcessChunkG1Affine(uint64(j), chChunk, buckets[:], c, points, scalars)
+		msmProcessChunkG1Affine(uint64(j), chChunk, buckets[:], c, points, scalars, scalarsMont)
 	}
 
 	for j := int(nbChunks - 1); j > 0; j-- {
@@ -573,7 +775,7 @@ func (p *G1Jac) msmC6(points []G1Affine, scalars []fr.Element, splitFirstChunk b
 	return msmReduceChunkG1Affine(p, c, chChunks[:])
 }
 
-func (p *G1Jac) msmC7(points []G1Affine, scalars []fr.Element, splitFirstChunk bool) *G1Jac {
+func (p *G1Jac) msmC7(points []G1Affine, scalars []fr.Element, scalarsMont, splitFirstChunk bool) *G1Jac {
 	const (
 		c        = 7                   // scalars partitioned into c-bit radixes
 		nbChunks = (fr.Limbs * 64 / c) // number of c-bit radixes in a scalar
@@ -594,12 +796,12 @@ func (p *G1Jac) msmC7(points []G1Affine, scalars []fr.Element, splitFirstChunk b
 	const lastC = (fr.Limbs * 64) - (c * (fr.Limbs * 64 / c))
 	go func(j uint64, points []G1Affine, scalars []fr.Element) {
 		var buckets [1 << (lastC - 1)]g1JacExtended
-		msmProcessChunkG1Affine(j, chChunks[j], buckets[:], c, points, scalars)
+		msmProcessChunkG1Affine(j, chChunks[j], buckets[:], c, points, scalars, scalarsMont)
 	}(uint64(nbChunks), points, scalars)
 
 	processChunk := func(j int, points []G1Affine, scalars []fr.Element, chChunk chan g1JacExtended) {
 		var buckets [1 << (c - 1)]g1JacExtended
-		msmProcessChunkG1Affine(uint64(j), chChunk, buckets[:], c, points, scalars)
+		msmProcessChunkG1Affine(uint64(j), chChunk, buckets[:], c, points, scalars, scalarsMont)
 	}
 
 	for j := int(nbChunks - 1); j > 0; j-- {
@@ -625,7 +827,7 @@ func (p *G1Jac) msmC7(points []G1Affine, scalars []fr.Element, splitFirstChunk b
 	return msmReduceChunkG1Affine(p, c, chChunks[:])
 }
 
-func (p *G1Jac) msmC8(points []G1Affine, scalars []fr.Element, splitFirstChunk bool) *G1Jac {
+func (p *G1Jac) msmC8(points []G1Affine, scalars []fr.Element, scalarsMont, splitFirstChunk bool) *G1Jac {
 	const (
 		c        = 8                   // scalars partitioned into c-bit radixes
 		nbChunks = (fr.Limbs * 64 / c) // number of c-bit radixes in a scalar
@@ -644,7 +846,7 @@ func (p *G1Jac) msmC8(points []G1Affine, scalars []fr.Element, splitFirstChunk b
 
 	processChunk := func(j int, points []G1Affine, scalars []fr.Element, chChunk chan g1JacExtended) {
 		var buckets [1 << (c - 1)]g1JacExtended
-		msmProcessChunkG1Affine(uint64(j), chChunk, buckets[:], c, points, scalars)
+		msmProcessChunkG1Affine(uint64(j), chChunk, buckets[:], c, points, scalars, scalarsMont)
 	}
 
 	for j := int(nbChunks - 1); j > 0; j-- {
@@ -670,7 +872,7 @@ func (p *G1Jac) msmC8(points []G1Affine, scalars []fr.Element, splitFirstChunk b
 	return msmReduceChunkG1Affine(p, c, chChunks[:])
 }
 
-func (p *G1Jac) msmC9(points []G1Affine, scalars []fr.Element, splitFirstChunk bool) *G1Jac {
+func (p *G1Jac) msmC9(points []G1Affine, scalars []fr.Element, scalarsMont, splitFirstChunk bool) *G1Jac {
 	const (
 		c        = 9                   // scalars partitioned into c-bit radixes
 		nbChunks = (fr.Limbs * 64 / c) // number of c-bit radixes in a scalar
@@ -691,12 +893,12 @@ func (p *G1Jac) msmC9(points []G1Affine, scalars []fr.Element, splitFirstChunk b
 	const lastC = (fr.Limbs * 64) - (c * (fr.Limbs * 64 / c))
 	go func(j uint64, points []G1Affine, scalars []fr.Element) {
 		var buckets [1 << (lastC - 1)]g1JacExtended
-		msmProcessChunkG1Affine(j, chChunks[j], buckets[:], c, points, scalars)
+		msmProcessChunkG1Affine(j, chChunks[j], buckets[:], c, points, scalars, scalarsMont)
 	}(uint64(nbChunks), points, scalars)
 
 	processChunk := func(j int, points []G1Affine, scalars []fr.Element, chChunk chan g1JacExtended) {
 		var buckets [1 << (c - 1)]g1JacExtended
-		msmProcessChunkG1Affine(uint64(j), chChunk, buckets[:], c, points, scalars)
+		msmProcessChunkG1Affine(uint64(j), chChunk, buckets[:], c, points, scalars, scalarsMont)
 	}
 
 	for j := int(nbChunks - 1); j > 0; j-- {
@@ -722,7 +924,7 @@ func (p *G1Jac) msmC9(points []G1Affine, scalars []fr.Element, splitFirstChunk b
 	return msmReduceChunkG1Affine(p, c, chChunks[:])
 }
 
-func (p *G1Jac) msmC10(points []G1Affine, scalars []fr.Element, splitFirstChunk bool) *G1Jac {
+func (p *G1Jac) msmC10(points []G1Affine, scalars []fr.Element, scalarsMont, splitFirstChunk bool) *G1Jac {
 	const (
 		c        = 10                  // scalars partitioned into c-bit radixes
 		nbChunks = (fr.Limbs * 64 / c) // number of c-bit radixes in a scalar
@@ -743,12 +945,12 @@ func (p *G1Jac) msmC10(points []G1Affine, scalars []fr.Element, splitFirstChunk
 	const lastC = (fr.Limbs * 64) - (c * (fr.Limbs * 64 / c))
 	go func(j uint64, points []G1Affine, scalars []fr.Element) {
 		var buckets [1 << (lastC - 1)]g1JacExtended
-		msmProcessChunkG1Affine(j, chChunks[j], buckets[:], c, points, scalars)
+		msmProcessChunkG1Affine(j, chChunks[j], buckets[:], c, points, scalars, scalarsMont)
 	}(uint64(nbChunks), points, scalars)
 
 	processChunk := func(j int, points []G1Affine, scalars []fr.Element, chChunk chan g1JacExtended) {
 		var buckets [1 << (c - 1)]g1JacExtended
-		msmProcessChunkG1Affine(uint64(j), chChunk, buckets[:], c, points, scalars)
+		msmProcessChunkG1Affine(uint64(j), chChunk, buckets[:], c, points, scalars, scalarsMont)
 	}
 
 	for j := int(nbChunks - 1); j > 0; j-- {
@@ -774,7 +976,7 @@ func (p *G1Jac) msmC10(points []G1Affine, scalars []fr.Element, splitFirstChunk
 	return msmReduceChunkG1Affine(p, c, chChunks[:])
 }
 
-func (p *G1Jac) msmC11(points []G1Affine, scalars []fr.Element, splitFirstChunk bool) *G1Jac {
+func (p *G1Jac) msmC11(points []G1Affine, scalars []fr.Element, scalarsMont, splitFirstChunk bool) *G1Jac {
 	const (
 		c        = 11                  // scalars partitioned into c-bit radixes
 		nbChunks = (fr.Limbs * 64 / c) // number of c-bit radixes in a scalar
@@ -795,12 +997,12 @@ func (p *G1Jac) msmC11(points []G1Affine, scalars []fr.Element, splitFirstChunk
 	const lastC = (fr.Limbs * 64) - (c * (fr.Limbs * 64 / c))
 	go func(j uint64, points []G1Affine, scalars []fr.Element) {
 		var buckets [1 << (lastC - 1)]g1JacExtended
-		msmProcessChunkG1Affine(j, chChunks[j], buckets[:], c, points, scalars)
+		msmProcessChunkG1Affine(j, chChunks[j], buckets[:], c, points, scalars, scalarsMont)
 	}(uint64(nbChunks), points, scalars)
 
 	processChunk := func(j int, points []G1Affine, scalars []fr.Element, chChunk chan g1JacExtended) {
 		var buckets [1 << (c - 1)]g1JacExtended
-		msmProcessChunkG1Affine(uint64(j), chChunk, buckets[:], c, points, scalars)
+		msmProcessChunkG1Affine(uint64(j), chChunk, buckets[:], c, points, scalars, scalarsMont)
 	}
 
 	for j := int(nbChunks - 1); j > 0; j-- {
@@ -826,7 +1028,7 @@ func (p *G1Jac) msmC11(points []G1Affine, scalars []fr.Element, splitFirstChunk
 	return msmReduceChunkG1Affine(p, c, chChunks[:])
 }
 
-func (p *G1Jac) msmC12(points []G1Affine, scalars []fr.Element, splitFirstChunk bool) *G1Jac {
+func (p *G1Jac) msmC12(points []G1Affine, scalars []fr.Element, scalarsMont, splitFirstChunk bool) *G1Jac {
 	const (
 		c        = 12                  // scalars partitioned into c-bit radixes
 		nbChunks = (fr.Limbs * 64 / c) // number of c-bit radixes in a scalar
@@ -847,12 +1049,12 @@ func (p *G1Jac) msmC12(points []G1Affine, scalars []fr.Element, splitFirstChunk
 	const lastC = (fr.Limbs * 64) - (c * (fr.Limbs * 64 / c))
 	go func(j uint64, points []G1Affine, scalars []fr.Element) {
 		var buckets [1 << (lastC - 1)]g1JacExtended
-		msmProcessChunkG1Affine(j, chChunks[j], buckets[:], c, points, scalars)
+		msmProcessChunkG1Affine(j, chChunks[j], buckets[:], c, points, scalars, scalarsMont)
 	}(uint64(nbChunks), points, scalars)
 
 	processChunk := func(j int, points []G1Affine, scalars []fr.Element, chChunk chan g1JacExtended) {
 		var buckets [1 << (c - 1)]g1JacExtended
-		msmProcessChunkG1Affine(uint64(j), chChunk, buckets[:], c, points, scalars)
+		msmProcessChunkG1Affine(uint64(j), chChunk, buckets[:], c, points, scalars, scalarsMont)
 	}
 
 	for j := int(nbChunks - 1); j > 0; j-- {
@@ -878,7 +1080,7 @@ func (p *G1Jac) msmC12(points []G1Affine, scalars []fr.Element, splitFirstChunk
 	return msmReduceChunkG1Affine(p, c, chChunks[:])
 }
 
-func (p *G1Jac) msmC13(points []G1Affine, scalars []fr.Element, splitFirstChunk bool) *G1Jac {
+func (p *G1Jac) msmC13(points []G1Affine, scalars []fr.Element, scalarsMont, splitFirstChunk bool) *G1Jac {
 	const (
 		c        = 13                  // scalars partitioned into c-bit radixes
 		nbChunks = (fr.Limbs * 64 / c) // number of c-bit radixes in a scalar
@@ -899,12 +1101,12 @@ func (p *G1Jac) msmC13(points []G1Affine, scalars []fr.Element, splitFirstChunk
 	const lastC = (fr.Limbs * 64) - (c * (fr.Limbs * 64 / c))
 	go func(j uint64, points []G1Affine, scalars []fr.Element) {
 		var buckets [1 << (lastC - 1)]g1JacExtended
-		msmProcessChunkG1Affine(j, chChunks[j], buckets[:], c, points, scalars)
+		msmProcessChunkG1Affine(j, chChunks[j], buckets[:], c, points, scalars, scalarsMont)
 	}(uint64(nbChunks), points, scalars)
 
 	processChunk := func(j int, points []G1Affine, scalars []fr.Element, chChunk chan g1JacExtended) {
 		var buckets [1 << (c - 1)]g1JacExtended
-		msmProcessChunkG1Affine(uint64(j), chChunk, buckets[:], c, points, scalars)
+		msmProcessChunkG1Affine(uint64(j), chChunk, buckets[:], c, points, scalars, scalarsMont)
 	}
 
 	for j := int(nbChunks - 1); j > 0; j-- {
@@ -930,7 +1132,7 @@ func (p *G1Jac) msmC13(points []G1Affine, scalars []fr.Element, splitFirstChunk
 	return msmReduceChunkG1Affine(p, c, chChunks[:])
 }
 
-func (p *G1Jac) msmC14(points []G1Affine, scalars []fr.Element, splitFirstChunk bool) *G1Jac {
+func (p *G1Jac) msmC14(points []G1Affine, scalars []fr.Element, scalarsMont, splitFirstChunk bool) *G1Jac {
 	const (
 		c        = 14                  // scalars partitioned into c-bit radixes
 		nbChunks = (fr.Limbs * 64 / c) // number of c-bit radixes in a scalar
@@ -951,12 +1153,12 @@ func (p *G1Jac) msmC14(points []G1Affine, scalars []fr.Element, splitFirstChunk
 	const lastC = (fr.Limbs * 64) - (c * (fr.Limbs * 64 / c))
 	go func(j uint64, points []G1Affine, scalars []fr.Element) {
 		var buckets [1 << (lastC - 1)]g1JacExtended
-		msmProcessChunkG1Affine(j, chChunks[j], buckets[:], c, points, scalars)
+		msmProcessChunkG1Affine(j, chChunks[j], buckets[:], c, points, scalars, scalarsMont)
 	}(uint64(nbChunks), points, scalars)
 
 	processChunk := func(j int, points []G1Affine, scalars []fr.Element, chChunk chan g1JacExtended) {
 		var buckets [1 << (c - 1)]g1JacExtended
-		msmProcessChunkG1Affine(uint64(j), chChunk, buckets[:], c, points, scalars)
+		msmProcessChunkG1Affine(uint64(j), chChunk, buckets[:], c, points, scalars, scalarsMont)
 	}
 
 	for j := int(nbChunks - 1); j > 0; j-- {
@@ -982,7 +1184,7 @@ func (p *G1Jac) msmC14(points []G1Affine, scalars []fr.Element, splitFirstChunk
 	return msmReduceChunkG1Affine(p, c, chChunks[:])
 }
 
-func (p *G1Jac) msmC15(points []G1Affine, scalars []fr.Element, splitFirstChunk bool) *G1Jac {
+func (p *G1Jac) msmC15(points []G1Affine, scalars []fr.Element, scalarsMont, splitFirstChunk bool) *G1Jac {
 	const (
 		c        = 15                  // scalars partitioned into c-bit radixes
 		nbChunks = (fr.Limbs * 64 / c) // number of c-bit radixes in a scalar
@@ -1003,12 +1205,12 @@ func (p *G1Jac) msmC15(points []G1Affine, scalars []fr.Element, splitFirstChunk
 	const lastC = (fr.Limbs * 64) - (c * (fr.Limbs * 64 / c))
 	go func(j uint64, points []G1Affine, scalars []fr.Element) {
 		var buckets [1 << (lastC - 1)]g1JacExtended
-		msmProcessChunkG1Affine(j, chChunks[j], buckets[:], c, points, scalars)
+		msmProcessChunkG1Affine(j, chChunks[j], buckets[:], c, points, scalars, scalarsMont)
 	}(uint64(nbChunks), points, scalars)
 
 	processChunk := func(j int, points []G1Affine, scalars []fr.Element, chChunk chan g1JacExtended) {
 		var buckets [1 << (c - 1)]g1JacExtended
-		msmProcessChunkG1Affine(uint64(j), chChunk, buckets[:], c, points, scalars)
+		msmProcessChunkG1Affine(uint64(j), chChunk, buckets[:], c, points, scalars, scalarsMont)
 	}
 
 	for j := int(nbChunks - 1); j > 0; j-- {
@@ -1034,7 +1236,7 @@ func (p *G1Jac) msmC15(points []G1Affine, scalars []fr.Element, splitFirstChunk
 	return msmReduceChunkG1Affine(p, c, chChunks[:])
 }
 
-func (p *G1Jac) msmC16(points []G1Affine, scalars []fr.Element, splitFirstChunk bool) *G1Jac {
+func (p *G1Jac) msmC16(points []G1Affine, scalars []fr.Element, scalarsMont, splitFirstChunk bool) *G1Jac {
 	const (
 		c        = 16                  // scalars partitioned into c-bit radixes
 		nbChunks = (fr.Limbs * 64 / c) // number of c-bit radixes in a scalar
@@ -1053,7 +1255,7 @@ func (p *G1Jac) msmC16(points []G1Affine, scalars []fr.Element, splitFirstChunk
 
 	processChunk := func(j int, points []G1Affine, scalars []fr.Element, chChunk chan g1JacExtended) {
 		var buckets [1 << (c - 1)]g1JacExtended
-		msmProcessChunkG1Affine(uint64(j), chChunk, buckets[:], c, points, scalars)
+		msmProcessChunkG1Affine(uint64(j), chChunk, buckets[:], c, points, scalars, scalarsMont)
 	}
 
 	for j := int(nbChunks - 1); j > 0; j-- {
@@ -1079,7 +1281,7 @@ func (p *G1Jac) msmC16(points []G1Affine, scalars []fr.Element, splitFirstChunk
 	return msmReduceChunkG1Affine(p, c, chChunks[:])
 }
 
-func (p *G1Jac) msmC20(points []G1Affine, scalars []fr.Element, splitFirstChunk bool) *G1Jac {
+func (p *G1Jac) msmC20(points []G1Affine, scalars []fr.Element, scalarsMont, splitFirstChunk bool) *G1Jac {
 	const (
 		c        = 20                  // scalars partitioned into c-bit radixes
 		nbChunks = (fr.Limbs * 64 / c) // number of c-bit radixes in a scalar
@@ -1100,12 +1302,12 @@ func (p *G1Jac) msmC20(points []G1Affine, scalars []fr.Element, splitFirstChunk
 	const lastC = (fr.Limbs * 64) - (c * (fr.Limbs * 64 / c))
 	go func(j uint64, points []G1Affine, scalars []fr.Element) {
 		var buckets [1 << (lastC - 1)]g1JacExtended
-		msmProcessChunkG1Affine(j, chChunks[j], buckets[:], c, points, scalars)
+		msmProcessChunkG1Affine(j, chChunks[j], buckets[:], c, points, scalars, scalarsMont)
 	}(uint64(nbChunks), points, scalars)
 
 	processChunk := func(j int, points []G1Affine, scalars []fr.Element, chChunk chan g1JacExtended) {
 		var buckets [1 << (c - 1)]g1JacExtended
-		msmProcessChunkG1Affine(uint64(j), chChunk, buckets[:], c, points, scalars)
+		msmProcessChunkG1Affine(uint64(j), chChunk, buckets[:], c, points, scalars, scalarsMont)
 	}
 
 	for j := int(nbChunks - 1); j > 0; j-- {
@@ -1131,7 +1333,7 @@ func (p *G1Jac) msmC20(points []G1Affine, scalars []fr.Element, splitFirstChunk
 	return msmReduceChunkG1Affine(p, c, chChunks[:])
 }
 
-func (p *G1Jac) msmC21(points []G1Affine, scalars []fr.Element, splitFirstChunk bool) *G1Jac {
+func (p *G1Jac) msmC21(points []G1Affine, scalars []fr.Element, scalarsMont, splitFirstChunk bool) *G1Jac {
 	const (
 		c        = 21                  // scalars partitioned into c-bit radixes
 		nbChunks = (fr.Limbs * 64 / c) // number of c-bit radixes in a scalar
@@ -1152,12 +1354,12 @@ func (p *G1Jac) msmC21(points []G1Affine, scalars []fr.Element, splitFirstChunk
 	const lastC = (fr.Limbs * 64) - (c * (fr.Limbs * 64 / c))
 	go func(j uint64, points []G1Affine, scalars []fr.Element) {
 		var buckets [1 << (lastC - 1)]g1JacExtended
-		msmProcessChunkG1Affine(j, chChunks[j], buckets[:], c, points, scalars)
+		msmProcessChunkG1Affine(j, chChunks[j], buckets[:], c, points, scalars, scalarsMont)
 	}(uint64(nbChunks), points, scalars)
 
 	processChunk := func(j int, points []G1Affine, scalars []fr.Element, chChunk chan g1JacExtended) {
 		var buckets [1 << (c - 1)]g1JacExtended
-		msmProcessChunkG1Affine(uint64(j), chChunk, buckets[:], c, points, scalars)
+		msmProcessChunkG1Affine(uint64(j), chChunk, buckets[:], c, points, scalars, scalarsMont)
 	}
 
 	for j := int(nbChunks - 1); j > 0; j-- {
@@ -1183,6 +1385,109 @@ func (p *G1Jac) msmC21(points []G1Affine, scalars []fr.Element, splitFirstChunk
 	return msmReduceChunkG1Affine(p, c, chChunks[:])
 }
 
+// implementedCsG2 are the window sizes for which a msmC method is implemented
+var implementedCsG2 = []uint64{4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 20, 21}
+
+var (
+	multiExpTuningG2     ecc.MultiExpTuning
+	multiExpTuningG2Lock sync.RWMutex
+)
+
+// SetMultiExpTuningG2 sets the window sizes used by the G2 MultiExp,
+// when config.C is not set. Sizes which are not in the tuning use the default heuristic;
+// a nil tuning restores the default heuristic for all sizes.
+func SetMultiExpTuningG2(tuning ecc.MultiExpTuning) error {
+	t := make(ecc.MultiExpTuning, len(tuning))
+	for k, c := range tuning {
+		if !isImplementedC(c, implementedCsG2) {
+			return errors.New("invalid tuning: not an implemented window size")
+		}
+		t[k] = c
+	}
+	multiExpTuningG2Lock.Lock()
+	multiExpTuningG2 = t
+	multiExpTuningG2Lock.Unlock()
+	return nil
+}
+
+// CalibrateMultiExpG2 benchmarks the implemented window sizes on random instances of
+// the G2 MultiExp, with nbTasks go routines (see ecc.MultiExpConfig), for sizes 2ᵏ up
+// to 2^maxLogSize. It returns, for each size, the fastest window size on this machine.
+//
+// The calibration runs a MultiExp of each size for each candidate window, so it takes time:
+// the result is meant to be persisted and set with SetMultiExpTuningG2.
+func CalibrateMultiExpG2(maxLogSize int, nbTasks int) ecc.MultiExpTuning {
+	tuning := make(ecc.MultiExpTuning)
+	if maxLogSize < minLogSizeCalibration {
+		return tuning
+	}
+
+	scalars := make([]fr.Element, 1<<maxLogSize)
+	for i := range scalars {
+		scalars[i].SetRandom()
+	}
+	points := BatchScalarMultiplicationG2(&g2GenAff, scalars)
+	for i := range scalars {
+		scalars[i].SetRandom()
+	}
+
+	var p G2Jac
+	for k := minLogSizeCalibration; k <= maxLogSize; k++ {
+		n := 1 << k
+		best := time.Duration(math.MaxInt64)
+		for _, c := range implementedCsG2 {
+			// the 2^{c-1} buckets would dominate the cost
+			if c > uint64(k+2) {
+				continue
+			}
+			start := time.Now()
+			if _, err := p.MultiExp(points[:n], scalars[:n], ecc.MultiExpConfig{NbTasks: nbTasks, C: c}); err != nil {
+				panic(err)
+			}
+			if d := time.Since(start); d < best {
+				best = d
+				tuning[k] = c
+			}
+		}
+	}
+
+	return tuning
+}
+
+// AutoCalibrateMultiExpG2 sets the G2 MultiExp tuning from the file at path.
+// If the file doesn't exist, the tuning is first computed with CalibrateMultiExpG2
+// (using all CPUs) and saved to path, so that the calibration runs once per machine.
+func AutoCalibrateMultiExpG2(path string, maxLogSize int) error {
+	var tuning ecc.MultiExpTuning
+
+	f, err := os.Open(path)
+	if err == nil {
+		_, err = tuning.ReadFrom(f)
+		f.Close()
+		if err != nil {
+			return err
+		}
+		return SetMultiExpTuningG2(tuning)
+	}
+	if !os.IsNotExist(err) {
+		return err
+	}
+
+	tuning = CalibrateMultiExpG2(maxLogSize, parallel.MaxProcs())
+	f, err = os.Create(path)
+	if err != nil {
+		return err
+	}
+	if _, err = tuning.WriteTo(f); err != nil {
+		f.Close()
+		return err
+	}
+	if err = f.Close(); err != nil {
+		return err
+	}
+	return SetMultiExpTuningG2(tuning)
+}
+
 // MultiExp implements section 4 of https://eprint.iacr.org/2012/549.pdf
 //
 // This call return an error if len(scalars) != len(points) or if provided config is invalid.
@@ -1198,6 +1503,7 @@ func (p *G2Affine) MultiExp(points []G2Affine, scalars []fr.Element, config ecc.
 // MultiExp implements section 4 of https://eprint.iacr.org/2012/549.pdf
 //
 // This call return an error if len(scalars) != len(points) or if provided config is invalid.
+// The digits of the scalars are extracted on the fly, window by window: no copy of the scalars is made.
 func (p *G2Jac) MultiExp(points []G2Affine, scalars []fr.Element, config ecc.MultiExpConfig) (*G2Jac, error) {
 	// note:
 	// each of the msmCX method is the same, except for the c constant it declares
@@ -1209,19 +1515,19 @@ func (p *G2Jac) MultiExp(points []G2Affine, scalars []fr.Element, config ecc.Mul
 	// * number of CPUs
 	// * cache friendliness (which depends on the host, G1 or G2... )
 	//	--> for example, on BN254, a G1 point fits into one cache line of 64bytes, but a G2 point don't.
+	// hence c can be set in the config, or calibrated on the host (see CalibrateMultiExpG2).
 
 	// for each msmCX
 	// step 1
-	// we compute, for each scalars over c-bit wide windows, nbChunk digits
-	// if the digit is larger than 2^{c-1}, then, we borrow 2^c from the next window and substract
-	// 2^{c} to the current digit, making it negative.
-	// negative digits will be processed in the next step as adding -G into the bucket instead of G
+	// each c-bit wide window of the scalars is processed by its own go routine, which extracts
+	// the digits of the window (see msmDigit); the digits are in [-2^{c-1}, 2^{c-1}], and
+	// negative digits are processed as adding -G into the bucket instead of G
 	// (computing -G is cheap, and this saves us half of the buckets)
 	// step 2
 	// buckets are declared on the stack
 	// notice that we have 2^{c-1} buckets instead of 2^{c} (see step1)
 	// we use jacobian extended formulas here as they are faster than mixed addition
-	// msmProcessChunk places points into buckets base on their selector and return the weighted bucket sum in given channel
+	// msmProcessChunk places points into buckets base on their digit and return the weighted bucket sum in given channel
 	// step 3
 	// reduce the buckets weigthed sums into our result (msmReduceChunk)
 
@@ -1233,35 +1539,30 @@ func (p *G2Jac) MultiExp(points []G2Affine, scalars []fr.Element, config ecc.Mul
 
 	// if nbTasks is not set, use all available CPUs
 	if config.NbTasks <= 0 {
-		config.NbTasks = runtime.NumCPU()
+		config.NbTasks = parallel.MaxProcs()
 	} else if config.NbTasks > 1024 {
 		return nil, errors.New("invalid config: config.NbTasks > 1024")
 	}
 
+	// a window size set in the config must be implemented
+	if config.C != 0 && !isImplementedC(config.C, implementedCsG2) {
+		return nil, errors.New("invalid config: config.C is not an implemented window size")
+	}
+
 	// here, we compute the best C for nbPoints
 	// we split recursively until nbChunks(c) >= nbTasks,
 	bestC := func(nbPoints int) uint64 {
-		// implemented msmC methods (the c we use must be in this slice)
-		implementedCs := []uint64{4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 20, 21}
-		var C uint64
-		// approximate cost (in group operations)
-		// cost = bits/c * (nbPoints + 2^{c})
-		// this needs to be verified empirically.
-		// for example, on a MBP 2016, for G2 MultiExp > 8M points, hand picking c gives better results
-		min := math.MaxFloat64
-		for _, c := range implementedCs {
-			cc := fr.Limbs * 64 * (nbPoints + (1 << (c)))
-			cost := float64(cc) / float64(c)
-			if cost < min {
-				min = cost
-				C = c
-			}
+		if config.C != 0 {
+			return config.C
+		}
+		// window size calibrated on this machine, if any
+		multiExpTuningG2Lock.RLock()
+		c, ok := multiExpTuningG2.Window(nbPoints)
+		multiExpTuningG2Lock.RUnlock()
+		if ok {
+			return c
 		}
-		// empirical, needs to be tuned.
-		// if C > 16 && nbPoints < 1 << 23 {
-		// 	C = 16
-		// }
-		return C
+		return heuristicC(nbPoints, implementedCsG2)
 	}
 
 	var C uint64
@@ -1280,11 +1581,8 @@ func (p *G2Jac) MultiExp(points []G2Affine, scalars []fr.Element, config ecc.Mul
 		}
 	}
 
-	// partition the scalars
-	// note: we do that before the actual chunk processing, as for each c-bit window (starting from LSW)
-	// if it's larger than 2^{c-1}, we have a carry we need to propagate up to the higher window
-	var smallValues int
-	scalars, smallValues = partitionScalars(scalars, C, config.ScalarsMont, config.NbTasks)
+	// count the small values, where only the first window is non zero
+	smallValues := countSmallValues(scalars, C, config.ScalarsMont, config.NbTasks)
 
 	// if we have more than 10% of small values, we split the processing of the first chunk in 2
 	// we may want to do that in msmInnerG2Jac , but that would incur a cost of looping through all scalars one more time
@@ -1297,12 +1595,12 @@ func (p *G2Jac) MultiExp(points []G2Affine, scalars []fr.Element, config ecc.Mul
 		start := i * nbPoints
 		end := start + nbPoints
 		go func(start, end, i int) {
-			msmInnerG2Jac(&_p[i], int(C), points[start:end], scalars[start:end], splitFirstChunk)
+			msmInnerG2Jac(&_p[i], int(C), points[start:end], scalars[start:end], config.ScalarsMont, splitFirstChunk)
 			chDone <- i
 		}(start, end, i)
 	}
 
-	msmInnerG2Jac(p, int(C), points[(nbSplits-1)*nbPoints:], scalars[(nbSplits-1)*nbPoints:], splitFirstChunk)
+	msmInnerG2Jac(p, int(C), points[(nbSplits-1)*nbPoints:], scalars[(nbSplits-1)*nbPoints:], config.ScalarsMont, splitFirstChunk)
 	for i := 0; i < nbSplits-1; i++ {
 		done := <-chDone
 		p.AddAssign(&_p[done])
@@ -1311,54 +1609,54 @@ func (p *G2Jac) MultiExp(points []G2Affine, scalars []fr.Element, config ecc.Mul
 	return p, nil
 }
 
-func msmInnerG2Jac(p *G2Jac, c int, points []G2Affine, scalars []fr.Element, splitFirstChunk bool) {
+func msmInnerG2Jac(p *G2Jac, c int, points []G2Affine, scalars []fr.Element, scalarsMont, splitFirstChunk bool) {
 
 	switch c {
 
 	case 4:
-		p.msmC4(points, scalars, splitFirstChunk)
+		p.msmC4(points, scalars, scalarsMont, splitFirstChunk)
 
 	case 5:
-		p.msmC5(points, scalars, splitFirstChunk)
+		p.msmC5(points, scalars, scalarsMont, splitFirstChunk)
 
 	case 6:
-		p.msmC6(points, scalars, splitFirstChunk)
+		p.msmC6(points, scalars, scalarsMont, splitFirstChunk)
 
 	case 7:
-		p.msmC7(points, scalars, splitFirstChunk)
+		p.msmC7(points, scalars, scalarsMont, splitFirstChunk)
 
 	case 8:
-		p.msmC8(points, scalars, splitFirstChunk)
+		p.msmC8(points, scalars, scalarsMont, splitFirstChunk)
 
 	case 9:
-		p.msmC9(points, scalars, splitFirstChunk)
+		p.msmC9(points, scalars, scalarsMont, splitFirstChunk)
 
 	case 10:
-		p.msmC10(points, scalars, splitFirstChunk)
+		p.msmC10(points, scalars, scalarsMont, splitFirstChunk)
 
 	case 11:
-		p.msmC11(points, scalars, splitFirstChunk)
+		p.msmC11(points, scalars, scalarsMont, splitFirstChunk)
 
 	case 12:
-		p.msmC12(points, scalars, splitFirstChunk)
+		p.msmC12(points, scalars, scalarsMont, splitFirstChunk)
 
 	case 13:
-		p.msmC13(points, scalars, splitFirstChunk)
+		p.msmC13(points, scalars, scalarsMont, splitFirstChunk)
 
 	case 14:
-		p.msmC14(points, scalars, splitFirstChunk)
+		p.msmC14(points, scalars, scalarsMont, splitFirstChunk)
 
 	case 15:
-		p.msmC15(points, scalars, splitFirstChunk)
+		p.msmC15(points, scalars, scalarsMont, splitFirstChunk)
 
 	case 16:
-		p.msmC16(points, scalars, splitFirstChunk)
+		p.msmC16(points, scalars, scalarsMont, splitFirstChunk)
 
 	case 20:
-		p.msmC20(points, scalars, splitFirstChunk)
+		p.msmC20(points, scalars, scalarsMont, splitFirstChunk)
 
 	case 21:
-		p.msmC21(points, scalars, splitFirstChunk)
+		p.msmC21(points, scalars, scalarsMont, splitFirstChunk)
 
 	default:
 		panic("not implemented")
@@ -1386,45 +1684,33 @@ func msmProcessChunkG2Affine(chunk uint64,
 	buckets []g2JacExtended,
 	c uint64,
 	points []G2Affine,
-	scalars []fr.Element) {
-
-	mask := uint64((1 << c) - 1) // low c bits are 1
-	msbWindow := uint64(1 << (c - 1))
+	scalars []fr.Element,
+	scalarsMont bool) {
 
 	for i := 0; i < len(buckets); i++ {
 		buckets[i].setInfinity()
 	}
 
-	jc := uint64(chunk * c)
-	s := selector{}
-	s.index = jc / 64
-	s.shift = jc - (s.index * 64)
-	s.mask = mask << s.shift
-	s.multiWordSelect = (64%c) != 0 && s.shift > (64-c) && s.index < (fr.Limbs-1)
-	if s.multiWordSelect {
-		nbBitsHigh := s.shift - uint64(64-c)
-		s.maskHigh = (1 << nbBitsHigh) - 1
-		s.shiftHigh = (c - nbBitsHigh)
-	}
+	s := newWindowSelector(chunk, c)
 
 	// for each scalars, get the digit corresponding to the chunk we're processing.
 	for i := 0; i < len(scalars); i++ {
-		bits := (scalars[i][s.index] & s.mask) >> s.shift
-		if s.multiWordSelect {
-			bits += (scalars[i][s.index+1] & s.maskHigh) << s.shiftHigh
+		scalar := scalars[i]
+		if scalarsMont {
+			scalar.FromMont()
 		}
+		digit := s.digit(&scalar)
 
-		if bits == 0 {
+		if digit == 0 {
 			continue
 		}
 
-		// if msbWindow bit is set, we need to substract
-		if bits&msbWindow == 0 {
+		if digit > 0 {
 			// add
-			buckets[bits-1].addMixed(&points[i])
+			buckets[digit-1].addMixed(&points[i])
 		} else {
 			// sub
-			buckets[bits & ^msbWindow].subMixed(&points[i])
+			buckets[-digit-1].subMixed(&points[i])
 		}
 	}
 
@@ -1445,7 +1731,7 @@ func msmProcessChunkG2Affine(chunk uint64,
 
 }
 
-func (p *G2Jac) msmC4(points []G2Affine, scalars []fr.Element, splitFirstChunk bool) *G2Jac {
+func (p *G2Jac) msmC4(points []G2Affine, scalars []fr.Element, scalarsMont, splitFirstChunk bool) *G2Jac {
 	const (
 		c        = 4                   // scalars partitioned into c-bit radixes
 		nbChunks = (fr.Limbs * 64 / c) // number of c-bit radixes in a scalar
@@ -1464,7 +1750,7 @@ func (p *G2Jac) msmC4(points []G2Affine, scalars []fr.Element, splitFirstChunk b
 
 	processChunk := func(j int, points []G2Affine, scalars []fr.Element, chChunk chan g2JacExtended) {
 		var buckets [1 << (c - 1)]g2JacExtended
-		msmProcessChunkG2Affine(uint64(j), chChunk, buckets[:], c, points, scalars)
+		msmProcessChunkG2Affine(uint64(j), chChunk, buckets[:], c, points, scalars, scalarsMont)
 	}
 
 	for j := int(nbChunks - 1); j > 0; j-- {
@@ -1490,7 +1776,7 @@ func (p *G2Jac) msmC4(points []G2Affine, scalars []fr.Element, splitFirstChunk b
 	return msmReduceChunkG2Affine(p, c, chChunks[:])
 }
 
-func (p *G2Jac) msmC5(points []G2Affine, scalars []fr.Element, splitFirstChunk bool) *G2Jac {
+func (p *G2Jac) msmC5(points []G2Affine, scalars []fr.Element, scalarsMont, splitFirstChunk bool) *G2Jac {
 	const (
 		c        = 5                   // scalars partitioned into c-bit radixes
 		nbChunks = (fr.Limbs * 64 / c) // number of c-bit radixes in a scalar
@@ -1511,12 +1797,12 @@ func (p *G2Jac) msmC5(points []G2Affine, scalars []fr.Element, splitFirstChunk b
 	const lastC = (fr.Limbs * 64) - (c * (fr.Limbs * 64 / c))
 	go func(j uint64, points []G2Affine, scalars []fr.Element) {
 		var buckets [1 << (lastC - 1)]g2JacExtended
-		msmProcessChunkG2Affine(j, chChunks[j], buckets[:], c, points, scalars)
+		msmProcessChunkG2Affine(j, chChunks[j], buckets[:], c, points, scalars, scalarsMont)
 	}(uint64(nbChunks), points, scalars)
 
 	processChunk := func(j int, points []G2Affine, scalars []fr.Element, chChunk chan g2JacExtended) {
 		var buckets [1 << (c - 1)]g2JacExtended
-		msmProcessChunkG2Affine(uint64(j), chChunk, buckets[:], c, points, scalars)
+		msmProcessChunkG2Affine(uint64(j), chChunk, buckets[:], c, points, scalars, scalarsMont)
 	}
 
 	for j := int(nbChunks - 1); j > 0; j-- {
@@ -1542,7 +1828,7 @@ func (p *G2Jac) msmC5(points []G2Affine, scalars []fr.Element, splitFirstChunk b
 	return msmReduceChunkG2Affine(p, c, chChunks[:])
 }
 
-func (p *G2Jac) msmC6(points []G2Affine, scalars []fr.Element, splitFirstChunk bool) *G2Jac {
+func (p *G2Jac) msmC6(points []G2Affine, scalars []fr.Element, scalarsMont, splitFirstChunk bool) *G2Jac {
 	const (
 		c        = 6                   // scalars partitioned into c-bit radixes
 		nbChunks = (fr.Limbs * 64 / c) // number of c-bit radixes in a scalar
@@ -1563,12 +1849,12 @@ func (p *G2Jac) msmC6(points []G2Affine, scalars []fr.Element, splitFirstChunk b
 	const lastC = (fr.Limbs * 64) - (c * (fr.Limbs * 64 / c))
 	go func(j uint64, points []G2Affine, scalars []fr.Element) {
 		var buckets [1 << (lastC - 1)]g2JacExtended
-		msmProcessChunkG2Affine(j, chChunks[j], buckets[:], c, points, scalars)
+		msmProcessChunkG2Affine(j, chChunks[j], buckets[:], c, points, scalars, scalarsMont)
 	}(uint64(nbChunks), points, scalars)
 
 	processChunk := func(j int, points []G2Affine, scalars []fr.Element, chChunk chan g2JacExtended) {
 		var buckets [1 << (c - 1)]g2JacExtended
-		msmProcessChunkG2Affine(uint64(j), chChunk, buckets[:], c, points, scalars)
+		msmProcessChunkG2Affine(uint64(j), chChunk, buckets[:], c, points, scalars, scalarsMont)
 	}
 
 	for j := int(nbChunks - 1); j > 0; j-- {
@@ -1594,7 +1880,7 @@ func (p *G2Jac) msmC6(points []G2Affine, scalars []fr.Element, splitFirstChunk b
 	return msmReduceChunkG2Affine(p, c, chChunks[:])
 }
 
-func (p *G2Jac) msmC7(points []G2Affine, scalars []fr.Element, splitFirstChunk bool) *G2Jac {
+func (p *G2Jac) msmC7(points []G2Affine, scalars []fr.Element, scalarsMont, splitFirstChunk bool) *G2Jac {
 	const (
 		c        = 7                   // scalars partitioned into c-bit radixes
 		nbChunks = (fr.Limbs * 64 / c) // number of c-bit radixes in a scalar
@@ -1615,12 +1901,12 @@ func (p *G2Jac) msmC7(points []G2Affine, scalars []fr.Element, splitFirstChunk b
 	const lastC = (fr.Limbs * 64) - (c * (fr.Limbs * 64 / c))
 	go func(j uint64, points []G2Affine, scalars []fr.Element) {
 		var buckets [1 << (lastC - 1)]g2JacExtended
-		msmProcessChunkG2Affine(j, chChunks[j], buckets[:], c, points, scalars)
+		msmProcessChunkG2Affine(j, chChunks[j], buckets[:], c, points, scalars, scalarsMont)
 	}(uint64(nbChunks), points, scalars)
 
 	processChunk := func(j int, points []G2Affine, scalars []fr.Element, chChunk chan g2JacExtended) {
 		var buckets [1 << (c - 1)]g2JacExtended
-		msmProcessChunkG2Affine(uint64(j), chChunk, buckets[:], c, points, scalars)
+		msmProcessChunkG2Affine(uint64(j), chChunk, buckets[:], c, points, scalars, scalarsMont)
 	}
 
 	for j := int(nbChunks - 1); j > 0; j-- {
@@ -1646,7 +1932,7 @@ func (p *G2Jac) msmC7(points []G2Affine, scalars []fr.Element, splitFirstChunk b
 	return msmReduceChunkG2Affine(p, c, chChunks[:])
 }
 
-func (p *G2Jac) msmC8(points []G2Affine, scalars []fr.Element, splitFirstChunk bool) *G2Jac {
+func (p *G2Jac) msmC8(points []G2Affine, scalars []fr.Element, scalarsMont, splitFirstChunk bool) *G2Jac {
 	const (
 		c        = 8                   // scalars partitioned into c-bit radixes
 		nbChunks = (fr.Limbs * 64 / c) // number of c-bit radixes in a scalar
@@ -1665,7 +1951,7 @@ func (p *G2Jac) msmC8(points []G2Affine, scalars []fr.Element, splitFirstChunk b
 
 	processChunk := func(j int, points []G2Affine, scalars []fr.Element, chChunk chan g2JacExtended) {
 		var buckets [1 << (c - 1)]g2JacExtended
-		msmProcessChunkG2Affine(uint64(j), chChunk, buckets[:], c, points, scalars)
+		msmProcessChunkG2Affine(uint64(j), chChunk, buckets[:], c, points, scalars, scalarsMont)
 	}
 
 	for j := int(nbChunks - 1); j > 0; j-- {
@@ -1691,7 +1977,7 @@ func (p *G2Jac) msmC8(points []G2Affine, scalars []fr.Element, splitFirstChunk b
 	return msmReduceChunkG2Affine(p, c, chChunks[:])
 }
 
-func (p *G2Jac) msmC9(points []G2Affine, scalars []fr.Element, splitFirstChunk bool) *G2Jac {
+func (p *G2Jac) msmC9(points []G2Affine, scalars []fr.Element, scalarsMont, splitFirstChunk bool) *G2Jac {
 	const (
 		c        = 9                   // scalars partitioned into c-bit radixes
 		nbChunks = (fr.Limbs * 64 / c) // number of c-bit radixes in a scalar
@@ -1712,12 +1998,12 @@ func (p *G2Jac) msmC9(points []G2Affine, scalars []fr.Element, splitFirstChunk b
 	const lastC = (fr.Limbs * 64) - (c * (fr.Limbs * 64 / c))
 	go func(j uint64, points []G2Affine, scalars []fr.Element) {
 		var buckets [1 << (lastC - 1)]g2JacExtended
-		msmProcessChunkG2Affine(j, chChunks[j], buckets[:], c, points, scalars)
+		msmProcessChunkG2Affine(j, chChunks[j], buckets[:], c, points, scalars, scalarsMont)
 	}(uint64(nbChunks), points, scalars)
 
 	processChunk := func(j int, points []G2Affine, scalars []fr.Element, chChunk chan g2JacExtended) {
 		var buckets [1 << (c - 1)]g2JacExtended
-		msmProcessChunkG2Affine(uint64(j), chChunk, buckets[:], c, points, scalars)
+		msmProcessChunkG2Affine(uint64(j), chChunk, buckets[:], c, points, scalars, scalarsMont)
 	}
 
 	for j := int(nbChunks - 1); j > 0; j-- {
@@ -1743,7 +2029,7 @@ func (p *G2Jac) msmC9(points []G2Affine, scalars []fr.Element, splitFirstChunk b
 	return msmReduceChunkG2Affine(p, c, chChunks[:])
 }
 
-func (p *G2Jac) msmC10(points []G2Affine, scalars []fr.Element, splitFirstChunk bool) *G2Jac {
+func (p *G2Jac) msmC10(points []G2Affine, scalars []fr.Element, scalarsMont, splitFirstChunk bool) *G2Jac {
 	const (
 		c        = 10                  // scalars partitioned into c-bit radixes
 		nbChunks = (fr.Limbs * 64 / c) // number of c-bit radixes in a scalar
@@ -1764,12 +2050,12 @@ func (p *G2Jac) msmC10(points []G2Affine, scalars []fr.Element, splitFirstChunk
 	const lastC = (fr.Limbs * 64) - (c * (fr.Limbs * 64 / c))
 	go func(j uint64, points []G2Affine, scalars []fr.Element) {
 		var buckets [1 << (lastC - 1)]g2JacExtended
-		msmProcessChunkG2Affine(j, chChunks[j], buckets[:], c, points, scalars)
+		msmProcessChunkG2Affine(j, chChunks[j], buckets[:], c, points, scalars, scalarsMont)
 	}(uint64(nbChunks), points, scalars)
 
 	processChunk := func(j int, points []G2Affine, scalars []fr.Element, chChunk chan g2JacExtended) {
 		var buckets [1 << (c - 1)]g2JacExtended
-		msmProcessChunkG2Affine(uint64(j), chChunk, buckets[:], c, points, scalars)
+		msmProcessChunkG2Affine(uint64(j), chChunk, buckets[:], c, points, scalars, scalarsMont)
 	}
 
 	for j := int(nbChunks - 1); j > 0; j-- {
@@ -1795,7 +2081,7 @@ func (p *G2Jac) msmC10(points []G2Affine, scalars []fr.Element, splitFirstChunk
 	return msmReduceChunkG2Affine(p, c, chChunks[:])
 }
 
-func (p *G2Jac) msmC11(points []G2Affine, scalars []fr.Element, splitFirstChunk bool) *G2Jac {
+func (p *G2Jac) msmC11(points []G2Affine, scalars []fr.Element, scalarsMont, splitFirstChunk bool) *G2Jac {
 	const (
 		c        = 11                  // scalars partitioned into c-bit radixes
 		nbChunks = (fr.Limbs * 64 / c) // number of c-bit radixes in a scalar
@@ -1816,12 +2102,12 @@ func (p *G2Jac) msmC11(points []G2Affine, scalars []fr.Element, splitFirstChunk
 	const lastC = (fr.Limbs * 64) - (c * (fr.Limbs * 64 / c))
 	go func(j uint64, points []G2Affine, scalars []fr.Element) {
 		var buckets [1 << (lastC - 1)]g2JacExtended
-		msmProcessChunkG2Affine(j, chChunks[j], buckets[:], c, points, scalars)
+		msmProcessChunkG2Affine(j, chChunks[j], buckets[:], c, points, scalars, scalarsMont)
 	}(uint64(nbChunks), points, scalars)
 
 	processChunk := func(j int, points []G2Affine, scalars []fr.Element, chChunk chan g2JacExtended) {
 		var buckets [1 << (c - 1)]g2JacExtended
-		msmProcessChunkG2Affine(uint64(j), chChunk, buckets[:], c, points, scalars)
+		msmProcessChunkG2Affine(uint64(j), chChunk, buckets[:], c, points, scalars, scalarsMont)
 	}
 
 	for j := int(nbChunks - 1); j > 0; j-- {
@@ -1847,7 +2133,7 @@ func (p *G2Jac) msmC11(points []G2Affine, scalars []fr.Element, splitFirstChunk
 	return msmReduceChunkG2Affine(p, c, chChunks[:])
 }
 
-func (p *G2Jac) msmC12(points []G2Affine, scalars []fr.Element, splitFirstChunk bool) *G2Jac {
+func (p *G2Jac) msmC12(points []G2Affine, scalars []fr.Element, scalarsMont, splitFirstChunk bool) *G2Jac {
 	const (
 		c        = 12                  // scalars partitioned into c-bit radixes
 		nbChunks = (fr.Limbs * 64 / c) // number of c-bit radixes in a scalar
@@ -1868,12 +2154,12 @@ func (p *G2Jac) msmC12(points []G2Affine, scalars []fr.Element, splitFirstChunk
 	const lastC = (fr.Limbs * 64) - (c * (fr.Limbs * 64 / c))
 	go func(j uint64, points []G2Affine, scalars []fr.Element) {
 		var buckets [1 << (lastC - 1)]g2JacExtended
-		msmProcessChunkG2Affine(j, chChunks[j], buckets[:], c, points, scalars)
+		msmProcessChunkG2Affine(j, chChunks[j], buckets[:], c, points, scalars, scalarsMont)
 	}(uint64(nbChunks), points, scalars)
 
 	processChunk := func(j int, points []G2Affine, scalars []fr.Element, chChunk chan g2JacExtended) {
 		var buckets [1 << (c - 1)]g2JacExtended
-		msmProcessChunkG2Affine(uint64(j), chChunk, buckets[:], c, points, scalars)
+		msmProcessChunkG2Affine(uint64(j), chChunk, buckets[:], c, points, scalars, scalarsMont)
 	}
 
 	for j := int(nbChunks - 1); j > 0; j-- {
@@ -1899,7 +2185,7 @@ func (p *G2Jac) msmC12(points []G2Affine, scalars []fr.Element, splitFirstChunk
 	return msmReduceChunkG2Affine(p, c, chChunks[:])
 }
 
-func (p *G2Jac) msmC13(points []G2Affine, scalars []fr.Element, splitFirstChunk bool) *G2Jac {
+func (p *G2Jac) msmC13(points []G2Affine, scalars []fr.Element, scalarsMont, splitFirstChunk bool) *G2Jac {
 	const (
 		c        = 13                  // scalars partitioned into c-bit radixes
 		nbChunks = (fr.Limbs * 64 / c) // number of c-bit radixes in a scalar
@@ -1920,12 +2206,12 @@ func (p *G2Jac) msmC13(points []G2Affine, scalars []fr.Element, splitFirstChunk
 	const lastC = (fr.Limbs * 64) - (c * (fr.Limbs * 64 / c))
 	go func(j uint64, points []G2Affine, scalars []fr.Element) {
 		var buckets [1 << (lastC - 1)]g2JacExtended
-		msmProcessChunkG2Affine(j, chChunks[j], buckets[:], c, points, scalars)
+		msmProcessChunkG2Affine(j, chChunks[j], buckets[:], c, points, scalars, scalarsMont)
 	}(uint64(nbChunks), points, scalars)
 
 	processChunk := func(j int, points []G2Affine, scalars []fr.Element, chChunk chan g2JacExtended) {
 		var buckets [1 << (c - 1)]g2JacExtended
-		msmProcessChunkG2Affine(uint64(j), chChunk, buckets[:], c, points, scalars)
+		msmProcessChunkG2Affine(uint64(j), chChunk, buckets[:], c, points, scalars, scalarsMont)
 	}
 
 	for j := int(nbChunks - 1); j > 0; j-- {
@@ -1951,7 +2237,7 @@ func (p *G2Jac) msmC13(points []G2Affine, scalars []fr.Element, splitFirstChunk
 	return msmReduceChunkG2Affine(p, c, chChunks[:])
 }
 
-func (p *G2Jac) msmC14(points []G2Affine, scalars []fr.Element, splitFirstChunk bool) *G2Jac {
+func (p *G2Jac) msmC14(points []G2Affine, scalars []fr.Element, scalarsMont, splitFirstChunk bool) *G2Jac {
 	const (
 		c        = 14                  // scalars partitioned into c-bit radixes
 		nbChunks = (fr.Limbs * 64 / c) // number of c-bit radixes in a scalar
@@ -1972,12 +2258,12 @@ func (p *G2Jac) msmC14(points []G2Affine, scalars []fr.Element, splitFirstChunk
 	const lastC = (fr.Limbs * 64) - (c * (fr.Limbs * 64 / c))
 	go func(j uint64, points []G2Affine, scalars []fr.Element) {
 		var buckets [1 << (lastC - 1)]g2JacExtended
-		msmProcessChunkG2Affine(j, chChunks[j], buckets[:], c, points, scalars)
+		msmProcessChunkG2Affine(j, chChunks[j], buckets[:], c, points, scalars, scalarsMont)
 	}(uint64(nbChunks), points, scalars)
 
 	processChunk := func(j int, points []G2Affine, scalars []fr.Element, chChunk chan g2JacExtended) {
 		var buckets [1 << (c - 1)]g2JacExtended
-		msmProcessChunkG2Affine(uint64(j), chChunk, buckets[:], c, points, scalars)
+		msmProcessChunkG2Affine(uint64(j), chChunk, buckets[:], c, points, scalars, scalarsMont)
 	}
 
 	for j := int(nbChunks - 1); j > 0; j-- {
@@ -2003,7 +2289,7 @@ func (p *G2Jac) msmC14(points []G2Affine, scalars []fr.Element, splitFirstChunk
 	return msmReduceChunkG2Affine(p, c, chChunks[:])
 }
 
-func (p *G2Jac) msmC15(points []G2Affine, scalars []fr.Element, splitFirstChunk bool) *G2Jac {
+func (p *G2Jac) msmC15(points []G2Affine, scalars []fr.Element, scalarsMont, splitFirstChunk bool) *G2Jac {
 	const (
 		c        = 15                  // scalars partitioned into c-bit radixes
 		nbChunks = (fr.Limbs * 64 / c) // number of c-bit radixes in a scalar
@@ -2024,12 +2310,12 @@ func (p *G2Jac) msmC15(points []G2Affine, scalars []fr.Element, splitFirstChunk
 	const lastC = (fr.Limbs * 64) - (c * (fr.Limbs * 64 / c))
 	go func(j uint64, points []G2Affine, scalars []fr.Element) {
 		var buckets [1 << (lastC - 1)]g2JacExtended
-		msmProcessChunkG2Affine(j, chChunks[j], buckets[:], c, points, scalars)
+		msmProcessChunkG2Affine(j, chChunks[j], buckets[:], c, points, scalars, scalarsMont)
 	}(uint64(nbChunks), points, scalars)
 
 	processChunk := func(j int, points []G2Affine, scalars []fr.Element, chChunk chan g2JacExtended) {
 		var buckets [1 << (c - 1)]g2JacExtended
-		msmProcessChunkG2Affine(uint64(j), chChunk, buckets[:], c, points, scalars)
+		msmProcessChunkG2Affine(uint64(j), chChunk, buckets[:], c, points, scalars, scalarsMont)
 	}
 
 	for j := int(nbChunks - 1); j > 0; j-- {
@@ -2055,7 +2341,7 @@ func (p *G2Jac) msmC15(points []G2Affine, scalars []fr.Element, splitFirstChunk
 	return msmReduceChunkG2Affine(p, c, chChunks[:])
 }
 
-func (p *G2Jac) msmC16(points []G2Affine, scalars []fr.Element, splitFirstChunk bool) *G2Jac {
+func (p *G2Jac) msmC16(points []G2Affine, scalars []fr.Element, scalarsMont, splitFirstChunk bool) *G2Jac {
 	const (
 		c        = 16                  // scalars partitioned into c-bit radixes
 		nbChunks = (fr.Limbs * 64 / c) // number of c-bit radixes in a scalar
@@ -2074,7 +2360,7 @@ func (p *G2Jac) msmC16(points []G2Affine, scalars []fr.Element, splitFirstChunk
 
 	processChunk := func(j int, points []G2Affine, scalars []fr.Element, chChunk chan g2JacExtended) {
 		var buckets [1 << (c - 1)]g2JacExtended
-		msmProcessChunkG2Affine(uint64(j), chChunk, buckets[:], c, points, scalars)
+		msmProcessChunkG2Affine(uint64(j), chChunk, buckets[:], c, points, scalars, scalarsMont)
 	}
 
 	for j := int(nbChunks - 1); j > 0; j-- {
@@ -2100,7 +2386,7 @@ func (p *G2Jac) msmC16(points []G2Affine, scalars []fr.Element, splitFirstChunk
 	return msmReduceChunkG2Affine(p, c, chChunks[:])
 }
 
-func (p *G2Jac) msmC20(points []G2Affine, scalars []fr.Element, splitFirstChunk bool) *G2Jac {
+func (p *G2Jac) msmC20(points []G2Affine, scalars []fr.Element, scalarsMont, splitFirstChunk bool) *G2Jac {
 	const (
 		c        = 20                  // scalars partitioned into c-bit radixes
 		nbChunks = (fr.Limbs * 64 / c) // number of c-bit radixes in a scalar
@@ -2121,12 +2407,12 @@ func (p *G2Jac) msmC20(points []G2Affine, scalars []fr.Element, splitFirstChunk
 	const lastC = (fr.Limbs * 64) - (c * (fr.Limbs * 64 / c))
 	go func(j uint64, points []G2Affine, scalars []fr.Element) {
 		var buckets [1 << (lastC - 1)]g2JacExtended
-		msmProcessChunkG2Affine(j, chChunks[j], buckets[:], c, points, scalars)
+		msmProcessChunkG2Affine(j, chChunks[j], buckets[:], c, points, scalars, scalarsMont)
 	}(uint64(nbChunks), points, scalars)
 
 	processChunk := func(j int, points []G2Affine, scalars []fr.Element, chChunk chan g2JacExtended) {
 		var buckets [1 << (c - 1)]g2JacExtended
-		msmProcessChunkG2Affine(uint64(j), chChunk, buckets[:], c, points, scalars)
+		msmProcessChunkG2Affine(uint64(j), chChunk, buckets[:], c, points, scalars, scalarsMont)
 	}
 
 	for j := int(nbChunks - 1); j > 0; j-- {
@@ -2152,7 +2438,7 @@ func (p *G2Jac) msmC20(points []G2Affine, scalars []fr.Element, splitFirstChunk
 	return msmReduceChunkG2Affine(p, c, chChunks[:])
 }
 
-func (p *G2Jac) msmC21(points []G2Affine, scalars []fr.Element, splitFirstChunk bool) *G2Jac {
+func (p *G2Jac) msmC21(points []G2Affine, scalars []fr.Element, scalarsMont, splitFirstChunk bool) *G2Jac {
 	const (
 		c        = 21                  // scalars partitioned into c-bit radixes
 		nbChunks = (fr.Limbs * 64 / c) // number of c-bit radixes in a scalar
@@ -2173,12 +2459,12 @@ func (p *G2Jac) msmC21(points []G2Affine, scalars []fr.Element, splitFirstChunk
 	const lastC = (fr.Limbs * 64) - (c * (fr.Limbs * 64 / c))
 	go func(j uint64, points []G2Affine, scalars []fr.Element) {
 		var buckets [1 << (lastC - 1)]g2JacExtended
-		msmProcessChunkG2Affine(j, chChunks[j], buckets[:], c, points, scalars)
+		msmProcessChunkG2Affine(j, chChunks[j], buckets[:], c, points, scalars, scalarsMont)
 	}(uint64(nbChunks), points, scalars)
 
 	processChunk := func(j int, points []G2Affine, scalars []fr.Element, chChunk chan g2JacExtended) {
 		var buckets [1 << (c - 1)]g2JacExtended
-		msmProcessChunkG2Affine(uint64(j), chChunk, buckets[:], c, points, scalars)
+		msmProcessChunkG2Affine(uint64(j), chChunk, buckets[:], c, points, scalars, scalarsMont)
 	}
 
 	for j := int(nbChunks - 1); j > 0; j-- {
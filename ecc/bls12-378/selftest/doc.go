@@ -0,0 +1,23 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+// Package selftest runs randomized consistency checks of the arithmetic of bls12-378.
+//
+// The checks compare the (possibly assembly) implementations to independent computations:
+// field arithmetic against math/big, multi-exponentiation against scalar multiplications,
+// pairing bilinearity, FFT round trip and a KZG commitment opening. They are meant to be run
+// at deployment time, to detect a CPU on which the optimized code paths are incorrect.
+package selftest
@@ -0,0 +1,385 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package selftest
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	bls12378 "github.com/consensys/gnark-crypto/ecc/bls12-378"
+	"github.com/consensys/gnark-crypto/ecc/bls12-378/fp"
+	"github.com/consensys/gnark-crypto/ecc/bls12-378/fr"
+	"github.com/consensys/gnark-crypto/ecc/bls12-378/fr/fft"
+	"github.com/consensys/gnark-crypto/ecc/bls12-378/fr/kzg"
+)
+
+var (
+	ErrFieldArithmetic = errors.New("field arithmetic is inconsistent with math/big")
+	ErrMultiExp        = errors.New("multi-exponentiation is inconsistent with scalar multiplications")
+	ErrPairing         = errors.New("pairing is not bilinear")
+	ErrFFT             = errors.New("fft is inconsistent with polynomial evaluation")
+	ErrKZG             = errors.New("kzg opening proof is not verified correctly")
+)
+
+const (
+	nbFieldChecks = 64
+	msmSize       = 16
+	fftSize       = 64
+	kzgSize       = 32
+)
+
+// Run runs the randomized consistency checks and returns the first failure,
+// which wraps one of the errors of the package.
+func Run() error {
+	for _, check := range []func() error{checkFr, checkFp, checkMultiExp, checkPairing, checkFFT, checkKZG} {
+		if err := check(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkFr compares the arithmetic of fr with math/big, on random and edge case operands
+func checkFr() error {
+	modulus := fr.Modulus()
+	var a, b, z fr.Element
+	var bA, bB, bZ, expected big.Int
+
+	check := func(op string) error {
+		z.ToBigIntRegular(&bZ)
+		if bZ.Cmp(&expected) != 0 {
+			return fmt.Errorf("fr: %s: %w", op, ErrFieldArithmetic)
+		}
+		return nil
+	}
+
+	for i := 0; i < nbFieldChecks; i++ {
+		switch i {
+		case 0:
+			// largest element
+			a.SetOne().Neg(&a)
+			b = a
+		case 1:
+			a.SetZero()
+			b.SetOne()
+		default:
+			if _, err := a.SetRandom(); err != nil {
+				return err
+			}
+			if _, err := b.SetRandom(); err != nil {
+				return err
+			}
+			if b.IsZero() {
+				b.SetOne()
+			}
+		}
+		a.ToBigIntRegular(&bA)
+		b.ToBigIntRegular(&bB)
+
+		z.Add(&a, &b)
+		expected.Add(&bA, &bB).Mod(&expected, modulus)
+		if err := check("a+b"); err != nil {
+			return err
+		}
+		z.Sub(&a, &b)
+		expected.Sub(&bA, &bB).Mod(&expected, modulus)
+		if err := check("a-b"); err != nil {
+			return err
+		}
+		z.Mul(&a, &b)
+		expected.Mul(&bA, &bB).Mod(&expected, modulus)
+		if err := check("a·b"); err != nil {
+			return err
+		}
+		z.Div(&z, &b)
+		expected.Set(&bA)
+		if err := check("a·b/b"); err != nil {
+			return err
+		}
+		z.Square(&a)
+		expected.Mul(&bA, &bA).Mod(&expected, modulus)
+		if err := check("a²"); err != nil {
+			return err
+		}
+		z.Inverse(&b)
+		expected.ModInverse(&bB, modulus)
+		if err := check("1/b"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkFp compares the arithmetic of fp with math/big, on random and edge case operands
+func checkFp() error {
+	modulus := fp.Modulus()
+	var a, b, z fp.Element
+	var bA, bB, bZ, expected big.Int
+
+	check := func(op string) error {
+		z.ToBigIntRegular(&bZ)
+		if bZ.Cmp(&expected) != 0 {
+			return fmt.Errorf("fp: %s: %w", op, ErrFieldArithmetic)
+		}
+		return nil
+	}
+
+	for i := 0; i < nbFieldChecks; i++ {
+		switch i {
+		case 0:
+			// largest element
+			a.SetOne().Neg(&a)
+			b = a
+		case 1:
+			a.SetZero()
+			b.SetOne()
+		default:
+			if _, err := a.SetRandom(); err != nil {
+				return err
+			}
+			if _, err := b.SetRandom(); err != nil {
+				return err
+			}
+			if b.IsZero() {
+				b.SetOne()
+			}
+		}
+		a.ToBigIntRegular(&bA)
+		b.ToBigIntRegular(&bB)
+
+		z.Add(&a, &b)
+		expected.Add(&bA, &bB).Mod(&expected, modulus)
+		if err := check("a+b"); err != nil {
+			return err
+		}
+		z.Sub(&a, &b)
+		expected.Sub(&bA, &bB).Mod(&expected, modulus)
+		if err := check("a-b"); err != nil {
+			return err
+		}
+		z.Mul(&a, &b)
+		expected.Mul(&bA, &bB).Mod(&expected, modulus)
+		if err := check("a·b"); err != nil {
+			return err
+		}
+		z.Div(&z, &b)
+		expected.Set(&bA)
+		if err := check("a·b/b"); err != nil {
+			return err
+		}
+		z.Square(&a)
+		expected.Mul(&bA, &bA).Mod(&expected, modulus)
+		if err := check("a²"); err != nil {
+			return err
+		}
+		z.Inverse(&b)
+		expected.ModInverse(&bB, modulus)
+		if err := check("1/b"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkMultiExp compares the multi-exponentiations in G1 and G2 with sums of scalar multiplications
+func checkMultiExp() error {
+	_, _, g1, g2 := bls12378.Generators()
+
+	var s big.Int
+	scalars := make([]fr.Element, msmSize)
+	points1 := make([]bls12378.G1Affine, msmSize)
+	points2 := make([]bls12378.G2Affine, msmSize)
+	var expected1 bls12378.G1Jac
+	var expected2 bls12378.G2Jac
+	for i := range scalars {
+		var r fr.Element
+		if _, err := r.SetRandom(); err != nil {
+			return err
+		}
+		r.ToBigIntRegular(&s)
+		points1[i].ScalarMultiplication(&g1, &s)
+		points2[i].ScalarMultiplication(&g2, &s)
+
+		if _, err := scalars[i].SetRandom(); err != nil {
+			return err
+		}
+		scalars[i].ToBigIntRegular(&s)
+		var p1 bls12378.G1Affine
+		var p2 bls12378.G2Affine
+		p1.ScalarMultiplication(&points1[i], &s)
+		p2.ScalarMultiplication(&points2[i], &s)
+		expected1.AddMixed(&p1)
+		expected2.AddMixed(&p2)
+	}
+
+	var res1, exp1 bls12378.G1Affine
+	var res2, exp2 bls12378.G2Affine
+	if _, err := res1.MultiExp(points1, scalars, ecc.MultiExpConfig{ScalarsMont: true}); err != nil {
+		return err
+	}
+	if _, err := res2.MultiExp(points2, scalars, ecc.MultiExpConfig{ScalarsMont: true}); err != nil {
+		return err
+	}
+	exp1.FromJacobian(&expected1)
+	exp2.FromJacobian(&expected2)
+	if !res1.Equal(&exp1) {
+		return fmt.Errorf("G1: %w", ErrMultiExp)
+	}
+	if !res2.Equal(&exp2) {
+		return fmt.Errorf("G2: %w", ErrMultiExp)
+	}
+	return nil
+}
+
+// checkPairing checks that e([a]g₁, [b]g₂) = e(g₁, g₂)^{ab}
+func checkPairing() error {
+	_, _, g1, g2 := bls12378.Generators()
+
+	var a, b, ab fr.Element
+	if _, err := a.SetRandom(); err != nil {
+		return err
+	}
+	if _, err := b.SetRandom(); err != nil {
+		return err
+	}
+	ab.Mul(&a, &b)
+	var bA, bB, bAB big.Int
+	a.ToBigIntRegular(&bA)
+	b.ToBigIntRegular(&bB)
+	ab.ToBigIntRegular(&bAB)
+
+	var p, pAB bls12378.G1Affine
+	var q bls12378.G2Affine
+	p.ScalarMultiplication(&g1, &bA)
+	q.ScalarMultiplication(&g2, &bB)
+	pAB.ScalarMultiplication(&g1, &bAB)
+
+	left, err := bls12378.Pair([]bls12378.G1Affine{p}, []bls12378.G2Affine{q})
+	if err != nil {
+		return err
+	}
+	right, err := bls12378.Pair([]bls12378.G1Affine{g1}, []bls12378.G2Affine{g2})
+	if err != nil {
+		return err
+	}
+	right.Exp(right, &bAB)
+	var one bls12378.GT
+	one.SetOne()
+	if !left.Equal(&right) || left.Equal(&one) {
+		return ErrPairing
+	}
+
+	// e([a]g₁, [b]g₂)·e(-[ab]g₁, g₂) = 1
+	pAB.Neg(&pAB)
+	ok, err := bls12378.PairingCheck([]bls12378.G1Affine{p, pAB}, []bls12378.G2Affine{q, g2})
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrPairing
+	}
+	return nil
+}
+
+// checkFFT checks the round trip of the fft, and one of the evaluations against Horner's method
+func checkFFT() error {
+	domain := fft.NewDomain(fftSize)
+	p := make([]fr.Element, fftSize)
+	for i := range p {
+		if _, err := p[i].SetRandom(); err != nil {
+			return err
+		}
+	}
+
+	evaluations := make([]fr.Element, fftSize)
+	copy(evaluations, p)
+	domain.FFT(evaluations, fft.DIF)
+
+	natural := make([]fr.Element, fftSize)
+	copy(natural, evaluations)
+	fft.BitReverse(natural)
+	if expected := eval(p, domain.Generator); !natural[1].Equal(&expected) {
+		return ErrFFT
+	}
+
+	domain.FFTInverse(evaluations, fft.DIT)
+	for i := range p {
+		if !evaluations[i].Equal(&p[i]) {
+			return ErrFFT
+		}
+	}
+	return nil
+}
+
+// checkKZG commits to a random polynomial, opens it at a random point, and checks
+// that the proof is accepted, and rejected for a wrong claimed value
+func checkKZG() error {
+	var alpha fr.Element
+	if _, err := alpha.SetRandom(); err != nil {
+		return err
+	}
+	var bAlpha big.Int
+	srs, err := kzg.NewSRS(kzgSize, alpha.ToBigIntRegular(&bAlpha))
+	if err != nil {
+		return err
+	}
+
+	p := make([]fr.Element, kzgSize)
+	for i := range p {
+		if _, err := p[i].SetRandom(); err != nil {
+			return err
+		}
+	}
+	var point fr.Element
+	if _, err := point.SetRandom(); err != nil {
+		return err
+	}
+
+	digest, err := kzg.Commit(p, srs)
+	if err != nil {
+		return err
+	}
+	proof, err := kzg.Open(p, point, srs)
+	if err != nil {
+		return err
+	}
+	if expected := eval(p, point); !proof.ClaimedValue.Equal(&expected) {
+		return ErrKZG
+	}
+	if err := kzg.Verify(&digest, &proof, point, srs); err != nil {
+		return fmt.Errorf("%w: %v", ErrKZG, err)
+	}
+
+	var one fr.Element
+	one.SetOne()
+	proof.ClaimedValue.Add(&proof.ClaimedValue, &one)
+	if err := kzg.Verify(&digest, &proof, point, srs); err == nil {
+		return ErrKZG
+	}
+	return nil
+}
+
+// eval returns p(x), p in canonical form
+func eval(p []fr.Element, x fr.Element) fr.Element {
+	var res fr.Element
+	for i := len(p) - 1; i >= 0; i-- {
+		res.Mul(&res, &x).Add(&res, &p[i])
+	}
+	return res
+}
@@ -0,0 +1,33 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+// Package hdkey provides hierarchical deterministic key derivation on bls12-378's twistededwards curve,
+// in the style of BIP32.
+//
+// An extended key is a key together with a 32 bytes chain code. Child keys are derived
+// from their parent and a 32 bits index:
+//   - hardened children (index ≥ HardenedOffset) can only be derived from the private key
+//   - normal children can also be derived from the public key, so that a watch-only wallet
+//     can compute the public keys of the children without knowing the private keys
+//
+// The derivation follows BIP32, with the group of the curve instead of secp256k1: the child
+// private key is k + t mod r and the child public key A + [t]G, where the tweak t and the child
+// chain code are derived from the parent chain code with HMAC-SHA512. As the order r of the
+// curve is far from a power of 2, t is obtained by reducing 64 bytes modulo r instead of
+// rejecting values ≥ r. The keys are thus not compatible with BIP32 wallets on secp256k1.
+//
+// The secret scalar of a key can be used with the ecdh package (see ecdh.NewPrivateKey).
+package hdkey
@@ -0,0 +1,104 @@
+//go:build dudect
+// +build dudect
+
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package bandersnatch
+
+import (
+	"crypto/rand"
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/consensys/gnark-crypto/internal/dudect"
+)
+
+// the timing tests compare a fixed input (class 0) with random inputs (class 1); a random
+// input is drawn for both classes, so that the preparation of the inputs does not bias the measurements
+
+func TestTimingInverseCT(t *testing.T) {
+	var x, z fr.Element
+	prepare := func(class int) {
+		x.SetRandom()
+		if class == 0 {
+			x.SetOne()
+		}
+	}
+	dudect.Assert(t, dudect.Config{Samples: 20000}, prepare, func() {
+		invCT(&z, &x)
+	})
+}
+
+func TestTimingMapToCurve(t *testing.T) {
+	var u fr.Element
+	prepare := func(class int) {
+		u.SetRandom()
+		if class == 0 {
+			u.SetOne()
+		}
+	}
+	dudect.Assert(t, dudect.Config{Samples: 10000}, prepare, func() {
+		MapToCurve(&u)
+	})
+}
+
+func TestTimingScalarMultiplicationU(t *testing.T) {
+	params := GetEdwardsCurve()
+	u := params.Base.MontgomeryU()
+
+	// the scalars have the bit length of the order, as the ladder runs on max(bitlen(s), bitlen(order)) bits
+	var fixed big.Int
+	fixed.SetBit(&fixed, params.Order.BitLen()-1, 1)
+	var s *big.Int
+	prepare := func(class int) {
+		var err error
+		if s, err = rand.Int(rand.Reader, &params.Order); err != nil {
+			t.Fatal(err)
+		}
+		s.SetBit(s, params.Order.BitLen()-1, 1)
+		if class == 0 {
+			s.Set(&fixed)
+		}
+	}
+	dudect.Assert(t, dudect.Config{Samples: 10000}, prepare, func() {
+		ScalarMultiplicationU(&u, s)
+	})
+}
+
+func TestTimingScalarMultiplicationCT(t *testing.T) {
+	params := GetEdwardsCurve()
+
+	// the scalars have the bit length of the order, as the ladder runs on max(bitlen(s), bitlen(order)) bits
+	var fixed big.Int
+	fixed.SetBit(&fixed, params.Order.BitLen()-1, 1)
+	var s *big.Int
+	prepare := func(class int) {
+		var err error
+		if s, err = rand.Int(rand.Reader, &params.Order); err != nil {
+			t.Fatal(err)
+		}
+		s.SetBit(s, params.Order.BitLen()-1, 1)
+		if class == 0 {
+			s.Set(&fixed)
+		}
+	}
+	var p PointAffine
+	dudect.Assert(t, dudect.Config{Samples: 10000}, prepare, func() {
+		p.ScalarMultiplicationCT(&params.Base, s)
+	})
+}
@@ -0,0 +1,28 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+// Package ecies provides ECIES encryption on bls12-381's bandersnatch curve.
+//
+// A message is encrypted with an ephemeral Diffie-Hellman key exchange with the
+// recipient's public key. The shared point is fed to HKDF-SHA256 to derive an
+// AES-256-GCM key and nonce, which are used once.
+//
+// The ciphertext is the compressed ephemeral public key followed by the sealed message.
+//
+// # See also
+//
+// https://en.wikipedia.org/wiki/Integrated_Encryption_Scheme
+package ecies
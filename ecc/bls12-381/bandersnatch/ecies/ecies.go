@@ -0,0 +1,182 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package ecies
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"errors"
+	"io"
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/bandersnatch"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"golang.org/x/crypto/hkdf"
+)
+
+var (
+	ErrInvalidPublicKey = errors.New("public key is not a point of the prime order subgroup")
+	ErrCiphertextSize   = errors.New("ciphertext is too short")
+	ErrDecryption       = errors.New("decryption failed")
+)
+
+const (
+	sizeFr         = fr.Bytes
+	sizePublicKey  = sizeFr
+	sizeKey        = 32
+	sizeNonce      = 12
+	sizeCiphertext = sizePublicKey + 16 // overhead of a ciphertext: ephemeral key and tag
+)
+
+// info is the context information of the key derivation
+var info = []byte("gnark-crypto ecies bls12-381 bandersnatch")
+
+// PublicKey ecies public key
+type PublicKey struct {
+	A bandersnatch.PointAffine
+}
+
+// PrivateKey ecies private key
+type PrivateKey struct {
+	PublicKey PublicKey // copy of the associated public key
+	scalar    big.Int   // secret scalar
+}
+
+// GenerateKey generates a public and private key pair, the secret scalar
+// is sampled uniformly in [1, order).
+func GenerateKey(r io.Reader) (*PrivateKey, error) {
+	c := bandersnatch.GetEdwardsCurve()
+
+	var priv PrivateKey
+	var orderMinusOne big.Int
+	orderMinusOne.Sub(&c.Order, big.NewInt(1))
+	s, err := rand.Int(r, &orderMinusOne)
+	if err != nil {
+		return nil, err
+	}
+	priv.scalar.Add(s, big.NewInt(1))
+	priv.PublicKey.A.ScalarMultiplicationCT(&c.Base, &priv.scalar)
+
+	return &priv, nil
+}
+
+// Bytes returns the compressed public key
+func (pub *PublicKey) Bytes() []byte {
+	b := pub.A.Bytes()
+	return b[:]
+}
+
+// SetBytes sets pub from its compressed form, and checks it is a valid key.
+// It returns the number of bytes read.
+func (pub *PublicKey) SetBytes(buf []byte) (int, error) {
+	n, err := pub.A.SetBytes(buf)
+	if err != nil {
+		return n, err
+	}
+	if !pub.IsValid() {
+		return n, ErrInvalidPublicKey
+	}
+	return n, nil
+}
+
+// IsValid returns true if the public key is a point of the prime order subgroup,
+// different from the identity.
+func (pub *PublicKey) IsValid() bool {
+	if !pub.A.IsOnCurve() || pub.A.IsZero() {
+		return false
+	}
+	c := bandersnatch.GetEdwardsCurve()
+	var p bandersnatch.PointAffine
+	p.ScalarMultiplication(&pub.A, &c.Order)
+	return p.IsZero()
+}
+
+// Encrypt encrypts plaintext for pub, additionalData is authenticated but
+// not encrypted. r is the source of randomness for the ephemeral key.
+func Encrypt(r io.Reader, pub *PublicKey, plaintext, additionalData []byte) ([]byte, error) {
+	if !pub.IsValid() {
+		return nil, ErrInvalidPublicKey
+	}
+
+	ephemeral, err := GenerateKey(r)
+	if err != nil {
+		return nil, err
+	}
+	var shared bandersnatch.PointAffine
+	shared.ScalarMultiplicationCT(&pub.A, &ephemeral.scalar)
+
+	ephemeralBytes := ephemeral.PublicKey.Bytes()
+	aead, nonce, err := deriveCipher(&shared, ephemeralBytes, pub.Bytes())
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]byte, sizePublicKey, sizeCiphertext+len(plaintext))
+	copy(res, ephemeralBytes)
+	return aead.Seal(res, nonce, plaintext, additionalData), nil
+}
+
+// Decrypt decrypts a ciphertext produced by Encrypt, with the same additionalData.
+func (priv *PrivateKey) Decrypt(ciphertext, additionalData []byte) ([]byte, error) {
+	if len(ciphertext) < sizeCiphertext {
+		return nil, ErrCiphertextSize
+	}
+
+	var ephemeral PublicKey
+	if _, err := ephemeral.SetBytes(ciphertext[:sizePublicKey]); err != nil {
+		return nil, ErrDecryption
+	}
+	var shared bandersnatch.PointAffine
+	shared.ScalarMultiplicationCT(&ephemeral.A, &priv.scalar)
+
+	aead, nonce, err := deriveCipher(&shared, ciphertext[:sizePublicKey], priv.PublicKey.Bytes())
+	if err != nil {
+		return nil, err
+	}
+	plaintext, err := aead.Open(nil, nonce, ciphertext[sizePublicKey:], additionalData)
+	if err != nil {
+		return nil, ErrDecryption
+	}
+	return plaintext, nil
+}
+
+// deriveCipher derives the AES-GCM key and nonce from the shared point, binded to
+// the ephemeral and recipient public keys.
+func deriveCipher(shared *bandersnatch.PointAffine, ephemeral, recipient []byte) (cipher.AEAD, []byte, error) {
+	secret := shared.Bytes()
+	salt := make([]byte, 0, 2*sizePublicKey)
+	salt = append(salt, ephemeral...)
+	salt = append(salt, recipient...)
+
+	kdf := hkdf.New(sha256.New, secret[:], salt, info)
+	key := make([]byte, sizeKey+sizeNonce)
+	if _, err := io.ReadFull(kdf, key); err != nil {
+		return nil, nil, err
+	}
+
+	block, err := aes.NewCipher(key[:sizeKey])
+	if err != nil {
+		return nil, nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, nil, err
+	}
+	return aead, key[sizeKey:], nil
+}
@@ -0,0 +1,192 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package bandersnatch
+
+import (
+	crand "crypto/rand"
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/leanovate/gopter"
+	"github.com/leanovate/gopter/prop"
+)
+
+func TestElligator(t *testing.T) {
+	t.Parallel()
+	parameters := gopter.DefaultTestParameters()
+	if testing.Short() {
+		parameters.MinSuccessfulTests = nbFuzzShort
+	} else {
+		parameters.MinSuccessfulTests = nbFuzz
+	}
+
+	properties := gopter.NewProperties(parameters)
+
+	genS := GenBigInt()
+
+	properties.Property("MapToCurve should output a point on the curve, the same for u and -u", prop.ForAll(
+		func(s big.Int) bool {
+			var u, minusU fr.Element
+			u.SetBigInt(&s)
+			minusU.Neg(&u)
+			p, q := MapToCurve(&u), MapToCurve(&minusU)
+			return p.IsOnCurve() && p.Equal(&q)
+		},
+		genS,
+	))
+
+	properties.Property("MapToCurveInverse should invert MapToCurve", prop.ForAll(
+		func(s big.Int) bool {
+			var u fr.Element
+			u.SetBigInt(&s)
+			p := MapToCurve(&u)
+			r, ok := MapToCurveInverse(&p)
+			if !ok || sgn0(&r) != 0 {
+				return false
+			}
+			q := MapToCurve(&r)
+			r.Square(&r)
+			u.Square(&u)
+			return q.Equal(&p) && r.Equal(&u)
+		},
+		genS,
+	))
+
+	properties.Property("MapToCurveInverse should fail on points outside of the image of MapToCurve", prop.ForAll(
+		func(s big.Int) bool {
+			var p PointAffine
+			p.ScalarMultiplication(&curveParams.Base, &s)
+			u, ok := MapToCurveInverse(&p)
+			q := MapToCurve(&u)
+			return ok == q.Equal(&p)
+		},
+		genS,
+	))
+
+	properties.Property("DecodeUniform(EncodeUniform(p)) should output p", prop.ForAll(
+		func(s big.Int) bool {
+			var p PointAffine
+			p.ScalarMultiplication(&curveParams.Base, &s)
+			buf, err := EncodeUniform(&p, crand.Reader)
+			if err == ErrNoUniformEncoding {
+				// check that p + T has no preimage for all T
+				var mp, q montPoint
+				mp.fromEdwards(&p)
+				for i := range ell.torsion {
+					if _, ok := elligatorInverse(q.add(&mp, &ell.torsion[i])); ok {
+						return false
+					}
+				}
+				return true
+			}
+			if err != nil || len(buf) != SizeOfUniformEncoding {
+				return false
+			}
+			q, err := DecodeUniform(buf)
+			return err == nil && q.Equal(&p)
+		},
+		genS,
+	))
+
+	properties.Property("DecodeUniform should output a point of the prime order subgroup for any input", prop.ForAll(
+		func(s big.Int) bool {
+			buf := make([]byte, SizeOfUniformEncoding)
+			s.FillBytes(buf[16:])
+			p, err := DecodeUniform(buf)
+			if err != nil || !p.IsOnCurve() {
+				return false
+			}
+			var q montPoint
+			q.fromEdwards(&p)
+			return q.scalarMul(&q, &curveParams.Order).infinity
+		},
+		genS,
+	))
+
+	properties.Property("sqrtCT should output a square root", prop.ForAll(
+		func(s big.Int) bool {
+			var x, r fr.Element
+			x.SetBigInt(&s).Square(&x)
+			sqrtCT(&r, &x)
+			r.Square(&r)
+			return r.Equal(&x) && isSquareCT(&x) == 1
+		},
+		genS,
+	))
+
+	properties.TestingRun(t, gopter.ConsoleReporter(false))
+}
+
+func TestElligatorEdgeCases(t *testing.T) {
+	t.Parallel()
+	initOnce.Do(initCurveParams)
+	ellOnce.Do(initElligatorParams)
+
+	if len(ell.torsion) != int(ell.cofactor.Uint64()) {
+		t.Fatal("unexpected number of torsion points")
+	}
+	for i := range ell.torsion {
+		var q montPoint
+		if !q.scalarMul(&ell.torsion[i], &ell.cofactor).infinity {
+			t.Fatal("invalid torsion point")
+		}
+	}
+
+	var zero fr.Element
+	p := MapToCurve(&zero)
+	if !p.IsOnCurve() {
+		t.Fatal("MapToCurve(0) should be on the curve")
+	}
+
+	var identity PointAffine
+	identity.setInfinity()
+	buf, err := EncodeUniform(&identity, crand.Reader)
+	if err == nil {
+		if q, err := DecodeUniform(buf); err != nil || !q.IsZero() {
+			t.Fatal("failed to encode the identity")
+		}
+	} else if err != ErrNoUniformEncoding {
+		t.Fatal(err)
+	}
+
+	var twoTorsion PointAffine
+	twoTorsion.Y.SetOne().Neg(&twoTorsion.Y)
+	if _, err := EncodeUniform(&twoTorsion, crand.Reader); err != ErrNotInSubGroup {
+		t.Fatal("expected ErrNotInSubGroup")
+	}
+	if _, err := DecodeUniform(make([]byte, SizeOfUniformEncoding-1)); err != ErrInvalidUniformSize {
+		t.Fatal("expected ErrInvalidUniformSize")
+	}
+}
+
+func BenchmarkMapToCurve(b *testing.B) {
+	var u fr.Element
+	u.SetRandom()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		MapToCurve(&u)
+	}
+}
+
+func BenchmarkEncodeUniform(b *testing.B) {
+	params := GetEdwardsCurve()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		EncodeUniform(&params.Base, crand.Reader)
+	}
+}
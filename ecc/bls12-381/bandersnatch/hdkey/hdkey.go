@@ -0,0 +1,262 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package hdkey
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/binary"
+	"errors"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/bandersnatch"
+)
+
+// HardenedOffset is the index of the first hardened child
+const HardenedOffset uint32 = 1 << 31
+
+// ChainCodeSize size in bytes of a chain code
+const ChainCodeSize = 32
+
+var (
+	ErrInvalidSeed     = errors.New("seed must be between 16 and 64 bytes")
+	ErrInvalidChild    = errors.New("invalid child key, use the next index")
+	ErrHardenedPublic  = errors.New("cannot derive a hardened child from a public key")
+	ErrMaxDepth        = errors.New("maximum depth reached")
+	ErrInvalidPath     = errors.New("invalid derivation path")
+	ErrInvalidKeyState = errors.New("invalid extended key")
+)
+
+// masterKey is the HMAC key used to derive the master key from the seed
+var masterKey = []byte("gnark-crypto bls12-381/bandersnatch seed")
+
+// ExtendedPublicKey public key with its chain code and its position in the tree
+type ExtendedPublicKey struct {
+	A                 bandersnatch.PointAffine
+	ChainCode         [ChainCodeSize]byte
+	Depth             uint8
+	ParentFingerprint [4]byte
+	Index             uint32
+}
+
+// ExtendedPrivateKey private key with its chain code and its position in the tree
+type ExtendedPrivateKey struct {
+	PublicKey ExtendedPublicKey // copy of the associated extended public key
+	scalar    big.Int           // secret scalar
+}
+
+// NewMasterKey derives the master key, at the root of the tree, from a seed of 16 to 64 bytes.
+func NewMasterKey(seed []byte) (*ExtendedPrivateKey, error) {
+	if len(seed) < 16 || len(seed) > 64 {
+		return nil, ErrInvalidSeed
+	}
+	var t big.Int
+	var chainCode [ChainCodeSize]byte
+	tweak(masterKey, seed, &t, &chainCode)
+	if t.Sign() == 0 {
+		return nil, ErrInvalidSeed
+	}
+	return newExtendedPrivateKey(&t, chainCode, 0, [4]byte{}, 0), nil
+}
+
+func newExtendedPrivateKey(s *big.Int, chainCode [ChainCodeSize]byte, depth uint8, fingerprint [4]byte, index uint32) *ExtendedPrivateKey {
+	c := bandersnatch.GetEdwardsCurve()
+	var priv ExtendedPrivateKey
+	priv.scalar.Set(s)
+	priv.PublicKey.A.ScalarMultiplicationCT(&c.Base, &priv.scalar)
+	priv.PublicKey.ChainCode = chainCode
+	priv.PublicKey.Depth = depth
+	priv.PublicKey.ParentFingerprint = fingerprint
+	priv.PublicKey.Index = index
+	return &priv
+}
+
+// Scalar returns a copy of the secret scalar, in [1, order)
+func (priv *ExtendedPrivateKey) Scalar() *big.Int {
+	return new(big.Int).Set(&priv.scalar)
+}
+
+// Public returns the extended public key, from which the normal children can be derived
+func (priv *ExtendedPrivateKey) Public() *ExtendedPublicKey {
+	res := priv.PublicKey
+	return &res
+}
+
+// Child derives the child of index i. Indices ≥ HardenedOffset give hardened children.
+//
+// ErrInvalidChild is returned, with a negligible probability, if the child key is 0; the
+// caller should then use the next index.
+func (priv *ExtendedPrivateKey) Child(i uint32) (*ExtendedPrivateKey, error) {
+	if priv.PublicKey.Depth == 0xff {
+		return nil, ErrMaxDepth
+	}
+	if priv.scalar.Sign() == 0 {
+		return nil, ErrInvalidKeyState
+	}
+
+	// hardened: 0x00 ‖ k ‖ i, normal: A ‖ i
+	var data []byte
+	if i >= HardenedOffset {
+		data = make([]byte, 1+scalarSize(), 1+scalarSize()+4)
+		priv.scalar.FillBytes(data[1:])
+	} else {
+		data = priv.PublicKey.bytesA()
+	}
+	data = appendIndex(data, i)
+
+	var t big.Int
+	var chainCode [ChainCodeSize]byte
+	tweak(priv.PublicKey.ChainCode[:], data, &t, &chainCode)
+
+	// k' = k + t mod r
+	c := bandersnatch.GetEdwardsCurve()
+	t.Add(&t, &priv.scalar).Mod(&t, &c.Order)
+	if t.Sign() == 0 {
+		return nil, ErrInvalidChild
+	}
+	return newExtendedPrivateKey(&t, chainCode, priv.PublicKey.Depth+1, priv.PublicKey.Fingerprint(), i), nil
+}
+
+// Child derives the public key of the normal child of index i < HardenedOffset, which is
+// the public key of the child of the associated private key.
+//
+// ErrInvalidChild is returned, with a negligible probability, if the child key is the
+// identity; the caller should then use the next index.
+func (pub *ExtendedPublicKey) Child(i uint32) (*ExtendedPublicKey, error) {
+	if i >= HardenedOffset {
+		return nil, ErrHardenedPublic
+	}
+	if pub.Depth == 0xff {
+		return nil, ErrMaxDepth
+	}
+	if pub.A.IsZero() || !pub.A.IsInSubGroup() {
+		return nil, ErrInvalidKeyState
+	}
+
+	data := appendIndex(pub.bytesA(), i)
+	var t big.Int
+	var chainCode [ChainCodeSize]byte
+	tweak(pub.ChainCode[:], data, &t, &chainCode)
+
+	// A' = A + [t]G, in constant time as t is derived from the chain code
+	c := bandersnatch.GetEdwardsCurve()
+	res := ExtendedPublicKey{
+		ChainCode:         chainCode,
+		Depth:             pub.Depth + 1,
+		ParentFingerprint: pub.Fingerprint(),
+		Index:             i,
+	}
+	res.A.ScalarMultiplicationCT(&c.Base, &t)
+	res.A.Add(&res.A, &pub.A)
+	if res.A.IsZero() {
+		return nil, ErrInvalidChild
+	}
+	return &res, nil
+}
+
+// Derive derives the descendant at the given path, relative to priv (see ParsePath)
+func (priv *ExtendedPrivateKey) Derive(path []uint32) (*ExtendedPrivateKey, error) {
+	res := priv
+	var err error
+	for _, i := range path {
+		if res, err = res.Child(i); err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+// Derive derives the public key of the descendant at the given path, relative to pub,
+// which must not contain hardened indices (see ParsePath)
+func (pub *ExtendedPublicKey) Derive(path []uint32) (*ExtendedPublicKey, error) {
+	res := pub
+	var err error
+	for _, i := range path {
+		if res, err = res.Child(i); err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+// Fingerprint returns the first 4 bytes of the SHA-256 digest of the compressed public key,
+// which identifies the parent of the children of pub
+func (pub *ExtendedPublicKey) Fingerprint() [4]byte {
+	h := sha256.Sum256(pub.bytesA())
+	var res [4]byte
+	copy(res[:], h[:4])
+	return res
+}
+
+// ParsePath parses a derivation path of the form "m/44'/0'/1/2", where ' (or h)
+// marks a hardened index. The leading "m" is optional.
+func ParsePath(path string) ([]uint32, error) {
+	path = strings.TrimPrefix(strings.TrimPrefix(path, "m"), "/")
+	if path == "" {
+		return nil, nil
+	}
+	parts := strings.Split(path, "/")
+	res := make([]uint32, len(parts))
+	for j, p := range parts {
+		var offset uint32
+		if strings.HasSuffix(p, "'") || strings.HasSuffix(p, "h") {
+			offset = HardenedOffset
+			p = p[:len(p)-1]
+		}
+		i, err := strconv.ParseUint(p, 10, 31)
+		if err != nil {
+			return nil, ErrInvalidPath
+		}
+		res[j] = uint32(i) + offset
+	}
+	return res, nil
+}
+
+func (pub *ExtendedPublicKey) bytesA() []byte {
+	b := pub.A.Bytes()
+	return b[:]
+}
+
+// tweak sets t to HMAC-SHA512(key, 0x00 ‖ data) reduced modulo the
+// order, and chainCode to the first 32 bytes of HMAC-SHA512(key, 0x01 ‖ data)
+func tweak(key, data []byte, t *big.Int, chainCode *[ChainCodeSize]byte) {
+	c := bandersnatch.GetEdwardsCurve()
+	mac := hmac.New(sha512.New, key)
+	mac.Write([]byte{0x00})
+	mac.Write(data)
+	t.SetBytes(mac.Sum(nil)).Mod(t, &c.Order)
+
+	mac.Reset()
+	mac.Write([]byte{0x01})
+	mac.Write(data)
+	copy(chainCode[:], mac.Sum(nil))
+}
+
+func scalarSize() int {
+	c := bandersnatch.GetEdwardsCurve()
+	return (c.Order.BitLen() + 7) / 8
+}
+
+// appendIndex appends the big endian encoding of i to buf
+func appendIndex(buf []byte, i uint32) []byte {
+	var b [4]byte
+	binary.BigEndian.PutUint32(b[:], i)
+	return append(buf, b[:]...)
+}
@@ -0,0 +1,189 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package bandersnatch
+
+import (
+	"math/big"
+	"sync"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+)
+
+// MontgomeryParams parameters of the Montgomery curve B⋅v² = u³ + A⋅u² + u, birationally
+// equivalent to the twisted Edwards curve: A = 2(a+d)/(a-d) and B = 4/(a-d)
+type MontgomeryParams struct {
+	A, B fr.Element
+	A24  fr.Element // (A-2)/4, used by the ladder (RFC 7748)
+}
+
+// PointMontgomery point in affine coordinates on the Montgomery curve
+type PointMontgomery struct {
+	U, V fr.Element
+}
+
+var (
+	initMontgomeryOnce sync.Once
+	montgomeryParams   MontgomeryParams
+)
+
+func initMontgomeryParams() {
+	c := GetEdwardsCurve()
+	var aMinusD, four fr.Element
+	aMinusD.Sub(&c.A, &c.D).Inverse(&aMinusD)
+	four.SetUint64(4)
+
+	montgomeryParams.A.Add(&c.A, &c.D).Double(&montgomeryParams.A).Mul(&montgomeryParams.A, &aMinusD)
+	montgomeryParams.B.Mul(&four, &aMinusD)
+
+	var two fr.Element
+	two.SetUint64(2)
+	montgomeryParams.A24.Sub(&montgomeryParams.A, &two).Div(&montgomeryParams.A24, &four)
+}
+
+// GetMontgomeryCurve returns the Montgomery form of the twisted Edwards curve
+func GetMontgomeryCurve() MontgomeryParams {
+	initMontgomeryOnce.Do(initMontgomeryParams)
+	return montgomeryParams
+}
+
+// IsOnCurve checks if a point is on the Montgomery curve
+func (p *PointMontgomery) IsOnCurve() bool {
+	m := GetMontgomeryCurve()
+	var lhs, rhs fr.Element
+	lhs.Square(&p.V).Mul(&lhs, &m.B)
+	rhs.Add(&p.U, &m.A).Mul(&rhs, &p.U).Add(&rhs, new(fr.Element).SetOne()).Mul(&rhs, &p.U)
+	return lhs.Equal(&rhs)
+}
+
+// FromEdwards sets p to the image of q by the map (x, y) -> ((1+y)/(1-y), (1+y)/((1-y)x))
+// and returns it. (0, -1) maps to (0, 0). The identity maps to the point at infinity, which
+// has no affine coordinates: q must not be the identity.
+func (p *PointMontgomery) FromEdwards(q *PointAffine) *PointMontgomery {
+	var one, num, den fr.Element
+	one.SetOne()
+	num.Add(&one, &q.Y)
+	den.Sub(&one, &q.Y)
+	if q.X.IsZero() {
+		// (0, -1)
+		p.U.SetZero()
+		p.V.SetZero()
+		return p
+	}
+
+	// u = num/den, v = num/(den⋅x), with a single inversion
+	var inv fr.Element
+	inv.Mul(&den, &q.X).Inverse(&inv)
+	p.V.Mul(&num, &inv)
+	p.U.Mul(&p.V, &q.X)
+	return p
+}
+
+// FromMontgomery sets p to the image of q by the map (u, v) -> (u/v, (u-1)/(u+1)), the
+// inverse of PointMontgomery.FromEdwards, and returns it
+func (p *PointAffine) FromMontgomery(q *PointMontgomery) *PointAffine {
+	if q.U.IsZero() {
+		// (0, 0)
+		p.X.SetZero()
+		p.Y.SetOne().Neg(&p.Y)
+		return p
+	}
+	var one, num, den, inv fr.Element
+	one.SetOne()
+	num.Sub(&q.U, &one)
+	den.Add(&q.U, &one)
+
+	// x = u/v, y = num/den, with a single inversion
+	inv.Mul(&q.V, &den).Inverse(&inv)
+	p.X.Mul(&q.U, &den).Mul(&p.X, &inv)
+	p.Y.Mul(&num, &q.V).Mul(&p.Y, &inv)
+	return p
+}
+
+// MontgomeryU returns the u-coordinate (1+y)/(1-y) of the image of p on the Montgomery curve.
+// As in RFC 7748, 1/0 is 0, so the identity maps to 0.
+func (p *PointAffine) MontgomeryU() fr.Element {
+	var one, num, den fr.Element
+	one.SetOne()
+	num.Add(&one, &p.Y)
+	den.Sub(&one, &p.Y).Inverse(&den)
+	return *num.Mul(&num, &den)
+}
+
+// ScalarMultiplicationU returns the u-coordinate of [s]P, where u is the u-coordinate of P
+// on the Montgomery curve, with the x-only Montgomery ladder of RFC 7748.
+//
+// isInfinity is true if [s]P is the point at infinity, in which case u is 0. The ladder runs
+// on max(bitlen(s), bitlen(order)) bits with conditional swaps, so its sequence of operations
+// only depends on the size of s. s must be non-negative.
+func ScalarMultiplicationU(u *fr.Element, s *big.Int) (res fr.Element, isInfinity bool) {
+	m := GetMontgomeryCurve()
+	c := GetEdwardsCurve()
+
+	var x1, x2, z2, x3, z3 fr.Element
+	x1.Set(u)
+	x2.SetOne()
+	x3.Set(u)
+	z3.SetOne()
+
+	nbBits := c.Order.BitLen()
+	if s.BitLen() > nbBits {
+		nbBits = s.BitLen()
+	}
+
+	var a, aa, b, bb, e, cc, d, da, cb, t fr.Element
+	swap := uint(0)
+	for i := nbBits - 1; i >= 0; i-- {
+		bit := s.Bit(i)
+		swap ^= bit
+		cswap(&x2, &x3, swap)
+		cswap(&z2, &z3, swap)
+		swap = bit
+
+		a.Add(&x2, &z2)
+		aa.Square(&a)
+		b.Sub(&x2, &z2)
+		bb.Square(&b)
+		e.Sub(&aa, &bb)
+		cc.Add(&x3, &z3)
+		d.Sub(&x3, &z3)
+		da.Mul(&d, &a)
+		cb.Mul(&cc, &b)
+
+		x3.Add(&da, &cb).Square(&x3)
+		z3.Sub(&da, &cb).Square(&z3).Mul(&z3, &x1)
+		x2.Mul(&aa, &bb)
+		t.Mul(&m.A24, &e).Add(&t, &aa)
+		z2.Mul(&e, &t)
+	}
+	cswap(&x2, &x3, swap)
+	cswap(&z2, &z3, swap)
+
+	if z2.IsZero() {
+		return res, true
+	}
+	invCT(&res, &z2)
+	res.Mul(&res, &x2)
+	return res, false
+}
+
+// cswap swaps a and b if c == 1, in constant time
+func cswap(a, b *fr.Element, c uint) {
+	var t fr.Element
+	t.Select(int(c), a, b)
+	b.Select(int(c), b, a)
+	a.Set(&t)
+}
@@ -0,0 +1,106 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package bandersnatch
+
+import (
+	"crypto/rand"
+	"math/big"
+	"testing"
+)
+
+func TestMontgomeryMaps(t *testing.T) {
+	t.Parallel()
+	params := GetEdwardsCurve()
+
+	for i := 0; i < 10; i++ {
+		s, err := rand.Int(rand.Reader, &params.Order)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var p, q PointAffine
+		p.ScalarMultiplication(&params.Base, s)
+
+		var m PointMontgomery
+		m.FromEdwards(&p)
+		if !m.IsOnCurve() {
+			t.Fatal("the image of an Edwards point should be on the Montgomery curve")
+		}
+		if u := p.MontgomeryU(); !u.Equal(&m.U) {
+			t.Fatal("MontgomeryU should be the u-coordinate of the image")
+		}
+		if !q.FromMontgomery(&m).Equal(&p) {
+			t.Fatal("FromMontgomery should invert FromEdwards")
+		}
+	}
+
+	// (0, -1) <-> (0, 0)
+	var torsion, q PointAffine
+	torsion.Y.SetOne().Neg(&torsion.Y)
+	var m PointMontgomery
+	m.FromEdwards(&torsion)
+	if !m.U.IsZero() || !m.V.IsZero() || !m.IsOnCurve() {
+		t.Fatal("(0, -1) should map to (0, 0)")
+	}
+	if !q.FromMontgomery(&m).Equal(&torsion) {
+		t.Fatal("(0, 0) should map to (0, -1)")
+	}
+}
+
+func TestScalarMultiplicationU(t *testing.T) {
+	t.Parallel()
+	params := GetEdwardsCurve()
+	u := params.Base.MontgomeryU()
+
+	for _, s := range []*big.Int{big.NewInt(1), big.NewInt(2), big.NewInt(3), big.NewInt(1 << 40)} {
+		var p PointAffine
+		p.ScalarMultiplication(&params.Base, s)
+		expected := p.MontgomeryU()
+		res, isInfinity := ScalarMultiplicationU(&u, s)
+		if isInfinity || !res.Equal(&expected) {
+			t.Fatalf("unexpected u([%s]P)", s.String())
+		}
+	}
+	for i := 0; i < 5; i++ {
+		s, err := rand.Int(rand.Reader, &params.Order)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var p PointAffine
+		p.ScalarMultiplication(&params.Base, s)
+		expected := p.MontgomeryU()
+		if res, _ := ScalarMultiplicationU(&u, s); !res.Equal(&expected) {
+			t.Fatal("the ladder should match the scalar multiplication")
+		}
+	}
+
+	if _, isInfinity := ScalarMultiplicationU(&u, &params.Order); !isInfinity {
+		t.Fatal("[r]P should be the point at infinity")
+	}
+	if _, isInfinity := ScalarMultiplicationU(&u, big.NewInt(0)); !isInfinity {
+		t.Fatal("[0]P should be the point at infinity")
+	}
+}
+
+func BenchmarkScalarMultiplicationU(b *testing.B) {
+	params := GetEdwardsCurve()
+	u := params.Base.MontgomeryU()
+	s, _ := rand.Int(rand.Reader, &params.Order)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		ScalarMultiplicationU(&u, s)
+	}
+}
@@ -62,7 +62,7 @@ func (p *PointAffine) Bytes() [sizePointCompressed]byte {
 
 	y := p.Y.Bytes()
 
-	if p.X.LexicographicallyLargest() {
+	if p.X.IsLexLargerThanHalf() {
 		mask = mCompressedNegative
 	} else {
 		mask = mCompressedPositive
@@ -116,11 +116,11 @@ func (p *PointAffine) SetBytes(buf []byte) (int, error) {
 	p.Y.SetBytes(bufCopy)
 	p.X = computeX(&p.Y)
 	if isLexicographicallyLargest {
-		if !p.X.LexicographicallyLargest() {
+		if !p.X.IsLexLargerThanHalf() {
 			p.X.Neg(&p.X)
 		}
 	} else {
-		if p.X.LexicographicallyLargest() {
+		if p.X.IsLexLargerThanHalf() {
 			p.X.Neg(&p.X)
 		}
 	}
@@ -267,6 +267,22 @@ func (p *PointAffine) ScalarMultiplication(p1 *PointAffine, scalar *big.Int) *Po
 	return p
 }
 
+// ScalarMultiplicationCT scalar multiplication of a point p1 in affine coordinates with a
+// scalar in big.Int, in constant time with respect to the scalar.
+// See PointProj.ScalarMultiplicationCT.
+func (p *PointAffine) ScalarMultiplicationCT(p1 *PointAffine, scalar *big.Int) *PointAffine {
+	var _p PointProj
+	_p.FromAffine(p1)
+	_p.ScalarMultiplicationCT(&_p, scalar)
+
+	// Z ≠ 0 as the formulas are complete
+	var zInv fr.Element
+	invCT(&zInv, &_p.Z)
+	p.X.Mul(&_p.X, &zInv)
+	p.Y.Mul(&_p.Y, &zInv)
+	return p
+}
+
 // setInfinity sets p to O (0:1)
 func (p *PointAffine) setInfinity() *PointAffine {
 	p.X.SetZero()
@@ -414,6 +430,53 @@ func (p *PointProj) ScalarMultiplication(p1 *PointProj, scalar *big.Int) *PointP
 	return p.scalarMulGLV(p1, scalar)
 }
 
+// ScalarMultiplicationCT scalar multiplication of a point p1 in projective coordinates with a
+// scalar in big.Int, in constant time with respect to the scalar.
+//
+// It uses a Montgomery ladder with conditional swaps over max(bitlen(scalar), bitlen(order))
+// bits, and the addition and doubling formulas are complete, so that the sequence of field
+// operations only depends on the size of the scalar. It is slower than ScalarMultiplication,
+// and meant for secret scalars (e.g. private keys). The sign of the scalar is not hidden.
+func (p *PointProj) ScalarMultiplicationCT(p1 *PointProj, scalar *big.Int) *PointProj {
+	var _scalar big.Int
+	var r0, r1 PointProj
+	r0.setInfinity()
+	r1.Set(p1)
+	_scalar.Set(scalar)
+	if _scalar.Sign() == -1 {
+		_scalar.Neg(&_scalar)
+		r1.Neg(&r1)
+	}
+
+	c := GetEdwardsCurve()
+	nbBits := c.Order.BitLen()
+	if _scalar.BitLen() > nbBits {
+		nbBits = _scalar.BitLen()
+	}
+
+	// R₁ - R₀ = p1 is invariant
+	swap := uint(0)
+	for i := nbBits - 1; i >= 0; i-- {
+		bit := _scalar.Bit(i)
+		swap ^= bit
+		r0.cswap(&r1, swap)
+		swap = bit
+		r1.Add(&r0, &r1)
+		r0.Double(&r0)
+	}
+	r0.cswap(&r1, swap)
+
+	p.Set(&r0)
+	return p
+}
+
+// cswap swaps p and q if c == 1, in constant time
+func (p *PointProj) cswap(q *PointProj, c uint) {
+	cswap(&p.X, &q.X, c)
+	cswap(&p.Y, &q.Y, c)
+	cswap(&p.Z, &q.Z, c)
+}
+
 // ------- Extended coordinates
 
 // Set sets p to p1 and return it
@@ -709,6 +709,26 @@ func TestOps(t *testing.T) {
 		genS1,
 	))
 
+	properties.Property("constant time scalar multiplication should match ScalarMultiplication", prop.ForAll(
+		func(s big.Int) bool {
+
+			params := GetEdwardsCurve()
+
+			var neg big.Int
+			neg.Neg(&s)
+			var p1, p2, p3, p4, zero PointAffine
+			zero.setInfinity()
+			p1.ScalarMultiplication(&params.Base, &s)
+			p2.ScalarMultiplicationCT(&params.Base, &s)
+			p3.ScalarMultiplicationCT(&params.Base, &neg)
+			p4.ScalarMultiplicationCT(&params.Base, &params.Order)
+			p3.Neg(&p3)
+
+			return p1.Equal(&p2) && p1.Equal(&p3) && p4.Equal(&zero)
+		},
+		genS1,
+	))
+
 	properties.TestingRun(t, gopter.ConsoleReporter(false))
 
 }
@@ -0,0 +1,148 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blssig
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"math/big"
+	"sort"
+
+	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/internal/parallel"
+)
+
+// BatchVerify verifies the signatures sigs[i] of msgs[i] for the public keys pubs[i], and
+// returns the indices of the invalid ones in increasing order, or none if all the signatures
+// are valid. Invalid points (public keys at infinity or not in the subgroup, signatures not
+// in the subgroup) are reported as invalid signatures. The signatures are independent, so in
+// any scheme the messages need not be distinct.
+//
+// The signatures are checked together with a random linear combination: with random non-zero 64-bit rᵢ,
+//
+//	e(-g₁, ∑ᵢ rᵢ⋅sigs[i]) ⋅ ∏ᵢ e(rᵢ⋅pubs[i], H(msgs[i])) = 1
+//
+// which costs n+1 Miller loops and 1 final exponentiation, instead of n pairing checks. When
+// a batch fails, it is split in two halves which are checked in turn, so that k invalid
+// signatures cost O(k⋅log(n)) batch checks. An invalid signature is accepted with probability
+// at most 1/(2⁶⁴-1).
+func BatchVerify(pubs []bls12381.G1Affine, msgs [][]byte, sigs []bls12381.G2Affine, dst []byte) ([]int, error) {
+	n := len(sigs)
+	if len(pubs) != n || len(msgs) != n {
+		return nil, ErrSizeMismatch
+	}
+	if n == 0 {
+		return nil, nil
+	}
+
+	s, err := schemeOf(dst)
+	if err != nil {
+		return nil, err
+	}
+	hashes, err := hashMessages(augmentAll(s, pubs, msgs), dst)
+	if err != nil {
+		return nil, err
+	}
+
+	// random coefficients
+	var buf [8]byte
+	coeffs := make([]big.Int, n)
+	for i := range coeffs {
+		// rᵢ is drawn uniformly among the non-zero 64-bit integers
+		var r uint64
+		for r == 0 {
+			if _, err := rand.Read(buf[:]); err != nil {
+				return nil, err
+			}
+			r = binary.LittleEndian.Uint64(buf[:])
+		}
+		coeffs[i].SetUint64(r)
+	}
+
+	// rᵢ⋅pubs[i] and rᵢ⋅sigs[i], with the invalid points set aside
+	invalid := make([]bool, n)
+	rPubs := make([]bls12381.G1Affine, n)
+	rSigs := make([]bls12381.G2Jac, n)
+	parallel.Execute(n, func(start, end int) {
+		for i := start; i < end; i++ {
+			if pubs[i].IsInfinity() || !pubs[i].IsInSubGroup() || !sigs[i].IsInSubGroup() {
+				invalid[i] = true
+				continue
+			}
+			rPubs[i].ScalarMultiplication(&pubs[i], &coeffs[i])
+			rSigs[i].FromAffine(&sigs[i])
+			rSigs[i].ScalarMultiplication(&rSigs[i], &coeffs[i])
+		}
+	})
+	valid := make([]int, 0, n)
+	var res []int
+	for i := range invalid {
+		if invalid[i] {
+			res = append(res, i)
+		} else {
+			valid = append(valid, i)
+		}
+	}
+
+	_, _, g1, _ := bls12381.Generators()
+	g1.Neg(&g1)
+
+	// check verifies the signatures of indices with a single multi-pairing
+	check := func(indices []int) (bool, error) {
+		var sum bls12381.G2Jac
+		P := make([]bls12381.G1Affine, 0, len(indices)+1)
+		Q := make([]bls12381.G2Affine, 0, len(indices)+1)
+		for _, i := range indices {
+			sum.AddAssign(&rSigs[i])
+			P = append(P, rPubs[i])
+			Q = append(Q, hashes[i])
+		}
+		var sumAff bls12381.G2Affine
+		sumAff.FromJacobian(&sum)
+		P = append(P, g1)
+		Q = append(Q, sumAff)
+		return bls12381.PairingCheck(P, Q)
+	}
+
+	// bisect the failing batches
+	var bisect func(indices []int) error
+	bisect = func(indices []int) error {
+		if len(indices) == 0 {
+			return nil
+		}
+		ok, err := check(indices)
+		if err != nil {
+			return err
+		}
+		if ok {
+			return nil
+		}
+		if len(indices) == 1 {
+			res = append(res, indices[0])
+			return nil
+		}
+		mid := len(indices) / 2
+		if err := bisect(indices[:mid]); err != nil {
+			return err
+		}
+		return bisect(indices[mid:])
+	}
+	if err := bisect(valid); err != nil {
+		return nil, err
+	}
+
+	sort.Ints(res)
+	return res, nil
+}
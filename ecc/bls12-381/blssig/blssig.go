@@ -0,0 +1,188 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package blssig implements BLS signatures on bls12-381, in the minimal-pubkey-size variant
+// of the IETF specification (draft-irtf-cfrg-bls-signature) used by Ethereum: public keys
+// are in G₁ and signatures in G₂.
+//
+// A signature of msg with the secret key sk is sk⋅H(msg), where H hashes to G₂ with the
+// domain separation tag dst of the ciphersuite, and it is verified with the pairing check
+// e(g₁, σ) = e(pk, H(msg)).
+//
+// The ciphersuite tag selects one of the three schemes of the specification, which prevent
+// rogue key attacks on aggregate signatures in different ways (see DSTBasic, DSTAug and
+// DSTPop). Signatures aggregate into a single signature, verified by AggregateVerify (or
+// FastAggregateVerify when all the signers signed the same message) with a single
+// multi-pairing; independent signatures can be verified together with BatchVerify, which
+// finds the invalid ones.
+package blssig
+
+import (
+	"errors"
+	"math/big"
+	"sync"
+
+	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/consensys/gnark-crypto/internal/parallel"
+)
+
+var (
+	ErrInvalidPublicKey  = errors.New("invalid public key: infinity or not in the subgroup")
+	ErrInvalidSignature  = errors.New("invalid signature: not in the subgroup")
+	ErrSizeMismatch      = errors.New("the numbers of public keys, messages and signatures should be the same")
+	ErrNoMessage         = errors.New("no message to verify")
+	ErrDuplicateMessages = errors.New("the messages of an aggregate signature should be distinct")
+	ErrUnknownScheme     = errors.New("the ciphersuite tag should end with _NUL_, _AUG_ or _POP_")
+)
+
+// PublicKey returns the public key sk⋅g₁ of the secret key sk, computed in constant time
+func PublicKey(sk *fr.Element) bls12381.G1Affine {
+	_, _, g1, _ := bls12381.Generators()
+	var pk bls12381.G1Affine
+	var bsk big.Int
+	sk.ToBigIntRegular(&bsk)
+	pk.ScalarMultiplicationCT(&g1, &bsk)
+	return pk
+}
+
+// Sign returns the signature sk⋅H(msg) of msg, computed in constant time with respect to sk.
+// In the message augmentation scheme, the signed message is the public key followed by msg.
+func Sign(sk *fr.Element, msg, dst []byte) (bls12381.G2Affine, error) {
+	s, err := schemeOf(dst)
+	if err != nil {
+		return bls12381.G2Affine{}, err
+	}
+	if s == schemeAug {
+		pk := PublicKey(sk)
+		msg = augment(&pk, msg)
+	}
+	return coreSign(sk, msg, dst)
+}
+
+// coreSign returns sk⋅H(msg)
+func coreSign(sk *fr.Element, msg, dst []byte) (bls12381.G2Affine, error) {
+	h, err := bls12381.HashToG2(msg, dst)
+	if err != nil {
+		return h, err
+	}
+	var sig bls12381.G2Affine
+	var bsk big.Int
+	sk.ToBigIntRegular(&bsk)
+	sig.ScalarMultiplicationCT(&h, &bsk)
+	return sig, nil
+}
+
+// Verify returns true if sig is a valid signature of msg for the public key pk.
+// It returns an error if pk or sig are not valid points.
+func Verify(pk *bls12381.G1Affine, msg []byte, sig *bls12381.G2Affine, dst []byte) (bool, error) {
+	return AggregateVerify([]bls12381.G1Affine{*pk}, [][]byte{msg}, sig, dst)
+}
+
+// Aggregate returns the aggregate signature ∑ᵢ sigs[i]. It doesn't check the signatures,
+// which are verified with AggregateVerify.
+func Aggregate(sigs []bls12381.G2Affine) bls12381.G2Affine {
+	var acc bls12381.G2Jac
+	for i := range sigs {
+		acc.AddMixed(&sigs[i])
+	}
+	var res bls12381.G2Affine
+	res.FromJacobian(&acc)
+	return res
+}
+
+// AggregateVerify returns true if aggSig is the aggregate of the signatures of msgs[i] for
+// the public keys pubs[i], that is
+//
+//	e(g₁, aggSig) = ∏ᵢ e(pubs[i], H(msgs[i]))
+//
+// which is checked with a single multi-pairing: n+1 Miller loops and 1 final exponentiation.
+//
+// The rogue key attacks, where a public key cancels the contribution of another signer, are
+// prevented according to the scheme of dst: in the basic scheme the messages must be distinct,
+// in the message augmentation scheme each message is prefixed with its public key, and in the
+// proof of possession scheme the public keys must have been checked with PopVerify beforehand.
+func AggregateVerify(pubs []bls12381.G1Affine, msgs [][]byte, aggSig *bls12381.G2Affine, dst []byte) (bool, error) {
+	if len(pubs) != len(msgs) {
+		return false, ErrSizeMismatch
+	}
+	if len(msgs) == 0 {
+		return false, ErrNoMessage
+	}
+	s, err := schemeOf(dst)
+	if err != nil {
+		return false, err
+	}
+	if s == schemeBasic {
+		seen := make(map[string]struct{}, len(msgs))
+		for _, m := range msgs {
+			if _, ok := seen[string(m)]; ok {
+				return false, ErrDuplicateMessages
+			}
+			seen[string(m)] = struct{}{}
+		}
+	}
+	return coreAggregateVerify(pubs, augmentAll(s, pubs, msgs), aggSig, dst)
+}
+
+// coreAggregateVerify checks e(g₁, aggSig) = ∏ᵢ e(pubs[i], H(msgs[i])), whatever the scheme
+func coreAggregateVerify(pubs []bls12381.G1Affine, msgs [][]byte, aggSig *bls12381.G2Affine, dst []byte) (bool, error) {
+	if err := checkPublicKeys(pubs); err != nil {
+		return false, err
+	}
+	if !aggSig.IsInSubGroup() {
+		return false, ErrInvalidSignature
+	}
+
+	hashes, err := hashMessages(msgs, dst)
+	if err != nil {
+		return false, err
+	}
+
+	// e(-g₁, aggSig) ⋅ ∏ᵢ e(pubs[i], H(msgs[i])) = 1
+	_, _, g1, _ := bls12381.Generators()
+	P := make([]bls12381.G1Affine, 0, len(pubs)+1)
+	Q := make([]bls12381.G2Affine, 0, len(pubs)+1)
+	P = append(P, *g1.Neg(&g1))
+	Q = append(Q, *aggSig)
+	P = append(P, pubs...)
+	Q = append(Q, hashes...)
+	return bls12381.PairingCheck(P, Q)
+}
+
+func checkPublicKeys(pubs []bls12381.G1Affine) error {
+	for i := range pubs {
+		if pubs[i].IsInfinity() || !pubs[i].IsInSubGroup() {
+			return ErrInvalidPublicKey
+		}
+	}
+	return nil
+}
+
+// hashMessages hashes the messages to G₂, in parallel
+func hashMessages(msgs [][]byte, dst []byte) ([]bls12381.G2Affine, error) {
+	hashes := make([]bls12381.G2Affine, len(msgs))
+	var errOnce sync.Once
+	var err error
+	parallel.Execute(len(msgs), func(start, end int) {
+		for i := start; i < end; i++ {
+			var errI error
+			if hashes[i], errI = bls12381.HashToG2(msgs[i], dst); errI != nil {
+				errOnce.Do(func() { err = errI })
+				return
+			}
+		}
+	})
+	return hashes, err
+}
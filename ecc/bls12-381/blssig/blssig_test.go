@@ -0,0 +1,292 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blssig
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"testing"
+
+	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+)
+
+var dst = []byte(DSTBasic)
+
+func keys(t *testing.T, n int) ([]fr.Element, []bls12381.G1Affine) {
+	sks := make([]fr.Element, n)
+	pks := make([]bls12381.G1Affine, n)
+	for i := range sks {
+		if _, err := sks[i].SetRandom(); err != nil {
+			t.Fatal(err)
+		}
+		pks[i] = PublicKey(&sks[i])
+	}
+	return sks, pks
+}
+
+func messages(n int) [][]byte {
+	msgs := make([][]byte, n)
+	for i := range msgs {
+		msgs[i] = []byte(fmt.Sprintf("message %d", i))
+	}
+	return msgs
+}
+
+func TestTestVector(t *testing.T) {
+	t.Parallel()
+
+	// sign test vector of the Ethereum consensus specifications
+	skBytes, _ := hex.DecodeString("263dbd792f5b1be47ed85f8938c0f29586af0d3ac7b977f21c278fe1462040e3")
+	msg := bytes.Repeat([]byte{0x56}, 32)
+	expected, _ := hex.DecodeString("882730e5d03f6b42c3abc26d3372625034e1d871b65a8a6b900a56dae22da98abbe1b68f85e49fe7652a55ec3d0591c20767677e33e5cbb1207315c41a9ac03be39c2e7668edc043d6cb1d9fd93033caa8a1c5b0e84bedaeb6c64972503a43eb")
+
+	var sk fr.Element
+	sk.SetBytes(skBytes)
+	// Ethereum uses the proof of possession scheme
+	popDST := []byte(DSTPop)
+	sig, err := Sign(&sk, msg, popDST)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b := sig.Bytes(); !bytes.Equal(b[:], expected) {
+		t.Fatal("unexpected signature")
+	}
+	pk := PublicKey(&sk)
+	if ok, err := Verify(&pk, msg, &sig, popDST); err != nil || !ok {
+		t.Fatal("the signature should be valid")
+	}
+}
+
+func TestAggregateVerify(t *testing.T) {
+	t.Parallel()
+
+	const n = 5
+	sks, pks := keys(t, n)
+	msgs := messages(n)
+	sigs := make([]bls12381.G2Affine, n)
+	for i := range sigs {
+		var err error
+		if sigs[i], err = Sign(&sks[i], msgs[i], dst); err != nil {
+			t.Fatal(err)
+		}
+		if ok, err := Verify(&pks[i], msgs[i], &sigs[i], dst); err != nil || !ok {
+			t.Fatal("the signature should be valid")
+		}
+	}
+
+	aggSig := Aggregate(sigs)
+	if ok, err := AggregateVerify(pks, msgs, &aggSig, dst); err != nil || !ok {
+		t.Fatal("the aggregate signature should be valid")
+	}
+
+	// wrong message
+	msgs[1] = []byte("another message")
+	if ok, err := AggregateVerify(pks, msgs, &aggSig, dst); err != nil || ok {
+		t.Fatal("the aggregate signature should be invalid")
+	}
+
+	// duplicate messages
+	msgs[1] = msgs[0]
+	if _, err := AggregateVerify(pks, msgs, &aggSig, dst); err != ErrDuplicateMessages {
+		t.Fatal("duplicate messages should be rejected")
+	}
+
+	// invalid public key
+	msgs = messages(n)
+	pks[2] = bls12381.G1Affine{}
+	if _, err := AggregateVerify(pks, msgs, &aggSig, dst); err != ErrInvalidPublicKey {
+		t.Fatal("public keys at infinity should be rejected")
+	}
+
+	if _, err := AggregateVerify(pks[:2], msgs, &aggSig, dst); err != ErrSizeMismatch {
+		t.Fatal("sizes should be checked")
+	}
+}
+
+func TestSchemes(t *testing.T) {
+	t.Parallel()
+
+	const n = 4
+	sks, pks := keys(t, n)
+	msg := []byte("the same message")
+	msgs := [][]byte{msg, msg, msg, msg}
+
+	// the messages need not be distinct in the message augmentation and proof of possession schemes
+	for _, tag := range []string{DSTAug, DSTPop} {
+		sigs := make([]bls12381.G2Affine, n)
+		for i := range sigs {
+			var err error
+			if sigs[i], err = Sign(&sks[i], msg, []byte(tag)); err != nil {
+				t.Fatal(err)
+			}
+			if ok, err := Verify(&pks[i], msg, &sigs[i], []byte(tag)); err != nil || !ok {
+				t.Fatalf("%s: the signature should be valid", tag)
+			}
+		}
+		aggSig := Aggregate(sigs)
+		if ok, err := AggregateVerify(pks, msgs, &aggSig, []byte(tag)); err != nil || !ok {
+			t.Fatalf("%s: the aggregate signature should be valid", tag)
+		}
+		invalid, err := BatchVerify(pks, msgs, sigs, []byte(tag))
+		if err != nil || len(invalid) != 0 {
+			t.Fatalf("%s: all the signatures should be valid", tag)
+		}
+	}
+
+	// the public key is signed with the message
+	sigAug, _ := Sign(&sks[0], msg, []byte(DSTAug))
+	if ok, err := Verify(&pks[1], msg, &sigAug, []byte(DSTAug)); err != nil || ok {
+		t.Fatal("the signature should be bound to the public key")
+	}
+	sigCore, _ := coreSign(&sks[0], msg, []byte(DSTAug))
+	if ok, err := Verify(&pks[0], msg, &sigCore, []byte(DSTAug)); err != nil || ok {
+		t.Fatal("the message should be augmented with the public key")
+	}
+
+	if _, err := Sign(&sks[0], msg, []byte("BLS_SIG_BLS12381G2_XMD:SHA-256_SSWU_RO_")); err != ErrUnknownScheme {
+		t.Fatal("the scheme should be checked")
+	}
+	if _, err := AggregateVerify(pks, msgs, &sigAug, []byte("custom tag")); err != ErrUnknownScheme {
+		t.Fatal("the scheme should be checked")
+	}
+}
+
+func TestProofOfPossession(t *testing.T) {
+	t.Parallel()
+
+	const n = 5
+	sks, pks := keys(t, n)
+	for i := range sks {
+		proof, err := PopProve(&sks[i])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if ok, err := PopVerify(&pks[i], &proof); err != nil || !ok {
+			t.Fatal("the proof of possession should be valid")
+		}
+		if ok, err := PopVerify(&pks[(i+1)%n], &proof); err != nil || ok {
+			t.Fatal("the proof of possession should be bound to the public key")
+		}
+	}
+
+	// a signature of the public key with DSTPop is not a proof of possession
+	b := pks[0].Bytes()
+	sig, _ := Sign(&sks[0], b[:], []byte(DSTPop))
+	if ok, err := PopVerify(&pks[0], &sig); err != nil || ok {
+		t.Fatal("proofs of possession and signatures should be domain separated")
+	}
+}
+
+func TestFastAggregateVerify(t *testing.T) {
+	t.Parallel()
+
+	const n = 6
+	sks, pks := keys(t, n)
+	msg := []byte("attestation")
+	sigs := make([]bls12381.G2Affine, n)
+	for i := range sigs {
+		var err error
+		if sigs[i], err = Sign(&sks[i], msg, []byte(DSTPop)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	aggSig := Aggregate(sigs)
+	if ok, err := FastAggregateVerify(pks, msg, &aggSig); err != nil || !ok {
+		t.Fatal("the aggregate signature should be valid")
+	}
+	if ok, err := FastAggregateVerify(pks[1:], msg, &aggSig); err != nil || ok {
+		t.Fatal("the aggregate signature should be invalid for a subset of the signers")
+	}
+	if ok, err := FastAggregateVerify(pks, []byte("another message"), &aggSig); err != nil || ok {
+		t.Fatal("the aggregate signature should be invalid for another message")
+	}
+
+	// public keys which cancel out
+	var neg bls12381.G1Affine
+	neg.Neg(&pks[0])
+	var infinity bls12381.G2Affine
+	if ok, err := FastAggregateVerify([]bls12381.G1Affine{pks[0], neg}, msg, &infinity); err != nil || ok {
+		t.Fatal("an aggregate public key at infinity should be rejected")
+	}
+
+	if _, err := FastAggregateVerify(nil, msg, &aggSig); err != ErrNoMessage {
+		t.Fatal("the number of public keys should be checked")
+	}
+}
+
+func TestBatchVerify(t *testing.T) {
+	t.Parallel()
+
+	const n = 9
+	sks, pks := keys(t, n)
+	msgs := messages(n)
+	msgs[4] = msgs[3] // the messages need not be distinct
+	sigs := make([]bls12381.G2Affine, n)
+	for i := range sigs {
+		var err error
+		if sigs[i], err = Sign(&sks[i], msgs[i], dst); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	invalid, err := BatchVerify(pks, msgs, sigs, dst)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(invalid) != 0 {
+		t.Fatal("all the signatures should be valid")
+	}
+
+	// invalid signatures
+	sigs[2], sigs[7] = sigs[7], sigs[2]
+	pks[5] = bls12381.G1Affine{}
+	invalid, err = BatchVerify(pks, msgs, sigs, dst)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []int{2, 5, 7}
+	if len(invalid) != len(expected) {
+		t.Fatalf("expected invalid signatures %v, got %v", expected, invalid)
+	}
+	for i := range expected {
+		if invalid[i] != expected[i] {
+			t.Fatalf("expected invalid signatures %v, got %v", expected, invalid)
+		}
+	}
+
+	if _, err := BatchVerify(pks, msgs[1:], sigs, dst); err != ErrSizeMismatch {
+		t.Fatal("sizes should be checked")
+	}
+}
+
+func BenchmarkAggregateVerify(b *testing.B) {
+	const n = 64
+	sks := make([]fr.Element, n)
+	pks := make([]bls12381.G1Affine, n)
+	sigs := make([]bls12381.G2Affine, n)
+	msgs := messages(n)
+	for i := range sks {
+		sks[i].SetRandom()
+		pks[i] = PublicKey(&sks[i])
+		sigs[i], _ = Sign(&sks[i], msgs[i], dst)
+	}
+	aggSig := Aggregate(sigs)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = AggregateVerify(pks, msgs, &aggSig, dst)
+	}
+}
@@ -0,0 +1,124 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blssig
+
+import (
+	"bytes"
+
+	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+)
+
+// Ciphersuite tags of the minimal-pubkey-size variant, the last 5 bytes of which select the scheme
+const (
+	// DSTBasic is the tag of the basic scheme: the messages of an aggregate signature must be
+	// distinct.
+	DSTBasic = "BLS_SIG_BLS12381G2_XMD:SHA-256_SSWU_RO_NUL_"
+
+	// DSTAug is the tag of the message augmentation scheme: the signed message is the
+	// compressed public key followed by the message, so the messages need not be distinct.
+	DSTAug = "BLS_SIG_BLS12381G2_XMD:SHA-256_SSWU_RO_AUG_"
+
+	// DSTPop is the tag of the proof of possession scheme, used by Ethereum: the public keys of
+	// an aggregate signature must come with a proof of possession of their secret key, checked
+	// with PopVerify, so the messages need not be distinct.
+	DSTPop = "BLS_SIG_BLS12381G2_XMD:SHA-256_SSWU_RO_POP_"
+
+	// DSTPopProve is the tag of the hash of the public keys in the proofs of possession
+	DSTPopProve = "BLS_POP_BLS12381G2_XMD:SHA-256_SSWU_RO_POP_"
+)
+
+type scheme uint8
+
+const (
+	schemeBasic scheme = iota
+	schemeAug
+	schemePop
+)
+
+// schemeOf returns the scheme selected by the ciphersuite tag dst
+func schemeOf(dst []byte) (scheme, error) {
+	switch {
+	case bytes.HasSuffix(dst, []byte("_NUL_")):
+		return schemeBasic, nil
+	case bytes.HasSuffix(dst, []byte("_AUG_")):
+		return schemeAug, nil
+	case bytes.HasSuffix(dst, []byte("_POP_")):
+		return schemePop, nil
+	}
+	return 0, ErrUnknownScheme
+}
+
+// augment returns pk ‖ msg, with pk compressed
+func augment(pk *bls12381.G1Affine, msg []byte) []byte {
+	b := pk.Bytes()
+	res := make([]byte, 0, len(b)+len(msg))
+	res = append(res, b[:]...)
+	return append(res, msg...)
+}
+
+// augmentAll returns the signed messages: pubs[i] ‖ msgs[i] in the message augmentation
+// scheme, msgs otherwise
+func augmentAll(s scheme, pubs []bls12381.G1Affine, msgs [][]byte) [][]byte {
+	if s != schemeAug {
+		return msgs
+	}
+	res := make([][]byte, len(msgs))
+	for i := range msgs {
+		res[i] = augment(&pubs[i], msgs[i])
+	}
+	return res
+}
+
+// PopProve returns the proof of possession sk⋅H(pk) of the secret key sk, where H hashes the
+// compressed public key pk with DSTPopProve. It is computed in constant time with respect to sk.
+func PopProve(sk *fr.Element) (bls12381.G2Affine, error) {
+	pk := PublicKey(sk)
+	b := pk.Bytes()
+	return coreSign(sk, b[:], []byte(DSTPopProve))
+}
+
+// PopVerify returns true if proof is a valid proof of possession of the secret key of pk.
+// In the proof of possession scheme, the public keys must be checked with PopVerify before
+// being used in AggregateVerify or FastAggregateVerify.
+func PopVerify(pk *bls12381.G1Affine, proof *bls12381.G2Affine) (bool, error) {
+	b := pk.Bytes()
+	return coreAggregateVerify([]bls12381.G1Affine{*pk}, [][]byte{b[:]}, proof, []byte(DSTPopProve))
+}
+
+// FastAggregateVerify returns true if sig is the aggregate of the signatures of msg for the
+// public keys pubs, in the proof of possession scheme (with the tag DSTPop). The public keys
+// are aggregated, so the check costs 2 Miller loops whatever the number of signers.
+//
+// The public keys must have been checked with PopVerify: otherwise, a rogue public key could
+// cancel the contribution of another signer.
+func FastAggregateVerify(pubs []bls12381.G1Affine, msg []byte, sig *bls12381.G2Affine) (bool, error) {
+	if len(pubs) == 0 {
+		return false, ErrNoMessage
+	}
+	if err := checkPublicKeys(pubs); err != nil {
+		return false, err
+	}
+	var acc bls12381.G1Jac
+	for i := range pubs {
+		acc.AddMixed(&pubs[i])
+	}
+	var aggPk bls12381.G1Affine
+	aggPk.FromJacobian(&acc)
+	if aggPk.IsInfinity() {
+		return false, nil
+	}
+	return coreAggregateVerify([]bls12381.G1Affine{aggPk}, [][]byte{msg}, sig, []byte(DSTPop))
+}
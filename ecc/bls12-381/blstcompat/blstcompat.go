@@ -0,0 +1,163 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package blstcompat converts bls12-381 objects from and to the representations of blst
+// (https://github.com/supranational/blst), for applications migrating between the two
+// libraries or using both. It doesn't depend on blst.
+//
+// blst and gnark-crypto use the same encodings and the same internal representations:
+//   - points are serialized as specified by ZCash, with the G₂ conventions of G2Encoding{}:
+//     Compress / Uncompress match Bytes(), Serialize / Deserialize match RawBytes()
+//   - scalars (blst_scalar) are 32-byte integers, big-endian (Scalar.Serialize,
+//     blst_bendian_from_scalar) or little-endian (blst_lendian_from_scalar)
+//   - field elements (blst_fr, blst_fp) are in Montgomery form with R = 2^256 (resp. 2^384),
+//     as little-endian 64-bit limbs
+//
+// The functions of this package make these correspondences explicit, and, on the way in,
+// reject the non-canonical inputs: values larger than the modulus, points not on the curve
+// or not in the subgroup. Note that blst's Uncompress and Deserialize don't check that the
+// point is in the subgroup, so a point accepted by blst may be rejected here.
+package blstcompat
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fp"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+)
+
+// ScalarSize is the size of a blst_scalar in bytes
+const ScalarSize = fr.Bytes
+
+var (
+	ErrInvalidScalar = errors.New("invalid blst scalar: not a canonical element of fr")
+	ErrInvalidLimbs  = errors.New("invalid blst field element: Montgomery form not smaller than the modulus")
+)
+
+// ScalarFromBEndian returns the element of fr encoded in b, a big-endian blst_scalar
+// (the output of Scalar.Serialize or blst_bendian_from_scalar). b must be smaller than r.
+func ScalarFromBEndian(b []byte) (fr.Element, error) {
+	var s fr.Element
+	if err := s.SetBytesCanonical(b); err != nil {
+		return s, fmt.Errorf("%w: %v", ErrInvalidScalar, err)
+	}
+	return s, nil
+}
+
+// ScalarToBEndian returns the big-endian blst_scalar encoding of s, which is s.Bytes()
+func ScalarToBEndian(s *fr.Element) [ScalarSize]byte {
+	return s.Bytes()
+}
+
+// ScalarFromLEndian returns the element of fr encoded in b, a little-endian blst_scalar
+// (the output of blst_lendian_from_scalar). b must be smaller than r.
+func ScalarFromLEndian(b []byte) (fr.Element, error) {
+	if len(b) != ScalarSize {
+		var s fr.Element
+		return s, fmt.Errorf("%w: expected %d bytes, got %d", ErrInvalidScalar, ScalarSize, len(b))
+	}
+	var be [ScalarSize]byte
+	for i := range be {
+		be[i] = b[ScalarSize-1-i]
+	}
+	return ScalarFromBEndian(be[:])
+}
+
+// ScalarToLEndian returns the little-endian blst_scalar encoding of s
+func ScalarToLEndian(s *fr.Element) (res [ScalarSize]byte) {
+	be := s.Bytes()
+	for i := range res {
+		res[i] = be[ScalarSize-1-i]
+	}
+	return
+}
+
+// FrFromMont returns the element of fr whose Montgomery form is given by the limbs of a
+// blst_fr. The limbs must be smaller than r.
+func FrFromMont(limbs [fr.Limbs]uint64) (fr.Element, error) {
+	var b [fr.Bytes]byte
+	for i := 0; i < fr.Limbs; i++ {
+		binary.BigEndian.PutUint64(b[(fr.Limbs-1-i)*8:], limbs[i])
+	}
+	var check fr.Element
+	if err := check.SetBytesCanonical(b[:]); err != nil {
+		return check, ErrInvalidLimbs
+	}
+	return fr.UnsafeNewElementFromMontLimbs(limbs), nil
+}
+
+// FrToMont returns the limbs of the blst_fr corresponding to s, its Montgomery form
+func FrToMont(s *fr.Element) [fr.Limbs]uint64 {
+	return [fr.Limbs]uint64(*s)
+}
+
+// FpFromMont returns the element of fp whose Montgomery form is given by the limbs of a
+// blst_fp. The limbs must be smaller than p.
+func FpFromMont(limbs [fp.Limbs]uint64) (fp.Element, error) {
+	var b [fp.Bytes]byte
+	for i := 0; i < fp.Limbs; i++ {
+		binary.BigEndian.PutUint64(b[(fp.Limbs-1-i)*8:], limbs[i])
+	}
+	var check fp.Element
+	if err := check.SetBytesCanonical(b[:]); err != nil {
+		return check, ErrInvalidLimbs
+	}
+	return fp.UnsafeNewElementFromMontLimbs(limbs), nil
+}
+
+// FpToMont returns the limbs of the blst_fp corresponding to x, its Montgomery form
+func FpToMont(x *fp.Element) [fp.Limbs]uint64 {
+	return [fp.Limbs]uint64(*x)
+}
+
+// G1FromBlst returns the G₁ point encoded in b, the output of blst's P1Affine.Compress
+// (48 bytes) or P1Affine.Serialize (96 bytes). The encoding must be canonical and the point
+// in the subgroup (see G1Affine.SetBytesChecked).
+func G1FromBlst(b []byte) (bls12381.G1Affine, error) {
+	var p bls12381.G1Affine
+	err := p.SetBytesChecked(b)
+	return p, err
+}
+
+// G1ToBlstCompressed returns the encoding of p read by blst's P1Affine.Uncompress
+func G1ToBlstCompressed(p *bls12381.G1Affine) [bls12381.SizeOfG1AffineCompressed]byte {
+	return p.Bytes()
+}
+
+// G1ToBlstSerialized returns the encoding of p read by blst's P1Affine.Deserialize
+func G1ToBlstSerialized(p *bls12381.G1Affine) [bls12381.SizeOfG1AffineUncompressed]byte {
+	return p.RawBytes()
+}
+
+// G2FromBlst returns the G₂ point encoded in b, the output of blst's P2Affine.Compress
+// (96 bytes) or P2Affine.Serialize (192 bytes). The encoding must be canonical and the point
+// in the subgroup (see G2Affine.SetBytesChecked).
+func G2FromBlst(b []byte) (bls12381.G2Affine, error) {
+	var p bls12381.G2Affine
+	err := p.SetBytesChecked(b)
+	return p, err
+}
+
+// G2ToBlstCompressed returns the encoding of p read by blst's P2Affine.Uncompress
+func G2ToBlstCompressed(p *bls12381.G2Affine) [bls12381.SizeOfG2AffineCompressed]byte {
+	return p.BytesWithEncoding(bls12381.G2Encoding{Sign: bls12381.G2SignLexicographic, Order: bls12381.G2OrderA1A0})
+}
+
+// G2ToBlstSerialized returns the encoding of p read by blst's P2Affine.Deserialize
+func G2ToBlstSerialized(p *bls12381.G2Affine) [bls12381.SizeOfG2AffineUncompressed]byte {
+	return p.RawBytesWithEncoding(bls12381.G2Encoding{Sign: bls12381.G2SignLexicographic, Order: bls12381.G2OrderA1A0})
+}
@@ -0,0 +1,180 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blstcompat
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"math/big"
+	"testing"
+
+	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fp"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+)
+
+// the test vectors are outputs of blst
+
+func mustDecode(s string) []byte {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+func TestScalars(t *testing.T) {
+	t.Parallel()
+
+	// blst_bendian_from_scalar and blst_lendian_from_scalar of r - 1
+	be := mustDecode("73eda753299d7d483339d80809a1d80553bda402fffe5bfeffffffff00000000")
+	le := mustDecode("00000000fffffffffe5bfeff02a4bd5305d8a10908d83933487d9d2953a7ed73")
+
+	var minusOne fr.Element
+	minusOne.SetOne().Neg(&minusOne)
+
+	s, err := ScalarFromBEndian(be)
+	if err != nil || !s.Equal(&minusOne) {
+		t.Fatal("ScalarFromBEndian failed")
+	}
+	s, err = ScalarFromLEndian(le)
+	if err != nil || !s.Equal(&minusOne) {
+		t.Fatal("ScalarFromLEndian failed")
+	}
+	if b := ScalarToBEndian(&minusOne); !bytes.Equal(b[:], be) {
+		t.Fatal("ScalarToBEndian failed")
+	}
+	if b := ScalarToLEndian(&minusOne); !bytes.Equal(b[:], le) {
+		t.Fatal("ScalarToLEndian failed")
+	}
+
+	// r is not canonical
+	be[31]++
+	le[0]++
+	if _, err := ScalarFromBEndian(be); !errors.Is(err, ErrInvalidScalar) {
+		t.Fatal("ScalarFromBEndian should reject r")
+	}
+	if _, err := ScalarFromLEndian(le); !errors.Is(err, ErrInvalidScalar) {
+		t.Fatal("ScalarFromLEndian should reject r")
+	}
+	if _, err := ScalarFromLEndian(le[:31]); !errors.Is(err, ErrInvalidScalar) {
+		t.Fatal("ScalarFromLEndian should reject short inputs")
+	}
+}
+
+func TestMontgomeryLimbs(t *testing.T) {
+	t.Parallel()
+
+	// the blst_fr and blst_fp of 1 are R mod r and R mod p
+	frOne := [fr.Limbs]uint64{0x00000001fffffffe, 0x5884b7fa00034802, 0x998c4fefecbc4ff5, 0x1824b159acc5056f}
+	fpOne := [fp.Limbs]uint64{0x760900000002fffd, 0xebf4000bc40c0002, 0x5f48985753c758ba, 0x77ce585370525745, 0x5c071a97a256ec6d, 0x15f65ec3fa80e493}
+
+	var s fr.Element
+	s.SetOne()
+	if FrToMont(&s) != frOne {
+		t.Fatal("FrToMont(1) doesn't match blst")
+	}
+	s, err := FrFromMont(frOne)
+	if err != nil || !s.IsOne() {
+		t.Fatal("FrFromMont failed")
+	}
+
+	var x fp.Element
+	x.SetOne()
+	if FpToMont(&x) != fpOne {
+		t.Fatal("FpToMont(1) doesn't match blst")
+	}
+	x, err = FpFromMont(fpOne)
+	if err != nil || !x.IsOne() {
+		t.Fatal("FpFromMont failed")
+	}
+
+	// round trip
+	s.SetRandom()
+	if s2, err := FrFromMont(FrToMont(&s)); err != nil || !s2.Equal(&s) {
+		t.Fatal("fr round trip failed")
+	}
+	x.SetRandom()
+	if x2, err := FpFromMont(FpToMont(&x)); err != nil || !x2.Equal(&x) {
+		t.Fatal("fp round trip failed")
+	}
+
+	// limbs larger than the modulus
+	if _, err := FrFromMont([fr.Limbs]uint64{0, 0, 0, ^uint64(0)}); err != ErrInvalidLimbs {
+		t.Fatal("FrFromMont should reject non reduced limbs")
+	}
+	if _, err := FpFromMont([fp.Limbs]uint64{0, 0, 0, 0, 0, ^uint64(0)}); err != ErrInvalidLimbs {
+		t.Fatal("FpFromMont should reject non reduced limbs")
+	}
+}
+
+func TestPoints(t *testing.T) {
+	t.Parallel()
+
+	_, _, g1, g2 := bls12381.Generators()
+
+	// P1Affine.Compress and Serialize of the generator
+	g1Compressed := mustDecode("97f1d3a73197d7942695638c4fa9ac0fc3688c4f9774b905a14e3a3f171bac586c55e83ff97a1aeffb3af00adb22c6bb")
+	g1Serialized := mustDecode("17f1d3a73197d7942695638c4fa9ac0fc3688c4f9774b905a14e3a3f171bac586c55e83ff97a1aeffb3af00adb22c6bb08b3f481e3aaa0f1a09e30ed741d8ae4fcf5e095d5d00af600db18cb2c04b3edd03cc744a2888ae40caa232946c5e7e1")
+	// P2Affine.Compress of the generator
+	g2Compressed := mustDecode("93e02b6052719f607dacd3a088274f65596bd0d09920b61ab5da61bbdc7f5049334cf11213945d57e5ac7d055d042b7e024aa2b2f08f0a91260805272dc51051c6e47ad4fa403b02b4510b647ae3d1770bac0326a805bbefd48056c8c121bdb8")
+
+	if b := G1ToBlstCompressed(&g1); !bytes.Equal(b[:], g1Compressed) {
+		t.Fatal("G1ToBlstCompressed doesn't match blst")
+	}
+	if b := G1ToBlstSerialized(&g1); !bytes.Equal(b[:], g1Serialized) {
+		t.Fatal("G1ToBlstSerialized doesn't match blst")
+	}
+	if b := G2ToBlstCompressed(&g2); !bytes.Equal(b[:], g2Compressed) {
+		t.Fatal("G2ToBlstCompressed doesn't match blst")
+	}
+	for _, b := range [][]byte{g1Compressed, g1Serialized} {
+		if p, err := G1FromBlst(b); err != nil || !p.Equal(&g1) {
+			t.Fatal("G1FromBlst failed")
+		}
+	}
+	if p, err := G2FromBlst(g2Compressed); err != nil || !p.Equal(&g2) {
+		t.Fatal("G2FromBlst failed")
+	}
+
+	// round trips
+	var p1 bls12381.G1Affine
+	var p2 bls12381.G2Affine
+	k := big.NewInt(123456789)
+	p1.ScalarMultiplication(&g1, k)
+	p2.ScalarMultiplication(&g2, k)
+	b1 := G1ToBlstSerialized(&p1)
+	b2 := G2ToBlstSerialized(&p2)
+	if q, err := G1FromBlst(b1[:]); err != nil || !q.Equal(&p1) {
+		t.Fatal("G1 round trip failed")
+	}
+	if q, err := G2FromBlst(b2[:]); err != nil || !q.Equal(&p2) {
+		t.Fatal("G2 round trip failed")
+	}
+
+	// infinity, compressed by blst as 0xc0 followed by zeroes
+	infinity := make([]byte, bls12381.SizeOfG1AffineCompressed)
+	infinity[0] = 0xc0
+	if p, err := G1FromBlst(infinity); err != nil || !p.IsInfinity() {
+		t.Fatal("G1FromBlst failed on infinity")
+	}
+
+	// non canonical encodings are rejected
+	g1Compressed[0] |= 0x40
+	if _, err := G1FromBlst(g1Compressed); err == nil {
+		t.Fatal("G1FromBlst should reject the infinity flag with non-zero coordinates")
+	}
+}
@@ -0,0 +1,148 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package eip2333 derives bls12-381 secret keys deterministically from a seed, following
+// EIP-2333 (BLS12-381 key generation), as Ethereum validator clients and wallets do.
+//
+// The master key is derived from the seed with HKDF-SHA256, and the child keys from their
+// parent with a Lamport one-time signature key pair, so that a tree of keys can be recovered
+// from the seed alone. The keys are designated by their path in the tree, following the
+// conventions of EIP-2334 for validators: m/12381/3600/i/0 is the withdrawal key of the i-th
+// validator, and m/12381/3600/i/0/0 its signing key (see ParsePath and DeriveSKFromPath).
+//
+// The public keys are in G₁, as in the Ethereum consensus layer (minimal-pubkey-size).
+//
+// See https://eips.ethereum.org/EIPS/eip-2333 and https://eips.ethereum.org/EIPS/eip-2334
+package eip2333
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"io"
+	"math/big"
+
+	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"golang.org/x/crypto/hkdf"
+)
+
+// MinSeedSize is the minimum size of a seed, in bytes
+const MinSeedSize = 32
+
+var ErrSeedTooShort = errors.New("seed must be at least 32 bytes")
+
+const (
+	lamportChunks    = 255 // number of chunks of a Lamport secret key
+	lamportChunkSize = sha256.Size
+	hkdfModROKMSize  = 48 // ceil((3 * ceil(log2(r))) / 16)
+)
+
+// DeriveMasterSK returns the master secret key derived from seed, which must be at least
+// MinSeedSize bytes long (derive_master_SK).
+func DeriveMasterSK(seed []byte) (fr.Element, error) {
+	if len(seed) < MinSeedSize {
+		return fr.Element{}, ErrSeedTooShort
+	}
+	return hkdfModR(seed), nil
+}
+
+// DeriveChildSK returns the child of parentSK at index (derive_child_SK).
+func DeriveChildSK(parentSK *fr.Element, index uint32) fr.Element {
+	return hkdfModR(parentSKToLamportPK(parentSK, index))
+}
+
+// DerivePath returns the secret key at path in the tree of keys derived from seed, that is
+// the master key for an empty path, its child at path[0], the child of the latter at path[1]...
+func DerivePath(seed []byte, path []uint32) (fr.Element, error) {
+	sk, err := DeriveMasterSK(seed)
+	if err != nil {
+		return sk, err
+	}
+	for _, index := range path {
+		sk = DeriveChildSK(&sk, index)
+	}
+	return sk, nil
+}
+
+// GenerateKeyFromSeed returns the master secret key derived from seed, and the public key
+// sk⋅g₁ in G₁.
+//
+// The seed must be kept secret, as the secret key.
+func GenerateKeyFromSeed(seed [32]byte) (sk fr.Element, pk bls12381.G1Affine) {
+	sk = hkdfModR(seed[:])
+	var bsk big.Int
+	sk.ToBigIntRegular(&bsk)
+	pk.ScalarMultiplicationBase(&bsk)
+	return
+}
+
+// hkdfModR hashes ikm to a non-zero element of fr (HKDF_mod_r with an empty key_info)
+func hkdfModR(ikm []byte) fr.Element {
+	salt := []byte("BLS-SIG-KEYGEN-SALT-")
+
+	// IKM || I2OSP(0, 1)
+	ikm0 := make([]byte, len(ikm)+1)
+	copy(ikm0, ikm)
+
+	// key_info || I2OSP(L, 2)
+	var info [2]byte
+	binary.BigEndian.PutUint16(info[:], hkdfModROKMSize)
+
+	var sk fr.Element
+	var okm [hkdfModROKMSize]byte
+	for sk.IsZero() {
+		h := sha256.Sum256(salt)
+		salt = h[:]
+		r := hkdf.New(sha256.New, ikm0, salt, info[:])
+		if _, err := io.ReadFull(r, okm[:]); err != nil {
+			panic(err) // can't happen, the output is short enough
+		}
+		sk.SetBytesWide(okm[:])
+	}
+	return sk
+}
+
+// parentSKToLamportPK returns the compressed Lamport public key used to derive the child of
+// parentSK at index
+func parentSKToLamportPK(parentSK *fr.Element, index uint32) []byte {
+	var salt [4]byte
+	binary.BigEndian.PutUint32(salt[:], index)
+
+	ikm := parentSK.Bytes()
+	var notIKM [fr.Bytes]byte
+	for i := range ikm {
+		notIKM[i] = ^ikm[i]
+	}
+
+	h := sha256.New()
+	for _, k := range [][]byte{ikm[:], notIKM[:]} {
+		lamportSK := ikmToLamportSK(k, salt[:])
+		for i := 0; i < lamportChunks; i++ {
+			pk := sha256.Sum256(lamportSK[i*lamportChunkSize : (i+1)*lamportChunkSize])
+			h.Write(pk[:])
+		}
+	}
+	return h.Sum(nil)
+}
+
+// ikmToLamportSK returns the 255 chunks of a Lamport secret key, concatenated
+func ikmToLamportSK(ikm, salt []byte) []byte {
+	okm := make([]byte, lamportChunks*lamportChunkSize)
+	r := hkdf.New(sha256.New, ikm, salt, nil)
+	if _, err := io.ReadFull(r, okm); err != nil {
+		panic(err) // can't happen, the output is 255 hashes long at most
+	}
+	return okm
+}
@@ -0,0 +1,164 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eip2333
+
+import (
+	"encoding/hex"
+	"errors"
+	"math/big"
+	"testing"
+
+	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
+)
+
+// test vectors of EIP-2333
+var testVectors = []struct {
+	seed       string
+	masterSK   string
+	childIndex uint32
+	childSK    string
+}{
+	{
+		seed:       "c55257c360c07c72029aebc1b53c05ed0362ada38ead3e3e9efa3708e53495531f09a6987599d18264c1e1c92f2cf141630c7a3c4ab7c81b2f001698e7463b04",
+		masterSK:   "6083874454709270928345386274498605044986640685124978867557563392430687146096",
+		childIndex: 0,
+		childSK:    "20397789859736650942317412262472558107875392172444076792671091975210932703118",
+	},
+	{
+		seed:       "3141592653589793238462643383279502884197169399375105820974944592",
+		masterSK:   "29757020647961307431480504535336562678282505419141012933316116377660817309383",
+		childIndex: 3141592653,
+		childSK:    "25457201688850691947727629385191704516744796114925897962676248250929345014287",
+	},
+	{
+		seed:       "0099ff991111002299dd7744ee3355bbdd8844115566cc55663355668888cc00",
+		masterSK:   "27580842291869792442942448775674722299803720648445448686099262467207037398656",
+		childIndex: 4294967295,
+		childSK:    "29358610794459428860402234341874281240803786294062035874021252734817515685787",
+	},
+	{
+		seed:       "d4e56740f876aef8c010b86a40d5f56745a118d0906a34e69aec8c0db1cb8fa3",
+		masterSK:   "19022158461524446591288038168518313374041767046816487870552872741050760015818",
+		childIndex: 42,
+		childSK:    "31372231650479070279774297061823572166496564838472787488249775572789064611981",
+	},
+}
+
+func TestTestVectors(t *testing.T) {
+	t.Parallel()
+
+	for i, v := range testVectors {
+		seed, err := hex.DecodeString(v.seed)
+		if err != nil {
+			t.Fatal(err)
+		}
+		sk, err := DeriveMasterSK(seed)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if sk.String() != v.masterSK {
+			t.Fatalf("test vector %d: wrong master secret key", i)
+		}
+		child := DeriveChildSK(&sk, v.childIndex)
+		if child.String() != v.childSK {
+			t.Fatalf("test vector %d: wrong child secret key", i)
+		}
+		child, err = DerivePath(seed, []uint32{v.childIndex})
+		if err != nil || child.String() != v.childSK {
+			t.Fatalf("test vector %d: DerivePath failed", i)
+		}
+	}
+}
+
+func TestGenerateKeyFromSeed(t *testing.T) {
+	t.Parallel()
+
+	var seed [32]byte
+	b, _ := hex.DecodeString(testVectors[1].seed)
+	copy(seed[:], b)
+
+	sk, pk := GenerateKeyFromSeed(seed)
+	if sk.String() != testVectors[1].masterSK {
+		t.Fatal("GenerateKeyFromSeed should return the master secret key")
+	}
+
+	_, _, g1, _ := bls12381.Generators()
+	var expected bls12381.G1Affine
+	var bsk big.Int
+	sk.ToBigIntRegular(&bsk)
+	expected.ScalarMultiplication(&g1, &bsk)
+	if !pk.Equal(&expected) {
+		t.Fatal("the public key should be sk⋅g₁")
+	}
+
+	if _, err := DeriveMasterSK(seed[:31]); err != ErrSeedTooShort {
+		t.Fatal("seeds shorter than 32 bytes should be rejected")
+	}
+}
+
+func TestPath(t *testing.T) {
+	t.Parallel()
+
+	if p := SigningKeyPath(7); p != "m/12381/3600/7/0/0" {
+		t.Fatal("unexpected signing key path", p)
+	}
+	if p := WithdrawalKeyPath(7); p != "m/12381/3600/7/0" {
+		t.Fatal("unexpected withdrawal key path", p)
+	}
+
+	indices, err := ParsePath("m/12381/3600/4294967295/0/0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []uint32{12381, 3600, 4294967295, 0, 0}
+	if len(indices) != len(expected) {
+		t.Fatal("wrong number of indices")
+	}
+	for i := range expected {
+		if indices[i] != expected[i] {
+			t.Fatal("wrong index", i)
+		}
+	}
+	if indices, err := ParsePath("m"); err != nil || len(indices) != 0 {
+		t.Fatal("m is the path of the master key")
+	}
+
+	for _, p := range []string{"", "n/0", "m/", "m//0", "m/-1", "m/+1", "m/01", "m/4294967296", "m/0x10", "m/1'"} {
+		if _, err := ParsePath(p); !errors.Is(err, ErrInvalidPath) {
+			t.Fatalf("%q should be rejected", p)
+		}
+	}
+
+	// the signing key is the child of the withdrawal key
+	seed, _ := hex.DecodeString(testVectors[0].seed)
+	withdrawal, err := DeriveSKFromPath(seed, WithdrawalKeyPath(3))
+	if err != nil {
+		t.Fatal(err)
+	}
+	signing, err := DeriveSKFromPath(seed, SigningKeyPath(3))
+	if err != nil {
+		t.Fatal(err)
+	}
+	child := DeriveChildSK(&withdrawal, 0)
+	if !signing.Equal(&child) {
+		t.Fatal("the signing key should be the child of the withdrawal key")
+	}
+
+	// the master key
+	master, err := DeriveSKFromPath(seed, "m")
+	if err != nil || master.String() != testVectors[0].masterSK {
+		t.Fatal("m should derive the master key")
+	}
+}
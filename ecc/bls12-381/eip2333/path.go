@@ -0,0 +1,78 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eip2333
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+)
+
+// EIP-2334 paths are m / purpose / coin_type / account / use
+const (
+	// Purpose is the first index of the EIP-2334 paths
+	Purpose uint32 = 12381
+	// CoinTypeEth is the coin type of the Ethereum consensus layer
+	CoinTypeEth uint32 = 3600
+)
+
+var ErrInvalidPath = errors.New("invalid EIP-2334 path")
+
+// ParsePath parses a path of the tree of keys of EIP-2333, such as "m/12381/3600/0/0/0":
+// "m" (the master key) followed by decimal indices smaller than 2³², separated by "/".
+//
+// It doesn't check that the path follows the structure of EIP-2334, which is a convention
+// for the first indices only.
+func ParsePath(path string) ([]uint32, error) {
+	components := strings.Split(path, "/")
+	if components[0] != "m" {
+		return nil, fmt.Errorf("%w: %q doesn't start with m", ErrInvalidPath, path)
+	}
+	indices := make([]uint32, len(components)-1)
+	for i, c := range components[1:] {
+		// leading zeroes are rejected, so that a path has a single representation
+		index, err := strconv.ParseUint(c, 10, 32)
+		if err != nil || (len(c) > 1 && c[0] == '0') {
+			return nil, fmt.Errorf("%w: invalid index %q in %q", ErrInvalidPath, c, path)
+		}
+		indices[i] = uint32(index)
+	}
+	return indices, nil
+}
+
+// DeriveSKFromPath returns the secret key at path (see ParsePath) in the tree of keys derived
+// from seed.
+func DeriveSKFromPath(seed []byte, path string) (fr.Element, error) {
+	indices, err := ParsePath(path)
+	if err != nil {
+		return fr.Element{}, err
+	}
+	return DerivePath(seed, indices)
+}
+
+// WithdrawalKeyPath returns the EIP-2334 path of the withdrawal key of the validator at
+// index: m/12381/3600/index/0
+func WithdrawalKeyPath(index uint32) string {
+	return fmt.Sprintf("m/%d/%d/%d/0", Purpose, CoinTypeEth, index)
+}
+
+// SigningKeyPath returns the EIP-2334 path of the signing key of the validator at index,
+// which is a child of its withdrawal key: m/12381/3600/index/0/0
+func SigningKeyPath(index uint32) string {
+	return WithdrawalKeyPath(index) + "/0"
+}
@@ -0,0 +1,219 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package ecfft
+
+import (
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fp"
+)
+
+// Curve is the auxiliary elliptic curve y² = x³ + A2·x² + A4·x + A6 over fp
+type Curve struct {
+	A2, A4, A6 fp.Element
+}
+
+// Point is an affine point of a Curve
+type Point struct {
+	X, Y fp.Element
+}
+
+// Parameters define the domain of a Tree: the x-coordinates of the points of the coset
+// Offset + ⟨Generator⟩ of Curve, where Generator has order 2^LogSize.
+//
+// The x-coordinates must be distinct, that is 2·Offset ∉ ⟨Generator⟩.
+type Parameters struct {
+	Curve     Curve
+	Generator Point
+	Offset    Point
+	LogSize   int
+}
+
+// IsOnCurve returns true if p is on the curve
+func (c *Curve) IsOnCurve(p *Point) bool {
+	var left, right fp.Element
+	left.Square(&p.Y)
+	right = c.eval(&p.X)
+	return left.Equal(&right)
+}
+
+// eval returns x³ + A2·x² + A4·x + A6
+func (c *Curve) eval(x *fp.Element) fp.Element {
+	var res fp.Element
+	res.Add(x, &c.A2).
+		Mul(&res, x).
+		Add(&res, &c.A4).
+		Mul(&res, x).
+		Add(&res, &c.A6)
+	return res
+}
+
+// add returns p + q, given inv = 1/(q.X - p.X); p ≠ ±q
+func (c *Curve) add(p, q *Point, inv *fp.Element) Point {
+	var res Point
+	var lambda fp.Element
+	lambda.Sub(&q.Y, &p.Y).Mul(&lambda, inv)
+	res.X.Square(&lambda).
+		Sub(&res.X, &c.A2).
+		Sub(&res.X, &p.X).
+		Sub(&res.X, &q.X)
+	res.Y.Sub(&p.X, &res.X).
+		Mul(&res.Y, &lambda).
+		Sub(&res.Y, &p.Y)
+	return res
+}
+
+// double returns 2p; p.Y ≠ 0
+func (c *Curve) double(p *Point) Point {
+	var res Point
+	var lambda, den, tmp fp.Element
+	lambda.Square(&p.X)
+	tmp.Double(&lambda)
+	lambda.Add(&lambda, &tmp)
+	tmp.Mul(&c.A2, &p.X).Double(&tmp)
+	lambda.Add(&lambda, &tmp).Add(&lambda, &c.A4)
+	den.Double(&p.Y).Inverse(&den)
+	lambda.Mul(&lambda, &den)
+	res.X.Square(&lambda).
+		Sub(&res.X, &c.A2).
+		Sub(&res.X, &p.X).
+		Sub(&res.X, &p.X)
+	res.Y.Sub(&p.X, &res.X).
+		Mul(&res.Y, &lambda).
+		Sub(&res.Y, &p.Y)
+	return res
+}
+
+// SearchParameters returns random parameters for a domain of size 2^logSize.
+//
+// It draws curves y² = (x-e₁)(x-e₂)(x-e₃) until one has a rational point of order 2^logSize,
+// found by successive halvings of its points of order 2. The expected number of curves drawn
+// grows like 2^logSize: for large domains, the parameters should be searched once and hardcoded.
+func SearchParameters(logSize int) (Parameters, error) {
+	if logSize < 1 || logSize > maxLogSize {
+		return Parameters{}, ErrInvalidLogSize
+	}
+	for {
+		var e [3]fp.Element
+		for i := range e {
+			if _, err := e[i].SetRandom(); err != nil {
+				return Parameters{}, err
+			}
+		}
+		if e[0].Equal(&e[1]) || e[0].Equal(&e[2]) || e[1].Equal(&e[2]) {
+			continue
+		}
+
+		// (x-e₁)(x-e₂)(x-e₃) = x³ - (e₁+e₂+e₃)x² + (e₁e₂+e₁e₃+e₂e₃)x - e₁e₂e₃
+		var c Curve
+		var tmp fp.Element
+		c.A2.Add(&e[0], &e[1]).Add(&c.A2, &e[2]).Neg(&c.A2)
+		c.A4.Mul(&e[0], &e[1])
+		tmp.Add(&e[0], &e[1]).Mul(&tmp, &e[2])
+		c.A4.Add(&c.A4, &tmp)
+		c.A6.Mul(&e[0], &e[1]).Mul(&c.A6, &e[2]).Neg(&c.A6)
+
+		for i := range e {
+			g, ok := searchHalvings(&c, &e, Point{X: e[i]}, logSize-1)
+			if !ok {
+				continue
+			}
+			// the offset is a random point, 2·Offset ∈ ⟨Generator⟩ happens with negligible probability
+			for {
+				offset, err := randomPoint(&c)
+				if err != nil {
+					return Parameters{}, err
+				}
+				p := Parameters{Curve: c, Generator: g, Offset: offset, LogSize: logSize}
+				if _, err := NewTree(p); err == nil {
+					return p, nil
+				}
+			}
+		}
+	}
+}
+
+// searchHalvings returns a point q such that [2^depth]q = p, if there is one
+func searchHalvings(c *Curve, e *[3]fp.Element, p Point, depth int) (Point, bool) {
+	if depth == 0 {
+		return p, true
+	}
+	for _, q := range halves(c, e, &p) {
+		if res, ok := searchHalvings(c, e, q, depth-1); ok {
+			return res, true
+		}
+	}
+	return Point{}, false
+}
+
+// halves returns the rational points q such that 2q = p, p ≠ O, on the curve
+// y² = (x-e₁)(x-e₂)(x-e₃).
+//
+// p is in 2E(fp) iff the x(p)-eᵢ are squares, and then x(q) = x(p) + r₁r₂ + r₁r₃ + r₂r₃,
+// where rᵢ² = x(p)-eᵢ, for the four choices of signs of r₂ and r₃.
+func halves(c *Curve, e *[3]fp.Element, p *Point) []Point {
+	var r [3]fp.Element
+	for i := range e {
+		r[i].Sub(&p.X, &e[i])
+		if r[i].Legendre() == -1 {
+			return nil
+		}
+		r[i].Sqrt(&r[i])
+	}
+
+	res := make([]Point, 0, 4)
+	for s2 := 0; s2 < 2; s2++ {
+		for s3 := 0; s3 < 2; s3++ {
+			var q Point
+			var tmp fp.Element
+			q.X.Mul(&r[0], &r[1])
+			tmp.Add(&r[0], &r[1]).Mul(&tmp, &r[2])
+			q.X.Add(&q.X, &tmp).Add(&q.X, &p.X)
+			r[2].Neg(&r[2])
+
+			y2 := c.eval(&q.X)
+			if y2.Legendre() != 1 {
+				continue
+			}
+			q.Y.Sqrt(&y2)
+			d := c.double(&q)
+			if !d.X.Equal(&p.X) {
+				continue
+			}
+			if !d.Y.Equal(&p.Y) {
+				q.Y.Neg(&q.Y)
+			}
+			res = append(res, q)
+		}
+		r[1].Neg(&r[1])
+	}
+	return res
+}
+
+// randomPoint returns a random point of the curve
+func randomPoint(c *Curve) (Point, error) {
+	for {
+		var p Point
+		if _, err := p.X.SetRandom(); err != nil {
+			return p, err
+		}
+		y2 := c.eval(&p.X)
+		if y2.Legendre() != 1 {
+			continue
+		}
+		p.Y.Sqrt(&y2)
+		return p, nil
+	}
+}
@@ -0,0 +1,140 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package anemoi
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+)
+
+func TestParameters(t *testing.T) {
+	once.Do(initConstants)
+
+	// x -> x^{1/α} is the inverse of x -> x^α
+	var x, y fr.Element
+	x.SetRandom()
+	y.Exp(x, &alphaInv).Exp(y, big.NewInt(Alpha))
+	if !x.Equal(&y) {
+		t.Fatal("α⁻¹ is not the inverse of α")
+	}
+
+	cs, ds := GetConstants()
+	if len(cs) != NbRounds || len(ds) != NbRounds || cs[0].Cmp(&ds[0]) == 0 {
+		t.Fatal("unexpected round constants")
+	}
+}
+
+func TestSBox(t *testing.T) {
+	once.Do(initConstants)
+
+	// the closed Flystel relation holds between the input and the output:
+	// (y - v)^α = x - g⋅y², u = (y - v)^α + g⋅v² + g⁻¹
+	var x, y, u, v fr.Element
+	x.SetRandom()
+	y.SetRandom()
+	u, v = x, y
+	sBox(&u, &v)
+
+	var e, lhs, rhs fr.Element
+	e.Sub(&y, &v).Exp(e, big.NewInt(Alpha))
+	rhs.Square(&y).Mul(&rhs, &g)
+	lhs.Add(&e, &rhs)
+	if !lhs.Equal(&x) {
+		t.Fatal("(y - v)^α != x - g⋅y²")
+	}
+	rhs.Square(&v).Mul(&rhs, &g).Add(&rhs, &gInv).Add(&rhs, &e)
+	if !rhs.Equal(&u) {
+		t.Fatal("(y - v)^α + g⋅v² + g⁻¹ != u")
+	}
+}
+
+func TestJive(t *testing.T) {
+	var x, y fr.Element
+	x.SetRandom()
+	y.SetRandom()
+
+	a, b := Jive(x, y), Jive(x, y)
+	if !a.Equal(&b) {
+		t.Fatal("Jive is not deterministic")
+	}
+	b = Jive(y, x)
+	if a.Equal(&b) {
+		t.Fatal("Jive should not be symmetric")
+	}
+
+	u, v := x, y
+	Permutation(&u, &v)
+	if u.Equal(&x) || v.Equal(&y) {
+		t.Fatal("the permutation shouldn't have fixed points")
+	}
+}
+
+func TestHash(t *testing.T) {
+	msg := make([]byte, 3*BlockSize+5)
+	for i := range msg {
+		msg[i] = byte(i)
+	}
+	expected := Sum(msg)
+
+	// writes split at arbitrary positions
+	h := NewAnemoi()
+	_, _ = h.Write(msg[:7])
+	_, _ = h.Write(msg[7 : BlockSize+3])
+	_, _ = h.Write(msg[BlockSize+3:])
+	if !bytes.Equal(h.Sum(nil), expected) {
+		t.Fatal("the hash should not depend on how the data is written")
+	}
+	// Sum doesn't change the state
+	if !bytes.Equal(h.Sum(nil), expected) {
+		t.Fatal("Sum should not change the state")
+	}
+
+	h.Reset()
+	_, _ = h.Write(msg)
+	if !bytes.Equal(h.Sum(nil), expected) {
+		t.Fatal("Reset should restore the initial state")
+	}
+
+	// leading zeroes are not ignored thanks to the length padding
+	if bytes.Equal(Sum([]byte{1}), Sum([]byte{0, 1})) {
+		t.Fatal("messages differing by leading zeroes should have different hashes")
+	}
+
+	// the chaining value is Jive(Jive(0, m₀), len)
+	var m, l, zero fr.Element
+	m.SetBytes(msg[:BlockSize])
+	l.SetUint64(BlockSize)
+	chain := Jive(zero, m)
+	chain = Jive(chain, l)
+	b := chain.Bytes()
+	if !bytes.Equal(Sum(msg[:BlockSize]), b[:]) {
+		t.Fatal("unexpected chaining")
+	}
+}
+
+func BenchmarkJive(b *testing.B) {
+	var x, y fr.Element
+	x.SetRandom()
+	y.SetRandom()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		x = Jive(x, y)
+	}
+}
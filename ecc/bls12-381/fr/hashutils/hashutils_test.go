@@ -0,0 +1,167 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package hashutils
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"hash"
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	fiatshamir "github.com/consensys/gnark-crypto/fiat-shamir"
+	"github.com/consensys/gnark-crypto/hash/blake3"
+	"golang.org/x/crypto/blake2s"
+	"golang.org/x/crypto/sha3"
+)
+
+func TestHashToFr(t *testing.T) {
+	for _, h := range []func() hash.Hash{sha256.New, sha3.NewLegacyKeccak256} {
+		a := HashToFr(h, []byte("ab"), []byte("c"))
+		b := HashToFr(h, []byte("a"), []byte("bc"))
+		if a.Equal(&b) {
+			t.Fatal("HashToFr should be injective on the data split")
+		}
+		c := HashToFr(h, []byte("ab"), []byte("c"))
+		if !a.Equal(&c) {
+			t.Fatal("HashToFr should be deterministic")
+		}
+
+		// the result is the reduction of the expanded bytes
+		var v big.Int
+		v.SetBytes(Expand(h, WideSize, []byte("ab"), []byte("c"))).Mod(&v, fr.Modulus())
+		var expected fr.Element
+		expected.SetBigInt(&v)
+		if !a.Equal(&expected) {
+			t.Fatal("HashToFr should reduce the expanded digest")
+		}
+	}
+}
+
+func TestExpand(t *testing.T) {
+	data := []byte("gnark-crypto")
+	for _, n := range []int{0, 1, 32, 33, WideSize, 100} {
+		out := Expand(sha256.New, n, data)
+		if len(out) != n {
+			t.Fatalf("Expand(%d) returned %d bytes", n, len(out))
+		}
+		// outputs are prefixes of each other
+		longer := Expand(sha256.New, n+7, data)
+		if !bytes.Equal(out, longer[:n]) {
+			t.Fatal("Expand output should not depend on the requested length")
+		}
+	}
+
+	// first block is H(0 || len || data)
+	h := sha256.New()
+	h.Write([]byte{0, 0, 0, 0})
+	h.Write([]byte{0, 0, 0, 0, 0, 0, 0, byte(len(data))})
+	h.Write(data)
+	if !bytes.Equal(Expand(sha256.New, 32, data), h.Sum(nil)) {
+		t.Fatal("unexpected first block")
+	}
+}
+
+func TestWideReduce(t *testing.T) {
+	// r + 1 reduces to 1
+	var v big.Int
+	v.Add(fr.Modulus(), big.NewInt(1))
+	b := make([]byte, WideSize)
+	v.FillBytes(b)
+	res := WideReduce(b)
+	if !res.IsOne() {
+		t.Fatal("WideReduce(r+1) should be 1")
+	}
+}
+
+func TestPackElements(t *testing.T) {
+	var a, b fr.Element
+	a.SetUint64(1)
+	b.SetUint64(2)
+
+	packed := PackElements(a, b)
+	if len(packed) != 8+2*fr.Bytes || packed[7] != 2 || packed[8+fr.Bytes-1] != 1 || packed[8+2*fr.Bytes-1] != 2 {
+		t.Fatal("unexpected encoding")
+	}
+	if bytes.Equal(PackElements(a), PackElements(a, a)[:8+fr.Bytes]) {
+		t.Fatal("the number of elements should be encoded")
+	}
+
+	blake2 := func() hash.Hash {
+		h, _ := blake2s.New256(nil)
+		return h
+	}
+	for _, newHash := range []func() hash.Hash{blake3.New, blake2} {
+		h := newHash()
+		WriteElements(h, a, b)
+		expected := newHash()
+		expected.Write(packed)
+		if !bytes.Equal(h.Sum(nil), expected.Sum(nil)) {
+			t.Fatal("WriteElements should write PackElements")
+		}
+	}
+}
+
+func TestPackPoints(t *testing.T) {
+	_, _, g1, g2 := bls12381.Generators()
+
+	packed := PackPoints(&g1, &g2)
+	expected := append([]byte{0, 0, 0, 0, 0, 0, 0, 2}, g1.Marshal()...)
+	expected = append(expected, g2.Marshal()...)
+	if !bytes.Equal(packed, expected) {
+		t.Fatal("unexpected encoding")
+	}
+
+	h := blake3.New()
+	WritePoints(h, &g1, &g2)
+	digest := blake3.Sum256(packed)
+	if !bytes.Equal(h.Sum(nil), digest[:]) {
+		t.Fatal("WritePoints should write PackPoints")
+	}
+}
+
+func TestTranscript(t *testing.T) {
+	var a fr.Element
+	a.SetRandom()
+	_, _, g1, _ := bls12381.Generators()
+
+	challenge := func() fr.Element {
+		fs := fiatshamir.NewTranscript(blake3.New(), "alpha")
+		if err := BindElements(&fs, "alpha", a); err != nil {
+			t.Fatal(err)
+		}
+		if err := BindPoints(&fs, "alpha", &g1); err != nil {
+			t.Fatal(err)
+		}
+		c, err := ChallengeToFr(&fs, blake3.New, "alpha")
+		if err != nil {
+			t.Fatal(err)
+		}
+		return c
+	}
+	c1, c2 := challenge(), challenge()
+	if !c1.Equal(&c2) {
+		t.Fatal("the challenge should be deterministic")
+	}
+	a.SetRandom()
+	c2 = challenge()
+	if c1.Equal(&c2) {
+		t.Fatal("the challenge should depend on the bound values")
+	}
+}
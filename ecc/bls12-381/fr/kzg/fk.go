@@ -0,0 +1,63 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package kzg
+
+import (
+	"github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr/fft"
+)
+
+// OpenAll computes the opening proofs of p at all the points ωⁱ of domain, where ω is the
+// generator of domain. The i-th proof is the opening at ωⁱ.
+//
+// Following Feist-Khovratovich, the commitments to the quotients (p-p(ωⁱ))/(X-ωⁱ) are
+// the evaluations at ωⁱ of h = ∑ₘhₘXᵐ where hₘ = ∑ⱼp_{m+j+1}[αʲ]G₁. h is computed as a
+// Toeplitz matrix-vector product, so the whole computation costs O(n log n) group
+// operations instead of O(n²) for n calls to Open.
+func OpenAll(p []fr.Element, domain *fft.Domain, srs *SRS) ([]OpeningProof, error) {
+	n := int(domain.Cardinality)
+	if len(p) == 0 || len(p) > len(srs.G1) || len(p) > n {
+		return nil, ErrInvalidPolynomialSize
+	}
+
+	// p and the points of the SRS, padded to n
+	c := make([]fr.Element, n)
+	copy(c, p)
+	points := make([]bls12381.G1Affine, n)
+	copy(points, srs.G1)
+
+	// [hₘ] then [h(ωⁱ)]
+	h, err := fft.FeistKhovratovichG1(c, points)
+	if err != nil {
+		return nil, err
+	}
+	fft.FFTG1(h, domain.Generator)
+	quotients := bls12381.BatchJacobianToAffineG1(h)
+
+	// p(ωⁱ)
+	domain.FFT(c, fft.DIF)
+	fft.BitReverse(c)
+
+	res := make([]OpeningProof, n)
+	for i := 0; i < n; i++ {
+		res[i].H.Set(&quotients[i])
+		res[i].ClaimedValue.Set(&c[i])
+	}
+
+	return res, nil
+}
@@ -18,6 +18,7 @@ package kzg
 
 import (
 	"github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
 	"io"
 )
 
@@ -132,3 +133,39 @@ func (proof *BatchOpeningProof) ReadFrom(r io.Reader) (int64, error) {
 
 	return dec.BytesRead(), nil
 }
+
+// WriteTo writes binary encoding of a ShiftedBatchOpeningProof
+func (proof *ShiftedBatchOpeningProof) WriteTo(w io.Writer) (int64, error) {
+	n, err := proof.Proof.WriteTo(w)
+	if err != nil {
+		return n, err
+	}
+	m, err := proof.ShiftedProof.WriteTo(w)
+	return n + m, err
+}
+
+// ReadFrom decodes ShiftedBatchOpeningProof data from reader.
+func (proof *ShiftedBatchOpeningProof) ReadFrom(r io.Reader) (int64, error) {
+	n, err := proof.Proof.ReadFrom(r)
+	if err != nil {
+		return n, err
+	}
+	m, err := proof.ShiftedProof.ReadFrom(r)
+	return n + m, err
+}
+
+// SizeInBytes returns the number of bytes written by WriteTo
+func (proof *OpeningProof) SizeInBytes() int {
+	return bls12381.SizeOfG1AffineCompressed + fr.Bytes
+}
+
+// SizeInBytes returns the number of bytes written by WriteTo
+func (proof *BatchOpeningProof) SizeInBytes() int {
+	// the claimed values are prefixed by their number, on 4 bytes
+	return bls12381.SizeOfG1AffineCompressed + 4 + len(proof.ClaimedValues)*fr.Bytes
+}
+
+// SizeInBytes returns the number of bytes written by WriteTo
+func (proof *ShiftedBatchOpeningProof) SizeInBytes() int {
+	return proof.Proof.SizeInBytes() + proof.ShiftedProof.SizeInBytes()
+}
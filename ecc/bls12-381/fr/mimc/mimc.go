@@ -17,6 +17,7 @@
 package mimc
 
 import (
+	"errors"
 	"hash"
 
 	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
@@ -40,8 +41,80 @@ var (
 // digest represents the partial evaluation of the checksum
 // along with the params of the mimc function
 type digest struct {
-	h    fr.Element
-	data []byte // data to hash
+	h      fr.Element
+	data   []byte // data to hash
+	length uint64 // number of bytes written since the last Sum or Reset
+
+	// options
+	padding Padding
+	packing Packing
+	h0      fr.Element // initial state, derived from the domain separation tag
+}
+
+// Padding is the padding scheme applied to the data when Sum is called
+type Padding uint8
+
+const (
+	// PaddingZero left-pads the last incomplete block with zeroes, so
+	// that it is read as a big endian integer. This is the default.
+	//
+	// Messages differing only by leading zeroes in the last block have the same hash.
+	PaddingZero Padding = iota
+
+	// PaddingLength applies PaddingZero, then absorbs the number of bytes
+	// written since the last Sum as an extra block.
+	PaddingLength
+)
+
+// Packing is the strategy used to map the written bytes to field elements
+type Packing uint8
+
+const (
+	// PackingFull reads BlockSize bytes per field element, reduced modulo r. This is the default.
+	//
+	// Blocks larger than the modulus are silently reduced, so different
+	// messages may have the same hash.
+	PackingFull Packing = iota
+
+	// PackingCanonical reads BlockSize bytes per field element, and Write returns
+	// ErrNonCanonicalBlock if a complete block is not smaller than the modulus.
+	PackingCanonical
+
+	// PackingShort reads BlockSize-1 bytes per field element, which is always
+	// smaller than the modulus. Any byte string can be hashed without reduction.
+	PackingShort
+)
+
+// ErrNonCanonicalBlock is returned by Write with PackingCanonical when a block
+// of data is not the canonical encoding of a field element
+var ErrNonCanonicalBlock = errors.New("mimc: block is not smaller than the modulus")
+
+// Option configures a MiMC hash function returned by NewMiMC
+type Option func(*digest)
+
+// WithPadding sets the padding scheme
+func WithPadding(padding Padding) Option {
+	return func(d *digest) {
+		d.padding = padding
+	}
+}
+
+// WithPacking sets the strategy mapping bytes to field elements
+func WithPacking(packing Packing) Option {
+	return func(d *digest) {
+		d.packing = packing
+	}
+}
+
+// WithDomainSeparation sets the initial state of the hash function to the
+// hash of tag (with PaddingLength and PackingShort), so that hash functions with
+// different tags behave as independent functions.
+func WithDomainSeparation(tag []byte) Option {
+	return func(d *digest) {
+		sep := NewMiMC(WithPadding(PaddingLength), WithPacking(PackingShort)).(*digest)
+		_, _ = sep.Write(tag)
+		d.h0 = sep.checksum()
+	}
 }
 
 // GetConstants exposed to be used in gnark
@@ -55,8 +128,14 @@ func GetConstants() []big.Int {
 }
 
 // NewMiMC returns a MiMCImpl object, pure-go reference implementation
-func NewMiMC() hash.Hash {
+//
+// Without options, the hash function uses PaddingZero and PackingFull and
+// starts from the zero state, which is compatible with previous versions.
+func NewMiMC(opts ...Option) hash.Hash {
 	d := new(digest)
+	for _, opt := range opts {
+		opt(d)
+	}
 	d.Reset()
 	return d
 }
@@ -64,14 +143,21 @@ func NewMiMC() hash.Hash {
 // Reset resets the Hash to its initial state.
 func (d *digest) Reset() {
 	d.data = nil
-	d.h = fr.Element{0, 0, 0, 0}
+	d.length = 0
+	d.h = d.h0
 }
 
 // Sum appends the current hash to b and returns the resulting slice.
-// It does not change the underlying hash state.
+//
+// The data written so far is absorbed in the state and flushed, so that
+// subsequent writes continue the chain from the returned hash: writing a
+// then calling Sum, then writing b and calling Sum again, does not give
+// the same result as writing a||b and calling Sum once. Call Reset to start
+// a new hash.
 func (d *digest) Sum(b []byte) []byte {
 	buffer := d.checksum()
 	d.data = nil // flush the data already hashed
+	d.length = 0
 	hash := buffer.Bytes()
 	b = append(b, hash[:]...)
 	return b
@@ -91,10 +177,28 @@ func (d *digest) BlockSize() int {
 }
 
 // Write (via the embedded io.Writer interface) adds more data to the running hash.
-// It never returns an error.
+// It only returns an error with PackingCanonical, if p completes a block which
+// is not smaller than the modulus; in that case, none of p is written.
 func (d *digest) Write(p []byte) (n int, err error) {
+	if d.packing == PackingCanonical {
+		var x big.Int
+		for i := len(d.data) / BlockSize; (i+1)*BlockSize <= len(d.data)+len(p); i++ {
+			var block [BlockSize]byte
+			start := i * BlockSize
+			if start < len(d.data) {
+				copy(block[:], d.data[start:])
+				copy(block[len(d.data)-start:], p)
+			} else {
+				copy(block[:], p[start-len(d.data):])
+			}
+			if x.SetBytes(block[:]).Cmp(fr.Modulus()) >= 0 {
+				return 0, ErrNonCanonicalBlock
+			}
+		}
+	}
 	n = len(p)
 	d.data = append(d.data, p...)
+	d.length += uint64(n)
 	return
 }
 
@@ -103,39 +207,67 @@ func (d *digest) Write(p []byte) (n int, err error) {
 // The XOR operation is replaced by field addition, data is in Montgomery form
 func (d *digest) checksum() fr.Element {
 
-	var buffer [BlockSize]byte
 	var x fr.Element
 
-	// if data size is not multiple of BlockSizes we padd:
+	chunkSize := BlockSize
+	if d.packing == PackingShort {
+		chunkSize = BlockSize - 1
+	}
+
+	// if data size is not multiple of the chunk size we padd:
 	// .. || 0xaf8 -> .. || 0x0000...0af8
-	if len(d.data)%BlockSize != 0 {
-		q := len(d.data) / BlockSize
-		r := len(d.data) % BlockSize
-		sliceq := make([]byte, q*BlockSize)
+	if len(d.data)%chunkSize != 0 {
+		q := len(d.data) / chunkSize
+		r := len(d.data) % chunkSize
+		sliceq := make([]byte, q*chunkSize)
 		copy(sliceq, d.data)
 		slicer := make([]byte, r)
-		copy(slicer, d.data[q*BlockSize:])
-		sliceremainder := make([]byte, BlockSize-r)
+		copy(slicer, d.data[q*chunkSize:])
+		sliceremainder := make([]byte, chunkSize-r)
 		d.data = append(sliceq, sliceremainder...)
 		d.data = append(d.data, slicer...)
 	}
 
 	if len(d.data) == 0 {
-		d.data = make([]byte, 32)
+		if d.packing == PackingFull {
+			// kept for compatibility with previous versions
+			d.data = make([]byte, 32)
+		} else {
+			d.data = make([]byte, chunkSize)
+		}
 	}
 
-	nbChunks := len(d.data) / BlockSize
+	nbChunks := len(d.data) / chunkSize
 
 	for i := 0; i < nbChunks; i++ {
-		copy(buffer[:], d.data[i*BlockSize:(i+1)*BlockSize])
-		x.SetBytes(buffer[:])
-		r := d.encrypt(x)
-		d.h.Add(&r, &d.h).Add(&d.h, &x)
+		x.SetBytes(d.data[i*chunkSize : (i+1)*chunkSize])
+		d.absorb(&x)
+	}
+
+	if d.padding == PaddingLength {
+		x.SetUint64(d.length)
+		d.absorb(&x)
 	}
 
 	return d.h
 }
 
+// absorb updates the state with one Miyaguchi–Preneel step
+func (d *digest) absorb(x *fr.Element) {
+	r := d.encrypt(*x)
+	d.h.Add(&r, &d.h).Add(&d.h, x)
+}
+
+// Compress is the two-to-one compression function underlying the hash,
+// i.e. a single Miyaguchi–Preneel step with left as the key:
+//
+//	Compress(left, right) = MiMC_left(right) + left + right
+func Compress(left, right fr.Element) fr.Element {
+	d := digest{h: left}
+	d.absorb(&right)
+	return d.h
+}
+
 // plain execution of a mimc run
 // m: message
 // k: encryption key
@@ -146,9 +278,7 @@ func (d *digest) encrypt(m fr.Element) fr.Element {
 		// m = (m+k+c)^5
 		var tmp fr.Element
 		tmp.Add(&m, &d.h).Add(&tmp, &mimcConstants[i])
-		m.Square(&tmp).
-			Square(&m).
-			Mul(&m, &tmp)
+		m.Pow5(&tmp)
 	}
 	m.Add(&m, &d.h)
 	return m
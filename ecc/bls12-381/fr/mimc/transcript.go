@@ -0,0 +1,122 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package mimc
+
+import (
+	"errors"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+)
+
+var (
+	errChallengeNotFound            = errors.New("challenge not recorded in the transcript")
+	errChallengeAlreadyComputed     = errors.New("challenge already computed, cannot be binded to other values")
+	errPreviousChallengeNotComputed = errors.New("the previous challenge is needed and has not been computed")
+)
+
+// Transcript is a Fiat-Shamir transcript on 𝔽_r, which binds field elements without
+// serializing them, for proofs which are verified in a circuit over 𝔽_r.
+//
+// It follows fiatshamir.Transcript, with MiMC in Miyaguchi–Preneel mode as the hash
+// function: the challenge named name is
+//
+//	c = H(H(name), c_prev, v₀, v₁, ...)
+//
+// where c_prev is the previous challenge (omitted for the first one), v₀, v₁, ... are the
+// values binded to the challenge, and H(x₀, x₁, ...) = Compress(...Compress(Compress(0, x₀), x₁)...).
+// H(name) is the MiMC hash of the name (with PaddingLength and PackingShort), a constant
+// of the protocol. In a circuit, c is computed with the same chain of Compress.
+type Transcript struct {
+	challenges map[string]*transcriptChallenge
+	previous   *transcriptChallenge
+}
+
+type transcriptChallenge struct {
+	position   int          // position of the challenge in the transcript. order matters.
+	bindings   []fr.Element // values the challenge is binded to
+	value      fr.Element   // computed challenge
+	isComputed bool
+}
+
+// NewTranscript returns a new transcript for the challenges challengesID.
+// The order of the challenges IDs matters.
+func NewTranscript(challengesID ...string) *Transcript {
+	t := &Transcript{
+		challenges: make(map[string]*transcriptChallenge, len(challengesID)),
+	}
+	for i, id := range challengesID {
+		t.challenges[id] = &transcriptChallenge{position: i}
+	}
+	return t
+}
+
+// Bind binds the challenge to values. A challenge can be binded to an arbitrary
+// number of values, but the order in which they are added is important. Once a
+// challenge is computed, it cannot be binded to other values.
+func (t *Transcript) Bind(challengeID string, values ...fr.Element) error {
+	c, ok := t.challenges[challengeID]
+	if !ok {
+		return errChallengeNotFound
+	}
+	if c.isComputed {
+		return errChallengeAlreadyComputed
+	}
+	c.bindings = append(c.bindings, values...)
+	return nil
+}
+
+// ComputeChallenge computes the challenge corresponding to the given name.
+// If the challenge was already computed, the same value is returned.
+func (t *Transcript) ComputeChallenge(challengeID string) (fr.Element, error) {
+	c, ok := t.challenges[challengeID]
+	if !ok {
+		return fr.Element{}, errChallengeNotFound
+	}
+	if c.isComputed {
+		return c.value, nil
+	}
+
+	// the name of the challenge is a domain separator
+	h := Compress(fr.Element{}, ChallengeName(challengeID))
+
+	// the previous challenge, if it's not the first challenge
+	if c.position != 0 {
+		if t.previous == nil || t.previous.position != c.position-1 {
+			return fr.Element{}, errPreviousChallengeNotComputed
+		}
+		h = Compress(h, t.previous.value)
+	}
+
+	// the binded values in the order they were added
+	for i := range c.bindings {
+		h = Compress(h, c.bindings[i])
+	}
+
+	c.value = h
+	c.isComputed = true
+	t.previous = c
+
+	return h, nil
+}
+
+// ChallengeName returns H(name), the MiMC hash of name with PaddingLength and PackingShort,
+// which is absorbed first by the challenge name of a Transcript.
+func ChallengeName(name string) fr.Element {
+	h := NewMiMC(WithPadding(PaddingLength), WithPacking(PackingShort)).(*digest)
+	_, _ = h.Write([]byte(name))
+	return h.checksum()
+}
@@ -0,0 +1,93 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package mimc
+
+import (
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+)
+
+func TestTranscript(t *testing.T) {
+	var a, b fr.Element
+	if _, err := a.SetRandom(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := b.SetRandom(); err != nil {
+		t.Fatal(err)
+	}
+
+	ts := NewTranscript("alpha", "beta")
+	if err := ts.Bind("alpha", a, b); err != nil {
+		t.Fatal(err)
+	}
+
+	// beta needs alpha
+	if _, err := ts.ComputeChallenge("beta"); err != errPreviousChallengeNotComputed {
+		t.Fatalf("expected %v, got %v", errPreviousChallengeNotComputed, err)
+	}
+
+	alpha, err := ts.ComputeChallenge("alpha")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ts.Bind("alpha", a); err != errChallengeAlreadyComputed {
+		t.Fatalf("expected %v, got %v", errChallengeAlreadyComputed, err)
+	}
+	if err := ts.Bind("gamma", a); err != errChallengeNotFound {
+		t.Fatalf("expected %v, got %v", errChallengeNotFound, err)
+	}
+	beta, err := ts.ComputeChallenge("beta")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// consistent with the definition
+	expected := Compress(Compress(Compress(fr.Element{}, ChallengeName("alpha")), a), b)
+	if !alpha.Equal(&expected) {
+		t.Fatal("alpha != H(H(\"alpha\"), a, b)")
+	}
+	expected = Compress(Compress(fr.Element{}, ChallengeName("beta")), alpha)
+	if !beta.Equal(&expected) {
+		t.Fatal("beta != H(H(\"beta\"), alpha)")
+	}
+
+	// H(x₀, x₁, ...) is the MiMC hash of the canonical encodings of x₀, x₁, ...
+	h := NewMiMC()
+	for _, x := range []fr.Element{ChallengeName("alpha"), a, b} {
+		bx := x.Bytes()
+		h.Write(bx[:])
+	}
+	expected.SetBytes(h.Sum(nil))
+	if !alpha.Equal(&expected) {
+		t.Fatal("alpha != MiMC(H(\"alpha\") || a || b)")
+	}
+
+	// a computed challenge is returned as is
+	if again, err := ts.ComputeChallenge("alpha"); err != nil || !again.Equal(&alpha) {
+		t.Fatal("the challenge should not change once computed")
+	}
+
+	// the order of the binded values matters
+	ts = NewTranscript("alpha")
+	if err := ts.Bind("alpha", b, a); err != nil {
+		t.Fatal(err)
+	}
+	if other, _ := ts.ComputeChallenge("alpha"); other.Equal(&alpha) {
+		t.Fatal("the order of the binded values should matter")
+	}
+}
@@ -0,0 +1,224 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package pcs
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"hash"
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr/fft"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr/fri"
+)
+
+// nbQueriesFRI number of positions at which the quotient relation is checked
+const nbQueriesFRI = 16
+
+// FRI is the FRI backend, the digests are FRIDigest and the proofs *FRIOpeningProof.
+//
+// The digest of p is the Merkle root of its evaluations on the Reed-Solomon domain. To
+// open p at z, the prover sends proofs of proximity for p and q = (p - p(z))/(X - z),
+// and the verifier checks q(x)(x - z) = p(x) - p(z) on a few positions x of the domain
+// derived from the transcript.
+type FRI struct {
+	iopp   fri.Iopp
+	domain *fft.Domain
+	size   uint64
+}
+
+// FRIDigest is the Merkle root of the evaluations of a polynomial
+type FRIDigest []byte
+
+// Marshal returns the Merkle root
+func (d FRIDigest) Marshal() []byte {
+	return d
+}
+
+// FRIOpeningProof proof of the evaluation of a polynomial
+type FRIOpeningProof struct {
+
+	// ProximityP, ProximityQ proofs of proximity of p and of the quotient
+	ProximityP, ProximityQ fri.ProofOfProximity
+
+	// OpeningsP, OpeningsQ openings of p and of the quotient at the query positions
+	OpeningsP, OpeningsQ []fri.OpeningProof
+
+	// Value is the claimed evaluation
+	Value fr.Element
+}
+
+// ClaimedValue returns the claimed evaluation
+func (proof *FRIOpeningProof) ClaimedValue() fr.Element {
+	return proof.Value
+}
+
+// NewFRI returns a FRI backend for polynomials of up to size coefficients, size >= 2.
+// h is used for the Merkle trees and the Fiat Shamir challenges of the proofs of proximity.
+func NewFRI(size uint64, h hash.Hash) (*FRI, error) {
+	if size < 2 {
+		return nil, ErrInvalidPolynomialSize
+	}
+	size = ecc.NextPowerOfTwo(size)
+	return &FRI{
+		iopp:   fri.RADIX_2_FRI.New(size, h),
+		domain: fft.NewDomain(uint64(fri.GetRho()) * size),
+		size:   size,
+	}, nil
+}
+
+// Commit implements PolynomialCommitment
+func (s *FRI) Commit(p []fr.Element) (Digest, error) {
+	if len(p) == 0 || uint64(len(p)) > s.size {
+		return nil, ErrInvalidPolynomialSize
+	}
+	pp, err := s.iopp.BuildProofOfProximity(p)
+	if err != nil {
+		return nil, err
+	}
+	return FRIDigest(merkleRoot(&pp)), nil
+}
+
+// Open implements PolynomialCommitment
+func (s *FRI) Open(p []fr.Element, point fr.Element) (OpeningProof, error) {
+	if len(p) == 0 || uint64(len(p)) > s.size {
+		return nil, ErrInvalidPolynomialSize
+	}
+	proof := &FRIOpeningProof{Value: evaluate(p, point)}
+
+	// q = (p - p(z))/(X - z), by synthetic division
+	q := make([]fr.Element, len(p))
+	for i := len(p) - 1; i > 0; i-- {
+		q[i-1].Mul(&q[i], &point).Add(&q[i-1], &p[i])
+	}
+
+	var err error
+	if proof.ProximityP, err = s.iopp.BuildProofOfProximity(p); err != nil {
+		return nil, err
+	}
+	if proof.ProximityQ, err = s.iopp.BuildProofOfProximity(q); err != nil {
+		return nil, err
+	}
+
+	positions := s.deriveQueriesPositions(merkleRoot(&proof.ProximityP), merkleRoot(&proof.ProximityQ), point, proof.Value)
+	proof.OpeningsP = make([]fri.OpeningProof, len(positions))
+	proof.OpeningsQ = make([]fri.OpeningProof, len(positions))
+	for i, pos := range positions {
+		if proof.OpeningsP[i], err = s.iopp.Open(p, pos); err != nil {
+			return nil, err
+		}
+		if proof.OpeningsQ[i], err = s.iopp.Open(q, pos); err != nil {
+			return nil, err
+		}
+	}
+
+	return proof, nil
+}
+
+// Verify implements PolynomialCommitment
+func (s *FRI) Verify(digest Digest, proof OpeningProof, point fr.Element) error {
+	d, ok := digest.(FRIDigest)
+	if !ok {
+		return ErrBackendMismatch
+	}
+	p, ok := proof.(*FRIOpeningProof)
+	if !ok {
+		return ErrBackendMismatch
+	}
+	if len(p.OpeningsP) != nbQueriesFRI || len(p.OpeningsQ) != nbQueriesFRI {
+		return ErrVerifyOpeningProof
+	}
+
+	// p and q are close to low degree polynomials, p being the committed one
+	if err := s.iopp.VerifyProofOfProximity(p.ProximityP); err != nil {
+		return err
+	}
+	if err := s.iopp.VerifyProofOfProximity(p.ProximityQ); err != nil {
+		return err
+	}
+	rootP, rootQ := merkleRoot(&p.ProximityP), merkleRoot(&p.ProximityQ)
+	if !bytes.Equal(rootP, d) {
+		return ErrVerifyOpeningProof
+	}
+
+	// q(x)(x - z) = p(x) - p(z) at the query positions
+	positions := s.deriveQueriesPositions(rootP, rootQ, point, p.Value)
+	var x, lhs, rhs fr.Element
+	for i, pos := range positions {
+		if err := s.iopp.VerifyOpening(pos, p.OpeningsP[i], p.ProximityP); err != nil {
+			return err
+		}
+		if err := s.iopp.VerifyOpening(pos, p.OpeningsQ[i], p.ProximityQ); err != nil {
+			return err
+		}
+		x.Exp(s.domain.Generator, new(big.Int).SetUint64(pos))
+		lhs.Sub(&x, &point).Mul(&lhs, &p.OpeningsQ[i].ClaimedValue)
+		rhs.Sub(&p.OpeningsP[i].ClaimedValue, &p.Value)
+		if !lhs.Equal(&rhs) {
+			return ErrVerifyOpeningProof
+		}
+	}
+
+	return nil
+}
+
+// BatchVerify implements PolynomialCommitment, verifying the proofs one by one
+func (s *FRI) BatchVerify(digests []Digest, proofs []OpeningProof, points []fr.Element) error {
+	if err := checkBatchSizes(digests, proofs, points); err != nil {
+		return err
+	}
+	for i := range digests {
+		if err := s.Verify(digests[i], proofs[i], points[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// merkleRoot returns the root of the Merkle tree of the evaluations of the
+// polynomial, committed in the first interaction of a proof of proximity
+func merkleRoot(pp *fri.ProofOfProximity) []byte {
+	if len(pp.Rounds) == 0 || len(pp.Rounds[0].Interactions) == 0 {
+		return nil
+	}
+	return pp.Rounds[0].Interactions[0][0].MerkleRoot
+}
+
+// deriveQueriesPositions derives the positions at which the quotient relation is
+// checked, by hashing the statement and both commitments
+func (s *FRI) deriveQueriesPositions(rootP, rootQ []byte, point, value fr.Element) []uint64 {
+	h := sha256.New()
+	h.Write(rootP)
+	h.Write(rootQ)
+	h.Write(point.Marshal())
+	h.Write(value.Marshal())
+	seed := h.Sum(nil)
+
+	res := make([]uint64, nbQueriesFRI)
+	var buf [4]byte
+	for i := range res {
+		h.Reset()
+		h.Write(seed)
+		binary.BigEndian.PutUint32(buf[:], uint32(i))
+		h.Write(buf[:])
+		res[i] = binary.BigEndian.Uint64(h.Sum(nil)) % s.domain.Cardinality
+	}
+	return res
+}
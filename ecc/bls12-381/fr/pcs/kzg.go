@@ -0,0 +1,112 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package pcs
+
+import (
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr/kzg"
+)
+
+// KZG is the KZG backend, the digests are *kzg.Digest and the proofs *KZGOpeningProof
+type KZG struct {
+	SRS *kzg.SRS
+}
+
+// KZGOpeningProof wraps a kzg opening proof
+type KZGOpeningProof struct {
+	Proof kzg.OpeningProof
+}
+
+// ClaimedValue returns the claimed evaluation
+func (proof *KZGOpeningProof) ClaimedValue() fr.Element {
+	return proof.Proof.ClaimedValue
+}
+
+// NewKZG returns a KZG backend using the given SRS
+func NewKZG(srs *kzg.SRS) *KZG {
+	return &KZG{SRS: srs}
+}
+
+// Commit implements PolynomialCommitment
+func (s *KZG) Commit(p []fr.Element) (Digest, error) {
+	d, err := kzg.Commit(p, s.SRS)
+	if err != nil {
+		return nil, kzgError(err)
+	}
+	return &d, nil
+}
+
+// Open implements PolynomialCommitment
+func (s *KZG) Open(p []fr.Element, point fr.Element) (OpeningProof, error) {
+	proof, err := kzg.Open(p, point, s.SRS)
+	if err != nil {
+		return nil, kzgError(err)
+	}
+	return &KZGOpeningProof{Proof: proof}, nil
+}
+
+// Verify implements PolynomialCommitment
+func (s *KZG) Verify(digest Digest, proof OpeningProof, point fr.Element) error {
+	d, p, err := kzgCast(digest, proof)
+	if err != nil {
+		return err
+	}
+	return kzgError(kzg.Verify(d, &p.Proof, point, s.SRS))
+}
+
+// BatchVerify implements PolynomialCommitment, with a single pairing check
+func (s *KZG) BatchVerify(digests []Digest, proofs []OpeningProof, points []fr.Element) error {
+	if err := checkBatchSizes(digests, proofs, points); err != nil {
+		return err
+	}
+	_digests := make([]kzg.Digest, len(digests))
+	_proofs := make([]kzg.OpeningProof, len(proofs))
+	for i := range digests {
+		d, p, err := kzgCast(digests[i], proofs[i])
+		if err != nil {
+			return err
+		}
+		_digests[i] = *d
+		_proofs[i] = p.Proof
+	}
+	return kzgError(kzg.BatchVerifyMultiPoints(_digests, _proofs, points, s.SRS))
+}
+
+func kzgCast(digest Digest, proof OpeningProof) (*kzg.Digest, *KZGOpeningProof, error) {
+	d, ok := digest.(*kzg.Digest)
+	if !ok {
+		return nil, nil, ErrBackendMismatch
+	}
+	p, ok := proof.(*KZGOpeningProof)
+	if !ok {
+		return nil, nil, ErrBackendMismatch
+	}
+	return d, p, nil
+}
+
+// kzgError maps the errors of the kzg package to the ones of this package
+func kzgError(err error) error {
+	switch err {
+	case kzg.ErrInvalidPolynomialSize:
+		return ErrInvalidPolynomialSize
+	case kzg.ErrVerifyOpeningProof:
+		return ErrVerifyOpeningProof
+	case kzg.ErrInvalidNbDigests:
+		return ErrInvalidNbDigests
+	}
+	return err
+}
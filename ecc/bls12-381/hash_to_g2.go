@@ -25,91 +25,137 @@ import (
 
 //Note: This only works for simple extensions
 
-func g2IsogenyXNumerator(dst *fptower.E2, x *fptower.E2) {
-	g2EvalPolynomial(dst,
-		false,
-		[]fptower.E2{
-			{
-				A0: fp.Element{5185457120960601698, 494647221959407934, 8971396042087821730, 324544954362548322, 14214792730224113654, 1405280679127738945},
-				A1: fp.Element{5185457120960601698, 494647221959407934, 8971396042087821730, 324544954362548322, 14214792730224113654, 1405280679127738945},
-			},
-			{
-				A0: fp.Element{0},
-				A1: fp.Element{6910023028261548496, 9745789443900091043, 7668299866710145304, 2432656849393633605, 2897729527445498821, 776645607375592125},
-			},
-			{
-				A0: fp.Element{724047465092313539, 15783990863276714670, 12824896677063784855, 15246381572572671516, 13186611051602728692, 1485475813959743803},
-				A1: fp.Element{12678383550985550056, 4872894721950045521, 13057521970209848460, 10439700461551592610, 10672236800577525218, 388322803687796062},
-			},
-			{
-				A0: fp.Element{4659755689450087917, 1804066951354704782, 15570919779568036803, 15592734958806855601, 7597208057374167129, 1841438384006890194},
-				A1: fp.Element{0},
-			},
+// coefficients of the rational maps of the isogeny E' → E, in increasing degree.
+// The denominators are monic, their leading coefficient is omitted.
+var (
+	g2IsogenyXNumeratorMap = []fptower.E2{
+		{
+			A0: fp.Element{5185457120960601698, 494647221959407934, 8971396042087821730, 324544954362548322, 14214792730224113654, 1405280679127738945},
+			A1: fp.Element{5185457120960601698, 494647221959407934, 8971396042087821730, 324544954362548322, 14214792730224113654, 1405280679127738945},
+		},
+		{
+			A0: fp.Element{0},
+			A1: fp.Element{6910023028261548496, 9745789443900091043, 7668299866710145304, 2432656849393633605, 2897729527445498821, 776645607375592125},
+		},
+		{
+			A0: fp.Element{724047465092313539, 15783990863276714670, 12824896677063784855, 15246381572572671516, 13186611051602728692, 1485475813959743803},
+			A1: fp.Element{12678383550985550056, 4872894721950045521, 13057521970209848460, 10439700461551592610, 10672236800577525218, 388322803687796062},
+		},
+		{
+			A0: fp.Element{4659755689450087917, 1804066951354704782, 15570919779568036803, 15592734958806855601, 7597208057374167129, 1841438384006890194},
+			A1: fp.Element{0},
+		},
+	}
+	g2IsogenyXDenominatorMap = []fptower.E2{
+		{
+			A0: fp.Element{0},
+			A1: fp.Element{2250392438786206615, 17463829474098544446, 14571211649711714824, 4495761442775821336, 258811604141191305, 357646605018048850},
+		},
+		{
+			A0: fp.Element{4933130441833534766, 15904462746612662304, 8034115857496836953, 12755092135412849606, 7007796720291435703, 252692002104915169},
+			A1: fp.Element{8469300574244328829, 4752422838614097887, 17848302789776796362, 12930989898711414520, 16851051131888818207, 1621106615542624696},
+		},
+	}
+	g2IsogenyYNumeratorMap = []fptower.E2{
+		{
+			A0: fp.Element{10869708750642247614, 13056187057366814946, 1750362034917495549, 6326189602300757217, 1140223926335695785, 632761649765668291},
+			A1: fp.Element{10869708750642247614, 13056187057366814946, 1750362034917495549, 6326189602300757217, 1140223926335695785, 632761649765668291},
+		},
+		{
+			A0: fp.Element{0},
+			A1: fp.Element{13765940311003083782, 5579209876153186557, 11349908400803699438, 11707848830955952341, 199199289641242246, 899896674917908607},
 		},
-		x)
+		{
+			A0: fp.Element{15562563812347550836, 2436447360975022760, 6528760985104924230, 5219850230775796305, 5336118400288762609, 194161401843898031},
+			A1: fp.Element{16286611277439864375, 18220438224251737430, 906913588459157469, 2019487729638916206, 75985378181939686, 1679637215803641835},
+		},
+		{
+			A0: fp.Element{11849179119594500956, 13906615243538674725, 14543197362847770509, 2041759640812427310, 2879701092679313252, 1259985822978576468},
+			A1: fp.Element{0},
+		},
+	}
+	g2IsogenyYDenominatorMap = []fptower.E2{
+		{
+			A0: fp.Element{99923616639376095, 10339114964526300021, 6204619029868000785, 1288486622530663893, 14587509920085997152, 272081012460753233},
+			A1: fp.Element{99923616639376095, 10339114964526300021, 6204619029868000785, 1288486622530663893, 14587509920085997152, 272081012460753233},
+		},
+		{
+			A0: fp.Element{0},
+			A1: fp.Element{6751177316358619845, 15498000274876530106, 6820146801716041242, 13487284328327464010, 776434812423573915, 1072939815054146550},
+		},
+		{
+			A0: fp.Element{7399695662750302149, 14633322083064217648, 12051173786245255430, 9909266166264498601, 1288323043582377747, 379038003157372754},
+			A1: fp.Element{6002735353327561446, 6023563502162542543, 13831244861028377885, 15776815867859765525, 4123780734888324547, 1494760614490167112},
+		},
+	}
+)
+
+// coefficients A', B' of the curve E': y² = x³ + A'x + B' isogenous to E, on which the SSWU map is computed,
+// and the non-square Z of the map
+var (
+	g2SSWUIsoCurveCoeffA = fptower.E2{
+		A0: fp.Element{0},
+		A1: fp.Element{16517514583386313282, 74322656156451461, 16683759486841714365, 815493829203396097, 204518332920448171, 1306242806803223655},
+	}
+	g2SSWUIsoCurveCoeffB = fptower.E2{
+		A0: fp.Element{2515823342057463218, 7982686274772798116, 7934098172177393262, 8484566552980779962, 4455086327883106868, 1323173589274087377},
+		A1: fp.Element{2515823342057463218, 7982686274772798116, 7934098172177393262, 8484566552980779962, 4455086327883106868, 1323173589274087377},
+	}
+	g2SSWUZ = fptower.E2{
+		A0: fp.Element{9794203289623549276, 7309342082925068282, 1139538881605221074, 15659550692327388916, 16008355200866287827, 582484205531694093},
+		A1: fp.Element{4897101644811774638, 3654671041462534141, 569769440802610537, 17053147383018470266, 17227549637287919721, 291242102765847046},
+	}
+)
+
+// G2SSWUConstants returns the coefficients A', B' of the curve E': y² = x³ + A'x + B' isogenous to
+// the curve of G2, and the constant Z of the simplified SWU map to E'.
+// https://www.rfc-editor.org/rfc/rfc9380.html#name-simplified-swu-for-ab-0
+func G2SSWUConstants() (a, b, z fptower.E2) {
+	return g2SSWUIsoCurveCoeffA, g2SSWUIsoCurveCoeffB, g2SSWUZ
+}
+
+// G2IsogenyCoefficients returns the coefficients of the rational maps of the 3-isogeny
+// (x, y) ↦ (xNum(x)/xDen(x), y·yNum(x)/yDen(x)) from E' to the curve of G2, in increasing degree.
+// The denominators are monic, their leading coefficient 1 is omitted.
+// The slices are copies, they can be modified freely.
+func G2IsogenyCoefficients() (xNum, xDen, yNum, yDen []fptower.E2) {
+	xNum = append([]fptower.E2(nil), g2IsogenyXNumeratorMap...)
+	xDen = append([]fptower.E2(nil), g2IsogenyXDenominatorMap...)
+	yNum = append([]fptower.E2(nil), g2IsogenyYNumeratorMap...)
+	yDen = append([]fptower.E2(nil), g2IsogenyYDenominatorMap...)
+	return
+}
+
+// G2EvalSSWU evaluates the simplified SWU map at u. The result is a point of E', not of
+// the curve of G2; MapToG2(u) is G2EvalIsogeny(G2EvalSSWU(u)) with the cofactor cleared.
+func G2EvalSSWU(u *fptower.E2) G2Affine {
+	return mapToCurve2(u)
+}
+
+// G2EvalIsogeny evaluates the isogeny E' → E at p, a point of E'
+func G2EvalIsogeny(p *G2Affine) G2Affine {
+	res := *p
+	g2Isogeny(&res)
+	return res
+}
+
+func g2IsogenyXNumerator(dst *fptower.E2, x *fptower.E2) {
+	g2EvalPolynomial(dst, false, g2IsogenyXNumeratorMap, x)
 }
 
 func g2IsogenyXDenominator(dst *fptower.E2, x *fptower.E2) {
-	g2EvalPolynomial(dst,
-		true,
-		[]fptower.E2{
-			{
-				A0: fp.Element{0},
-				A1: fp.Element{2250392438786206615, 17463829474098544446, 14571211649711714824, 4495761442775821336, 258811604141191305, 357646605018048850},
-			},
-			{
-				A0: fp.Element{4933130441833534766, 15904462746612662304, 8034115857496836953, 12755092135412849606, 7007796720291435703, 252692002104915169},
-				A1: fp.Element{8469300574244328829, 4752422838614097887, 17848302789776796362, 12930989898711414520, 16851051131888818207, 1621106615542624696},
-			},
-		},
-		x)
+	g2EvalPolynomial(dst, true, g2IsogenyXDenominatorMap, x)
 }
 
 func g2IsogenyYNumerator(dst *fptower.E2, x *fptower.E2, y *fptower.E2) {
 	var _dst fptower.E2
-	g2EvalPolynomial(&_dst,
-		false,
-		[]fptower.E2{
-			{
-				A0: fp.Element{10869708750642247614, 13056187057366814946, 1750362034917495549, 6326189602300757217, 1140223926335695785, 632761649765668291},
-				A1: fp.Element{10869708750642247614, 13056187057366814946, 1750362034917495549, 6326189602300757217, 1140223926335695785, 632761649765668291},
-			},
-			{
-				A0: fp.Element{0},
-				A1: fp.Element{13765940311003083782, 5579209876153186557, 11349908400803699438, 11707848830955952341, 199199289641242246, 899896674917908607},
-			},
-			{
-				A0: fp.Element{15562563812347550836, 2436447360975022760, 6528760985104924230, 5219850230775796305, 5336118400288762609, 194161401843898031},
-				A1: fp.Element{16286611277439864375, 18220438224251737430, 906913588459157469, 2019487729638916206, 75985378181939686, 1679637215803641835},
-			},
-			{
-				A0: fp.Element{11849179119594500956, 13906615243538674725, 14543197362847770509, 2041759640812427310, 2879701092679313252, 1259985822978576468},
-				A1: fp.Element{0},
-			},
-		},
-		x)
+	g2EvalPolynomial(&_dst, false, g2IsogenyYNumeratorMap, x)
 
 	dst.Mul(&_dst, y)
 }
 
 func g2IsogenyYDenominator(dst *fptower.E2, x *fptower.E2) {
-	g2EvalPolynomial(dst,
-		true,
-		[]fptower.E2{
-			{
-				A0: fp.Element{99923616639376095, 10339114964526300021, 6204619029868000785, 1288486622530663893, 14587509920085997152, 272081012460753233},
-				A1: fp.Element{99923616639376095, 10339114964526300021, 6204619029868000785, 1288486622530663893, 14587509920085997152, 272081012460753233},
-			},
-			{
-				A0: fp.Element{0},
-				A1: fp.Element{6751177316358619845, 15498000274876530106, 6820146801716041242, 13487284328327464010, 776434812423573915, 1072939815054146550},
-			},
-			{
-				A0: fp.Element{7399695662750302149, 14633322083064217648, 12051173786245255430, 9909266166264498601, 1288323043582377747, 379038003157372754},
-				A1: fp.Element{6002735353327561446, 6023563502162542543, 13831244861028377885, 15776815867859765525, 4123780734888324547, 1494760614490167112},
-			},
-		},
-		x)
+	g2EvalPolynomial(dst, true, g2IsogenyYDenominatorMap, x)
 }
 
 func g2Isogeny(p *G2Affine) {
@@ -217,15 +263,6 @@ func g2MulByZ(z *fptower.E2, x *fptower.E2) {
 // No cofactor clearing or isogeny
 func mapToCurve2(u *fptower.E2) G2Affine {
 
-	var sswuIsoCurveCoeffA = fptower.E2{
-		A0: fp.Element{0},
-		A1: fp.Element{16517514583386313282, 74322656156451461, 16683759486841714365, 815493829203396097, 204518332920448171, 1306242806803223655},
-	}
-	var sswuIsoCurveCoeffB = fptower.E2{
-		A0: fp.Element{2515823342057463218, 7982686274772798116, 7934098172177393262, 8484566552980779962, 4455086327883106868, 1323173589274087377},
-		A1: fp.Element{2515823342057463218, 7982686274772798116, 7934098172177393262, 8484566552980779962, 4455086327883106868, 1323173589274087377},
-	}
-
 	var tv1 fptower.E2
 	tv1.Square(u) // 1.  tv1 = u²
 
@@ -239,20 +276,17 @@ func mapToCurve2(u *fptower.E2) G2Affine {
 	var tv3 fptower.E2
 	var tv4 fptower.E2
 	tv4.SetOne()
-	tv3.Add(&tv2, &tv4)                // 5.  tv3 = tv2 + 1
-	tv3.Mul(&tv3, &sswuIsoCurveCoeffB) // 6.  tv3 = B * tv3
+	tv3.Add(&tv2, &tv4)                  // 5.  tv3 = tv2 + 1
+	tv3.Mul(&tv3, &g2SSWUIsoCurveCoeffB) // 6.  tv3 = B * tv3
 
 	tv2NZero := g2NotZero(&tv2)
 
 	// tv4 = Z
-	tv4 = fptower.E2{
-		A0: fp.Element{9794203289623549276, 7309342082925068282, 1139538881605221074, 15659550692327388916, 16008355200866287827, 582484205531694093},
-		A1: fp.Element{4897101644811774638, 3654671041462534141, 569769440802610537, 17053147383018470266, 17227549637287919721, 291242102765847046},
-	}
+	tv4 = g2SSWUZ
 
 	tv2.Neg(&tv2)
 	tv4.Select(int(tv2NZero), &tv4, &tv2) // 7.  tv4 = CMOV(Z, -tv2, tv2 != 0)
-	tv4.Mul(&tv4, &sswuIsoCurveCoeffA)    // 8.  tv4 = A * tv4
+	tv4.Mul(&tv4, &g2SSWUIsoCurveCoeffA)  // 8.  tv4 = A * tv4
 
 	tv2.Square(&tv3) // 9.  tv2 = tv3²
 
@@ -260,14 +294,14 @@ func mapToCurve2(u *fptower.E2) G2Affine {
 	tv6.Square(&tv4) // 10. tv6 = tv4²
 
 	var tv5 fptower.E2
-	tv5.Mul(&tv6, &sswuIsoCurveCoeffA) // 11. tv5 = A * tv6
+	tv5.Mul(&tv6, &g2SSWUIsoCurveCoeffA) // 11. tv5 = A * tv6
 
 	tv2.Add(&tv2, &tv5) // 12. tv2 = tv2 + tv5
 	tv2.Mul(&tv2, &tv3) // 13. tv2 = tv2 * tv3
 	tv6.Mul(&tv6, &tv4) // 14. tv6 = tv6 * tv4
 
-	tv5.Mul(&tv6, &sswuIsoCurveCoeffB) // 15. tv5 = B * tv6
-	tv2.Add(&tv2, &tv5)                // 16. tv2 = tv2 + tv5
+	tv5.Mul(&tv6, &g2SSWUIsoCurveCoeffB) // 15. tv5 = B * tv6
+	tv2.Add(&tv2, &tv5)                  // 16. tv2 = tv2 + tv5
 
 	var x fptower.E2
 	x.Mul(&tv1, &tv3) // 17.   x = tv1 * tv3
@@ -347,27 +381,36 @@ func MapToG2(u fptower.E2) G2Affine {
 	return res
 }
 
+// G2HashTrace holds the intermediate values of HashToG2 and EncodeToG2,
+// named as in the test vectors of RFC 9380 (appendix J), to compare an implementation
+// or debug the handling of a DST against them.
+type G2HashTrace struct {
+	// U is the output of hash_to_field: one element for EncodeToG2, two for HashToG2
+	U []fptower.E2
+
+	// Q[i] is map_to_curve(U[i]), after the isogeny, before clearing the cofactor
+	Q []G2Affine
+}
+
 // EncodeToG2 hashes a message to a point on the G2 curve using the SSWU map.
 // It is faster than HashToG2, but the result is not uniformly distributed. Unsuitable as a random oracle.
 // dst stands for "domain separation tag", a string unique to the construction using the hash function
 //https://www.ietf.org/archive/id/draft-irtf-cfrg-hash-to-curve-16.html#roadmap
 func EncodeToG2(msg, dst []byte) (G2Affine, error) {
+	res, _, err := EncodeToG2Trace(msg, dst)
+	return res, err
+}
 
-	var res G2Affine
-	u, err := hashToFp(msg, dst, 2)
+// EncodeToG2Trace is EncodeToG2, which also returns its intermediate values.
+func EncodeToG2Trace(msg, dst []byte) (G2Affine, G2HashTrace, error) {
+	trace, err := g2HashToCurveTrace(msg, dst, 1)
 	if err != nil {
-		return res, err
+		return G2Affine{}, trace, err
 	}
 
-	res = mapToCurve2(&fptower.E2{
-		A0: u[0],
-		A1: u[1],
-	})
-
-	//this is in an isogenous curve
-	g2Isogeny(&res)
+	res := trace.Q[0]
 	res.ClearCofactor(&res)
-	return res, nil
+	return res, trace, nil
 }
 
 // HashToG2 hashes a message to a point on the G2 curve using the SSWU map.
@@ -375,32 +418,48 @@ func EncodeToG2(msg, dst []byte) (G2Affine, error) {
 // dst stands for "domain separation tag", a string unique to the construction using the hash function
 //https://www.ietf.org/archive/id/draft-irtf-cfrg-hash-to-curve-16.html#roadmap
 func HashToG2(msg, dst []byte) (G2Affine, error) {
-	u, err := hashToFp(msg, dst, 2*2)
+	res, _, err := HashToG2Trace(msg, dst)
+	return res, err
+}
+
+// HashToG2Trace is HashToG2, which also returns its intermediate values.
+func HashToG2Trace(msg, dst []byte) (G2Affine, G2HashTrace, error) {
+	trace, err := g2HashToCurveTrace(msg, dst, 2)
 	if err != nil {
-		return G2Affine{}, err
+		return G2Affine{}, trace, err
 	}
-
-	Q0 := mapToCurve2(&fptower.E2{
-		A0: u[0],
-		A1: u[1],
-	})
-	Q1 := mapToCurve2(&fptower.E2{
-		A0: u[2+0],
-		A1: u[2+1],
-	})
-
 	//TODO (perf): Add in E' first, then apply isogeny
-	g2Isogeny(&Q0)
-	g2Isogeny(&Q1)
-
 	var _Q0, _Q1 G2Jac
-	_Q0.FromAffine(&Q0)
-	_Q1.FromAffine(&Q1).AddAssign(&_Q0)
+	_Q0.FromAffine(&trace.Q[0])
+	_Q1.FromAffine(&trace.Q[1]).AddAssign(&_Q0)
 
 	_Q1.ClearCofactor(&_Q1)
 
-	Q1.FromJacobian(&_Q1)
-	return Q1, nil
+	var res G2Affine
+	res.FromJacobian(&_Q1)
+	return res, trace, nil
+}
+
+// g2HashToCurveTrace hashes msg to count elements of the base field of G2, and maps them to the curve
+func g2HashToCurveTrace(msg, dst []byte, count int) (G2HashTrace, error) {
+	var trace G2HashTrace
+	u, err := hashToFp(msg, dst, count*2)
+	if err != nil {
+		return trace, err
+	}
+
+	trace.U = make([]fptower.E2, count)
+	trace.Q = make([]G2Affine, count)
+	for i := 0; i < count; i++ {
+		trace.U[i] = fptower.E2{
+			A0: u[i*2+0],
+			A1: u[i*2+1],
+		}
+		trace.Q[i] = mapToCurve2(&trace.U[i])
+		//this is in an isogenous curve
+		g2Isogeny(&trace.Q[i])
+	}
+	return trace, nil
 }
 
 func g2NotZero(x *fptower.E2) uint64 {
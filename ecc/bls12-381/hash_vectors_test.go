@@ -1,8 +1,10 @@
 package bls12381
 
 import (
+	"bytes"
 	"testing"
 
+	"github.com/consensys/gnark-crypto/ecc"
 	"github.com/consensys/gnark-crypto/ecc/bls12-381/fp"
 )
 
@@ -38,6 +40,24 @@ func TestG1IsogenyVectors(t *testing.T) {
 		t.Fail()
 	}
 }
+func TestVectorDST(t *testing.T) {
+	t.Parallel()
+
+	for _, c := range []struct {
+		suite string
+		dst   []byte
+	}{
+		{ecc.SuiteBLS12381G1NU, encodeToG1Vector.dst},
+		{ecc.SuiteBLS12381G1RO, hashToG1Vector.dst},
+		{ecc.SuiteBLS12381G2NU, encodeToG2Vector.dst},
+		{ecc.SuiteBLS12381G2RO, hashToG2Vector.dst},
+	} {
+		if !bytes.Equal(ecc.TestVectorDST(c.suite), c.dst) {
+			t.Fatalf("unexpected DST for %s", c.suite)
+		}
+	}
+}
+
 func init() {
 	encodeToG1Vector = encodeTestVector{
 		dst: []byte("QUUX-V01-CS02-with-BLS12381G1_XMD:SHA-256_SSWU_NU_"),
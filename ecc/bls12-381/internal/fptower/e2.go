@@ -49,9 +49,9 @@ func (z *E2) Cmp(x *E2) int {
 func (z *E2) LexicographicallyLargest() bool {
 	// adapted from github.com/zkcrypto/bls12_381
 	if z.A1.IsZero() {
-		return z.A0.LexicographicallyLargest()
+		return z.A0.IsLexLargerThanHalf()
 	}
-	return z.A1.LexicographicallyLargest()
+	return z.A1.IsLexLargerThanHalf()
 }
 
 // SetString sets a E2 element from strings
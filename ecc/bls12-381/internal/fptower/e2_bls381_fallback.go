@@ -1,5 +1,5 @@
-//go:build !amd64
-// +build !amd64
+//go:build !amd64 || purego
+// +build !amd64 purego
 
 // Copyright 2020 ConsenSys AG
 //
@@ -0,0 +1,442 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kzg4844
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"math/big"
+	"math/bits"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr/fft"
+	"github.com/consensys/gnark-crypto/internal/parallel"
+)
+
+// A blob is extended to FieldElementsPerExtBlob evaluations, on the roots of unity of
+// order FieldElementsPerExtBlob in bit-reversed order, so that the first half of the
+// extended blob is the blob itself. The extended blob is split in cells, each of which is
+// made of the evaluations on a coset of the subgroup of order FieldElementsPerCell.
+const (
+	FieldElementsPerExtBlob = 2 * FieldElementsPerBlob
+	FieldElementsPerCell    = 64
+	BytesPerCell            = FieldElementsPerCell * BytesPerFieldElement
+	CellsPerExtBlob         = FieldElementsPerExtBlob / FieldElementsPerCell
+)
+
+var (
+	ErrInvalidCellIndex  = errors.New("invalid cell index: out of range or duplicated")
+	ErrNotEnoughCells    = errors.New("not enough cells to recover the data")
+	ErrInconsistentCells = errors.New("cells are not the evaluations of a polynomial of the expected degree")
+	ErrInvalidNbBlobs    = errors.New("number of blobs should be a non-zero power of 2")
+)
+
+// domain separation tag of the cell batch verification challenge
+const randomChallengeCellBatchDomain = "RCKZGCBATCH__V1_"
+
+// Cell FieldElementsPerCell consecutive evaluations of an extended blob, in big-endian
+type Cell [BytesPerCell]byte
+
+// dasContext precomputations for cells, computed on first use
+type dasContext struct {
+	blobDomain *fft.Domain // order FieldElementsPerBlob
+	extDomain  *fft.Domain // order FieldElementsPerExtBlob
+	cellDomain *fft.Domain // order FieldElementsPerCell
+	fkDomain   *fft.Domain // order 2*FieldElementsPerBlob/FieldElementsPerCell
+
+	// monomial [αⁱ]G₁
+	monomial []bls12381.G1Affine
+
+	// fkPoints[k][r] is the k-th coefficient of the DFT of ([α^{ℓ(n-1-m)+r}]G₁)ₘ padded with
+	// zeroes, for ℓ = FieldElementsPerCell and n = FieldElementsPerBlob/ℓ, see computeCellProofs
+	fkPoints [][]bls12381.G1Affine
+}
+
+// dasContext returns the precomputations for cells. The trusted setup must contain
+// [α^FieldElementsPerCell]G₂.
+func (ctx *Context) dasContext() (*dasContext, error) {
+	if len(ctx.g2) <= FieldElementsPerCell {
+		return nil, ErrInvalidSetup
+	}
+	ctx.dasOnce.Do(func() {
+		ctx.das = newDasContext(ctx)
+	})
+	return ctx.das, nil
+}
+
+func newDasContext(ctx *Context) *dasContext {
+	const nbCosets = FieldElementsPerBlob / FieldElementsPerCell
+	d := dasContext{
+		blobDomain: fft.NewDomain(FieldElementsPerBlob),
+		extDomain:  fft.NewDomain(FieldElementsPerExtBlob),
+		cellDomain: fft.NewDomain(FieldElementsPerCell),
+		fkDomain:   fft.NewDomain(2 * nbCosets),
+		monomial:   ctx.dasG1,
+	}
+
+	// [αʲ]G₁ = ∑ᵢωⁱʲ[Lᵢ(α)]G₁
+	if d.monomial == nil {
+		points := make([]bls12381.G1Jac, FieldElementsPerBlob)
+		for i := range points {
+			points[i].FromAffine(&ctx.lagrange[i])
+		}
+		bitReverseG1(points)
+		fftG1(points, d.blobDomain.Generator)
+		d.monomial = bls12381.BatchJacobianToAffineG1(points)
+	}
+
+	var infinity bls12381.G1Affine
+	d.fkPoints = make([][]bls12381.G1Affine, 2*nbCosets)
+	for k := range d.fkPoints {
+		d.fkPoints[k] = make([]bls12381.G1Affine, FieldElementsPerCell)
+	}
+	points := make([]bls12381.G1Jac, 2*nbCosets)
+	for r := 0; r < FieldElementsPerCell; r++ {
+		for m := 0; m < nbCosets; m++ {
+			points[m].FromAffine(&d.monomial[FieldElementsPerCell*(nbCosets-1-m)+r])
+		}
+		for m := nbCosets; m < 2*nbCosets; m++ {
+			points[m].FromAffine(&infinity)
+		}
+		fftG1(points, d.fkDomain.Generator)
+		affine := bls12381.BatchJacobianToAffineG1(points)
+		for k := range affine {
+			d.fkPoints[k][r] = affine[k]
+		}
+	}
+
+	return &d
+}
+
+// ComputeCells returns the cells of the extended blob.
+func (ctx *Context) ComputeCells(blob *Blob) ([]Cell, error) {
+	d, err := ctx.dasContext()
+	if err != nil {
+		return nil, err
+	}
+	p, err := blobToPolynomial(blob)
+	if err != nil {
+		return nil, err
+	}
+	d.blobDomain.FFTInverse(p, fft.DIT)
+	return d.computeCells(p), nil
+}
+
+// ComputeCellsAndKZGProofs returns the cells of the extended blob, and the proofs that
+// they are openings of the commitment to the blob. The proofs are computed at once
+// following Feist-Khovratovich.
+func (ctx *Context) ComputeCellsAndKZGProofs(blob *Blob) ([]Cell, []Proof, error) {
+	d, err := ctx.dasContext()
+	if err != nil {
+		return nil, nil, err
+	}
+	p, err := blobToPolynomial(blob)
+	if err != nil {
+		return nil, nil, err
+	}
+	d.blobDomain.FFTInverse(p, fft.DIT)
+	proofs, err := d.computeCellProofs(p)
+	if err != nil {
+		return nil, nil, err
+	}
+	return d.computeCells(p), encodeProofs(proofs), nil
+}
+
+// VerifyCellKZGProof checks that proof attests that cell is the cell of index cellIndex of
+// the extended blob committed in commitment.
+func (ctx *Context) VerifyCellKZGProof(commitment Commitment, cellIndex uint64, cell *Cell, proof Proof) error {
+	return ctx.VerifyCellKZGProofBatch([]Commitment{commitment}, []uint64{cellIndex}, []Cell{*cell}, []Proof{proof})
+}
+
+// VerifyCellKZGProofBatch checks proofs of cells, possibly from different blobs.
+// The proofs are folded with a random linear combination, so only one pairing check is performed.
+func (ctx *Context) VerifyCellKZGProofBatch(commitments []Commitment, cellIndices []uint64, cells []Cell, proofs []Proof) error {
+	n := len(cells)
+	if len(commitments) != n || len(cellIndices) != n || len(proofs) != n {
+		return ErrInvalidBatchSize
+	}
+	if n == 0 {
+		return nil
+	}
+	d, err := ctx.dasContext()
+	if err != nil {
+		return err
+	}
+
+	c := make([]bls12381.G1Affine, n)
+	h := make([]bls12381.G1Affine, n)
+	for i := 0; i < n; i++ {
+		if cellIndices[i] >= CellsPerExtBlob {
+			return ErrInvalidCellIndex
+		}
+		if c[i], err = decodeG1(commitments[i][:]); err != nil {
+			return err
+		}
+		if h[i], err = decodeG1(proofs[i][:]); err != nil {
+			return err
+		}
+	}
+
+	// r = H(domain || n || (Cᵢ || kᵢ || cellᵢ || πᵢ)ᵢ)
+	hash := sha256.New()
+	var buf [8]byte
+	hash.Write([]byte(randomChallengeCellBatchDomain))
+	binary.BigEndian.PutUint64(buf[:], uint64(n))
+	hash.Write(buf[:])
+	for i := 0; i < n; i++ {
+		hash.Write(commitments[i][:])
+		binary.BigEndian.PutUint64(buf[:], cellIndices[i])
+		hash.Write(buf[:])
+		hash.Write(cells[i][:])
+		hash.Write(proofs[i][:])
+	}
+	var r fr.Element
+	r.SetBytes(hash.Sum(nil))
+
+	// for the cell k, on the coset hₖH where H is the subgroup of order ℓ = FieldElementsPerCell,
+	// Cₖ - [Iₖ(α)]G₁ = (αˡ-hₖˡ)πₖ where Iₖ interpolates the cell.
+	// We check e(∑rⁱπᵢ, [αˡ]G₂) == e(∑rⁱ(Cᵢ + hᵢˡπᵢ) - [∑rⁱIᵢ(α)]G₁, G₂)
+	rPowers := make([]fr.Element, n)
+	rhPowers := make([]fr.Element, n)
+	interpolation := make([]fr.Element, FieldElementsPerCell)
+	var ri fr.Element
+	ri.SetOne()
+	for i := 0; i < n; i++ {
+		rPowers[i].Set(&ri)
+
+		shift := d.cellShift(cellIndices[i])
+		coeffs, err := d.interpolateCell(&cells[i], &shift)
+		if err != nil {
+			return err
+		}
+		for j := range coeffs {
+			coeffs[j].Mul(&coeffs[j], &ri)
+			interpolation[j].Add(&interpolation[j], &coeffs[j])
+		}
+
+		for j := 0; j < bits.TrailingZeros(FieldElementsPerCell); j++ {
+			shift.Square(&shift)
+		}
+		rhPowers[i].Mul(&ri, &shift)
+		ri.Mul(&ri, &r)
+	}
+
+	config := ecc.MultiExpConfig{ScalarsMont: true}
+	var proofLincomb, proofHLincomb, cLincomb, iLincomb bls12381.G1Jac
+	if _, err := proofLincomb.MultiExp(h, rPowers, config); err != nil {
+		return err
+	}
+	if _, err := proofHLincomb.MultiExp(h, rhPowers, config); err != nil {
+		return err
+	}
+	if _, err := cLincomb.MultiExp(c, rPowers, config); err != nil {
+		return err
+	}
+	if _, err := iLincomb.MultiExp(d.monomial[:FieldElementsPerCell], interpolation, config); err != nil {
+		return err
+	}
+	cLincomb.AddAssign(&proofHLincomb).SubAssign(&iLincomb)
+
+	var p [2]bls12381.G1Affine
+	var q [2]bls12381.G2Affine
+	p[0].FromJacobian(&proofLincomb)
+	p[1].FromJacobian(&cLincomb)
+	q[0].Set(&ctx.g2[FieldElementsPerCell])
+	q[1].Neg(&ctx.g2[0])
+	ok, err := bls12381.PairingCheck(p[:], q[:])
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrVerifyProof
+	}
+	return nil
+}
+
+// computeCells returns the cells of the extended blob, from the coefficients of the blob
+func (d *dasContext) computeCells(coeffs []fr.Element) []Cell {
+	e := make([]fr.Element, FieldElementsPerExtBlob)
+	copy(e, coeffs)
+	d.extDomain.FFT(e, fft.DIF)
+	return evaluationsToCells(e)
+}
+
+// computeCellProofs returns the proofs of all the cells of the polynomial p, given by its
+// coefficients, in the order of the cells.
+//
+// The proof for the coset hH, where H is the subgroup of order ℓ = FieldElementsPerCell, is the
+// commitment to the quotient of p by Xˡ-hˡ. Following Feist-Khovratovich, it is equal to
+// ∑ₛhˡˢπₛ where πₛ = ∑ᵣ∑ₘp_{ℓ(s+1+m)+r}[α^{ℓm+r}]G₁. For each r, (πₛ)ₛ is a Toeplitz matrix-vector
+// product, which is embedded in a circulant matrix so that it is computed with FFTs, and the
+// FFTs of the points of the SRS are precomputed in fkPoints. The hˡ are the roots of unity of
+// order 2n (n = FieldElementsPerBlob/ℓ) so the proofs are the FFT of (πₛ)ₛ.
+func (d *dasContext) computeCellProofs(coeffs []fr.Element) ([]bls12381.G1Affine, error) {
+	const n = FieldElementsPerBlob / FieldElementsPerCell
+
+	// FFT of the first column of the circulant matrix, for each r
+	columns := make([][]fr.Element, FieldElementsPerCell)
+	parallel.Execute(FieldElementsPerCell, func(start, end int) {
+		for r := start; r < end; r++ {
+			columns[r] = make([]fr.Element, 2*n)
+			for j := 1; j < n; j++ {
+				columns[r][n+j] = coeffs[FieldElementsPerCell*j+r]
+			}
+			d.fkDomain.FFT(columns[r], fft.DIF)
+			fft.BitReverse(columns[r])
+			for k := range columns[r] {
+				columns[r][k].Mul(&columns[r][k], &d.fkDomain.CardinalityInv)
+			}
+		}
+	})
+
+	// ∑ᵣFFT(cᵣ)*FFT(pᵣ), then πₛ is the inverse FFT
+	h := make([]bls12381.G1Jac, 2*n)
+	scalars := make([]fr.Element, FieldElementsPerCell)
+	for k := range h {
+		for r := range scalars {
+			scalars[r] = columns[r][k]
+		}
+		if _, err := h[k].MultiExp(d.fkPoints[k], scalars, ecc.MultiExpConfig{ScalarsMont: true}); err != nil {
+			return nil, err
+		}
+	}
+	fftG1(h, d.fkDomain.GeneratorInv)
+
+	// proofs, the cells being in bit-reversed order of the cosets
+	var infinity bls12381.G1Affine
+	for s := n; s < 2*n; s++ {
+		h[s].FromAffine(&infinity)
+	}
+	fftG1(h, d.fkDomain.Generator)
+	bitReverseG1(h)
+
+	return bls12381.BatchJacobianToAffineG1(h), nil
+}
+
+// encodeProofs compresses the proofs
+func encodeProofs(points []bls12381.G1Affine) []Proof {
+	proofs := make([]Proof, len(points))
+	for k := range points {
+		proofs[k] = Proof(points[k].Bytes())
+	}
+	return proofs
+}
+
+// cellShift returns h such that the cell k holds the evaluations on hH, where H is the subgroup
+// of order FieldElementsPerCell: h = ω^brp(k) where ω is of order FieldElementsPerExtBlob.
+func (d *dasContext) cellShift(k uint64) fr.Element {
+	var h fr.Element
+	h.Exp(d.extDomain.Generator, new(big.Int).SetUint64(bitReverse(k, CellsPerExtBlob)))
+	return h
+}
+
+// interpolateCell returns the coefficients of the polynomial of degree < FieldElementsPerCell
+// which interpolates the cell on the coset shift*H. The cell holds the evaluations on
+// shift*ωʲ for ω of order FieldElementsPerCell, in bit-reversed order of j.
+func (d *dasContext) interpolateCell(cell *Cell, shift *fr.Element) ([]fr.Element, error) {
+	coeffs, err := cellToEvaluations(cell)
+	if err != nil {
+		return nil, err
+	}
+
+	// coefficients of I(shift*X), then of I
+	d.cellDomain.FFTInverse(coeffs, fft.DIT)
+	var shiftInv, s fr.Element
+	shiftInv.Inverse(shift)
+	s.SetOne()
+	for j := range coeffs {
+		coeffs[j].Mul(&coeffs[j], &s)
+		s.Mul(&s, &shiftInv)
+	}
+	return coeffs, nil
+}
+
+// cellToEvaluations decodes the field elements of a cell
+func cellToEvaluations(cell *Cell) ([]fr.Element, error) {
+	e := make([]fr.Element, FieldElementsPerCell)
+	for j := range e {
+		if err := setScalar(&e[j], cell[j*BytesPerFieldElement:(j+1)*BytesPerFieldElement]); err != nil {
+			return nil, err
+		}
+	}
+	return e, nil
+}
+
+// evaluationsToCells encodes an extended blob into cells
+func evaluationsToCells(e []fr.Element) []Cell {
+	cells := make([]Cell, len(e)/FieldElementsPerCell)
+	for i := range e {
+		b := e[i].Bytes()
+		k, j := i/FieldElementsPerCell, i%FieldElementsPerCell
+		copy(cells[k][j*BytesPerFieldElement:], b[:])
+	}
+	return cells
+}
+
+// bitReverse returns the bit-reversal of i, of order n a power of 2
+func bitReverse(i, n uint64) uint64 {
+	if n <= 1 {
+		return 0
+	}
+	return bits.Reverse64(i) >> (64 - bits.TrailingZeros64(n))
+}
+
+// bitReverseG1 permutes a in bit-reversed order
+func bitReverseG1(a []bls12381.G1Jac) {
+	n := uint64(len(a))
+	for i := uint64(0); i < n; i++ {
+		irev := bitReverse(i, n)
+		if irev > i {
+			a[i], a[irev] = a[irev], a[i]
+		}
+	}
+}
+
+// fftG1 computes in place the discrete Fourier transform of a, in G₁,
+// where omega is a root of unity of order len(a): a[i] ← ∑ⱼωⁱʲa[j].
+// Inputs and outputs are in natural order.
+func fftG1(a []bls12381.G1Jac, omega fr.Element) {
+
+	n := len(a)
+	bitReverseG1(a)
+
+	for m := 2; m <= n; m <<= 1 {
+
+		// twiddle factors for this stage, wm is of order m
+		half := m / 2
+		var wm, w fr.Element
+		wm.Exp(omega, big.NewInt(int64(n/m)))
+		w.SetOne()
+		twiddles := make([]big.Int, half)
+		for k := 0; k < half; k++ {
+			w.ToBigIntRegular(&twiddles[k])
+			w.Mul(&w, &wm)
+		}
+
+		parallel.Execute(n/2, func(start, end int) {
+			var t bls12381.G1Jac
+			for idx := start; idx < end; idx++ {
+				i := (idx/half)*m + idx%half
+				j := i + half
+				t.ScalarMultiplication(&a[j], &twiddles[idx%half])
+				a[j].Set(&a[i]).SubAssign(&t)
+				a[i].AddAssign(&t)
+			}
+		})
+	}
+}
@@ -0,0 +1,264 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kzg4844
+
+import (
+	"bytes"
+	"math/rand"
+	"testing"
+)
+
+func TestComputeCellsAndKZGProofs(t *testing.T) {
+
+	blob := randomBlob()
+	commitment, err := testContext.BlobToKZGCommitment(blob)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cells, proofs, err := testContext.ComputeCellsAndKZGProofs(blob)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cells) != CellsPerExtBlob || len(proofs) != CellsPerExtBlob {
+		t.Fatal("wrong number of cells")
+	}
+
+	// the first half of the extended blob is the blob
+	for k := 0; k < CellsPerExtBlob/2; k++ {
+		if !bytes.Equal(cells[k][:], blob[k*BytesPerCell:(k+1)*BytesPerCell]) {
+			t.Fatal("the extended blob should start with the blob")
+		}
+	}
+	other, err := testContext.ComputeCells(blob)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for k := range cells {
+		if cells[k] != other[k] {
+			t.Fatal("ComputeCells and ComputeCellsAndKZGProofs don't match")
+		}
+	}
+
+	// each proof matches its cell
+	for _, k := range []uint64{0, 1, 77, CellsPerExtBlob - 1} {
+		if err := testContext.VerifyCellKZGProof(commitment, k, &cells[k], proofs[k]); err != nil {
+			t.Fatal(err)
+		}
+		if err := testContext.VerifyCellKZGProof(commitment, (k+1)%CellsPerExtBlob, &cells[k], proofs[k]); err != ErrVerifyProof {
+			t.Fatal("verifying a cell at a wrong index should fail")
+		}
+	}
+	if err := testContext.VerifyCellKZGProof(commitment, CellsPerExtBlob, &cells[0], proofs[0]); err != ErrInvalidCellIndex {
+		t.Fatal("expected ErrInvalidCellIndex")
+	}
+
+	// batch, with cells from two blobs
+	otherBlob := randomBlob()
+	otherCommitment, err := testContext.BlobToKZGCommitment(otherBlob)
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherCells, otherProofs, err := testContext.ComputeCellsAndKZGProofs(otherBlob)
+	if err != nil {
+		t.Fatal(err)
+	}
+	commitments := []Commitment{commitment, otherCommitment, commitment}
+	indices := []uint64{3, 5, 100}
+	batchCells := []Cell{cells[3], otherCells[5], cells[100]}
+	batchProofs := []Proof{proofs[3], otherProofs[5], proofs[100]}
+	if err := testContext.VerifyCellKZGProofBatch(commitments, indices, batchCells, batchProofs); err != nil {
+		t.Fatal(err)
+	}
+	batchCells[2][0] ^= 1
+	if err := testContext.VerifyCellKZGProofBatch(commitments, indices, batchCells, batchProofs); err != ErrVerifyProof {
+		t.Fatal("verifying a wrong cell should fail")
+	}
+}
+
+func TestMonomialFromLagrange(t *testing.T) {
+	ctx, err := NewContext(&TrustedSetup{G1Lagrange: testSetup.G1Lagrange, G2Monomial: testSetup.G2Monomial})
+	if err != nil {
+		t.Fatal(err)
+	}
+	d, err := ctx.dasContext()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range testSetup.G1Monomial {
+		if !d.monomial[i].Equal(&testSetup.G1Monomial[i]) {
+			t.Fatal("wrong monomial point")
+		}
+	}
+
+	// [α^FieldElementsPerCell]G₂ is needed for the cells
+	ctx, err = NewContext(&TrustedSetup{G1Lagrange: testSetup.G1Lagrange, G2Monomial: testSetup.G2Monomial[:2]})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ctx.ComputeCells(randomBlob()); err != ErrInvalidSetup {
+		t.Fatal("expected ErrInvalidSetup")
+	}
+}
+
+func TestRecoverCellsAndKZGProofs(t *testing.T) {
+
+	blob := randomBlob()
+	cells, proofs, err := testContext.ComputeCellsAndKZGProofs(blob)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// keep half of the cells
+	indices := rand.Perm(CellsPerExtBlob)[:CellsPerExtBlob/2]
+	cellIndices := make([]uint64, len(indices))
+	samples := make([]Cell, len(indices))
+	for i, k := range indices {
+		cellIndices[i] = uint64(k)
+		samples[i] = cells[k]
+	}
+	recovered, recoveredProofs, err := testContext.RecoverCellsAndKZGProofs(cellIndices, samples)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for k := range cells {
+		if recovered[k] != cells[k] || recoveredProofs[k] != proofs[k] {
+			t.Fatal("wrong recovered cell")
+		}
+	}
+
+	if _, _, err := testContext.RecoverCellsAndKZGProofs(cellIndices[1:], samples[1:]); err != ErrNotEnoughCells {
+		t.Fatal("expected ErrNotEnoughCells")
+	}
+	cellIndices[1] = cellIndices[0]
+	if _, _, err := testContext.RecoverCellsAndKZGProofs(cellIndices, samples); err != ErrInvalidCellIndex {
+		t.Fatal("expected ErrInvalidCellIndex")
+	}
+
+	// with more than half of the cells, inconsistent cells are detected
+	indices = rand.Perm(CellsPerExtBlob)[:CellsPerExtBlob/2+1]
+	cellIndices = make([]uint64, len(indices))
+	samples = make([]Cell, len(indices))
+	for i, k := range indices {
+		cellIndices[i] = uint64(k)
+		samples[i] = cells[k]
+	}
+	samples[0][BytesPerCell-1] ^= 1
+	if _, _, err := testContext.RecoverCellsAndKZGProofs(cellIndices, samples); err != ErrInconsistentCells {
+		t.Fatal("expected ErrInconsistentCells")
+	}
+}
+
+func TestExtendBlobs(t *testing.T) {
+
+	const nbBlobs = 2
+	blobs := make([]Blob, nbBlobs)
+	for i := range blobs {
+		blobs[i] = *randomBlob()
+	}
+	extended, err := testContext.ExtendBlobs(blobs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(extended.Cells) != 2*nbBlobs || len(extended.Commitments) != 2*nbBlobs || len(extended.Proofs) != 2*nbBlobs {
+		t.Fatal("wrong number of rows")
+	}
+
+	// the first rows are the extended blobs
+	for i := range blobs {
+		commitment, err := testContext.BlobToKZGCommitment(&blobs[i])
+		if err != nil {
+			t.Fatal(err)
+		}
+		cells, proofs, err := testContext.ComputeCellsAndKZGProofs(&blobs[i])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if commitment != extended.Commitments[i] {
+			t.Fatal("wrong commitment")
+		}
+		for k := range cells {
+			if cells[k] != extended.Cells[i][k] || proofs[k] != extended.Proofs[i][k] {
+				t.Fatal("wrong cell")
+			}
+		}
+	}
+
+	// the cells of the extension rows verify against the extended commitments
+	var commitments []Commitment
+	var indices []uint64
+	var cells []Cell
+	var proofs []Proof
+	for i := nbBlobs; i < 2*nbBlobs; i++ {
+		for _, k := range []uint64{0, 42, CellsPerExtBlob - 1} {
+			commitments = append(commitments, extended.Commitments[i])
+			indices = append(indices, k)
+			cells = append(cells, extended.Cells[i][k])
+			proofs = append(proofs, extended.Proofs[i][k])
+		}
+	}
+	if err := testContext.VerifyCellKZGProofBatch(commitments, indices, cells, proofs); err != nil {
+		t.Fatal(err)
+	}
+
+	// erase most of the cells of the first rows, which can only be recovered through the columns,
+	// once the other rows are recovered
+	grid := make([][]Cell, 2*nbBlobs)
+	available := make([][]bool, 2*nbBlobs)
+	for i := range grid {
+		grid[i] = make([]Cell, CellsPerExtBlob)
+		available[i] = make([]bool, CellsPerExtBlob)
+		for k := range grid[i] {
+			if (i < nbBlobs) == (k >= CellsPerExtBlob/4*3) {
+				grid[i][k] = extended.Cells[i][k]
+				available[i][k] = true
+			}
+		}
+	}
+	if err := testContext.RecoverExtendedBlobs(grid, available); err != nil {
+		t.Fatal(err)
+	}
+	for i := range grid {
+		for k := range grid[i] {
+			if !available[i][k] || grid[i][k] != extended.Cells[i][k] {
+				t.Fatal("wrong recovered cell")
+			}
+		}
+	}
+
+	// not enough cells
+	for i := range grid {
+		for k := range grid[i] {
+			available[i][k] = i == 0
+		}
+	}
+	if err := testContext.RecoverExtendedBlobs(grid, available); err != ErrNotEnoughCells {
+		t.Fatal("expected ErrNotEnoughCells")
+	}
+
+	if _, err := testContext.ExtendBlobs(blobs[:0]); err != ErrInvalidNbBlobs {
+		t.Fatal("expected ErrInvalidNbBlobs")
+	}
+}
+
+func BenchmarkComputeCellsAndKZGProofs(b *testing.B) {
+	blob := randomBlob()
+	if _, err := testContext.dasContext(); err != nil {
+		b.Fatal(err)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _, _ = testContext.ComputeCellsAndKZGProofs(blob)
+	}
+}
@@ -0,0 +1,32 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package kzg4844 implements the KZG commitments to blobs of EIP-4844, on bls12-381.
+//
+// It follows the polynomial commitments of the Deneb consensus specifications, so that
+// commitments, proofs and versioned hashes are byte-compatible with c-kzg-4844:
+// a blob is a vector of 4096 field elements encoded in big-endian, which are the
+// evaluations of a polynomial on the 4096-th roots of unity in bit-reversed order.
+// Commitments and proofs are compressed G₁ points, as specified by ZCash.
+//
+// For data availability sampling, blobs are extended with a Reed-Solomon code and split in
+// cells which can be verified independently (see ComputeCellsAndKZGProofs), and recovered
+// from half of them. ExtendBlobs extends several blobs in a 2D grid of cells.
+//
+// The trusted setup is the output of the Ethereum KZG ceremony, either in the text format
+// of c-kzg-4844 (see ReadTrustedSetup) or in the JSON format of the consensus
+// specifications (see ReadTrustedSetupJSON).
+//
+// See https://eips.ethereum.org/EIPS/eip-4844
+package kzg4844
@@ -0,0 +1,505 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kzg4844
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"math/big"
+	"math/bits"
+	"sync"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fp"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+)
+
+const (
+	FieldElementsPerBlob    = 4096
+	BytesPerFieldElement    = fr.Bytes
+	BytesPerBlob            = FieldElementsPerBlob * BytesPerFieldElement
+	BytesPerCommitment      = bls12381.SizeOfG1AffineCompressed
+	BytesPerProof           = bls12381.SizeOfG1AffineCompressed
+	VersionedHashVersionKZG = 0x01
+)
+
+var (
+	ErrInvalidFieldElement = errors.New("invalid field element: not in canonical form")
+	ErrInvalidG1Point      = errors.New("invalid G1 point: not a compressed point in the subgroup")
+	ErrInvalidBatchSize    = errors.New("number of blobs, commitments and proofs should be the same")
+	ErrInvalidSetup        = errors.New("invalid trusted setup")
+	ErrVerifyProof         = errors.New("can't verify KZG proof")
+)
+
+// domain separation tags of the Fiat-Shamir challenges, and generator of the multiplicative group of fr
+const (
+	fiatShamirProtocolDomain   = "FSBLOBVERIFY_V1_"
+	randomChallengeBatchDomain = "RCKZGBATCH___V1_"
+	primitiveRootOfUnity       = 7
+)
+
+// Blob data of a blob transaction, made of FieldElementsPerBlob field elements in big-endian
+type Blob [BytesPerBlob]byte
+
+// Commitment KZG commitment to a blob, a compressed G₁ point
+type Commitment [BytesPerCommitment]byte
+
+// Proof KZG opening proof, a compressed G₁ point
+type Proof [BytesPerProof]byte
+
+// Scalar field element in big-endian
+type Scalar [BytesPerFieldElement]byte
+
+// VersionedHash hash of a commitment, as referenced in a blob transaction
+type VersionedHash [32]byte
+
+// VersionedHash returns VersionedHashVersionKZG || sha256(c)[1:]
+func (c *Commitment) VersionedHash() VersionedHash {
+	res := VersionedHash(sha256.Sum256(c[:]))
+	res[0] = VersionedHashVersionKZG
+	return res
+}
+
+// Context holds the trusted setup, prepared to commit to and open blobs.
+type Context struct {
+	lagrange []bls12381.G1Affine // [Lᵢ(α)]G₁ in bit-reversed order
+	roots    []fr.Element        // 4096-th roots of unity in bit-reversed order
+	g1       bls12381.G1Affine   // G₁
+	g2       []bls12381.G2Affine // [G₂, [α]G₂, [α²]G₂, ...]
+
+	// precomputations for cells, see das.go
+	das     *dasContext
+	dasOnce sync.Once
+	dasG1   []bls12381.G1Affine // [αⁱ]G₁ from the trusted setup, if provided
+}
+
+// NewContext returns a Context from the trusted setup.
+func NewContext(ts *TrustedSetup) (*Context, error) {
+	if len(ts.G1Lagrange) != FieldElementsPerBlob || len(ts.G2Monomial) < 2 {
+		return nil, ErrInvalidSetup
+	}
+
+	var ctx Context
+	_, _, ctx.g1, _ = bls12381.Generators()
+	ctx.g2 = make([]bls12381.G2Affine, len(ts.G2Monomial))
+	copy(ctx.g2, ts.G2Monomial)
+	if len(ts.G1Monomial) != 0 {
+		if len(ts.G1Monomial) != FieldElementsPerBlob {
+			return nil, ErrInvalidSetup
+		}
+		ctx.dasG1 = make([]bls12381.G1Affine, FieldElementsPerBlob)
+		copy(ctx.dasG1, ts.G1Monomial)
+	}
+
+	// ω = 7^((r-1)/4096)
+	var omega fr.Element
+	e := fr.Modulus()
+	e.Sub(e, big.NewInt(1)).Div(e, big.NewInt(FieldElementsPerBlob))
+	omega.SetUint64(primitiveRootOfUnity).Exp(omega, e)
+
+	ctx.roots = make([]fr.Element, FieldElementsPerBlob)
+	ctx.lagrange = make([]bls12381.G1Affine, FieldElementsPerBlob)
+	ctx.roots[0].SetOne()
+	for i := 1; i < FieldElementsPerBlob; i++ {
+		ctx.roots[i].Mul(&ctx.roots[i-1], &omega)
+	}
+	copy(ctx.lagrange, ts.G1Lagrange)
+
+	nn := uint64(64 - bits.TrailingZeros64(FieldElementsPerBlob))
+	for i := 0; i < FieldElementsPerBlob; i++ {
+		irev := int(bits.Reverse64(uint64(i)) >> nn)
+		if irev > i {
+			ctx.roots[i], ctx.roots[irev] = ctx.roots[irev], ctx.roots[i]
+			ctx.lagrange[i], ctx.lagrange[irev] = ctx.lagrange[irev], ctx.lagrange[i]
+		}
+	}
+
+	return &ctx, nil
+}
+
+// BlobToKZGCommitment returns the commitment to the polynomial whose evaluations are the blob.
+func (ctx *Context) BlobToKZGCommitment(blob *Blob) (Commitment, error) {
+	p, err := blobToPolynomial(blob)
+	if err != nil {
+		return Commitment{}, err
+	}
+	c, err := ctx.commit(p)
+	if err != nil {
+		return Commitment{}, err
+	}
+	return Commitment(c.Bytes()), nil
+}
+
+// ComputeKZGProof returns the opening proof of the blob at z, and the claimed value y.
+func (ctx *Context) ComputeKZGProof(blob *Blob, z Scalar) (Proof, Scalar, error) {
+	p, err := blobToPolynomial(blob)
+	if err != nil {
+		return Proof{}, Scalar{}, err
+	}
+	var _z fr.Element
+	if err := setScalar(&_z, z[:]); err != nil {
+		return Proof{}, Scalar{}, err
+	}
+	proof, y, err := ctx.open(p, _z)
+	if err != nil {
+		return Proof{}, Scalar{}, err
+	}
+	return Proof(proof.Bytes()), Scalar(y.Bytes()), nil
+}
+
+// ComputeBlobKZGProof returns the opening proof of the blob at the Fiat-Shamir challenge
+// derived from the blob and its commitment.
+func (ctx *Context) ComputeBlobKZGProof(blob *Blob, commitment Commitment) (Proof, error) {
+	if _, err := decodeG1(commitment[:]); err != nil {
+		return Proof{}, err
+	}
+	p, err := blobToPolynomial(blob)
+	if err != nil {
+		return Proof{}, err
+	}
+	z := computeChallenge(blob, &commitment)
+	proof, _, err := ctx.open(p, z)
+	if err != nil {
+		return Proof{}, err
+	}
+	return Proof(proof.Bytes()), nil
+}
+
+// VerifyKZGProof checks that proof attests that the polynomial committed in commitment
+// evaluates to y at z.
+func (ctx *Context) VerifyKZGProof(commitment Commitment, z, y Scalar, proof Proof) error {
+	c, err := decodeG1(commitment[:])
+	if err != nil {
+		return err
+	}
+	var _z, _y fr.Element
+	if err := setScalar(&_z, z[:]); err != nil {
+		return err
+	}
+	if err := setScalar(&_y, y[:]); err != nil {
+		return err
+	}
+	h, err := decodeG1(proof[:])
+	if err != nil {
+		return err
+	}
+	return ctx.verify(&c, _z, _y, &h)
+}
+
+// VerifyBlobKZGProof checks a proof computed by ComputeBlobKZGProof.
+func (ctx *Context) VerifyBlobKZGProof(blob *Blob, commitment Commitment, proof Proof) error {
+	c, err := decodeG1(commitment[:])
+	if err != nil {
+		return err
+	}
+	p, err := blobToPolynomial(blob)
+	if err != nil {
+		return err
+	}
+	h, err := decodeG1(proof[:])
+	if err != nil {
+		return err
+	}
+	z := computeChallenge(blob, &commitment)
+	y := ctx.evaluate(p, z)
+	return ctx.verify(&c, z, y, &h)
+}
+
+// VerifyBlobKZGProofBatch checks proofs computed by ComputeBlobKZGProof. The proofs are
+// folded with a random linear combination, so only one pairing check is performed.
+func (ctx *Context) VerifyBlobKZGProofBatch(blobs []Blob, commitments []Commitment, proofs []Proof) error {
+	n := len(blobs)
+	if len(commitments) != n || len(proofs) != n {
+		return ErrInvalidBatchSize
+	}
+	if n == 0 {
+		return nil
+	}
+
+	c := make([]bls12381.G1Affine, n)
+	h := make([]bls12381.G1Affine, n)
+	z := make([]fr.Element, n)
+	y := make([]fr.Element, n)
+	for i := 0; i < n; i++ {
+		var err error
+		if c[i], err = decodeG1(commitments[i][:]); err != nil {
+			return err
+		}
+		p, err := blobToPolynomial(&blobs[i])
+		if err != nil {
+			return err
+		}
+		if h[i], err = decodeG1(proofs[i][:]); err != nil {
+			return err
+		}
+		z[i] = computeChallenge(&blobs[i], &commitments[i])
+		y[i] = ctx.evaluate(p, z[i])
+	}
+
+	// r = H(domain || 4096 || n || (Cᵢ || zᵢ || yᵢ || πᵢ)ᵢ)
+	data := make([]byte, 0, len(randomChallengeBatchDomain)+16+n*(2*BytesPerCommitment+2*BytesPerFieldElement))
+	data = append(data, randomChallengeBatchDomain...)
+	var buf [8]byte
+	binary.BigEndian.PutUint64(buf[:], FieldElementsPerBlob)
+	data = append(data, buf[:]...)
+	binary.BigEndian.PutUint64(buf[:], uint64(n))
+	data = append(data, buf[:]...)
+	for i := 0; i < n; i++ {
+		zb, yb := z[i].Bytes(), y[i].Bytes()
+		data = append(data, commitments[i][:]...)
+		data = append(data, zb[:]...)
+		data = append(data, yb[:]...)
+		data = append(data, proofs[i][:]...)
+	}
+	r := hashToField(data)
+
+	// ∑rⁱπᵢ, ∑rⁱzᵢπᵢ and ∑rⁱ(Cᵢ-[yᵢ]G₁)
+	rPowers := make([]fr.Element, n)
+	rzPowers := make([]fr.Element, n)
+	rPowers[0].SetOne()
+	for i := 1; i < n; i++ {
+		rPowers[i].Mul(&rPowers[i-1], &r)
+	}
+	// ∑rⁱ(Cᵢ-[yᵢ]G₁) = ∑rⁱCᵢ - [∑rⁱyᵢ]G₁
+	var ry, t fr.Element
+	for i := 0; i < n; i++ {
+		rzPowers[i].Mul(&rPowers[i], &z[i])
+		t.Mul(&rPowers[i], &y[i])
+		ry.Add(&ry, &t)
+	}
+
+	config := ecc.MultiExpConfig{ScalarsMont: true}
+	var proofLincomb, proofZLincomb, cLincomb, yG1 bls12381.G1Jac
+	if _, err := proofLincomb.MultiExp(h, rPowers, config); err != nil {
+		return err
+	}
+	if _, err := proofZLincomb.MultiExp(h, rzPowers, config); err != nil {
+		return err
+	}
+	if _, err := cLincomb.MultiExp(c, rPowers, config); err != nil {
+		return err
+	}
+	var bRy big.Int
+	ry.ToBigIntRegular(&bRy)
+	yG1.ScalarMultiplicationAffine(&ctx.g1, &bRy)
+	cLincomb.SubAssign(&yG1).AddAssign(&proofZLincomb)
+
+	// e(∑rⁱπᵢ, -[α]G₂)e(∑rⁱ(Cᵢ-[yᵢ]G₁+[zᵢ]πᵢ), G₂) == 1
+	var p [2]bls12381.G1Affine
+	var q [2]bls12381.G2Affine
+	p[0].FromJacobian(&proofLincomb)
+	p[1].FromJacobian(&cLincomb)
+	q[0].Neg(&ctx.g2[1])
+	q[1].Set(&ctx.g2[0])
+	ok, err := bls12381.PairingCheck(p[:], q[:])
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrVerifyProof
+	}
+	return nil
+}
+
+// commit returns ∑pᵢ[Lᵢ(α)]G₁
+func (ctx *Context) commit(p []fr.Element) (bls12381.G1Affine, error) {
+	var res bls12381.G1Affine
+	if _, err := res.MultiExp(ctx.lagrange, p, ecc.MultiExpConfig{ScalarsMont: true}); err != nil {
+		return res, err
+	}
+	return res, nil
+}
+
+// open returns the commitment to the quotient q = (p-y)/(X-z) where y = p(z),
+// computed in Lagrange form, and y.
+func (ctx *Context) open(p []fr.Element, z fr.Element) (bls12381.G1Affine, fr.Element, error) {
+	y := ctx.evaluate(p, z)
+
+	// 1/(ωᵢ-z), where at most one of the denominators is zero
+	denominators := make([]fr.Element, FieldElementsPerBlob)
+	m := -1
+	for i := 0; i < FieldElementsPerBlob; i++ {
+		denominators[i].Sub(&ctx.roots[i], &z)
+		if denominators[i].IsZero() {
+			m = i
+		}
+	}
+	denominators = fr.BatchInvert(denominators)
+
+	// qᵢ = (pᵢ-y)/(ωᵢ-z)
+	q := make([]fr.Element, FieldElementsPerBlob)
+	for i := 0; i < FieldElementsPerBlob; i++ {
+		q[i].Sub(&p[i], &y).Mul(&q[i], &denominators[i])
+	}
+
+	// if z = ωₘ, qₘ = ∑_{i≠m}(pᵢ-y)ωᵢ/(z(z-ωᵢ)) = -1/z*∑_{i≠m}qᵢωᵢ
+	if m >= 0 {
+		var qm, t fr.Element
+		for i := 0; i < FieldElementsPerBlob; i++ {
+			t.Mul(&q[i], &ctx.roots[i])
+			qm.Sub(&qm, &t)
+		}
+		t.Inverse(&z)
+		q[m].Mul(&qm, &t)
+	}
+
+	h, err := ctx.commit(q)
+	return h, y, err
+}
+
+// evaluate returns p(z) with the barycentric formula p(z) = (zⁿ-1)/n*∑pᵢωᵢ/(z-ωᵢ)
+func (ctx *Context) evaluate(p []fr.Element, z fr.Element) fr.Element {
+	denominators := make([]fr.Element, FieldElementsPerBlob)
+	for i := 0; i < FieldElementsPerBlob; i++ {
+		if ctx.roots[i].Equal(&z) {
+			return p[i]
+		}
+		denominators[i].Sub(&z, &ctx.roots[i])
+	}
+	denominators = fr.BatchInvert(denominators)
+
+	var res, t fr.Element
+	for i := 0; i < FieldElementsPerBlob; i++ {
+		t.Mul(&p[i], &ctx.roots[i]).Mul(&t, &denominators[i])
+		res.Add(&res, &t)
+	}
+
+	// (zⁿ-1)/n
+	var one, n fr.Element
+	one.SetOne()
+	n.SetUint64(FieldElementsPerBlob).Inverse(&n)
+	t.Set(&z)
+	for i := 1; i < FieldElementsPerBlob; i <<= 1 {
+		t.Square(&t)
+	}
+	t.Sub(&t, &one)
+	res.Mul(&res, &t).Mul(&res, &n)
+
+	return res
+}
+
+// verify checks that e(C-[y]G₁, -G₂)e(π, [α]G₂-[z]G₂) == 1
+func (ctx *Context) verify(commitment *bls12381.G1Affine, z, y fr.Element, proof *bls12381.G1Affine) error {
+	var bz, by big.Int
+	z.ToBigIntRegular(&bz)
+	y.ToBigIntRegular(&by)
+
+	var p [2]bls12381.G1Affine
+	var q [2]bls12381.G2Affine
+	p[0].ScalarMultiplication(&ctx.g1, &by)
+	p[0].Sub(commitment, &p[0])
+	p[1].Set(proof)
+	q[0].Neg(&ctx.g2[0])
+	q[1].ScalarMultiplication(&ctx.g2[0], &bz)
+	q[1].Sub(&ctx.g2[1], &q[1])
+
+	ok, err := bls12381.PairingCheck(p[:], q[:])
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrVerifyProof
+	}
+	return nil
+}
+
+// computeChallenge returns H(domain || 4096 (on 16 bytes) || blob || commitment)
+func computeChallenge(blob *Blob, commitment *Commitment) fr.Element {
+	data := make([]byte, 0, len(fiatShamirProtocolDomain)+16+BytesPerBlob+BytesPerCommitment)
+	data = append(data, fiatShamirProtocolDomain...)
+	var degree [16]byte
+	binary.BigEndian.PutUint64(degree[8:], FieldElementsPerBlob)
+	data = append(data, degree[:]...)
+	data = append(data, blob[:]...)
+	data = append(data, commitment[:]...)
+	return hashToField(data)
+}
+
+// hashToField returns sha256(data) mod r
+func hashToField(data []byte) fr.Element {
+	h := sha256.Sum256(data)
+	var res fr.Element
+	res.SetBytes(h[:])
+	return res
+}
+
+// blobToPolynomial decodes the field elements of the blob
+func blobToPolynomial(blob *Blob) ([]fr.Element, error) {
+	p := make([]fr.Element, FieldElementsPerBlob)
+	for i := 0; i < FieldElementsPerBlob; i++ {
+		if err := setScalar(&p[i], blob[i*BytesPerFieldElement:(i+1)*BytesPerFieldElement]); err != nil {
+			return nil, err
+		}
+	}
+	return p, nil
+}
+
+// setScalar sets z from a big-endian encoding, which must be strictly smaller than r
+func setScalar(z *fr.Element, buf []byte) error {
+	var v big.Int
+	v.SetBytes(buf)
+	if v.Cmp(frModulus) >= 0 {
+		return ErrInvalidFieldElement
+	}
+	z.SetBigInt(&v)
+	return nil
+}
+
+// decodeG1 decodes a compressed G₁ point. Contrary to G1Affine.SetBytes, it rejects
+// uncompressed points, non-canonical encodings of the point at infinity and of x,
+// as c-kzg-4844 does.
+func decodeG1(buf []byte) (bls12381.G1Affine, error) {
+	var p bls12381.G1Affine
+	if len(buf) != bls12381.SizeOfG1AffineCompressed || buf[0]&mCompressed == 0 {
+		return p, ErrInvalidG1Point
+	}
+
+	if buf[0]&mInfinity != 0 {
+		if buf[0] != mCompressed|mInfinity {
+			return p, ErrInvalidG1Point
+		}
+		for i := 1; i < len(buf); i++ {
+			if buf[i] != 0 {
+				return p, ErrInvalidG1Point
+			}
+		}
+		return p, nil
+	}
+
+	var x big.Int
+	x.SetBytes(buf)
+	x.SetBit(&x, 8*len(buf)-1, 0).SetBit(&x, 8*len(buf)-3, 0) // compression and sign flags
+	if x.Cmp(fpModulus) >= 0 {
+		return p, ErrInvalidG1Point
+	}
+
+	if _, err := p.SetBytes(buf); err != nil {
+		return p, ErrInvalidG1Point
+	}
+	return p, nil
+}
+
+// flags in the most significant byte of a compressed point
+const (
+	mCompressed byte = 0b100 << 5
+	mInfinity   byte = 0b010 << 5
+)
+
+var (
+	frModulus = fr.Modulus()
+	fpModulus = fp.Modulus()
+)
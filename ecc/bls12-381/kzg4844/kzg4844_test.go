@@ -0,0 +1,384 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kzg4844
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+)
+
+// testAlpha secret of the test setup
+const testAlpha = 42
+
+var (
+	testSetup   *TrustedSetup
+	testContext *Context
+)
+
+func init() {
+	var err error
+	testSetup = newTestSetup(testAlpha)
+	testContext, err = NewContext(testSetup)
+	if err != nil {
+		panic(err)
+	}
+}
+
+// newTestSetup returns a trusted setup with a known secret α: [Lᵢ(α)]G₁ = [ωⁱ(αⁿ-1)/(n(α-ωⁱ))]G₁
+func newTestSetup(alpha int64) *TrustedSetup {
+	var omega, a, an, one fr.Element
+	e := fr.Modulus()
+	e.Sub(e, big.NewInt(1)).Div(e, big.NewInt(FieldElementsPerBlob))
+	omega.SetUint64(primitiveRootOfUnity).Exp(omega, e)
+	a.SetInt64(alpha)
+	one.SetOne()
+	an.Exp(a, big.NewInt(FieldElementsPerBlob)).Sub(&an, &one)
+
+	scalars := make([]fr.Element, FieldElementsPerBlob)
+	denominators := make([]fr.Element, FieldElementsPerBlob)
+	var w, n fr.Element
+	w.SetOne()
+	n.SetUint64(FieldElementsPerBlob)
+	for i := 0; i < FieldElementsPerBlob; i++ {
+		scalars[i].Mul(&w, &an)
+		denominators[i].Sub(&a, &w).Mul(&denominators[i], &n)
+		w.Mul(&w, &omega)
+	}
+	denominators = fr.BatchInvert(denominators)
+	for i := range scalars {
+		scalars[i].Mul(&scalars[i], &denominators[i]).FromMont()
+	}
+
+	_, _, g1, g2 := bls12381.Generators()
+	var ts TrustedSetup
+	ts.G1Lagrange = bls12381.BatchScalarMultiplicationG1(&g1, scalars)
+
+	// [αⁱ]G₁ and [αⁱ]G₂
+	scalars[0].SetOne()
+	for i := 1; i < len(scalars); i++ {
+		scalars[i].Mul(&scalars[i-1], &a)
+	}
+	ts.G2Monomial = make([]bls12381.G2Affine, FieldElementsPerCell+1)
+	for i := range ts.G2Monomial {
+		var s big.Int
+		scalars[i].ToBigIntRegular(&s)
+		ts.G2Monomial[i].ScalarMultiplication(&g2, &s)
+	}
+	for i := range scalars {
+		scalars[i].FromMont()
+	}
+	ts.G1Monomial = bls12381.BatchScalarMultiplicationG1(&g1, scalars)
+
+	return &ts
+}
+
+func randomBlob() *Blob {
+	var blob Blob
+	for i := 0; i < FieldElementsPerBlob; i++ {
+		var e fr.Element
+		e.SetRandom()
+		b := e.Bytes()
+		copy(blob[i*BytesPerFieldElement:], b[:])
+	}
+	return &blob
+}
+
+func randomScalar() Scalar {
+	var e fr.Element
+	e.SetRandom()
+	return Scalar(e.Bytes())
+}
+
+func TestTrustedSetup(t *testing.T) {
+
+	// text format
+	var buf bytes.Buffer
+	written, err := testSetup.WriteTo(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if written != int64(buf.Len()) {
+		t.Fatal("inconsistent number of bytes written")
+	}
+	ts, err := ReadTrustedSetup(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !equalSetups(ts, testSetup) {
+		t.Fatal("text format: setups don't match")
+	}
+
+	// json format
+	var js struct {
+		G1Lagrange []string `json:"g1_lagrange"`
+		G2Monomial []string `json:"g2_monomial"`
+		G1Monomial []string `json:"g1_monomial"`
+	}
+	for i := range testSetup.G1Lagrange {
+		b := testSetup.G1Lagrange[i].Bytes()
+		js.G1Lagrange = append(js.G1Lagrange, "0x"+hex.EncodeToString(b[:]))
+	}
+	for i := range testSetup.G2Monomial {
+		b := testSetup.G2Monomial[i].Bytes()
+		js.G2Monomial = append(js.G2Monomial, "0x"+hex.EncodeToString(b[:]))
+	}
+	for i := range testSetup.G1Monomial {
+		b := testSetup.G1Monomial[i].Bytes()
+		js.G1Monomial = append(js.G1Monomial, "0x"+hex.EncodeToString(b[:]))
+	}
+	data, err := json.Marshal(js)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ts, err = ReadTrustedSetupJSON(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !equalSetups(ts, testSetup) {
+		t.Fatal("json format: setups don't match")
+	}
+
+	// wrong number of points
+	if _, err := ReadTrustedSetup(bytes.NewReader([]byte("4095\n65\n"))); err != ErrInvalidSetup {
+		t.Fatal("expected ErrInvalidSetup")
+	}
+	if _, err := NewContext(&TrustedSetup{G1Lagrange: testSetup.G1Lagrange}); err != ErrInvalidSetup {
+		t.Fatal("expected ErrInvalidSetup")
+	}
+}
+
+func equalSetups(a, b *TrustedSetup) bool {
+	if len(a.G1Lagrange) != len(b.G1Lagrange) || len(a.G2Monomial) != len(b.G2Monomial) || len(a.G1Monomial) != len(b.G1Monomial) {
+		return false
+	}
+	for i := range a.G1Monomial {
+		if !a.G1Monomial[i].Equal(&b.G1Monomial[i]) {
+			return false
+		}
+	}
+	for i := range a.G1Lagrange {
+		if !a.G1Lagrange[i].Equal(&b.G1Lagrange[i]) {
+			return false
+		}
+	}
+	for i := range a.G2Monomial {
+		if !a.G2Monomial[i].Equal(&b.G2Monomial[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func TestBlobToKZGCommitment(t *testing.T) {
+
+	// the zero blob commits to the point at infinity
+	var blob Blob
+	c, err := testContext.BlobToKZGCommitment(&blob)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c[0] != mCompressed|mInfinity || !bytes.Equal(c[1:], make([]byte, BytesPerCommitment-1)) {
+		t.Fatal("expected the point at infinity")
+	}
+
+	// the evaluations of X on the roots of unity (in bit-reversed order) commit to [α]G₁
+	for i := 0; i < FieldElementsPerBlob; i++ {
+		b := testContext.roots[i].Bytes()
+		copy(blob[i*BytesPerFieldElement:], b[:])
+	}
+	c, err = testContext.BlobToKZGCommitment(&blob)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var expected bls12381.G1Affine
+	expected.ScalarMultiplication(&testContext.g1, big.NewInt(testAlpha))
+	if c != Commitment(expected.Bytes()) {
+		t.Fatal("wrong commitment")
+	}
+
+	// versioned hash
+	h := sha256.Sum256(c[:])
+	vh := c.VersionedHash()
+	if vh[0] != VersionedHashVersionKZG || !bytes.Equal(vh[1:], h[1:]) {
+		t.Fatal("wrong versioned hash")
+	}
+
+	// field elements must be canonical
+	copy(blob[BytesPerFieldElement:], fr.Modulus().Bytes())
+	if _, err := testContext.BlobToKZGCommitment(&blob); err != ErrInvalidFieldElement {
+		t.Fatal("expected ErrInvalidFieldElement")
+	}
+}
+
+func TestKZGProof(t *testing.T) {
+
+	blob := randomBlob()
+	commitment, err := testContext.BlobToKZGCommitment(blob)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// open outside and inside the domain
+	inside := Scalar(testContext.roots[3].Bytes())
+	for _, z := range []Scalar{randomScalar(), inside} {
+		proof, y, err := testContext.ComputeKZGProof(blob, z)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := testContext.VerifyKZGProof(commitment, z, y, proof); err != nil {
+			t.Fatal(err)
+		}
+
+		// wrong claimed value
+		wrongY := randomScalar()
+		if err := testContext.VerifyKZGProof(commitment, z, wrongY, proof); err != ErrVerifyProof {
+			t.Fatal("verifying a wrong claimed value should fail")
+		}
+	}
+
+	// inside the domain, the claimed value is the blob's element
+	_, y, err := testContext.ComputeKZGProof(blob, inside)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(y[:], blob[3*BytesPerFieldElement:4*BytesPerFieldElement]) {
+		t.Fatal("wrong claimed value")
+	}
+}
+
+func TestBlobKZGProof(t *testing.T) {
+
+	const nbBlobs = 3
+	blobs := make([]Blob, nbBlobs)
+	commitments := make([]Commitment, nbBlobs)
+	proofs := make([]Proof, nbBlobs)
+	for i := 0; i < nbBlobs; i++ {
+		var err error
+		blobs[i] = *randomBlob()
+		if commitments[i], err = testContext.BlobToKZGCommitment(&blobs[i]); err != nil {
+			t.Fatal(err)
+		}
+		if proofs[i], err = testContext.ComputeBlobKZGProof(&blobs[i], commitments[i]); err != nil {
+			t.Fatal(err)
+		}
+		if err = testContext.VerifyBlobKZGProof(&blobs[i], commitments[i], proofs[i]); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := testContext.VerifyBlobKZGProofBatch(blobs, commitments, proofs); err != nil {
+		t.Fatal(err)
+	}
+	if err := testContext.VerifyBlobKZGProofBatch(nil, nil, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	// swapped proofs
+	if err := testContext.VerifyBlobKZGProof(&blobs[0], commitments[0], proofs[1]); err != ErrVerifyProof {
+		t.Fatal("verifying a wrong proof should fail")
+	}
+	proofs[0], proofs[1] = proofs[1], proofs[0]
+	if err := testContext.VerifyBlobKZGProofBatch(blobs, commitments, proofs); err != ErrVerifyProof {
+		t.Fatal("verifying a wrong batch should fail")
+	}
+
+	if err := testContext.VerifyBlobKZGProofBatch(blobs, commitments[1:], proofs); err != ErrInvalidBatchSize {
+		t.Fatal("expected ErrInvalidBatchSize")
+	}
+}
+
+func TestDecodeG1(t *testing.T) {
+
+	_, _, g1, _ := bls12381.Generators()
+	valid := g1.Bytes()
+	if _, err := decodeG1(valid[:]); err != nil {
+		t.Fatal(err)
+	}
+	infinity := make([]byte, BytesPerCommitment)
+	infinity[0] = mCompressed | mInfinity
+	if p, err := decodeG1(infinity); err != nil || !p.IsInfinity() {
+		t.Fatal("expected the point at infinity")
+	}
+
+	// uncompressed flag
+	b := valid
+	b[0] &^= mCompressed
+	if _, err := decodeG1(b[:]); err != ErrInvalidG1Point {
+		t.Fatal("expected ErrInvalidG1Point (uncompressed)")
+	}
+
+	// non-canonical infinity
+	b = [BytesPerCommitment]byte{}
+	copy(b[:], infinity)
+	b[47] = 1
+	if _, err := decodeG1(b[:]); err != ErrInvalidG1Point {
+		t.Fatal("expected ErrInvalidG1Point (infinity)")
+	}
+	b[47] = 0
+	b[0] |= 1 << 5
+	if _, err := decodeG1(b[:]); err != ErrInvalidG1Point {
+		t.Fatal("expected ErrInvalidG1Point (infinity with sign)")
+	}
+
+	// x + p
+	var x big.Int
+	x.SetBytes(valid[:])
+	x.SetBit(&x, 8*BytesPerCommitment-1, 0).SetBit(&x, 8*BytesPerCommitment-3, 0)
+	x.Add(&x, fpModulus)
+	x.FillBytes(b[:])
+	b[0] |= valid[0] & (mCompressed | 1<<5)
+	if _, err := decodeG1(b[:]); err != ErrInvalidG1Point {
+		t.Fatal("expected ErrInvalidG1Point (non-canonical x)")
+	}
+
+	// wrong size
+	if _, err := decodeG1(valid[1:]); err != ErrInvalidG1Point {
+		t.Fatal("expected ErrInvalidG1Point (size)")
+	}
+}
+
+func BenchmarkBlobToKZGCommitment(b *testing.B) {
+	blob := randomBlob()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = testContext.BlobToKZGCommitment(blob)
+	}
+}
+
+func BenchmarkComputeBlobKZGProof(b *testing.B) {
+	blob := randomBlob()
+	commitment, _ := testContext.BlobToKZGCommitment(blob)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = testContext.ComputeBlobKZGProof(blob, commitment)
+	}
+}
+
+func BenchmarkVerifyBlobKZGProof(b *testing.B) {
+	blob := randomBlob()
+	commitment, _ := testContext.BlobToKZGCommitment(blob)
+	proof, _ := testContext.ComputeBlobKZGProof(blob, commitment)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = testContext.VerifyBlobKZGProof(blob, commitment, proof)
+	}
+}
@@ -0,0 +1,383 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kzg4844
+
+import (
+	"math/big"
+
+	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr/fft"
+)
+
+// RecoverCellsAndKZGProofs returns all the cells of an extended blob and their proofs, from at
+// least half of the cells.
+func (ctx *Context) RecoverCellsAndKZGProofs(cellIndices []uint64, cells []Cell) ([]Cell, []Proof, error) {
+	if len(cellIndices) != len(cells) {
+		return nil, nil, ErrInvalidBatchSize
+	}
+	if len(cells) < CellsPerExtBlob/2 {
+		return nil, nil, ErrNotEnoughCells
+	}
+	d, err := ctx.dasContext()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	e := make([]fr.Element, FieldElementsPerExtBlob)
+	missing := make([]bool, CellsPerExtBlob)
+	for k := range missing {
+		missing[k] = true
+	}
+	for i, k := range cellIndices {
+		if k >= CellsPerExtBlob || !missing[k] {
+			return nil, nil, ErrInvalidCellIndex
+		}
+		missing[k] = false
+		evaluations, err := cellToEvaluations(&cells[i])
+		if err != nil {
+			return nil, nil, err
+		}
+		copy(e[k*FieldElementsPerCell:], evaluations)
+	}
+
+	coeffs, err := recoverPolynomial(e, missing, FieldElementsPerBlob, d.extDomain)
+	if err != nil {
+		return nil, nil, err
+	}
+	proofs, err := d.computeCellProofs(coeffs)
+	if err != nil {
+		return nil, nil, err
+	}
+	return d.computeCells(coeffs), encodeProofs(proofs), nil
+}
+
+// recoverPolynomial returns the coefficients of the polynomial p of degree < degree, from its
+// evaluations on domain in bit-reversed order. The evaluations are split in len(missing)
+// consecutive groups, and the evaluations of the missing groups are zero. The size of the domain
+// must be at least 2*degree, and at most half of the groups can be missing.
+//
+// Let Z be the vanishing polynomial of the missing evaluations and E the polynomial interpolating
+// the evaluations. Then E*Z = p*Z on the domain, and deg(p*Z) < |domain|, so p*Z is computed with
+// an inverse FFT and p = (p*Z)/Z is computed on a coset of the domain.
+func recoverPolynomial(e []fr.Element, missing []bool, degree int, domain *fft.Domain) ([]fr.Element, error) {
+	n := len(e)
+	groupSize := n / len(missing)
+
+	// the group m holds the evaluations on cₘH, where H is the subgroup of order groupSize and
+	// cₘ = ω^brp(m), so it vanishes on X^groupSize - wᵇʳᵖ⁽ᵐ⁾ for w = ω^groupSize.
+	// Z(X) = ∏(Y - wᵇʳᵖ⁽ᵐ⁾) where Y = X^groupSize
+	var w fr.Element
+	w.Exp(domain.Generator, big.NewInt(int64(groupSize)))
+	zY := []fr.Element{fr.One()}
+	for m := range missing {
+		if !missing[m] {
+			continue
+		}
+		var c fr.Element
+		c.Exp(w, new(big.Int).SetUint64(bitReverse(uint64(m), uint64(len(missing)))))
+		zY = append(zY, fr.Element{})
+		for i := len(zY) - 1; i > 0; i-- {
+			var t fr.Element
+			t.Mul(&zY[i], &c)
+			zY[i].Sub(&zY[i-1], &t)
+		}
+		zY[0].Mul(&zY[0], &c).Neg(&zY[0])
+	}
+	if degree+groupSize*(len(zY)-1) > n {
+		return nil, ErrNotEnoughCells
+	}
+	z := make([]fr.Element, n)
+	for i := range zY {
+		z[i*groupSize] = zY[i]
+	}
+
+	// p*Z
+	pz := make([]fr.Element, n)
+	copy(pz, z)
+	domain.FFT(pz, fft.DIF)
+	for i := range pz {
+		pz[i].Mul(&pz[i], &e[i])
+	}
+	domain.FFTInverse(pz, fft.DIT)
+
+	// p = (p*Z)/Z on a coset
+	domain.FFT(pz, fft.DIF, true)
+	domain.FFT(z, fft.DIF, true)
+	z = fr.BatchInvert(z)
+	for i := range pz {
+		pz[i].Mul(&pz[i], &z[i])
+	}
+	domain.FFTInverse(pz, fft.DIT, true)
+
+	for i := degree; i < n; i++ {
+		if !pz[i].IsZero() {
+			return nil, ErrInconsistentCells
+		}
+	}
+	return pz[:degree], nil
+}
+
+// ExtendedBlobs 2D erasure coding of blobs. Each blob is extended in a row of cells, and the
+// rows are extended as well: the cells of a column are the evaluations of polynomials of degree
+// smaller than the number of blobs, on the roots of unity of order twice the number of blobs in
+// bit-reversed order. Hence the first rows hold the blobs, and any row or column can be recovered
+// from half of its cells.
+//
+// The commitments and proofs being linear in the blobs, they are extended the same way, so that
+// the cells of any row can be verified against the commitment of the row.
+type ExtendedBlobs struct {
+	Commitments []Commitment
+	Cells       [][]Cell
+	Proofs      [][]Proof
+}
+
+// ExtendBlobs returns the 2D erasure coding of the blobs, whose number must be a power of 2.
+func (ctx *Context) ExtendBlobs(blobs []Blob) (*ExtendedBlobs, error) {
+	m := len(blobs)
+	if m == 0 || m&(m-1) != 0 {
+		return nil, ErrInvalidNbBlobs
+	}
+	d, err := ctx.dasContext()
+	if err != nil {
+		return nil, err
+	}
+
+	// rows
+	evaluations := make([][]fr.Element, 2*m)
+	commitments := make([]bls12381.G1Jac, 2*m)
+	proofs := make([][]bls12381.G1Jac, CellsPerExtBlob)
+	for k := range proofs {
+		proofs[k] = make([]bls12381.G1Jac, 2*m)
+	}
+	for i := 0; i < m; i++ {
+		p, err := blobToPolynomial(&blobs[i])
+		if err != nil {
+			return nil, err
+		}
+		c, err := ctx.commit(p)
+		if err != nil {
+			return nil, err
+		}
+		commitments[i].FromAffine(&c)
+
+		d.blobDomain.FFTInverse(p, fft.DIT)
+		rowProofs, err := d.computeCellProofs(p)
+		if err != nil {
+			return nil, err
+		}
+		for k := range rowProofs {
+			proofs[k][i].FromAffine(&rowProofs[k])
+		}
+
+		evaluations[i] = make([]fr.Element, FieldElementsPerExtBlob)
+		copy(evaluations[i], p)
+		d.extDomain.FFT(evaluations[i], fft.DIF)
+	}
+
+	// columns
+	for i := m; i < 2*m; i++ {
+		evaluations[i] = make([]fr.Element, FieldElementsPerExtBlob)
+	}
+	column := make([]fr.Element, 2*m)
+	small, large := fft.NewDomain(uint64(m)), fft.NewDomain(uint64(2*m))
+	for j := 0; j < FieldElementsPerExtBlob; j++ {
+		for i := 0; i < m; i++ {
+			column[i] = evaluations[i][j]
+		}
+		extendColumn(column, small, large)
+		for i := m; i < 2*m; i++ {
+			evaluations[i][j] = column[i]
+		}
+	}
+	extendColumnG1(commitments, small, large)
+	for k := range proofs {
+		extendColumnG1(proofs[k], small, large)
+	}
+
+	res := ExtendedBlobs{
+		Commitments: make([]Commitment, 2*m),
+		Cells:       make([][]Cell, 2*m),
+		Proofs:      make([][]Proof, 2*m),
+	}
+	commitmentsAffine := bls12381.BatchJacobianToAffineG1(commitments)
+	for i := 0; i < 2*m; i++ {
+		res.Commitments[i] = Commitment(commitmentsAffine[i].Bytes())
+		res.Cells[i] = evaluationsToCells(evaluations[i])
+		res.Proofs[i] = make([]Proof, CellsPerExtBlob)
+	}
+	for k := range proofs {
+		proofsAffine := bls12381.BatchJacobianToAffineG1(proofs[k])
+		for i := range proofsAffine {
+			res.Proofs[i][k] = Proof(proofsAffine[i].Bytes())
+		}
+	}
+
+	return &res, nil
+}
+
+// RecoverExtendedBlobs fills in the missing cells of ExtendedBlobs.Cells, where available[i][k]
+// reports whether the cell k of the row i is known. Rows and columns with at least half of
+// their cells known are recovered in turn, until all the cells are known, in which case the
+// first rows hold the blobs. cells and available are updated in place.
+func (ctx *Context) RecoverExtendedBlobs(cells [][]Cell, available [][]bool) error {
+	nbRows := len(cells)
+	if nbRows < 2 || nbRows&(nbRows-1) != 0 || len(available) != nbRows {
+		return ErrInvalidNbBlobs
+	}
+	for i := range cells {
+		if len(cells[i]) != CellsPerExtBlob || len(available[i]) != CellsPerExtBlob {
+			return ErrInvalidBatchSize
+		}
+	}
+	d, err := ctx.dasContext()
+	if err != nil {
+		return err
+	}
+	columnDomain := fft.NewDomain(uint64(nbRows))
+
+	for {
+		progress, done := false, true
+
+		// rows
+		for i := range cells {
+			known := 0
+			missing := make([]bool, CellsPerExtBlob)
+			e := make([]fr.Element, FieldElementsPerExtBlob)
+			for k := range cells[i] {
+				if !available[i][k] {
+					missing[k] = true
+					continue
+				}
+				known++
+				evaluations, err := cellToEvaluations(&cells[i][k])
+				if err != nil {
+					return err
+				}
+				copy(e[k*FieldElementsPerCell:], evaluations)
+			}
+			if known == CellsPerExtBlob || 2*known < CellsPerExtBlob {
+				done = done && known == CellsPerExtBlob
+				continue
+			}
+			coeffs, err := recoverPolynomial(e, missing, FieldElementsPerBlob, d.extDomain)
+			if err != nil {
+				return err
+			}
+			cells[i] = d.computeCells(coeffs)
+			for k := range available[i] {
+				available[i][k] = true
+			}
+			progress = true
+		}
+
+		// columns of cells
+		for k := 0; k < CellsPerExtBlob; k++ {
+			known := 0
+			missing := make([]bool, nbRows)
+			for i := range cells {
+				if available[i][k] {
+					known++
+				} else {
+					missing[i] = true
+				}
+			}
+			if known == nbRows || 2*known < nbRows {
+				done = done && known == nbRows
+				continue
+			}
+			evaluations := make([][]fr.Element, nbRows)
+			for i := range cells {
+				if missing[i] {
+					evaluations[i] = make([]fr.Element, FieldElementsPerCell)
+				} else if evaluations[i], err = cellToEvaluations(&cells[i][k]); err != nil {
+					return err
+				}
+			}
+			e := make([]fr.Element, nbRows)
+			for j := 0; j < FieldElementsPerCell; j++ {
+				for i := range cells {
+					if missing[i] {
+						e[i].SetZero()
+					} else {
+						e[i] = evaluations[i][j]
+					}
+				}
+				coeffs, err := recoverPolynomial(e, missing, nbRows/2, columnDomain)
+				if err != nil {
+					return err
+				}
+				copy(e, coeffs)
+				for i := len(coeffs); i < nbRows; i++ {
+					e[i].SetZero()
+				}
+				columnDomain.FFT(e, fft.DIF)
+				for i := range cells {
+					evaluations[i][j] = e[i]
+				}
+			}
+			for i := range cells {
+				if missing[i] {
+					cells[i][k] = evaluationsToCells(evaluations[i])[0]
+					available[i][k] = true
+				}
+			}
+			progress = true
+		}
+
+		if done {
+			return nil
+		}
+		if !progress {
+			return ErrNotEnoughCells
+		}
+	}
+}
+
+// extendColumn sets column[m:] from column[:m], where m = len(column)/2, such that column holds
+// the evaluations of a polynomial of degree < m on the roots of unity of order 2m in bit-reversed
+// order.
+func extendColumn(column []fr.Element, small, large *fft.Domain) {
+	m := len(column) / 2
+	if m == 1 {
+		column[1] = column[0]
+		return
+	}
+	small.FFTInverse(column[:m], fft.DIT)
+	for i := m; i < 2*m; i++ {
+		column[i].SetZero()
+	}
+	large.FFT(column, fft.DIF)
+}
+
+// extendColumnG1 is extendColumn in G₁
+func extendColumnG1(column []bls12381.G1Jac, small, large *fft.Domain) {
+	m := len(column) / 2
+	if m == 1 {
+		column[1] = column[0]
+		return
+	}
+	bitReverseG1(column[:m])
+	fftG1(column[:m], small.GeneratorInv)
+	var mInv big.Int
+	small.CardinalityInv.ToBigIntRegular(&mInv)
+	var infinity bls12381.G1Affine
+	for i := 0; i < m; i++ {
+		column[i].ScalarMultiplication(&column[i], &mInv)
+		column[m+i].FromAffine(&infinity)
+	}
+	fftG1(column, large.Generator)
+	bitReverseG1(column)
+}
@@ -0,0 +1,310 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kzg4844
+
+import (
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+// The reference vectors are in the format of the consensus specifications (also shipped by
+// c-kzg-4844): the test cases of a handler (blob_to_kzg_commitment, ...) are in
+// <handler>/kzg-mainnet/<case>/data.yaml, and an output of null means that the inputs are
+// invalid, and an error is expected.
+//
+// The vectors of testdata are those whose output does not depend on the trusted setup (points
+// at infinity, zero blobs, invalid encodings and lengths), so they are checked with the test
+// setup. The whole suite, which needs the mainnet trusted setup, is run with:
+//
+//	KZG4844_TRUSTED_SETUP=/path/to/c-kzg-4844/src/trusted_setup.txt \
+//	KZG4844_TEST_VECTORS=/path/to/consensus-spec-tests/tests/general/deneb/kzg \
+//	go test -run TestReferenceVectors ./ecc/bls12-381/kzg4844/
+const (
+	envTrustedSetup = "KZG4844_TRUSTED_SETUP"
+	envTestVectors  = "KZG4844_TEST_VECTORS"
+)
+
+// referenceContext returns the context and the directory of the vectors: the mainnet setup
+// and the whole suite if the environment variables are set, the test setup and testdata otherwise
+func referenceContext(t *testing.T) (*Context, string) {
+	setupPath, vectorsDir := os.Getenv(envTrustedSetup), os.Getenv(envTestVectors)
+	if setupPath == "" && vectorsDir == "" {
+		return testContext, "testdata"
+	}
+	if setupPath == "" || vectorsDir == "" {
+		t.Fatalf("%s and %s should be set together", envTrustedSetup, envTestVectors)
+	}
+	f, err := os.Open(setupPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	ts, err := ReadTrustedSetup(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, err := NewContext(ts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return ctx, vectorsDir
+}
+
+// forEachCase decodes the data.yaml of each test case of the handler into a new value of
+// data, and calls check on it
+func forEachCase(t *testing.T, vectorsDir, handler string, data func() interface{}, check func(t *testing.T, d interface{})) {
+	files, err := filepath.Glob(filepath.Join(vectorsDir, handler, "*", "*", "data.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) == 0 {
+		t.Fatalf("no test case for %s in %s", handler, vectorsDir)
+	}
+	for _, file := range files {
+		file := file
+		t.Run(filepath.Base(filepath.Dir(file)), func(t *testing.T) {
+			b, err := os.ReadFile(file)
+			if err != nil {
+				t.Fatal(err)
+			}
+			d := data()
+			if err := yaml.Unmarshal(b, d); err != nil {
+				t.Fatal(err)
+			}
+			check(t, d)
+		})
+	}
+}
+
+// decodeHex decodes a 0x-prefixed hexadecimal string into dst, and returns false if it is
+// not of the size of dst
+func decodeHex(dst []byte, s string) bool {
+	b, err := hex.DecodeString(strings.TrimPrefix(s, "0x"))
+	if err != nil || len(b) != len(dst) {
+		return false
+	}
+	copy(dst, b)
+	return true
+}
+
+func TestReferenceVectors(t *testing.T) {
+	ctx, vectorsDir := referenceContext(t)
+
+	t.Run("blob_to_kzg_commitment", func(t *testing.T) {
+		type data struct {
+			Input struct {
+				Blob string `yaml:"blob"`
+			} `yaml:"input"`
+			Output *string `yaml:"output"`
+		}
+		forEachCase(t, vectorsDir, "blob_to_kzg_commitment", func() interface{} { return new(data) }, func(t *testing.T, i interface{}) {
+			d := i.(*data)
+			var blob Blob
+			var commitment Commitment
+			var err error
+			if !decodeHex(blob[:], d.Input.Blob) {
+				err = ErrInvalidFieldElement
+			} else {
+				commitment, err = ctx.BlobToKZGCommitment(&blob)
+			}
+			if d.Output == nil {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			var expected Commitment
+			if err != nil || !decodeHex(expected[:], *d.Output) || commitment != expected {
+				t.Fatalf("unexpected commitment (err: %v)", err)
+			}
+		})
+	})
+
+	t.Run("compute_kzg_proof", func(t *testing.T) {
+		type data struct {
+			Input struct {
+				Blob string `yaml:"blob"`
+				Z    string `yaml:"z"`
+			} `yaml:"input"`
+			Output []string `yaml:"output"`
+		}
+		forEachCase(t, vectorsDir, "compute_kzg_proof", func() interface{} { return new(data) }, func(t *testing.T, i interface{}) {
+			d := i.(*data)
+			var blob Blob
+			var z, y Scalar
+			var proof Proof
+			var err error
+			if !decodeHex(blob[:], d.Input.Blob) || !decodeHex(z[:], d.Input.Z) {
+				err = ErrInvalidFieldElement
+			} else {
+				proof, y, err = ctx.ComputeKZGProof(&blob, z)
+			}
+			if d.Output == nil {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			var expectedProof Proof
+			var expectedY Scalar
+			if err != nil || len(d.Output) != 2 ||
+				!decodeHex(expectedProof[:], d.Output[0]) || !decodeHex(expectedY[:], d.Output[1]) ||
+				proof != expectedProof || y != expectedY {
+				t.Fatalf("unexpected proof (err: %v)", err)
+			}
+		})
+	})
+
+	t.Run("compute_blob_kzg_proof", func(t *testing.T) {
+		type data struct {
+			Input struct {
+				Blob       string `yaml:"blob"`
+				Commitment string `yaml:"commitment"`
+			} `yaml:"input"`
+			Output *string `yaml:"output"`
+		}
+		forEachCase(t, vectorsDir, "compute_blob_kzg_proof", func() interface{} { return new(data) }, func(t *testing.T, i interface{}) {
+			d := i.(*data)
+			var blob Blob
+			var commitment Commitment
+			var proof Proof
+			var err error
+			if !decodeHex(blob[:], d.Input.Blob) || !decodeHex(commitment[:], d.Input.Commitment) {
+				err = ErrInvalidFieldElement
+			} else {
+				proof, err = ctx.ComputeBlobKZGProof(&blob, commitment)
+			}
+			if d.Output == nil {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			var expected Proof
+			if err != nil || !decodeHex(expected[:], *d.Output) || proof != expected {
+				t.Fatalf("unexpected proof (err: %v)", err)
+			}
+		})
+	})
+
+	t.Run("verify_kzg_proof", func(t *testing.T) {
+		type data struct {
+			Input struct {
+				Commitment string `yaml:"commitment"`
+				Z          string `yaml:"z"`
+				Y          string `yaml:"y"`
+				Proof      string `yaml:"proof"`
+			} `yaml:"input"`
+			Output *bool `yaml:"output"`
+		}
+		forEachCase(t, vectorsDir, "verify_kzg_proof", func() interface{} { return new(data) }, func(t *testing.T, i interface{}) {
+			d := i.(*data)
+			var commitment Commitment
+			var z, y Scalar
+			var proof Proof
+			var err error
+			if !decodeHex(commitment[:], d.Input.Commitment) || !decodeHex(z[:], d.Input.Z) ||
+				!decodeHex(y[:], d.Input.Y) || !decodeHex(proof[:], d.Input.Proof) {
+				err = ErrInvalidFieldElement
+			} else {
+				err = ctx.VerifyKZGProof(commitment, z, y, proof)
+			}
+			checkVerification(t, d.Output, err)
+		})
+	})
+
+	t.Run("verify_blob_kzg_proof", func(t *testing.T) {
+		type data struct {
+			Input struct {
+				Blob       string `yaml:"blob"`
+				Commitment string `yaml:"commitment"`
+				Proof      string `yaml:"proof"`
+			} `yaml:"input"`
+			Output *bool `yaml:"output"`
+		}
+		forEachCase(t, vectorsDir, "verify_blob_kzg_proof", func() interface{} { return new(data) }, func(t *testing.T, i interface{}) {
+			d := i.(*data)
+			var blob Blob
+			var commitment Commitment
+			var proof Proof
+			var err error
+			if !decodeHex(blob[:], d.Input.Blob) || !decodeHex(commitment[:], d.Input.Commitment) ||
+				!decodeHex(proof[:], d.Input.Proof) {
+				err = ErrInvalidFieldElement
+			} else {
+				err = ctx.VerifyBlobKZGProof(&blob, commitment, proof)
+			}
+			checkVerification(t, d.Output, err)
+		})
+	})
+
+	t.Run("verify_blob_kzg_proof_batch", func(t *testing.T) {
+		type data struct {
+			Input struct {
+				Blobs       []string `yaml:"blobs"`
+				Commitments []string `yaml:"commitments"`
+				Proofs      []string `yaml:"proofs"`
+			} `yaml:"input"`
+			Output *bool `yaml:"output"`
+		}
+		forEachCase(t, vectorsDir, "verify_blob_kzg_proof_batch", func() interface{} { return new(data) }, func(t *testing.T, i interface{}) {
+			d := i.(*data)
+			blobs := make([]Blob, len(d.Input.Blobs))
+			commitments := make([]Commitment, len(d.Input.Commitments))
+			proofs := make([]Proof, len(d.Input.Proofs))
+			ok := true
+			for j := range blobs {
+				ok = ok && decodeHex(blobs[j][:], d.Input.Blobs[j])
+			}
+			for j := range commitments {
+				ok = ok && decodeHex(commitments[j][:], d.Input.Commitments[j])
+			}
+			for j := range proofs {
+				ok = ok && decodeHex(proofs[j][:], d.Input.Proofs[j])
+			}
+			var err error
+			if !ok {
+				err = ErrInvalidFieldElement
+			} else {
+				err = ctx.VerifyBlobKZGProofBatch(blobs, commitments, proofs)
+			}
+			checkVerification(t, d.Output, err)
+		})
+	})
+}
+
+// checkVerification checks the result of a verification: an error other than ErrVerifyProof
+// if the inputs are invalid (output null), ErrVerifyProof if the proof is wrong (false)
+func checkVerification(t *testing.T, output *bool, err error) {
+	switch {
+	case output == nil:
+		if err == nil || err == ErrVerifyProof {
+			t.Fatalf("expected invalid inputs, got %v", err)
+		}
+	case *output:
+		if err != nil {
+			t.Fatalf("the proof should be valid, got %v", err)
+		}
+	default:
+		if err != ErrVerifyProof {
+			t.Fatalf("the proof should be invalid, got %v", err)
+		}
+	}
+}
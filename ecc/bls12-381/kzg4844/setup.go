@@ -0,0 +1,194 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kzg4844
+
+import (
+	"bufio"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
+)
+
+// TrustedSetup output of the Ethereum KZG ceremony: the points [Lᵢ(α)]G₁ for the Lagrange
+// polynomials on the 4096-th roots of unity, in natural order, and the points [αⁱ]G₂.
+// The points [αⁱ]G₁ are optional: they are only needed for cells, and are computed from
+// the Lagrange form if missing.
+//
+// implements io.WriterTo
+type TrustedSetup struct {
+	G1Lagrange []bls12381.G1Affine
+	G2Monomial []bls12381.G2Affine
+	G1Monomial []bls12381.G1Affine
+}
+
+// ReadTrustedSetup reads a trusted setup in the text format of c-kzg-4844: the number of
+// G₁ points, the number of G₂ points, then the compressed points in hexadecimal: the G₁ points
+// in Lagrange form, the G₂ points and, in recent versions, the G₁ points in monomial form.
+func ReadTrustedSetup(r io.Reader) (*TrustedSetup, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 1024), 1024)
+	scanner.Split(bufio.ScanWords)
+	next := func() (string, error) {
+		if !scanner.Scan() {
+			if err := scanner.Err(); err != nil {
+				return "", err
+			}
+			return "", io.ErrUnexpectedEOF
+		}
+		return scanner.Text(), nil
+	}
+
+	var sizes [2]int
+	for i := range sizes {
+		s, err := next()
+		if err != nil {
+			return nil, err
+		}
+		if sizes[i], err = strconv.Atoi(s); err != nil || sizes[i] < 0 {
+			return nil, ErrInvalidSetup
+		}
+	}
+	if sizes[0] != FieldElementsPerBlob || sizes[1] < 2 {
+		return nil, ErrInvalidSetup
+	}
+
+	g1 := make([]string, sizes[0])
+	g2 := make([]string, sizes[1])
+	for _, points := range [][]string{g1, g2} {
+		for i := range points {
+			var err error
+			if points[i], err = next(); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	// the G₁ points in monomial form are optional
+	var g1Monomial []string
+	if s, err := next(); err == nil {
+		g1Monomial = make([]string, sizes[0])
+		g1Monomial[0] = s
+		for i := 1; i < len(g1Monomial); i++ {
+			if g1Monomial[i], err = next(); err != nil {
+				return nil, err
+			}
+		}
+	} else if err != io.ErrUnexpectedEOF {
+		return nil, err
+	}
+
+	return newTrustedSetup(g1, g2, g1Monomial)
+}
+
+// ReadTrustedSetupJSON reads a trusted setup in the JSON format of the consensus specifications,
+// with the fields "g1_lagrange", "g2_monomial" and optionally "g1_monomial".
+func ReadTrustedSetupJSON(r io.Reader) (*TrustedSetup, error) {
+	var ts struct {
+		G1Lagrange []string `json:"g1_lagrange"`
+		G2Monomial []string `json:"g2_monomial"`
+		G1Monomial []string `json:"g1_monomial"`
+	}
+	if err := json.NewDecoder(r).Decode(&ts); err != nil {
+		return nil, err
+	}
+	if len(ts.G1Lagrange) != FieldElementsPerBlob || len(ts.G2Monomial) < 2 {
+		return nil, ErrInvalidSetup
+	}
+	if len(ts.G1Monomial) != 0 && len(ts.G1Monomial) != FieldElementsPerBlob {
+		return nil, ErrInvalidSetup
+	}
+	return newTrustedSetup(ts.G1Lagrange, ts.G2Monomial, ts.G1Monomial)
+}
+
+// WriteTo writes the trusted setup in the text format of c-kzg-4844
+func (ts *TrustedSetup) WriteTo(w io.Writer) (int64, error) {
+	bw := bufio.NewWriter(w)
+	var written int64
+	write := func(format string, a ...interface{}) error {
+		n, err := fmt.Fprintf(bw, format, a...)
+		written += int64(n)
+		return err
+	}
+
+	if err := write("%d\n%d\n", len(ts.G1Lagrange), len(ts.G2Monomial)); err != nil {
+		return written, err
+	}
+	for i := range ts.G1Lagrange {
+		b := ts.G1Lagrange[i].Bytes()
+		if err := write("%s\n", hex.EncodeToString(b[:])); err != nil {
+			return written, err
+		}
+	}
+	for i := range ts.G2Monomial {
+		b := ts.G2Monomial[i].Bytes()
+		if err := write("%s\n", hex.EncodeToString(b[:])); err != nil {
+			return written, err
+		}
+	}
+	for i := range ts.G1Monomial {
+		b := ts.G1Monomial[i].Bytes()
+		if err := write("%s\n", hex.EncodeToString(b[:])); err != nil {
+			return written, err
+		}
+	}
+
+	return written, bw.Flush()
+}
+
+// newTrustedSetup decodes the compressed points, given in hexadecimal with an optional 0x prefix.
+// The points must be in the subgroups, and can't be the point at infinity.
+func newTrustedSetup(g1, g2, g1Monomial []string) (*TrustedSetup, error) {
+	ts := TrustedSetup{
+		G1Lagrange: make([]bls12381.G1Affine, len(g1)),
+		G2Monomial: make([]bls12381.G2Affine, len(g2)),
+	}
+	if len(g1Monomial) != 0 {
+		ts.G1Monomial = make([]bls12381.G1Affine, len(g1Monomial))
+	}
+
+	for _, g := range []struct {
+		hex    []string
+		points []bls12381.G1Affine
+	}{{g1, ts.G1Lagrange}, {g1Monomial, ts.G1Monomial}} {
+		for i := range g.hex {
+			b, err := hex.DecodeString(strings.TrimPrefix(g.hex[i], "0x"))
+			if err != nil {
+				return nil, err
+			}
+			if g.points[i], err = decodeG1(b); err != nil || g.points[i].IsInfinity() {
+				return nil, ErrInvalidSetup
+			}
+		}
+	}
+	for i := range g2 {
+		b, err := hex.DecodeString(strings.TrimPrefix(g2[i], "0x"))
+		if err != nil {
+			return nil, err
+		}
+		if len(b) != bls12381.SizeOfG2AffineCompressed {
+			return nil, ErrInvalidSetup
+		}
+		if _, err = ts.G2Monomial[i].SetBytes(b); err != nil || ts.G2Monomial[i].IsInfinity() {
+			return nil, ErrInvalidSetup
+		}
+	}
+
+	return &ts, nil
+}
@@ -0,0 +1,167 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package bls12381
+
+import (
+	"bytes"
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fp"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+)
+
+func TestArkworksEncoder(t *testing.T) {
+	t.Parallel()
+
+	var inA uint64
+	var inB fr.Element
+	var inC fp.Element
+	var inD, inE G1Affine
+	var inF, inG G2Affine
+	var inH []G1Affine
+	var inI []G2Affine
+	var inJ []fr.Element
+	var inK []fp.Element
+
+	inA = rand.Uint64()
+	inB.SetRandom()
+	inC.SetRandom()
+	inD.ScalarMultiplication(&g1GenAff, new(big.Int).SetUint64(rand.Uint64()))
+	// inE --> infinity
+	inF.ScalarMultiplication(&g2GenAff, new(big.Int).SetUint64(rand.Uint64()))
+	// inG --> infinity
+	inH = []G1Affine{inD, inE, g1GenAff}
+	inH[2].Neg(&inH[2])
+	inI = []G2Affine{g2GenAff, inG, inF}
+	inI[0].Neg(&inI[0])
+	inJ = make([]fr.Element, 3)
+	inJ[1] = inB
+	inK = make([]fp.Element, 0)
+
+	for _, compress := range []bool{true, false} {
+		var buf bytes.Buffer
+		enc := NewEncoder(&buf, ArkworksEncoding(compress))
+		toEncode := []interface{}{inA, &inB, &inC, &inD, &inE, &inF, &inG, inH, inI, inJ, inK}
+		for _, v := range toEncode {
+			if err := enc.Encode(v); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if enc.BytesWritten() != int64(buf.Len()) {
+			t.Fatal("unexpected number of bytes written")
+		}
+
+		var outA uint64
+		var outB fr.Element
+		var outC fp.Element
+		var outD, outE G1Affine
+		var outF, outG G2Affine
+		var outH []G1Affine
+		var outI []G2Affine
+		var outJ []fr.Element
+		var outK []fp.Element
+
+		n := int64(buf.Len())
+		dec := NewDecoder(&buf, ArkworksDecoding(compress))
+		toDecode := []interface{}{&outA, &outB, &outC, &outD, &outE, &outF, &outG, &outH, &outI, &outJ, &outK}
+		for _, v := range toDecode {
+			if err := dec.Decode(v); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if dec.BytesRead() != n {
+			t.Fatal("didn't read as many bytes as we wrote")
+		}
+
+		if inA != outA {
+			t.Fatal("didn't encode/decode uint64 value properly")
+		}
+		if !inB.Equal(&outB) || !inC.Equal(&outC) {
+			t.Fatal("decode(encode(Element)) failed")
+		}
+		if !inD.Equal(&outD) || !inE.Equal(&outE) {
+			t.Fatal("decode(encode(G1Affine)) failed")
+		}
+		if !inF.Equal(&outF) || !inG.Equal(&outG) {
+			t.Fatal("decode(encode(G2Affine)) failed")
+		}
+		if len(inH) != len(outH) || len(inI) != len(outI) || len(inJ) != len(outJ) || len(inK) != len(outK) {
+			t.Fatal("decode(encode(slice)) failed")
+		}
+		for i := range inH {
+			if !inH[i].Equal(&outH[i]) {
+				t.Fatal("decode(encode(slice(G1Affine))) failed")
+			}
+		}
+		for i := range inI {
+			if !inI[i].Equal(&outI[i]) {
+				t.Fatal("decode(encode(slice(G2Affine))) failed")
+			}
+		}
+		for i := range inJ {
+			if !inJ[i].Equal(&outJ[i]) {
+				t.Fatal("decode(encode(slice(fr.Element))) failed")
+			}
+		}
+	}
+}
+
+func TestArkworksVectors(t *testing.T) {
+	t.Parallel()
+
+	// field elements are little-endian
+	var one fr.Element
+	one.SetOne()
+	var buf bytes.Buffer
+	if err := NewEncoder(&buf, ArkworksEncoding(true)).Encode(&one); err != nil {
+		t.Fatal(err)
+	}
+	expected := make([]byte, fr.Bytes)
+	expected[0] = 1
+	if !bytes.Equal(buf.Bytes(), expected) {
+		t.Fatal("unexpected encoding of fr.Element")
+	}
+
+	// non canonical elements are rejected
+	for i := range expected {
+		expected[i] = 0xff
+	}
+	var e fr.Element
+	if err := NewDecoder(bytes.NewReader(expected), ArkworksDecoding(true)).Decode(&e); err == nil {
+		t.Fatal("decoding a non canonical element should fail")
+	}
+
+	// ark-bls12-381 follows the ZCash encoding for points
+	var p G1Affine
+	p.ScalarMultiplication(&g1GenAff, new(big.Int).SetUint64(rand.Uint64()))
+	if p.ArkworksBytes() != p.Bytes() || p.ArkworksRawBytes() != p.RawBytes() {
+		t.Fatal("unexpected encoding of G1Affine")
+	}
+	var q G2Affine
+	q.ScalarMultiplication(&g2GenAff, new(big.Int).SetUint64(rand.Uint64()))
+	if q.ArkworksBytes() != q.Bytes() || q.ArkworksRawBytes() != q.RawBytes() {
+		t.Fatal("unexpected encoding of G2Affine")
+	}
+
+	// uncompressed encoding must be decoded as such
+	raw := p.ArkworksRawBytes()
+	if _, err := p.SetArkworksBytes(raw[:]); err == nil {
+		t.Fatal("decoding an uncompressed point as compressed should fail")
+	}
+}
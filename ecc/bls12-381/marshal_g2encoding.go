@@ -0,0 +1,164 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package bls12381
+
+import (
+	"errors"
+	"io"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fp"
+)
+
+// G2SignConvention is the meaning of the third-most significant bit of a compressed G2
+// point, which tells which of y and -y is the y-coordinate of the point.
+type G2SignConvention uint8
+
+const (
+	// G2SignLexicographic sets the bit if y is the lexicographically largest of y and -y,
+	// comparing y.A1 first. It is the convention of ZCash and of the IETF pairing-friendly
+	// curves draft, followed by blst and py_ecc, and the one of Bytes() and SetBytes().
+	G2SignLexicographic G2SignConvention = iota
+
+	// G2SignSgn0 sets the bit to sgn0(y), as defined in RFC 9380 (hashing to elliptic
+	// curves): the parity of y.A0, or of y.A1 if y.A0 is zero.
+	G2SignSgn0
+)
+
+// G2CoordinateOrder is the order in which the components of the coordinates of a G2 point
+// (elements a0 + a1⋅u of Fp2) are encoded. The components are always big-endian.
+type G2CoordinateOrder uint8
+
+const (
+	// G2OrderA1A0 encodes a1 first. It is the order of ZCash, blst and py_ecc, and the one
+	// of Bytes() and RawBytes().
+	G2OrderA1A0 G2CoordinateOrder = iota
+
+	// G2OrderA0A1 encodes a0 first.
+	G2OrderA0A1
+)
+
+// G2Encoding is an encoding of G2 points, in the BLS12-381 style of Bytes() and RawBytes()
+// (metadata in the 3 most significant bits), with explicit conventions for the sign of y
+// and the order of the components of the coordinates.
+//
+// The zero value is the encoding of Bytes() and RawBytes().
+type G2Encoding struct {
+	Sign  G2SignConvention
+	Order G2CoordinateOrder
+}
+
+var errInvalidG2Encoding = errors.New("invalid G2 encoding conventions")
+
+func (e G2Encoding) valid() bool {
+	return e.Sign <= G2SignSgn0 && e.Order <= G2OrderA0A1
+}
+
+// BytesWithEncoding returns the compressed binary representation of p in the encoding e.
+// It panics if e is not a valid encoding.
+func (p *G2Affine) BytesWithEncoding(e G2Encoding) (res [SizeOfG2AffineCompressed]byte) {
+	if !e.valid() {
+		panic(errInvalidG2Encoding)
+	}
+	res = p.Bytes()
+	if p.X.IsZero() && p.Y.IsZero() {
+		return
+	}
+	if e.Sign == G2SignSgn0 {
+		msbMask := mCompressedSmallest
+		if g2Sgn0(&p.Y) == 1 {
+			msbMask = mCompressedLargest
+		}
+		res[0] = (res[0] &^ mMask) | msbMask
+	}
+	if e.Order == G2OrderA0A1 {
+		swapG2Components(res[:])
+	}
+	return
+}
+
+// RawBytesWithEncoding returns the uncompressed binary representation of p in the encoding e.
+// It panics if e is not a valid encoding.
+func (p *G2Affine) RawBytesWithEncoding(e G2Encoding) (res [SizeOfG2AffineUncompressed]byte) {
+	if !e.valid() {
+		panic(errInvalidG2Encoding)
+	}
+	res = p.RawBytes()
+	if e.Order == G2OrderA0A1 {
+		swapG2Components(res[:])
+	}
+	return
+}
+
+// SetBytesWithEncoding sets p from its binary representation in buf, compressed or not, in the
+// encoding e, and returns the number of consumed bytes. It is SetBytes for the other encodings.
+//
+// this check if the resulting point is on the curve and in the correct subgroup
+func (p *G2Affine) SetBytesWithEncoding(buf []byte, e G2Encoding) (int, error) {
+	if !e.valid() {
+		return 0, errInvalidG2Encoding
+	}
+	if e == (G2Encoding{}) {
+		return p.SetBytes(buf)
+	}
+	if len(buf) < SizeOfG2AffineCompressed {
+		return 0, io.ErrShortBuffer
+	}
+
+	// most significant byte
+	mData := buf[0] & mMask
+
+	size := SizeOfG2AffineCompressed
+	if mData == mUncompressed || mData == mUncompressedInfinity {
+		size = SizeOfG2AffineUncompressed
+	}
+	if len(buf) < size {
+		return 0, io.ErrShortBuffer
+	}
+
+	// we copy the buffer to bring it to the encoding of Bytes() and RawBytes()
+	var bufP [SizeOfG2AffineUncompressed]byte
+	copy(bufP[:size], buf[:size])
+	if e.Order == G2OrderA0A1 {
+		swapG2Components(bufP[:size])
+	}
+
+	if _, err := p.setBytes(bufP[:size], true); err != nil {
+		return 0, err
+	}
+
+	// -p is in the subgroup iff p is, so we can fix the sign after the checks
+	if e.Sign == G2SignSgn0 && (mData == mCompressedSmallest || mData == mCompressedLargest) {
+		if (g2Sgn0(&p.Y) == 1) != (mData == mCompressedLargest) {
+			p.Y.Neg(&p.Y)
+		}
+	}
+
+	return size, nil
+}
+
+// swapG2Components swaps the two components of each coordinate encoded in buf,
+// leaving the metadata bits in the most significant byte
+func swapG2Components(buf []byte) {
+	mData := buf[0] & mMask
+	buf[0] &^= mMask
+	for i := 0; i < len(buf); i += 2 * fp.Bytes {
+		for j := 0; j < fp.Bytes; j++ {
+			buf[i+j], buf[i+fp.Bytes+j] = buf[i+fp.Bytes+j], buf[i+j]
+		}
+	}
+	buf[0] |= mData
+}
@@ -0,0 +1,114 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package bls12381
+
+import (
+	"bytes"
+	"encoding/hex"
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fp"
+)
+
+func TestG2Encoding(t *testing.T) {
+	t.Parallel()
+
+	encodings := []G2Encoding{
+		{Sign: G2SignLexicographic, Order: G2OrderA1A0},
+		{Sign: G2SignLexicographic, Order: G2OrderA0A1},
+		{Sign: G2SignSgn0, Order: G2OrderA1A0},
+		{Sign: G2SignSgn0, Order: G2OrderA0A1},
+	}
+
+	var infinity G2Affine
+	points := []G2Affine{g2GenAff, infinity}
+	for i := 0; i < 10; i++ {
+		var p G2Affine
+		p.ScalarMultiplication(&g2GenAff, new(big.Int).SetUint64(rand.Uint64()))
+		points = append(points, p)
+		p.Neg(&p)
+		points = append(points, p)
+	}
+
+	for _, e := range encodings {
+		for _, p := range points {
+			compressed := p.BytesWithEncoding(e)
+			raw := p.RawBytesWithEncoding(e)
+
+			// round trip
+			for _, buf := range [][]byte{compressed[:], raw[:]} {
+				var q G2Affine
+				n, err := q.SetBytesWithEncoding(buf, e)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if n != len(buf) {
+					t.Fatal("invalid number of bytes consumed in buffer")
+				}
+				if !q.Equal(&p) {
+					t.Fatalf("%+v: round trip failed", e)
+				}
+			}
+			if p.IsInfinity() {
+				continue
+			}
+
+			// sign of y
+			largest := compressed[0]&mMask == mCompressedLargest
+			if e.Sign == G2SignLexicographic && largest != p.Y.LexicographicallyLargest() {
+				t.Fatal("sign bit doesn't match the lexicographic convention")
+			}
+			if e.Sign == G2SignSgn0 && largest != (g2Sgn0(&p.Y) == 1) {
+				t.Fatal("sign bit doesn't match sgn0")
+			}
+
+			// order of the components
+			first, second := p.X.A1.Bytes(), p.X.A0.Bytes()
+			if e.Order == G2OrderA0A1 {
+				first, second = second, first
+			}
+			first[0] |= compressed[0] & mMask
+			if !bytes.Equal(compressed[:fp.Bytes], first[:]) || !bytes.Equal(compressed[fp.Bytes:], second[:]) {
+				t.Fatal("unexpected order of the components of X")
+			}
+		}
+	}
+
+	// the zero value is the encoding of Bytes()
+	for _, p := range points {
+		if p.BytesWithEncoding(G2Encoding{}) != p.Bytes() || p.RawBytesWithEncoding(G2Encoding{}) != p.RawBytes() {
+			t.Fatal("G2Encoding{} should match Bytes() and RawBytes()")
+		}
+	}
+
+	// compressed generator, as serialized by blst and py_ecc
+	expected, _ := hex.DecodeString("93e02b6052719f607dacd3a088274f65596bd0d09920b61ab5da61bbdc7f5049334cf11213945d57e5ac7d055d042b7e024aa2b2f08f0a91260805272dc51051c6e47ad4fa403b02b4510b647ae3d1770bac0326a805bbefd48056c8c121bdb8")
+	if b := g2GenAff.BytesWithEncoding(G2Encoding{}); !bytes.Equal(b[:], expected) {
+		t.Fatal("unexpected encoding of the generator")
+	}
+
+	// invalid conventions
+	var p G2Affine
+	if _, err := p.SetBytesWithEncoding(expected, G2Encoding{Sign: G2SignSgn0 + 1}); err == nil {
+		t.Fatal("invalid sign convention should be rejected")
+	}
+	if _, err := p.SetBytesWithEncoding(expected, G2Encoding{Order: G2OrderA0A1 + 1}); err == nil {
+		t.Fatal("invalid order should be rejected")
+	}
+}
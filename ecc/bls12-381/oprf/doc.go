@@ -0,0 +1,35 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+// Package oprf provides (verifiable) oblivious pseudo-random functions on bls12-381's G1,
+// following the 2HashDH construction of the CFRG OPRF specification.
+//
+// The server holds a key k, and the client an input x. The client obtains
+// F(k, x) = H(x, k·H₁(x)) without learning k, and the server learns nothing about x:
+//  1. the client blinds its input: Blind(x) = r·H₁(x)
+//  2. the server evaluates the blinded element: k·r·H₁(x)
+//  3. the client unblinds and hashes the result: Finalize
+//
+// In the verifiable mode (VOPRF), the server also proves with a DLEQ proof that
+// it used the key committed in its public key kG.
+//
+// The ciphersuites of the specification do not cover pairing-friendly curves, the
+// context string of this package uses the identifier "bls12-381-G1-SHA256".
+//
+// # See also
+//
+// https://datatracker.ietf.org/doc/draft-irtf-cfrg-voprf/
+package oprf
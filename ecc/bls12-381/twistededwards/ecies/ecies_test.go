@@ -0,0 +1,116 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package ecies
+
+import (
+	"bytes"
+	"crypto/rand"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/twistededwards"
+)
+
+func TestEncryptDecrypt(t *testing.T) {
+
+	priv, err := GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	msg := []byte("the quick brown fox jumps over the lazy dog")
+	ad := []byte("header")
+
+	ciphertext, err := Encrypt(rand.Reader, &priv.PublicKey, msg, ad)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ciphertext) != len(msg)+sizeCiphertext {
+		t.Fatal("wrong ciphertext size")
+	}
+
+	plaintext, err := priv.Decrypt(ciphertext, ad)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(plaintext, msg) {
+		t.Fatal("decrypted message differs from the original")
+	}
+
+	// wrong additional data
+	if _, err = priv.Decrypt(ciphertext, []byte("other header")); err != ErrDecryption {
+		t.Fatal("expected ErrDecryption")
+	}
+
+	// tampered ciphertext
+	ciphertext[len(ciphertext)-1] ^= 1
+	if _, err = priv.Decrypt(ciphertext, ad); err != ErrDecryption {
+		t.Fatal("expected ErrDecryption")
+	}
+
+	// wrong recipient
+	ciphertext[len(ciphertext)-1] ^= 1
+	other, err := GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = other.Decrypt(ciphertext, ad); err != ErrDecryption {
+		t.Fatal("expected ErrDecryption")
+	}
+
+	// truncated ciphertext
+	if _, err = priv.Decrypt(ciphertext[:sizeCiphertext-1], ad); err != ErrCiphertextSize {
+		t.Fatal("expected ErrCiphertextSize")
+	}
+}
+
+func TestInvalidPublicKey(t *testing.T) {
+
+	// identity
+	var pub PublicKey
+	pub.A.Y.SetOne()
+	if pub.IsValid() {
+		t.Fatal("the identity should not be a valid public key")
+	}
+	if _, err := Encrypt(rand.Reader, &pub, []byte("msg"), nil); err != ErrInvalidPublicKey {
+		t.Fatal("expected ErrInvalidPublicKey")
+	}
+
+	// point of small order: (0, -1) is of order 2
+	pub.A.Y.Neg(&pub.A.Y)
+	if !pub.A.IsOnCurve() || pub.IsValid() {
+		t.Fatal("a point of small order should not be a valid public key")
+	}
+
+	// round trip through the compressed form
+	priv, err := GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded PublicKey
+	if _, err = decoded.SetBytes(priv.PublicKey.Bytes()); err != nil {
+		t.Fatal(err)
+	}
+	if !decoded.A.Equal(&priv.PublicKey.A) {
+		t.Fatal("SetBytes(Bytes()) failed")
+	}
+
+	// base point is valid
+	c := twistededwards.GetEdwardsCurve()
+	pub.A.Set(&c.Base)
+	if !pub.IsValid() {
+		t.Fatal("the base point should be a valid public key")
+	}
+}
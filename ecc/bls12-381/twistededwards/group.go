@@ -0,0 +1,263 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package twistededwards
+
+import (
+	"crypto/rand"
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/group"
+)
+
+// Group returns the prime order subgroup of the twisted Edwards curve as a group.Group
+func Group() group.Group {
+	return edwardsGroup{}
+}
+
+type edwardsGroup struct{}
+
+func (edwardsGroup) String() string {
+	return "bls12-381/twistededwards"
+}
+
+func (edwardsGroup) Order() *big.Int {
+	c := GetEdwardsCurve()
+	return &c.Order
+}
+
+func (edwardsGroup) NewScalar() group.Scalar {
+	return new(scalar)
+}
+
+func (edwardsGroup) NewPoint() group.Point {
+	return new(point).SetIdentity()
+}
+
+func (edwardsGroup) Generator() group.Point {
+	return new(point).SetGenerator()
+}
+
+func (edwardsGroup) ScalarSize() int {
+	return scalarSize()
+}
+
+func (edwardsGroup) PointSize() int {
+	return sizePointCompressed
+}
+
+func (edwardsGroup) MultiScalarMul(points []group.Point, scalars []group.Scalar) (group.Point, error) {
+	if len(points) != len(scalars) {
+		return nil, group.ErrSizeMismatch
+	}
+	_points := make([]PointProj, len(points))
+	_scalars := make([]big.Int, len(scalars))
+	for i := range points {
+		_points[i] = points[i].(*point).p
+		_scalars[i] = scalars[i].(*scalar).v
+	}
+	res := new(point)
+	if _, err := res.p.MultiExp(BatchFromProj(_points), _scalars, ecc.MultiExpConfig{}); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// point implements group.Point
+type point struct {
+	p PointProj
+}
+
+func (p *point) Set(a group.Point) group.Point {
+	p.p.Set(&a.(*point).p)
+	return p
+}
+
+func (p *point) SetIdentity() group.Point {
+	p.p.setInfinity()
+	return p
+}
+
+func (p *point) SetGenerator() group.Point {
+	c := GetEdwardsCurve()
+	p.p.FromAffine(&c.Base)
+	return p
+}
+
+func (p *point) Add(a, b group.Point) group.Point {
+	p.p.Add(&a.(*point).p, &b.(*point).p)
+	return p
+}
+
+func (p *point) Sub(a, b group.Point) group.Point {
+	var nb PointProj
+	nb.Neg(&b.(*point).p)
+	p.p.Add(&a.(*point).p, &nb)
+	return p
+}
+
+func (p *point) Neg(a group.Point) group.Point {
+	p.p.Neg(&a.(*point).p)
+	return p
+}
+
+func (p *point) Double(a group.Point) group.Point {
+	p.p.Double(&a.(*point).p)
+	return p
+}
+
+func (p *point) ScalarMul(a group.Point, s group.Scalar) group.Point {
+	p.p.ScalarMultiplication(&a.(*point).p, &s.(*scalar).v)
+	return p
+}
+
+func (p *point) Equal(a group.Point) bool {
+	return p.p.Equal(&a.(*point).p)
+}
+
+func (p *point) IsIdentity() bool {
+	return p.p.IsZero()
+}
+
+func (p *point) Bytes() []byte {
+	var a PointAffine
+	a.FromProj(&p.p)
+	b := a.Bytes()
+	return b[:]
+}
+
+// SetBytes sets p from its compressed encoding, and checks that it is the canonical
+// encoding of a point of the prime order subgroup
+func (p *point) SetBytes(buf []byte) error {
+	if len(buf) != sizePointCompressed {
+		return group.ErrInvalidEncoding
+	}
+	var a PointAffine
+	if _, err := a.SetBytes(buf); err != nil {
+		return err
+	}
+	if b := a.Bytes(); string(b[:]) != string(buf) {
+		return group.ErrInvalidEncoding
+	}
+	if !a.IsInSubGroup() {
+		return group.ErrInvalidPoint
+	}
+	p.p.FromAffine(&a)
+	return nil
+}
+
+// scalar implements group.Scalar, it is always reduced modulo the order of the group
+type scalar struct {
+	v big.Int
+}
+
+func scalarSize() int {
+	c := GetEdwardsCurve()
+	return (c.Order.BitLen() + 7) / 8
+}
+
+func (z *scalar) reduce() group.Scalar {
+	c := GetEdwardsCurve()
+	z.v.Mod(&z.v, &c.Order)
+	return z
+}
+
+func (z *scalar) Set(a group.Scalar) group.Scalar {
+	z.v.Set(&a.(*scalar).v)
+	return z
+}
+
+func (z *scalar) SetUint64(v uint64) group.Scalar {
+	z.v.SetUint64(v)
+	return z.reduce()
+}
+
+func (z *scalar) SetBigInt(v *big.Int) group.Scalar {
+	z.v.Set(v)
+	return z.reduce()
+}
+
+func (z *scalar) SetRandom() (group.Scalar, error) {
+	c := GetEdwardsCurve()
+	v, err := rand.Int(rand.Reader, &c.Order)
+	if err != nil {
+		return nil, err
+	}
+	z.v.Set(v)
+	return z, nil
+}
+
+func (z *scalar) Add(a, b group.Scalar) group.Scalar {
+	z.v.Add(&a.(*scalar).v, &b.(*scalar).v)
+	return z.reduce()
+}
+
+func (z *scalar) Sub(a, b group.Scalar) group.Scalar {
+	z.v.Sub(&a.(*scalar).v, &b.(*scalar).v)
+	return z.reduce()
+}
+
+func (z *scalar) Mul(a, b group.Scalar) group.Scalar {
+	z.v.Mul(&a.(*scalar).v, &b.(*scalar).v)
+	return z.reduce()
+}
+
+func (z *scalar) Neg(a group.Scalar) group.Scalar {
+	z.v.Neg(&a.(*scalar).v)
+	return z.reduce()
+}
+
+func (z *scalar) Inverse(a group.Scalar) group.Scalar {
+	if a.IsZero() {
+		z.v.SetUint64(0)
+		return z
+	}
+	c := GetEdwardsCurve()
+	z.v.ModInverse(&a.(*scalar).v, &c.Order)
+	return z
+}
+
+func (z *scalar) Equal(a group.Scalar) bool {
+	return z.v.Cmp(&a.(*scalar).v) == 0
+}
+
+func (z *scalar) IsZero() bool {
+	return z.v.Sign() == 0
+}
+
+func (z *scalar) BigInt(res *big.Int) *big.Int {
+	return res.Set(&z.v)
+}
+
+func (z *scalar) Bytes() []byte {
+	b := make([]byte, scalarSize())
+	return z.v.FillBytes(b)
+}
+
+func (z *scalar) SetBytes(buf []byte) error {
+	if len(buf) != scalarSize() {
+		return group.ErrInvalidScalar
+	}
+	var v big.Int
+	v.SetBytes(buf)
+	c := GetEdwardsCurve()
+	if v.Cmp(&c.Order) >= 0 {
+		return group.ErrInvalidScalar
+	}
+	z.v.Set(&v)
+	return nil
+}
@@ -0,0 +1,89 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package ringsig
+
+import (
+	"io"
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/twistededwards"
+)
+
+// sizeScalar number of bytes of a scalar modulo the order of the subgroup
+var sizeScalar = func() int {
+	c := twistededwards.GetEdwardsCurve()
+	return (c.Order.BitLen() + 7) / 8
+}()
+
+// Bytes returns the compressed public key
+func (pub *PublicKey) Bytes() []byte {
+	b := pub.A.Bytes()
+	return b[:]
+}
+
+// SetBytes sets pub from its compressed form and checks it is a valid key.
+// It returns the number of bytes read.
+func (pub *PublicKey) SetBytes(buf []byte) (int, error) {
+	n, err := pub.A.SetBytes(buf)
+	if err != nil {
+		return n, err
+	}
+	if !isValid(&pub.A) {
+		return n, ErrInvalidKey
+	}
+	return n, nil
+}
+
+// Bytes returns the binary representation of the signature as
+// I||c₀||s₀||...||sₙ₋₁, where I is compressed and the scalars are in big endian.
+func (sig *Signature) Bytes() []byte {
+	res := make([]byte, 0, fr.Bytes+(len(sig.S)+1)*sizeScalar)
+	b := sig.KeyImage.Bytes()
+	res = append(res, b[:]...)
+	res = appendScalar(res, &sig.C)
+	for i := range sig.S {
+		res = appendScalar(res, &sig.S[i])
+	}
+	return res
+}
+
+// SetBytes sets sig from its binary representation, for a ring of size ringSize.
+// It returns the number of bytes read.
+func (sig *Signature) SetBytes(buf []byte, ringSize int) (int, error) {
+	if len(buf) < fr.Bytes+(ringSize+1)*sizeScalar {
+		return 0, io.ErrShortBuffer
+	}
+	n, err := sig.KeyImage.SetBytes(buf)
+	if err != nil {
+		return n, err
+	}
+	sig.C.SetBytes(buf[n : n+sizeScalar])
+	n += sizeScalar
+	sig.S = make([]big.Int, ringSize)
+	for i := range sig.S {
+		sig.S[i].SetBytes(buf[n : n+sizeScalar])
+		n += sizeScalar
+	}
+	return n, nil
+}
+
+func appendScalar(buf []byte, s *big.Int) []byte {
+	b := make([]byte, sizeScalar)
+	s.FillBytes(b)
+	return append(buf, b...)
+}
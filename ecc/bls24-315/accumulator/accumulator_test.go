@@ -0,0 +1,171 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package accumulator
+
+import (
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls24-315/fr"
+)
+
+func randomElements(n int) []fr.Element {
+	res := make([]fr.Element, n)
+	for i := range res {
+		res[i].SetRandom()
+	}
+	return res
+}
+
+func TestAccumulator(t *testing.T) {
+	t.Parallel()
+
+	priv, err := GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	pub := &priv.PublicKey
+
+	set := randomElements(10)
+	acc, err := priv.NewAccumulator(set...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	v := acc.Value()
+
+	// membership
+	mw, err := acc.MembershipWitness(set[3])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := pub.Verify(&v, &mw); err != nil {
+		t.Fatal(err)
+	}
+	wrong := mw
+	wrong.Element.SetRandom()
+	if err := pub.Verify(&v, &wrong); err != ErrInvalidWitness {
+		t.Fatal("verifying a membership witness of another element should fail")
+	}
+
+	// non-membership
+	y := randomElements(1)[0]
+	nmw, err := acc.NonMembershipWitness(y)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := pub.VerifyNonMembership(&v, &nmw); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := acc.NonMembershipWitness(set[0]); err != ErrMember {
+		t.Fatal("a non-membership witness of an accumulated element shouldn't exist")
+	}
+	wrongNm := nmw
+	wrongNm.R.Double(&wrongNm.R)
+	if err := pub.VerifyNonMembership(&v, &wrongNm); err != ErrInvalidWitness {
+		t.Fatal("verifying a wrong non-membership witness should fail")
+	}
+
+	// batch insert: the witnesses are updated without the secret
+	added := randomElements(5)
+	u, err := acc.Add(added...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	v = acc.Value()
+	if err := mw.Update(&u); err != nil {
+		t.Fatal(err)
+	}
+	if err := pub.Verify(&v, &mw); err != nil {
+		t.Fatal(err)
+	}
+	if err := nmw.Update(&u); err != nil {
+		t.Fatal(err)
+	}
+	if err := pub.VerifyNonMembership(&v, &nmw); err != nil {
+		t.Fatal(err)
+	}
+	expected, _ := acc.MembershipWitness(set[3])
+	if !expected.W.Equal(&mw.W) {
+		t.Fatal("the updated witness should match a fresh witness")
+	}
+
+	// batch delete
+	u, err = acc.Delete(set[0], added[2], set[7])
+	if err != nil {
+		t.Fatal(err)
+	}
+	v = acc.Value()
+	if err := mw.Update(&u); err != nil {
+		t.Fatal(err)
+	}
+	if err := pub.Verify(&v, &mw); err != nil {
+		t.Fatal(err)
+	}
+	if err := nmw.Update(&u); err != nil {
+		t.Fatal(err)
+	}
+	if err := pub.VerifyNonMembership(&v, &nmw); err != nil {
+		t.Fatal(err)
+	}
+	expectedNm, _ := acc.NonMembershipWitness(y)
+	if !expectedNm.W.Equal(&nmw.W) || !expectedNm.R.Equal(&nmw.R) {
+		t.Fatal("the updated non-membership witness should match a fresh witness")
+	}
+	if acc.Len() != 12 || acc.Contains(&set[0]) || !acc.Contains(&added[0]) {
+		t.Fatal("unexpected accumulated set")
+	}
+
+	// revocation
+	u, err = acc.Delete(set[3])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := mw.Update(&u); err != ErrRevoked {
+		t.Fatal("updating the witness of a deleted element should fail")
+	}
+	u, err = acc.Add(y)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := nmw.Update(&u); err != ErrMember {
+		t.Fatal("updating the non-membership witness of an added element should fail")
+	}
+
+	// invalid operations
+	if _, err := acc.Add(added[0]); err != ErrMember {
+		t.Fatal("adding an accumulated element should fail")
+	}
+	if _, err := acc.Delete(set[0]); err != ErrNotMember {
+		t.Fatal("deleting an element which is not accumulated should fail")
+	}
+	if _, err := acc.MembershipWitness(set[0]); err != ErrNotMember {
+		t.Fatal("a membership witness of a deleted element shouldn't exist")
+	}
+}
+
+func BenchmarkWitnessUpdate(b *testing.B) {
+	priv, _ := GenerateKey()
+	set := randomElements(10)
+	acc, _ := priv.NewAccumulator(set...)
+	w, _ := acc.MembershipWitness(set[0])
+	u, _ := acc.Add(randomElements(64)...)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		tmp := w
+		_ = tmp.Update(&u)
+	}
+}
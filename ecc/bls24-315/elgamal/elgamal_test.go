@@ -0,0 +1,135 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package elgamal
+
+import (
+	"crypto/rand"
+	"math/big"
+	"testing"
+)
+
+func TestEncryptDecrypt(t *testing.T) {
+
+	priv, err := GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pub := &priv.PublicKey
+	table, err := NewDecryptionTable(1000)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, m := range []uint64{0, 1, 31, 32, 999} {
+		c, err := pub.Encrypt(rand.Reader, new(big.Int).SetUint64(m))
+		if err != nil {
+			t.Fatal(err)
+		}
+		d, err := priv.Decrypt(&c, table)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if d != m {
+			t.Fatalf("decrypted %d instead of %d", d, m)
+		}
+	}
+
+	// out of range
+	c, err := pub.Encrypt(rand.Reader, new(big.Int).SetUint64(table.Max()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = priv.Decrypt(&c, table); err != ErrPlaintextOutOfRange {
+		t.Fatal("expected ErrPlaintextOutOfRange")
+	}
+
+	// serialization
+	var reconstructed Ciphertext
+	if _, err = reconstructed.SetBytes(c.Bytes()); err != nil {
+		t.Fatal(err)
+	}
+	if !reconstructed.C1.Equal(&c.C1) || !reconstructed.C2.Equal(&c.C2) {
+		t.Fatal("SetBytes(Bytes()) failed")
+	}
+}
+
+func TestHomomorphism(t *testing.T) {
+
+	priv, err := GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pub := &priv.PublicKey
+	table, err := NewDecryptionTable(1 << 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	a, err := pub.Encrypt(rand.Reader, big.NewInt(42))
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := pub.Encrypt(rand.Reader, big.NewInt(58))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var c Ciphertext
+	c.Add(&a, &b)
+	if m, err := priv.Decrypt(&c, table); err != nil || m != 100 {
+		t.Fatal("wrong sum")
+	}
+
+	if err = c.CheckedSub(&b, &a); err != nil {
+		t.Fatal(err)
+	}
+	if m, err := priv.Decrypt(&c, table); err != nil || m != 16 {
+		t.Fatal("wrong difference")
+	}
+
+	c.ScalarMultiplication(&a, big.NewInt(3))
+	if m, err := priv.Decrypt(&c, table); err != nil || m != 126 {
+		t.Fatal("wrong scalar multiplication")
+	}
+
+	// re-randomization changes the ciphertext, not the message
+	c, err = pub.Rerandomize(rand.Reader, &a)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.C1.Equal(&a.C1) {
+		t.Fatal("re-randomized ciphertext should differ")
+	}
+	if m, err := priv.Decrypt(&c, table); err != nil || m != 42 {
+		t.Fatal("wrong re-randomized message")
+	}
+
+	// subtracting ciphertexts sharing the same randomness is detected
+	if err = c.CheckedSub(&a, &a); err != ErrRandomnessReuse {
+		t.Fatal("expected ErrRandomnessReuse")
+	}
+}
+
+func BenchmarkDecrypt(b *testing.B) {
+	priv, _ := GenerateKey(rand.Reader)
+	table, _ := NewDecryptionTable(1 << 20)
+	c, _ := priv.PublicKey.Encrypt(rand.Reader, big.NewInt(1<<20-1))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		priv.Decrypt(&c, table)
+	}
+}
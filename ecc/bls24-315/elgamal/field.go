@@ -0,0 +1,196 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package elgamal
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc/bls24-315/fr"
+	"github.com/consensys/gnark-crypto/homomorphic"
+)
+
+// Encryption of scalar field elements.
+//
+// Exponential ElGamal only decrypts small messages. To encrypt an element of fr,
+// the message is split in limbs of limbBits bits, and each limb is encrypted
+// separately. Ciphertexts are added limb-wise, without carries, so the limbs
+// of a sum of at most MaxAdditions+1 ciphertexts stay below 2^(limbBits+8) and
+// can be decrypted with a fixed table. The message is recomposed modulo r.
+
+const (
+	limbBits = 16
+	nbLimbs  = (fr.Bits + limbBits - 1) / limbBits
+
+	// MaxAdditions is the maximum number of homomorphic additions a ciphertext
+	// of a field element can result from.
+	MaxAdditions = 255
+)
+
+var (
+	ErrTooManyAdditions = errors.New("too many homomorphic additions")
+)
+
+// FieldPublicKey public key to encrypt elements of fr, implements homomorphic.PublicKey
+type FieldPublicKey struct {
+	PublicKey
+}
+
+// FieldPrivateKey private key to decrypt elements of fr, implements homomorphic.Decrypter
+type FieldPrivateKey struct {
+	PrivateKey
+	table *DecryptionTable
+}
+
+// FieldCiphertext encryption of an element of fr, limb by limb
+type FieldCiphertext struct {
+	// number of ciphertexts added to obtain this one, minus one
+	additions uint16
+	limbs     [nbLimbs]Ciphertext
+}
+
+var (
+	_ homomorphic.PublicKey = &FieldPublicKey{}
+	_ homomorphic.Decrypter = &FieldPrivateKey{}
+)
+
+// GenerateFieldKey generates a key pair to encrypt elements of fr, and the
+// decryption table for the limbs.
+func GenerateFieldKey(r io.Reader) (*FieldPrivateKey, error) {
+	priv, err := GenerateKey(r)
+	if err != nil {
+		return nil, err
+	}
+	table, err := NewDecryptionTable((MaxAdditions + 1) << limbBits)
+	if err != nil {
+		return nil, err
+	}
+	return &FieldPrivateKey{PrivateKey: *priv, table: table}, nil
+}
+
+// Public returns the public key associated to the private key.
+func (priv *FieldPrivateKey) Public() homomorphic.PublicKey {
+	return &FieldPublicKey{PublicKey: priv.PublicKey}
+}
+
+// Encrypt encrypts m mod r, limb by limb
+func (pub *FieldPublicKey) Encrypt(r io.Reader, m *big.Int) ([]byte, error) {
+	var mm big.Int
+	mm.Mod(m, fr.Modulus())
+
+	var c FieldCiphertext
+	var limb big.Int
+	mask := big.NewInt(1<<limbBits - 1)
+	for i := 0; i < nbLimbs; i++ {
+		limb.And(&mm, mask)
+		mm.Rsh(&mm, limbBits)
+		var err error
+		if c.limbs[i], err = pub.PublicKey.Encrypt(r, &limb); err != nil {
+			return nil, err
+		}
+	}
+	return c.Bytes(), nil
+}
+
+// Add returns an encryption of the sum of the messages encrypted in a and b.
+// It returns ErrTooManyAdditions if the result would not be decryptable.
+func (pub *FieldPublicKey) Add(a, b []byte) ([]byte, error) {
+	var ca, cb FieldCiphertext
+	if _, err := ca.SetBytes(a); err != nil {
+		return nil, err
+	}
+	if _, err := cb.SetBytes(b); err != nil {
+		return nil, err
+	}
+	if int(ca.additions)+int(cb.additions)+1 > MaxAdditions {
+		return nil, ErrTooManyAdditions
+	}
+	var res FieldCiphertext
+	res.additions = ca.additions + cb.additions + 1
+	for i := 0; i < nbLimbs; i++ {
+		res.limbs[i].Add(&ca.limbs[i], &cb.limbs[i])
+	}
+	return res.Bytes(), nil
+}
+
+// Bytes returns the compressed public key
+func (pub *FieldPublicKey) Bytes() []byte {
+	b := pub.H.Bytes()
+	return b[:]
+}
+
+// SetBytes sets the public key from its compressed form and validates it.
+// It returns the number of bytes read.
+func (pub *FieldPublicKey) SetBytes(buf []byte) (int, error) {
+	n, err := pub.H.SetBytes(buf)
+	if err != nil {
+		return n, err
+	}
+	return n, pub.Validate()
+}
+
+// Decrypt returns the element of fr encrypted in c
+func (priv *FieldPrivateKey) Decrypt(c []byte) (*big.Int, error) {
+	var fc FieldCiphertext
+	if _, err := fc.SetBytes(c); err != nil {
+		return nil, err
+	}
+
+	var res, limb big.Int
+	for i := nbLimbs - 1; i >= 0; i-- {
+		m, err := priv.PrivateKey.Decrypt(&fc.limbs[i], priv.table)
+		if err != nil {
+			return nil, err
+		}
+		limb.SetUint64(m)
+		res.Lsh(&res, limbBits).Add(&res, &limb)
+	}
+	return res.Mod(&res, fr.Modulus()), nil
+}
+
+// Bytes returns the encoding of the ciphertext: the number of additions on 2 bytes,
+// followed by the limbs' ciphertexts
+func (c *FieldCiphertext) Bytes() []byte {
+	res := make([]byte, 2, 2+nbLimbs*sizeCiphertext)
+	binary.BigEndian.PutUint16(res, c.additions)
+	for i := 0; i < nbLimbs; i++ {
+		res = append(res, c.limbs[i].Bytes()...)
+	}
+	return res
+}
+
+// SetBytes sets c from its encoding. It returns the number of bytes read.
+func (c *FieldCiphertext) SetBytes(buf []byte) (int, error) {
+	if len(buf) < 2+nbLimbs*sizeCiphertext {
+		return 0, ErrInvalidCiphertext
+	}
+	c.additions = binary.BigEndian.Uint16(buf)
+	if c.additions > MaxAdditions {
+		return 2, ErrTooManyAdditions
+	}
+	n := 2
+	for i := 0; i < nbLimbs; i++ {
+		m, err := c.limbs[i].SetBytes(buf[n:])
+		n += m
+		if err != nil {
+			return n, err
+		}
+	}
+	return n, nil
+}
@@ -1,4 +1,5 @@
-// +build !amd64_adx
+//go:build !amd64_adx && !purego
+// +build !amd64_adx,!purego
 
 // Copyright 2020 ConsenSys Software Inc.
 //
@@ -445,6 +446,361 @@ l1:
 	CALL ·_mulGeneric(SB)
 	RET
 
+// square(res, x *Element)
+TEXT ·square(SB), $16-16
+
+	// the algorithm is described in the Element.Square declaration (.go)
+	// this is the CIOS multiplication of x by x (see mul), where round i
+	// only computes the products of x[i] with x[i], 2x[i+1], ..., 2x[N-1]
+
+	NO_LOCAL_POINTERS
+	CMPB ·supportAdx(SB), $1
+	JNE  l2
+	MOVQ x+8(FP), R14
+
+	// y = 2x
+	MOVQ 0(R14), AX
+	ADDQ AX, AX
+	MOVQ 8(R14), AX
+	ADCQ AX, AX
+	MOVQ 16(R14), AX
+	ADCQ AX, AX
+	MOVQ AX, R10
+	MOVQ 24(R14), AX
+	ADCQ AX, AX
+	MOVQ AX, R11
+	MOVQ 32(R14), AX
+	ADCQ AX, AX
+	MOVQ AX, R12
+
+	// A -> BP
+	// t[0] -> R13
+	// t[1] -> CX
+	// t[2] -> BX
+	// t[3] -> SI
+	// t[4] -> DI
+	MOVQ 8(R14), R9
+	ADDQ R9, R9
+
+	// clear the flags
+	XORQ AX, AX
+	MOVQ 0(R14), DX
+
+	// (A,t[0])  := x[0]*x[0] + A
+	MULXQ DX, R13, CX
+
+	// (A,t[1])  := 2x[1]*x[0] + A
+	MULXQ R9, AX, BX
+	ADOXQ AX, CX
+
+	// (A,t[2])  := 2x[2]*x[0] + A
+	MULXQ R10, AX, SI
+	ADOXQ AX, BX
+
+	// (A,t[3])  := 2x[3]*x[0] + A
+	MULXQ R11, AX, DI
+	ADOXQ AX, SI
+
+	// (A,t[4])  := 2x[4]*x[0] + A
+	MULXQ R12, AX, BP
+	ADOXQ AX, DI
+
+	// A += carries from ADCXQ and ADOXQ
+	MOVQ  $0, AX
+	ADOXQ AX, BP
+
+	// m := t[0]*q'[0] mod W
+	MOVQ  qInv0<>(SB), DX
+	IMULQ R13, DX
+
+	// clear the flags
+	XORQ AX, AX
+
+	// C,_ := t[0] + m*q[0]
+	MULXQ q<>+0(SB), AX, R8
+	ADCXQ R13, AX
+	MOVQ  R8, R13
+
+	// (C,t[0]) := t[1] + m*q[1] + C
+	ADCXQ CX, R13
+	MULXQ q<>+8(SB), AX, CX
+	ADOXQ AX, R13
+
+	// (C,t[1]) := t[2] + m*q[2] + C
+	ADCXQ BX, CX
+	MULXQ q<>+16(SB), AX, BX
+	ADOXQ AX, CX
+
+	// (C,t[2]) := t[3] + m*q[3] + C
+	ADCXQ SI, BX
+	MULXQ q<>+24(SB), AX, SI
+	ADOXQ AX, BX
+
+	// (C,t[3]) := t[4] + m*q[4] + C
+	ADCXQ DI, SI
+	MULXQ q<>+32(SB), AX, DI
+	ADOXQ AX, SI
+
+	// t[4] = C + A
+	MOVQ  $0, AX
+	ADCXQ AX, DI
+	ADOXQ BP, DI
+	MOVQ  16(R14), R9
+	ADDQ  R9, R9
+
+	// clear the flags
+	XORQ AX, AX
+	MOVQ 8(R14), DX
+
+	// (A,t[1])  := t[1] + x[1]*x[1]
+	MULXQ DX, AX, BP
+	ADOXQ AX, CX
+
+	// (A,t[2])  := t[2] + 2x[2]*x[1] + A
+	ADCXQ BP, BX
+	MULXQ R9, AX, BP
+	ADOXQ AX, BX
+
+	// (A,t[3])  := t[3] + 2x[3]*x[1] + A
+	ADCXQ BP, SI
+	MULXQ R11, AX, BP
+	ADOXQ AX, SI
+
+	// (A,t[4])  := t[4] + 2x[4]*x[1] + A
+	ADCXQ BP, DI
+	MULXQ R12, AX, BP
+	ADOXQ AX, DI
+
+	// A += carries from ADCXQ and ADOXQ
+	MOVQ  $0, AX
+	ADCXQ AX, BP
+	ADOXQ AX, BP
+
+	// m := t[0]*q'[0] mod W
+	MOVQ  qInv0<>(SB), DX
+	IMULQ R13, DX
+
+	// clear the flags
+	XORQ AX, AX
+
+	// C,_ := t[0] + m*q[0]
+	MULXQ q<>+0(SB), AX, R8
+	ADCXQ R13, AX
+	MOVQ  R8, R13
+
+	// (C,t[0]) := t[1] + m*q[1] + C
+	ADCXQ CX, R13
+	MULXQ q<>+8(SB), AX, CX
+	ADOXQ AX, R13
+
+	// (C,t[1]) := t[2] + m*q[2] + C
+	ADCXQ BX, CX
+	MULXQ q<>+16(SB), AX, BX
+	ADOXQ AX, CX
+
+	// (C,t[2]) := t[3] + m*q[3] + C
+	ADCXQ SI, BX
+	MULXQ q<>+24(SB), AX, SI
+	ADOXQ AX, BX
+
+	// (C,t[3]) := t[4] + m*q[4] + C
+	ADCXQ DI, SI
+	MULXQ q<>+32(SB), AX, DI
+	ADOXQ AX, SI
+
+	// t[4] = C + A
+	MOVQ  $0, AX
+	ADCXQ AX, DI
+	ADOXQ BP, DI
+	MOVQ  24(R14), R9
+	ADDQ  R9, R9
+
+	// clear the flags
+	XORQ AX, AX
+	MOVQ 16(R14), DX
+
+	// (A,t[2])  := t[2] + x[2]*x[2]
+	MULXQ DX, AX, BP
+	ADOXQ AX, BX
+
+	// (A,t[3])  := t[3] + 2x[3]*x[2] + A
+	ADCXQ BP, SI
+	MULXQ R9, AX, BP
+	ADOXQ AX, SI
+
+	// (A,t[4])  := t[4] + 2x[4]*x[2] + A
+	ADCXQ BP, DI
+	MULXQ R12, AX, BP
+	ADOXQ AX, DI
+
+	// A += carries from ADCXQ and ADOXQ
+	MOVQ  $0, AX
+	ADCXQ AX, BP
+	ADOXQ AX, BP
+
+	// m := t[0]*q'[0] mod W
+	MOVQ  qInv0<>(SB), DX
+	IMULQ R13, DX
+
+	// clear the flags
+	XORQ AX, AX
+
+	// C,_ := t[0] + m*q[0]
+	MULXQ q<>+0(SB), AX, R8
+	ADCXQ R13, AX
+	MOVQ  R8, R13
+
+	// (C,t[0]) := t[1] + m*q[1] + C
+	ADCXQ CX, R13
+	MULXQ q<>+8(SB), AX, CX
+	ADOXQ AX, R13
+
+	// (C,t[1]) := t[2] + m*q[2] + C
+	ADCXQ BX, CX
+	MULXQ q<>+16(SB), AX, BX
+	ADOXQ AX, CX
+
+	// (C,t[2]) := t[3] + m*q[3] + C
+	ADCXQ SI, BX
+	MULXQ q<>+24(SB), AX, SI
+	ADOXQ AX, BX
+
+	// (C,t[3]) := t[4] + m*q[4] + C
+	ADCXQ DI, SI
+	MULXQ q<>+32(SB), AX, DI
+	ADOXQ AX, SI
+
+	// t[4] = C + A
+	MOVQ  $0, AX
+	ADCXQ AX, DI
+	ADOXQ BP, DI
+	MOVQ  32(R14), R9
+	ADDQ  R9, R9
+
+	// clear the flags
+	XORQ AX, AX
+	MOVQ 24(R14), DX
+
+	// (A,t[3])  := t[3] + x[3]*x[3]
+	MULXQ DX, AX, BP
+	ADOXQ AX, SI
+
+	// (A,t[4])  := t[4] + 2x[4]*x[3] + A
+	ADCXQ BP, DI
+	MULXQ R9, AX, BP
+	ADOXQ AX, DI
+
+	// A += carries from ADCXQ and ADOXQ
+	MOVQ  $0, AX
+	ADCXQ AX, BP
+	ADOXQ AX, BP
+
+	// m := t[0]*q'[0] mod W
+	MOVQ  qInv0<>(SB), DX
+	IMULQ R13, DX
+
+	// clear the flags
+	XORQ AX, AX
+
+	// C,_ := t[0] + m*q[0]
+	MULXQ q<>+0(SB), AX, R8
+	ADCXQ R13, AX
+	MOVQ  R8, R13
+
+	// (C,t[0]) := t[1] + m*q[1] + C
+	ADCXQ CX, R13
+	MULXQ q<>+8(SB), AX, CX
+	ADOXQ AX, R13
+
+	// (C,t[1]) := t[2] + m*q[2] + C
+	ADCXQ BX, CX
+	MULXQ q<>+16(SB), AX, BX
+	ADOXQ AX, CX
+
+	// (C,t[2]) := t[3] + m*q[3] + C
+	ADCXQ SI, BX
+	MULXQ q<>+24(SB), AX, SI
+	ADOXQ AX, BX
+
+	// (C,t[3]) := t[4] + m*q[4] + C
+	ADCXQ DI, SI
+	MULXQ q<>+32(SB), AX, DI
+	ADOXQ AX, SI
+
+	// t[4] = C + A
+	MOVQ  $0, AX
+	ADCXQ AX, DI
+	ADOXQ BP, DI
+
+	// clear the flags
+	XORQ AX, AX
+	MOVQ 32(R14), DX
+
+	// (A,t[4])  := t[4] + x[4]*x[4]
+	MULXQ DX, AX, BP
+	ADOXQ AX, DI
+
+	// A += carries from ADCXQ and ADOXQ
+	MOVQ  $0, AX
+	ADCXQ AX, BP
+	ADOXQ AX, BP
+
+	// m := t[0]*q'[0] mod W
+	MOVQ  qInv0<>(SB), DX
+	IMULQ R13, DX
+
+	// clear the flags
+	XORQ AX, AX
+
+	// C,_ := t[0] + m*q[0]
+	MULXQ q<>+0(SB), AX, R8
+	ADCXQ R13, AX
+	MOVQ  R8, R13
+
+	// (C,t[0]) := t[1] + m*q[1] + C
+	ADCXQ CX, R13
+	MULXQ q<>+8(SB), AX, CX
+	ADOXQ AX, R13
+
+	// (C,t[1]) := t[2] + m*q[2] + C
+	ADCXQ BX, CX
+	MULXQ q<>+16(SB), AX, BX
+	ADOXQ AX, CX
+
+	// (C,t[2]) := t[3] + m*q[3] + C
+	ADCXQ SI, BX
+	MULXQ q<>+24(SB), AX, SI
+	ADOXQ AX, BX
+
+	// (C,t[3]) := t[4] + m*q[4] + C
+	ADCXQ DI, SI
+	MULXQ q<>+32(SB), AX, DI
+	ADOXQ AX, SI
+
+	// t[4] = C + A
+	MOVQ  $0, AX
+	ADCXQ AX, DI
+	ADOXQ BP, DI
+
+	// reduce element(R13,CX,BX,SI,DI) using temp registers (R10,R11,R12,R8,R9)
+	REDUCE(R13,CX,BX,SI,DI,R10,R11,R12,R8,R9)
+
+	MOVQ res+0(FP), AX
+	MOVQ R13, 0(AX)
+	MOVQ CX, 8(AX)
+	MOVQ BX, 16(AX)
+	MOVQ SI, 24(AX)
+	MOVQ DI, 32(AX)
+	RET
+
+l2:
+	MOVQ res+0(FP), AX
+	MOVQ AX, (SP)
+	MOVQ x+8(FP), AX
+	MOVQ AX, 8(SP)
+	CALL ·_squareGeneric(SB)
+	RET
+
 TEXT ·fromMont(SB), $8-8
 	NO_LOCAL_POINTERS
 
@@ -460,7 +816,7 @@ TEXT ·fromMont(SB), $8-8
 	// 		    (C,t[j-1]) := t[j] + m*q[j] + C
 	// 		t[N-1] = C
 	CMPB ·supportAdx(SB), $1
-	JNE  l2
+	JNE  l3
 	MOVQ res+0(FP), DX
 	MOVQ 0(DX), R14
 	MOVQ 8(DX), R13
@@ -649,7 +1005,7 @@ TEXT ·fromMont(SB), $8-8
 	MOVQ SI, 32(AX)
 	RET
 
-l2:
+l3:
 	MOVQ res+0(FP), AX
 	MOVQ AX, (SP)
 	CALL ·_fromMontGeneric(SB)
@@ -1,5 +1,5 @@
-//go:build !amd64
-// +build !amd64
+//go:build (!amd64 && !wasm) || purego
+// +build !amd64,!wasm purego
 
 // Copyright 2020 ConsenSys Software Inc.
 //
@@ -49,10 +49,15 @@ func MulBy13(x *Element) {
 func Butterfly(a, b *Element) {
 	_butterflyGeneric(a, b)
 }
+
 func mul(z, x, y *Element) {
 	_mulGeneric(z, x, y)
 }
 
+func square(z, x *Element) {
+	_squareGeneric(z, x)
+}
+
 func fromMont(z *Element) {
 	_fromMontGeneric(z)
 }
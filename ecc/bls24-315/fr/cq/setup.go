@@ -0,0 +1,187 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package cq
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bls24-315"
+	"github.com/consensys/gnark-crypto/ecc/bls24-315/fr"
+	"github.com/consensys/gnark-crypto/ecc/bls24-315/fr/fft"
+	"github.com/consensys/gnark-crypto/ecc/bls24-315/fr/kzg"
+)
+
+var (
+	ErrTableSize = errors.New("the table is empty or larger than the SRS")
+)
+
+// SRS structured reference string for cq. Contrary to the KZG SRS, it contains
+// the powers of α in G₂ as well, which are needed by the verifier.
+//
+// implements io.ReaderFrom and io.WriterTo
+type SRS struct {
+	G1 []bls24315.G1Affine // [G₁ [α]G₁ , [α²]G₁, ... ]
+	G2 []bls24315.G2Affine // [G₂ [α]G₂ , [α²]G₂, ... ]
+}
+
+// NewSRS returns a new SRS using alpha as randomness source, for tables of
+// at most size elements. It contains size points in G₁ and size+1 points in G₂.
+//
+// In production, a SRS generated through MPC should be used.
+func NewSRS(size uint64, bAlpha *big.Int) (*SRS, error) {
+
+	if size < 2 {
+		return nil, kzg.ErrMinSRSSize
+	}
+
+	var srs SRS
+	srs.G1 = make([]bls24315.G1Affine, size)
+	srs.G2 = make([]bls24315.G2Affine, size+1)
+
+	var alpha fr.Element
+	alpha.SetBigInt(bAlpha)
+
+	_, _, gen1Aff, gen2Aff := bls24315.Generators()
+	srs.G1[0] = gen1Aff
+	srs.G2[0] = gen2Aff
+
+	alphas := make([]fr.Element, size)
+	alphas[0] = alpha
+	for i := 1; i < len(alphas); i++ {
+		alphas[i].Mul(&alphas[i-1], &alpha)
+	}
+	for i := 0; i < len(alphas); i++ {
+		alphas[i].FromMont()
+	}
+	g1s := bls24315.BatchScalarMultiplicationG1(&gen1Aff, alphas[:size-1])
+	copy(srs.G1[1:], g1s)
+	g2s := bls24315.BatchScalarMultiplicationG2(&gen2Aff, alphas)
+	copy(srs.G2[1:], g2s)
+
+	return &srs, nil
+}
+
+// KZG returns the KZG SRS made of the points of srs in G₁ and the first two points of srs in G₂.
+func (srs *SRS) KZG() *kzg.SRS {
+	return &kzg.SRS{
+		G1: srs.G1,
+		G2: [2]bls24315.G2Affine{srs.G2[0], srs.G2[1]},
+	}
+}
+
+// VerifyingKey data needed by the verifier to check lookups in a table.
+//
+// implements io.ReaderFrom and io.WriterTo
+type VerifyingKey struct {
+
+	// N size of the table
+	N uint64
+
+	// T commitment to the table [T(α)]G₂
+	T bls24315.G2Affine
+
+	// ZV commitment to the vanishing polynomial of the table domain [αᴺ-1]G₂
+	ZV bls24315.G2Affine
+}
+
+// ProvingKey preprocessed table. Once computed, the cost of proving a lookup
+// does not depend on the size of the table.
+//
+// implements io.ReaderFrom and io.WriterTo
+type ProvingKey struct {
+	Vk VerifyingKey
+
+	// Table values of the table, padded to a power of 2
+	Table []fr.Element
+
+	// Generator of the fft domain of the table
+	Generator fr.Element
+
+	// Lagrange commitments to the Lagrange polynomials [Lᵢ(α)]G₁
+	Lagrange []bls24315.G1Affine
+
+	// Quotients cached quotients [Qᵢ(α)]G₁ where Qᵢ = Lᵢ(T-tᵢ)/Z_V
+	Quotients []bls24315.G1Affine
+}
+
+// Setup preprocesses a table. The table is padded to the next power of 2 using its last element.
+//
+// The commitments to the Lagrange polynomials are computed with an inverse FFT on the
+// points of the SRS, and the cached quotients Qᵢ = ωⁱ/N*(T-tᵢ)/(X-ωⁱ) are computed at once,
+// as a Toeplitz matrix-vector product followed by an FFT, following Feist-Khovratovich.
+func Setup(srs *SRS, table []fr.Element) (*ProvingKey, error) {
+
+	if len(table) == 0 || ecc.NextPowerOfTwo(uint64(len(table))) > uint64(len(srs.G1)) || len(srs.G2) <= len(srs.G1) {
+		return nil, ErrTableSize
+	}
+
+	d := fft.NewDomain(uint64(len(table)))
+	n := int(d.Cardinality)
+
+	var pk ProvingKey
+	pk.Vk.N = d.Cardinality
+	pk.Generator.Set(&d.Generator)
+	pk.Table = make([]fr.Element, n)
+	copy(pk.Table, table)
+	for i := len(table); i < n; i++ {
+		pk.Table[i] = table[len(table)-1]
+	}
+
+	// T in canonical basis
+	ct := make([]fr.Element, n)
+	copy(ct, pk.Table)
+	d.FFTInverse(ct, fft.DIF)
+	fft.BitReverse(ct)
+
+	// [T(α)]G₂, [αᴺ-1]G₂
+	if _, err := pk.Vk.T.MultiExp(srs.G2[:n], ct, ecc.MultiExpConfig{ScalarsMont: true}); err != nil {
+		return nil, err
+	}
+	pk.Vk.ZV.Sub(&srs.G2[n], &srs.G2[0])
+
+	// [Lᵢ(α)]G₁ = 1/N ∑ⱼω⁻ⁱʲ[αʲ]G₁
+	lagrange := make([]bls24315.G1Jac, n)
+	for i := 0; i < n; i++ {
+		lagrange[i].FromAffine(&srs.G1[i])
+	}
+	fft.FFTG1(lagrange, d.GeneratorInv)
+	scalars := make([]fr.Element, n)
+	for i := 0; i < n; i++ {
+		scalars[i].Set(&d.CardinalityInv)
+	}
+	fft.ScaleG1(lagrange, scalars)
+	pk.Lagrange = bls24315.BatchJacobianToAffineG1(lagrange)
+
+	// [Kᵢ(α)]G₁ = [(T-tᵢ)/(X-ωⁱ)](α) = ∑ₘωⁱᵐhₘ where hₘ = ∑ⱼT_{m+j+1}[αʲ]G₁
+	h, err := fft.FeistKhovratovichG1(ct, srs.G1[:n])
+	if err != nil {
+		return nil, err
+	}
+	fft.FFTG1(h, d.Generator)
+
+	// [Qᵢ(α)]G₁ = ωⁱ/N*[Kᵢ(α)]G₁
+	scalars[0].Set(&d.CardinalityInv)
+	for i := 1; i < n; i++ {
+		scalars[i].Mul(&scalars[i-1], &d.Generator)
+	}
+	fft.ScaleG1(h, scalars)
+	pk.Quotients = bls24315.BatchJacobianToAffineG1(h)
+
+	return &pk, nil
+}
@@ -20,6 +20,10 @@ import (
 	"bytes"
 	"reflect"
 	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls24-315/fr"
+
+	"github.com/consensys/gnark-crypto/ecc"
 )
 
 func TestDomainSerialization(t *testing.T) {
@@ -45,3 +49,70 @@ func TestDomainSerialization(t *testing.T) {
 		t.Fatal("Domain.SetBytes(Bytes()) failed")
 	}
 }
+
+func TestBarycentricEvaluation(t *testing.T) {
+
+	const size = 1 << 5
+	domain := NewDomain(size)
+
+	// random polynomial in canonical basis, and its evaluations on the domain
+	pol := make([]fr.Element, size)
+	evaluations := make([]fr.Element, size)
+	for i := 0; i < size; i++ {
+		pol[i].SetRandom()
+	}
+	copy(evaluations, pol)
+	domain.FFT(evaluations, DIF, false)
+	BitReverse(evaluations)
+
+	// out of domain point
+	var x fr.Element
+	x.SetRandom()
+	expected := evaluatePolynomial(pol, x)
+	got := domain.BarycentricEvaluation(evaluations, x)
+	if !got.Equal(&expected) {
+		t.Fatal("barycentric evaluation out of the domain failed")
+	}
+
+	// with scratch memory taken from an arena
+	arena := ecc.NewArena(2 * size * fr.Limbs)
+	got = domain.BarycentricEvaluationWithArena(evaluations, x, arena)
+	if !got.Equal(&expected) || arena.Available() != 0 {
+		t.Fatal("barycentric evaluation with an arena failed")
+	}
+
+	// point in the domain
+	x.Square(&domain.Generator)
+	got = domain.BarycentricEvaluation(evaluations, x)
+	if !got.Equal(&evaluations[2]) {
+		t.Fatal("barycentric evaluation on the domain failed")
+	}
+
+	// ∑ᵢLᵢ(x) = 1 and ∑ᵢpᵢLᵢ(x) = p(x)
+	x.SetRandom()
+	expected = evaluatePolynomial(pol, x)
+	var sumL, sumP, l, tmp fr.Element
+	for i := uint64(0); i < size; i++ {
+		l = domain.EvaluateLagrangePolynomial(i, x)
+		sumL.Add(&sumL, &l)
+		tmp.Mul(&l, &evaluations[i])
+		sumP.Add(&sumP, &tmp)
+	}
+	if !sumL.IsOne() {
+		t.Fatal("the Lagrange polynomials do not sum to one")
+	}
+	if !sumP.Equal(&expected) {
+		t.Fatal("evaluation in the Lagrange basis failed")
+	}
+
+	// Lᵢ(ωʲ) = δᵢⱼ
+	x.Set(&domain.Generator)
+	l = domain.EvaluateLagrangePolynomial(1, x)
+	if !l.IsOne() {
+		t.Fatal("L₁(ω) should be one")
+	}
+	l = domain.EvaluateLagrangePolynomial(3, x)
+	if !l.IsZero() {
+		t.Fatal("L₃(ω) should be zero")
+	}
+}
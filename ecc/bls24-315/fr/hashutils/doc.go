@@ -0,0 +1,30 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+// Package hashutils provides helpers to hash arbitrary data to fr elements
+// with a uniform output, for use in transcripts and challenge derivation.
+//
+// A single 256-bit digest reduced modulo r is biased when r is not close to a
+// power of 2; the helpers here expand the digest to at least 128 bits more than
+// the size of r before reducing, which makes the bias negligible.
+//
+// PackElements and PackPoints define the encoding of field elements and curve points
+// absorbed in a hash function (e.g. BLAKE3, see hash/blake3) or bound to a fiatshamir
+// transcript.
+//
+// HashStream hashes an io.Reader to a sequence of elements, one per chunk, for
+// commitments to large files (data availability), and MerkleRoot compresses them.
+package hashutils
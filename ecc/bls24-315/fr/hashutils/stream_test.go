@@ -0,0 +1,130 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package hashutils
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"math/rand"
+	"testing"
+	"testing/iotest"
+
+	"github.com/consensys/gnark-crypto/ecc/bls24-315/fr"
+	"github.com/consensys/gnark-crypto/internal/parallel"
+)
+
+// hashStreamNaive hashes data chunk by chunk, following the definition of HashStream
+func hashStreamNaive(data []byte, chunkSize int) []fr.Element {
+	padded := make([]byte, (len(data)/chunkSize+1)*chunkSize)
+	copy(padded, data)
+	padded[len(data)] = 0x80
+	res := make([]fr.Element, len(padded)/chunkSize)
+	var index [8]byte
+	for i := range res {
+		binary.BigEndian.PutUint64(index[:], uint64(i))
+		res[i] = HashToFr(sha256.New, index[:], padded[i*chunkSize:(i+1)*chunkSize])
+	}
+	return res
+}
+
+func TestHashStream(t *testing.T) {
+	const chunkSize = 32
+	rng := rand.New(rand.NewSource(0)) //#nosec G404 weak rng is fine here
+	data := make([]byte, 10000)
+	rng.Read(data)
+
+	for _, size := range []int{0, 1, chunkSize - 1, chunkSize, chunkSize + 1, 4096, len(data)} {
+		expected := hashStreamNaive(data[:size], chunkSize)
+
+		// one byte at a time, to check that the batches are read completely
+		res, err := HashStream(iotest.OneByteReader(bytes.NewReader(data[:size])), sha256.New, chunkSize)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(res) != len(expected) {
+			t.Fatalf("size %d: expected %d elements, got %d", size, len(expected), len(res))
+		}
+		for i := range res {
+			if !res[i].Equal(&expected[i]) {
+				t.Fatalf("size %d: wrong element %d", size, i)
+			}
+		}
+	}
+
+	// a multiple of the size of the batches, which ends with a chunk of padding only
+	size := 2 * 4 * parallel.MaxProcs() * chunkSize
+	data = make([]byte, size)
+	res, err := HashStream(bytes.NewReader(data[:size]), sha256.New, chunkSize)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res) != size/chunkSize+1 {
+		t.Fatal("the data should be followed by a chunk of padding")
+	}
+
+	// the padding makes the encoding injective
+	a, _ := HashStream(bytes.NewReader([]byte{1, 2}), sha256.New, chunkSize)
+	b, _ := HashStream(bytes.NewReader([]byte{1, 2, 0}), sha256.New, chunkSize)
+	if a[0].Equal(&b[0]) {
+		t.Fatal("trailing zeroes should change the hash")
+	}
+
+	// errors
+	errRead := errors.New("read error")
+	if _, err := HashStream(iotest.ErrReader(errRead), sha256.New, chunkSize); err != errRead {
+		t.Fatal("read errors should be returned")
+	}
+	if _, err := HashStream(bytes.NewReader(data), sha256.New, 0); err != ErrChunkSize {
+		t.Fatal("the chunk size should be checked")
+	}
+}
+
+func TestMerkleRoot(t *testing.T) {
+	leaves := make([]fr.Element, 3)
+	for i := range leaves {
+		leaves[i].SetRandom()
+	}
+
+	node := func(a, b fr.Element) fr.Element {
+		ba, bb := a.Bytes(), b.Bytes()
+		return HashToFr(sha256.New, ba[:], bb[:])
+	}
+	var zero fr.Element
+	expected := node(node(leaves[0], leaves[1]), node(leaves[2], zero))
+	root := MerkleRoot(sha256.New, leaves)
+	if !root.Equal(&expected) {
+		t.Fatal("wrong Merkle root")
+	}
+
+	if root := MerkleRoot(sha256.New, leaves[:1]); !root.Equal(&leaves[0]) {
+		t.Fatal("the root of a single leaf should be the leaf")
+	}
+	if root := MerkleRoot(sha256.New, nil); !root.IsZero() {
+		t.Fatal("the root of no leaves should be zero")
+	}
+}
+
+func BenchmarkHashStream(b *testing.B) {
+	data := make([]byte, 1<<22)
+	b.SetBytes(int64(len(data)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = HashStream(bytes.NewReader(data), sha256.New, DefaultChunkSize)
+	}
+}
@@ -19,6 +19,7 @@ package kzg
 import (
 	"bytes"
 	"crypto/sha256"
+	"errors"
 	"math/big"
 	"reflect"
 	"testing"
@@ -26,6 +27,7 @@ import (
 	"github.com/consensys/gnark-crypto/ecc"
 	"github.com/consensys/gnark-crypto/ecc/bls24-315"
 	"github.com/consensys/gnark-crypto/ecc/bls24-315/fr"
+	"github.com/consensys/gnark-crypto/ecc/bls24-315/fr/fft"
 )
 
 // testSRS re-used accross tests of the KZG scheme
@@ -36,6 +38,122 @@ func init() {
 	testSRS, _ = NewSRS(ecc.NextPowerOfTwo(srsSize), new(big.Int).SetInt64(42))
 }
 
+func TestNewSRSWithLagrange(t *testing.T) {
+
+	const size = 32
+	alpha := new(big.Int).SetInt64(42)
+
+	srs, lagrange, err := NewSRSWithLagrange(size, alpha)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(lagrange) != size {
+		t.Fatal("unexpected size of the Lagrange basis")
+	}
+
+	// the monomial basis is the same as NewSRS
+	ref, err := NewSRS(size, alpha)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(srs, ref) {
+		t.Fatal("NewSRSWithLagrange and NewSRS should output the same monomial basis")
+	}
+
+	// committing to the evaluations with the Lagrange basis is the same as
+	// committing to the coefficients with the monomial basis
+	pol := make([]fr.Element, size)
+	for i := range pol {
+		pol[i].SetRandom()
+	}
+	expected, err := Commit(pol, srs)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	domain := fft.NewDomain(size)
+	evals := make([]fr.Element, size)
+	copy(evals, pol)
+	domain.FFT(evals, fft.DIF)
+	fft.BitReverse(evals)
+
+	var lagrangeCommit bls24315.G1Affine
+	if _, err := lagrangeCommit.MultiExp(lagrange, evals, ecc.MultiExpConfig{ScalarsMont: true}); err != nil {
+		t.Fatal(err)
+	}
+	if !lagrangeCommit.Equal(&expected) {
+		t.Fatal("Lagrange and monomial commitments differ")
+	}
+
+	// α is a root of unity: the Lagrange basis is a permutation of the generator and infinity
+	omega, _ := fr.RootOfUnity(size)
+	var bOmega big.Int
+	omega.ToBigIntRegular(&bOmega)
+	_, lagrange, err = NewSRSWithLagrange(size, &bOmega)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _, g1, _ := bls24315.Generators()
+	if !lagrange[1].Equal(&g1) || !lagrange[0].IsInfinity() {
+		t.Fatal("unexpected Lagrange basis when alpha is a root of unity")
+	}
+
+	// size must be a power of 2
+	if _, _, err := NewSRSWithLagrange(size+1, alpha); err != ErrLagrangeSRSSize {
+		t.Fatal("expected ErrLagrangeSRSSize")
+	}
+}
+
+func TestNewTestSRS(t *testing.T) {
+	srs1, err := NewTestSRS(16)
+	if err != nil {
+		t.Fatal(err)
+	}
+	srs2, err := NewTestSRS(16)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(srs1, srs2) {
+		t.Fatal("NewTestSRS should be deterministic")
+	}
+}
+
+func TestVerifySRS(t *testing.T) {
+	srs, err := NewSRS(16, new(big.Int).SetInt64(42))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := srs.Verify(); err != nil {
+		t.Fatal(err)
+	}
+
+	// powers out of order
+	srs.G1[3], srs.G1[4] = srs.G1[4], srs.G1[3]
+	if err := srs.Verify(); err != ErrSRSInconsistent {
+		t.Fatalf("expected ErrSRSInconsistent, got %v", err)
+	}
+	srs.G1[3], srs.G1[4] = srs.G1[4], srs.G1[3]
+
+	// G2 from another SRS
+	other, err := NewSRS(16, new(big.Int).SetInt64(43))
+	if err != nil {
+		t.Fatal(err)
+	}
+	g2 := srs.G2[1]
+	srs.G2[1] = other.G2[1]
+	if err := srs.Verify(); err != ErrSRSInconsistent {
+		t.Fatalf("expected ErrSRSInconsistent, got %v", err)
+	}
+	srs.G2[1] = g2
+
+	// point at infinity
+	srs.G1[1].X.SetZero()
+	srs.G1[1].Y.SetZero()
+	if err := srs.Verify(); err != ErrSRSDegenerate {
+		t.Fatalf("expected ErrSRSDegenerate, got %v", err)
+	}
+}
+
 func TestDividePolyByXminusA(t *testing.T) {
 
 	const pSize = 230
@@ -139,6 +257,115 @@ func TestCommit(t *testing.T) {
 
 }
 
+func TestCommitSparse(t *testing.T) {
+
+	// sparse polynomial, with its dense coefficients
+	coeffs := make(map[uint64]fr.Element)
+	indices := []uint64{0, 3, 17, 18, 42, uint64(len(testSRS.G1) - 1)}
+	dense := make([]fr.Element, len(testSRS.G1))
+	for _, i := range indices {
+		var c fr.Element
+		c.SetRandom()
+		coeffs[i] = c
+		dense[i] = c
+	}
+
+	digest, err := CommitSparse(coeffs, testSRS)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected, err := Commit(dense, testSRS)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !digest.Equal(&expected) {
+		t.Fatal("CommitSparse and Commit should compute the same digest")
+	}
+
+	// opening
+	var point fr.Element
+	point.SetRandom()
+	proof, err := OpenSparse(coeffs, point, testSRS)
+	if err != nil {
+		t.Fatal(err)
+	}
+	claimedValue := eval(dense, point)
+	if !proof.ClaimedValue.Equal(&claimedValue) {
+		t.Fatal("inconsistent claimed value")
+	}
+	if err = Verify(&digest, &proof, point, testSRS); err != nil {
+		t.Fatal(err)
+	}
+
+	// invalid sizes
+	if _, err = CommitSparse(nil, testSRS); err != ErrInvalidPolynomialSize {
+		t.Fatal("committing to an empty polynomial should fail")
+	}
+	coeffs[uint64(len(testSRS.G1))] = fr.One()
+	if _, err = CommitSparse(coeffs, testSRS); err != ErrInvalidPolynomialSize {
+		t.Fatal("committing to a polynomial larger than the SRS should fail")
+	}
+	if _, err = OpenSparse(coeffs, point, testSRS); err != ErrInvalidPolynomialSize {
+		t.Fatal("opening a polynomial larger than the SRS should fail")
+	}
+}
+
+func TestLinearCombination(t *testing.T) {
+
+	// digests of random polynomials, and of their linear combination
+	const nbPolynomials = 5
+	polynomials := make([][]fr.Element, nbPolynomials)
+	digests := make([]Digest, nbPolynomials)
+	scalars := make([]fr.Element, nbPolynomials)
+	combination := make([]fr.Element, 20)
+	var err error
+	for i := range polynomials {
+		polynomials[i] = make([]fr.Element, 20)
+		scalars[i].SetRandom()
+		for j := range polynomials[i] {
+			var tmp fr.Element
+			polynomials[i][j].SetRandom()
+			tmp.Mul(&polynomials[i][j], &scalars[i])
+			combination[j].Add(&combination[j], &tmp)
+		}
+		if digests[i], err = Commit(polynomials[i], testSRS); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	digest, err := LinearCombination(digests, scalars)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected, err := Commit(combination, testSRS)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !digest.Equal(&expected) {
+		t.Fatal("LinearCombination should compute the digest of the linear combination")
+	}
+
+	// the digest of a constant polynomial is a scalar multiplication of the base point
+	var c big.Int
+	scalars[0].ToBigIntRegular(&c)
+	constant, err := Commit(scalars[:1], testSRS)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var base Digest
+	base.ScalarMultiplicationBase(&c)
+	if !constant.Equal(&base) {
+		t.Fatal("ScalarMultiplicationBase should compute the digest of a constant polynomial")
+	}
+
+	if digest, err = LinearCombination(nil, nil); err != nil || !digest.IsInfinity() {
+		t.Fatal("the empty linear combination should be the point at infinity")
+	}
+	if _, err = LinearCombination(digests, scalars[1:]); err != ErrInvalidNbDigests {
+		t.Fatalf("expected %v, got %v", ErrInvalidNbDigests, err)
+	}
+}
+
 func TestVerifySinglePoint(t *testing.T) {
 
 	// create a polynomial
@@ -170,12 +397,25 @@ func TestVerifySinglePoint(t *testing.T) {
 		t.Fatal(err)
 	}
 
+	// the same proof is computed with scratch memory from an arena
+	arena := ecc.NewArena(len(f) * fr.Limbs)
+	proofArena, err := OpenWithArena(f, point, testSRS, arena)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !proofArena.H.Equal(&proof.H) || !proofArena.ClaimedValue.Equal(&proof.ClaimedValue) {
+		t.Fatal("OpenWithArena and Open should compute the same proof")
+	}
+	if arena.Available() == len(f)*fr.Limbs {
+		t.Fatal("OpenWithArena should use the arena")
+	}
+
 	{
 		// verify wrong proof
 		proof.ClaimedValue.Double(&proof.ClaimedValue)
 		err = Verify(&digest, &proof, point, testSRS)
-		if err == nil {
-			t.Fatal("verifying wrong proof should have failed")
+		if !errors.Is(err, ecc.ErrPairingCheckFailed) {
+			t.Fatalf("verifying wrong proof should have failed the pairing check, got %v", err)
 		}
 	}
 	{
@@ -190,6 +430,107 @@ func TestVerifySinglePoint(t *testing.T) {
 	}
 }
 
+func TestOpenLagrange(t *testing.T) {
+
+	// create a polynomial and its evaluations on the domain
+	domain := fft.NewDomain(64)
+	f := randomPolynomial(int(domain.Cardinality))
+	evaluations := make([]fr.Element, len(f))
+	copy(evaluations, f)
+	domain.FFT(evaluations, fft.DIF)
+	fft.BitReverse(evaluations)
+
+	// commit the polynomial
+	digest, err := Commit(f, testSRS)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the Lagrange basis of the test SRS
+	_, lagrange, err := NewSRSWithLagrange(domain.Cardinality, new(big.Int).SetInt64(42))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// open at a point outside and inside the domain
+	var outside, inside fr.Element
+	outside.SetString("4321")
+	inside.Square(&domain.Generator)
+	for _, point := range []fr.Element{outside, inside} {
+
+		proof, err := OpenLagrange(evaluations, point, domain, lagrange)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// the proof should match the one computed in canonical form
+		expected, err := Open(f, point, testSRS)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !proof.ClaimedValue.Equal(&expected.ClaimedValue) {
+			t.Fatal("inconsistant claimed value")
+		}
+		if !proof.H.Equal(&expected.H) {
+			t.Fatal("inconsistant quotient")
+		}
+
+		err = Verify(&digest, &proof, point, testSRS)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if _, err := OpenLagrange(evaluations, outside, domain, lagrange[1:]); err != ErrInvalidPolynomialSize {
+		t.Fatal("the size of the Lagrange basis should be checked")
+	}
+}
+
+func TestOpenAll(t *testing.T) {
+
+	// a polynomial of degree smaller than the size of the domain
+	domain := fft.NewDomain(32)
+	f := randomPolynomial(27)
+
+	digest, err := Commit(f, testSRS)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	proofs, err := OpenAll(f, domain, testSRS)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(proofs) != int(domain.Cardinality) {
+		t.Fatal("wrong number of proofs")
+	}
+
+	// each proof should match the one computed with Open at ωⁱ
+	var point fr.Element
+	point.SetOne()
+	for i := range proofs {
+		expected, err := Open(f, point, testSRS)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !proofs[i].ClaimedValue.Equal(&expected.ClaimedValue) {
+			t.Fatal("inconsistant claimed value")
+		}
+		if !proofs[i].H.Equal(&expected.H) {
+			t.Fatal("inconsistant quotient")
+		}
+		if err := Verify(&digest, &proofs[i], point, testSRS); err != nil {
+			t.Fatal(err)
+		}
+		point.Mul(&point, &domain.Generator)
+	}
+
+	// the polynomial must fit in the domain
+	if _, err := OpenAll(randomPolynomial(33), domain, testSRS); err != ErrInvalidPolynomialSize {
+		t.Fatal("expected ErrInvalidPolynomialSize")
+	}
+}
+
 func TestBatchVerifySinglePoint(t *testing.T) {
 
 	size := 40
@@ -253,6 +594,130 @@ func TestBatchVerifySinglePoint(t *testing.T) {
 
 }
 
+func TestOpeningContext(t *testing.T) {
+
+	size := 40
+
+	// create polynomials, the last one larger than the context
+	f := make([][]fr.Element, 4)
+	digests := make([]Digest, len(f))
+	for i := 0; i < len(f); i++ {
+		f[i] = randomPolynomial(size + i)
+		digests[i], _ = Commit(f[i], testSRS)
+	}
+
+	var point fr.Element
+	point.SetString("4321")
+
+	ctx := NewOpeningContext(testSRS, size+2)
+	for i := 0; i < len(f); i++ {
+		proof, err := Open(f[i], point, testSRS)
+		if err != nil {
+			t.Fatal(err)
+		}
+		proofCtx, err := ctx.Open(f[i], point)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !proofCtx.H.Equal(&proof.H) || !proofCtx.ClaimedValue.Equal(&proof.ClaimedValue) {
+			t.Fatal("OpeningContext.Open and Open should compute the same proof")
+		}
+	}
+
+	// the context is reused for a batch opening
+	hf := sha256.New()
+	proof, err := BatchOpenSinglePoint(f[:3], digests[:3], point, hf, testSRS)
+	if err != nil {
+		t.Fatal(err)
+	}
+	proofCtx, err := ctx.BatchOpenSinglePoint(f[:3], digests[:3], point, hf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !proofCtx.H.Equal(&proof.H) {
+		t.Fatal("OpeningContext.BatchOpenSinglePoint and BatchOpenSinglePoint should compute the same proof")
+	}
+	if err = BatchVerifySinglePoint(digests[:3], &proofCtx, point, hf, testSRS); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestBatchVerifyShifted(t *testing.T) {
+
+	// create polynomials
+	f := make([][]fr.Element, 5)
+	digests := make([]Digest, 5)
+	for i := 0; i < 5; i++ {
+		f[i] = randomPolynomial(40)
+		digests[i], _ = Commit(f[i], testSRS)
+	}
+	shifted := []int{1, 3}
+
+	// open at z and ωz
+	hf := sha256.New()
+	var point fr.Element
+	point.SetRandom()
+	omega := fft.NewDomain(64).Generator
+	proof, err := BatchOpenShifted(f, digests, shifted, point, omega, hf, testSRS)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// verify the claimed values
+	var shiftedPoint fr.Element
+	shiftedPoint.Mul(&point, &omega)
+	for i, j := range shifted {
+		expected := eval(f[j], shiftedPoint)
+		if !expected.Equal(&proof.ShiftedProof.ClaimedValues[i]) {
+			t.Fatal("inconsistant shifted claimed values")
+		}
+	}
+
+	// verify correct proof
+	err = BatchVerifyShifted(digests, shifted, &proof, point, omega, hf, testSRS)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// serialization
+	var buf bytes.Buffer
+	written, err := proof.WriteTo(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if written != int64(proof.SizeInBytes()) {
+		t.Fatal("SizeInBytes doesn't match the number of bytes written")
+	}
+	var reconstructed ShiftedBatchOpeningProof
+	if _, err = reconstructed.ReadFrom(&buf); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(&proof, &reconstructed) {
+		t.Fatal("ReadFrom(WriteTo()) failed")
+	}
+
+	// verify wrong proof
+	proof.ShiftedProof.ClaimedValues[1].Double(&proof.ShiftedProof.ClaimedValues[1])
+	err = BatchVerifyShifted(digests, shifted, &proof, point, omega, hf, testSRS)
+	if err == nil {
+		t.Fatal("verifying wrong proof should have failed")
+	}
+
+	// verify with wrong shifted indices
+	proof = reconstructed
+	err = BatchVerifyShifted(digests, []int{1, 2}, &proof, point, omega, hf, testSRS)
+	if err == nil {
+		t.Fatal("verifying with wrong shifted indices should have failed")
+	}
+
+	// verify with an invalid shifted index
+	err = BatchVerifyShifted(digests, []int{1, len(digests)}, &proof, point, omega, hf, testSRS)
+	var indexedErr *ecc.IndexedError
+	if !errors.Is(err, ErrInvalidShiftedIndex) || !errors.As(err, &indexedErr) || indexedErr.Index != 1 {
+		t.Fatalf("expected ErrInvalidShiftedIndex at index 1, got %v", err)
+	}
+}
+
 func TestBatchVerifyMultiPoints(t *testing.T) {
 
 	// create polynomials
@@ -340,6 +805,24 @@ func BenchmarkKZGCommit(b *testing.B) {
 	}
 }
 
+func BenchmarkKZGCommitSparse(b *testing.B) {
+	benchSRS, err := NewSRS(ecc.NextPowerOfTwo(benchSize), new(big.Int).SetInt64(42))
+	if err != nil {
+		b.Fatal(err)
+	}
+	// random polynomial with 1% of non-zero coefficients
+	p := randomPolynomial(benchSize / 200)
+	coeffs := make(map[uint64]fr.Element, len(p))
+	for i := range p {
+		coeffs[uint64(i*100)] = p[i]
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = CommitSparse(coeffs, benchSRS)
+	}
+}
+
 func BenchmarkDivideByXMinusA(b *testing.B) {
 	const pSize = 1 << 22
 
@@ -378,6 +861,40 @@ func BenchmarkKZGOpen(b *testing.B) {
 	}
 }
 
+func BenchmarkKZGOpenWithContext(b *testing.B) {
+	benchSRS, err := NewSRS(ecc.NextPowerOfTwo(benchSize), new(big.Int).SetInt64(42))
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	// random polynomial
+	p := randomPolynomial(benchSize / 2)
+	var r fr.Element
+	r.SetRandom()
+	ctx := NewOpeningContext(benchSRS, len(p))
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = ctx.Open(p, r)
+	}
+}
+
+func BenchmarkKZGOpenAll(b *testing.B) {
+	benchSRS, err := NewSRS(ecc.NextPowerOfTwo(benchSize), new(big.Int).SetInt64(42))
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	// random polynomial
+	p := randomPolynomial(benchSize / 2)
+	domain := fft.NewDomain(uint64(len(p)))
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = OpenAll(p, domain, benchSRS)
+	}
+}
+
 func BenchmarkKZGVerify(b *testing.B) {
 	benchSRS, err := NewSRS(ecc.NextPowerOfTwo(benchSize), new(big.Int).SetInt64(42))
 	if err != nil {
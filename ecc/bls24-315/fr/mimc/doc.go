@@ -15,4 +15,9 @@
 // Code generated by consensys/gnark-crypto DO NOT EDIT
 
 // Package mimc provides MiMC hash function using Miyaguchi–Preneel construction.
+//
+// It also provides a pseudo random function on 𝔽_r built on the MiMC block cipher
+// (see PRF), to derive nullifiers, nonces or subkeys from a secret key, and a
+// Fiat-Shamir transcript binding field elements natively (see Transcript), for
+// proofs verified in a circuit.
 package mimc
@@ -0,0 +1,155 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package mimc
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls24-315/fr"
+)
+
+func TestVectors(t *testing.T) {
+	var expectedShort, expectedLong, expectedOptions string
+
+	expectedShort = "10500ec84f198777f3abe09e974fbecd8c75ec4c0f2d4c402ec984ed42060bec"
+	expectedLong = "14e5c52ca260cb5e936766b3744424a22425e3f49f40db857582f8ece5fe7363"
+	expectedOptions = "18d928880f16828ddd61d4beb4caad3ca202807491d95fe4b139a21aa79b7975"
+
+	long := make([]byte, 96)
+	for i := range long {
+		long[i] = byte(i + 1)
+	}
+
+	// default options must stay compatible with previous versions
+	h := NewMiMC()
+	h.Write([]byte("gnark-crypto"))
+	if got := hex.EncodeToString(h.Sum(nil)); got != expectedShort {
+		t.Fatalf("unexpected hash: %s", got)
+	}
+	h = NewMiMC()
+	h.Write(long)
+	if got := hex.EncodeToString(h.Sum(nil)); got != expectedLong {
+		t.Fatalf("unexpected hash: %s", got)
+	}
+
+	h = NewMiMC(WithPadding(PaddingLength), WithPacking(PackingShort), WithDomainSeparation([]byte("test")))
+	h.Write([]byte("gnark-crypto"))
+	if got := hex.EncodeToString(h.Sum(nil)); got != expectedOptions {
+		t.Fatalf("unexpected hash: %s", got)
+	}
+}
+
+func TestPadding(t *testing.T) {
+	a := []byte("abc")
+	b := append([]byte{0}, a...)
+
+	sum := func(msg []byte, opts ...Option) []byte {
+		h := NewMiMC(opts...)
+		h.Write(msg)
+		return h.Sum(nil)
+	}
+
+	if !bytes.Equal(sum(a), sum(b)) {
+		t.Fatal("PaddingZero should ignore leading zeroes of the last block")
+	}
+	if bytes.Equal(sum(a, WithPadding(PaddingLength)), sum(b, WithPadding(PaddingLength))) {
+		t.Fatal("PaddingLength should distinguish messages of different lengths")
+	}
+}
+
+func TestPacking(t *testing.T) {
+	invalid := bytes.Repeat([]byte{0xff}, BlockSize)
+
+	h := NewMiMC(WithPacking(PackingCanonical))
+	if _, err := h.Write(invalid); err != ErrNonCanonicalBlock {
+		t.Fatal("PackingCanonical should reject a block larger than the modulus")
+	}
+
+	// the block is completed over several writes
+	h.Reset()
+	if _, err := h.Write(invalid[:BlockSize/2]); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := h.Write(invalid[BlockSize/2:]); err != ErrNonCanonicalBlock {
+		t.Fatal("PackingCanonical should reject a block larger than the modulus written in several parts")
+	}
+
+	// canonical blocks hash as with PackingFull
+	var x fr.Element
+	x.SetRandom()
+	xb := x.Bytes()
+	h.Reset()
+	if _, err := h.Write(xb[:]); err != nil {
+		t.Fatal(err)
+	}
+	ref := NewMiMC()
+	ref.Write(xb[:])
+	if !bytes.Equal(h.Sum(nil), ref.Sum(nil)) {
+		t.Fatal("PackingCanonical and PackingFull should agree on canonical blocks")
+	}
+
+	// PackingShort never reduces
+	h = NewMiMC(WithPacking(PackingShort))
+	if _, err := h.Write(invalid); err != nil {
+		t.Fatal(err)
+	}
+	h2 := NewMiMC(WithPacking(PackingShort))
+	h2.Write(append(invalid[:len(invalid)-1:len(invalid)-1], 0xfe))
+	if bytes.Equal(h.Sum(nil), h2.Sum(nil)) {
+		t.Fatal("PackingShort should not reduce blocks")
+	}
+}
+
+func TestDomainSeparation(t *testing.T) {
+	msg := []byte("gnark-crypto")
+
+	sum := func(tag string) []byte {
+		h := NewMiMC(WithDomainSeparation([]byte(tag)))
+		h.Write([]byte("some data"))
+		h.Reset()
+		h.Write(msg)
+		return h.Sum(nil)
+	}
+
+	if !bytes.Equal(sum("a"), sum("a")) {
+		t.Fatal("hash with the same tag should be deterministic")
+	}
+	if bytes.Equal(sum("a"), sum("b")) {
+		t.Fatal("hash with different tags should differ")
+	}
+}
+
+func TestCompress(t *testing.T) {
+	var left, right, zero fr.Element
+	left.SetRandom()
+	right.SetRandom()
+
+	lb := left.Bytes()
+	rb := right.Bytes()
+	h := NewMiMC()
+	h.Write(lb[:])
+	h.Write(rb[:])
+
+	l := Compress(zero, left)
+	expected := Compress(l, right)
+	eb := expected.Bytes()
+	if !bytes.Equal(h.Sum(nil), eb[:]) {
+		t.Fatal("hash of two blocks should be the chained compression of the blocks")
+	}
+}
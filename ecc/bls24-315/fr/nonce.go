@@ -0,0 +1,142 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package fr
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"errors"
+	"hash"
+	"math/big"
+)
+
+// ErrNonceKey is returned when the secret key of a nonce derivation is zero.
+var ErrNonceKey = errors.New("fr: the secret key of the nonce derivation is zero")
+
+// DRBG is the HMAC-DRBG of RFC 6979 (section 3.2), instantiated with a secret key and the hash
+// of a message. Its output is a deterministic stream of uniformly distributed non-zero elements:
+// the first one is the nonce of RFC 6979, the next ones are the nonces to use when a signature
+// scheme rejects the previous one. A DRBG is not safe for concurrent use.
+type DRBG struct {
+	h       func() hash.Hash
+	q       *big.Int
+	qlen    int
+	k, v    []byte
+	started bool
+}
+
+// NonceRFC6979 returns the deterministic nonce of RFC 6979 for the secret key and the hash
+// of the message, with HMAC-SHA256.
+func NonceRFC6979(key *Element, msgHash []byte) (Element, error) {
+	d, err := NewDRBG(sha256.New, key, msgHash)
+	if err != nil {
+		return Element{}, err
+	}
+	return d.Next(), nil
+}
+
+// NewDRBG returns the HMAC-DRBG of RFC 6979 for the secret key and the hash of the message,
+// where h is the hash function of the HMAC.
+func NewDRBG(h func() hash.Hash, key *Element, msgHash []byte) (*DRBG, error) {
+	if key.IsZero() {
+		return nil, ErrNonceKey
+	}
+	var x big.Int
+	key.ToBigIntRegular(&x)
+	return newDRBG(h, Modulus(), Bits, &x, msgHash), nil
+}
+
+// Next returns the next element of the stream.
+func (d *DRBG) Next() Element {
+	var k big.Int
+	d.next(&k)
+	var z Element
+	z.SetBigInt(&k)
+	return z
+}
+
+// newDRBG instantiates the HMAC-DRBG for a modulus q of qlen bits (steps a. to g.)
+func newDRBG(h func() hash.Hash, q *big.Int, qlen int, x *big.Int, msgHash []byte) *DRBG {
+	d := &DRBG{h: h, q: q, qlen: qlen}
+	size := h().Size()
+	d.v = make([]byte, size)
+	d.k = make([]byte, size)
+	for i := range d.v {
+		d.v[i] = 0x01
+	}
+
+	xOctets := d.int2octets(x)
+	hOctets := d.bits2octets(msgHash)
+	for _, b := range []byte{0x00, 0x01} {
+		d.k = d.mac(d.k, d.v, []byte{b}, xOctets, hOctets)
+		d.v = d.mac(d.k, d.v)
+	}
+	return d
+}
+
+// next sets k to the next candidate in [1, q-1] (step h.)
+func (d *DRBG) next(k *big.Int) {
+	for {
+		if d.started {
+			d.k = d.mac(d.k, d.v, []byte{0x00})
+			d.v = d.mac(d.k, d.v)
+		}
+		d.started = true
+
+		t := make([]byte, 0, (d.qlen+7)/8+len(d.v))
+		for len(t)*8 < d.qlen {
+			d.v = d.mac(d.k, d.v)
+			t = append(t, d.v...)
+		}
+		d.bits2int(t, k)
+		if k.Sign() > 0 && k.Cmp(d.q) < 0 {
+			return
+		}
+	}
+}
+
+// mac returns HMAC_key(data[0] || data[1] || ...)
+func (d *DRBG) mac(key []byte, data ...[]byte) []byte {
+	m := hmac.New(d.h, key)
+	for _, b := range data {
+		m.Write(b)
+	}
+	return m.Sum(nil)
+}
+
+// bits2int sets res to the integer of the leftmost qlen bits of b (section 2.3.2)
+func (d *DRBG) bits2int(b []byte, res *big.Int) {
+	res.SetBytes(b)
+	if blen := len(b) * 8; blen > d.qlen {
+		res.Rsh(res, uint(blen-d.qlen))
+	}
+}
+
+// int2octets returns x, x < q, on ⌈qlen/8⌉ big-endian bytes (section 2.3.3)
+func (d *DRBG) int2octets(x *big.Int) []byte {
+	return x.FillBytes(make([]byte, (d.qlen+7)/8))
+}
+
+// bits2octets returns bits2int(b) mod q on ⌈qlen/8⌉ bytes (section 2.3.4)
+func (d *DRBG) bits2octets(b []byte) []byte {
+	var z big.Int
+	d.bits2int(b, &z)
+	if z.Cmp(d.q) >= 0 {
+		z.Sub(&z, d.q)
+	}
+	return d.int2octets(&z)
+}
@@ -0,0 +1,381 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package pcs
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"math/big"
+	"math/bits"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bls24-315"
+	"github.com/consensys/gnark-crypto/ecc/bls24-315/fr"
+	fiatshamir "github.com/consensys/gnark-crypto/fiat-shamir"
+	"github.com/consensys/gnark-crypto/internal/parallel"
+)
+
+// dstIPA is the domain separation tag used to derive the IPA generators
+var dstIPA = []byte("gnark-crypto/pcs: IPA generators")
+
+// IPA is the inner product argument backend, the digests are *IPADigest and the
+// proofs *IPAOpeningProof.
+//
+// The commitment to p is C = ∑ pᵢGᵢ, for generators Gᵢ with unknown discrete logarithms
+// derived by hashing to G1. p(z) = ⟨p, (1, z, z², …)⟩ is proven by halving the vectors
+// log(n) times, each round producing two points Lⱼ, Rⱼ; the verifier recomputes the folded
+// generator with one multi-scalar multiplication of size n.
+type IPA struct {
+	G []bls24315.G1Affine // generators for the coefficients
+	U bls24315.G1Affine   // generator for the inner product
+}
+
+// IPADigest is a commitment ∑ pᵢGᵢ
+type IPADigest struct {
+	C bls24315.G1Affine
+}
+
+// Marshal returns the compressed encoding of the commitment
+func (d *IPADigest) Marshal() []byte {
+	return d.C.Marshal()
+}
+
+// IPAOpeningProof proof of the evaluation of a polynomial
+type IPAOpeningProof struct {
+	// L, R are the cross terms of each round
+	L, R []bls24315.G1Affine
+
+	// A is the fully folded coefficient
+	A fr.Element
+
+	// Value is the claimed evaluation
+	Value fr.Element
+}
+
+// ClaimedValue returns the claimed evaluation
+func (proof *IPAOpeningProof) ClaimedValue() fr.Element {
+	return proof.Value
+}
+
+// NewIPA returns an IPA backend for polynomials of up to size coefficients. The setup is
+// transparent: the generators only depend on the size, rounded to the next power of 2.
+func NewIPA(size uint64) (*IPA, error) {
+	if size == 0 {
+		return nil, ErrInvalidPolynomialSize
+	}
+	n := ecc.NextPowerOfTwo(size)
+	s := &IPA{G: make([]bls24315.G1Affine, n)}
+
+	var err error
+	if s.U, err = bls24315.HashToG1([]byte("U"), dstIPA); err != nil {
+		return nil, err
+	}
+	chErr := make(chan error, 1)
+	parallel.Execute(int(n), func(start, end int) {
+		var msg [8]byte
+		for i := start; i < end; i++ {
+			binary.BigEndian.PutUint64(msg[:], uint64(i))
+			g, err := bls24315.HashToG1(msg[:], dstIPA)
+			if err != nil {
+				select {
+				case chErr <- err:
+				default:
+				}
+				return
+			}
+			s.G[i] = g
+		}
+	})
+	close(chErr)
+	if err := <-chErr; err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// Commit implements PolynomialCommitment
+func (s *IPA) Commit(p []fr.Element) (Digest, error) {
+	if len(p) == 0 || len(p) > len(s.G) {
+		return nil, ErrInvalidPolynomialSize
+	}
+	var d IPADigest
+	if _, err := d.C.MultiExp(s.G[:len(p)], p, ecc.MultiExpConfig{ScalarsMont: true}); err != nil {
+		return nil, err
+	}
+	return &d, nil
+}
+
+// Open implements PolynomialCommitment
+func (s *IPA) Open(p []fr.Element, point fr.Element) (OpeningProof, error) {
+	digest, err := s.Commit(p)
+	if err != nil {
+		return nil, err
+	}
+	n := len(s.G)
+	k := bits.TrailingZeros(uint(n))
+
+	// a = p, padded with zeroes, b = (1, z, z², …)
+	a := make([]fr.Element, n)
+	copy(a, p)
+	b := make([]fr.Element, n)
+	b[0].SetOne()
+	for i := 1; i < n; i++ {
+		b[i].Mul(&b[i-1], &point)
+	}
+	g := make([]bls24315.G1Affine, n)
+	copy(g, s.G)
+
+	proof := &IPAOpeningProof{
+		L:     make([]bls24315.G1Affine, k),
+		R:     make([]bls24315.G1Affine, k),
+		Value: evaluate(p, point),
+	}
+
+	fs := newIPATranscript(k)
+	u, err := s.deriveInnerProductBase(&fs, digest.(*IPADigest), point, proof.Value)
+	if err != nil {
+		return nil, err
+	}
+
+	var x, xInv fr.Element
+	for j := 0; j < k; j++ {
+		m := len(a) / 2
+		aL, aR := a[:m], a[m:]
+		bL, bR := b[:m], b[m:]
+		gL, gR := g[:m], g[m:]
+
+		// L = ⟨aL, GR⟩ + ⟨aL, bR⟩U, R = ⟨aR, GL⟩ + ⟨aR, bL⟩U
+		if err := crossTerm(&proof.L[j], gR, aL, bR, &u); err != nil {
+			return nil, err
+		}
+		if err := crossTerm(&proof.R[j], gL, aR, bL, &u); err != nil {
+			return nil, err
+		}
+
+		if x, err = deriveRoundChallenge(&fs, j, &proof.L[j], &proof.R[j]); err != nil {
+			return nil, err
+		}
+		xInv.Inverse(&x)
+
+		// a ← x⋅aL + x⁻¹⋅aR, b ← x⁻¹⋅bL + x⋅bR, G ← x⁻¹⋅GL + x⋅GR
+		var t fr.Element
+		for i := 0; i < m; i++ {
+			aL[i].Mul(&aL[i], &x)
+			t.Mul(&aR[i], &xInv)
+			aL[i].Add(&aL[i], &t)
+
+			bL[i].Mul(&bL[i], &xInv)
+			t.Mul(&bR[i], &x)
+			bL[i].Add(&bL[i], &t)
+		}
+		gJac := make([]bls24315.G1Jac, m)
+		parallel.Execute(m, func(start, end int) {
+			var bx, bxInv big.Int
+			x.ToBigIntRegular(&bx)
+			xInv.ToBigIntRegular(&bxInv)
+			var tmp bls24315.G1Jac
+			for i := start; i < end; i++ {
+				gJac[i].ScalarMultiplicationAffine(&gL[i], &bxInv)
+				tmp.ScalarMultiplicationAffine(&gR[i], &bx)
+				gJac[i].AddAssign(&tmp)
+			}
+		})
+		a, b = aL, bL
+		g = bls24315.BatchJacobianToAffineG1(gJac)
+	}
+	proof.A = a[0]
+
+	return proof, nil
+}
+
+// crossTerm sets res to ⟨a, g⟩ + ⟨a, b⟩u
+func crossTerm(res *bls24315.G1Affine, g []bls24315.G1Affine, a, b []fr.Element, u *bls24315.G1Affine) error {
+	var ab fr.Element
+	for i := range a {
+		var t fr.Element
+		t.Mul(&a[i], &b[i])
+		ab.Add(&ab, &t)
+	}
+	points := make([]bls24315.G1Affine, len(g)+1)
+	copy(points, g)
+	points[len(g)] = *u
+	scalars := make([]fr.Element, len(a)+1)
+	copy(scalars, a)
+	scalars[len(a)] = ab
+	_, err := res.MultiExp(points, scalars, ecc.MultiExpConfig{ScalarsMont: true})
+	return err
+}
+
+// Verify implements PolynomialCommitment.
+//
+// It checks C + vU' + ∑ⱼ (xⱼ²Lⱼ + xⱼ⁻²Rⱼ) = a⋅(∑ᵢ sᵢGᵢ) + a⋅b⋅U', where U' = ξU, sᵢ = ∏ⱼ xⱼ^{±1}
+// is the coefficient of Gᵢ in the folded generator, and b = ∏ⱼ (xⱼ⁻¹ + xⱼz^{n/2ʲ⁺¹}) is the
+// folded evaluation vector.
+func (s *IPA) Verify(digest Digest, proof OpeningProof, point fr.Element) error {
+	d, ok := digest.(*IPADigest)
+	if !ok {
+		return ErrBackendMismatch
+	}
+	p, ok := proof.(*IPAOpeningProof)
+	if !ok {
+		return ErrBackendMismatch
+	}
+	n := len(s.G)
+	k := bits.TrailingZeros(uint(n))
+	if len(p.L) != k || len(p.R) != k {
+		return ErrVerifyOpeningProof
+	}
+	for j := 0; j < k; j++ {
+		if !p.L[j].IsInSubGroup() || !p.R[j].IsInSubGroup() {
+			return ErrVerifyOpeningProof
+		}
+	}
+
+	fs := newIPATranscript(k)
+	u, err := s.deriveInnerProductBase(&fs, d, point, p.Value)
+	if err != nil {
+		return err
+	}
+	x := make([]fr.Element, k)
+	for j := 0; j < k; j++ {
+		if x[j], err = deriveRoundChallenge(&fs, j, &p.L[j], &p.R[j]); err != nil {
+			return err
+		}
+	}
+	xInv := fr.BatchInvert(x)
+
+	// sᵢ = ∏ⱼ xⱼ^{±1}, the sign being the bit k-1-j of i
+	sc := make([]fr.Element, 1, n)
+	sc[0].SetOne()
+	for j := k - 1; j >= 0; j-- {
+		m := len(sc)
+		sc = sc[:2*m]
+		for i := 0; i < m; i++ {
+			sc[m+i].Mul(&sc[i], &x[j])
+			sc[i].Mul(&sc[i], &xInv[j])
+		}
+	}
+
+	// folded b
+	var b, zPow, t fr.Element
+	b.SetOne()
+	zPow = point
+	for j := k - 1; j >= 0; j-- {
+		t.Mul(&x[j], &zPow).Add(&t, &xInv[j])
+		b.Mul(&b, &t)
+		zPow.Square(&zPow)
+	}
+
+	// single multi-scalar multiplication, which must vanish:
+	// C + (v - a⋅b)U' + ∑ⱼ (xⱼ²Lⱼ + xⱼ⁻²Rⱼ) - ∑ᵢ a⋅sᵢGᵢ
+	points := make([]bls24315.G1Affine, 0, n+2*k+2)
+	scalars := make([]fr.Element, 0, n+2*k+2)
+	var one, c fr.Element
+	one.SetOne()
+	points = append(points, d.C, u)
+	c.Mul(&p.A, &b).Sub(&p.Value, &c)
+	scalars = append(scalars, one, c)
+	for j := 0; j < k; j++ {
+		var x2, x2Inv fr.Element
+		x2.Square(&x[j])
+		x2Inv.Square(&xInv[j])
+		points = append(points, p.L[j], p.R[j])
+		scalars = append(scalars, x2, x2Inv)
+	}
+	var negA fr.Element
+	negA.Neg(&p.A)
+	for i := 0; i < n; i++ {
+		sc[i].Mul(&sc[i], &negA)
+	}
+	points = append(points, s.G...)
+	scalars = append(scalars, sc...)
+
+	var res bls24315.G1Jac
+	if _, err := res.MultiExp(points, scalars, ecc.MultiExpConfig{ScalarsMont: true}); err != nil {
+		return err
+	}
+	if !res.Z.IsZero() {
+		return ErrVerifyOpeningProof
+	}
+	return nil
+}
+
+// BatchVerify implements PolynomialCommitment, verifying the proofs one by one
+func (s *IPA) BatchVerify(digests []Digest, proofs []OpeningProof, points []fr.Element) error {
+	if err := checkBatchSizes(digests, proofs, points); err != nil {
+		return err
+	}
+	for i := range digests {
+		if err := s.Verify(digests[i], proofs[i], points[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// newIPATranscript returns the transcript of an opening with k rounds
+func newIPATranscript(k int) fiatshamir.Transcript {
+	ids := make([]string, k+1)
+	ids[0] = "xi"
+	for j := 0; j < k; j++ {
+		ids[j+1] = fmt.Sprintf("x%d", j)
+	}
+	return fiatshamir.NewTranscript(sha256.New(), ids...)
+}
+
+// deriveInnerProductBase returns U' = ξU, ξ being bound to the statement
+func (s *IPA) deriveInnerProductBase(fs *fiatshamir.Transcript, d *IPADigest, point, value fr.Element) (bls24315.G1Affine, error) {
+	for _, b := range [][]byte{d.C.Marshal(), point.Marshal(), value.Marshal()} {
+		if err := fs.Bind("xi", b); err != nil {
+			return bls24315.G1Affine{}, err
+		}
+	}
+	bxi, err := fs.ComputeChallenge("xi")
+	if err != nil {
+		return bls24315.G1Affine{}, err
+	}
+	var xi fr.Element
+	xi.SetBytes(bxi)
+	var bi big.Int
+	var res bls24315.G1Affine
+	res.ScalarMultiplication(&s.U, xi.ToBigIntRegular(&bi))
+	return res, nil
+}
+
+// deriveRoundChallenge returns the challenge of round j, bound to Lⱼ and Rⱼ
+func deriveRoundChallenge(fs *fiatshamir.Transcript, j int, l, r *bls24315.G1Affine) (fr.Element, error) {
+	id := fmt.Sprintf("x%d", j)
+	if err := fs.Bind(id, l.Marshal()); err != nil {
+		return fr.Element{}, err
+	}
+	if err := fs.Bind(id, r.Marshal()); err != nil {
+		return fr.Element{}, err
+	}
+	bx, err := fs.ComputeChallenge(id)
+	if err != nil {
+		return fr.Element{}, err
+	}
+	var x fr.Element
+	x.SetBytes(bx)
+	if x.IsZero() {
+		// negligible, x must be invertible
+		x.SetOne()
+	}
+	return x, nil
+}
@@ -0,0 +1,244 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package plookup
+
+import (
+	"crypto/sha256"
+	"math/big"
+	"sync"
+
+	bls24315 "github.com/consensys/gnark-crypto/ecc/bls24-315"
+	"github.com/consensys/gnark-crypto/ecc/bls24-315/fr"
+	"github.com/consensys/gnark-crypto/ecc/bls24-315/fr/fft"
+	"github.com/consensys/gnark-crypto/ecc/bls24-315/fr/kzg"
+	"github.com/consensys/gnark-crypto/ecc/bls24-315/fr/permutation"
+	fiatshamir "github.com/consensys/gnark-crypto/fiat-shamir"
+	"github.com/consensys/gnark-crypto/internal/parallel"
+)
+
+// ProofMultiset proves that two tables f and g are equal as multisets: the
+// columns of g are the columns of f, up to a permutation (the same for all rows).
+//
+// Unlike a lookup proof, there is no sorted concatenation of f and g to commit to,
+// so it is a lot cheaper: the proof is a single permutation proof on the rows folded
+// with a random challenge.
+//
+// A ProofMultiset is a shuffle proof of the committed vectors. Ciphertexts are shuffled
+// by putting their coordinates in the rows of the tables; note that the ciphertexts
+// are not re-randomized, which a mixnet must prove separately.
+type ProofMultiset struct {
+
+	// commitments to the rows of f and g
+	fs, gs []kzg.Digest
+
+	// proof that the folded f and g are permuted
+	permutationProof permutation.Proof
+}
+
+// Commitments returns the commitments to the rows of f and of g
+func (proof *ProofMultiset) Commitments() (fs, gs []kzg.Digest) {
+	fs = make([]kzg.Digest, len(proof.fs))
+	gs = make([]kzg.Digest, len(proof.gs))
+	copy(fs, proof.fs)
+	copy(gs, proof.gs)
+	return
+}
+
+// PermutationProof returns the proof that the folded f and g are permuted
+func (proof *ProofMultiset) PermutationProof() permutation.Proof {
+	return proof.permutationProof
+}
+
+// SizeInBytes returns the size of the proof, with its points in compressed form
+func (proof *ProofMultiset) SizeInBytes() int {
+	// the commitments to the rows are prefixed by their number, on 4 bytes
+	return 8 + (len(proof.fs)+len(proof.gs))*bls24315.SizeOfG1AffineCompressed +
+		proof.permutationProof.SizeInBytes()
+}
+
+// ProveMultiset generates a proof that the vectors f and g are equal as multisets.
+// f and g must be of the same size, which needs not be a power of 2: both are
+// padded with zeroes up to the next power of 2, and the commitments in the proof are
+// the ones of the padded vectors.
+func ProveMultiset(srs *kzg.SRS, f, g Table) (ProofMultiset, error) {
+	return ProveMultisetTables(srs, []Table{f}, []Table{g})
+}
+
+// ProveMultisetTables generates a proof that the tables f and g, seen as lists of
+// columns f[:][i], are equal as multisets: there is a permutation σ such that
+// f[:][i] = g[:][σ(i)] for all i. It is typically used to prove that a list of records
+// (e.g. (address, value, timestamp) tuples in a memory trace) has been shuffled.
+//
+// The Table in f and g must all be of the same size. As in ProveMultiset, they are padded
+// with zeroes up to the next power of 2.
+func ProveMultisetTables(srs *kzg.SRS, f, g []Table) (ProofMultiset, error) {
+
+	// res
+	proof := ProofMultiset{}
+	var err error
+
+	// hash function used for Fiat Shamir
+	hFunc := sha256.New()
+
+	// transcript to derive the challenge
+	fs := fiatshamir.NewTranscript(hFunc, "lambda")
+
+	// check the sizes
+	if len(f) == 0 || len(f) != len(g) || len(f[0]) == 0 {
+		return proof, ErrIncompatibleSize
+	}
+	s := len(f[0])
+	for i := 0; i < len(f); i++ {
+		if len(f[i]) != s || len(g[i]) != s {
+			return proof, ErrIncompatibleSize
+		}
+	}
+
+	// commit to the rows of f and g, padded with zeroes
+	nbRows := len(f)
+	d := fft.NewDomain(uint64(s))
+	nbColumns := int(d.Cardinality)
+	proof.fs = make([]kzg.Digest, nbRows)
+	proof.gs = make([]kzg.Digest, nbRows)
+	lfs := make([][]fr.Element, nbRows)
+	lgs := make([][]fr.Element, nbRows)
+
+	// the rows of f and g are interpolated and committed in parallel
+	errs := make([]error, 2*nbRows)
+	var wg sync.WaitGroup
+	wg.Add(2 * nbRows)
+	interpolateAndCommit := func(src []fr.Element, l *[]fr.Element, digest *kzg.Digest, err *error) {
+		defer wg.Done()
+		*l = make([]fr.Element, nbColumns)
+		copy(*l, src)
+		c := make([]fr.Element, nbColumns)
+		copy(c, *l)
+		d.FFTInverse(c, fft.DIF)
+		fft.BitReverse(c)
+		*digest, *err = kzg.Commit(c, srs)
+	}
+	for i := 0; i < nbRows; i++ {
+		go interpolateAndCommit(f[i], &lfs[i], &proof.fs[i], &errs[2*i])
+		go interpolateAndCommit(g[i], &lgs[i], &proof.gs[i], &errs[2*i+1])
+	}
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			return proof, err
+		}
+	}
+
+	// fold f and g
+	comms := make([]*kzg.Digest, 2*nbRows)
+	for i := 0; i < nbRows; i++ {
+		comms[i] = &proof.fs[i]
+		comms[nbRows+i] = &proof.gs[i]
+	}
+	lambda, err := deriveRandomness(&fs, "lambda", comms...)
+	if err != nil {
+		return proof, err
+	}
+	foldedf := make(Table, nbColumns)
+	foldedg := make(Table, nbColumns)
+	parallel.Execute(nbColumns, func(start, end int) {
+		for i := start; i < end; i++ {
+			for j := nbRows - 1; j >= 0; j-- {
+				foldedf[i].Mul(&foldedf[i], &lambda).
+					Add(&foldedf[i], &lfs[j][i])
+				foldedg[i].Mul(&foldedg[i], &lambda).
+					Add(&foldedg[i], &lgs[j][i])
+			}
+		}
+	})
+
+	// prove that the folded f and g are permuted
+	proof.permutationProof, err = permutation.Prove(srs, foldedf, foldedg)
+
+	return proof, err
+}
+
+// VerifyMultiset verifies a ProofMultiset proof, generated by ProveMultiset or
+// ProveMultisetTables.
+func VerifyMultiset(srs *kzg.SRS, proof ProofMultiset) error {
+	if err := verifyFoldedCommitments(&proof); err != nil {
+		return err
+	}
+	return permutation.Verify(srs, proof.permutationProof)
+}
+
+// BatchVerifyMultiset verifies a list of ProofMultiset proofs, for instance the shuffles
+// of the successive rounds of a mixnet, with a single pairing check (see permutation.BatchVerify).
+//
+// When it fails, the error does not tell which proof is invalid.
+func BatchVerifyMultiset(srs *kzg.SRS, proofs []ProofMultiset) error {
+	permutationProofs := make([]permutation.Proof, len(proofs))
+	for i := range proofs {
+		if err := verifyFoldedCommitments(&proofs[i]); err != nil {
+			return err
+		}
+		permutationProofs[i] = proofs[i].permutationProof
+	}
+	return permutation.BatchVerify(srs, permutationProofs)
+}
+
+// verifyFoldedCommitments checks that the commitments of the permutation proof are the
+// commitments to the rows of f and g, folded with the challenge lambda.
+func verifyFoldedCommitments(proof *ProofMultiset) error {
+
+	// hash function used for Fiat Shamir
+	hFunc := sha256.New()
+
+	// transcript to derive the challenge
+	fs := fiatshamir.NewTranscript(hFunc, "lambda")
+
+	// check that the number of digests is the same
+	if len(proof.fs) == 0 || len(proof.fs) != len(proof.gs) {
+		return ErrNumberDigests
+	}
+
+	// derive the folding challenge
+	nbRows := len(proof.fs)
+	comms := make([]*kzg.Digest, 2*nbRows)
+	for i := 0; i < nbRows; i++ {
+		comms[i] = &proof.fs[i]
+		comms[nbRows+i] = &proof.gs[i]
+	}
+	lambda, err := deriveRandomness(&fs, "lambda", comms...)
+	if err != nil {
+		return err
+	}
+
+	// fold the commitments of the rows of f and g
+	var comf, comg kzg.Digest
+	comf.Set(&proof.fs[nbRows-1])
+	comg.Set(&proof.gs[nbRows-1])
+	var blambda big.Int
+	lambda.ToBigIntRegular(&blambda)
+	for i := nbRows - 2; i >= 0; i-- {
+		comf.ScalarMultiplication(&comf, &blambda).
+			Add(&comf, &proof.fs[i])
+		comg.ScalarMultiplication(&comg, &blambda).
+			Add(&comg, &proof.gs[i])
+	}
+
+	// check that the folded commitments are the ones of the permutation proof
+	permutationComms := proof.permutationProof.Commitments()
+	if !comf.Equal(&permutationComms[0]) || !comg.Equal(&permutationComms[1]) {
+		return ErrFoldedCommitment
+	}
+	return nil
+}
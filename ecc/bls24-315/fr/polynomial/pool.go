@@ -21,7 +21,6 @@ import (
 	"github.com/consensys/gnark-crypto/ecc/bls24-315/fr"
 	"reflect"
 	"sync"
-	"unsafe"
 )
 
 // Memory management for polynomials
@@ -122,9 +121,13 @@ func Dump(arrs ...[]fr.Element) int {
 	return cnt
 }
 
-func ptr(m []fr.Element) unsafe.Pointer {
-	if cap(m) != maxNForSmallPool && cap(m) != maxNForLargePool {
-		panic(fmt.Sprintf("can't cast to large or small array, the put array's is %v it should have capacity %v or %v", cap(m), maxNForLargePool, maxNForSmallPool))
+// ptr returns the pointer to the array backing m, as registered by Make
+func ptr(m []fr.Element) interface{} {
+	switch cap(m) {
+	case maxNForSmallPool:
+		return (*smallArr)(m[:maxNForSmallPool])
+	case maxNForLargePool:
+		return (*largeArr)(m[:maxNForLargePool])
 	}
-	return unsafe.Pointer(&m[0])
+	panic(fmt.Sprintf("can't cast to large or small array, the put array's is %v it should have capacity %v or %v", cap(m), maxNForLargePool, maxNForSmallPool))
 }
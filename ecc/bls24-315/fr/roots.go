@@ -0,0 +1,79 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package fr
+
+import (
+	"errors"
+	"math/bits"
+)
+
+// ErrRootOfUnityOrder is returned when the requested root of unity does not exist in the field
+var ErrRootOfUnityOrder = errors.New("order must be a power of 2 dividing 2^TwoAdicity")
+
+const (
+	multiplicativeGenerator = 7
+	rootOfUnityDecimal      = "1792993287828780812362846131493071959406149719416102105453370749552622525216"
+)
+
+// rootOfUnity is a primitive 2^TwoAdicity-th root of unity
+var rootOfUnity Element
+
+func init() {
+	if _, err := rootOfUnity.SetString(rootOfUnityDecimal); err != nil {
+		panic(err)
+	}
+}
+
+// MultiplicativeGenerator returns a generator of the multiplicative group of the field
+func MultiplicativeGenerator() Element {
+	var g Element
+	g.SetUint64(multiplicativeGenerator)
+	return g
+}
+
+// RootOfUnity returns a primitive root of unity of the given order, which
+// must be a power of 2 not larger than 2^TwoAdicity.
+//
+// The roots returned are consistent: RootOfUnity(n)² == RootOfUnity(n/2).
+func RootOfUnity(order uint64) (Element, error) {
+	var res Element
+	if order == 0 || order&(order-1) != 0 {
+		return res, ErrRootOfUnityOrder
+	}
+	logOrder := uint64(bits.TrailingZeros64(order))
+	if logOrder > TwoAdicity {
+		return res, ErrRootOfUnityOrder
+	}
+	res.ExpUint64(rootOfUnity, uint64(1)<<(TwoAdicity-logOrder))
+	return res, nil
+}
+
+// CosetGenerators returns n shifts g⁰, g¹, …, gⁿ⁻¹ of the multiplicative
+// generator g, such that the cosets gⁱ⋅H are pairwise disjoint for any subgroup
+// H of order 2^TwoAdicity or less.
+func CosetGenerators(n int) []Element {
+	res := make([]Element, n)
+	if n == 0 {
+		return res
+	}
+	g := MultiplicativeGenerator()
+	res[0].SetOne()
+	for i := 1; i < n; i++ {
+		res[i].Mul(&res[i-1], &g)
+	}
+	return res
+}
@@ -0,0 +1,208 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package shplonk
+
+import (
+	"crypto/sha256"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bls24-315/fr"
+	"github.com/consensys/gnark-crypto/ecc/bls24-315/fr/kzg"
+)
+
+// testSRS re-used accross tests of the shplonk scheme
+var testSRS *kzg.SRS
+
+func init() {
+	const srsSize = 64
+	testSRS, _ = kzg.NewSRS(srsSize, new(big.Int).SetInt64(42))
+}
+
+// randomInstance returns random polynomials of the given sizes, their commitments,
+// and random sets of points of the given sizes, the first point being shared by all the sets
+func randomInstance(t *testing.T, sizes, nbPoints []int) ([][]fr.Element, []kzg.Digest, [][]fr.Element) {
+	polynomials := make([][]fr.Element, len(sizes))
+	digests := make([]kzg.Digest, len(sizes))
+	points := make([][]fr.Element, len(sizes))
+	var shared fr.Element
+	shared.SetRandom()
+	for i := range sizes {
+		polynomials[i] = make([]fr.Element, sizes[i])
+		for j := range polynomials[i] {
+			polynomials[i][j].SetRandom()
+		}
+		var err error
+		digests[i], err = kzg.Commit(polynomials[i], testSRS)
+		if err != nil {
+			t.Fatal(err)
+		}
+		points[i] = make([]fr.Element, nbPoints[i])
+		points[i][0] = shared
+		for j := 1; j < nbPoints[i]; j++ {
+			points[i][j].SetRandom()
+		}
+	}
+	return polynomials, digests, points
+}
+
+func TestOpening(t *testing.T) {
+	t.Parallel()
+
+	// the polynomials have different sizes, some are smaller than their set of points
+	polynomials, digests, points := randomInstance(t,
+		[]int{64, 20, 3, 1, 33},
+		[]int{1, 5, 4, 2, 3},
+	)
+
+	proof, err := BatchOpen(polynomials, digests, points, sha256.New(), testSRS)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range polynomials {
+		for j := range points[i] {
+			expected := eval(polynomials[i], points[i][j])
+			if !proof.ClaimedValues[i][j].Equal(&expected) {
+				t.Fatal("wrong claimed value")
+			}
+		}
+	}
+	if err := BatchVerify(proof, digests, points, sha256.New(), testSRS); err != nil {
+		t.Fatal(err)
+	}
+
+	// the extra data is binded to the challenges
+	proof, err = BatchOpen(polynomials, digests, points, sha256.New(), testSRS, []byte("data"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := BatchVerify(proof, digests, points, sha256.New(), testSRS, []byte("data")); err != nil {
+		t.Fatal(err)
+	}
+	if err := BatchVerify(proof, digests, points, sha256.New(), testSRS); !errors.Is(err, ecc.ErrPairingCheckFailed) {
+		t.Fatalf("expected %v, got %v", ecc.ErrPairingCheckFailed, err)
+	}
+
+	// wrong claimed value
+	proof.ClaimedValues[1][2].SetRandom()
+	if err := BatchVerify(proof, digests, points, sha256.New(), testSRS, []byte("data")); !errors.Is(err, ecc.ErrPairingCheckFailed) {
+		t.Fatalf("expected %v, got %v", ecc.ErrPairingCheckFailed, err)
+	}
+}
+
+func TestOpeningErrors(t *testing.T) {
+	t.Parallel()
+
+	polynomials, digests, points := randomInstance(t, []int{10, 12}, []int{2, 3})
+
+	if _, err := BatchOpen(polynomials, digests[:1], points, sha256.New(), testSRS); err != kzg.ErrInvalidNbDigests {
+		t.Fatalf("expected %v, got %v", kzg.ErrInvalidNbDigests, err)
+	}
+	if _, err := BatchOpen(polynomials, digests, points[:1], sha256.New(), testSRS); err != ErrInvalidNumberOfPoints {
+		t.Fatalf("expected %v, got %v", ErrInvalidNumberOfPoints, err)
+	}
+	tooLarge := [][]fr.Element{make([]fr.Element, len(testSRS.G1)+1), polynomials[1]}
+	if _, err := BatchOpen(tooLarge, digests, points, sha256.New(), testSRS); err != kzg.ErrInvalidPolynomialSize {
+		t.Fatalf("expected %v, got %v", kzg.ErrInvalidPolynomialSize, err)
+	}
+	if _, err := BatchOpen(polynomials, digests, [][]fr.Element{points[0], {}}, sha256.New(), testSRS); err != ErrEmptyPointSet {
+		t.Fatalf("expected %v, got %v", ErrEmptyPointSet, err)
+	}
+	duplicated := [][]fr.Element{points[0], {points[1][0], points[1][1], points[1][0]}}
+	if _, err := BatchOpen(polynomials, digests, duplicated, sha256.New(), testSRS); err != ErrDuplicatedPoint {
+		t.Fatalf("expected %v, got %v", ErrDuplicatedPoint, err)
+	}
+
+	proof, err := BatchOpen(polynomials, digests, points, sha256.New(), testSRS)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := BatchVerify(proof, digests, [][]fr.Element{points[0], points[1][:2]}, sha256.New(), testSRS); err != ErrInvalidNbClaimedValues {
+		t.Fatalf("expected %v, got %v", ErrInvalidNbClaimedValues, err)
+	}
+	if err := BatchVerify(proof, digests, duplicated, sha256.New(), testSRS); err != ErrDuplicatedPoint {
+		t.Fatalf("expected %v, got %v", ErrDuplicatedPoint, err)
+	}
+}
+
+func TestQuotientByVanishing(t *testing.T) {
+	t.Parallel()
+
+	// f = q * ∏ᵢ(X-pᵢ) + r, deg(r) < len(points)
+	const size, nbPoints = 12, 4
+	f := make([]fr.Element, size)
+	for i := range f {
+		f[i].SetRandom()
+	}
+	points := make([]fr.Element, nbPoints)
+	for i := range points {
+		points[i].SetRandom()
+	}
+	q := quotientByVanishing(f, points)
+	if len(q) != size-nbPoints {
+		t.Fatal("unexpected size of the quotient")
+	}
+
+	var x, qx, zx, tmp, fx fr.Element
+	x.SetRandom()
+	qx = eval(q, x)
+	zx.SetOne()
+	for i := range points {
+		tmp.Sub(&x, &points[i])
+		zx.Mul(&zx, &tmp)
+	}
+
+	// r is the interpolation of f on the points
+	values := make([]fr.Element, nbPoints)
+	for i := range points {
+		values[i] = eval(f, points[i])
+	}
+	rx, err := interpolateAt(points, values, x)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fx = eval(f, x)
+	qx.Mul(&qx, &zx).Add(&qx, &rx)
+	if !qx.Equal(&fx) {
+		t.Fatal("f != q * Z + r")
+	}
+}
+
+func BenchmarkBatchOpen(b *testing.B) {
+	const nbPolynomials = 10
+	polynomials := make([][]fr.Element, nbPolynomials)
+	digests := make([]kzg.Digest, nbPolynomials)
+	points := make([][]fr.Element, nbPolynomials)
+	for i := range polynomials {
+		polynomials[i] = make([]fr.Element, len(testSRS.G1))
+		for j := range polynomials[i] {
+			polynomials[i][j].SetRandom()
+		}
+		digests[i], _ = kzg.Commit(polynomials[i], testSRS)
+		points[i] = make([]fr.Element, 1+i%3)
+		for j := range points[i] {
+			points[i][j].SetRandom()
+		}
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = BatchOpen(polynomials, digests, points, sha256.New(), testSRS)
+	}
+}
@@ -0,0 +1,24 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+// Package zerocheck provides a reusable sub-protocol proving that a committed
+// polynomial f vanishes on a multiplicative subgroup H of size n.
+//
+// The prover commits to q = f/Z_H, where Z_H = Xⁿ-1, derives an evaluation
+// challenge ζ from a transcript supplied by the caller, and opens f and q at ζ.
+// The verifier checks f(ζ) = q(ζ)(ζⁿ-1). Protocols built on top of it (plookup,
+// permutation, ...) only need to provide the numerator f and the transcript.
+package zerocheck
@@ -0,0 +1,129 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package zerocheck
+
+import (
+	"crypto/sha256"
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls24-315/fr"
+	"github.com/consensys/gnark-crypto/ecc/bls24-315/fr/fft"
+	"github.com/consensys/gnark-crypto/ecc/bls24-315/fr/kzg"
+	fiatshamir "github.com/consensys/gnark-crypto/fiat-shamir"
+)
+
+// vanishingMultiple returns g(Xⁿ-1) and g in canonical form, with g random of size m
+func vanishingMultiple(n, m int) ([]fr.Element, []fr.Element) {
+	res := make([]fr.Element, n+m)
+	g := make([]fr.Element, m)
+	for i := 0; i < m; i++ {
+		g[i].SetRandom()
+		res[i].Sub(&res[i], &g[i])
+		res[i+n].Add(&res[i+n], &g[i])
+	}
+	return res, g
+}
+
+func TestDivideByVanishing(t *testing.T) {
+
+	const n = 8
+	f, g := vanishingMultiple(n, 13)
+	q, err := DivideByVanishing(f, n)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(q) != len(g) {
+		t.Fatal("wrong quotient size")
+	}
+	for i := 0; i < len(q); i++ {
+		if !q[i].Equal(&g[i]) {
+			t.Fatal("wrong quotient")
+		}
+	}
+
+	f[3].SetRandom()
+	if _, err = DivideByVanishing(f, n); err != ErrNotVanishing {
+		t.Fatal("expected ErrNotVanishing")
+	}
+}
+
+func TestQuotientFromCosetEvaluations(t *testing.T) {
+
+	const n = 16
+	d := fft.NewDomain(n)
+	f, expected := vanishingMultiple(n, n)
+
+	// evaluate f on the coset, in bit reversed order
+	evaluations := make([]fr.Element, n)
+	var x fr.Element
+	x.Set(&d.FrMultiplicativeGen)
+	for i := 0; i < n; i++ {
+		for j := len(f) - 1; j >= 0; j-- {
+			evaluations[i].Mul(&evaluations[i], &x).Add(&evaluations[i], &f[j])
+		}
+		x.Mul(&x, &d.Generator)
+	}
+	fft.BitReverse(evaluations)
+
+	q := QuotientFromCosetEvaluations(evaluations, d)
+	for i := 0; i < n; i++ {
+		if !q[i].Equal(&expected[i]) {
+			t.Fatal("wrong quotient")
+		}
+	}
+}
+
+func TestProof(t *testing.T) {
+
+	const n = 8
+	srs, err := kzg.NewSRS(64, big.NewInt(13))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	f, _ := vanishingMultiple(n, 2*n)
+	digest, err := kzg.Commit(f, srs)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fs := fiatshamir.NewTranscript(sha256.New(), "zeta")
+	proof, err := Prove(srs, f, digest, n, &fs, "zeta")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// correct proof
+	fs = fiatshamir.NewTranscript(sha256.New(), "zeta")
+	if err = Verify(srs, digest, proof, n, &fs, "zeta"); err != nil {
+		t.Fatal(err)
+	}
+
+	// wrong size
+	fs = fiatshamir.NewTranscript(sha256.New(), "zeta")
+	if err = Verify(srs, digest, proof, 2*n, &fs, "zeta"); err == nil {
+		t.Fatal("verifying with a wrong size should have failed")
+	}
+
+	// wrong claimed value
+	proof.BatchedProof.ClaimedValues[0].SetRandom()
+	fs = fiatshamir.NewTranscript(sha256.New(), "zeta")
+	if err = Verify(srs, digest, proof, n, &fs, "zeta"); err == nil {
+		t.Fatal("verifying wrong proof should have failed")
+	}
+}
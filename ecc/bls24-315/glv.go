@@ -0,0 +1,36 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package bls24315
+
+import (
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc"
+)
+
+// GLVLambda returns λ, the eigenvalue of the GLV endomorphism ϕ restricted to the
+// prime order subgroups of G1 and G2, i.e. ϕ(P) = [λ]P.
+func GLVLambda() *big.Int {
+	return new(big.Int).Set(&lambdaGLV)
+}
+
+// SplitScalarGLV decomposes s into (k₁, k₂) such that k₁ + k₂⋅λ = s mod r,
+// where λ is GLVLambda(). Both halves are about half the bit size of r
+// and may be negative.
+func SplitScalarGLV(s *big.Int) [2]big.Int {
+	return ecc.SplitScalar(s, &glvBasis)
+}
@@ -19,10 +19,13 @@ package bls24315
 import (
 	"encoding/binary"
 	"errors"
+	"fmt"
 	"io"
 	"reflect"
+	"sync"
 	"sync/atomic"
 
+	"github.com/consensys/gnark-crypto/ecc"
 	"github.com/consensys/gnark-crypto/ecc/bls24-315/fp"
 	"github.com/consensys/gnark-crypto/ecc/bls24-315/fr"
 	"github.com/consensys/gnark-crypto/ecc/bls24-315/internal/fptower"
@@ -47,6 +50,9 @@ const (
 	mCompressedInfinity   byte = 0b110 << 5
 )
 
+// errSqrtDoesntExist is returned when decompressing an x coordinate for which the curve equation has no solution
+var errSqrtDoesntExist = fmt.Errorf("invalid compressed coordinate: square root doesn't exist: %w", ecc.ErrPointNotOnCurve)
+
 // SizeOfGT represents the size in bytes that a GT element need in binary form
 const SizeOfGT = fptower.SizeOfGT
 
@@ -328,6 +334,16 @@ func (dec *Decoder) readUint32() (r uint32, err error) {
 	return
 }
 
+// isZeroBytes returns true if all the bytes of buf are zero
+func isZeroBytes(buf []byte) bool {
+	for _, b := range buf {
+		if b != 0 {
+			return false
+		}
+	}
+	return true
+}
+
 func isCompressed(msb byte) bool {
 	mData := msb & mMask
 	return !((mData == mUncompressed) || (mData == mUncompressedInfinity))
@@ -720,6 +736,136 @@ func (p *G1Affine) SetBytes(buf []byte) (int, error) {
 	return p.setBytes(buf, true)
 }
 
+// SetBytesChecked sets p from its binary representation in buf, which must match exactly either
+// RawBytes() or Bytes() output.
+//
+// Unlike SetBytes, it rejects non canonical encodings: buf must have the exact size of the encoding,
+// the metadata bits must be valid, the infinity point must be encoded with zeroes, and the coordinates
+// must be smaller than the modulus. The returned error wraps
+//   - ecc.ErrNonCanonicalEncoding if the encoding is malformed (or io.ErrShortBuffer if buf is too short)
+//   - ecc.ErrPointNotOnCurve if the coordinates don't satisfy the curve equation
+//   - ecc.ErrPointNotInSubGroup if the point is not in the correct subgroup
+func (p *G1Affine) SetBytesChecked(buf []byte) error {
+	if len(buf) == 0 {
+		return io.ErrShortBuffer
+	}
+
+	// most significant byte
+	mData := buf[0] & mMask
+
+	size := SizeOfG1AffineCompressed
+	switch mData {
+	case mUncompressed, mUncompressedInfinity:
+		size = SizeOfG1AffineUncompressed
+	case mCompressedSmallest, mCompressedLargest, mCompressedInfinity:
+	default:
+		return fmt.Errorf("%w: invalid metadata bits %08b", ecc.ErrNonCanonicalEncoding, mData)
+	}
+	if len(buf) < size {
+		return io.ErrShortBuffer
+	}
+	if len(buf) > size {
+		return fmt.Errorf("%w: expected %d bytes, got %d", ecc.ErrNonCanonicalEncoding, size, len(buf))
+	}
+
+	// we copy the first coordinate without the metadata bits
+	var bufX [fp.Bytes]byte
+	copy(bufX[:], buf[:fp.Bytes])
+	bufX[0] &= ^mMask
+
+	if mData == mCompressedInfinity || mData == mUncompressedInfinity {
+		if !isZeroBytes(bufX[:]) || !isZeroBytes(buf[fp.Bytes:]) {
+			return fmt.Errorf("%w: the infinity point must be encoded with zeroes", ecc.ErrNonCanonicalEncoding)
+		}
+		p.X.SetZero()
+		p.Y.SetZero()
+		return nil
+	}
+
+	// read the coordinates
+	coords := [...]*fp.Element{&p.X, &p.Y}
+	nbCoords := size / fp.Bytes
+	for i := 0; i < nbCoords; i++ {
+		b := buf[i*fp.Bytes : (i+1)*fp.Bytes]
+		if i == 0 {
+			b = bufX[:]
+		}
+		if err := coords[i].SetBytesCanonical(b); err != nil {
+			return fmt.Errorf("%w: %v", ecc.ErrNonCanonicalEncoding, err)
+		}
+	}
+
+	if mData == mUncompressed {
+		if !p.IsOnCurve() {
+			return ecc.ErrPointNotOnCurve
+		}
+	} else {
+		// store mData in Y and solve the curve equation, as in the Decoder
+		p.Y[0] = uint64(mData)
+		if err := p.unsafeComputeY(false); err != nil {
+			return err
+		}
+	}
+
+	if !p.IsInSubGroup() {
+		return ecc.ErrPointNotInSubGroup
+	}
+	return nil
+}
+
+// UnmarshalBatchG1Affine decodes a buffer of concatenated G1Affine encodings, as
+// produced by Bytes or RawBytes, splitting the points across CPUs.
+//
+// The decoding is parallel, as in the Decoder, but not batched: the decompression of a point
+// is dominated by a square root, an exponentiation which can't be shared across points as
+// inversions are. Unlike the Decoder, it reads a raw buffer, without length prefix, and
+// reports the index of the first invalid point.
+//
+// All points must use the same encoding (compressed or not) as the first one.
+// If subGroupCheck is set, every decoded point is checked to be in the subgroup.
+// On error, the returned error is an *ecc.IndexedError pointing at the first invalid point.
+func UnmarshalBatchG1Affine(buf []byte, subGroupCheck bool) ([]G1Affine, error) {
+	if len(buf) == 0 {
+		return []G1Affine{}, nil
+	}
+
+	stride := SizeOfG1AffineCompressed
+	if !isCompressed(buf[0]) {
+		stride = SizeOfG1AffineUncompressed
+	}
+	if len(buf)%stride != 0 {
+		return nil, fmt.Errorf("%w: buffer length %d is not a multiple of %d", ecc.ErrNonCanonicalEncoding, len(buf), stride)
+	}
+
+	points := make([]G1Affine, len(buf)/stride)
+
+	// lowest index of an invalid point, and its error
+	var lock sync.Mutex
+	firstErr, firstIdx := error(nil), len(points)
+
+	parallel.Execute(len(points), func(start, end int) {
+		for i := start; i < end; i++ {
+			n, err := points[i].setBytes(buf[i*stride:(i+1)*stride], subGroupCheck)
+			if err == nil && n != stride {
+				err = fmt.Errorf("%w: mixed compressed and uncompressed points", ecc.ErrNonCanonicalEncoding)
+			}
+			if err != nil {
+				lock.Lock()
+				if i < firstIdx {
+					firstErr, firstIdx = err, i
+				}
+				lock.Unlock()
+				return
+			}
+		}
+	})
+	if firstErr != nil {
+		return nil, &ecc.IndexedError{Index: firstIdx, Err: firstErr}
+	}
+
+	return points, nil
+}
+
 func (p *G1Affine) setBytes(buf []byte, subGroupCheck bool) (int, error) {
 	if len(buf) < SizeOfG1AffineCompressed {
 		return 0, io.ErrShortBuffer
@@ -755,7 +901,7 @@ func (p *G1Affine) setBytes(buf []byte, subGroupCheck bool) (int, error) {
 
 		// subgroup check
 		if subGroupCheck && !p.IsInSubGroup() {
-			return 0, errors.New("invalid point: subgroup check failed")
+			return 0, ecc.ErrPointNotInSubGroup
 		}
 
 		return SizeOfG1AffineUncompressed, nil
@@ -778,7 +924,7 @@ func (p *G1Affine) setBytes(buf []byte, subGroupCheck bool) (int, error) {
 	YSquared.Square(&p.X).Mul(&YSquared, &p.X)
 	YSquared.Add(&YSquared, &bCurveCoeff)
 	if Y.Sqrt(&YSquared) == nil {
-		return 0, errors.New("invalid compressed coordinate: square root doesn't exist")
+		return 0, errSqrtDoesntExist
 	}
 
 	if Y.LexicographicallyLargest() {
@@ -797,7 +943,7 @@ func (p *G1Affine) setBytes(buf []byte, subGroupCheck bool) (int, error) {
 
 	// subgroup check
 	if subGroupCheck && !p.IsInSubGroup() {
-		return 0, errors.New("invalid point: subgroup check failed")
+		return 0, ecc.ErrPointNotInSubGroup
 	}
 
 	return SizeOfG1AffineCompressed, nil
@@ -816,7 +962,7 @@ func (p *G1Affine) unsafeComputeY(subGroupCheck bool) error {
 	YSquared.Square(&p.X).Mul(&YSquared, &p.X)
 	YSquared.Add(&YSquared, &bCurveCoeff)
 	if Y.Sqrt(&YSquared) == nil {
-		return errors.New("invalid compressed coordinate: square root doesn't exist")
+		return errSqrtDoesntExist
 	}
 
 	if Y.LexicographicallyLargest() {
@@ -835,7 +981,7 @@ func (p *G1Affine) unsafeComputeY(subGroupCheck bool) error {
 
 	// subgroup check
 	if subGroupCheck && !p.IsInSubGroup() {
-		return errors.New("invalid point: subgroup check failed")
+		return ecc.ErrPointNotInSubGroup
 	}
 
 	return nil
@@ -1058,6 +1204,139 @@ func (p *G2Affine) SetBytes(buf []byte) (int, error) {
 	return p.setBytes(buf, true)
 }
 
+// SetBytesChecked sets p from its binary representation in buf, which must match exactly either
+// RawBytes() or Bytes() output.
+//
+// Unlike SetBytes, it rejects non canonical encodings: buf must have the exact size of the encoding,
+// the metadata bits must be valid, the infinity point must be encoded with zeroes, and the coordinates
+// must be smaller than the modulus. The returned error wraps
+//   - ecc.ErrNonCanonicalEncoding if the encoding is malformed (or io.ErrShortBuffer if buf is too short)
+//   - ecc.ErrPointNotOnCurve if the coordinates don't satisfy the curve equation
+//   - ecc.ErrPointNotInSubGroup if the point is not in the correct subgroup
+func (p *G2Affine) SetBytesChecked(buf []byte) error {
+	if len(buf) == 0 {
+		return io.ErrShortBuffer
+	}
+
+	// most significant byte
+	mData := buf[0] & mMask
+
+	size := SizeOfG2AffineCompressed
+	switch mData {
+	case mUncompressed, mUncompressedInfinity:
+		size = SizeOfG2AffineUncompressed
+	case mCompressedSmallest, mCompressedLargest, mCompressedInfinity:
+	default:
+		return fmt.Errorf("%w: invalid metadata bits %08b", ecc.ErrNonCanonicalEncoding, mData)
+	}
+	if len(buf) < size {
+		return io.ErrShortBuffer
+	}
+	if len(buf) > size {
+		return fmt.Errorf("%w: expected %d bytes, got %d", ecc.ErrNonCanonicalEncoding, size, len(buf))
+	}
+
+	// we copy the first coordinate without the metadata bits
+	var bufX [fp.Bytes]byte
+	copy(bufX[:], buf[:fp.Bytes])
+	bufX[0] &= ^mMask
+
+	if mData == mCompressedInfinity || mData == mUncompressedInfinity {
+		if !isZeroBytes(bufX[:]) || !isZeroBytes(buf[fp.Bytes:]) {
+			return fmt.Errorf("%w: the infinity point must be encoded with zeroes", ecc.ErrNonCanonicalEncoding)
+		}
+		p.X.SetZero()
+		p.Y.SetZero()
+		return nil
+	}
+
+	// read the coordinates
+	coords := [...]*fp.Element{
+		&p.X.B1.A1, &p.X.B1.A0, &p.X.B0.A1, &p.X.B0.A0,
+		&p.Y.B1.A1, &p.Y.B1.A0, &p.Y.B0.A1, &p.Y.B0.A0,
+	}
+	nbCoords := size / fp.Bytes
+	for i := 0; i < nbCoords; i++ {
+		b := buf[i*fp.Bytes : (i+1)*fp.Bytes]
+		if i == 0 {
+			b = bufX[:]
+		}
+		if err := coords[i].SetBytesCanonical(b); err != nil {
+			return fmt.Errorf("%w: %v", ecc.ErrNonCanonicalEncoding, err)
+		}
+	}
+
+	if mData == mUncompressed {
+		if !p.IsOnCurve() {
+			return ecc.ErrPointNotOnCurve
+		}
+	} else {
+		// store mData in Y and solve the curve equation, as in the Decoder
+		p.Y.B0.A0[0] = uint64(mData)
+		if err := p.unsafeComputeY(false); err != nil {
+			return err
+		}
+	}
+
+	if !p.IsInSubGroup() {
+		return ecc.ErrPointNotInSubGroup
+	}
+	return nil
+}
+
+// UnmarshalBatchG2Affine decodes a buffer of concatenated G2Affine encodings, as
+// produced by Bytes or RawBytes, splitting the points across CPUs.
+//
+// The decoding is parallel, as in the Decoder, but not batched: the decompression of a point
+// is dominated by a square root, an exponentiation which can't be shared across points as
+// inversions are. Unlike the Decoder, it reads a raw buffer, without length prefix, and
+// reports the index of the first invalid point.
+//
+// All points must use the same encoding (compressed or not) as the first one.
+// If subGroupCheck is set, every decoded point is checked to be in the subgroup.
+// On error, the returned error is an *ecc.IndexedError pointing at the first invalid point.
+func UnmarshalBatchG2Affine(buf []byte, subGroupCheck bool) ([]G2Affine, error) {
+	if len(buf) == 0 {
+		return []G2Affine{}, nil
+	}
+
+	stride := SizeOfG2AffineCompressed
+	if !isCompressed(buf[0]) {
+		stride = SizeOfG2AffineUncompressed
+	}
+	if len(buf)%stride != 0 {
+		return nil, fmt.Errorf("%w: buffer length %d is not a multiple of %d", ecc.ErrNonCanonicalEncoding, len(buf), stride)
+	}
+
+	points := make([]G2Affine, len(buf)/stride)
+
+	// lowest index of an invalid point, and its error
+	var lock sync.Mutex
+	firstErr, firstIdx := error(nil), len(points)
+
+	parallel.Execute(len(points), func(start, end int) {
+		for i := start; i < end; i++ {
+			n, err := points[i].setBytes(buf[i*stride:(i+1)*stride], subGroupCheck)
+			if err == nil && n != stride {
+				err = fmt.Errorf("%w: mixed compressed and uncompressed points", ecc.ErrNonCanonicalEncoding)
+			}
+			if err != nil {
+				lock.Lock()
+				if i < firstIdx {
+					firstErr, firstIdx = err, i
+				}
+				lock.Unlock()
+				return
+			}
+		}
+	})
+	if firstErr != nil {
+		return nil, &ecc.IndexedError{Index: firstIdx, Err: firstErr}
+	}
+
+	return points, nil
+}
+
 func (p *G2Affine) setBytes(buf []byte, subGroupCheck bool) (int, error) {
 	if len(buf) < SizeOfG2AffineCompressed {
 		return 0, io.ErrShortBuffer
@@ -1101,7 +1380,7 @@ func (p *G2Affine) setBytes(buf []byte, subGroupCheck bool) (int, error) {
 
 		// subgroup check
 		if subGroupCheck && !p.IsInSubGroup() {
-			return 0, errors.New("invalid point: subgroup check failed")
+			return 0, ecc.ErrPointNotInSubGroup
 		}
 
 		return SizeOfG2AffineUncompressed, nil
@@ -1128,7 +1407,7 @@ func (p *G2Affine) setBytes(buf []byte, subGroupCheck bool) (int, error) {
 	YSquared.Square(&p.X).Mul(&YSquared, &p.X)
 	YSquared.Add(&YSquared, &bTwistCurveCoeff)
 	if YSquared.Legendre() == -1 {
-		return 0, errors.New("invalid compressed coordinate: square root doesn't exist")
+		return 0, errSqrtDoesntExist
 	}
 	Y.Sqrt(&YSquared)
 
@@ -1148,7 +1427,7 @@ func (p *G2Affine) setBytes(buf []byte, subGroupCheck bool) (int, error) {
 
 	// subgroup check
 	if subGroupCheck && !p.IsInSubGroup() {
-		return 0, errors.New("invalid point: subgroup check failed")
+		return 0, ecc.ErrPointNotInSubGroup
 	}
 
 	return SizeOfG2AffineCompressed, nil
@@ -1167,7 +1446,7 @@ func (p *G2Affine) unsafeComputeY(subGroupCheck bool) error {
 	YSquared.Square(&p.X).Mul(&YSquared, &p.X)
 	YSquared.Add(&YSquared, &bTwistCurveCoeff)
 	if YSquared.Legendre() == -1 {
-		return errors.New("invalid compressed coordinate: square root doesn't exist")
+		return errSqrtDoesntExist
 	}
 	Y.Sqrt(&YSquared)
 
@@ -1187,7 +1466,7 @@ func (p *G2Affine) unsafeComputeY(subGroupCheck bool) error {
 
 	// subgroup check
 	if subGroupCheck && !p.IsInSubGroup() {
-		return errors.New("invalid point: subgroup check failed")
+		return ecc.ErrPointNotInSubGroup
 	}
 
 	return nil
@@ -0,0 +1,279 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package sigma
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bls24-315"
+	"github.com/consensys/gnark-crypto/ecc/bls24-315/fr"
+	fiatshamir "github.com/consensys/gnark-crypto/fiat-shamir"
+)
+
+var (
+	ErrMalformedStatement = errors.New("malformed statement")
+	ErrWitnessSize        = errors.New("witness size doesn't match the statement")
+	ErrInvalidWitness     = errors.New("witness doesn't satisfy the statement")
+	ErrProofSize          = errors.New("proof size doesn't match the statement")
+	ErrVerification       = errors.New("sigma protocol verification failed")
+)
+
+// Statement system of linear relations Images[j] = ∑ᵢ xᵢBases[j][i], where x is
+// the witness. Bases[j][i] may be the point at infinity when xᵢ does not appear
+// in the j-th relation.
+type Statement struct {
+	Bases  [][]bls24315.G1Affine
+	Images []bls24315.G1Affine
+}
+
+// Proof non-interactive proof of knowledge of a witness for a Statement
+type Proof struct {
+	// Commitments[j] = ∑ᵢ kᵢBases[j][i], with k random
+	Commitments []bls24315.G1Affine
+
+	// Responses[i] = kᵢ + c xᵢ, with c the challenge
+	Responses []fr.Element
+}
+
+// NewDLog returns the statement y = xg (Schnorr proof of knowledge of a discrete logarithm)
+func NewDLog(g, y bls24315.G1Affine) Statement {
+	return Statement{
+		Bases:  [][]bls24315.G1Affine{{g}},
+		Images: []bls24315.G1Affine{y},
+	}
+}
+
+// NewDLEQ returns the statement y1 = xg1, y2 = xg2 (Chaum-Pedersen proof of equality
+// of discrete logarithms, or DH-tuple proof when g1 is the generator)
+func NewDLEQ(g1, y1, g2, y2 bls24315.G1Affine) Statement {
+	return Statement{
+		Bases:  [][]bls24315.G1Affine{{g1}, {g2}},
+		Images: []bls24315.G1Affine{y1, y2},
+	}
+}
+
+// NewRepresentation returns the statement y = ∑ᵢ xᵢbases[i]
+func NewRepresentation(bases []bls24315.G1Affine, y bls24315.G1Affine) Statement {
+	b := make([]bls24315.G1Affine, len(bases))
+	copy(b, bases)
+	return Statement{
+		Bases:  [][]bls24315.G1Affine{b},
+		Images: []bls24315.G1Affine{y},
+	}
+}
+
+// And returns the conjunction of the statements. The witnesses are independent,
+// the witness of the result is the concatenation of the statements' witnesses.
+func And(statements ...Statement) Statement {
+	var res Statement
+	nbVars := 0
+	for _, s := range statements {
+		nbVars += s.nbVariables()
+	}
+	offset := 0
+	for _, s := range statements {
+		n := s.nbVariables()
+		for j := range s.Bases {
+			row := make([]bls24315.G1Affine, nbVars)
+			copy(row[offset:], s.Bases[j])
+			res.Bases = append(res.Bases, row)
+		}
+		res.Images = append(res.Images, s.Images...)
+		offset += n
+	}
+	return res
+}
+
+func (s *Statement) nbVariables() int {
+	if len(s.Bases) == 0 {
+		return 0
+	}
+	return len(s.Bases[0])
+}
+
+func (s *Statement) check() error {
+	if len(s.Bases) == 0 || len(s.Bases) != len(s.Images) {
+		return ErrMalformedStatement
+	}
+	n := len(s.Bases[0])
+	if n == 0 {
+		return ErrMalformedStatement
+	}
+	for j := range s.Bases {
+		if len(s.Bases[j]) != n {
+			return ErrMalformedStatement
+		}
+	}
+	return nil
+}
+
+// Prove returns a proof of knowledge of witness for the statement. context is
+// binded to the challenge, the verifier must provide the same.
+func Prove(statement *Statement, witness []fr.Element, context []byte) (Proof, error) {
+
+	var proof Proof
+
+	if err := statement.check(); err != nil {
+		return proof, err
+	}
+	if len(witness) != statement.nbVariables() {
+		return proof, ErrWitnessSize
+	}
+
+	config := ecc.MultiExpConfig{ScalarsMont: true}
+
+	// check the witness, so that we never output a proof for a false statement
+	var y bls24315.G1Affine
+	for j := range statement.Bases {
+		if _, err := y.MultiExp(statement.Bases[j], witness, config); err != nil {
+			return proof, err
+		}
+		if !y.Equal(&statement.Images[j]) {
+			return proof, ErrInvalidWitness
+		}
+	}
+
+	// commit to random k
+	k := make([]fr.Element, len(witness))
+	for i := range k {
+		if _, err := k[i].SetRandom(); err != nil {
+			return proof, err
+		}
+	}
+	proof.Commitments = make([]bls24315.G1Affine, len(statement.Bases))
+	for j := range statement.Bases {
+		if _, err := proof.Commitments[j].MultiExp(statement.Bases[j], k, config); err != nil {
+			return proof, err
+		}
+	}
+
+	// derive the challenge
+	c, err := deriveChallenge(statement, proof.Commitments, context)
+	if err != nil {
+		return proof, err
+	}
+
+	// s = k + cx
+	proof.Responses = make([]fr.Element, len(witness))
+	for i := range witness {
+		proof.Responses[i].Mul(&c, &witness[i]).Add(&proof.Responses[i], &k[i])
+	}
+
+	return proof, nil
+}
+
+// Verify verifies a proof for the statement, with the context used by the prover.
+func Verify(statement *Statement, proof *Proof, context []byte) error {
+	return BatchVerify([]Statement{*statement}, []Proof{*proof}, [][]byte{context})
+}
+
+// BatchVerify verifies several proofs at once. The relations are folded with
+// random coefficients and checked with a single multi-exponentiation.
+func BatchVerify(statements []Statement, proofs []Proof, contexts [][]byte) error {
+
+	if len(statements) != len(proofs) || len(statements) != len(contexts) {
+		return ErrProofSize
+	}
+
+	// ∑ₚ∑ⱼρₚⱼ(∑ᵢsᵢGⱼᵢ - Tⱼ - cYⱼ) ==? 0
+	var points []bls24315.G1Affine
+	var scalars []fr.Element
+	for p := range statements {
+		statement, proof := &statements[p], &proofs[p]
+		if err := statement.check(); err != nil {
+			return err
+		}
+		if len(proof.Commitments) != len(statement.Bases) || len(proof.Responses) != statement.nbVariables() {
+			return ErrProofSize
+		}
+
+		c, err := deriveChallenge(statement, proof.Commitments, contexts[p])
+		if err != nil {
+			return err
+		}
+
+		var rho, tmp fr.Element
+		for j := range statement.Bases {
+			if _, err := rho.SetRandom(); err != nil {
+				return err
+			}
+			for i := range statement.Bases[j] {
+				points = append(points, statement.Bases[j][i])
+				scalars = append(scalars, *tmp.Mul(&rho, &proof.Responses[i]))
+			}
+			points = append(points, proof.Commitments[j], statement.Images[j])
+			scalars = append(scalars, *tmp.Neg(&rho))
+			scalars = append(scalars, *tmp.Mul(&rho, &c).Neg(&tmp))
+		}
+	}
+
+	var res bls24315.G1Jac
+	if _, err := res.MultiExp(points, scalars, ecc.MultiExpConfig{ScalarsMont: true}); err != nil {
+		return err
+	}
+	if !res.Z.IsZero() {
+		return ErrVerification
+	}
+	return nil
+}
+
+// deriveChallenge binds the context, the statement and the commitments, and
+// returns the challenge.
+func deriveChallenge(statement *Statement, commitments []bls24315.G1Affine, context []byte) (fr.Element, error) {
+
+	var c fr.Element
+	fs := fiatshamir.NewTranscript(sha256.New(), "challenge")
+
+	if err := fs.Bind("challenge", context); err != nil {
+		return c, err
+	}
+	var dims [16]byte
+	binary.BigEndian.PutUint64(dims[:8], uint64(len(statement.Bases)))
+	binary.BigEndian.PutUint64(dims[8:], uint64(statement.nbVariables()))
+	if err := fs.Bind("challenge", dims[:]); err != nil {
+		return c, err
+	}
+	bind := func(p *bls24315.G1Affine) error {
+		buf := p.RawBytes()
+		return fs.Bind("challenge", buf[:])
+	}
+	for j := range statement.Bases {
+		for i := range statement.Bases[j] {
+			if err := bind(&statement.Bases[j][i]); err != nil {
+				return c, err
+			}
+		}
+		if err := bind(&statement.Images[j]); err != nil {
+			return c, err
+		}
+	}
+	for j := range commitments {
+		if err := bind(&commitments[j]); err != nil {
+			return c, err
+		}
+	}
+
+	b, err := fs.ComputeChallenge("challenge")
+	if err != nil {
+		return c, err
+	}
+	c.SetBytes(b)
+	return c, nil
+}
@@ -17,6 +17,7 @@
 package eddsa
 
 import (
+	"bytes"
 	"crypto/sha256"
 	"math/rand"
 	"testing"
@@ -180,6 +181,42 @@ func TestEddsaSHA256(t *testing.T) {
 
 }
 
+func TestGenerateKeyFromSeed(t *testing.T) {
+
+	var seed [32]byte
+	for i := range seed {
+		seed[i] = byte(i)
+	}
+
+	// the key pair only depends on the seed
+	privKey1 := GenerateKeyFromSeed(seed)
+	privKey2, err := GenerateKey(bytes.NewReader(seed[:]))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(privKey1.Bytes(), privKey2.Bytes()) {
+		t.Fatal("GenerateKey and GenerateKeyFromSeed should derive the same key from the same seed")
+	}
+
+	seed[0]++
+	privKey3 := GenerateKeyFromSeed(seed)
+	if bytes.Equal(privKey1.Bytes(), privKey3.Bytes()) {
+		t.Fatal("different seeds should derive different keys")
+	}
+
+	// the key is usable
+	hFunc := sha256.New()
+	msg := []byte("deterministic")
+	signature, err := privKey1.Sign(msg, hFunc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pubKey := privKey1.Public()
+	if ok, err := pubKey.Verify(signature, msg, hFunc); err != nil || !ok {
+		t.Fatal("verification of a signature with a key derived from a seed failed")
+	}
+}
+
 // benchmarks
 
 func BenchmarkVerify(b *testing.B) {
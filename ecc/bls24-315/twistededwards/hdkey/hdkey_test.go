@@ -0,0 +1,144 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package hdkey
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls24-315/twistededwards"
+)
+
+var testSeed = []byte("000102030405060708090a0b0c0d0e0f")
+
+func TestPublicDerivation(t *testing.T) {
+
+	master, err := NewMasterKey(testSeed)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the public key of a normal child is the child of the public key
+	path, err := ParsePath("m/1/2/3")
+	if err != nil {
+		t.Fatal(err)
+	}
+	priv, err := master.Derive(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pub, err := master.Public().Derive(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(priv.Public(), pub) {
+		t.Fatal("public derivation differs from private derivation")
+	}
+
+	// A = [k]G
+	c := twistededwards.GetEdwardsCurve()
+	var A twistededwards.PointAffine
+	A.ScalarMultiplication(&c.Base, priv.Scalar())
+	if !A.Equal(&pub.A) {
+		t.Fatal("public key is not [k]G")
+	}
+
+	if pub.Depth != 3 || pub.Index != 3 {
+		t.Fatal("wrong depth or index")
+	}
+	parent, _ := master.Derive(path[:2])
+	if pub.ParentFingerprint != parent.PublicKey.Fingerprint() {
+		t.Fatal("wrong parent fingerprint")
+	}
+}
+
+func TestHardenedDerivation(t *testing.T) {
+
+	master, err := NewMasterKey(testSeed)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err = master.Public().Child(HardenedOffset); err != ErrHardenedPublic {
+		t.Fatal("expected ErrHardenedPublic")
+	}
+
+	hardened, err := master.Child(HardenedOffset)
+	if err != nil {
+		t.Fatal(err)
+	}
+	normal, err := master.Child(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hardened.PublicKey.A.Equal(&normal.PublicKey.A) || bytes.Equal(hardened.PublicKey.ChainCode[:], normal.PublicKey.ChainCode[:]) {
+		t.Fatal("hardened and normal children should differ")
+	}
+
+	// the derivation is deterministic
+	path, err := ParsePath("m/44'/0h/7")
+	if err != nil {
+		t.Fatal(err)
+	}
+	a, err := master.Derive(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, _ := NewMasterKey(testSeed)
+	b, err := other.Derive(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a.Scalar().Cmp(b.Scalar()) != 0 || !reflect.DeepEqual(a.Public(), b.Public()) {
+		t.Fatal("derivation is not deterministic")
+	}
+
+	// different seeds give different keys
+	other, _ = NewMasterKey(append([]byte{1}, testSeed...))
+	if other.Scalar().Cmp(master.Scalar()) == 0 {
+		t.Fatal("master keys of different seeds should differ")
+	}
+}
+
+func TestParsePath(t *testing.T) {
+
+	path, err := ParsePath("m/44'/1h/2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(path, []uint32{44 + HardenedOffset, 1 + HardenedOffset, 2}) {
+		t.Fatal("wrong path")
+	}
+	if path, err = ParsePath("m"); err != nil || len(path) != 0 {
+		t.Fatal("expected empty path")
+	}
+	for _, p := range []string{"m/a", "m/1//2", "m/2147483648", "m/-1"} {
+		if _, err = ParsePath(p); err != ErrInvalidPath {
+			t.Fatalf("%s: expected ErrInvalidPath", p)
+		}
+	}
+}
+
+func TestInvalidSeed(t *testing.T) {
+	if _, err := NewMasterKey(make([]byte, 15)); err != ErrInvalidSeed {
+		t.Fatal("expected ErrInvalidSeed")
+	}
+	if _, err := NewMasterKey(make([]byte, 65)); err != ErrInvalidSeed {
+		t.Fatal("expected ErrInvalidSeed")
+	}
+}
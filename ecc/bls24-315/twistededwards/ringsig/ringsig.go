@@ -0,0 +1,300 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package ringsig
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"hash"
+	"io"
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bls24-315/fr"
+	"github.com/consensys/gnark-crypto/ecc/bls24-315/twistededwards"
+)
+
+var (
+	ErrRingSize      = errors.New("the ring should contain at least one public key")
+	ErrNotInRing     = errors.New("the signer's public key is not in the ring")
+	ErrInvalidKey    = errors.New("public key is not a point of the prime order subgroup")
+	ErrSignatureSize = errors.New("signature size doesn't match the ring size")
+)
+
+// PublicKey ring signature public key, A = xG
+type PublicKey struct {
+	A twistededwards.PointAffine
+}
+
+// PrivateKey ring signature private key
+type PrivateKey struct {
+	PublicKey PublicKey // copy of the associated public key
+	scalar    big.Int   // secret scalar
+}
+
+// Signature linkable ring signature (c₀, s₀, ..., sₙ₋₁, I)
+type Signature struct {
+	KeyImage twistededwards.PointAffine
+	C        big.Int
+	S        []big.Int
+}
+
+// GenerateKey generates a public and private key pair
+func GenerateKey(r io.Reader) (*PrivateKey, error) {
+	c := twistededwards.GetEdwardsCurve()
+	s, err := randomScalar(r, &c.Order)
+	if err != nil {
+		return nil, err
+	}
+	var priv PrivateKey
+	priv.scalar.Set(s)
+	priv.PublicKey.A.ScalarMultiplicationCT(&c.Base, &priv.scalar)
+	return &priv, nil
+}
+
+// KeyImage returns I = x·Hₚ(P), the tag linking the signatures of priv
+func (priv *PrivateKey) KeyImage() twistededwards.PointAffine {
+	var res twistededwards.PointAffine
+	hp := hashToPoint(&priv.PublicKey.A)
+	res.ScalarMultiplicationCT(&hp, &priv.scalar)
+	return res
+}
+
+// Sign signs message on behalf of the ring, which must contain priv's public key.
+// r is the source of randomness, hFunc is used to compute the challenges.
+func (priv *PrivateKey) Sign(r io.Reader, ring []PublicKey, message []byte, hFunc hash.Hash) (Signature, error) {
+
+	var sig Signature
+	n := len(ring)
+	if n == 0 {
+		return sig, ErrRingSize
+	}
+	pi := -1
+	for i := range ring {
+		if ring[i].A.Equal(&priv.PublicKey.A) {
+			pi = i
+			break
+		}
+	}
+	if pi == -1 {
+		return sig, ErrNotInRing
+	}
+	for i := range ring {
+		if !isValid(&ring[i].A) {
+			return sig, ErrInvalidKey
+		}
+	}
+
+	curve := twistededwards.GetEdwardsCurve()
+	sig.KeyImage = priv.KeyImage()
+	sig.S = make([]big.Int, n)
+	c := make([]big.Int, n)
+	prefix := challengePrefix(ring, &sig.KeyImage, message)
+
+	// Lπ = αG, Rπ = αHₚ(Pπ), in constant time as sπ = α - cπ·x reveals x if α leaks
+	alpha, err := randomScalar(r, &curve.Order)
+	if err != nil {
+		return sig, err
+	}
+	var l, rr, tmp twistededwards.PointAffine
+	l.ScalarMultiplicationCT(&curve.Base, alpha)
+	hp := hashToPoint(&ring[pi].A)
+	rr.ScalarMultiplicationCT(&hp, alpha)
+	if err = challenge(&c[(pi+1)%n], hFunc, prefix, &l, &rr, &curve.Order); err != nil {
+		return sig, err
+	}
+
+	// Lᵢ = sᵢG + cᵢPᵢ, Rᵢ = sᵢHₚ(Pᵢ) + cᵢI for the other members of the ring
+	for k := 1; k < n; k++ {
+		i := (pi + k) % n
+		s, err := randomScalar(r, &curve.Order)
+		if err != nil {
+			return sig, err
+		}
+		sig.S[i].Set(s)
+		ringTerm(&l, &rr, &tmp, &curve.Base, &ring[i].A, &sig.KeyImage, &sig.S[i], &c[i])
+		if err = challenge(&c[(i+1)%n], hFunc, prefix, &l, &rr, &curve.Order); err != nil {
+			return sig, err
+		}
+	}
+
+	// sπ = α - cπx closes the ring
+	sig.S[pi].Mul(&c[pi], &priv.scalar).
+		Sub(alpha, &sig.S[pi]).
+		Mod(&sig.S[pi], &curve.Order)
+	sig.C.Set(&c[0])
+
+	return sig, nil
+}
+
+// Verify verifies a ring signature of message for the ring.
+func Verify(ring []PublicKey, message []byte, sig *Signature, hFunc hash.Hash) (bool, error) {
+
+	n := len(ring)
+	if n == 0 {
+		return false, ErrRingSize
+	}
+	if len(sig.S) != n {
+		return false, ErrSignatureSize
+	}
+	for i := range ring {
+		if !isValid(&ring[i].A) {
+			return false, ErrInvalidKey
+		}
+	}
+	if !isValid(&sig.KeyImage) {
+		return false, nil
+	}
+
+	// scalars must be reduced, to prevent malleability
+	curve := twistededwards.GetEdwardsCurve()
+	if sig.C.Sign() < 0 || sig.C.Cmp(&curve.Order) >= 0 {
+		return false, nil
+	}
+	for i := range sig.S {
+		if sig.S[i].Sign() < 0 || sig.S[i].Cmp(&curve.Order) >= 0 {
+			return false, nil
+		}
+	}
+
+	prefix := challengePrefix(ring, &sig.KeyImage, message)
+
+	var c big.Int
+	var l, rr, tmp twistededwards.PointAffine
+	c.Set(&sig.C)
+	for i := 0; i < n; i++ {
+		ringTerm(&l, &rr, &tmp, &curve.Base, &ring[i].A, &sig.KeyImage, &sig.S[i], &c)
+		if err := challenge(&c, hFunc, prefix, &l, &rr, &curve.Order); err != nil {
+			return false, err
+		}
+	}
+
+	return c.Cmp(&sig.C) == 0, nil
+}
+
+// Linked returns true if the two signatures were produced with the same private key.
+// The signatures must have been verified beforehand.
+func Linked(a, b *Signature) bool {
+	return a.KeyImage.Equal(&b.KeyImage)
+}
+
+// ringTerm sets l = sG + cP and r = sHₚ(P) + cI
+func ringTerm(l, r, tmp, g, p, keyImage *twistededwards.PointAffine, s, c *big.Int) {
+	l.ScalarMultiplication(g, s)
+	tmp.ScalarMultiplication(p, c)
+	l.Add(l, tmp)
+	hp := hashToPoint(p)
+	r.ScalarMultiplication(&hp, s)
+	tmp.ScalarMultiplication(keyImage, c)
+	r.Add(r, tmp)
+}
+
+// challengePrefix returns ring || I || message, binded to every challenge
+func challengePrefix(ring []PublicKey, keyImage *twistededwards.PointAffine, message []byte) []byte {
+	res := make([]byte, 0, (len(ring)+1)*fr.Bytes+len(message))
+	for i := range ring {
+		b := ring[i].A.Bytes()
+		res = append(res, b[:]...)
+	}
+	b := keyImage.Bytes()
+	res = append(res, b[:]...)
+	return append(res, message...)
+}
+
+// challenge sets c = H(prefix || l || r) mod order
+func challenge(c *big.Int, hFunc hash.Hash, prefix []byte, l, r *twistededwards.PointAffine, order *big.Int) error {
+	hFunc.Reset()
+	if _, err := hFunc.Write(prefix); err != nil {
+		return err
+	}
+	bl, br := l.Bytes(), r.Bytes()
+	if _, err := hFunc.Write(bl[:]); err != nil {
+		return err
+	}
+	if _, err := hFunc.Write(br[:]); err != nil {
+		return err
+	}
+	c.SetBytes(hFunc.Sum(nil)).Mod(c, order)
+	return nil
+}
+
+// hashToPoint maps p to a point of the prime order subgroup whose discrete
+// logarithm is unknown, by try-and-increment on the y coordinate followed by
+// cofactor clearing.
+func hashToPoint(p *twistededwards.PointAffine) twistededwards.PointAffine {
+	curve := twistededwards.GetEdwardsCurve()
+	var cofactor big.Int
+	curve.Cofactor.ToBigIntRegular(&cofactor)
+
+	pBytes := p.Bytes()
+	var res twistededwards.PointAffine
+	var one, num, den fr.Element
+	one.SetOne()
+	var counter [8]byte
+	dst := []byte("bls24-315-twistededwards-ringsig-hash-to-point")
+	for i := uint64(0); ; i++ {
+		binary.BigEndian.PutUint64(counter[:], i)
+		b, err := ecc.ExpandMsgXmd(append(pBytes[:], counter[:]...), dst, 16+fr.Bytes)
+		if err != nil {
+			panic(err)
+		}
+
+		// x² = (1-y²)/(a-dy²)
+		res.Y.SetBytes(b)
+		num.Square(&res.Y)
+		den.Mul(&num, &curve.D)
+		den.Sub(&curve.A, &den)
+		num.Sub(&one, &num)
+		if den.IsZero() {
+			continue
+		}
+		num.Div(&num, &den)
+		if res.X.Sqrt(&num) == nil {
+			continue
+		}
+		if res.X.IsLexLargerThanHalf() {
+			res.X.Neg(&res.X)
+		}
+		res.ScalarMultiplication(&res, &cofactor)
+		if !res.IsZero() {
+			return res
+		}
+	}
+}
+
+// isValid returns true if p is a point of the prime order subgroup, different from the identity
+func isValid(p *twistededwards.PointAffine) bool {
+	if p.IsZero() || !p.IsOnCurve() {
+		return false
+	}
+	curve := twistededwards.GetEdwardsCurve()
+	var q twistededwards.PointAffine
+	q.ScalarMultiplication(p, &curve.Order)
+	return q.IsZero()
+}
+
+// randomScalar returns a scalar sampled uniformly in [1, order)
+func randomScalar(r io.Reader, order *big.Int) (*big.Int, error) {
+	var orderMinusOne big.Int
+	orderMinusOne.Sub(order, big.NewInt(1))
+	s, err := rand.Int(r, &orderMinusOne)
+	if err != nil {
+		return nil, err
+	}
+	return s.Add(s, big.NewInt(1)), nil
+}
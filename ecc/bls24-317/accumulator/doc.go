@@ -0,0 +1,34 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+// Package accumulator provides a pairing-based accumulator on bls24-317, secure under the
+// q-SDH assumption, with membership and non-membership witnesses.
+//
+// The manager holds a secret s, and publishes [s]G₂. The accumulator of a set X of
+// elements of fr is the single point V = [∏ₓ(s+x)]G₁, so that:
+//   - a membership witness for y ∈ X is W = [1/(s+y)]V, checked with e(W, [s+y]G₂) = e(V, G₂)
+//   - a non-membership witness for y ∉ X is (W, r), where r = ∏ₓ(x-y) ≠ 0 and
+//     W = [(∏ₓ(s+x) - r)/(s+y)]G₁, checked with e(W, [s+y]G₂)⋅e([r]G₁, G₂) = e(V, G₂)
+//
+// Both checks are a single multi-pairing. When elements are added or deleted, the
+// manager publishes an Update, which the holders apply to their witnesses with a single
+// multi-scalar multiplication, without the secret. A typical use is credential
+// revocation, where the accumulator holds the revoked (or valid) credential identifiers.
+//
+// # See also
+//
+// https://eprint.iacr.org/2005/123.pdf (Nguyen), https://eprint.iacr.org/2008/538.pdf (Au et al.)
+package accumulator
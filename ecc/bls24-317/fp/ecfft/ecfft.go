@@ -0,0 +1,328 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package ecfft
+
+import (
+	"errors"
+
+	"github.com/consensys/gnark-crypto/ecc/bls24-317/fp"
+)
+
+var (
+	ErrInvalidLogSize   = errors.New("log size of the domain should be between 1 and 31")
+	ErrPointNotOnCurve  = errors.New("generator and offset should be on the curve")
+	ErrInvalidGenerator = errors.New("generator should have order 2^LogSize")
+	ErrInvalidOffset    = errors.New("the points of the coset offset + ⟨generator⟩ should have distinct x-coordinates")
+	ErrInvalidSize      = errors.New("invalid number of coefficients or evaluations")
+)
+
+const maxLogSize = 31
+
+// Tree (FFTree in the paper) holds the domain L₀ of size n = 2ᵏ and its successive images
+// Lᵢ₊₁ = ψᵢ(Lᵢ) of size n/2ⁱ⁺¹, where ψᵢ is the x-coordinate map of a 2-isogeny.
+//
+// The positions are such that ψᵢ(Lᵢ[j]) = Lᵢ₊₁[j mod |Lᵢ₊₁|], in particular ψᵢ maps the points
+// at even (resp. odd) positions of Lᵢ to the points at even (resp. odd) positions of Lᵢ₊₁.
+type Tree struct {
+	layers    [][]fp.Element
+	isogenies []isogeny
+	weights   []fp.Element // barycentric weights of L₀
+}
+
+// isogeny is the x-coordinate map ψ(x) = X + a + b/X, X = x - t, of the 2-isogeny
+// of kernel (t, 0) from y² = X(X² + aX + b) to y² = X(X² - 2aX + a² - 4b)
+type isogeny struct {
+	t, a, b fp.Element
+}
+
+// NewTree checks the parameters and returns the tree of the domain they define.
+func NewTree(p Parameters) (*Tree, error) {
+	if p.LogSize < 1 || p.LogSize > maxLogSize {
+		return nil, ErrInvalidLogSize
+	}
+	c := p.Curve
+	if !c.IsOnCurve(&p.Generator) || !c.IsOnCurve(&p.Offset) {
+		return nil, ErrPointNotOnCurve
+	}
+	k := p.LogSize
+	n := 1 << k
+
+	// torsion[m] = [2ᵐ]Generator; the last one has order 2
+	torsion := make([]Point, k)
+	torsion[0] = p.Generator
+	for m := 0; m < k; m++ {
+		if (m == k-1) != torsion[m].Y.IsZero() {
+			return nil, ErrInvalidGenerator
+		}
+		if m < k-1 {
+			torsion[m+1] = c.double(&torsion[m])
+		}
+	}
+
+	// coset[j] = Offset + [j]Generator, by blocks of size 2ᵐ
+	coset := make([]Point, n)
+	coset[0] = p.Offset
+	den := make([]fp.Element, n/2)
+	for m := 0; m < k; m++ {
+		size := 1 << m
+		for j := 0; j < size; j++ {
+			den[j].Sub(&torsion[m].X, &coset[j].X)
+			if den[j].IsZero() {
+				return nil, ErrInvalidOffset
+			}
+		}
+		inv := fp.BatchInvert(den[:size])
+		for j := 0; j < size; j++ {
+			coset[size+j] = c.add(&coset[j], &torsion[m], &inv[j])
+		}
+	}
+
+	t := &Tree{
+		layers:    make([][]fp.Element, k+1),
+		isogenies: make([]isogeny, k),
+	}
+	t.layers[0] = make([]fp.Element, n)
+	seen := make(map[fp.Element]struct{}, n)
+	for j := range coset {
+		t.layers[0][j] = coset[j].X
+		seen[coset[j].X] = struct{}{}
+	}
+	if len(seen) != n {
+		return nil, ErrInvalidOffset
+	}
+
+	// x-coordinates of the [2ᵐ]Generator, mapped along the chain of isogenies
+	torsionX := make([]fp.Element, k)
+	for m := range torsion {
+		torsionX[m] = torsion[m].X
+	}
+
+	// derivatives[j] = Z'(L₀[j]), Z the vanishing polynomial of L₀
+	derivatives := make([]fp.Element, n)
+	for j := range derivatives {
+		derivatives[j].SetOne()
+	}
+
+	a2, a4 := c.A2, c.A4
+	for i := 0; i < k; i++ {
+		size := n >> i
+		layer := t.layers[i]
+
+		// translate the kernel (t, 0) to (0, 0): y² = X³ + (3t + a₂)X² + (3t² + 2a₂t + a₄)X
+		iso := &t.isogenies[i]
+		var tmp fp.Element
+		iso.t = torsionX[k-1-i]
+		iso.a.Double(&iso.t).Add(&iso.a, &iso.t).Add(&iso.a, &a2)
+		tmp.Double(&a2).Add(&tmp, &iso.t).Add(&tmp, &iso.t).Add(&tmp, &iso.t).Mul(&tmp, &iso.t)
+		iso.b.Add(&tmp, &a4)
+
+		// X = x - t for the points of Lᵢ and the remaining points of order 2ᵐ
+		xs := make([]fp.Element, size+k-1-i)
+		for j := 0; j < size; j++ {
+			xs[j].Sub(&layer[j], &iso.t)
+		}
+		for m := 0; m < k-1-i; m++ {
+			xs[size+m].Sub(&torsionX[m], &iso.t)
+		}
+		inv := fp.BatchInvert(xs)
+
+		// ψ(x) = X + a + b/X
+		t.layers[i+1] = make([]fp.Element, size/2)
+		for j := 0; j < size/2; j++ {
+			iso.evalTranslated(&t.layers[i+1][j], &xs[j], &inv[j])
+		}
+		for m := 0; m < k-1-i; m++ {
+			iso.evalTranslated(&torsionX[m], &xs[size+m], &inv[size+m])
+		}
+
+		// Z_{Lᵢ}(x) = v(x)^{|Lᵢ|/2}·Z_{Lᵢ₊₁}(ψ(x)), v(x) = X, so that on Lᵢ
+		// Z'_{Lᵢ}(x) = X^{|Lᵢ|/2}·ψ'(x)·Z'_{Lᵢ₊₁}(ψ(x)), with ψ'(x) = 1 - b/X²
+		factors := make([]fp.Element, size)
+		for j := range factors {
+			var dpsi fp.Element
+			dpsi.Square(&inv[j]).Mul(&dpsi, &iso.b)
+			factors[j].SetOne().Sub(&factors[j], &dpsi)
+			tmp.ExpUint64(xs[j], uint64(size/2))
+			factors[j].Mul(&factors[j], &tmp)
+		}
+		for j := range derivatives {
+			derivatives[j].Mul(&derivatives[j], &factors[j%size])
+		}
+
+		// image curve y² = X(X² - 2aX + a² - 4b)
+		a2.Double(&iso.a).Neg(&a2)
+		tmp.Double(&iso.b).Double(&tmp)
+		a4.Square(&iso.a).Sub(&a4, &tmp)
+	}
+	t.weights = fp.BatchInvert(derivatives)
+
+	return t, nil
+}
+
+// evalTranslated sets z = ψ(x) = X + a + b/X, given X = x - t and inv = 1/X
+func (iso *isogeny) evalTranslated(z, X, inv *fp.Element) {
+	var res fp.Element
+	res.Mul(&iso.b, inv).Add(&res, &iso.a).Add(&res, X)
+	z.Set(&res)
+}
+
+// Size returns the size n of the domain
+func (t *Tree) Size() int {
+	return len(t.layers[0])
+}
+
+// Domain returns a copy of the domain L₀
+func (t *Tree) Domain() []fp.Element {
+	return append([]fp.Element(nil), t.layers[0]...)
+}
+
+// Extend takes the evaluations of a polynomial P of degree < n/2 on the even positions
+// of the domain, evens[j] = P(Domain()[2j]), and returns its evaluations on the odd
+// positions, odds[j] = P(Domain()[2j+1]).
+//
+// Together, they form the encoding of P in the Reed-Solomon code of rate 1/2 on the domain.
+func (t *Tree) Extend(evens []fp.Element) ([]fp.Element, error) {
+	if len(evens) != t.Size()/2 {
+		return nil, ErrInvalidSize
+	}
+	return t.extend(0, 1, 0, evens), nil
+}
+
+// Evaluate returns the evaluations of the polynomial of the given coefficients, of
+// degree < n, on the domain.
+func (t *Tree) Evaluate(coefficients []fp.Element) ([]fp.Element, error) {
+	if len(coefficients) > t.Size() {
+		return nil, ErrInvalidSize
+	}
+	padded := make([]fp.Element, t.Size())
+	copy(padded, coefficients)
+	return t.enter(1, 0, padded), nil
+}
+
+// EvaluateAt returns P(z), P being the polynomial of degree < n of the given evaluations
+// on the domain, using the barycentric formula
+//
+//	P(z) = Z(z)·∑ⱼ wⱼ·P(xⱼ)/(z - xⱼ), wⱼ = 1/Z'(xⱼ), Z = ∏ⱼ(X - xⱼ)
+func (t *Tree) EvaluateAt(evaluations []fp.Element, z fp.Element) (fp.Element, error) {
+	var res fp.Element
+	if len(evaluations) != t.Size() {
+		return res, ErrInvalidSize
+	}
+	domain := t.layers[0]
+	diffs := make([]fp.Element, len(domain))
+	for j := range domain {
+		diffs[j].Sub(&z, &domain[j])
+		if diffs[j].IsZero() {
+			return evaluations[j], nil
+		}
+	}
+	var vanishing, tmp fp.Element
+	vanishing.SetOne()
+	for j := range diffs {
+		vanishing.Mul(&vanishing, &diffs[j])
+	}
+	diffs = fp.BatchInvert(diffs)
+	for j := range diffs {
+		tmp.Mul(&t.weights[j], &evaluations[j]).Mul(&tmp, &diffs[j])
+		res.Add(&res, &tmp)
+	}
+	res.Mul(&res, &vanishing)
+	return res, nil
+}
+
+// extend runs EXTEND on the node of Lᵢ of positions c + s·j, j < m = 2·len(in):
+// in holds the evaluations of P, of degree < m/2, on the positions of even j,
+// the evaluations on the positions of odd j are returned.
+//
+// With v(x) = x - tᵢ, P is decomposed as P(x) = (P₀(ψᵢ(x)) + x·P₁(ψᵢ(x)))·v(x)^{m/4-1},
+// deg P₀, P₁ < m/4, and P₀, P₁ are extended on the image node of Lᵢ₊₁.
+func (t *Tree) extend(i, s, c int, in []fp.Element) []fp.Element {
+	h := len(in)
+	out := make([]fp.Element, h)
+	if h == 1 {
+		// P is constant
+		out[0] = in[0]
+		return out
+	}
+	q := h / 2
+	e := uint64(q - 1)
+	layer := t.layers[i]
+	iso := &t.isogenies[i]
+
+	// the even j and j + h have the same image by ψᵢ
+	buf := make([]fp.Element, 3*q)
+	for a := 0; a < q; a++ {
+		x0, x1 := &layer[c+s*2*a], &layer[c+s*(2*a+h)]
+		buf[a].Sub(x0, x1)
+		buf[q+a].Sub(x0, &iso.t)
+		buf[q+a].ExpUint64(buf[q+a], e)
+		buf[2*q+a].Sub(x1, &iso.t)
+		buf[2*q+a].ExpUint64(buf[2*q+a], e)
+	}
+	buf = fp.BatchInvert(buf)
+
+	// P(x)/v(x)^{m/4-1} = P₀(y) + x·P₁(y) for the two preimages x of y
+	p0 := make([]fp.Element, q)
+	p1 := make([]fp.Element, q)
+	for a := 0; a < q; a++ {
+		var v0, v1 fp.Element
+		v0.Mul(&in[a], &buf[q+a])
+		v1.Mul(&in[a+q], &buf[2*q+a])
+		p1[a].Sub(&v0, &v1).Mul(&p1[a], &buf[a])
+		p0[a].Mul(&p1[a], &layer[c+s*2*a])
+		p0[a].Sub(&v0, &p0[a])
+	}
+
+	r0 := t.extend(i+1, s, c, p0)
+	r1 := t.extend(i+1, s, c, p1)
+
+	for b := 0; b < h; b++ {
+		x := &layer[c+s*(2*b+1)]
+		var v fp.Element
+		v.Sub(x, &iso.t).ExpUint64(v, e)
+		out[b].Mul(x, &r1[b%q]).Add(&out[b], &r0[b%q]).Mul(&out[b], &v)
+	}
+	return out
+}
+
+// enter runs ENTER on the node of L₀ of positions c + s·j, j < len(coefficients):
+// P = P_lo + X^{m/2}·P_hi is evaluated on the even j recursively, then P_lo and P_hi
+// are extended to the odd j.
+func (t *Tree) enter(s, c int, coefficients []fp.Element) []fp.Element {
+	m := len(coefficients)
+	if m == 1 {
+		return []fp.Element{coefficients[0]}
+	}
+	h := m / 2
+	lo := t.enter(2*s, c, coefficients[:h])
+	hi := t.enter(2*s, c, coefficients[h:])
+	lo1 := t.extend(0, s, c, lo)
+	hi1 := t.extend(0, s, c, hi)
+
+	layer := t.layers[0]
+	out := make([]fp.Element, m)
+	for j := 0; j < m; j++ {
+		var xh fp.Element
+		xh.ExpUint64(layer[c+s*j], uint64(h))
+		if j%2 == 0 {
+			out[j].Mul(&xh, &hi[j/2]).Add(&out[j], &lo[j/2])
+		} else {
+			out[j].Mul(&xh, &hi1[j/2]).Add(&out[j], &lo1[j/2])
+		}
+	}
+	return out
+}
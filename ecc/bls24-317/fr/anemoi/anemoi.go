@@ -0,0 +1,208 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package anemoi
+
+import (
+	"hash"
+	"math/big"
+	"sync"
+
+	"github.com/consensys/gnark-crypto/ecc/bls24-317/fr"
+	"golang.org/x/crypto/sha3"
+)
+
+const (
+	NbRounds  = 20       // number of rounds of the permutation
+	Alpha     = 7        // exponent of the S-box
+	seed      = "anemoi" // seed to derive the constants
+	BlockSize = fr.Bytes // BlockSize size that the hash function consumes
+)
+
+// Params constants for the Anemoi permutation
+var (
+	c, d     [NbRounds]fr.Element // round constants
+	g, gInv  fr.Element           // multiplicative generator of fr and its inverse
+	alphaInv big.Int              // α⁻¹ mod r-1
+	once     sync.Once
+)
+
+// GetConstants exposed to be used in gnark
+func GetConstants() (cs, ds []big.Int) {
+	once.Do(initConstants) // init constants
+	cs = make([]big.Int, NbRounds)
+	ds = make([]big.Int, NbRounds)
+	for i := 0; i < NbRounds; i++ {
+		c[i].ToBigIntRegular(&cs[i])
+		d[i].ToBigIntRegular(&ds[i])
+	}
+	return
+}
+
+// Permutation applies the Anemoi permutation to the state (x, y)
+func Permutation(x, y *fr.Element) {
+	once.Do(initConstants) // init constants
+
+	for i := 0; i < NbRounds; i++ {
+		x.Add(x, &c[i])
+		y.Add(y, &d[i])
+		linearLayer(x, y)
+		sBox(x, y)
+	}
+	linearLayer(x, y)
+}
+
+// Jive is the 2-to-1 compression function of Anemoi:
+//
+//	Jive(x, y) = x + y + u + v, where (u, v) = Permutation(x, y)
+func Jive(x, y fr.Element) fr.Element {
+	u, v := x, y
+	Permutation(&u, &v)
+	var res fr.Element
+	res.Add(&x, &y).Add(&res, &u).Add(&res, &v)
+	return res
+}
+
+// linearLayer applies the linear layer, which with a single column
+// is the pseudo-Hadamard transform (x, y) -> (2x + y, x + y)
+func linearLayer(x, y *fr.Element) {
+	y.Add(y, x)
+	x.Add(x, y)
+}
+
+// sBox applies the open Flystel, with Q_γ(y) = g⋅y² and Q_δ(y) = g⋅y² + g⁻¹
+//
+//	x ← x - Q_γ(y)
+//	y ← y - x^{1/α}
+//	x ← x + Q_δ(y)
+func sBox(x, y *fr.Element) {
+	var t fr.Element
+	t.Square(y).Mul(&t, &g)
+	x.Sub(x, &t)
+
+	t.Exp(*x, &alphaInv)
+	y.Sub(y, &t)
+
+	t.Square(y).Mul(&t, &g).Add(&t, &gInv)
+	x.Add(x, &t)
+}
+
+// digest represents the partial evaluation of the checksum
+type digest struct {
+	h      fr.Element
+	data   []byte // data to hash
+	length uint64 // number of bytes written since the last Sum or Reset
+}
+
+// NewAnemoi returns a hash function chaining Jive over the blocks of data:
+// hᵢ₊₁ = Jive(hᵢ, mᵢ), starting from h₀ = 0. The last block is left-padded with
+// zeroes, and the number of bytes written is absorbed as a final block.
+func NewAnemoi() hash.Hash {
+	d := new(digest)
+	d.Reset()
+	return d
+}
+
+// Reset resets the Hash to its initial state.
+func (d *digest) Reset() {
+	d.data = nil
+	d.length = 0
+	d.h.SetZero()
+}
+
+// Sum appends the current hash to b and returns the resulting slice.
+// It does not change the underlying hash state.
+func (d *digest) Sum(b []byte) []byte {
+	h := d.checksum()
+	bytes := h.Bytes()
+	return append(b, bytes[:]...)
+}
+
+// Size returns the number of bytes Sum will return.
+func (d *digest) Size() int {
+	return BlockSize
+}
+
+// BlockSize returns the hash's underlying block size.
+// The Write method must be able to accept any amount
+// of data, but it may operate more efficiently if all writes
+// are a multiple of the block size.
+func (d *digest) BlockSize() int {
+	return BlockSize
+}
+
+// Write (via the embedded io.Writer interface) adds more data to the running hash.
+// Complete blocks are absorbed right away, as big endian integers reduced modulo r.
+// It never returns an error.
+func (d *digest) Write(p []byte) (n int, err error) {
+	n = len(p)
+	d.length += uint64(n)
+	d.data = append(d.data, p...)
+
+	var x fr.Element
+	i := 0
+	for ; i+BlockSize <= len(d.data); i += BlockSize {
+		x.SetBytes(d.data[i : i+BlockSize])
+		d.h = Jive(d.h, x)
+	}
+	d.data = append(d.data[:0], d.data[i:]...)
+	return
+}
+
+// checksum absorbs the pending data and the length, without modifying d
+func (d *digest) checksum() fr.Element {
+	h := d.h
+	var x fr.Element
+	if len(d.data) != 0 {
+		x.SetBytes(d.data)
+		h = Jive(h, x)
+	}
+	x.SetUint64(d.length)
+	return Jive(h, x)
+}
+
+// Sum computes the Anemoi hash of msg
+func Sum(msg []byte) []byte {
+	d := NewAnemoi()
+	_, _ = d.Write(msg)
+	return d.Sum(nil)
+}
+
+func initConstants() {
+	alphaInv.SetString("17639765277975339545450394147158801476911272336735320870580116816550099119543", 10)
+	g = fr.MultiplicativeGenerator()
+	gInv.Inverse(&g)
+
+	bseed := ([]byte)(seed)
+
+	hash := sha3.NewLegacyKeccak256()
+	_, _ = hash.Write(bseed)
+	rnd := hash.Sum(nil) // pre hash before use
+	hash.Reset()
+	_, _ = hash.Write(rnd)
+
+	for i := 0; i < NbRounds; i++ {
+		rnd = hash.Sum(nil)
+		c[i].SetBytes(rnd)
+		hash.Reset()
+		_, _ = hash.Write(rnd)
+
+		rnd = hash.Sum(nil)
+		d[i].SetBytes(rnd)
+		hash.Reset()
+		_, _ = hash.Write(rnd)
+	}
+}
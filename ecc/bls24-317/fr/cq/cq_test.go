@@ -0,0 +1,237 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package cq
+
+import (
+	"bytes"
+	"io"
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls24-317"
+	"github.com/consensys/gnark-crypto/ecc/bls24-317/fr"
+	"github.com/consensys/gnark-crypto/ecc/bls24-317/fr/fft"
+)
+
+func TestSetup(t *testing.T) {
+
+	srs, err := NewSRS(16, big.NewInt(13))
+	if err != nil {
+		t.Fatal(err)
+	}
+	table := make([]fr.Element, 16)
+	for i := 0; i < 16; i++ {
+		table[i].SetRandom()
+	}
+	pk, err := Setup(srs, table)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// compare with the naive computation, using the secret
+	var alpha, one fr.Element
+	alpha.SetUint64(13)
+	one.SetOne()
+	d := fft.NewDomain(16)
+	ct := make([]fr.Element, 16)
+	copy(ct, table)
+	d.FFTInverse(ct, fft.DIF)
+	fft.BitReverse(ct)
+
+	// T(α)
+	var tAlpha fr.Element
+	for i := len(ct) - 1; i >= 0; i-- {
+		tAlpha.Mul(&tAlpha, &alpha).Add(&tAlpha, &ct[i])
+	}
+
+	// αᴺ-1
+	var zAlpha fr.Element
+	zAlpha.Exp(alpha, big.NewInt(16)).Sub(&zAlpha, &one)
+
+	var omegaI fr.Element
+	omegaI.SetOne()
+	_, _, g1, _ := bls24317.Generators()
+	for i := 0; i < 16; i++ {
+
+		// Lᵢ(α) = ωⁱ(αᴺ-1)/(N(α-ωⁱ))
+		var l, den, q fr.Element
+		den.Sub(&alpha, &omegaI).Inverse(&den)
+		l.Mul(&zAlpha, &omegaI).Mul(&l, &den).Mul(&l, &d.CardinalityInv)
+
+		// Qᵢ(α) = Lᵢ(α)(T(α)-tᵢ)/(αᴺ-1)
+		q.Sub(&tAlpha, &table[i]).Mul(&q, &l).Div(&q, &zAlpha)
+
+		var expected bls24317.G1Affine
+		var b big.Int
+		expected.ScalarMultiplication(&g1, l.ToBigIntRegular(&b))
+		if !expected.Equal(&pk.Lagrange[i]) {
+			t.Fatal("wrong Lagrange commitment")
+		}
+		expected.ScalarMultiplication(&g1, q.ToBigIntRegular(&b))
+		if !expected.Equal(&pk.Quotients[i]) {
+			t.Fatal("wrong cached quotient")
+		}
+
+		omegaI.Mul(&omegaI, &d.Generator)
+	}
+}
+
+func TestProof(t *testing.T) {
+
+	srs, err := NewSRS(32, big.NewInt(13))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	table := make([]fr.Element, 32)
+	for i := 0; i < 32; i++ {
+		table[i].SetUint64(uint64(5*i + 1))
+	}
+	pk, err := Setup(srs, table)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// f is smaller than the table, some values are looked up several times
+	f := make([]fr.Element, 7)
+	for i := 0; i < 7; i++ {
+		f[i].Set(&table[(3*i)%10])
+	}
+
+	// correct proof
+	{
+		proof, err := Prove(srs, pk, f)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		err = Verify(srs, &pk.Vk, proof)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// value not in the table
+	{
+		f[0].SetUint64(2)
+		_, err := Prove(srs, pk, f)
+		if err != ErrNotInTable {
+			t.Fatal("expected ErrNotInTable")
+		}
+		f[0].Set(&table[0])
+	}
+
+	// wrong proof
+	{
+		proof, err := Prove(srs, pk, f)
+		if err != nil {
+			t.Fatal(err)
+		}
+		proof.bZero.SetRandom()
+
+		err = Verify(srs, &pk.Vk, proof)
+		if err == nil {
+			t.Fatal("verifying wrong proof should have failed")
+		}
+	}
+}
+
+func TestSerialization(t *testing.T) {
+
+	srs, err := NewSRS(16, big.NewInt(13))
+	if err != nil {
+		t.Fatal(err)
+	}
+	table := make([]fr.Element, 16)
+	for i := 0; i < 16; i++ {
+		table[i].SetUint64(uint64(i))
+	}
+	pk, err := Setup(srs, table)
+	if err != nil {
+		t.Fatal(err)
+	}
+	proof, err := Prove(srs, pk, table[3:7])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var reconstructedSrs SRS
+	roundTrip(t, srs, &reconstructedSrs)
+	var reconstructedPk ProvingKey
+	roundTrip(t, pk, &reconstructedPk)
+	var reconstructedProof Proof
+	roundTrip(t, &proof, &reconstructedProof)
+
+	var buf bytes.Buffer
+	written, err := proof.WriteTo(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if written != int64(proof.SizeInBytes()) {
+		t.Fatal("SizeInBytes doesn't match the number of bytes written")
+	}
+
+	err = Verify(&reconstructedSrs, &reconstructedPk.Vk, reconstructedProof)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func roundTrip(t *testing.T, from interface {
+	WriteTo(w io.Writer) (int64, error)
+}, to interface {
+	ReadFrom(r io.Reader) (int64, error)
+}) {
+	var buf bytes.Buffer
+	written, err := from.WriteTo(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	read, err := to.ReadFrom(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if written != read {
+		t.Fatal("didn't read as many bytes as we wrote")
+	}
+	if !reflect.DeepEqual(from, to) {
+		t.Fatal("ReadFrom(WriteTo()) failed")
+	}
+}
+
+func BenchmarkProver(b *testing.B) {
+
+	const tableSize = 1 << 10
+	const vectorSize = 1 << 6
+
+	srs, _ := NewSRS(tableSize, big.NewInt(13))
+	table := make([]fr.Element, tableSize)
+	for i := 0; i < tableSize; i++ {
+		table[i].SetUint64(uint64(i))
+	}
+	pk, _ := Setup(srs, table)
+	f := make([]fr.Element, vectorSize)
+	for i := 0; i < vectorSize; i++ {
+		f[i].SetUint64(uint64((8 * i) % tableSize))
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		Prove(srs, pk, f)
+	}
+}
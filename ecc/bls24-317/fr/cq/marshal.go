@@ -0,0 +1,140 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package cq
+
+import (
+	"io"
+
+	"github.com/consensys/gnark-crypto/ecc/bls24-317"
+	"github.com/consensys/gnark-crypto/ecc/bls24-317/fr"
+)
+
+// WriteTo writes binary encoding of the SRS
+func (srs *SRS) WriteTo(w io.Writer) (int64, error) {
+	return encode(w, srs.G1, srs.G2)
+}
+
+// ReadFrom decodes SRS data from reader.
+func (srs *SRS) ReadFrom(r io.Reader) (int64, error) {
+	return decode(r, &srs.G1, &srs.G2)
+}
+
+// WriteTo writes binary encoding of a VerifyingKey
+func (vk *VerifyingKey) WriteTo(w io.Writer) (int64, error) {
+	return encode(w, vk.N, &vk.T, &vk.ZV)
+}
+
+// ReadFrom decodes VerifyingKey data from reader.
+func (vk *VerifyingKey) ReadFrom(r io.Reader) (int64, error) {
+	return decode(r, &vk.N, &vk.T, &vk.ZV)
+}
+
+// WriteTo writes binary encoding of a ProvingKey
+func (pk *ProvingKey) WriteTo(w io.Writer) (int64, error) {
+	return encode(w,
+		pk.Vk.N,
+		&pk.Vk.T,
+		&pk.Vk.ZV,
+		pk.Table,
+		&pk.Generator,
+		pk.Lagrange,
+		pk.Quotients,
+	)
+}
+
+// ReadFrom decodes ProvingKey data from reader.
+func (pk *ProvingKey) ReadFrom(r io.Reader) (int64, error) {
+	return decode(r,
+		&pk.Vk.N,
+		&pk.Vk.T,
+		&pk.Vk.ZV,
+		&pk.Table,
+		&pk.Generator,
+		&pk.Lagrange,
+		&pk.Quotients,
+	)
+}
+
+// WriteTo writes binary encoding of a Proof
+func (proof *Proof) WriteTo(w io.Writer) (int64, error) {
+	return encode(w,
+		proof.size,
+		&proof.g,
+		&proof.f,
+		&proof.m,
+		&proof.a,
+		&proof.qa,
+		&proof.a0,
+		&proof.b,
+		&proof.b0,
+		&proof.p,
+		&proof.qb,
+		&proof.bZero,
+		&proof.batchedProof.H,
+		proof.batchedProof.ClaimedValues,
+	)
+}
+
+// ReadFrom decodes Proof data from reader.
+func (proof *Proof) ReadFrom(r io.Reader) (int64, error) {
+	return decode(r,
+		&proof.size,
+		&proof.g,
+		&proof.f,
+		&proof.m,
+		&proof.a,
+		&proof.qa,
+		&proof.a0,
+		&proof.b,
+		&proof.b0,
+		&proof.p,
+		&proof.qb,
+		&proof.bZero,
+		&proof.batchedProof.H,
+		&proof.batchedProof.ClaimedValues,
+	)
+}
+
+// SizeInBytes returns the number of bytes written by WriteTo
+func (proof *Proof) SizeInBytes() int {
+	return 8 + 2*fr.Bytes + 9*bls24317.SizeOfG1AffineCompressed +
+		proof.batchedProof.SizeInBytes()
+}
+
+func encode(w io.Writer, toEncode ...interface{}) (int64, error) {
+	enc := bls24317.NewEncoder(w)
+
+	for _, v := range toEncode {
+		if err := enc.Encode(v); err != nil {
+			return enc.BytesWritten(), err
+		}
+	}
+
+	return enc.BytesWritten(), nil
+}
+
+func decode(r io.Reader, toDecode ...interface{}) (int64, error) {
+	dec := bls24317.NewDecoder(r)
+
+	for _, v := range toDecode {
+		if err := dec.Decode(v); err != nil {
+			return dec.BytesRead(), err
+		}
+	}
+
+	return dec.BytesRead(), nil
+}
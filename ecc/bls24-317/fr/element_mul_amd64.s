@@ -1,4 +1,5 @@
-// +build !amd64_adx
+//go:build !amd64_adx && !purego
+// +build !amd64_adx,!purego
 
 // Copyright 2020 ConsenSys Software Inc.
 //
@@ -0,0 +1,219 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package fft
+
+import (
+	"errors"
+	"math/big"
+	"math/bits"
+
+	"github.com/consensys/gnark-crypto/ecc/bls24-317/fr"
+
+	curve "github.com/consensys/gnark-crypto/ecc/bls24-317"
+
+	"github.com/consensys/gnark-crypto/internal/parallel"
+)
+
+var (
+	ErrCirculantSize = errors.New("the column of the circulant matrix and the vector should be of the same size, a power of 2")
+	ErrToeplitzSize  = errors.New("the column and the row of the Toeplitz matrix and the vector should be of the same size")
+)
+
+// CirculantMul returns C⋅v, where C is the circulant matrix whose first column is c:
+// C[i][j] = c[(i-j) mod n]. c and v must be of the same size n, a power of 2.
+//
+// It costs 3 FFTs of size n: C⋅v = FFT⁻¹(FFT(c)*FFT(v)).
+func CirculantMul(c, v []fr.Element) ([]fr.Element, error) {
+	n := len(c)
+	if n == 0 || len(v) != n || n&(n-1) != 0 {
+		return nil, ErrCirculantSize
+	}
+	domain := NewDomain(uint64(n))
+	fc := make([]fr.Element, n)
+	res := make([]fr.Element, n)
+	copy(fc, c)
+	copy(res, v)
+	domain.FFT(fc, DIF)
+	domain.FFT(res, DIF)
+	parallel.Execute(n, func(start, end int) {
+		for i := start; i < end; i++ {
+			res[i].Mul(&res[i], &fc[i])
+		}
+	})
+	domain.FFTInverse(res, DIT)
+	return res, nil
+}
+
+// ToeplitzMul returns T⋅v, where T is the n×n Toeplitz matrix with first column column and
+// first row row: T[i][j] = column[i-j] if i ≥ j, row[j-i] otherwise. row[0] is not used (the
+// diagonal is column[0]). column, row and v must be of the same size n, which needs not be a
+// power of 2.
+//
+// T is embedded in a circulant matrix of size 2n (rounded up to a power of 2), so the product
+// costs 3 FFTs of that size instead of n² multiplications.
+func ToeplitzMul(column, row, v []fr.Element) ([]fr.Element, error) {
+	n := len(column)
+	if n == 0 || len(row) != n || len(v) != n {
+		return nil, ErrToeplitzSize
+	}
+	c := circulantEmbedding(column, row)
+	vv := make([]fr.Element, len(c))
+	copy(vv, v)
+	res, err := CirculantMul(c, vv)
+	if err != nil {
+		return nil, err
+	}
+	return res[:n], nil
+}
+
+// ToeplitzMulG1 returns T⋅points, where T is the n×n Toeplitz matrix with first column column
+// and first row row (see ToeplitzMul): the i-th result is ∑ⱼT[i][j]points[j]. column, row and
+// points must be of the same size n.
+//
+// It is the core of the Feist-Khovratovich computation of all the KZG opening proofs of a
+// polynomial (amortized openings, FK20 multiproofs for data availability sampling): it costs
+// O(n log n) scalar multiplications in G₁ instead of the O(n²) of the matrix product.
+func ToeplitzMulG1(column, row []fr.Element, points []curve.G1Affine) ([]curve.G1Jac, error) {
+	n := len(column)
+	if n == 0 || len(row) != n || len(points) != n {
+		return nil, ErrToeplitzSize
+	}
+
+	// FFT of the first column of the circulant matrix, scaled by 1/N for the inverse FFT
+	c := circulantEmbedding(column, row)
+	domain := NewDomain(uint64(len(c)))
+	domain.FFT(c, DIF)
+	BitReverse(c)
+	parallel.Execute(len(c), func(start, end int) {
+		for i := start; i < end; i++ {
+			c[i].Mul(&c[i], &domain.CardinalityInv)
+		}
+	})
+
+	// points padded with zeroes
+	res := make([]curve.G1Jac, len(c))
+	for i := 0; i < n; i++ {
+		res[i].FromAffine(&points[i])
+	}
+	var infinity curve.G1Affine
+	for i := n; i < len(c); i++ {
+		res[i].FromAffine(&infinity)
+	}
+
+	// FFT⁻¹(FFT(c)*FFT(points))
+	FFTG1(res, domain.Generator)
+	ScaleG1(res, c)
+	FFTG1(res, domain.GeneratorInv)
+
+	return res[:n], nil
+}
+
+// FeistKhovratovichG1 returns h, where hₘ = ∑ⱼc_{m+j+1}points[j] (with cₖ = 0 for k ≥ len(c)).
+// c and points must be of the same size n.
+//
+// With points = [αʲ]G₁, hₘ is the commitment to the m-th coefficient of the quotients
+// (c-c(x))/(X-x), so that the commitment to the quotient at ωⁱ is the evaluation at ωⁱ of
+// ∑ₘhₘXᵐ, computed with FFTG1: this is the computation of all the KZG opening proofs of
+// Feist-Khovratovich. With the points in reverse order, h is the product of the upper
+// triangular Toeplitz matrix T[m][k] = c_{n+m-k}, computed by ToeplitzMulG1.
+func FeistKhovratovichG1(c []fr.Element, points []curve.G1Affine) ([]curve.G1Jac, error) {
+	n := len(c)
+	if n == 0 || len(points) != n {
+		return nil, ErrToeplitzSize
+	}
+	column := make([]fr.Element, n)
+	row := make([]fr.Element, n)
+	for j := 1; j < n; j++ {
+		row[j] = c[n-j]
+	}
+	reversed := make([]curve.G1Affine, n)
+	for i := 0; i < n; i++ {
+		reversed[i] = points[n-1-i]
+	}
+	return ToeplitzMulG1(column, row, reversed)
+}
+
+// ScaleG1 sets points[i] to [scalars[i]]points[i]
+func ScaleG1(points []curve.G1Jac, scalars []fr.Element) {
+	parallel.Execute(len(points), func(start, end int) {
+		var s big.Int
+		for i := start; i < end; i++ {
+			scalars[i].ToBigIntRegular(&s)
+			points[i].ScalarMultiplication(&points[i], &s)
+		}
+	})
+}
+
+// circulantEmbedding returns the first column of a circulant matrix of size N, a power of 2
+// larger than 2n-1, whose top left n×n block is the Toeplitz matrix of first column column
+// and first row row.
+func circulantEmbedding(column, row []fr.Element) []fr.Element {
+	n := len(column)
+	N := 1
+	for N < 2*n-1 {
+		N <<= 1
+	}
+	c := make([]fr.Element, N)
+	copy(c, column)
+	for j := 1; j < n; j++ {
+		c[N-j] = row[j]
+	}
+	return c
+}
+
+// FFTG1 computes in place the discrete Fourier transform of a, in G₁,
+// where omega is a root of unity of order len(a), a power of 2: a[i] ← ∑ⱼωⁱʲa[j].
+// Inputs and outputs are in natural order.
+//
+// Each butterfly costs a scalar multiplication in G₁; with omega the inverse of the
+// generator of a domain, and the result scaled by 1/len(a), it is the inverse FFT.
+func FFTG1(a []curve.G1Jac, omega fr.Element) {
+
+	n := len(a)
+	nn := uint64(64 - bits.TrailingZeros64(uint64(n)))
+	for i := 0; i < n; i++ {
+		irev := int(bits.Reverse64(uint64(i)) >> nn)
+		if irev > i {
+			a[i], a[irev] = a[irev], a[i]
+		}
+	}
+
+	for m := 2; m <= n; m <<= 1 {
+
+		// twiddle factors for this stage, wm is of order m
+		half := m / 2
+		var wm, w fr.Element
+		wm.Exp(omega, big.NewInt(int64(n/m)))
+		w.SetOne()
+		twiddles := make([]big.Int, half)
+		for k := 0; k < half; k++ {
+			w.ToBigIntRegular(&twiddles[k])
+			w.Mul(&w, &wm)
+		}
+
+		parallel.Execute(n/2, func(start, end int) {
+			var t curve.G1Jac
+			for idx := start; idx < end; idx++ {
+				i := (idx/half)*m + idx%half
+				j := i + half
+				t.ScalarMultiplication(&a[j], &twiddles[idx%half])
+				a[j].Set(&a[i]).SubAssign(&t)
+				a[i].AddAssign(&t)
+			}
+		})
+	}
+}
@@ -0,0 +1,26 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+// Package pcs defines a polynomial commitment scheme interface, so that protocols
+// can be written once for several backends:
+//   - KZG, with a trusted setup, constant size proofs and batched pairing verification
+//   - IPA, the inner product argument of Bulletproofs, with a transparent setup,
+//     logarithmic proofs and linear verification
+//   - FRI, hash-based and transparent, where openings at arbitrary points are reduced
+//     to a proof of proximity of the quotient (p-p(z))/(X-z)
+//
+// Polynomials are given by their coefficients in canonical basis, in Montgomery form.
+package pcs
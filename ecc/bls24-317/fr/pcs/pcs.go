@@ -0,0 +1,74 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package pcs
+
+import (
+	"errors"
+
+	"github.com/consensys/gnark-crypto/ecc/bls24-317/fr"
+)
+
+var (
+	ErrBackendMismatch       = errors.New("the digest or the proof was not produced by this backend")
+	ErrInvalidPolynomialSize = errors.New("invalid polynomial size (larger than the setup or == 0)")
+	ErrVerifyOpeningProof    = errors.New("can't verify opening proof")
+	ErrInvalidNbDigests      = errors.New("number of digests is not the same as the number of proofs or points")
+)
+
+// Digest is a commitment to a polynomial
+type Digest interface {
+	Marshal() []byte
+}
+
+// OpeningProof is a proof that a committed polynomial evaluates to ClaimedValue at a point
+type OpeningProof interface {
+	ClaimedValue() fr.Element
+}
+
+// PolynomialCommitment is a polynomial commitment scheme. The digests and proofs passed to
+// a backend must have been produced by the same backend, otherwise ErrBackendMismatch is returned.
+type PolynomialCommitment interface {
+
+	// Commit commits to the polynomial p
+	Commit(p []fr.Element) (Digest, error)
+
+	// Open returns a proof of the evaluation of p at point
+	Open(p []fr.Element, point fr.Element) (OpeningProof, error)
+
+	// Verify checks that the polynomial committed in digest evaluates to
+	// proof.ClaimedValue() at point
+	Verify(digest Digest, proof OpeningProof, point fr.Element) error
+
+	// BatchVerify verifies the openings of several polynomials, each at its own point
+	BatchVerify(digests []Digest, proofs []OpeningProof, points []fr.Element) error
+}
+
+// evaluate returns p(point), p being in canonical basis
+func evaluate(p []fr.Element, point fr.Element) fr.Element {
+	var res fr.Element
+	for i := len(p) - 1; i >= 0; i-- {
+		res.Mul(&res, &point).Add(&res, &p[i])
+	}
+	return res
+}
+
+func checkBatchSizes(digests []Digest, proofs []OpeningProof, points []fr.Element) error {
+	if len(digests) != len(proofs) || len(digests) != len(points) {
+		return ErrInvalidNbDigests
+	}
+	return nil
+}
@@ -19,9 +19,12 @@ package permutation
 import (
 	"crypto/sha256"
 	"errors"
+	"fmt"
+	"hash"
 	"math/big"
 	"math/bits"
 
+	"github.com/consensys/gnark-crypto/ecc"
 	"github.com/consensys/gnark-crypto/ecc/bls24-317"
 	"github.com/consensys/gnark-crypto/ecc/bls24-317/fr"
 	"github.com/consensys/gnark-crypto/ecc/bls24-317/fr/fft"
@@ -32,7 +35,7 @@ import (
 var (
 	ErrIncompatibleSize = errors.New("t1 and t2 should be of the same size")
 	ErrSize             = errors.New("t1 and t2 should be of size a power of 2")
-	ErrPermutationProof = errors.New("permutation proof verification failed")
+	ErrPermutationProof = fmt.Errorf("permutation proof verification failed: %w", ecc.ErrTranscriptMismatch)
 	ErrGenerator        = errors.New("wrong generator")
 )
 
@@ -60,6 +63,37 @@ type Proof struct {
 	shiftedProof kzg.OpeningProof
 }
 
+// Size returns the size of the polynomials of the proof
+func (proof *Proof) Size() int {
+	return proof.size
+}
+
+// Generator returns the generator of the fft domain of the proof
+func (proof *Proof) Generator() fr.Element {
+	return proof.g
+}
+
+// Commitments returns the commitments of t1, t2, z and q (in that order)
+func (proof *Proof) Commitments() []kzg.Digest {
+	return []kzg.Digest{proof.t1, proof.t2, proof.z, proof.q}
+}
+
+// BatchedProof returns the opening proofs of t1, t2, z, q
+func (proof *Proof) BatchedProof() kzg.BatchOpeningProof {
+	return proof.batchedProof
+}
+
+// ShiftedProof returns the shifted opening proof of z
+func (proof *Proof) ShiftedProof() kzg.OpeningProof {
+	return proof.shiftedProof
+}
+
+// SizeInBytes returns the size of the proof, with its points in compressed form
+func (proof *Proof) SizeInBytes() int {
+	return 8 + fr.Bytes + 4*bls24317.SizeOfG1AffineCompressed +
+		proof.batchedProof.SizeInBytes() + proof.shiftedProof.SizeInBytes()
+}
+
 // evaluateAccumulationPolynomialBitReversed returns the accumulation polynomial in Lagrange basis.
 func evaluateAccumulationPolynomialBitReversed(lt1, lt2 []fr.Element, epsilon fr.Element) []fr.Element {
 
@@ -278,26 +312,109 @@ func Verify(srs *kzg.SRS, proof Proof) error {
 	// hash function that is used for Fiat Shamir
 	hFunc := sha256.New()
 
+	eta, err := verifyRelation(hFunc, &proof)
+	if err != nil {
+		return err
+	}
+
+	// check the opening proofs
+	err = kzg.BatchVerifySinglePoint(
+		[]kzg.Digest{
+			proof.t1,
+			proof.t2,
+			proof.z,
+			proof.q,
+		},
+		&proof.batchedProof,
+		eta,
+		hFunc,
+		srs,
+	)
+	if err != nil {
+		return err
+	}
+
+	var shiftedEta fr.Element
+	shiftedEta.Mul(&eta, &proof.g)
+	return kzg.Verify(&proof.z, &proof.shiftedProof, shiftedEta, srs)
+}
+
+// BatchVerify verifies a list of permutation proofs, typically the shuffles of several
+// vectors. It is equivalent to calling Verify on each proof, but the opening proofs
+// of all the proofs are checked with a single pairing check.
+//
+// When it fails, the error does not tell which proof is invalid.
+func BatchVerify(srs *kzg.SRS, proofs []Proof) error {
+
+	hFunc := sha256.New()
+
+	digests := make([]kzg.Digest, 0, 2*len(proofs))
+	openingProofs := make([]kzg.OpeningProof, 0, 2*len(proofs))
+	points := make([]fr.Element, 0, 2*len(proofs))
+	for i := range proofs {
+		hFunc.Reset()
+		eta, err := verifyRelation(hFunc, &proofs[i])
+		if err != nil {
+			return err
+		}
+
+		// fold the batched opening at eta, and add the shifted opening of z
+		foldedProof, foldedDigest, err := kzg.FoldProof(
+			[]kzg.Digest{
+				proofs[i].t1,
+				proofs[i].t2,
+				proofs[i].z,
+				proofs[i].q,
+			},
+			&proofs[i].batchedProof,
+			eta,
+			hFunc,
+		)
+		if err != nil {
+			return err
+		}
+		var shiftedEta fr.Element
+		shiftedEta.Mul(&eta, &proofs[i].g)
+		digests = append(digests, foldedDigest, proofs[i].z)
+		openingProofs = append(openingProofs, foldedProof, proofs[i].shiftedProof)
+		points = append(points, eta, shiftedEta)
+	}
+	if len(digests) == 0 {
+		return nil
+	}
+
+	return kzg.BatchVerifyMultiPoints(digests, openingProofs, points, srs)
+}
+
+// verifyRelation derives the challenges of the proof, checks the relation between the
+// claimed values and the generator of the domain, and returns the evaluation point eta.
+func verifyRelation(hFunc hash.Hash, proof *Proof) (fr.Element, error) {
+
+	var eta fr.Element
+
 	// transcript to derive the challenge
 	fs := fiatshamir.NewTranscript(hFunc, "epsilon", "omega", "eta")
 
 	// derive the challenges
 	epsilon, err := deriveRandomness(&fs, "epsilon", &proof.t1, &proof.t2)
 	if err != nil {
-		return err
+		return eta, err
 	}
 
 	omega, err := deriveRandomness(&fs, "omega", &proof.z)
 	if err != nil {
-		return err
+		return eta, err
 	}
 
-	eta, err := deriveRandomness(&fs, "eta", &proof.q)
+	eta, err = deriveRandomness(&fs, "eta", &proof.q)
 	if err != nil {
-		return err
+		return eta, err
 	}
 
 	// check the relation
+	if len(proof.batchedProof.ClaimedValues) != 4 {
+		return eta, ErrPermutationProof
+	}
 	bs := big.NewInt(int64(proof.size))
 	var l0, a, b, one, rhs, lhs fr.Element
 	one.SetOne()
@@ -316,45 +433,21 @@ func Verify(srs *kzg.SRS, proof Proof) error {
 		Mul(&a, &omega)
 	lhs.Add(&a, &lhs)
 	if !lhs.Equal(&rhs) {
-		return ErrPermutationProof
-	}
-
-	// check the opening proofs
-	err = kzg.BatchVerifySinglePoint(
-		[]kzg.Digest{
-			proof.t1,
-			proof.t2,
-			proof.z,
-			proof.q,
-		},
-		&proof.batchedProof,
-		eta,
-		hFunc,
-		srs,
-	)
-	if err != nil {
-		return err
-	}
-
-	var shiftedEta fr.Element
-	shiftedEta.Mul(&eta, &proof.g)
-	err = kzg.Verify(&proof.z, &proof.shiftedProof, shiftedEta, srs)
-	if err != nil {
-		return err
+		return eta, ErrPermutationProof
 	}
 
 	// check the generator is correct
 	var checkOrder fr.Element
 	checkOrder.Exp(proof.g, big.NewInt(int64(proof.size/2)))
 	if checkOrder.Equal(&one) {
-		return ErrGenerator
+		return eta, ErrGenerator
 	}
 	checkOrder.Square(&checkOrder)
 	if !checkOrder.Equal(&one) {
-		return ErrGenerator
+		return eta, ErrGenerator
 	}
 
-	return nil
+	return eta, nil
 }
 
 // TODO put that in fiat-shamir package
@@ -17,9 +17,12 @@
 package permutation
 
 import (
+	"errors"
 	"math/big"
 	"testing"
 
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bls24-317"
 	"github.com/consensys/gnark-crypto/ecc/bls24-317/fr"
 	"github.com/consensys/gnark-crypto/ecc/bls24-317/fr/kzg"
 )
@@ -52,6 +55,16 @@ func TestProof(t *testing.T) {
 		if err != nil {
 			t.Fatal(err)
 		}
+
+		if proof.Size() != 8 || len(proof.Commitments()) != 4 {
+			t.Fatal("unexpected proof components")
+		}
+		batchedProof, shiftedProof := proof.BatchedProof(), proof.ShiftedProof()
+		expectedSize := 8 + fr.Bytes + 4*bls24317.SizeOfG1AffineCompressed +
+			batchedProof.SizeInBytes() + shiftedProof.SizeInBytes()
+		if proof.SizeInBytes() != expectedSize {
+			t.Fatal("unexpected proof size")
+		}
 	}
 
 	// wrong proof
@@ -63,11 +76,53 @@ func TestProof(t *testing.T) {
 		}
 
 		err = Verify(srs, proof)
-		if err == nil {
+		if !errors.Is(err, ecc.ErrTranscriptMismatch) {
+			t.Fatalf("expected ecc.ErrTranscriptMismatch, got %v", err)
+		}
+	}
+
+}
+
+func TestBatchVerify(t *testing.T) {
+
+	srs, err := kzg.NewSRS(64, big.NewInt(13))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// shuffles of vectors of different sizes
+	proofs := make([]Proof, 3)
+	for i, size := range []int{8, 16, 4} {
+		a := make([]fr.Element, size)
+		b := make([]fr.Element, size)
+		for j := 0; j < size; j++ {
+			a[j].SetRandom()
+		}
+		for j := 0; j < size; j++ {
+			b[j].Set(&a[(3*j)%size])
+		}
+		proofs[i], err = Prove(srs, a, b)
+		if err != nil {
 			t.Fatal(err)
 		}
 	}
 
+	if err = BatchVerify(srs, proofs); err != nil {
+		t.Fatal(err)
+	}
+	if err = BatchVerify(srs, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	// the relation holds, but not the shifted opening of z
+	proofs[1].shiftedProof.H.Add(&proofs[1].shiftedProof.H, &srs.G1[0])
+	if err = BatchVerify(srs, proofs); err != kzg.ErrVerifyOpeningProof {
+		t.Fatalf("expected %v, got %v", kzg.ErrVerifyOpeningProof, err)
+	}
+	if err = Verify(srs, proofs[1]); err != kzg.ErrVerifyOpeningProof {
+		t.Fatalf("expected %v, got %v", kzg.ErrVerifyOpeningProof, err)
+	}
+
 }
 
 func BenchmarkProver(b *testing.B) {
@@ -17,9 +17,13 @@
 package plookup
 
 import (
+	"errors"
 	"math/big"
 	"testing"
 
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bls24-317"
+	"github.com/consensys/gnark-crypto/ecc/bls24-317/fr"
 	"github.com/consensys/gnark-crypto/ecc/bls24-317/fr/kzg"
 )
 
@@ -62,13 +66,117 @@ func TestLookupVector(t *testing.T) {
 		}
 
 		err = VerifyLookupVector(srs, proof)
-		if err == nil {
+		if !errors.Is(err, ecc.ErrTranscriptMismatch) {
+			t.Fatalf("expected ecc.ErrTranscriptMismatch, got %v", err)
+		}
+	}
+
+}
+
+func TestLookupVectorWithTrace(t *testing.T) {
+
+	lookupVector := make(Table, 8)
+	fvector := make(Table, 7)
+	for i := 0; i < 8; i++ {
+		lookupVector[i].SetUint64(uint64(2 * i))
+	}
+	for i := 0; i < 7; i++ {
+		fvector[i].Set(&lookupVector[(4*i+1)%8])
+	}
+
+	srs, err := kzg.NewSRS(64, big.NewInt(13))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// correct proof vector, the trace matches the proof
+	{
+		proof, trace, err := ProveLookupVectorWithTrace(srs, fvector, lookupVector)
+		if err != nil {
 			t.Fatal(err)
 		}
+		expected, err := ProveLookupVector(srs, fvector, lookupVector)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if proof.h != expected.h || proof.z != expected.z {
+			t.Fatal("ProveLookupVectorWithTrace and ProveLookupVector should compute the same proof")
+		}
+		if len(trace.Z) != int(proof.Size()) || !trace.Z[0].IsOne() {
+			t.Fatal("the accumulation polynomial should start with 1")
+		}
+		if len(trace.MissingEntries()) != 0 {
+			t.Fatal("no entry should be missing")
+		}
+	}
+
+	// wrong proofs vector, the trace gives the missing entries
+	{
+		fvector[2].SetUint64(3)
+		fvector[5].SetUint64(5)
+
+		proof, trace, err := ProveLookupVectorWithTrace(srs, fvector, lookupVector)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err = VerifyLookupVector(srs, proof); err == nil {
+			t.Fatal("verifying wrong proof should have failed")
+		}
+		missing := trace.MissingEntries()
+		if len(missing) != 2 || missing[0] != 2 || missing[1] != 5 {
+			t.Fatalf("wrong missing entries: %v", missing)
+		}
 	}
 
 }
 
+func TestLookupVectorInPlace(t *testing.T) {
+
+	lookupVector := make(Table, 8)
+	fvector := make(Table, 7)
+	for i := 0; i < 8; i++ {
+		lookupVector[i].SetUint64(uint64(2 * i))
+	}
+	for i := 0; i < 7; i++ {
+		fvector[i].Set(&lookupVector[(4*i+1)%8])
+	}
+
+	srs, err := kzg.NewSRS(64, big.NewInt(13))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected, err := ProveLookupVector(srs, fvector, lookupVector)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// same vectors, of the size of the domain
+	lf := make(Table, 8)
+	lt := make(Table, 8)
+	copy(lf, fvector)
+	lf[7] = fvector[6]
+	copy(lt, lookupVector)
+	proof, err := ProveLookupVectorInPlace(srs, lf, lt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !proof.h.Equal(&expected.h) || !proof.z.Equal(&expected.z) {
+		t.Fatal("in place proof differs from the proof on padded copies")
+	}
+
+	err = VerifyLookupVector(srs, proof)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// sizes that are not powers of 2 are rejected
+	_, err = ProveLookupVectorInPlace(srs, fvector, fvector)
+	if err != ErrDomainSize {
+		t.Fatal("expected ErrDomainSize")
+	}
+}
+
 func TestLookupTable(t *testing.T) {
 
 	srs, err := kzg.NewSRS(64, big.NewInt(13))
@@ -100,6 +208,20 @@ func TestLookupTable(t *testing.T) {
 		if err != nil {
 			t.Fatal(err)
 		}
+
+		fs, ts := proof.TableCommitments()
+		if len(fs) != 3 || len(ts) != 3 {
+			t.Fatal("unexpected number of commitments")
+		}
+		foldedProof, permutationProof := proof.FoldedProof(), proof.PermutationProof()
+		if len(foldedProof.Commitments()) != 6 {
+			t.Fatal("unexpected folded proof components")
+		}
+		expectedSize := 8 + 6*bls24317.SizeOfG1AffineCompressed +
+			foldedProof.SizeInBytes() + permutationProof.SizeInBytes()
+		if proof.SizeInBytes() != expectedSize {
+			t.Fatal("unexpected proof size")
+		}
 	}
 
 	// wrong proof
@@ -118,6 +240,302 @@ func TestLookupTable(t *testing.T) {
 
 }
 
+func TestLookupMultiTables(t *testing.T) {
+
+	srs, err := kzg.NewSRS(64, big.NewInt(13))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// two tables of different sizes, with a common value
+	tables := []Table{make(Table, 4), make(Table, 7)}
+	for i := 0; i < 4; i++ {
+		tables[0][i].SetUint64(uint64(i))
+	}
+	for i := 0; i < 7; i++ {
+		tables[1][i].SetUint64(uint64(3 + 10*i))
+	}
+
+	f := make(Table, 10)
+	selector := make([]int, 10)
+	for i := 0; i < 10; i++ {
+		selector[i] = i % 2
+		f[i].Set(&tables[i%2][i%len(tables[i%2])])
+	}
+
+	// correct proof
+	{
+		proof, err := ProveLookupMultiTables(srs, f, selector, tables)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		err = VerifyLookupMultiTables(srs, proof)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// wrong proof: 1 is in the first table, not in the second one
+	{
+		f[1].SetUint64(1)
+		proof, err := ProveLookupMultiTables(srs, f, selector, tables)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		err = VerifyLookupMultiTables(srs, proof)
+		if err == nil {
+			t.Fatal("verifying wrong proof should have failed")
+		}
+	}
+
+	// invalid selector
+	selector[0] = 2
+	_, err = ProveLookupMultiTables(srs, f, selector, tables)
+	if err != ErrTableSelector {
+		t.Fatal("expected ErrTableSelector")
+	}
+}
+
+func TestMultiset(t *testing.T) {
+
+	srs, err := kzg.NewSRS(64, big.NewInt(13))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// vectors of size 7, with repeated values
+	f := TableFromUint64s([]uint64{3, 1, 4, 1, 5, 9, 2})
+	g := TableFromUint64s([]uint64{1, 9, 2, 3, 1, 4, 5})
+
+	// correct proof
+	{
+		proof, err := ProveMultiset(srs, f, g)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = VerifyMultiset(srs, proof)
+		if err != nil {
+			t.Fatal(err)
+		}
+		fs, gs := proof.Commitments()
+		if len(fs) != 1 || len(gs) != 1 {
+			t.Fatal("unexpected number of commitments")
+		}
+		permutationProof := proof.PermutationProof()
+		if proof.SizeInBytes() != 8+2*bls24317.SizeOfG1AffineCompressed+permutationProof.SizeInBytes() {
+			t.Fatal("unexpected proof size")
+		}
+	}
+
+	// same values, different multiplicities
+	{
+		h := TableFromUint64s([]uint64{1, 9, 2, 3, 4, 4, 5})
+		proof, err := ProveMultiset(srs, f, h)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = VerifyMultiset(srs, proof)
+		if !errors.Is(err, ecc.ErrTranscriptMismatch) {
+			t.Fatalf("expected ecc.ErrTranscriptMismatch, got %v", err)
+		}
+	}
+
+	// tampered commitment
+	{
+		proof, err := ProveMultiset(srs, f, g)
+		if err != nil {
+			t.Fatal(err)
+		}
+		proof.fs[0].Add(&proof.fs[0], &srs.G1[0])
+		err = VerifyMultiset(srs, proof)
+		if !errors.Is(err, ecc.ErrCommitmentMismatch) {
+			t.Fatalf("expected ecc.ErrCommitmentMismatch, got %v", err)
+		}
+	}
+
+	// wrong sizes
+	if _, err := ProveMultiset(srs, f, g[1:]); err != ErrIncompatibleSize {
+		t.Fatalf("expected %v, got %v", ErrIncompatibleSize, err)
+	}
+
+	// tables: the same records (columns), shuffled
+	ft := []Table{
+		TableFromUint64s([]uint64{0, 1, 2, 3, 4}),
+		TableFromUint64s([]uint64{10, 11, 10, 13, 11}),
+	}
+	gt := []Table{
+		TableFromUint64s([]uint64{3, 0, 4, 2, 1}),
+		TableFromUint64s([]uint64{13, 10, 11, 10, 11}),
+	}
+	{
+		proof, err := ProveMultisetTables(srs, ft, gt)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = VerifyMultiset(srs, proof)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// batch verification
+	{
+		proofs := make([]ProofMultiset, 2)
+		if proofs[0], err = ProveMultiset(srs, f, g); err != nil {
+			t.Fatal(err)
+		}
+		if proofs[1], err = ProveMultisetTables(srs, ft, gt); err != nil {
+			t.Fatal(err)
+		}
+		if err = BatchVerifyMultiset(srs, proofs); err != nil {
+			t.Fatal(err)
+		}
+		proofs[1].gs[1].Add(&proofs[1].gs[1], &srs.G1[0])
+		if err = BatchVerifyMultiset(srs, proofs); !errors.Is(err, ecc.ErrCommitmentMismatch) {
+			t.Fatalf("expected ecc.ErrCommitmentMismatch, got %v", err)
+		}
+	}
+
+	// each row is a permutation of the other, but not the records
+	{
+		gt[1][0], gt[1][1] = gt[1][1], gt[1][0]
+		proof, err := ProveMultisetTables(srs, ft, gt)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = VerifyMultiset(srs, proof)
+		if !errors.Is(err, ecc.ErrTranscriptMismatch) {
+			t.Fatalf("expected ecc.ErrTranscriptMismatch, got %v", err)
+		}
+	}
+}
+
+func TestTable(t *testing.T) {
+	table := TableFromUint64s([]uint64{5, 3, 5, 1, 3, 0})
+	if !table.Contains(TableFromUint64s([]uint64{0, 5, 5})) {
+		t.Fatal("the values should be in the table")
+	}
+	if table.Contains(TableFromUint64s([]uint64{0, 2})) {
+		t.Fatal("2 is not in the table")
+	}
+
+	dedup := table.Dedup()
+	if len(dedup) != 4 {
+		t.Fatalf("expected 4 distinct values, got %d", len(dedup))
+	}
+	for i, v := range []uint64{0, 1, 3, 5} {
+		var e fr.Element
+		e.SetUint64(v)
+		if !dedup[i].Equal(&e) {
+			t.Fatal("the table should be sorted and without duplicates")
+		}
+	}
+	if len(Table{}.Dedup()) != 0 {
+		t.Fatal("the empty table should stay empty")
+	}
+
+	// bytes encoding
+	b := make([]byte, 0, len(dedup)*fr.Bytes)
+	for i := range dedup {
+		e := dedup[i].Bytes()
+		b = append(b, e[:]...)
+	}
+	decoded, err := TableFromBytes(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(decoded) != len(dedup) || !decoded.Contains(dedup) || !dedup.Contains(decoded) {
+		t.Fatal("TableFromBytes should decode the encoded table")
+	}
+	if _, err := TableFromBytes(b[1:]); !errors.Is(err, ErrTableEncoding) {
+		t.Fatalf("expected %v, got %v", ErrTableEncoding, err)
+	}
+	for i := range b[:fr.Bytes] {
+		b[i] = 0xff
+	}
+	if _, err := TableFromBytes(b); !errors.Is(err, ErrTableEncoding) {
+		t.Fatalf("expected %v, got %v", ErrTableEncoding, err)
+	}
+}
+
+func TestSortByTable(t *testing.T) {
+	table := make(Table, 16)
+	for i := range table {
+		table[i].SetUint64(uint64(3 * i))
+	}
+	f := make(Table, 21)
+	for i := range f {
+		f[i].Set(&table[(7*i)%len(table)])
+	}
+
+	// t sorted: SortByTable is the sorted concatenation
+	res, err := SortByTable(f, table)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := make(Table, 0, len(f)+len(table))
+	expected = append(expected, f...)
+	expected = append(expected, table...)
+	expected.Sort()
+	if len(res) != len(expected) {
+		t.Fatal("wrong size")
+	}
+	for i := range res {
+		if !res[i].Equal(&expected[i]) {
+			t.Fatal("SortByTable should match the sorted concatenation when t is sorted")
+		}
+	}
+
+	// t not sorted, with a duplicate: the order of t is followed
+	unsorted := TableFromUint64s([]uint64{4, 1, 4, 7})
+	res, err = SortByTable(TableFromUint64s([]uint64{7, 4, 7}), unsorted)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := TableFromUint64s([]uint64{4, 4, 1, 4, 7, 7, 7})
+	if len(res) != len(want) {
+		t.Fatal("wrong size")
+	}
+	for i := range res {
+		if !res[i].Equal(&want[i]) {
+			t.Fatal("SortByTable should follow the order of t")
+		}
+	}
+
+	if _, err := SortByTable(TableFromUint64s([]uint64{2}), unsorted); err != ErrNotInTable {
+		t.Fatalf("expected %v, got %v", ErrNotInTable, err)
+	}
+}
+
+func BenchmarkSortByTable(b *testing.B) {
+	const size = 1 << 16
+	table := make(Table, size)
+	f := make(Table, size)
+	for i := range table {
+		table[i].SetRandom()
+	}
+	table.Sort()
+	for i := range f {
+		f[i].Set(&table[(13*i)%size])
+	}
+
+	b.Run("counting", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_, _ = SortByTable(f, table)
+		}
+	})
+	b.Run("sort", func(b *testing.B) {
+		res := make(Table, 2*size)
+		for i := 0; i < b.N; i++ {
+			copy(res, table)
+			copy(res[size:], f)
+			res.Sort()
+		}
+	})
+}
+
 func BenchmarkPlookup(b *testing.B) {
 
 	srsSize := 1 << 15
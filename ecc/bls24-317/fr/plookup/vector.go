@@ -19,24 +19,126 @@ package plookup
 import (
 	"crypto/sha256"
 	"errors"
+	"fmt"
 	"math/big"
 	"math/bits"
 	"sort"
+	"sync"
 
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bls24-317"
 	"github.com/consensys/gnark-crypto/ecc/bls24-317/fr"
 	"github.com/consensys/gnark-crypto/ecc/bls24-317/fr/fft"
 	"github.com/consensys/gnark-crypto/ecc/bls24-317/fr/kzg"
 	fiatshamir "github.com/consensys/gnark-crypto/fiat-shamir"
+	"github.com/consensys/gnark-crypto/internal/parallel"
 )
 
 var (
 	ErrNotInTable          = errors.New("some value in the vector is not in the lookup table")
-	ErrPlookupVerification = errors.New("plookup verification failed")
+	ErrPlookupVerification = fmt.Errorf("plookup verification failed: %w", ecc.ErrTranscriptMismatch)
 	ErrGenerator           = errors.New("wrong generator")
+	ErrDomainSize          = errors.New("the size of the vectors must be a power of 2")
+	ErrTableEncoding       = errors.New("invalid table encoding")
 )
 
+// Table is a lookup table, or a vector of values to look up in one
 type Table []fr.Element
 
+// TableFromUint64s returns the table of the values
+func TableFromUint64s(values []uint64) Table {
+	t := make(Table, len(values))
+	for i := range values {
+		t[i].SetUint64(values[i])
+	}
+	return t
+}
+
+// TableFromBytes returns the table of the elements encoded in b, as concatenated
+// canonical big-endian encodings of fr.Bytes bytes (see fr.Element.SetBytesCanonical).
+func TableFromBytes(b []byte) (Table, error) {
+	if len(b)%fr.Bytes != 0 {
+		return nil, ErrTableEncoding
+	}
+	t := make(Table, len(b)/fr.Bytes)
+	for i := range t {
+		if err := t[i].SetBytesCanonical(b[i*fr.Bytes : (i+1)*fr.Bytes]); err != nil {
+			return nil, fmt.Errorf("element %d: %w", i, ErrTableEncoding)
+		}
+	}
+	return t, nil
+}
+
+// Sort sorts t in increasing order, in place
+func (t Table) Sort() {
+	sort.Sort(t)
+}
+
+// Dedup sorts t in place, and returns its prefix of distinct values, in increasing order.
+// The returned table shares the memory of t.
+func (t Table) Dedup() Table {
+	if len(t) == 0 {
+		return t
+	}
+	t.Sort()
+	n := 1
+	for i := 1; i < len(t); i++ {
+		if !t[i].Equal(&t[n-1]) {
+			t[n] = t[i]
+			n++
+		}
+	}
+	return t[:n]
+}
+
+// SortByTable returns f ∪ t sorted by t: the values of t in their order, each one followed
+// by its occurrences in f. It returns ErrNotInTable if a value of f is not in t.
+//
+// When t is sorted, this is the sorted concatenation of f and t, which SortByTable computes
+// by counting the occurrences in O(len(f)+len(t)), instead of a generic O(n log n) sort.
+func SortByTable(f, t Table) (Table, error) {
+	return sortByTable(f, t, make(Table, 0, len(f)+len(t)))
+}
+
+// sortByTable is SortByTable, appending the result to res
+func sortByTable(f, t, res Table) (Table, error) {
+	counts := make(map[fr.Element]int, len(t))
+	for i := range t {
+		counts[t[i]] = 0
+	}
+	for i := range f {
+		c, ok := counts[f[i]]
+		if !ok {
+			return nil, ErrNotInTable
+		}
+		counts[f[i]] = c + 1
+	}
+
+	for i := range t {
+		res = append(res, t[i])
+		for c := counts[t[i]]; c > 0; c-- {
+			res = append(res, t[i])
+		}
+		// the occurrences are written once if t has duplicates
+		counts[t[i]] = 0
+	}
+	return res, nil
+}
+
+// Contains returns true if all the values of f are in t, that is if the lookup of f in t holds
+func (t Table) Contains(f Table) bool {
+	set := make(map[fr.Element]struct{}, len(t))
+	for _, v := range t {
+		set[v] = struct{}{}
+	}
+	for _, v := range f {
+		if _, ok := set[v]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
 // Len is the number of elements in the collection.
 func (t Table) Len() int {
 	return len(t)
@@ -72,6 +174,55 @@ type ProofLookupVector struct {
 	BatchedProofShifted kzg.BatchOpeningProof
 }
 
+// Size returns the size of the system
+func (proof *ProofLookupVector) Size() uint64 {
+	return proof.size
+}
+
+// Generator returns the generator of the fft domain
+func (proof *ProofLookupVector) Generator() fr.Element {
+	return proof.g
+}
+
+// Commitments returns the commitments to h1, h2, t, z, f, h (in that order)
+func (proof *ProofLookupVector) Commitments() []kzg.Digest {
+	return []kzg.Digest{proof.h1, proof.h2, proof.t, proof.z, proof.f, proof.h}
+}
+
+// SizeInBytes returns the size of the proof, with its points in compressed form
+func (proof *ProofLookupVector) SizeInBytes() int {
+	return 8 + fr.Bytes + 6*bls24317.SizeOfG1AffineCompressed +
+		proof.BatchedProof.SizeInBytes() + proof.BatchedProofShifted.SizeInBytes()
+}
+
+// LookupVectorTrace holds the intermediate values computed by ProveLookupVectorWithTrace, to
+// debug a lookup whose proof doesn't verify. The polynomials are given in Lagrange form, that
+// is by their evaluations on the domain of size proof.Size().
+type LookupVectorTrace struct {
+	F, T   []fr.Element // f and sorted t, padded to the size of the domain
+	H1, H2 []fr.Element // f ∪ t sorted by t, split in two halves overlapping on one element
+	Z      []fr.Element // accumulation polynomial
+
+	Beta, Gamma, Alpha, Nu fr.Element // challenges
+}
+
+// MissingEntries returns the indices of the entries of f which are not in t, in increasing
+// order. It is empty if and only if the lookup holds.
+func (trace *LookupVectorTrace) MissingEntries() []int {
+	inT := make(map[fr.Element]struct{}, len(trace.T))
+	for _, v := range trace.T {
+		inT[v] = struct{}{}
+	}
+	var res []int
+	// the last entry of f is not looked up
+	for i := 0; i < len(trace.F)-1; i++ {
+		if _, ok := inT[trace.F[i]]; !ok {
+			res = append(res, i)
+		}
+	}
+	return res
+}
+
 // evaluateAccumulationPolynomial computes Z, in Lagrange basis. Z is the accumulation of the partial
 // ratios of 2 fully split polynomials (cf https://eprint.iacr.org/2020/315.pdf)
 // * lf is the list of values that should be in lt
@@ -84,40 +235,49 @@ func evaluateAccumulationPolynomial(lf, lt, lh1, lh2 []fr.Element, beta, gamma f
 
 	n := len(lt)
 	d := make([]fr.Element, n-1)
-	var u, c fr.Element
+	var c, e fr.Element
 	c.SetOne().
 		Add(&c, &beta).
 		Mul(&c, &gamma)
-	for i := 0; i < n-1; i++ {
+	e.SetOne().Add(&e, &beta)
 
-		d[i].Mul(&beta, &lh1[i+1]).
-			Add(&d[i], &lh1[i]).
-			Add(&d[i], &c)
+	// the ratios z[i+1]/z[i] are computed in parallel, by chunks sharing one inversion
+	parallel.Execute(n-1, func(start, end int) {
+		var u fr.Element
+		for i := start; i < end; i++ {
 
-		u.Mul(&beta, &lh2[i+1]).
-			Add(&u, &lh2[i]).
-			Add(&u, &c)
+			d[i].Mul(&beta, &lh1[i+1]).
+				Add(&d[i], &lh1[i]).
+				Add(&d[i], &c)
 
-		d[i].Mul(&d[i], &u)
-	}
-	d = fr.BatchInvert(d)
+			u.Mul(&beta, &lh2[i+1]).
+				Add(&u, &lh2[i]).
+				Add(&u, &c)
 
-	z[0].SetOne()
-	var a, b, e fr.Element
-	e.SetOne().Add(&e, &beta)
-	for i := 0; i < n-1; i++ {
+			d[i].Mul(&d[i], &u)
+		}
+		copy(d[start:end], fr.BatchInvert(d[start:end]))
+
+		var a, b fr.Element
+		for i := start; i < end; i++ {
 
-		a.Add(&gamma, &lf[i])
+			a.Add(&gamma, &lf[i])
 
-		b.Mul(&beta, &lt[i+1]).
-			Add(&b, &lt[i]).
-			Add(&b, &c)
+			b.Mul(&beta, &lt[i+1]).
+				Add(&b, &lt[i]).
+				Add(&b, &c)
 
-		a.Mul(&a, &b).
-			Mul(&a, &e)
+			a.Mul(&a, &b).
+				Mul(&a, &e)
 
-		z[i+1].Mul(&z[i], &a).
-			Mul(&z[i+1], &d[i])
+			d[i].Mul(&d[i], &a)
+		}
+	})
+
+	// z is the running product of the ratios
+	z[0].SetOne()
+	for i := 0; i < n-1; i++ {
+		z[i+1].Mul(&z[i], &d[i])
 	}
 
 	return z
@@ -137,54 +297,55 @@ func evaluateNumBitReversed(_lz, _lh1, _lh2, _lt, _lf []fr.Element, beta, gamma
 	s := int(domainBig.Cardinality)
 	num := make([]fr.Element, domainBig.Cardinality)
 
-	var u, onePlusBeta, GammaTimesOnePlusBeta, m, n, one fr.Element
+	var onePlusBeta, GammaTimesOnePlusBeta, one fr.Element
 
 	one.SetOne()
 	onePlusBeta.Add(&one, &beta)
 	GammaTimesOnePlusBeta.Mul(&onePlusBeta, &gamma)
 
-	g := make([]fr.Element, s)
-	g[0].Set(&domainBig.FrMultiplicativeGen)
-	for i := 1; i < s; i++ {
-		g[i].Mul(&g[i-1], &domainBig.Generator)
-	}
-
 	var gg fr.Element
 	expo := big.NewInt(int64(domainBig.Cardinality>>1 - 1))
 	gg.Square(&domainBig.Generator).Exp(gg, expo)
 
 	nn := uint64(64 - bits.TrailingZeros64(domainBig.Cardinality))
 
-	for i := 0; i < s; i++ {
-
-		_i := int(bits.Reverse64(uint64(i)) >> nn)
-		_is := int(bits.Reverse64(uint64((i+2)%s)) >> nn)
-
-		// m = z*(1+\beta)*(\gamma+f)*(\gamma(1+\beta) + t+ \beta*t(gX))
-		m.Mul(&onePlusBeta, &_lz[_i])
-		u.Add(&gamma, &_lf[_i])
-		m.Mul(&m, &u)
-		u.Mul(&beta, &_lt[_is]).
-			Add(&u, &_lt[_i]).
-			Add(&u, &GammaTimesOnePlusBeta)
-		m.Mul(&m, &u)
-
-		// n = z(gX)*(\gamma(1+\beta) + h_{1} + \beta*h_{1}(gX))*(\gamma(1+\beta) + h_{2} + \beta*h_{2}(gX)
-		n.Mul(&beta, &_lh1[_is]).
-			Add(&n, &_lh1[_i]).
-			Add(&n, &GammaTimesOnePlusBeta)
-		u.Mul(&beta, &_lh2[_is]).
-			Add(&u, &_lh2[_i]).
-			Add(&u, &GammaTimesOnePlusBeta)
-		n.Mul(&n, &u).
-			Mul(&n, &_lz[_is])
-
-		// (x-gg**(n-1))*(m-n)
-		num[_i].Sub(&m, &n)
-		u.Sub(&g[i], &gg)
-		num[_i].Mul(&num[_i], &u)
-
-	}
+	parallel.Execute(s, func(start, end int) {
+		// g = FrMultiplicativeGen⋅Generatorⁱ
+		var g, u, m, n fr.Element
+		g.ExpUint64(domainBig.Generator, uint64(start)).
+			Mul(&g, &domainBig.FrMultiplicativeGen)
+		for i := start; i < end; i++ {
+
+			_i := int(bits.Reverse64(uint64(i)) >> nn)
+			_is := int(bits.Reverse64(uint64((i+2)%s)) >> nn)
+
+			// m = z*(1+\beta)*(\gamma+f)*(\gamma(1+\beta) + t+ \beta*t(gX))
+			m.Mul(&onePlusBeta, &_lz[_i])
+			u.Add(&gamma, &_lf[_i])
+			m.Mul(&m, &u)
+			u.Mul(&beta, &_lt[_is]).
+				Add(&u, &_lt[_i]).
+				Add(&u, &GammaTimesOnePlusBeta)
+			m.Mul(&m, &u)
+
+			// n = z(gX)*(\gamma(1+\beta) + h_{1} + \beta*h_{1}(gX))*(\gamma(1+\beta) + h_{2} + \beta*h_{2}(gX)
+			n.Mul(&beta, &_lh1[_is]).
+				Add(&n, &_lh1[_i]).
+				Add(&n, &GammaTimesOnePlusBeta)
+			u.Mul(&beta, &_lh2[_is]).
+				Add(&u, &_lh2[_i]).
+				Add(&u, &GammaTimesOnePlusBeta)
+			n.Mul(&n, &u).
+				Mul(&n, &_lz[_is])
+
+			// (x-gg**(n-1))*(m-n)
+			num[_i].Sub(&m, &n)
+			u.Sub(&g, &gg)
+			num[_i].Mul(&num[_i], &u)
+
+			g.Mul(&g, &domainBig.Generator)
+		}
+	})
 
 	return num
 }
@@ -372,19 +533,25 @@ func computeQuotientCanonical(alpha fr.Element, lh, lh0, lhn, lh1h2 []fr.Element
 // the public commitment: it will contain the same values, but permuted.
 //
 func ProveLookupVector(srs *kzg.SRS, f, t Table) (ProofLookupVector, error) {
+	lf, lt, domainSmall := padLookupVector(f, t)
+	return proveLookupVector(srs, lf, lt, domainSmall, nil)
+}
 
-	// res
-	var proof ProofLookupVector
-	var err error
-
-	// hash function used for Fiat Shamir
-	hFunc := sha256.New()
+// ProveLookupVectorWithTrace is ProveLookupVector, and also returns the intermediate values
+// computed by the prover, even if it fails. It is meant for debugging: when a proof doesn't
+// verify, trace.MissingEntries() gives the entries of f which are not in t.
+func ProveLookupVectorWithTrace(srs *kzg.SRS, f, t Table) (ProofLookupVector, LookupVectorTrace, error) {
+	var trace LookupVectorTrace
+	lf, lt, domainSmall := padLookupVector(f, t)
+	proof, err := proveLookupVector(srs, lf, lt, domainSmall, &trace)
+	return proof, trace, err
+}
 
-	// transcript to derive the challenge
-	fs := fiatshamir.NewTranscript(hFunc, "beta", "gamma", "alpha", "nu")
+// padLookupVector returns copies of f and t padded to the size of the smallest domain which
+// fits them, with their last elements.
+func padLookupVector(f, t Table) (lf, lt []fr.Element, domainSmall *fft.Domain) {
 
 	// create domains
-	var domainSmall *fft.Domain
 	if len(t) <= len(f) {
 		domainSmall = fft.NewDomain(uint64(len(f) + 1))
 	} else {
@@ -392,18 +559,10 @@ func ProveLookupVector(srs *kzg.SRS, f, t Table) (ProofLookupVector, error) {
 	}
 	sizeDomainSmall := int(domainSmall.Cardinality)
 
-	// set the size
-	proof.size = domainSmall.Cardinality
-
-	// set the generator
-	proof.g.Set(&domainSmall.Generator)
-
 	// resize f and t
 	// note: the last element of lf does not matter
-	lf := make([]fr.Element, sizeDomainSmall)
-	lt := make([]fr.Element, sizeDomainSmall)
-	cf := make([]fr.Element, sizeDomainSmall)
-	ct := make([]fr.Element, sizeDomainSmall)
+	lf = make([]fr.Element, sizeDomainSmall)
+	lt = make([]fr.Element, sizeDomainSmall)
 	copy(lt, t)
 	copy(lf, f)
 	for i := len(f); i < sizeDomainSmall; i++ {
@@ -412,48 +571,115 @@ func ProveLookupVector(srs *kzg.SRS, f, t Table) (ProofLookupVector, error) {
 	for i := len(t); i < sizeDomainSmall; i++ {
 		lt[i] = t[len(t)-1]
 	}
+
+	return lf, lt, domainSmall
+}
+
+// ProveLookupVectorInPlace returns proof that the values in f[:len(f)-1] are in t.
+//
+// Unlike ProveLookupVector, f and t are not copied nor padded: they must be
+// of the same size n, a power of 2, and are used as the evaluations of the
+// polynomials f and t on the domain of that size. The last element of f is
+// not looked up.
+//
+// This saves the 2n elements of the padded copies of f and t. The prover still
+// allocates h1 and h2 (2n elements) and z (n elements), and the evaluations on
+// the domain of size 2n used to compute the quotient, which dominate the memory
+// usage.
+//
+// /!\IMPORTANT/!\
+//
+// f and t are used as scratch space: when the function returns, they hold the
+// canonical coefficients of the polynomials f and sort(t), regardless of the
+// returned error. Callers that need the original vectors must copy them first.
+// As for ProveLookupVector, a public commitment to t must be done on t sorted.
+func ProveLookupVectorInPlace(srs *kzg.SRS, f, t Table) (ProofLookupVector, error) {
+
+	if len(f) != len(t) {
+		return ProofLookupVector{}, ErrIncompatibleSize
+	}
+	if len(t) < 2 || bits.OnesCount(uint(len(t))) != 1 {
+		return ProofLookupVector{}, ErrDomainSize
+	}
+	domainSmall := fft.NewDomain(uint64(len(t)))
+
+	return proveLookupVector(srs, f, t, domainSmall, nil)
+}
+
+// proveLookupVector is the plookup prover. lf and lt are the evaluations of f and t on
+// domainSmall, they must be of size domainSmall.Cardinality. They are modified in place,
+// see ProveLookupVectorInPlace. If trace is not nil, the intermediate values are copied into it.
+func proveLookupVector(srs *kzg.SRS, lf, lt []fr.Element, domainSmall *fft.Domain, trace *LookupVectorTrace) (ProofLookupVector, error) {
+
+	// res
+	var proof ProofLookupVector
+	var err error
+
+	// hash function used for Fiat Shamir
+	hFunc := sha256.New()
+
+	// transcript to derive the challenge
+	fs := fiatshamir.NewTranscript(hFunc, "beta", "gamma", "alpha", "nu")
+
+	sizeDomainSmall := int(domainSmall.Cardinality)
+
+	// set the size
+	proof.size = domainSmall.Cardinality
+
+	// set the generator
+	proof.g.Set(&domainSmall.Generator)
+
+	// buffers of the evaluations on the big domain, used to compute the quotient
+	s := domainSmall.Cardinality
+	domainBig := fft.NewDomain(uint64(2 * s))
+	_lz := make([]fr.Element, 2*s)
+	_lh1 := make([]fr.Element, 2*s)
+	_lh2 := make([]fr.Element, 2*s)
+	_lt := make([]fr.Element, 2*s)
+	_lf := make([]fr.Element, 2*s)
+
+	// _lz is used as a scratch buffer to commit to the polynomials while their Lagrange
+	// form is still needed
+	scratch := _lz[:sizeDomainSmall]
+	commitLagrange := func(l []fr.Element) (kzg.Digest, error) {
+		copy(scratch, l)
+		domainSmall.FFTInverse(scratch, fft.DIF)
+		fft.BitReverse(scratch)
+		return kzg.Commit(scratch, srs)
+	}
+
 	sort.Sort(Table(lt))
-	copy(ct, lt)
-	copy(cf, lf)
-	domainSmall.FFTInverse(ct, fft.DIF)
-	domainSmall.FFTInverse(cf, fft.DIF)
-	fft.BitReverse(ct)
-	fft.BitReverse(cf)
-	proof.t, err = kzg.Commit(ct, srs)
+	proof.t, err = commitLagrange(lt)
 	if err != nil {
 		return proof, err
 	}
-	proof.f, err = kzg.Commit(cf, srs)
+	proof.f, err = commitLagrange(lf)
 	if err != nil {
 		return proof, err
 	}
 
-	// write f sorted by t
-	lfSortedByt := make(Table, 2*domainSmall.Cardinality-1)
-	copy(lfSortedByt, lt)
-	copy(lfSortedByt[domainSmall.Cardinality:], lf)
-	sort.Sort(lfSortedByt)
-
-	// compute h1, h2, commit to them
-	lh1 := make([]fr.Element, sizeDomainSmall)
-	lh2 := make([]fr.Element, sizeDomainSmall)
-	ch1 := make([]fr.Element, sizeDomainSmall)
-	ch2 := make([]fr.Element, sizeDomainSmall)
-	copy(lh1, lfSortedByt[:sizeDomainSmall])
-	copy(lh2, lfSortedByt[sizeDomainSmall-1:])
-
-	copy(ch1, lfSortedByt[:sizeDomainSmall])
-	copy(ch2, lfSortedByt[sizeDomainSmall-1:])
-	domainSmall.FFTInverse(ch1, fft.DIF)
-	domainSmall.FFTInverse(ch2, fft.DIF)
-	fft.BitReverse(ch1)
-	fft.BitReverse(ch2)
-
-	proof.h1, err = kzg.Commit(ch1, srs)
+	// compute h1, h2, commit to them.
+	// f sorted by t (the last element of lf is not looked up) is of size 2n-1, it is
+	// written in a buffer of size 2n, then its second half is shifted by one element:
+	// h1 and h2, which overlap on one element, are then disjoint, so that each can be
+	// interpolated in place later on.
+	h1h2, err := sortByTable(lf[:sizeDomainSmall-1], lt, make(Table, 0, 2*sizeDomainSmall))
+	if err != nil {
+		// some values of f are not in t: the proof won't verify, but it is still computed
+		h1h2 = append(h1h2[:0], lt...)
+		h1h2 = append(h1h2, lf[:sizeDomainSmall-1]...)
+		sort.Sort(h1h2)
+	}
+	h1h2 = h1h2[:2*sizeDomainSmall]
+	copy(h1h2[sizeDomainSmall:], h1h2[sizeDomainSmall-1:])
+	lh1 := h1h2[:sizeDomainSmall]
+	lh2 := h1h2[sizeDomainSmall:]
+
+	proof.h1, err = commitLagrange(lh1)
 	if err != nil {
 		return proof, err
 	}
-	proof.h2, err = kzg.Commit(ch2, srs)
+	proof.h2, err = commitLagrange(lh2)
 	if err != nil {
 		return proof, err
 	}
@@ -470,10 +696,26 @@ func ProveLookupVector(srs *kzg.SRS, f, t Table) (ProofLookupVector, error) {
 
 	// Compute to Z
 	lz := evaluateAccumulationPolynomial(lf, lt, lh1, lh2, beta, gamma)
-	cz := make([]fr.Element, len(lz))
-	copy(cz, lz)
-	domainSmall.FFTInverse(cz, fft.DIF)
-	fft.BitReverse(cz)
+
+	if trace != nil {
+		// the Lagrange forms are modified in place below
+		trace.F = append([]fr.Element(nil), lf...)
+		trace.T = append([]fr.Element(nil), lt...)
+		trace.H1 = append([]fr.Element(nil), lh1...)
+		trace.H2 = append([]fr.Element(nil), lh2...)
+		trace.Z = append([]fr.Element(nil), lz...)
+		trace.Beta, trace.Gamma = beta, gamma
+	}
+
+	// the Lagrange forms are not needed anymore, switch to canonical basis in place
+	cz, ct, cf, ch1, ch2 := lz, lt, lf, lh1, lh2
+	concurrently(
+		func() { domainSmall.FFTInverse(cz, fft.DIF); fft.BitReverse(cz) },
+		func() { domainSmall.FFTInverse(ct, fft.DIF); fft.BitReverse(ct) },
+		func() { domainSmall.FFTInverse(cf, fft.DIF); fft.BitReverse(cf) },
+		func() { domainSmall.FFTInverse(ch1, fft.DIF); fft.BitReverse(ch1) },
+		func() { domainSmall.FFTInverse(ch2, fft.DIF); fft.BitReverse(ch2) },
+	)
 	proof.z, err = kzg.Commit(cz, srs)
 	if err != nil {
 		return proof, err
@@ -481,24 +723,18 @@ func ProveLookupVector(srs *kzg.SRS, f, t Table) (ProofLookupVector, error) {
 
 	// prepare data for computing the quotient
 	// compute the numerator
-	s := domainSmall.Cardinality
-	domainBig := fft.NewDomain(uint64(2 * s))
-
-	_lz := make([]fr.Element, 2*s)
-	_lh1 := make([]fr.Element, 2*s)
-	_lh2 := make([]fr.Element, 2*s)
-	_lt := make([]fr.Element, 2*s)
-	_lf := make([]fr.Element, 2*s)
 	copy(_lz, cz)
 	copy(_lh1, ch1)
 	copy(_lh2, ch2)
 	copy(_lt, ct)
 	copy(_lf, cf)
-	domainBig.FFT(_lz, fft.DIF, true)
-	domainBig.FFT(_lh1, fft.DIF, true)
-	domainBig.FFT(_lh2, fft.DIF, true)
-	domainBig.FFT(_lt, fft.DIF, true)
-	domainBig.FFT(_lf, fft.DIF, true)
+	concurrently(
+		func() { domainBig.FFT(_lz, fft.DIF, true) },
+		func() { domainBig.FFT(_lh1, fft.DIF, true) },
+		func() { domainBig.FFT(_lh2, fft.DIF, true) },
+		func() { domainBig.FFT(_lt, fft.DIF, true) },
+		func() { domainBig.FFT(_lf, fft.DIF, true) },
+	)
 
 	// compute h
 	lh := evaluateNumBitReversed(_lz, _lh1, _lh2, _lt, _lf, beta, gamma, domainBig)
@@ -517,6 +753,9 @@ func ProveLookupVector(srs *kzg.SRS, f, t Table) (ProofLookupVector, error) {
 	if err != nil {
 		return proof, err
 	}
+	if trace != nil {
+		trace.Alpha = alpha
+	}
 	ch := computeQuotientCanonical(alpha, lh, lh0, lhn, lh1h2, domainBig)
 	proof.h, err = kzg.Commit(ch, srs)
 	if err != nil {
@@ -528,6 +767,9 @@ func ProveLookupVector(srs *kzg.SRS, f, t Table) (ProofLookupVector, error) {
 	if err != nil {
 		return proof, err
 	}
+	if trace != nil {
+		trace.Nu = nu
+	}
 	proof.BatchedProof, err = kzg.BatchOpenSinglePoint(
 		[][]fr.Element{
 			ch1,
@@ -733,3 +975,16 @@ func VerifyLookupVector(srs *kzg.SRS, proof ProofLookupVector) error {
 
 	return nil
 }
+
+// concurrently runs the functions in separate goroutines and waits for them
+func concurrently(fns ...func()) {
+	var wg sync.WaitGroup
+	wg.Add(len(fns))
+	for _, fn := range fns {
+		go func(fn func()) {
+			fn()
+			wg.Done()
+		}(fn)
+	}
+	wg.Wait()
+}
@@ -19,6 +19,7 @@ package polynomial
 import (
 	"github.com/consensys/gnark-crypto/ecc/bls24-317/fr"
 	"github.com/consensys/gnark-crypto/utils"
+	"io"
 	"strconv"
 
 	"math/big"
@@ -149,6 +150,20 @@ func (p *Polynomial) Equal(p1 Polynomial) bool {
 	return true
 }
 
+// WriteTo writes the coefficients of p to w, in the container format of fr.Vector
+//
+// implements io.WriterTo
+func (p Polynomial) WriteTo(w io.Writer) (int64, error) {
+	return fr.Vector(p).WriteTo(w)
+}
+
+// ReadFrom reads the coefficients of p from r, in the container format of fr.Vector
+//
+// implements io.ReaderFrom
+func (p *Polynomial) ReadFrom(r io.Reader) (int64, error) {
+	return (*fr.Vector)(p).ReadFrom(r)
+}
+
 func signedBigInt(v *fr.Element) big.Int {
 	var i big.Int
 	v.ToBigIntRegular(&i)
@@ -0,0 +1,235 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package polynomial
+
+import (
+	"errors"
+
+	"github.com/consensys/gnark-crypto/ecc/bls24-317/fr"
+	"github.com/consensys/gnark-crypto/ecc/bls24-317/fr/fft"
+	"github.com/consensys/gnark-crypto/internal/parallel"
+)
+
+var (
+	ErrPointInCoset  = errors.New("the vanishing polynomial of the points is zero on the coset")
+	ErrTooManyPoints = errors.New("the number of points should be smaller than the size of the domain")
+	ErrNbEvaluations = errors.New("the number of evaluations should be the size of the domain")
+)
+
+// mulThreshold is the size below which the products and divisions are computed with
+// the schoolbook algorithms rather than with FFTs
+const mulThreshold = 64
+
+// VanishingPolynomial returns ∏ᵢ(X-points[i]), computed with a subproduct tree.
+func VanishingPolynomial(points []fr.Element) Polynomial {
+	if len(points) == 0 {
+		return Polynomial{fr.One()}
+	}
+	tree := subproductTree(points)
+	return tree[len(tree)-1][0]
+}
+
+// DivideByVanishing returns the quotient q and the remainder r of the euclidean division of
+// p by the vanishing polynomial Z = ∏ᵢ(X-points[i]): p = q⋅Z + r, with deg(r) < len(points).
+// r is the polynomial interpolating p on the points, so q is the quotient of a multi-point
+// KZG opening.
+//
+// Z is computed with a subproduct tree, and the division with a Newton inversion of the
+// reversed Z, in O(M(n)⋅log(n)) where M(n) is the cost of a product of polynomials of degree n
+// with FFTs. Small sizes use the schoolbook algorithms.
+//
+// The points need not be distinct.
+func DivideByVanishing(p Polynomial, points []fr.Element) (q, r Polynomial) {
+	z := VanishingPolynomial(points)
+	m := len(points)
+	if len(p) <= m {
+		return Polynomial{}, p.Clone()
+	}
+
+	k := len(p) - m // number of coefficients of q
+	if m < mulThreshold || k < mulThreshold {
+		return divideMonicSchoolbook(p, z)
+	}
+
+	// rev(q) = rev(p)⋅rev(Z)⁻¹ mod Xᵏ
+	revZ := reversed(z)
+	revP := reversed(p)
+	inv := inverseSeries(revZ, k)
+	q = reversed(truncated(mul(truncated(revP, k), inv), k))
+
+	// r = p - q⋅Z mod Xᵐ
+	qz := mul(q, z)
+	r = make(Polynomial, m)
+	for i := 0; i < m; i++ {
+		r[i].Sub(&p[i], &qz[i])
+	}
+	return q, r
+}
+
+// DivideByVanishingOnCoset divides, in place, the evaluations of a polynomial f on the coset
+// g⋅⟨ω⟩ of the domain (g = domain.FrMultiplicativeGen, in natural order: evaluations[i] = f(g⋅ωⁱ))
+// by the evaluations of the vanishing polynomial Z = ∏ᵢ(X-points[i]) on the coset.
+//
+// When Z divides f and deg(f) < domain.Cardinality, the result is the evaluations of f/Z on the coset,
+// which is how quotients are computed in evaluation form, e.g. for custom gates constrained on a
+// subset of the rows. It returns an error if len(points) >= domain.Cardinality, or if Z has a zero
+// on the coset.
+func DivideByVanishingOnCoset(evaluations []fr.Element, points []fr.Element, domain *fft.Domain) error {
+	n := int(domain.Cardinality)
+	if len(evaluations) != n {
+		return ErrNbEvaluations
+	}
+	if len(points) >= n {
+		return ErrTooManyPoints
+	}
+
+	// evaluations of Z on the coset
+	z := make([]fr.Element, n)
+	copy(z, VanishingPolynomial(points))
+	domain.FFT(z, fft.DIF, true)
+	fft.BitReverse(z)
+	for i := range z {
+		if z[i].IsZero() {
+			return ErrPointInCoset
+		}
+	}
+
+	z = fr.BatchInvert(z)
+	parallel.Execute(n, func(start, end int) {
+		for i := start; i < end; i++ {
+			evaluations[i].Mul(&evaluations[i], &z[i])
+		}
+	})
+	return nil
+}
+
+// subproductTree returns the levels of the subproduct tree of the points: tree[0] holds the
+// polynomials X-points[i], and each polynomial of tree[l+1] is the product of two consecutive
+// polynomials of tree[l] (the last one is carried over if their number is odd). The last level
+// holds the vanishing polynomial of the points.
+func subproductTree(points []fr.Element) [][]Polynomial {
+	leaves := make([]Polynomial, len(points))
+	for i := range points {
+		leaves[i] = make(Polynomial, 2)
+		leaves[i][0].Neg(&points[i])
+		leaves[i][1].SetOne()
+	}
+	tree := [][]Polynomial{leaves}
+	for level := leaves; len(level) > 1; {
+		next := make([]Polynomial, (len(level)+1)/2)
+		parallel.Execute(len(level)/2, func(start, end int) {
+			for i := start; i < end; i++ {
+				next[i] = mul(level[2*i], level[2*i+1])
+			}
+		})
+		if len(level)%2 == 1 {
+			next[len(next)-1] = level[len(level)-1]
+		}
+		tree = append(tree, next)
+		level = next
+	}
+	return tree
+}
+
+// mul returns a⋅b, with FFTs for large inputs
+func mul(a, b Polynomial) Polynomial {
+	if len(a) == 0 || len(b) == 0 {
+		return Polynomial{}
+	}
+	n := len(a) + len(b) - 1
+	if len(a) < mulThreshold || len(b) < mulThreshold {
+		res := make(Polynomial, n)
+		var t fr.Element
+		for i := range a {
+			for j := range b {
+				t.Mul(&a[i], &b[j])
+				res[i+j].Add(&res[i+j], &t)
+			}
+		}
+		return res
+	}
+
+	domain := fft.NewDomain(uint64(n))
+	fa := make(Polynomial, domain.Cardinality)
+	fb := make(Polynomial, domain.Cardinality)
+	copy(fa, a)
+	copy(fb, b)
+	domain.FFT(fa, fft.DIF)
+	domain.FFT(fb, fft.DIF)
+	parallel.Execute(len(fa), func(start, end int) {
+		for i := start; i < end; i++ {
+			fa[i].Mul(&fa[i], &fb[i])
+		}
+	})
+	domain.FFTInverse(fa, fft.DIT)
+	return fa[:n]
+}
+
+// inverseSeries returns g⁻¹ mod Xᵏ, with Newton iterations: h ← h⋅(2 - g⋅h), doubling the
+// precision at each step. g[0] must be invertible.
+func inverseSeries(g Polynomial, k int) Polynomial {
+	h := make(Polynomial, 1)
+	h[0].Inverse(&g[0])
+	var two fr.Element
+	two.SetUint64(2)
+	for l := 1; l < k; {
+		l *= 2
+		if l > k {
+			l = k
+		}
+		e := truncated(mul(truncated(g, l), h), l)
+		for i := range e {
+			e[i].Neg(&e[i])
+		}
+		e[0].Add(&e[0], &two)
+		h = truncated(mul(h, e), l)
+	}
+	return h
+}
+
+// divideMonicSchoolbook returns the quotient and the remainder of the long division of p by
+// the monic polynomial z, with len(p) > len(z)-1
+func divideMonicSchoolbook(p, z Polynomial) (q, r Polynomial) {
+	m := len(z) - 1
+	r = p.Clone()
+	q = make(Polynomial, len(p)-m)
+	var t fr.Element
+	for i := len(q) - 1; i >= 0; i-- {
+		q[i] = r[i+m]
+		for j := 0; j < m; j++ {
+			t.Mul(&q[i], &z[j])
+			r[i+j].Sub(&r[i+j], &t)
+		}
+	}
+	return q, r[:m]
+}
+
+// reversed returns the coefficients of p in reverse order, in a new slice
+func reversed(p Polynomial) Polynomial {
+	res := make(Polynomial, len(p))
+	for i := range p {
+		res[len(p)-1-i] = p[i]
+	}
+	return res
+}
+
+// truncated returns the first n coefficients of p, padded with zeroes if needed, in a new slice
+func truncated(p Polynomial, n int) Polynomial {
+	res := make(Polynomial, n)
+	copy(res, p)
+	return res
+}
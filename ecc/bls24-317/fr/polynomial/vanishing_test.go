@@ -0,0 +1,151 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package polynomial
+
+import (
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls24-317/fr"
+	"github.com/consensys/gnark-crypto/ecc/bls24-317/fr/fft"
+)
+
+func randomPolynomial(size int) Polynomial {
+	p := make(Polynomial, size)
+	for i := range p {
+		p[i].SetRandom()
+	}
+	return p
+}
+
+func TestVanishingPolynomial(t *testing.T) {
+	points := randomPolynomial(100)
+	z := VanishingPolynomial(points)
+	if len(z) != len(points)+1 || !z[len(points)].IsOne() {
+		t.Fatal("the vanishing polynomial should be monic of degree len(points)")
+	}
+	for i := range points {
+		if e := z.Eval(&points[i]); !e.IsZero() {
+			t.Fatal("the vanishing polynomial should be zero on the points")
+		}
+	}
+
+	if z := VanishingPolynomial(nil); len(z) != 1 || !z[0].IsOne() {
+		t.Fatal("the vanishing polynomial of no points should be 1")
+	}
+}
+
+func TestDivideByVanishing(t *testing.T) {
+	// schoolbook and fast divisions, and a few edge cases
+	sizes := []struct{ p, points int }{
+		{30, 10}, {30, 29}, {10, 20}, {500, 100}, {500, 499}, {1000, 3}, {300, 100},
+	}
+	for _, s := range sizes {
+		p := randomPolynomial(s.p)
+		points := randomPolynomial(s.points)
+		q, r := DivideByVanishing(p, points)
+
+		if len(r) > len(points) {
+			t.Fatal("the degree of the remainder should be smaller than the number of points")
+		}
+
+		// p = q⋅Z + r
+		res := truncated(mul(q, VanishingPolynomial(points)), len(p))
+		for i := range r {
+			res[i].Add(&res[i], &r[i])
+		}
+		if !res.Equal(p) {
+			t.Fatalf("p != q⋅Z + r for sizes %v", s)
+		}
+
+		// r interpolates p on the points
+		for i := range points {
+			pi, ri := p.Eval(&points[i]), r.Eval(&points[i])
+			if !pi.Equal(&ri) {
+				t.Fatalf("r should interpolate p on the points for sizes %v", s)
+			}
+		}
+	}
+
+	// duplicated points
+	points := randomPolynomial(5)
+	points[3] = points[1]
+	p := mul(randomPolynomial(20), VanishingPolynomial(points))
+	if _, r := DivideByVanishing(p, points); !isZero(r) {
+		t.Fatal("the remainder of a multiple of Z should be zero")
+	}
+}
+
+func TestDivideByVanishingOnCoset(t *testing.T) {
+	const n = 64
+	domain := fft.NewDomain(n)
+
+	// f = q⋅Z, of degree < n
+	points := randomPolynomial(20)
+	q := randomPolynomial(n - len(points))
+	f := mul(q, VanishingPolynomial(points))
+
+	evaluations := make([]fr.Element, n)
+	copy(evaluations, f)
+	domain.FFT(evaluations, fft.DIF, true)
+	fft.BitReverse(evaluations)
+
+	if err := DivideByVanishingOnCoset(evaluations, points, domain); err != nil {
+		t.Fatal(err)
+	}
+
+	// compare to the evaluations of q on the coset
+	expected := make([]fr.Element, n)
+	copy(expected, q)
+	domain.FFT(expected, fft.DIF, true)
+	fft.BitReverse(expected)
+	for i := range expected {
+		if !expected[i].Equal(&evaluations[i]) {
+			t.Fatal("wrong quotient on the coset")
+		}
+	}
+
+	// a point of the coset
+	points[4].Mul(&domain.FrMultiplicativeGen, &domain.Generator)
+	if err := DivideByVanishingOnCoset(evaluations, points, domain); err != ErrPointInCoset {
+		t.Fatal("points in the coset should be rejected")
+	}
+	if err := DivideByVanishingOnCoset(evaluations, randomPolynomial(n), domain); err != ErrTooManyPoints {
+		t.Fatal("too many points should be rejected")
+	}
+	if err := DivideByVanishingOnCoset(evaluations[1:], points, domain); err != ErrNbEvaluations {
+		t.Fatal("the number of evaluations should be checked")
+	}
+}
+
+func isZero(p Polynomial) bool {
+	for i := range p {
+		if !p[i].IsZero() {
+			return false
+		}
+	}
+	return true
+}
+
+func BenchmarkDivideByVanishing(b *testing.B) {
+	const n = 1 << 14
+	p := randomPolynomial(n)
+	points := randomPolynomial(n / 4)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		DivideByVanishing(p, points)
+	}
+}
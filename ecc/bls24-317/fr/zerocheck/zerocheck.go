@@ -0,0 +1,209 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package zerocheck
+
+import (
+	"crypto/sha256"
+	"errors"
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc/bls24-317/fr"
+	"github.com/consensys/gnark-crypto/ecc/bls24-317/fr/fft"
+	"github.com/consensys/gnark-crypto/ecc/bls24-317/fr/kzg"
+	fiatshamir "github.com/consensys/gnark-crypto/fiat-shamir"
+)
+
+var (
+	ErrNotVanishing = errors.New("the polynomial does not vanish on the domain")
+	ErrDomainSize   = errors.New("the size of the domain should be a non zero power of 2")
+	ErrZeroCheck    = errors.New("zero check verification failed")
+)
+
+// Proof proof that the polynomial committed in a digest vanishes on a
+// subgroup of size n.
+type Proof struct {
+
+	// commitment to the quotient q = f/(Xⁿ-1)
+	Q kzg.Digest
+
+	// opening proofs of f and q (in that order) at the challenge
+	BatchedProof kzg.BatchOpeningProof
+}
+
+// DivideByVanishing returns q = f/(Xⁿ-1), f being in canonical form.
+// It returns ErrNotVanishing if the remainder of the division is not zero.
+func DivideByVanishing(f []fr.Element, n int) ([]fr.Element, error) {
+
+	if n <= 0 {
+		return nil, ErrDomainSize
+	}
+	if len(f) <= n {
+		for i := 0; i < len(f); i++ {
+			if !f[i].IsZero() {
+				return nil, ErrNotVanishing
+			}
+		}
+		return []fr.Element{}, nil
+	}
+
+	// f = q(Xⁿ-1) + r gives f_{i+n} = q_i - q_{i+n} for i+n < len(f)
+	q := make([]fr.Element, len(f)-n)
+	for i := len(q) - 1; i >= 0; i-- {
+		q[i].Set(&f[i+n])
+		if i+n < len(q) {
+			q[i].Add(&q[i], &q[i+n])
+		}
+	}
+
+	// r_i = f_i + q_i must be zero
+	var r fr.Element
+	for i := 0; i < n; i++ {
+		r.Set(&f[i])
+		if i < len(q) {
+			r.Add(&r, &q[i])
+		}
+		if !r.IsZero() {
+			return nil, ErrNotVanishing
+		}
+	}
+
+	return q, nil
+}
+
+// QuotientFromCosetEvaluations computes q = f/(Xⁿ-1) from the evaluations of f on the
+// coset of d, in bit reversed order (as returned by d.FFT(_, fft.DIF, true)). The
+// result is in canonical form, and the evaluations are overwritten.
+//
+// f should be of degree < 2n where n is the cardinality of d, so that q is of degree
+// < n and is fully determined by its evaluations on the coset.
+func QuotientFromCosetEvaluations(evaluations []fr.Element, d *fft.Domain) []fr.Element {
+
+	// Xⁿ-1 is constant on the coset gH, equal to gⁿ-1
+	var t, one fr.Element
+	one.SetOne()
+	t.Exp(d.FrMultiplicativeGen, big.NewInt(int64(d.Cardinality))).
+		Sub(&t, &one).
+		Inverse(&t)
+	for i := 0; i < len(evaluations); i++ {
+		evaluations[i].Mul(&evaluations[i], &t)
+	}
+	d.FFTInverse(evaluations, fft.DIT, true)
+	return evaluations
+}
+
+// Prove proves that f, in canonical form and committed in digest, vanishes on
+// the subgroup of size n.
+//
+// The challenge ζ is derived from fs using challengeID, after binding digest and
+// the commitment to the quotient. The caller may have bound extra data to
+// challengeID beforehand, as long as the verifier does the same.
+func Prove(srs *kzg.SRS, f []fr.Element, digest kzg.Digest, n uint64, fs *fiatshamir.Transcript, challengeID string) (Proof, error) {
+
+	var proof Proof
+
+	if n == 0 || n&(n-1) != 0 {
+		return proof, ErrDomainSize
+	}
+
+	// compute the quotient and commit it
+	q, err := DivideByVanishing(f, int(n))
+	if err != nil {
+		return proof, err
+	}
+	if len(q) == 0 {
+		q = make([]fr.Element, 1)
+	}
+	proof.Q, err = kzg.Commit(q, srs)
+	if err != nil {
+		return proof, err
+	}
+
+	// derive the evaluation challenge
+	zeta, err := deriveChallenge(fs, challengeID, &digest, &proof.Q)
+	if err != nil {
+		return proof, err
+	}
+
+	// open f and q at ζ
+	proof.BatchedProof, err = kzg.BatchOpenSinglePoint(
+		[][]fr.Element{f, q},
+		[]kzg.Digest{digest, proof.Q},
+		zeta,
+		sha256.New(),
+		srs,
+	)
+
+	return proof, err
+}
+
+// Verify verifies a zero check proof that the polynomial committed in digest
+// vanishes on the subgroup of size n, fs being in the same state as the prover's
+// transcript when Prove was called.
+func Verify(srs *kzg.SRS, digest kzg.Digest, proof Proof, n uint64, fs *fiatshamir.Transcript, challengeID string) error {
+
+	if n == 0 || n&(n-1) != 0 {
+		return ErrDomainSize
+	}
+	if len(proof.BatchedProof.ClaimedValues) != 2 {
+		return kzg.ErrInvalidNbDigests
+	}
+
+	// derive the evaluation challenge
+	zeta, err := deriveChallenge(fs, challengeID, &digest, &proof.Q)
+	if err != nil {
+		return err
+	}
+
+	// f(ζ) ==? q(ζ)(ζⁿ-1)
+	var rhs, one fr.Element
+	one.SetOne()
+	rhs.Exp(zeta, new(big.Int).SetUint64(n)).
+		Sub(&rhs, &one).
+		Mul(&rhs, &proof.BatchedProof.ClaimedValues[1])
+	if !rhs.Equal(&proof.BatchedProof.ClaimedValues[0]) {
+		return ErrZeroCheck
+	}
+
+	// check the opening proofs
+	return kzg.BatchVerifySinglePoint(
+		[]kzg.Digest{digest, proof.Q},
+		&proof.BatchedProof,
+		zeta,
+		sha256.New(),
+		srs,
+	)
+}
+
+// deriveChallenge binds the commitments to challengeID and computes the challenge
+func deriveChallenge(fs *fiatshamir.Transcript, challengeID string, digests ...*kzg.Digest) (fr.Element, error) {
+
+	var r fr.Element
+
+	for _, d := range digests {
+		buf := d.RawBytes()
+		if err := fs.Bind(challengeID, buf[:]); err != nil {
+			return r, err
+		}
+	}
+
+	b, err := fs.ComputeChallenge(challengeID)
+	if err != nil {
+		return r, err
+	}
+	r.SetBytes(b)
+	return r, nil
+}
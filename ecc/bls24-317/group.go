@@ -0,0 +1,375 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package bls24317
+
+import (
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bls24-317/fr"
+	"github.com/consensys/gnark-crypto/ecc/group"
+)
+
+// G1Group returns the prime order subgroup of G1 as a group.Group
+func G1Group() group.Group {
+	return g1Group{}
+}
+
+type g1Group struct{}
+
+func (g1Group) String() string {
+	return "bls24-317/G1"
+}
+
+func (g1Group) Order() *big.Int {
+	return fr.Modulus()
+}
+
+func (g1Group) NewScalar() group.Scalar {
+	return new(frScalar)
+}
+
+func (g1Group) NewPoint() group.Point {
+	return new(g1Point).SetIdentity()
+}
+
+func (g1Group) Generator() group.Point {
+	return new(g1Point).SetGenerator()
+}
+
+func (g1Group) ScalarSize() int {
+	return fr.Bytes
+}
+
+func (g1Group) PointSize() int {
+	return SizeOfG1AffineCompressed
+}
+
+func (g1Group) MultiScalarMul(points []group.Point, scalars []group.Scalar) (group.Point, error) {
+	if len(points) != len(scalars) {
+		return nil, group.ErrSizeMismatch
+	}
+	res := new(g1Point).SetIdentity().(*g1Point)
+	if len(points) == 0 {
+		return res, nil
+	}
+	_points := make([]G1Affine, len(points))
+	_scalars := make([]fr.Element, len(scalars))
+	for i := range points {
+		_points[i].FromJacobian(&points[i].(*g1Point).p)
+		_scalars[i] = scalars[i].(*frScalar).e
+	}
+	if _, err := res.p.MultiExp(_points, _scalars, ecc.MultiExpConfig{ScalarsMont: true}); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// g1Point implements group.Point
+type g1Point struct {
+	p G1Jac
+}
+
+func (p *g1Point) Set(a group.Point) group.Point {
+	p.p.Set(&a.(*g1Point).p)
+	return p
+}
+
+func (p *g1Point) SetIdentity() group.Point {
+	p.p.Set(&g1Infinity)
+	return p
+}
+
+func (p *g1Point) SetGenerator() group.Point {
+	p.p.Set(&g1Gen)
+	return p
+}
+
+func (p *g1Point) Add(a, b group.Point) group.Point {
+	var res G1Jac
+	res.Set(&a.(*g1Point).p).AddAssign(&b.(*g1Point).p)
+	p.p.Set(&res)
+	return p
+}
+
+func (p *g1Point) Sub(a, b group.Point) group.Point {
+	var res G1Jac
+	res.Set(&a.(*g1Point).p).SubAssign(&b.(*g1Point).p)
+	p.p.Set(&res)
+	return p
+}
+
+func (p *g1Point) Neg(a group.Point) group.Point {
+	p.p.Neg(&a.(*g1Point).p)
+	return p
+}
+
+func (p *g1Point) Double(a group.Point) group.Point {
+	p.p.Double(&a.(*g1Point).p)
+	return p
+}
+
+func (p *g1Point) ScalarMul(a group.Point, s group.Scalar) group.Point {
+	var b big.Int
+	s.(*frScalar).e.ToBigIntRegular(&b)
+	p.p.ScalarMultiplication(&a.(*g1Point).p, &b)
+	return p
+}
+
+func (p *g1Point) Equal(a group.Point) bool {
+	return p.p.Equal(&a.(*g1Point).p)
+}
+
+func (p *g1Point) IsIdentity() bool {
+	return p.p.Z.IsZero()
+}
+
+func (p *g1Point) Bytes() []byte {
+	var a G1Affine
+	a.FromJacobian(&p.p)
+	b := a.Bytes()
+	return b[:]
+}
+
+func (p *g1Point) SetBytes(buf []byte) error {
+	var a G1Affine
+	n, err := a.SetBytes(buf)
+	if err != nil {
+		return err
+	}
+	if n != len(buf) || n != SizeOfG1AffineCompressed {
+		return group.ErrInvalidEncoding
+	}
+	p.p.FromAffine(&a)
+	return nil
+}
+
+// G2Group returns the prime order subgroup of G2 as a group.Group
+func G2Group() group.Group {
+	return g2Group{}
+}
+
+type g2Group struct{}
+
+func (g2Group) String() string {
+	return "bls24-317/G2"
+}
+
+func (g2Group) Order() *big.Int {
+	return fr.Modulus()
+}
+
+func (g2Group) NewScalar() group.Scalar {
+	return new(frScalar)
+}
+
+func (g2Group) NewPoint() group.Point {
+	return new(g2Point).SetIdentity()
+}
+
+func (g2Group) Generator() group.Point {
+	return new(g2Point).SetGenerator()
+}
+
+func (g2Group) ScalarSize() int {
+	return fr.Bytes
+}
+
+func (g2Group) PointSize() int {
+	return SizeOfG2AffineCompressed
+}
+
+func (g2Group) MultiScalarMul(points []group.Point, scalars []group.Scalar) (group.Point, error) {
+	if len(points) != len(scalars) {
+		return nil, group.ErrSizeMismatch
+	}
+	res := new(g2Point).SetIdentity().(*g2Point)
+	if len(points) == 0 {
+		return res, nil
+	}
+	_points := make([]G2Affine, len(points))
+	_scalars := make([]fr.Element, len(scalars))
+	for i := range points {
+		_points[i].FromJacobian(&points[i].(*g2Point).p)
+		_scalars[i] = scalars[i].(*frScalar).e
+	}
+	if _, err := res.p.MultiExp(_points, _scalars, ecc.MultiExpConfig{ScalarsMont: true}); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// g2Point implements group.Point
+type g2Point struct {
+	p G2Jac
+}
+
+func (p *g2Point) Set(a group.Point) group.Point {
+	p.p.Set(&a.(*g2Point).p)
+	return p
+}
+
+func (p *g2Point) SetIdentity() group.Point {
+	p.p.Set(&g2Infinity)
+	return p
+}
+
+func (p *g2Point) SetGenerator() group.Point {
+	p.p.Set(&g2Gen)
+	return p
+}
+
+func (p *g2Point) Add(a, b group.Point) group.Point {
+	var res G2Jac
+	res.Set(&a.(*g2Point).p).AddAssign(&b.(*g2Point).p)
+	p.p.Set(&res)
+	return p
+}
+
+func (p *g2Point) Sub(a, b group.Point) group.Point {
+	var res G2Jac
+	res.Set(&a.(*g2Point).p).SubAssign(&b.(*g2Point).p)
+	p.p.Set(&res)
+	return p
+}
+
+func (p *g2Point) Neg(a group.Point) group.Point {
+	p.p.Neg(&a.(*g2Point).p)
+	return p
+}
+
+func (p *g2Point) Double(a group.Point) group.Point {
+	p.p.Double(&a.(*g2Point).p)
+	return p
+}
+
+func (p *g2Point) ScalarMul(a group.Point, s group.Scalar) group.Point {
+	var b big.Int
+	s.(*frScalar).e.ToBigIntRegular(&b)
+	p.p.ScalarMultiplication(&a.(*g2Point).p, &b)
+	return p
+}
+
+func (p *g2Point) Equal(a group.Point) bool {
+	return p.p.Equal(&a.(*g2Point).p)
+}
+
+func (p *g2Point) IsIdentity() bool {
+	return p.p.Z.IsZero()
+}
+
+func (p *g2Point) Bytes() []byte {
+	var a G2Affine
+	a.FromJacobian(&p.p)
+	b := a.Bytes()
+	return b[:]
+}
+
+func (p *g2Point) SetBytes(buf []byte) error {
+	var a G2Affine
+	n, err := a.SetBytes(buf)
+	if err != nil {
+		return err
+	}
+	if n != len(buf) || n != SizeOfG2AffineCompressed {
+		return group.ErrInvalidEncoding
+	}
+	p.p.FromAffine(&a)
+	return nil
+}
+
+// frScalar implements group.Scalar for G1 and G2
+type frScalar struct {
+	e fr.Element
+}
+
+func (z *frScalar) Set(a group.Scalar) group.Scalar {
+	z.e.Set(&a.(*frScalar).e)
+	return z
+}
+
+func (z *frScalar) SetUint64(v uint64) group.Scalar {
+	z.e.SetUint64(v)
+	return z
+}
+
+func (z *frScalar) SetBigInt(v *big.Int) group.Scalar {
+	z.e.SetBigInt(v)
+	return z
+}
+
+func (z *frScalar) SetRandom() (group.Scalar, error) {
+	if _, err := z.e.SetRandom(); err != nil {
+		return nil, err
+	}
+	return z, nil
+}
+
+func (z *frScalar) Add(a, b group.Scalar) group.Scalar {
+	z.e.Add(&a.(*frScalar).e, &b.(*frScalar).e)
+	return z
+}
+
+func (z *frScalar) Sub(a, b group.Scalar) group.Scalar {
+	z.e.Sub(&a.(*frScalar).e, &b.(*frScalar).e)
+	return z
+}
+
+func (z *frScalar) Mul(a, b group.Scalar) group.Scalar {
+	z.e.Mul(&a.(*frScalar).e, &b.(*frScalar).e)
+	return z
+}
+
+func (z *frScalar) Neg(a group.Scalar) group.Scalar {
+	z.e.Neg(&a.(*frScalar).e)
+	return z
+}
+
+func (z *frScalar) Inverse(a group.Scalar) group.Scalar {
+	z.e.Inverse(&a.(*frScalar).e)
+	return z
+}
+
+func (z *frScalar) Equal(a group.Scalar) bool {
+	return z.e.Equal(&a.(*frScalar).e)
+}
+
+func (z *frScalar) IsZero() bool {
+	return z.e.IsZero()
+}
+
+func (z *frScalar) BigInt(res *big.Int) *big.Int {
+	return z.e.ToBigIntRegular(res)
+}
+
+func (z *frScalar) Bytes() []byte {
+	b := z.e.Bytes()
+	return b[:]
+}
+
+func (z *frScalar) SetBytes(buf []byte) error {
+	if len(buf) != fr.Bytes {
+		return group.ErrInvalidScalar
+	}
+	var v big.Int
+	v.SetBytes(buf)
+	if v.Cmp(fr.Modulus()) >= 0 {
+		return group.ErrInvalidScalar
+	}
+	z.e.SetBigInt(&v)
+	return nil
+}
@@ -25,62 +25,99 @@ import (
 
 //Note: This only works for simple extensions
 
+// coefficients of the rational maps of the isogeny E' → E, in increasing degree.
+// The denominators are monic, their leading coefficient is omitted.
+var (
+	g1IsogenyXNumeratorMap = []fp.Element{
+		{13523513236317711848, 15327023349232218118, 8703648794266574884, 8264167271110563191, 40794431846902569},
+		{8812074666074491586, 50960250954420133, 14056404179861272537, 929938412739573318, 947153270783672532},
+		{15051608682446262522, 9488224519772198430, 11710444855428888956, 16398015671457218553, 1029622088557318610},
+		{4296820476805851409, 10780602457143466946, 10247933845608112961, 6951059907314751932, 722213278859423782},
+		{14764184048304945149, 5865289230433310091, 5581095008736809995, 9208735173835224741, 528727552546926153},
+		{11398597359936714397, 1594057801015249474, 13954376621701424207, 16271868308895978452, 690753220876234821},
+	}
+	g1IsogenyXDenominatorMap = []fp.Element{
+		{5399775903125704630, 4517816096475473808, 8510054034683086600, 15646083100922413141, 906999227924553668},
+		{828013697853572132, 458878942468938987, 5757230941761973224, 5158770805028806783, 869290263606291835},
+		{11118632362304015867, 6158437615457151578, 8167114226690349799, 18398210184903822958, 32908558142489459},
+		{17284245259114832476, 13149059755030257718, 10930970338758309391, 1062425496339030960, 261139743832662079},
+	}
+	g1IsogenyYNumeratorMap = []fp.Element{
+		{5736138424590314750, 6015908605773073009, 6156792889286183843, 17896612273365749807, 821435345686805089},
+		{9373359301599115869, 655867965241119234, 3304264667834595975, 12237805962366901484, 297609776634465799},
+		{3480981777823324659, 9475237666221295368, 11936228663660569620, 16004883291078000733, 694053280005543484},
+		{4229115995671887337, 9233280055297188894, 1359384483422747035, 11273993240180143056, 469494085796341224},
+		{18113844587232876680, 14242937351038565984, 777537960123335163, 6685524189684440232, 980736769871245076},
+		{11922196649017768415, 7237889860522244398, 3155125612682980193, 3938240406780725187, 665921220498498902},
+		{3446223578941560630, 13846992323172164671, 12292264306216531556, 7620005162288670125, 97432066185489249},
+	}
+	g1IsogenyYDenominatorMap = []fp.Element{
+		{8602082813304143536, 14359122824402329793, 2469007073274644071, 4254406725226729972, 992519966230345268},
+		{3085489453415801238, 15662911842127999867, 9714633693652399946, 9543599792786380558, 789455890382293440},
+		{17898042109793411276, 8772407166446083546, 16320058043659241709, 18250219114565265632, 721227617678419637},
+		{12665654738497754715, 10529888736786073619, 14298592531231225548, 714005056864991408, 1088730156414821854},
+		{11181082342903713721, 9065467944505387329, 647327075925674801, 8268923912961120967, 264633289965085690},
+		{7479623814962697098, 10500217595690610770, 16396455508137464087, 10817010281363322248, 391709615748993118},
+	}
+)
+
+// coefficients A', B' of the curve E': y² = x³ + A'x + B' isogenous to E, on which the SSWU map is computed,
+// and the non-square Z of the map
+var (
+	g1SSWUIsoCurveCoeffA = fp.Element{2751493217506761890, 10508083672876982400, 9568653941102734201, 1934905759174260726, 590687129635764257}
+	g1SSWUIsoCurveCoeffB = fp.Element{14477170886729819615, 1154054877908840441, 13400991584556574205, 3277375072715511934, 979998381373634863}
+	g1SSWUZ              = fp.Element{18400687542797871745, 809728271075671860, 17770696641280178537, 10361798156408411167, 334758614216279309}
+)
+
+// G1SSWUConstants returns the coefficients A', B' of the curve E': y² = x³ + A'x + B' isogenous to
+// the curve of G1, and the constant Z of the simplified SWU map to E'.
+// https://www.rfc-editor.org/rfc/rfc9380.html#name-simplified-swu-for-ab-0
+func G1SSWUConstants() (a, b, z fp.Element) {
+	return g1SSWUIsoCurveCoeffA, g1SSWUIsoCurveCoeffB, g1SSWUZ
+}
+
+// G1IsogenyCoefficients returns the coefficients of the rational maps of the 5-isogeny
+// (x, y) ↦ (xNum(x)/xDen(x), y·yNum(x)/yDen(x)) from E' to the curve of G1, in increasing degree.
+// The denominators are monic, their leading coefficient 1 is omitted.
+// The slices are copies, they can be modified freely.
+func G1IsogenyCoefficients() (xNum, xDen, yNum, yDen []fp.Element) {
+	xNum = append([]fp.Element(nil), g1IsogenyXNumeratorMap...)
+	xDen = append([]fp.Element(nil), g1IsogenyXDenominatorMap...)
+	yNum = append([]fp.Element(nil), g1IsogenyYNumeratorMap...)
+	yDen = append([]fp.Element(nil), g1IsogenyYDenominatorMap...)
+	return
+}
+
+// G1EvalSSWU evaluates the simplified SWU map at u. The result is a point of E', not of
+// the curve of G1; MapToG1(u) is G1EvalIsogeny(G1EvalSSWU(u)) with the cofactor cleared.
+func G1EvalSSWU(u *fp.Element) G1Affine {
+	return mapToCurve1(u)
+}
+
+// G1EvalIsogeny evaluates the isogeny E' → E at p, a point of E'
+func G1EvalIsogeny(p *G1Affine) G1Affine {
+	res := *p
+	g1Isogeny(&res)
+	return res
+}
+
 func g1IsogenyXNumerator(dst *fp.Element, x *fp.Element) {
-	g1EvalPolynomial(dst,
-		false,
-		[]fp.Element{
-			{13523513236317711848, 15327023349232218118, 8703648794266574884, 8264167271110563191, 40794431846902569},
-			{8812074666074491586, 50960250954420133, 14056404179861272537, 929938412739573318, 947153270783672532},
-			{15051608682446262522, 9488224519772198430, 11710444855428888956, 16398015671457218553, 1029622088557318610},
-			{4296820476805851409, 10780602457143466946, 10247933845608112961, 6951059907314751932, 722213278859423782},
-			{14764184048304945149, 5865289230433310091, 5581095008736809995, 9208735173835224741, 528727552546926153},
-			{11398597359936714397, 1594057801015249474, 13954376621701424207, 16271868308895978452, 690753220876234821},
-		},
-		x)
+	g1EvalPolynomial(dst, false, g1IsogenyXNumeratorMap, x)
 }
 
 func g1IsogenyXDenominator(dst *fp.Element, x *fp.Element) {
-	g1EvalPolynomial(dst,
-		true,
-		[]fp.Element{
-			{5399775903125704630, 4517816096475473808, 8510054034683086600, 15646083100922413141, 906999227924553668},
-			{828013697853572132, 458878942468938987, 5757230941761973224, 5158770805028806783, 869290263606291835},
-			{11118632362304015867, 6158437615457151578, 8167114226690349799, 18398210184903822958, 32908558142489459},
-			{17284245259114832476, 13149059755030257718, 10930970338758309391, 1062425496339030960, 261139743832662079},
-		},
-		x)
+	g1EvalPolynomial(dst, true, g1IsogenyXDenominatorMap, x)
 }
 
 func g1IsogenyYNumerator(dst *fp.Element, x *fp.Element, y *fp.Element) {
 	var _dst fp.Element
-	g1EvalPolynomial(&_dst,
-		false,
-		[]fp.Element{
-			{5736138424590314750, 6015908605773073009, 6156792889286183843, 17896612273365749807, 821435345686805089},
-			{9373359301599115869, 655867965241119234, 3304264667834595975, 12237805962366901484, 297609776634465799},
-			{3480981777823324659, 9475237666221295368, 11936228663660569620, 16004883291078000733, 694053280005543484},
-			{4229115995671887337, 9233280055297188894, 1359384483422747035, 11273993240180143056, 469494085796341224},
-			{18113844587232876680, 14242937351038565984, 777537960123335163, 6685524189684440232, 980736769871245076},
-			{11922196649017768415, 7237889860522244398, 3155125612682980193, 3938240406780725187, 665921220498498902},
-			{3446223578941560630, 13846992323172164671, 12292264306216531556, 7620005162288670125, 97432066185489249},
-		},
-		x)
+	g1EvalPolynomial(&_dst, false, g1IsogenyYNumeratorMap, x)
 
 	dst.Mul(&_dst, y)
 }
 
 func g1IsogenyYDenominator(dst *fp.Element, x *fp.Element) {
-	g1EvalPolynomial(dst,
-		true,
-		[]fp.Element{
-			{8602082813304143536, 14359122824402329793, 2469007073274644071, 4254406725226729972, 992519966230345268},
-			{3085489453415801238, 15662911842127999867, 9714633693652399946, 9543599792786380558, 789455890382293440},
-			{17898042109793411276, 8772407166446083546, 16320058043659241709, 18250219114565265632, 721227617678419637},
-			{12665654738497754715, 10529888736786073619, 14298592531231225548, 714005056864991408, 1088730156414821854},
-			{11181082342903713721, 9065467944505387329, 647327075925674801, 8268923912961120967, 264633289965085690},
-			{7479623814962697098, 10500217595690610770, 16396455508137464087, 10817010281363322248, 391709615748993118},
-		},
-		x)
+	g1EvalPolynomial(dst, true, g1IsogenyYDenominatorMap, x)
 }
 
 func g1Isogeny(p *G1Affine) {
@@ -150,9 +187,6 @@ func g1MulByZ(z *fp.Element, x *fp.Element) {
 // No cofactor clearing or isogeny
 func mapToCurve1(u *fp.Element) G1Affine {
 
-	var sswuIsoCurveCoeffA = fp.Element{2751493217506761890, 10508083672876982400, 9568653941102734201, 1934905759174260726, 590687129635764257}
-	var sswuIsoCurveCoeffB = fp.Element{14477170886729819615, 1154054877908840441, 13400991584556574205, 3277375072715511934, 979998381373634863}
-
 	var tv1 fp.Element
 	tv1.Square(u) // 1.  tv1 = u²
 
@@ -166,17 +200,17 @@ func mapToCurve1(u *fp.Element) G1Affine {
 	var tv3 fp.Element
 	var tv4 fp.Element
 	tv4.SetOne()
-	tv3.Add(&tv2, &tv4)                // 5.  tv3 = tv2 + 1
-	tv3.Mul(&tv3, &sswuIsoCurveCoeffB) // 6.  tv3 = B * tv3
+	tv3.Add(&tv2, &tv4)                  // 5.  tv3 = tv2 + 1
+	tv3.Mul(&tv3, &g1SSWUIsoCurveCoeffB) // 6.  tv3 = B * tv3
 
 	tv2NZero := g1NotZero(&tv2)
 
 	// tv4 = Z
-	tv4 = fp.Element{18400687542797871745, 809728271075671860, 17770696641280178537, 10361798156408411167, 334758614216279309}
+	tv4 = g1SSWUZ
 
 	tv2.Neg(&tv2)
 	tv4.Select(int(tv2NZero), &tv4, &tv2) // 7.  tv4 = CMOV(Z, -tv2, tv2 != 0)
-	tv4.Mul(&tv4, &sswuIsoCurveCoeffA)    // 8.  tv4 = A * tv4
+	tv4.Mul(&tv4, &g1SSWUIsoCurveCoeffA)  // 8.  tv4 = A * tv4
 
 	tv2.Square(&tv3) // 9.  tv2 = tv3²
 
@@ -184,14 +218,14 @@ func mapToCurve1(u *fp.Element) G1Affine {
 	tv6.Square(&tv4) // 10. tv6 = tv4²
 
 	var tv5 fp.Element
-	tv5.Mul(&tv6, &sswuIsoCurveCoeffA) // 11. tv5 = A * tv6
+	tv5.Mul(&tv6, &g1SSWUIsoCurveCoeffA) // 11. tv5 = A * tv6
 
 	tv2.Add(&tv2, &tv5) // 12. tv2 = tv2 + tv5
 	tv2.Mul(&tv2, &tv3) // 13. tv2 = tv2 * tv3
 	tv6.Mul(&tv6, &tv4) // 14. tv6 = tv6 * tv4
 
-	tv5.Mul(&tv6, &sswuIsoCurveCoeffB) // 15. tv5 = B * tv6
-	tv2.Add(&tv2, &tv5)                // 16. tv2 = tv2 + tv5
+	tv5.Mul(&tv6, &g1SSWUIsoCurveCoeffB) // 15. tv5 = B * tv6
+	tv2.Add(&tv2, &tv5)                  // 16. tv2 = tv2 + tv5
 
 	var x fp.Element
 	x.Mul(&tv1, &tv3) // 17.   x = tv1 * tv3
@@ -259,11 +293,8 @@ func hashToFp(msg, dst []byte, count int) ([]fp.Element, error) {
 // https://www.ietf.org/archive/id/draft-irtf-cfrg-hash-to-curve-16.html#name-the-sgn0-function
 // The sign of an element is not obviously related to that of its Montgomery form
 func g1Sgn0(z *fp.Element) uint64 {
-
-	nonMont := *z
-	nonMont.FromMont()
 	// m == 1
-	return nonMont[0] % 2
+	return z.Sign0()
 
 }
 
@@ -276,24 +307,36 @@ func MapToG1(u fp.Element) G1Affine {
 	return res
 }
 
+// G1HashTrace holds the intermediate values of HashToG1 and EncodeToG1,
+// named as in the test vectors of RFC 9380 (appendix J), to compare an implementation
+// or debug the handling of a DST against them.
+type G1HashTrace struct {
+	// U is the output of hash_to_field: one element for EncodeToG1, two for HashToG1
+	U []fp.Element
+
+	// Q[i] is map_to_curve(U[i]), after the isogeny, before clearing the cofactor
+	Q []G1Affine
+}
+
 // EncodeToG1 hashes a message to a point on the G1 curve using the SSWU map.
 // It is faster than HashToG1, but the result is not uniformly distributed. Unsuitable as a random oracle.
 // dst stands for "domain separation tag", a string unique to the construction using the hash function
 //https://www.ietf.org/archive/id/draft-irtf-cfrg-hash-to-curve-16.html#roadmap
 func EncodeToG1(msg, dst []byte) (G1Affine, error) {
+	res, _, err := EncodeToG1Trace(msg, dst)
+	return res, err
+}
 
-	var res G1Affine
-	u, err := hashToFp(msg, dst, 1)
+// EncodeToG1Trace is EncodeToG1, which also returns its intermediate values.
+func EncodeToG1Trace(msg, dst []byte) (G1Affine, G1HashTrace, error) {
+	trace, err := g1HashToCurveTrace(msg, dst, 1)
 	if err != nil {
-		return res, err
+		return G1Affine{}, trace, err
 	}
 
-	res = mapToCurve1(&u[0])
-
-	//this is in an isogenous curve
-	g1Isogeny(&res)
+	res := trace.Q[0]
 	res.ClearCofactor(&res)
-	return res, nil
+	return res, trace, nil
 }
 
 // HashToG1 hashes a message to a point on the G1 curve using the SSWU map.
@@ -301,26 +344,45 @@ func EncodeToG1(msg, dst []byte) (G1Affine, error) {
 // dst stands for "domain separation tag", a string unique to the construction using the hash function
 //https://www.ietf.org/archive/id/draft-irtf-cfrg-hash-to-curve-16.html#roadmap
 func HashToG1(msg, dst []byte) (G1Affine, error) {
-	u, err := hashToFp(msg, dst, 2*1)
+	res, _, err := HashToG1Trace(msg, dst)
+	return res, err
+}
+
+// HashToG1Trace is HashToG1, which also returns its intermediate values.
+func HashToG1Trace(msg, dst []byte) (G1Affine, G1HashTrace, error) {
+	trace, err := g1HashToCurveTrace(msg, dst, 2)
 	if err != nil {
-		return G1Affine{}, err
+		return G1Affine{}, trace, err
 	}
-
-	Q0 := mapToCurve1(&u[0])
-	Q1 := mapToCurve1(&u[1])
-
 	//TODO (perf): Add in E' first, then apply isogeny
-	g1Isogeny(&Q0)
-	g1Isogeny(&Q1)
-
 	var _Q0, _Q1 G1Jac
-	_Q0.FromAffine(&Q0)
-	_Q1.FromAffine(&Q1).AddAssign(&_Q0)
+	_Q0.FromAffine(&trace.Q[0])
+	_Q1.FromAffine(&trace.Q[1]).AddAssign(&_Q0)
 
 	_Q1.ClearCofactor(&_Q1)
 
-	Q1.FromJacobian(&_Q1)
-	return Q1, nil
+	var res G1Affine
+	res.FromJacobian(&_Q1)
+	return res, trace, nil
+}
+
+// g1HashToCurveTrace hashes msg to count elements of the base field of G1, and maps them to the curve
+func g1HashToCurveTrace(msg, dst []byte, count int) (G1HashTrace, error) {
+	var trace G1HashTrace
+	u, err := hashToFp(msg, dst, count*1)
+	if err != nil {
+		return trace, err
+	}
+
+	trace.U = make([]fp.Element, count)
+	trace.Q = make([]G1Affine, count)
+	for i := 0; i < count; i++ {
+		trace.U[i] = u[i]
+		trace.Q[i] = mapToCurve1(&trace.U[i])
+		//this is in an isogenous curve
+		g1Isogeny(&trace.Q[i])
+	}
+	return trace, nil
 }
 
 func g1NotZero(x *fp.Element) uint64 {
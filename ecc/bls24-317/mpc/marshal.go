@@ -0,0 +1,88 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package mpc
+
+import (
+	"errors"
+	"io"
+
+	"github.com/consensys/gnark-crypto/ecc/bls24-317"
+)
+
+// WriteTo writes binary encoding of the state
+func (p *Phase1) WriteTo(w io.Writer) (int64, error) {
+	if len(p.Challenge) > 255 {
+		return 0, errors.New("challenge is too long")
+	}
+	n, err := w.Write(append([]byte{byte(len(p.Challenge))}, p.Challenge...))
+	if err != nil {
+		return int64(n), err
+	}
+
+	enc := bls24317.NewEncoder(w)
+
+	toEncode := []interface{}{
+		p.G1,
+		&p.G2[0],
+		&p.G2[1],
+		&p.PublicKey.SG,
+		&p.PublicKey.SXG,
+		&p.PublicKey.XR,
+	}
+
+	for _, v := range toEncode {
+		if err := enc.Encode(v); err != nil {
+			return int64(n) + enc.BytesWritten(), err
+		}
+	}
+
+	return int64(n) + enc.BytesWritten(), nil
+}
+
+// ReadFrom decodes the state from reader.
+func (p *Phase1) ReadFrom(r io.Reader) (int64, error) {
+	var l [1]byte
+	n, err := io.ReadFull(r, l[:])
+	if err != nil {
+		return int64(n), err
+	}
+	p.Challenge = make([]byte, l[0])
+	m, err := io.ReadFull(r, p.Challenge)
+	n += m
+	if err != nil {
+		return int64(n), err
+	}
+
+	dec := bls24317.NewDecoder(r)
+
+	toDecode := []interface{}{
+		&p.G1,
+		&p.G2[0],
+		&p.G2[1],
+		&p.PublicKey.SG,
+		&p.PublicKey.SXG,
+		&p.PublicKey.XR,
+	}
+
+	for _, v := range toDecode {
+		if err := dec.Decode(v); err != nil {
+			return int64(n) + dec.BytesRead(), err
+		}
+	}
+
+	return int64(n) + dec.BytesRead(), nil
+}
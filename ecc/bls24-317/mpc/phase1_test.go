@@ -0,0 +1,188 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package mpc
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls24-317/fr"
+	"github.com/consensys/gnark-crypto/ecc/bls24-317/fr/kzg"
+)
+
+func TestPhase1(t *testing.T) {
+	const size = 8
+
+	prev, err := InitPhase1(size)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// a few contributions
+	for i := 0; i < 3; i++ {
+		next := clonePhase1(prev)
+		if err := next.Contribute(); err != nil {
+			t.Fatal(err)
+		}
+		if err := next.Verify(prev); err != nil {
+			t.Fatal(err)
+		}
+		prev = next
+	}
+
+	// beacon
+	const nbIterations = 3
+	beacon := []byte("beacon")
+	next := clonePhase1(prev)
+	if err := next.ApplyBeacon(beacon, nbIterations); err != nil {
+		t.Fatal(err)
+	}
+	if err := next.Verify(prev); err != nil {
+		t.Fatal(err)
+	}
+	if err := next.VerifyBeacon(prev, beacon, nbIterations); err != nil {
+		t.Fatal(err)
+	}
+	if err := next.VerifyBeacon(prev, []byte("another beacon"), nbIterations); err != ErrBeacon {
+		t.Fatal("expected ErrBeacon")
+	}
+
+	// the output is a valid KZG SRS
+	srs := next.SRS()
+	pol := make([]fr.Element, size)
+	for i := range pol {
+		pol[i].SetRandom()
+	}
+	digest, err := kzg.Commit(pol, srs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var point fr.Element
+	point.SetRandom()
+	proof, err := kzg.Open(pol, point, srs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := kzg.Verify(&digest, &proof, point, srs); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestPhase1Invalid(t *testing.T) {
+	const size = 8
+
+	prev, err := InitPhase1(size)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := prev.Contribute(); err != nil {
+		t.Fatal(err)
+	}
+
+	contribute := func() *Phase1 {
+		next := clonePhase1(prev)
+		if err := next.Contribute(); err != nil {
+			t.Fatal(err)
+		}
+		return next
+	}
+
+	// not chained to the previous state
+	next := contribute()
+	next.Challenge[0] ^= 1
+	if err := next.Verify(prev); err != ErrChallenge {
+		t.Fatalf("expected ErrChallenge, got %v", err)
+	}
+
+	// inconsistent powers
+	next = contribute()
+	next.G1[3], next.G1[4] = next.G1[4], next.G1[3]
+	if err := next.Verify(prev); err != ErrPowers {
+		t.Fatalf("expected ErrPowers, got %v", err)
+	}
+
+	// inconsistent G2
+	next = contribute()
+	next.G2[1] = prev.G2[1]
+	if err := next.Verify(prev); err != ErrPowers {
+		t.Fatalf("expected ErrPowers, got %v", err)
+	}
+
+	// proof of knowledge of another contribution
+	next = contribute()
+	other := contribute()
+	next.PublicKey = other.PublicKey
+	if err := next.Verify(prev); err != ErrUpdate {
+		t.Fatalf("expected ErrUpdate, got %v", err)
+	}
+
+	// invalid proof of knowledge
+	next = contribute()
+	next.PublicKey.SXG = next.PublicKey.SG
+	if err := next.Verify(prev); err != ErrProofOfKnowledge {
+		t.Fatalf("expected ErrProofOfKnowledge, got %v", err)
+	}
+
+	// size mismatch
+	if _, err := InitPhase1(1); err != ErrMinSize {
+		t.Fatal("expected ErrMinSize")
+	}
+	next = contribute()
+	next.G1 = next.G1[:size-1]
+	if err := next.Verify(prev); err != ErrSizeMismatch {
+		t.Fatalf("expected ErrSizeMismatch, got %v", err)
+	}
+}
+
+func TestPhase1Serialization(t *testing.T) {
+	p, err := InitPhase1(8)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := p.Contribute(); err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	written, err := p.WriteTo(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var q Phase1
+	read, err := q.ReadFrom(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if written != read {
+		t.Fatal("bytes written and read don't match")
+	}
+	if !bytes.Equal(p.Hash(), q.Hash()) {
+		t.Fatal("deserialized state doesn't match")
+	}
+}
+
+func clonePhase1(p *Phase1) *Phase1 {
+	var buf bytes.Buffer
+	if _, err := p.WriteTo(&buf); err != nil {
+		panic(err)
+	}
+	var r Phase1
+	if _, err := r.ReadFrom(&buf); err != nil {
+		panic(err)
+	}
+	return &r
+}
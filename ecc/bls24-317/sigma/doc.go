@@ -0,0 +1,28 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+// Package sigma provides non-interactive σ-protocols on bls24-317's G1.
+//
+// A Statement is a system of linear relations Yⱼ = ∑ᵢ xᵢGⱼᵢ between public points,
+// where the xᵢ are the secret witness. Usual proofs are special cases:
+//   - knowledge of a discrete logarithm (Schnorr): Y = xG
+//   - equality of discrete logarithms (Chaum-Pedersen): Y₁ = xG₁, Y₂ = xG₂
+//   - knowledge of a representation: Y = ∑ᵢ xᵢGᵢ
+//
+// Statements can be composed with And, and proofs are made non-interactive
+// with a Fiat-Shamir transcript. Several proofs can be verified at once with
+// BatchVerify.
+package sigma
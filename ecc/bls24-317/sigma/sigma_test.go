@@ -0,0 +1,156 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package sigma
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls24-317"
+	"github.com/consensys/gnark-crypto/ecc/bls24-317/fr"
+)
+
+// randomPoints returns n random points of G1 and their discrete logarithms
+func randomPoints(n int) ([]bls24317.G1Affine, []fr.Element) {
+	_, _, g, _ := bls24317.Generators()
+	points := make([]bls24317.G1Affine, n)
+	logs := make([]fr.Element, n)
+	var b big.Int
+	for i := 0; i < n; i++ {
+		logs[i].SetRandom()
+		points[i].ScalarMultiplication(&g, logs[i].ToBigIntRegular(&b))
+	}
+	return points, logs
+}
+
+func TestDLog(t *testing.T) {
+
+	_, _, g, _ := bls24317.Generators()
+	y, x := randomPoints(1)
+	statement := NewDLog(g, y[0])
+
+	proof, err := Prove(&statement, x, []byte("context"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = Verify(&statement, &proof, []byte("context")); err != nil {
+		t.Fatal(err)
+	}
+
+	// wrong context
+	if err = Verify(&statement, &proof, []byte("other context")); err != ErrVerification {
+		t.Fatal("expected ErrVerification")
+	}
+
+	// wrong witness
+	x[0].SetRandom()
+	if _, err = Prove(&statement, x, nil); err != ErrInvalidWitness {
+		t.Fatal("expected ErrInvalidWitness")
+	}
+}
+
+func TestDLEQ(t *testing.T) {
+
+	bases, _ := randomPoints(2)
+	var x fr.Element
+	var b big.Int
+	x.SetRandom()
+	x.ToBigIntRegular(&b)
+	var y1, y2 bls24317.G1Affine
+	y1.ScalarMultiplication(&bases[0], &b)
+	y2.ScalarMultiplication(&bases[1], &b)
+	statement := NewDLEQ(bases[0], y1, bases[1], y2)
+
+	proof, err := Prove(&statement, []fr.Element{x}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = Verify(&statement, &proof, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	// not a DH tuple
+	y2.Add(&y2, &bases[1])
+	statement = NewDLEQ(bases[0], y1, bases[1], y2)
+	if err = Verify(&statement, &proof, nil); err != ErrVerification {
+		t.Fatal("expected ErrVerification")
+	}
+}
+
+func TestRepresentationAnd(t *testing.T) {
+
+	bases, _ := randomPoints(3)
+	witness := make([]fr.Element, 3)
+	for i := range witness {
+		witness[i].SetRandom()
+	}
+	var y bls24317.G1Affine
+	var b big.Int
+	var tmp bls24317.G1Affine
+	for i := range bases {
+		tmp.ScalarMultiplication(&bases[i], witness[i].ToBigIntRegular(&b))
+		y.Add(&y, &tmp)
+	}
+	representation := NewRepresentation(bases, y)
+
+	_, _, g, _ := bls24317.Generators()
+	ys, xs := randomPoints(1)
+	dlog := NewDLog(g, ys[0])
+
+	statement := And(representation, dlog)
+	proof, err := Prove(&statement, append(witness, xs...), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = Verify(&statement, &proof, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	// wrong response
+	proof.Responses[3].SetRandom()
+	if err = Verify(&statement, &proof, nil); err != ErrVerification {
+		t.Fatal("expected ErrVerification")
+	}
+}
+
+func TestBatchVerify(t *testing.T) {
+
+	const n = 8
+	_, _, g, _ := bls24317.Generators()
+	ys, xs := randomPoints(n)
+	statements := make([]Statement, n)
+	proofs := make([]Proof, n)
+	contexts := make([][]byte, n)
+	for i := 0; i < n; i++ {
+		statements[i] = NewDLog(g, ys[i])
+		contexts[i] = []byte{byte(i)}
+		var err error
+		proofs[i], err = Prove(&statements[i], xs[i:i+1], contexts[i])
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := BatchVerify(statements, proofs, contexts); err != nil {
+		t.Fatal(err)
+	}
+
+	// swap two proofs
+	proofs[0], proofs[1] = proofs[1], proofs[0]
+	if err := BatchVerify(statements, proofs, contexts); err != ErrVerification {
+		t.Fatal("expected ErrVerification")
+	}
+}
@@ -0,0 +1,96 @@
+//go:build dudect
+// +build dudect
+
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package ecies
+
+import (
+	"bytes"
+	"crypto/rand"
+	"io"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls24-317/fr"
+	"github.com/consensys/gnark-crypto/internal/dudect"
+)
+
+// the timing tests compare a fixed secret scalar (class 0) with random ones (class 1); the
+// randomness is drawn for both classes, so that the preparation of the inputs does not bias
+// the measurements
+
+// scalarReader returns a reader of the randomness of GenerateKey. The first byte is 0, so that
+// rand.Int never rejects the sample, which would add a variable number of reads.
+func scalarReader(t *testing.T, class int) io.Reader {
+	b := make([]byte, fr.Bytes)
+	if _, err := rand.Read(b); err != nil {
+		t.Fatal(err)
+	}
+	if class == 0 {
+		for i := range b {
+			b[i] = 0x5a
+		}
+	}
+	b[0] = 0
+	return bytes.NewReader(b)
+}
+
+func TestTimingGenerateKey(t *testing.T) {
+	var r io.Reader
+	prepare := func(class int) {
+		r = scalarReader(t, class)
+	}
+	dudect.Assert(t, dudect.Config{Samples: 10000}, prepare, func() {
+		_, _ = GenerateKey(r)
+	})
+}
+
+func TestTimingEncrypt(t *testing.T) {
+	priv, err := GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	msg := []byte("the quick brown fox jumps over the lazy dog")
+
+	var r io.Reader
+	prepare := func(class int) {
+		r = scalarReader(t, class)
+	}
+	dudect.Assert(t, dudect.Config{Samples: 5000}, prepare, func() {
+		_, _ = Encrypt(r, &priv.PublicKey, msg, nil)
+	})
+}
+
+func TestTimingDecrypt(t *testing.T) {
+	msg := []byte("the quick brown fox jumps over the lazy dog")
+
+	// the ciphertext is encrypted for the key of the class, so that the decryption succeeds
+	var priv *PrivateKey
+	var ciphertext []byte
+	prepare := func(class int) {
+		var err error
+		if priv, err = GenerateKey(scalarReader(t, class)); err != nil {
+			t.Fatal(err)
+		}
+		if ciphertext, err = Encrypt(rand.Reader, &priv.PublicKey, msg, nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+	dudect.Assert(t, dudect.Config{Samples: 5000}, prepare, func() {
+		_, _ = priv.Decrypt(ciphertext, nil)
+	})
+}
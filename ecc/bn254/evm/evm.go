@@ -0,0 +1,212 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package evm implements the bn254 precompiled contracts of the EVM (EIP-196 and EIP-197),
+// with the encodings and validation rules of go-ethereum.
+//
+// Points are encoded uncompressed, each coordinate on 32 bytes in big-endian, and the point at
+// infinity is encoded with zeroes. Coordinates must be strictly smaller than the modulus, points
+// must be on the curve and, in G₂, in the subgroup of order r. An 𝔽p² element a₀+a₁u is
+// encoded as a₁ || a₀.
+//
+// Contrary to the encodings of the bn254 package, there are no metadata bits and no
+// compressed form.
+package evm
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fp"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+)
+
+const (
+	// SizeOfG1 size of a G₁ point: x || y
+	SizeOfG1 = 2 * fp.Bytes
+	// SizeOfG2 size of a G₂ point: x.A1 || x.A0 || y.A1 || y.A0
+	SizeOfG2 = 4 * fp.Bytes
+	// SizeOfScalar size of a scalar of ECMUL, any 256-bit big-endian integer, reduced mod r
+	// before the multiplication
+	SizeOfScalar = 32
+	// SizeOfAddInput size of the input of ECADD (0x06): two G₁ points
+	SizeOfAddInput = 2 * SizeOfG1
+	// SizeOfMulInput size of the input of ECMUL (0x07): a G₁ point and a scalar
+	SizeOfMulInput = SizeOfG1 + SizeOfScalar
+	// SizeOfPair size of a pair of the input of ECPAIRING (0x08): a G₁ and a G₂ point
+	SizeOfPair = SizeOfG1 + SizeOfG2
+)
+
+// errors returned by the precompiles, with the messages of go-ethereum
+var (
+	ErrCoordinateExceedsModulus = errors.New("bn256: coordinate exceeds modulus")
+	ErrMalformedPoint           = errors.New("bn256: malformed point")
+	ErrBadPairingInput          = errors.New("bad elliptic curve pairing size")
+)
+
+// UnmarshalG1 decodes a G₁ point from SizeOfG1 bytes, (0, 0) being the point at infinity.
+func UnmarshalG1(buf []byte) (bn254.G1Affine, error) {
+	var p bn254.G1Affine
+	if len(buf) != SizeOfG1 {
+		return p, ErrMalformedPoint
+	}
+	if err := setCoordinate(&p.X, buf[:fp.Bytes]); err != nil {
+		return p, err
+	}
+	if err := setCoordinate(&p.Y, buf[fp.Bytes:]); err != nil {
+		return p, err
+	}
+	if !p.IsInfinity() && !p.IsOnCurve() {
+		return p, ErrMalformedPoint
+	}
+	return p, nil
+}
+
+// UnmarshalG2 decodes a G₂ point from SizeOfG2 bytes, (0, 0) being the point at infinity.
+// The point must be in the subgroup of order r.
+func UnmarshalG2(buf []byte) (bn254.G2Affine, error) {
+	var p bn254.G2Affine
+	if len(buf) != SizeOfG2 {
+		return p, ErrMalformedPoint
+	}
+	for i, c := range []*fp.Element{&p.X.A1, &p.X.A0, &p.Y.A1, &p.Y.A0} {
+		if err := setCoordinate(c, buf[i*fp.Bytes:(i+1)*fp.Bytes]); err != nil {
+			return p, err
+		}
+	}
+	if !p.IsInfinity() && (!p.IsOnCurve() || !p.IsInSubGroup()) {
+		return p, ErrMalformedPoint
+	}
+	return p, nil
+}
+
+// MarshalG1 encodes p on SizeOfG1 bytes
+func MarshalG1(p *bn254.G1Affine) (res [SizeOfG1]byte) {
+	if p.IsInfinity() {
+		return
+	}
+	x, y := p.X.Bytes(), p.Y.Bytes()
+	copy(res[:fp.Bytes], x[:])
+	copy(res[fp.Bytes:], y[:])
+	return
+}
+
+// MarshalG2 encodes p on SizeOfG2 bytes
+func MarshalG2(p *bn254.G2Affine) (res [SizeOfG2]byte) {
+	if p.IsInfinity() {
+		return
+	}
+	for i, c := range []*fp.Element{&p.X.A1, &p.X.A0, &p.Y.A1, &p.Y.A0} {
+		b := c.Bytes()
+		copy(res[i*fp.Bytes:], b[:])
+	}
+	return
+}
+
+// Add implements the precompile ECADD (0x06). input is right-padded with zeroes to
+// SizeOfAddInput bytes, and the bytes beyond are ignored.
+func Add(input []byte) ([]byte, error) {
+	input = rightPad(input, SizeOfAddInput)
+	a, err := UnmarshalG1(input[:SizeOfG1])
+	if err != nil {
+		return nil, err
+	}
+	b, err := UnmarshalG1(input[SizeOfG1:SizeOfAddInput])
+	if err != nil {
+		return nil, err
+	}
+
+	var res bn254.G1Jac
+	res.FromAffine(&a).AddMixed(&b)
+	a.FromJacobian(&res)
+	out := MarshalG1(&a)
+	return out[:], nil
+}
+
+// ScalarMul implements the precompile ECMUL (0x07). input is right-padded with zeroes to
+// SizeOfMulInput bytes, and the bytes beyond are ignored. The scalar is any 256-bit integer.
+func ScalarMul(input []byte) ([]byte, error) {
+	input = rightPad(input, SizeOfMulInput)
+	p, err := UnmarshalG1(input[:SizeOfG1])
+	if err != nil {
+		return nil, err
+	}
+
+	var s big.Int
+	s.SetBytes(input[SizeOfG1:SizeOfMulInput])
+	s.Mod(&s, fr.Modulus())
+	p.ScalarMultiplication(&p, &s)
+	out := MarshalG1(&p)
+	return out[:], nil
+}
+
+// PairingCheck implements the precompile ECPAIRING (0x08). input is a sequence of pairs of
+// G₁ and G₂ points, and the output is 1 on 32 bytes if the product of the pairings is 1,
+// 0 otherwise. An empty input is valid, and returns 1.
+func PairingCheck(input []byte) ([]byte, error) {
+	if len(input)%SizeOfPair != 0 {
+		return nil, ErrBadPairingInput
+	}
+	n := len(input) / SizeOfPair
+	P := make([]bn254.G1Affine, n)
+	Q := make([]bn254.G2Affine, n)
+	for i := 0; i < n; i++ {
+		var err error
+		pair := input[i*SizeOfPair : (i+1)*SizeOfPair]
+		if P[i], err = UnmarshalG1(pair[:SizeOfG1]); err != nil {
+			return nil, err
+		}
+		if Q[i], err = UnmarshalG2(pair[SizeOfG1:]); err != nil {
+			return nil, err
+		}
+	}
+
+	out := make([]byte, 32)
+	if n == 0 {
+		out[31] = 1
+		return out, nil
+	}
+	ok, err := bn254.PairingCheck(P, Q)
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		out[31] = 1
+	}
+	return out, nil
+}
+
+// setCoordinate sets z from a big-endian encoding, which must be strictly smaller than p
+func setCoordinate(z *fp.Element, buf []byte) error {
+	var v big.Int
+	v.SetBytes(buf)
+	if v.Cmp(fpModulus) >= 0 {
+		return ErrCoordinateExceedsModulus
+	}
+	z.SetBigInt(&v)
+	return nil
+}
+
+// rightPad returns buf, right-padded with zeroes to size bytes
+func rightPad(buf []byte, size int) []byte {
+	if len(buf) >= size {
+		return buf
+	}
+	res := make([]byte, size)
+	copy(res, buf)
+	return res
+}
+
+var fpModulus = fp.Modulus()
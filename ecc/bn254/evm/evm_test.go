@@ -0,0 +1,293 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package evm
+
+import (
+	"bytes"
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fp"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/consensys/gnark-crypto/ecc/bn254/internal/fptower"
+)
+
+func decodeHex(t *testing.T, s string) []byte {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+func TestEncoding(t *testing.T) {
+	_, _, g1, g2 := bn254.Generators()
+
+	// G₁ = (1, 2)
+	b := MarshalG1(&g1)
+	expected := decodeHex(t, "0000000000000000000000000000000000000000000000000000000000000001"+
+		"0000000000000000000000000000000000000000000000000000000000000002")
+	if !bytes.Equal(b[:], expected) {
+		t.Fatal("wrong G1 encoding")
+	}
+
+	// G₂ as in EIP-197: x = 11559732032986387107991004021392285783925812861821192530917403151452391805634*u
+	// + 10857046999023057135944570762232829481370756359578518086990519993285655852781 ...
+	c := MarshalG2(&g2)
+	for i, s := range []string{
+		"11559732032986387107991004021392285783925812861821192530917403151452391805634",
+		"10857046999023057135944570762232829481370756359578518086990519993285655852781",
+		"4082367875863433681332203403145435568316851327593401208105741076214120093531",
+		"8495653923123431417604973247489272438418190587263600148770280649306958101930",
+	} {
+		v, _ := new(big.Int).SetString(s, 10)
+		if new(big.Int).SetBytes(c[i*32:(i+1)*32]).Cmp(v) != 0 {
+			t.Fatal("wrong G2 encoding")
+		}
+	}
+
+	// round trip
+	p, err := UnmarshalG1(b[:])
+	if err != nil || !p.Equal(&g1) {
+		t.Fatal("G1 round trip failed")
+	}
+	q, err := UnmarshalG2(c[:])
+	if err != nil || !q.Equal(&g2) {
+		t.Fatal("G2 round trip failed")
+	}
+
+	// point at infinity
+	if p, err := UnmarshalG1(make([]byte, SizeOfG1)); err != nil || !p.IsInfinity() {
+		t.Fatal("expected the point at infinity")
+	}
+	if q, err := UnmarshalG2(make([]byte, SizeOfG2)); err != nil || !q.IsInfinity() {
+		t.Fatal("expected the point at infinity")
+	}
+	var infinity bn254.G1Affine
+	if b := MarshalG1(&infinity); !bytes.Equal(b[:], make([]byte, SizeOfG1)) {
+		t.Fatal("wrong encoding of the point at infinity")
+	}
+}
+
+func TestValidation(t *testing.T) {
+	_, _, g1, g2 := bn254.Generators()
+
+	// coordinates ≥ p
+	b := MarshalG1(&g1)
+	fp.Modulus().FillBytes(b[:fp.Bytes])
+	if _, err := UnmarshalG1(b[:]); err != ErrCoordinateExceedsModulus {
+		t.Fatal("expected ErrCoordinateExceedsModulus")
+	}
+	// 1+p, 2 is G₁ once reduced
+	x := new(big.Int).Add(fp.Modulus(), big.NewInt(1))
+	x.FillBytes(b[:fp.Bytes])
+	if _, err := UnmarshalG1(b[:]); err != ErrCoordinateExceedsModulus {
+		t.Fatal("expected ErrCoordinateExceedsModulus")
+	}
+	c := MarshalG2(&g2)
+	fp.Modulus().FillBytes(c[3*fp.Bytes:])
+	if _, err := UnmarshalG2(c[:]); err != ErrCoordinateExceedsModulus {
+		t.Fatal("expected ErrCoordinateExceedsModulus")
+	}
+
+	// not on the curve
+	b = MarshalG1(&g1)
+	b[2*fp.Bytes-1] = 3
+	if _, err := UnmarshalG1(b[:]); err != ErrMalformedPoint {
+		t.Fatal("expected ErrMalformedPoint")
+	}
+	b = [SizeOfG1]byte{}
+	b[2*fp.Bytes-1] = 1 // (0, 1)
+	if _, err := UnmarshalG1(b[:]); err != ErrMalformedPoint {
+		t.Fatal("expected ErrMalformedPoint")
+	}
+	c = MarshalG2(&g2)
+	c[SizeOfG2-1] ^= 1
+	if _, err := UnmarshalG2(c[:]); err != ErrMalformedPoint {
+		t.Fatal("expected ErrMalformedPoint")
+	}
+
+	// on the twist, but not in the subgroup
+	q := twistPointNotInSubGroup()
+	c = MarshalG2(&q)
+	if _, err := UnmarshalG2(c[:]); err != ErrMalformedPoint {
+		t.Fatal("expected ErrMalformedPoint (subgroup)")
+	}
+
+	// pairing input size
+	if _, err := PairingCheck(make([]byte, SizeOfPair+1)); err != ErrBadPairingInput {
+		t.Fatal("expected ErrBadPairingInput")
+	}
+}
+
+// twistPointNotInSubGroup returns a point on the twist y² = x³ + 3/(9+u) which is not in G₂
+func twistPointNotInSubGroup() bn254.G2Affine {
+	var b, twist fptower.E2
+	b.A0.SetUint64(3)
+	twist.A0.SetUint64(9)
+	twist.A1.SetUint64(1)
+	twist.Inverse(&twist)
+	b.Mul(&b, &twist)
+
+	var p bn254.G2Affine
+	for x := uint64(1); ; x++ {
+		var y2 fptower.E2
+		p.X.A0.SetUint64(x)
+		y2.Square(&p.X).Mul(&y2, &p.X).Add(&y2, &b)
+		if y2.Legendre() != 1 {
+			continue
+		}
+		p.Y.Sqrt(&y2)
+		if p.IsOnCurve() && !p.IsInSubGroup() {
+			return p
+		}
+	}
+}
+
+func TestAdd(t *testing.T) {
+	_, _, g1, _ := bn254.Generators()
+	g := MarshalG1(&g1)
+
+	// 2G₁
+	expected := decodeHex(t, "030644e72e131a029b85045b68181585d97816a916871ca8d3c208c16d87cfd3"+
+		"15ed738c0e0a7c92e7845f96b2ae9c0a68a6a449e3538fc7ff3ebf7a5a18a2c4")
+	out, err := Add(append(g[:], g[:]...))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(out, expected) {
+		t.Fatal("wrong sum")
+	}
+
+	// G₁ + 0, with a short input
+	out, err = Add(g[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(out, g[:]) {
+		t.Fatal("wrong sum with the point at infinity")
+	}
+
+	// G₁ - G₁, with extra bytes
+	var neg bn254.G1Affine
+	neg.Neg(&g1)
+	n := MarshalG1(&neg)
+	out, err = Add(append(append(g[:], n[:]...), 0xff))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(out, make([]byte, SizeOfG1)) {
+		t.Fatal("expected the point at infinity")
+	}
+
+	// empty input
+	out, err = Add(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(out, make([]byte, SizeOfG1)) {
+		t.Fatal("expected the point at infinity")
+	}
+}
+
+func TestScalarMul(t *testing.T) {
+	_, _, g1, _ := bn254.Generators()
+	g := MarshalG1(&g1)
+
+	// [2]G₁
+	var s [SizeOfScalar]byte
+	s[SizeOfScalar-1] = 2
+	out, err := ScalarMul(append(g[:], s[:]...))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected, err := Add(append(g[:], g[:]...))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(out, expected) {
+		t.Fatal("wrong scalar multiplication")
+	}
+
+	// [r+2]G₁ = [2]G₁, scalars are not reduced
+	new(big.Int).Add(fr.Modulus(), big.NewInt(2)).FillBytes(s[:])
+	out, err = ScalarMul(append(g[:], s[:]...))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(out, expected) {
+		t.Fatal("wrong scalar multiplication")
+	}
+
+	// [0]G₁, with a short input
+	out, err = ScalarMul(g[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(out, make([]byte, SizeOfG1)) {
+		t.Fatal("expected the point at infinity")
+	}
+}
+
+func TestPairingCheck(t *testing.T) {
+	_, _, g1, g2 := bn254.Generators()
+	var neg bn254.G1Affine
+	neg.Neg(&g1)
+
+	pair := func(p *bn254.G1Affine, q *bn254.G2Affine) []byte {
+		a, b := MarshalG1(p), MarshalG2(q)
+		return append(a[:], b[:]...)
+	}
+	one := make([]byte, 32)
+	one[31] = 1
+
+	// e(G₁, G₂)e(-G₁, G₂) = 1
+	out, err := PairingCheck(append(pair(&g1, &g2), pair(&neg, &g2)...))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(out, one) {
+		t.Fatal("pairing check should succeed")
+	}
+
+	// e(G₁, G₂) ≠ 1
+	out, err = PairingCheck(pair(&g1, &g2))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(out, make([]byte, 32)) {
+		t.Fatal("pairing check should fail")
+	}
+
+	// empty input, and pairs with the point at infinity
+	var infinity bn254.G2Affine
+	for _, input := range [][]byte{nil, pair(&g1, &infinity)} {
+		out, err = PairingCheck(input)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(out, one) {
+			t.Fatal("pairing check should succeed")
+		}
+	}
+
+	// G₂ point not in the subgroup
+	q := twistPointNotInSubGroup()
+	if _, err := PairingCheck(pair(&g1, &q)); err != ErrMalformedPoint {
+		t.Fatal("expected ErrMalformedPoint")
+	}
+}
@@ -149,25 +149,36 @@ func MapToG2(u fptower.E2) G2Affine {
 	return res
 }
 
+// G2HashTrace holds the intermediate values of HashToG2 and EncodeToG2,
+// named as in the test vectors of RFC 9380 (appendix J), to compare an implementation
+// or debug the handling of a DST against them.
+type G2HashTrace struct {
+	// U is the output of hash_to_field: one element for EncodeToG2, two for HashToG2
+	U []fptower.E2
+
+	// Q[i] is map_to_curve(U[i]), before clearing the cofactor
+	Q []G2Affine
+}
+
 // EncodeToG2 hashes a message to a point on the G2 curve using the SVDW map.
 // It is faster than HashToG2, but the result is not uniformly distributed. Unsuitable as a random oracle.
 // dst stands for "domain separation tag", a string unique to the construction using the hash function
 //https://www.ietf.org/archive/id/draft-irtf-cfrg-hash-to-curve-16.html#roadmap
 func EncodeToG2(msg, dst []byte) (G2Affine, error) {
+	res, _, err := EncodeToG2Trace(msg, dst)
+	return res, err
+}
 
-	var res G2Affine
-	u, err := hashToFp(msg, dst, 2)
+// EncodeToG2Trace is EncodeToG2, which also returns its intermediate values.
+func EncodeToG2Trace(msg, dst []byte) (G2Affine, G2HashTrace, error) {
+	trace, err := g2HashToCurveTrace(msg, dst, 1)
 	if err != nil {
-		return res, err
+		return G2Affine{}, trace, err
 	}
 
-	res = mapToCurve2(&fptower.E2{
-		A0: u[0],
-		A1: u[1],
-	})
-
+	res := trace.Q[0]
 	res.ClearCofactor(&res)
-	return res, nil
+	return res, trace, nil
 }
 
 // HashToG2 hashes a message to a point on the G2 curve using the SVDW map.
@@ -175,28 +186,45 @@ func EncodeToG2(msg, dst []byte) (G2Affine, error) {
 // dst stands for "domain separation tag", a string unique to the construction using the hash function
 //https://www.ietf.org/archive/id/draft-irtf-cfrg-hash-to-curve-16.html#roadmap
 func HashToG2(msg, dst []byte) (G2Affine, error) {
-	u, err := hashToFp(msg, dst, 2*2)
+	res, _, err := HashToG2Trace(msg, dst)
+	return res, err
+}
+
+// HashToG2Trace is HashToG2, which also returns its intermediate values.
+func HashToG2Trace(msg, dst []byte) (G2Affine, G2HashTrace, error) {
+	trace, err := g2HashToCurveTrace(msg, dst, 2)
 	if err != nil {
-		return G2Affine{}, err
+		return G2Affine{}, trace, err
 	}
-
-	Q0 := mapToCurve2(&fptower.E2{
-		A0: u[0],
-		A1: u[1],
-	})
-	Q1 := mapToCurve2(&fptower.E2{
-		A0: u[2+0],
-		A1: u[2+1],
-	})
-
 	var _Q0, _Q1 G2Jac
-	_Q0.FromAffine(&Q0)
-	_Q1.FromAffine(&Q1).AddAssign(&_Q0)
+	_Q0.FromAffine(&trace.Q[0])
+	_Q1.FromAffine(&trace.Q[1]).AddAssign(&_Q0)
 
 	_Q1.ClearCofactor(&_Q1)
 
-	Q1.FromJacobian(&_Q1)
-	return Q1, nil
+	var res G2Affine
+	res.FromJacobian(&_Q1)
+	return res, trace, nil
+}
+
+// g2HashToCurveTrace hashes msg to count elements of the base field of G2, and maps them to the curve
+func g2HashToCurveTrace(msg, dst []byte, count int) (G2HashTrace, error) {
+	var trace G2HashTrace
+	u, err := hashToFp(msg, dst, count*2)
+	if err != nil {
+		return trace, err
+	}
+
+	trace.U = make([]fptower.E2, count)
+	trace.Q = make([]G2Affine, count)
+	for i := 0; i < count; i++ {
+		trace.U[i] = fptower.E2{
+			A0: u[i*2+0],
+			A1: u[i*2+1],
+		}
+		trace.Q[i] = mapToCurve2(&trace.U[i])
+	}
+	return trace, nil
 }
 
 func g2NotZero(x *fptower.E2) uint64 {
@@ -145,6 +145,40 @@ func TestHashToG2(t *testing.T) {
 	}
 }
 
+func TestEncodeToG2Trace(t *testing.T) {
+	t.Parallel()
+	for _, c := range encodeToG2Vector.cases {
+		p, trace, err := EncodeToG2Trace([]byte(c.msg), encodeToG2Vector.dst)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(trace.U) != 1 || len(trace.Q) != 1 {
+			t.Fatal("expected one field element and one point in the trace")
+		}
+		g2TestMatchCoord(t, "u", c.msg, c.u, trace.U[0])
+		g2TestMatchPoint(t, "Q", c.msg, c.Q, &trace.Q[0])
+		g2TestMatchPoint(t, "P", c.msg, c.P, &p)
+	}
+}
+
+func TestHashToG2Trace(t *testing.T) {
+	t.Parallel()
+	for _, c := range hashToG2Vector.cases {
+		p, trace, err := HashToG2Trace([]byte(c.msg), hashToG2Vector.dst)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(trace.U) != 2 || len(trace.Q) != 2 {
+			t.Fatal("expected two field elements and two points in the trace")
+		}
+		g2TestMatchCoord(t, "u0", c.msg, c.u0, trace.U[0])
+		g2TestMatchCoord(t, "u1", c.msg, c.u1, trace.U[1])
+		g2TestMatchPoint(t, "Q0", c.msg, c.Q0, &trace.Q[0])
+		g2TestMatchPoint(t, "Q1", c.msg, c.Q1, &trace.Q[1])
+		g2TestMatchPoint(t, "P", c.msg, c.P, &p)
+	}
+}
+
 func BenchmarkEncodeToG2(b *testing.B) {
 	const size = 54
 	bytes := make([]byte, size)
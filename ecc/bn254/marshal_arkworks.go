@@ -0,0 +1,622 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package bn254
+
+import (
+	"encoding/binary"
+	"errors"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fp"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/consensys/gnark-crypto/ecc/bn254/internal/fptower"
+	"github.com/consensys/gnark-crypto/internal/parallel"
+	"io"
+	"reflect"
+	"sync/atomic"
+)
+
+// The arkworks encoding mode matches the CanonicalSerialize / CanonicalDeserialize
+// implementations of the arkworks Rust libraries (ark-bn254), so that objects can be
+// exchanged between the two implementations:
+//
+//   - field elements are in regular form, little-endian
+//   - slices are prefixed with their length as a little-endian uint64, as is uint64
+//   - points are encoded as their little-endian coordinates (c0 first for extension fields), and
+//     flags are stored in the 2 most significant bits of the last byte: 0b01 for the point at
+//     infinity and 0b10 if Y is lexicographically largest. In compressed form only X is encoded.
+//
+// Non-canonical field elements are rejected.
+const (
+	mArkworksMask     byte = 0b11 << 6
+	mArkworksInfinity byte = 0b01 << 6
+	mArkworksLargest  byte = 0b10 << 6
+)
+
+// ArkworksEncoding returns an option to use in NewEncoder(...) which sets the encoding
+// to match arkworks' CanonicalSerialize with Compress::Yes (compress == true) or
+// Compress::No. It takes precedence over RawEncoding.
+func ArkworksEncoding(compress bool) func(*Encoder) {
+	return func(enc *Encoder) {
+		enc.arkworks = true
+		enc.raw = !compress
+	}
+}
+
+// ArkworksDecoding returns an option to use in NewDecoder(...) which sets the decoding
+// to match arkworks' CanonicalDeserialize with Compress::Yes (compress == true) or
+// Compress::No.
+func ArkworksDecoding(compress bool) func(*Decoder) {
+	return func(dec *Decoder) {
+		dec.arkworks = true
+		dec.arkworksCompressed = compress
+	}
+}
+
+func (enc *Encoder) encodeArkworks(v interface{}) (err error) {
+	rv := reflect.ValueOf(v)
+	if v == nil || (rv.Kind() == reflect.Ptr && rv.IsNil()) {
+		return errors.New("bn254 encoder: can't encode <nil>")
+	}
+
+	var written int
+	switch t := v.(type) {
+	case uint64:
+		var buf [8]byte
+		binary.LittleEndian.PutUint64(buf[:], t)
+		written, err = enc.w.Write(buf[:])
+		enc.n += int64(written)
+		return
+	case *fr.Element:
+		buf := frToArkworks(t)
+		written, err = enc.w.Write(buf[:])
+		enc.n += int64(written)
+		return
+	case *fp.Element:
+		var buf [fp.Bytes]byte
+		putFpArkworks(buf[:], t)
+		written, err = enc.w.Write(buf[:])
+		enc.n += int64(written)
+		return
+	case *G1Affine:
+		return enc.writeArkworksG1(t)
+	case *G2Affine:
+		return enc.writeArkworksG2(t)
+	case []fr.Element:
+		if err = enc.encodeArkworks(uint64(len(t))); err != nil {
+			return
+		}
+		for i := 0; i < len(t); i++ {
+			buf := frToArkworks(&t[i])
+			written, err = enc.w.Write(buf[:])
+			enc.n += int64(written)
+			if err != nil {
+				return
+			}
+		}
+		return nil
+	case []fp.Element:
+		if err = enc.encodeArkworks(uint64(len(t))); err != nil {
+			return
+		}
+		var buf [fp.Bytes]byte
+		for i := 0; i < len(t); i++ {
+			putFpArkworks(buf[:], &t[i])
+			written, err = enc.w.Write(buf[:])
+			enc.n += int64(written)
+			if err != nil {
+				return
+			}
+		}
+		return nil
+	case []G1Affine:
+		if err = enc.encodeArkworks(uint64(len(t))); err != nil {
+			return
+		}
+		for i := 0; i < len(t); i++ {
+			if err = enc.writeArkworksG1(&t[i]); err != nil {
+				return
+			}
+		}
+		return nil
+	case []G2Affine:
+		if err = enc.encodeArkworks(uint64(len(t))); err != nil {
+			return
+		}
+		for i := 0; i < len(t); i++ {
+			if err = enc.writeArkworksG2(&t[i]); err != nil {
+				return
+			}
+		}
+		return nil
+	default:
+		n := binary.Size(t)
+		if n == -1 {
+			return errors.New("bn254 encoder: unsupported type")
+		}
+		err = binary.Write(enc.w, binary.LittleEndian, t)
+		enc.n += int64(n)
+		return
+	}
+}
+
+func (enc *Encoder) writeArkworksG1(p *G1Affine) (err error) {
+	var written int
+	if enc.raw {
+		buf := p.ArkworksRawBytes()
+		written, err = enc.w.Write(buf[:])
+	} else {
+		buf := p.ArkworksBytes()
+		written, err = enc.w.Write(buf[:])
+	}
+	enc.n += int64(written)
+	return
+}
+
+func (enc *Encoder) writeArkworksG2(p *G2Affine) (err error) {
+	var written int
+	if enc.raw {
+		buf := p.ArkworksRawBytes()
+		written, err = enc.w.Write(buf[:])
+	} else {
+		buf := p.ArkworksBytes()
+		written, err = enc.w.Write(buf[:])
+	}
+	enc.n += int64(written)
+	return
+}
+
+func (dec *Decoder) decodeArkworks(v interface{}) (err error) {
+	var read int
+	switch t := v.(type) {
+	case *uint64:
+		var buf [8]byte
+		read, err = io.ReadFull(dec.r, buf[:])
+		dec.n += int64(read)
+		if err != nil {
+			return
+		}
+		*t = binary.LittleEndian.Uint64(buf[:])
+		return
+	case *fr.Element:
+		var buf [fr.Bytes]byte
+		read, err = io.ReadFull(dec.r, buf[:])
+		dec.n += int64(read)
+		if err != nil {
+			return
+		}
+		return frFromArkworks(t, buf[:])
+	case *fp.Element:
+		var buf [fp.Bytes]byte
+		read, err = io.ReadFull(dec.r, buf[:])
+		dec.n += int64(read)
+		if err != nil {
+			return
+		}
+		return setFpArkworks(t, buf[:])
+	case *G1Affine:
+		buf := make([]byte, dec.arkworksSize(SizeOfG1AffineCompressed))
+		read, err = io.ReadFull(dec.r, buf)
+		dec.n += int64(read)
+		if err != nil {
+			return
+		}
+		_, err = t.setArkworksBytes(buf, dec.arkworksCompressed, dec.subGroupCheck)
+		return
+	case *G2Affine:
+		buf := make([]byte, dec.arkworksSize(SizeOfG2AffineCompressed))
+		read, err = io.ReadFull(dec.r, buf)
+		dec.n += int64(read)
+		if err != nil {
+			return
+		}
+		_, err = t.setArkworksBytes(buf, dec.arkworksCompressed, dec.subGroupCheck)
+		return
+	case *[]fr.Element:
+		var sliceLen int
+		if sliceLen, err = dec.readArkworksLen(); err != nil {
+			return
+		}
+		if len(*t) != sliceLen {
+			*t = make([]fr.Element, sliceLen)
+		}
+		var buf [fr.Bytes]byte
+		for i := 0; i < sliceLen; i++ {
+			read, err = io.ReadFull(dec.r, buf[:])
+			dec.n += int64(read)
+			if err != nil {
+				return
+			}
+			if err = frFromArkworks(&(*t)[i], buf[:]); err != nil {
+				return
+			}
+		}
+		return nil
+	case *[]fp.Element:
+		var sliceLen int
+		if sliceLen, err = dec.readArkworksLen(); err != nil {
+			return
+		}
+		if len(*t) != sliceLen {
+			*t = make([]fp.Element, sliceLen)
+		}
+		var buf [fp.Bytes]byte
+		for i := 0; i < sliceLen; i++ {
+			read, err = io.ReadFull(dec.r, buf[:])
+			dec.n += int64(read)
+			if err != nil {
+				return
+			}
+			if err = setFpArkworks(&(*t)[i], buf[:]); err != nil {
+				return
+			}
+		}
+		return nil
+	case *[]G1Affine:
+		var sliceLen int
+		if sliceLen, err = dec.readArkworksLen(); err != nil {
+			return
+		}
+		if len(*t) != sliceLen {
+			*t = make([]G1Affine, sliceLen)
+		}
+		size := dec.arkworksSize(SizeOfG1AffineCompressed)
+		buf := make([]byte, sliceLen*size)
+		read, err = io.ReadFull(dec.r, buf)
+		dec.n += int64(read)
+		if err != nil {
+			return
+		}
+		var nbErrs uint64
+		parallel.Execute(sliceLen, func(start, end int) {
+			for i := start; i < end; i++ {
+				if _, err := (*t)[i].setArkworksBytes(buf[i*size:(i+1)*size], dec.arkworksCompressed, dec.subGroupCheck); err != nil {
+					atomic.AddUint64(&nbErrs, 1)
+				}
+			}
+		})
+		if nbErrs != 0 {
+			return errors.New("point decompression failed")
+		}
+		return nil
+	case *[]G2Affine:
+		var sliceLen int
+		if sliceLen, err = dec.readArkworksLen(); err != nil {
+			return
+		}
+		if len(*t) != sliceLen {
+			*t = make([]G2Affine, sliceLen)
+		}
+		size := dec.arkworksSize(SizeOfG2AffineCompressed)
+		buf := make([]byte, sliceLen*size)
+		read, err = io.ReadFull(dec.r, buf)
+		dec.n += int64(read)
+		if err != nil {
+			return
+		}
+		var nbErrs uint64
+		parallel.Execute(sliceLen, func(start, end int) {
+			for i := start; i < end; i++ {
+				if _, err := (*t)[i].setArkworksBytes(buf[i*size:(i+1)*size], dec.arkworksCompressed, dec.subGroupCheck); err != nil {
+					atomic.AddUint64(&nbErrs, 1)
+				}
+			}
+		})
+		if nbErrs != 0 {
+			return errors.New("point decompression failed")
+		}
+		return nil
+	default:
+		n := binary.Size(t)
+		if n == -1 {
+			return errors.New("bn254 encoder: unsupported type")
+		}
+		err = binary.Read(dec.r, binary.LittleEndian, t)
+		if err == nil {
+			dec.n += int64(n)
+		}
+		return
+	}
+}
+
+// arkworksSize returns the size of a point whose compressed size is compressedSize
+func (dec *Decoder) arkworksSize(compressedSize int) int {
+	if dec.arkworksCompressed {
+		return compressedSize
+	}
+	return 2 * compressedSize
+}
+
+// readArkworksLen reads a slice length, encoded as a little-endian uint64
+func (dec *Decoder) readArkworksLen() (int, error) {
+	var l uint64
+	if err := dec.decodeArkworks(&l); err != nil {
+		return 0, err
+	}
+	// reject lengths that can't be allocated, from a malformed input
+	if l > 1<<40 {
+		return 0, errors.New("invalid slice length")
+	}
+	return int(l), nil
+}
+
+// frToArkworks returns the little-endian encoding of e, in regular form
+func frToArkworks(e *fr.Element) (res [fr.Bytes]byte) {
+	b := e.Bytes()
+	for i := 0; i < fr.Bytes; i++ {
+		res[i] = b[fr.Bytes-1-i]
+	}
+	return
+}
+
+// frFromArkworks sets e from the little-endian encoding in buf, and fails if it is not canonical
+func frFromArkworks(e *fr.Element, buf []byte) error {
+	var b [fr.Bytes]byte
+	for i := 0; i < fr.Bytes; i++ {
+		b[i] = buf[fr.Bytes-1-i]
+	}
+	e.SetBytes(b[:])
+	if e.Bytes() != b {
+		return errors.New("invalid fr.Element encoding: not canonical")
+	}
+	return nil
+}
+
+// putFpArkworks writes the little-endian encoding of e, in regular form, to buf
+func putFpArkworks(buf []byte, e *fp.Element) {
+	b := e.Bytes()
+	for i := 0; i < fp.Bytes; i++ {
+		buf[i] = b[fp.Bytes-1-i]
+	}
+}
+
+// setFpArkworks sets e from the little-endian encoding in buf, and fails if it is not canonical
+func setFpArkworks(e *fp.Element, buf []byte) error {
+	var b [fp.Bytes]byte
+	for i := 0; i < fp.Bytes; i++ {
+		b[i] = buf[fp.Bytes-1-i]
+	}
+	e.SetBytes(b[:])
+	if e.Bytes() != b {
+		return errors.New("invalid fp.Element encoding: not canonical")
+	}
+	return nil
+}
+
+// ArkworksBytes returns the compressed arkworks encoding of p
+func (p *G1Affine) ArkworksBytes() (res [SizeOfG1AffineCompressed]byte) {
+	if p.X.IsZero() && p.Y.IsZero() {
+		res[SizeOfG1AffineCompressed-1] = mArkworksInfinity
+		return
+	}
+	putFpArkworks(res[:], &p.X)
+	if p.Y.LexicographicallyLargest() {
+		res[SizeOfG1AffineCompressed-1] |= mArkworksLargest
+	}
+	return
+}
+
+// ArkworksRawBytes returns the uncompressed arkworks encoding of p
+func (p *G1Affine) ArkworksRawBytes() (res [SizeOfG1AffineUncompressed]byte) {
+	if p.X.IsZero() && p.Y.IsZero() {
+		res[SizeOfG1AffineUncompressed-1] = mArkworksInfinity
+		return
+	}
+	putFpArkworks(res[:fp.Bytes], &p.X)
+	putFpArkworks(res[fp.Bytes:], &p.Y)
+	// arkworks sets the flag of the sign of Y in uncompressed form too
+	if p.Y.LexicographicallyLargest() {
+		res[SizeOfG1AffineUncompressed-1] |= mArkworksLargest
+	}
+	return
+}
+
+// SetArkworksBytes sets p from the compressed arkworks encoding in buf and returns the number
+// of consumed bytes
+//
+// this check if the resulting point is on the curve and in the correct subgroup
+func (p *G1Affine) SetArkworksBytes(buf []byte) (int, error) {
+	return p.setArkworksBytes(buf, true, true)
+}
+
+// SetArkworksRawBytes sets p from the uncompressed arkworks encoding in buf and returns the
+// number of consumed bytes
+//
+// this check if the resulting point is on the curve and in the correct subgroup
+func (p *G1Affine) SetArkworksRawBytes(buf []byte) (int, error) {
+	return p.setArkworksBytes(buf, false, true)
+}
+
+func (p *G1Affine) setArkworksBytes(buf []byte, compressed, subGroupCheck bool) (int, error) {
+	size := SizeOfG1AffineCompressed
+	if !compressed {
+		size = SizeOfG1AffineUncompressed
+	}
+	if len(buf) < size {
+		return 0, io.ErrShortBuffer
+	}
+
+	// copy the buffer to clear the flags
+	var b [SizeOfG1AffineUncompressed]byte
+	copy(b[:size], buf[:size])
+	flags := b[size-1] & mArkworksMask
+	b[size-1] &= ^mArkworksMask
+
+	if flags == mArkworksMask {
+		return 0, errors.New("invalid point encoding: invalid flags")
+	}
+	if flags == mArkworksInfinity {
+		for i := 0; i < size; i++ {
+			if b[i] != 0 {
+				return 0, errors.New("invalid point encoding: non zero point at infinity")
+			}
+		}
+		p.X.SetZero()
+		p.Y.SetZero()
+		return size, nil
+	}
+
+	// read X
+	if err := setFpArkworks(&p.X, b[:fp.Bytes]); err != nil {
+		return 0, err
+	}
+
+	if !compressed {
+		// read Y
+		if err := setFpArkworks(&p.Y, b[fp.Bytes:2*fp.Bytes]); err != nil {
+			return 0, err
+		}
+		if !p.IsOnCurve() {
+			return 0, errors.New("invalid point: not on curve")
+		}
+	} else {
+		// compute Y from the curve equation
+		var YSquared, Y fp.Element
+		YSquared.Square(&p.X).Mul(&YSquared, &p.X)
+		YSquared.Add(&YSquared, &bCurveCoeff)
+		if Y.Sqrt(&YSquared) == nil {
+			return 0, errors.New("invalid compressed coordinate: square root doesn't exist")
+		}
+		if Y.LexicographicallyLargest() != (flags == mArkworksLargest) {
+			Y.Neg(&Y)
+		}
+		p.Y = Y
+	}
+
+	// subgroup check
+	if subGroupCheck && !p.IsInSubGroup() {
+		return 0, errors.New("invalid point: subgroup check failed")
+	}
+
+	return size, nil
+}
+
+// ArkworksBytes returns the compressed arkworks encoding of p
+func (p *G2Affine) ArkworksBytes() (res [SizeOfG2AffineCompressed]byte) {
+	if p.X.IsZero() && p.Y.IsZero() {
+		res[SizeOfG2AffineCompressed-1] = mArkworksInfinity
+		return
+	}
+	putFpArkworks(res[:fp.Bytes], &p.X.A0)
+	putFpArkworks(res[fp.Bytes:2*fp.Bytes], &p.X.A1)
+	if p.Y.LexicographicallyLargest() {
+		res[SizeOfG2AffineCompressed-1] |= mArkworksLargest
+	}
+	return
+}
+
+// ArkworksRawBytes returns the uncompressed arkworks encoding of p
+func (p *G2Affine) ArkworksRawBytes() (res [SizeOfG2AffineUncompressed]byte) {
+	if p.X.IsZero() && p.Y.IsZero() {
+		res[SizeOfG2AffineUncompressed-1] = mArkworksInfinity
+		return
+	}
+	putFpArkworks(res[:fp.Bytes], &p.X.A0)
+	putFpArkworks(res[fp.Bytes:2*fp.Bytes], &p.X.A1)
+	putFpArkworks(res[2*fp.Bytes:3*fp.Bytes], &p.Y.A0)
+	putFpArkworks(res[3*fp.Bytes:4*fp.Bytes], &p.Y.A1)
+	// arkworks sets the flag of the sign of Y in uncompressed form too
+	if p.Y.LexicographicallyLargest() {
+		res[SizeOfG2AffineUncompressed-1] |= mArkworksLargest
+	}
+	return
+}
+
+// SetArkworksBytes sets p from the compressed arkworks encoding in buf and returns the number
+// of consumed bytes
+//
+// this check if the resulting point is on the curve and in the correct subgroup
+func (p *G2Affine) SetArkworksBytes(buf []byte) (int, error) {
+	return p.setArkworksBytes(buf, true, true)
+}
+
+// SetArkworksRawBytes sets p from the uncompressed arkworks encoding in buf and returns the
+// number of consumed bytes
+//
+// this check if the resulting point is on the curve and in the correct subgroup
+func (p *G2Affine) SetArkworksRawBytes(buf []byte) (int, error) {
+	return p.setArkworksBytes(buf, false, true)
+}
+
+func (p *G2Affine) setArkworksBytes(buf []byte, compressed, subGroupCheck bool) (int, error) {
+	size := SizeOfG2AffineCompressed
+	if !compressed {
+		size = SizeOfG2AffineUncompressed
+	}
+	if len(buf) < size {
+		return 0, io.ErrShortBuffer
+	}
+
+	// copy the buffer to clear the flags
+	var b [SizeOfG2AffineUncompressed]byte
+	copy(b[:size], buf[:size])
+	flags := b[size-1] & mArkworksMask
+	b[size-1] &= ^mArkworksMask
+
+	if flags == mArkworksMask {
+		return 0, errors.New("invalid point encoding: invalid flags")
+	}
+	if flags == mArkworksInfinity {
+		for i := 0; i < size; i++ {
+			if b[i] != 0 {
+				return 0, errors.New("invalid point encoding: non zero point at infinity")
+			}
+		}
+		p.X.SetZero()
+		p.Y.SetZero()
+		return size, nil
+	}
+
+	// read X
+	if err := setFpArkworks(&p.X.A0, b[:fp.Bytes]); err != nil {
+		return 0, err
+	}
+	if err := setFpArkworks(&p.X.A1, b[fp.Bytes:2*fp.Bytes]); err != nil {
+		return 0, err
+	}
+
+	if !compressed {
+		// read Y
+		if err := setFpArkworks(&p.Y.A0, b[2*fp.Bytes:3*fp.Bytes]); err != nil {
+			return 0, err
+		}
+		if err := setFpArkworks(&p.Y.A1, b[3*fp.Bytes:4*fp.Bytes]); err != nil {
+			return 0, err
+		}
+		if !p.IsOnCurve() {
+			return 0, errors.New("invalid point: not on curve")
+		}
+	} else {
+		// compute Y from the curve equation
+		var YSquared, Y fptower.E2
+		YSquared.Square(&p.X).Mul(&YSquared, &p.X)
+		YSquared.Add(&YSquared, &bTwistCurveCoeff)
+		if YSquared.Legendre() == -1 {
+			return 0, errors.New("invalid compressed coordinate: square root doesn't exist")
+		}
+		Y.Sqrt(&YSquared)
+		if Y.LexicographicallyLargest() != (flags == mArkworksLargest) {
+			Y.Neg(&Y)
+		}
+		p.Y = Y
+	}
+
+	// subgroup check
+	if subGroupCheck && !p.IsInSubGroup() {
+		return 0, errors.New("invalid point: subgroup check failed")
+	}
+
+	return size, nil
+}
@@ -59,6 +59,8 @@ func PairingCheck(P []G1Affine, Q []G2Affine) (bool, error) {
 // where d = (p¹²-1)/r = (p¹²-1)/Φ₁₂(p) ⋅ Φ₁₂(p)/r = (p⁶-1)(p²+1)(p⁴ - p² +1)/r
 // we use instead d=s ⋅ (p⁶-1)(p²+1)(p⁴ - p² +1)/r
 // where s is the cofactor 2x₀(6x₀²+3x₀+1) (Fuentes et al.)
+//
+// See FinalExponentiationExact for the exponentiation by d.
 func FinalExponentiation(z *GT, _z ...*GT) GT {
 
 	var result GT
@@ -116,6 +118,83 @@ func FinalExponentiation(z *GT, _z ...*GT) GT {
 	return result
 }
 
+// FinalExponentiationExact computes the exponentiation (∏ᵢ zᵢ)ᵈ
+// where d = (p¹²-1)/r = (p⁶-1)(p²+1)(p⁴ - p² +1)/r, without cofactor.
+//
+// FinalExponentiation is faster, but computes the d-th power up to the exponent
+// s = 2x₀(6x₀²+3x₀+1), which is coprime to r. Both are valid (non-degenerate, bilinear)
+// pairings when composed with the Miller loop, but the values in GT differ; the
+// exact exponent is needed to compare them with other implementations.
+func FinalExponentiationExact(z *GT, _z ...*GT) GT {
+
+	var result GT
+	result.Set(z)
+
+	for _, e := range _z {
+		result.Mul(&result, e)
+	}
+
+	var t0, t1 GT
+
+	// Easy part
+	// (p⁶-1)(p²+1)
+	t0.Conjugate(&result)
+	result.Inverse(&result)
+	t0.Mul(&t0, &result)
+	result.FrobeniusSquare(&t0).
+		Mul(&result, &t0)
+
+	// Hard part
+	// (p⁴-p²+1)/r
+	// Scott et al. vectorial addition chain
+	// https://eprint.iacr.org/2008/490.pdf (section 5)
+	var fx, fx2, fx3, y0, y1, y2, y3, y4, y5, y6 GT
+	fx.Expt(&result)
+	fx2.Expt(&fx)
+	fx3.Expt(&fx2)
+
+	// y₀ = f^(p+p²+p³)
+	y0.Frobenius(&result)
+	t0.FrobeniusSquare(&result)
+	y0.Mul(&y0, &t0)
+	t0.FrobeniusCube(&result)
+	y0.Mul(&y0, &t0)
+	// y₁ = f⁻¹
+	y1.Conjugate(&result)
+	// y₂ = f^(x²p²)
+	y2.FrobeniusSquare(&fx2)
+	// y₃ = f^(-xp)
+	y3.Frobenius(&fx).
+		Conjugate(&y3)
+	// y₄ = f^(-x-x²p)
+	y4.Frobenius(&fx2).
+		Mul(&y4, &fx).
+		Conjugate(&y4)
+	// y₅ = f^(-x²)
+	y5.Conjugate(&fx2)
+	// y₆ = f^(-x³-x³p)
+	y6.Frobenius(&fx3).
+		Mul(&y6, &fx3).
+		Conjugate(&y6)
+
+	// y₀ ⋅ y₁² ⋅ y₂⁶ ⋅ y₃¹² ⋅ y₄¹⁸ ⋅ y₅³⁰ ⋅ y₆³⁶
+	t0.CyclotomicSquare(&y6).
+		Mul(&t0, &y4).
+		Mul(&t0, &y5)
+	t1.Mul(&y3, &y5).
+		Mul(&t1, &t0)
+	t0.Mul(&t0, &y2)
+	t1.CyclotomicSquare(&t1).
+		Mul(&t1, &t0).
+		CyclotomicSquare(&t1)
+	t0.Mul(&t1, &y1)
+	t1.Mul(&t1, &y0)
+	t0.CyclotomicSquare(&t0)
+	result.Mul(&t0, &t1)
+
+	return result
+}
+
 // MillerLoop computes the multi-Miller loop
 // ∏ᵢ MillerLoop(Pᵢ, Qᵢ)
 func MillerLoop(P []G1Affine, Q []G2Affine) (GT, error) {
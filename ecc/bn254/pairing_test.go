@@ -65,6 +65,31 @@ func TestPairing(t *testing.T) {
 		genA,
 	))
 
+	properties.Property("[BN254] FinalExponentiationExact(a) should be a^((p¹²-1)/r), and FinalExponentiation(a) its power s=2x₀(6x₀²+3x₀+1)", prop.ForAll(
+		func(a GT) bool {
+			var d, s, tmp big.Int
+			p := fp.Modulus()
+			d.Exp(p, big.NewInt(12), nil).
+				Sub(&d, big.NewInt(1)).
+				Div(&d, fr.Modulus())
+			var expected GT
+			expected.Exp(a, &d)
+
+			// s = 2x₀(6x₀²+3x₀+1)
+			s.Mul(&xGen, &xGen).Mul(&s, big.NewInt(6))
+			tmp.Mul(&xGen, big.NewInt(3))
+			s.Add(&s, &tmp).Add(&s, big.NewInt(1)).
+				Mul(&s, &xGen).Mul(&s, big.NewInt(2))
+
+			exact := FinalExponentiationExact(&a)
+			var c GT
+			c.Exp(exact, &s)
+			b := FinalExponentiation(&a)
+			return exact.Equal(&expected) && c.Equal(&b)
+		},
+		genA,
+	))
+
 	properties.Property("[BN254] Exp, CyclotomicExp and ExpGLV results must be the same in GT", prop.ForAll(
 		func(a GT, e fp.Element) bool {
 			a = FinalExponentiation(&a)
@@ -327,6 +352,18 @@ func BenchmarkFinalExponentiation(b *testing.B) {
 
 }
 
+func BenchmarkFinalExponentiationExact(b *testing.B) {
+
+	var a GT
+	a.SetRandom()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		FinalExponentiationExact(&a)
+	}
+
+}
+
 func BenchmarkMultiMiller(b *testing.B) {
 
 	var g1GenAff G1Affine
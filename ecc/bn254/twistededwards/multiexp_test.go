@@ -0,0 +1,173 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package twistededwards
+
+import (
+	"crypto/rand"
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+)
+
+// randomPoints returns multiples of the base point and the random scalars used
+func randomPoints(tb testing.TB, n int) ([]PointAffine, []big.Int) {
+	params := GetEdwardsCurve()
+	points := make([]PointAffine, n)
+	scalars := make([]big.Int, n)
+	for i := range points {
+		s, err := rand.Int(rand.Reader, &params.Order)
+		if err != nil {
+			tb.Fatal(err)
+		}
+		scalars[i].Set(s)
+		points[i].ScalarMultiplication(&params.Base, s)
+	}
+	return points, scalars
+}
+
+func TestMultiExp(t *testing.T) {
+	t.Parallel()
+	params := GetEdwardsCurve()
+
+	for _, n := range []int{0, 1, 7, 130} {
+		points, _ := randomPoints(t, n)
+		_, scalars := randomPoints(t, n)
+		if n > 1 {
+			// unreduced and negative scalars
+			scalars[0].Add(&scalars[0], &params.Order)
+			scalars[1].Neg(&scalars[1])
+		}
+
+		var expected, tmp PointProj
+		expected.setInfinity()
+		for i := range points {
+			var p PointProj
+			p.FromAffine(&points[i])
+			tmp.ScalarMultiplication(&p, &scalars[i])
+			expected.Add(&expected, &tmp)
+		}
+
+		for _, c := range []uint64{0, 1, 5, 16} {
+			for _, nbTasks := range []int{0, 1, 3} {
+				var res PointProj
+				if _, err := res.MultiExp(points, scalars, ecc.MultiExpConfig{C: c, NbTasks: nbTasks}); err != nil {
+					t.Fatal(err)
+				}
+				if !res.Equal(&expected) && !(res.IsZero() && expected.IsZero()) {
+					t.Fatalf("n=%d, c=%d, nbTasks=%d: unexpected result", n, c, nbTasks)
+				}
+			}
+		}
+
+		var resAffine, expectedAffine PointAffine
+		if _, err := resAffine.MultiExp(points, scalars, ecc.MultiExpConfig{}); err != nil {
+			t.Fatal(err)
+		}
+		expectedAffine.FromProj(&expected)
+		if !resAffine.Equal(&expectedAffine) {
+			t.Fatal("unexpected affine result")
+		}
+	}
+
+	if _, err := new(PointProj).MultiExp(make([]PointAffine, 2), make([]big.Int, 1), ecc.MultiExpConfig{}); err == nil {
+		t.Fatal("different numbers of points and scalars should fail")
+	}
+	if _, err := new(PointProj).MultiExp(nil, nil, ecc.MultiExpConfig{C: 17}); err == nil {
+		t.Fatal("a window larger than 16 should fail")
+	}
+}
+
+func TestBatchConversion(t *testing.T) {
+	t.Parallel()
+	params := GetEdwardsCurve()
+
+	points, _ := randomPoints(t, 10)
+	proj := make([]PointProj, len(points))
+	ext := make([]PointExtended, len(points))
+	for i := range points {
+		proj[i].FromAffine(&points[i])
+		proj[i].Add(&proj[i], &proj[i])
+		ext[i].FromAffine(&params.Base)
+		ext[i].MixedAdd(&ext[i], &points[i])
+	}
+
+	fromProj := BatchFromProj(proj)
+	fromExt := BatchFromExtended(ext)
+	for i := range points {
+		var p PointAffine
+		if !p.FromProj(&proj[i]).Equal(&fromProj[i]) {
+			t.Fatal("BatchFromProj: unexpected result")
+		}
+		if !p.FromExtended(&ext[i]).Equal(&fromExt[i]) {
+			t.Fatal("BatchFromExtended: unexpected result")
+		}
+	}
+}
+
+func TestBatchAdd(t *testing.T) {
+	t.Parallel()
+
+	a, _ := randomPoints(t, 10)
+	b, _ := randomPoints(t, 10)
+	b[0] = a[0] // doubling
+	b[1].setInfinity()
+
+	res := BatchAdd(a, b)
+	for i := range a {
+		var expected PointAffine
+		expected.Add(&a[i], &b[i])
+		if !expected.Equal(&res[i]) {
+			t.Fatalf("BatchAdd: unexpected sum %d", i)
+		}
+	}
+}
+
+func TestBatchValidation(t *testing.T) {
+	t.Parallel()
+	params := GetEdwardsCurve()
+
+	points, _ := randomPoints(t, 10)
+	if !BatchIsOnCurve(points) || !BatchIsInSubGroup(points) {
+		t.Fatal("multiples of the base point should be valid")
+	}
+
+	// (0, -1) is a point of order 2
+	var torsion PointAffine
+	torsion.Y.SetOne().Neg(&torsion.Y)
+	if !torsion.IsOnCurve() || torsion.IsInSubGroup() {
+		t.Fatal("(0, -1) is on the curve and not in the subgroup")
+	}
+	points[3].Add(&points[3], &torsion)
+	if !BatchIsOnCurve(points) || BatchIsInSubGroup(points) {
+		t.Fatal("a point with a torsion component should not be in the subgroup")
+	}
+
+	points[5].X.Add(&points[5].X, &params.Base.X)
+	if BatchIsOnCurve(points) {
+		t.Fatal("a point not on the curve should be detected")
+	}
+}
+
+func BenchmarkMultiExp(b *testing.B) {
+	points, scalars := randomPoints(b, 1<<10)
+	var res PointProj
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = res.MultiExp(points, scalars, ecc.MultiExpConfig{})
+	}
+}
@@ -0,0 +1,1133 @@
+//go:build !purego
+// +build !purego
+
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package fp
+
+import "math/bits"
+
+// MulBy3 x *= 3 (mod q)
+func MulBy3(x *Element) {
+	_x := *x
+	x.Double(x).Add(x, &_x)
+}
+
+// MulBy5 x *= 5 (mod q)
+func MulBy5(x *Element) {
+	_x := *x
+	x.Double(x).Double(x).Add(x, &_x)
+}
+
+// MulBy13 x *= 13 (mod q)
+func MulBy13(x *Element) {
+	var y = Element{
+		4881606927653498122,
+		47978232019095094,
+		8555661377410121478,
+		17849732488791568215,
+		5227097555314997552,
+		839611732066804726,
+		5234648925333584632,
+		11936054402769696488,
+		1228498468693814883,
+		2857848702739380,
+	}
+	x.Mul(x, &y)
+}
+
+// Butterfly sets
+//
+//	a = a + b (mod q)
+//	b = a - b (mod q)
+func Butterfly(a, b *Element) {
+	_butterflyGeneric(a, b)
+}
+
+func mul(z, x, y *Element) {
+	_mulWasm(z, x, y)
+}
+
+func square(z, x *Element) {
+	_mulWasm(z, x, x)
+}
+
+const mask32 = 0xFFFFFFFF
+
+// madd32 returns hi, lo such that hi⋅2³² + lo = a⋅b + t + c, for a, b, t, c < 2³²
+func madd32(a, b, t, c uint64) (hi, lo uint64) {
+	r := a*b + t + c
+	return r >> 32, r & mask32
+}
+
+// _mulWasm sets z = x * y (mod q) with a CIOS Montgomery multiplication on 20 32-bit limbs.
+//
+// wasm has no 64x64 -> 128 bits multiplication, so bits.Mul64 is emulated with four
+// 32-bit multiplications and a handful of shifts and additions. Here, each 32x32 -> 64 bits
+// product is a single i64.mul, and the carries are propagated in the upper half of the 64-bit words.
+//
+// Since R = 2^(64⋅10) = 2^(32⋅20), the result is in Montgomery form, as with _mulGeneric.
+// Note that -q⁻¹ mod 2³² is qInvNeg mod 2³².
+func _mulWasm(z, x, y *Element) {
+	// x and y on 32-bit limbs
+	x0, x1 := x[0]&mask32, x[0]>>32
+	x2, x3 := x[1]&mask32, x[1]>>32
+	x4, x5 := x[2]&mask32, x[2]>>32
+	x6, x7 := x[3]&mask32, x[3]>>32
+	x8, x9 := x[4]&mask32, x[4]>>32
+	x10, x11 := x[5]&mask32, x[5]>>32
+	x12, x13 := x[6]&mask32, x[6]>>32
+	x14, x15 := x[7]&mask32, x[7]>>32
+	x16, x17 := x[8]&mask32, x[8]>>32
+	x18, x19 := x[9]&mask32, x[9]>>32
+	y0, y1 := y[0]&mask32, y[0]>>32
+	y2, y3 := y[1]&mask32, y[1]>>32
+	y4, y5 := y[2]&mask32, y[2]>>32
+	y6, y7 := y[3]&mask32, y[3]>>32
+	y8, y9 := y[4]&mask32, y[4]>>32
+	y10, y11 := y[5]&mask32, y[5]>>32
+	y12, y13 := y[6]&mask32, y[6]>>32
+	y14, y15 := y[7]&mask32, y[7]>>32
+	y16, y17 := y[8]&mask32, y[8]>>32
+	y18, y19 := y[9]&mask32, y[9]>>32
+
+	var t0, t1, t2, t3, t4, t5, t6, t7, t8, t9, t10, t11, t12, t13, t14, t15, t16, t17, t18, t19, t20, t21, C, m uint64
+
+	// round 0: (C,t) := t + x⋅y0
+	C, t0 = madd32(x0, y0, t0, 0)
+	C, t1 = madd32(x1, y0, t1, C)
+	C, t2 = madd32(x2, y0, t2, C)
+	C, t3 = madd32(x3, y0, t3, C)
+	C, t4 = madd32(x4, y0, t4, C)
+	C, t5 = madd32(x5, y0, t5, C)
+	C, t6 = madd32(x6, y0, t6, C)
+	C, t7 = madd32(x7, y0, t7, C)
+	C, t8 = madd32(x8, y0, t8, C)
+	C, t9 = madd32(x9, y0, t9, C)
+	C, t10 = madd32(x10, y0, t10, C)
+	C, t11 = madd32(x11, y0, t11, C)
+	C, t12 = madd32(x12, y0, t12, C)
+	C, t13 = madd32(x13, y0, t13, C)
+	C, t14 = madd32(x14, y0, t14, C)
+	C, t15 = madd32(x15, y0, t15, C)
+	C, t16 = madd32(x16, y0, t16, C)
+	C, t17 = madd32(x17, y0, t17, C)
+	C, t18 = madd32(x18, y0, t18, C)
+	C, t19 = madd32(x19, y0, t19, C)
+	C += t20
+	t20, t21 = C&mask32, C>>32
+
+	// (C,t) := (t + m⋅q) / 2³²
+	m = (t0 * qInvNeg) & mask32
+	C, _ = madd32(m, q0&mask32, t0, 0)
+	C, t0 = madd32(m, q0>>32, t1, C)
+	C, t1 = madd32(m, q1&mask32, t2, C)
+	C, t2 = madd32(m, q1>>32, t3, C)
+	C, t3 = madd32(m, q2&mask32, t4, C)
+	C, t4 = madd32(m, q2>>32, t5, C)
+	C, t5 = madd32(m, q3&mask32, t6, C)
+	C, t6 = madd32(m, q3>>32, t7, C)
+	C, t7 = madd32(m, q4&mask32, t8, C)
+	C, t8 = madd32(m, q4>>32, t9, C)
+	C, t9 = madd32(m, q5&mask32, t10, C)
+	C, t10 = madd32(m, q5>>32, t11, C)
+	C, t11 = madd32(m, q6&mask32, t12, C)
+	C, t12 = madd32(m, q6>>32, t13, C)
+	C, t13 = madd32(m, q7&mask32, t14, C)
+	C, t14 = madd32(m, q7>>32, t15, C)
+	C, t15 = madd32(m, q8&mask32, t16, C)
+	C, t16 = madd32(m, q8>>32, t17, C)
+	C, t17 = madd32(m, q9&mask32, t18, C)
+	C, t18 = madd32(m, q9>>32, t19, C)
+	C += t20
+	t19, t20 = C&mask32, t21+C>>32
+
+	// round 1: (C,t) := t + x⋅y1
+	C, t0 = madd32(x0, y1, t0, 0)
+	C, t1 = madd32(x1, y1, t1, C)
+	C, t2 = madd32(x2, y1, t2, C)
+	C, t3 = madd32(x3, y1, t3, C)
+	C, t4 = madd32(x4, y1, t4, C)
+	C, t5 = madd32(x5, y1, t5, C)
+	C, t6 = madd32(x6, y1, t6, C)
+	C, t7 = madd32(x7, y1, t7, C)
+	C, t8 = madd32(x8, y1, t8, C)
+	C, t9 = madd32(x9, y1, t9, C)
+	C, t10 = madd32(x10, y1, t10, C)
+	C, t11 = madd32(x11, y1, t11, C)
+	C, t12 = madd32(x12, y1, t12, C)
+	C, t13 = madd32(x13, y1, t13, C)
+	C, t14 = madd32(x14, y1, t14, C)
+	C, t15 = madd32(x15, y1, t15, C)
+	C, t16 = madd32(x16, y1, t16, C)
+	C, t17 = madd32(x17, y1, t17, C)
+	C, t18 = madd32(x18, y1, t18, C)
+	C, t19 = madd32(x19, y1, t19, C)
+	C += t20
+	t20, t21 = C&mask32, C>>32
+
+	// (C,t) := (t + m⋅q) / 2³²
+	m = (t0 * qInvNeg) & mask32
+	C, _ = madd32(m, q0&mask32, t0, 0)
+	C, t0 = madd32(m, q0>>32, t1, C)
+	C, t1 = madd32(m, q1&mask32, t2, C)
+	C, t2 = madd32(m, q1>>32, t3, C)
+	C, t3 = madd32(m, q2&mask32, t4, C)
+	C, t4 = madd32(m, q2>>32, t5, C)
+	C, t5 = madd32(m, q3&mask32, t6, C)
+	C, t6 = madd32(m, q3>>32, t7, C)
+	C, t7 = madd32(m, q4&mask32, t8, C)
+	C, t8 = madd32(m, q4>>32, t9, C)
+	C, t9 = madd32(m, q5&mask32, t10, C)
+	C, t10 = madd32(m, q5>>32, t11, C)
+	C, t11 = madd32(m, q6&mask32, t12, C)
+	C, t12 = madd32(m, q6>>32, t13, C)
+	C, t13 = madd32(m, q7&mask32, t14, C)
+	C, t14 = madd32(m, q7>>32, t15, C)
+	C, t15 = madd32(m, q8&mask32, t16, C)
+	C, t16 = madd32(m, q8>>32, t17, C)
+	C, t17 = madd32(m, q9&mask32, t18, C)
+	C, t18 = madd32(m, q9>>32, t19, C)
+	C += t20
+	t19, t20 = C&mask32, t21+C>>32
+
+	// round 2: (C,t) := t + x⋅y2
+	C, t0 = madd32(x0, y2, t0, 0)
+	C, t1 = madd32(x1, y2, t1, C)
+	C, t2 = madd32(x2, y2, t2, C)
+	C, t3 = madd32(x3, y2, t3, C)
+	C, t4 = madd32(x4, y2, t4, C)
+	C, t5 = madd32(x5, y2, t5, C)
+	C, t6 = madd32(x6, y2, t6, C)
+	C, t7 = madd32(x7, y2, t7, C)
+	C, t8 = madd32(x8, y2, t8, C)
+	C, t9 = madd32(x9, y2, t9, C)
+	C, t10 = madd32(x10, y2, t10, C)
+	C, t11 = madd32(x11, y2, t11, C)
+	C, t12 = madd32(x12, y2, t12, C)
+	C, t13 = madd32(x13, y2, t13, C)
+	C, t14 = madd32(x14, y2, t14, C)
+	C, t15 = madd32(x15, y2, t15, C)
+	C, t16 = madd32(x16, y2, t16, C)
+	C, t17 = madd32(x17, y2, t17, C)
+	C, t18 = madd32(x18, y2, t18, C)
+	C, t19 = madd32(x19, y2, t19, C)
+	C += t20
+	t20, t21 = C&mask32, C>>32
+
+	// (C,t) := (t + m⋅q) / 2³²
+	m = (t0 * qInvNeg) & mask32
+	C, _ = madd32(m, q0&mask32, t0, 0)
+	C, t0 = madd32(m, q0>>32, t1, C)
+	C, t1 = madd32(m, q1&mask32, t2, C)
+	C, t2 = madd32(m, q1>>32, t3, C)
+	C, t3 = madd32(m, q2&mask32, t4, C)
+	C, t4 = madd32(m, q2>>32, t5, C)
+	C, t5 = madd32(m, q3&mask32, t6, C)
+	C, t6 = madd32(m, q3>>32, t7, C)
+	C, t7 = madd32(m, q4&mask32, t8, C)
+	C, t8 = madd32(m, q4>>32, t9, C)
+	C, t9 = madd32(m, q5&mask32, t10, C)
+	C, t10 = madd32(m, q5>>32, t11, C)
+	C, t11 = madd32(m, q6&mask32, t12, C)
+	C, t12 = madd32(m, q6>>32, t13, C)
+	C, t13 = madd32(m, q7&mask32, t14, C)
+	C, t14 = madd32(m, q7>>32, t15, C)
+	C, t15 = madd32(m, q8&mask32, t16, C)
+	C, t16 = madd32(m, q8>>32, t17, C)
+	C, t17 = madd32(m, q9&mask32, t18, C)
+	C, t18 = madd32(m, q9>>32, t19, C)
+	C += t20
+	t19, t20 = C&mask32, t21+C>>32
+
+	// round 3: (C,t) := t + x⋅y3
+	C, t0 = madd32(x0, y3, t0, 0)
+	C, t1 = madd32(x1, y3, t1, C)
+	C, t2 = madd32(x2, y3, t2, C)
+	C, t3 = madd32(x3, y3, t3, C)
+	C, t4 = madd32(x4, y3, t4, C)
+	C, t5 = madd32(x5, y3, t5, C)
+	C, t6 = madd32(x6, y3, t6, C)
+	C, t7 = madd32(x7, y3, t7, C)
+	C, t8 = madd32(x8, y3, t8, C)
+	C, t9 = madd32(x9, y3, t9, C)
+	C, t10 = madd32(x10, y3, t10, C)
+	C, t11 = madd32(x11, y3, t11, C)
+	C, t12 = madd32(x12, y3, t12, C)
+	C, t13 = madd32(x13, y3, t13, C)
+	C, t14 = madd32(x14, y3, t14, C)
+	C, t15 = madd32(x15, y3, t15, C)
+	C, t16 = madd32(x16, y3, t16, C)
+	C, t17 = madd32(x17, y3, t17, C)
+	C, t18 = madd32(x18, y3, t18, C)
+	C, t19 = madd32(x19, y3, t19, C)
+	C += t20
+	t20, t21 = C&mask32, C>>32
+
+	// (C,t) := (t + m⋅q) / 2³²
+	m = (t0 * qInvNeg) & mask32
+	C, _ = madd32(m, q0&mask32, t0, 0)
+	C, t0 = madd32(m, q0>>32, t1, C)
+	C, t1 = madd32(m, q1&mask32, t2, C)
+	C, t2 = madd32(m, q1>>32, t3, C)
+	C, t3 = madd32(m, q2&mask32, t4, C)
+	C, t4 = madd32(m, q2>>32, t5, C)
+	C, t5 = madd32(m, q3&mask32, t6, C)
+	C, t6 = madd32(m, q3>>32, t7, C)
+	C, t7 = madd32(m, q4&mask32, t8, C)
+	C, t8 = madd32(m, q4>>32, t9, C)
+	C, t9 = madd32(m, q5&mask32, t10, C)
+	C, t10 = madd32(m, q5>>32, t11, C)
+	C, t11 = madd32(m, q6&mask32, t12, C)
+	C, t12 = madd32(m, q6>>32, t13, C)
+	C, t13 = madd32(m, q7&mask32, t14, C)
+	C, t14 = madd32(m, q7>>32, t15, C)
+	C, t15 = madd32(m, q8&mask32, t16, C)
+	C, t16 = madd32(m, q8>>32, t17, C)
+	C, t17 = madd32(m, q9&mask32, t18, C)
+	C, t18 = madd32(m, q9>>32, t19, C)
+	C += t20
+	t19, t20 = C&mask32, t21+C>>32
+
+	// round 4: (C,t) := t + x⋅y4
+	C, t0 = madd32(x0, y4, t0, 0)
+	C, t1 = madd32(x1, y4, t1, C)
+	C, t2 = madd32(x2, y4, t2, C)
+	C, t3 = madd32(x3, y4, t3, C)
+	C, t4 = madd32(x4, y4, t4, C)
+	C, t5 = madd32(x5, y4, t5, C)
+	C, t6 = madd32(x6, y4, t6, C)
+	C, t7 = madd32(x7, y4, t7, C)
+	C, t8 = madd32(x8, y4, t8, C)
+	C, t9 = madd32(x9, y4, t9, C)
+	C, t10 = madd32(x10, y4, t10, C)
+	C, t11 = madd32(x11, y4, t11, C)
+	C, t12 = madd32(x12, y4, t12, C)
+	C, t13 = madd32(x13, y4, t13, C)
+	C, t14 = madd32(x14, y4, t14, C)
+	C, t15 = madd32(x15, y4, t15, C)
+	C, t16 = madd32(x16, y4, t16, C)
+	C, t17 = madd32(x17, y4, t17, C)
+	C, t18 = madd32(x18, y4, t18, C)
+	C, t19 = madd32(x19, y4, t19, C)
+	C += t20
+	t20, t21 = C&mask32, C>>32
+
+	// (C,t) := (t + m⋅q) / 2³²
+	m = (t0 * qInvNeg) & mask32
+	C, _ = madd32(m, q0&mask32, t0, 0)
+	C, t0 = madd32(m, q0>>32, t1, C)
+	C, t1 = madd32(m, q1&mask32, t2, C)
+	C, t2 = madd32(m, q1>>32, t3, C)
+	C, t3 = madd32(m, q2&mask32, t4, C)
+	C, t4 = madd32(m, q2>>32, t5, C)
+	C, t5 = madd32(m, q3&mask32, t6, C)
+	C, t6 = madd32(m, q3>>32, t7, C)
+	C, t7 = madd32(m, q4&mask32, t8, C)
+	C, t8 = madd32(m, q4>>32, t9, C)
+	C, t9 = madd32(m, q5&mask32, t10, C)
+	C, t10 = madd32(m, q5>>32, t11, C)
+	C, t11 = madd32(m, q6&mask32, t12, C)
+	C, t12 = madd32(m, q6>>32, t13, C)
+	C, t13 = madd32(m, q7&mask32, t14, C)
+	C, t14 = madd32(m, q7>>32, t15, C)
+	C, t15 = madd32(m, q8&mask32, t16, C)
+	C, t16 = madd32(m, q8>>32, t17, C)
+	C, t17 = madd32(m, q9&mask32, t18, C)
+	C, t18 = madd32(m, q9>>32, t19, C)
+	C += t20
+	t19, t20 = C&mask32, t21+C>>32
+
+	// round 5: (C,t) := t + x⋅y5
+	C, t0 = madd32(x0, y5, t0, 0)
+	C, t1 = madd32(x1, y5, t1, C)
+	C, t2 = madd32(x2, y5, t2, C)
+	C, t3 = madd32(x3, y5, t3, C)
+	C, t4 = madd32(x4, y5, t4, C)
+	C, t5 = madd32(x5, y5, t5, C)
+	C, t6 = madd32(x6, y5, t6, C)
+	C, t7 = madd32(x7, y5, t7, C)
+	C, t8 = madd32(x8, y5, t8, C)
+	C, t9 = madd32(x9, y5, t9, C)
+	C, t10 = madd32(x10, y5, t10, C)
+	C, t11 = madd32(x11, y5, t11, C)
+	C, t12 = madd32(x12, y5, t12, C)
+	C, t13 = madd32(x13, y5, t13, C)
+	C, t14 = madd32(x14, y5, t14, C)
+	C, t15 = madd32(x15, y5, t15, C)
+	C, t16 = madd32(x16, y5, t16, C)
+	C, t17 = madd32(x17, y5, t17, C)
+	C, t18 = madd32(x18, y5, t18, C)
+	C, t19 = madd32(x19, y5, t19, C)
+	C += t20
+	t20, t21 = C&mask32, C>>32
+
+	// (C,t) := (t + m⋅q) / 2³²
+	m = (t0 * qInvNeg) & mask32
+	C, _ = madd32(m, q0&mask32, t0, 0)
+	C, t0 = madd32(m, q0>>32, t1, C)
+	C, t1 = madd32(m, q1&mask32, t2, C)
+	C, t2 = madd32(m, q1>>32, t3, C)
+	C, t3 = madd32(m, q2&mask32, t4, C)
+	C, t4 = madd32(m, q2>>32, t5, C)
+	C, t5 = madd32(m, q3&mask32, t6, C)
+	C, t6 = madd32(m, q3>>32, t7, C)
+	C, t7 = madd32(m, q4&mask32, t8, C)
+	C, t8 = madd32(m, q4>>32, t9, C)
+	C, t9 = madd32(m, q5&mask32, t10, C)
+	C, t10 = madd32(m, q5>>32, t11, C)
+	C, t11 = madd32(m, q6&mask32, t12, C)
+	C, t12 = madd32(m, q6>>32, t13, C)
+	C, t13 = madd32(m, q7&mask32, t14, C)
+	C, t14 = madd32(m, q7>>32, t15, C)
+	C, t15 = madd32(m, q8&mask32, t16, C)
+	C, t16 = madd32(m, q8>>32, t17, C)
+	C, t17 = madd32(m, q9&mask32, t18, C)
+	C, t18 = madd32(m, q9>>32, t19, C)
+	C += t20
+	t19, t20 = C&mask32, t21+C>>32
+
+	// round 6: (C,t) := t + x⋅y6
+	C, t0 = madd32(x0, y6, t0, 0)
+	C, t1 = madd32(x1, y6, t1, C)
+	C, t2 = madd32(x2, y6, t2, C)
+	C, t3 = madd32(x3, y6, t3, C)
+	C, t4 = madd32(x4, y6, t4, C)
+	C, t5 = madd32(x5, y6, t5, C)
+	C, t6 = madd32(x6, y6, t6, C)
+	C, t7 = madd32(x7, y6, t7, C)
+	C, t8 = madd32(x8, y6, t8, C)
+	C, t9 = madd32(x9, y6, t9, C)
+	C, t10 = madd32(x10, y6, t10, C)
+	C, t11 = madd32(x11, y6, t11, C)
+	C, t12 = madd32(x12, y6, t12, C)
+	C, t13 = madd32(x13, y6, t13, C)
+	C, t14 = madd32(x14, y6, t14, C)
+	C, t15 = madd32(x15, y6, t15, C)
+	C, t16 = madd32(x16, y6, t16, C)
+	C, t17 = madd32(x17, y6, t17, C)
+	C, t18 = madd32(x18, y6, t18, C)
+	C, t19 = madd32(x19, y6, t19, C)
+	C += t20
+	t20, t21 = C&mask32, C>>32
+
+	// (C,t) := (t + m⋅q) / 2³²
+	m = (t0 * qInvNeg) & mask32
+	C, _ = madd32(m, q0&mask32, t0, 0)
+	C, t0 = madd32(m, q0>>32, t1, C)
+	C, t1 = madd32(m, q1&mask32, t2, C)
+	C, t2 = madd32(m, q1>>32, t3, C)
+	C, t3 = madd32(m, q2&mask32, t4, C)
+	C, t4 = madd32(m, q2>>32, t5, C)
+	C, t5 = madd32(m, q3&mask32, t6, C)
+	C, t6 = madd32(m, q3>>32, t7, C)
+	C, t7 = madd32(m, q4&mask32, t8, C)
+	C, t8 = madd32(m, q4>>32, t9, C)
+	C, t9 = madd32(m, q5&mask32, t10, C)
+	C, t10 = madd32(m, q5>>32, t11, C)
+	C, t11 = madd32(m, q6&mask32, t12, C)
+	C, t12 = madd32(m, q6>>32, t13, C)
+	C, t13 = madd32(m, q7&mask32, t14, C)
+	C, t14 = madd32(m, q7>>32, t15, C)
+	C, t15 = madd32(m, q8&mask32, t16, C)
+	C, t16 = madd32(m, q8>>32, t17, C)
+	C, t17 = madd32(m, q9&mask32, t18, C)
+	C, t18 = madd32(m, q9>>32, t19, C)
+	C += t20
+	t19, t20 = C&mask32, t21+C>>32
+
+	// round 7: (C,t) := t + x⋅y7
+	C, t0 = madd32(x0, y7, t0, 0)
+	C, t1 = madd32(x1, y7, t1, C)
+	C, t2 = madd32(x2, y7, t2, C)
+	C, t3 = madd32(x3, y7, t3, C)
+	C, t4 = madd32(x4, y7, t4, C)
+	C, t5 = madd32(x5, y7, t5, C)
+	C, t6 = madd32(x6, y7, t6, C)
+	C, t7 = madd32(x7, y7, t7, C)
+	C, t8 = madd32(x8, y7, t8, C)
+	C, t9 = madd32(x9, y7, t9, C)
+	C, t10 = madd32(x10, y7, t10, C)
+	C, t11 = madd32(x11, y7, t11, C)
+	C, t12 = madd32(x12, y7, t12, C)
+	C, t13 = madd32(x13, y7, t13, C)
+	C, t14 = madd32(x14, y7, t14, C)
+	C, t15 = madd32(x15, y7, t15, C)
+	C, t16 = madd32(x16, y7, t16, C)
+	C, t17 = madd32(x17, y7, t17, C)
+	C, t18 = madd32(x18, y7, t18, C)
+	C, t19 = madd32(x19, y7, t19, C)
+	C += t20
+	t20, t21 = C&mask32, C>>32
+
+	// (C,t) := (t + m⋅q) / 2³²
+	m = (t0 * qInvNeg) & mask32
+	C, _ = madd32(m, q0&mask32, t0, 0)
+	C, t0 = madd32(m, q0>>32, t1, C)
+	C, t1 = madd32(m, q1&mask32, t2, C)
+	C, t2 = madd32(m, q1>>32, t3, C)
+	C, t3 = madd32(m, q2&mask32, t4, C)
+	C, t4 = madd32(m, q2>>32, t5, C)
+	C, t5 = madd32(m, q3&mask32, t6, C)
+	C, t6 = madd32(m, q3>>32, t7, C)
+	C, t7 = madd32(m, q4&mask32, t8, C)
+	C, t8 = madd32(m, q4>>32, t9, C)
+	C, t9 = madd32(m, q5&mask32, t10, C)
+	C, t10 = madd32(m, q5>>32, t11, C)
+	C, t11 = madd32(m, q6&mask32, t12, C)
+	C, t12 = madd32(m, q6>>32, t13, C)
+	C, t13 = madd32(m, q7&mask32, t14, C)
+	C, t14 = madd32(m, q7>>32, t15, C)
+	C, t15 = madd32(m, q8&mask32, t16, C)
+	C, t16 = madd32(m, q8>>32, t17, C)
+	C, t17 = madd32(m, q9&mask32, t18, C)
+	C, t18 = madd32(m, q9>>32, t19, C)
+	C += t20
+	t19, t20 = C&mask32, t21+C>>32
+
+	// round 8: (C,t) := t + x⋅y8
+	C, t0 = madd32(x0, y8, t0, 0)
+	C, t1 = madd32(x1, y8, t1, C)
+	C, t2 = madd32(x2, y8, t2, C)
+	C, t3 = madd32(x3, y8, t3, C)
+	C, t4 = madd32(x4, y8, t4, C)
+	C, t5 = madd32(x5, y8, t5, C)
+	C, t6 = madd32(x6, y8, t6, C)
+	C, t7 = madd32(x7, y8, t7, C)
+	C, t8 = madd32(x8, y8, t8, C)
+	C, t9 = madd32(x9, y8, t9, C)
+	C, t10 = madd32(x10, y8, t10, C)
+	C, t11 = madd32(x11, y8, t11, C)
+	C, t12 = madd32(x12, y8, t12, C)
+	C, t13 = madd32(x13, y8, t13, C)
+	C, t14 = madd32(x14, y8, t14, C)
+	C, t15 = madd32(x15, y8, t15, C)
+	C, t16 = madd32(x16, y8, t16, C)
+	C, t17 = madd32(x17, y8, t17, C)
+	C, t18 = madd32(x18, y8, t18, C)
+	C, t19 = madd32(x19, y8, t19, C)
+	C += t20
+	t20, t21 = C&mask32, C>>32
+
+	// (C,t) := (t + m⋅q) / 2³²
+	m = (t0 * qInvNeg) & mask32
+	C, _ = madd32(m, q0&mask32, t0, 0)
+	C, t0 = madd32(m, q0>>32, t1, C)
+	C, t1 = madd32(m, q1&mask32, t2, C)
+	C, t2 = madd32(m, q1>>32, t3, C)
+	C, t3 = madd32(m, q2&mask32, t4, C)
+	C, t4 = madd32(m, q2>>32, t5, C)
+	C, t5 = madd32(m, q3&mask32, t6, C)
+	C, t6 = madd32(m, q3>>32, t7, C)
+	C, t7 = madd32(m, q4&mask32, t8, C)
+	C, t8 = madd32(m, q4>>32, t9, C)
+	C, t9 = madd32(m, q5&mask32, t10, C)
+	C, t10 = madd32(m, q5>>32, t11, C)
+	C, t11 = madd32(m, q6&mask32, t12, C)
+	C, t12 = madd32(m, q6>>32, t13, C)
+	C, t13 = madd32(m, q7&mask32, t14, C)
+	C, t14 = madd32(m, q7>>32, t15, C)
+	C, t15 = madd32(m, q8&mask32, t16, C)
+	C, t16 = madd32(m, q8>>32, t17, C)
+	C, t17 = madd32(m, q9&mask32, t18, C)
+	C, t18 = madd32(m, q9>>32, t19, C)
+	C += t20
+	t19, t20 = C&mask32, t21+C>>32
+
+	// round 9: (C,t) := t + x⋅y9
+	C, t0 = madd32(x0, y9, t0, 0)
+	C, t1 = madd32(x1, y9, t1, C)
+	C, t2 = madd32(x2, y9, t2, C)
+	C, t3 = madd32(x3, y9, t3, C)
+	C, t4 = madd32(x4, y9, t4, C)
+	C, t5 = madd32(x5, y9, t5, C)
+	C, t6 = madd32(x6, y9, t6, C)
+	C, t7 = madd32(x7, y9, t7, C)
+	C, t8 = madd32(x8, y9, t8, C)
+	C, t9 = madd32(x9, y9, t9, C)
+	C, t10 = madd32(x10, y9, t10, C)
+	C, t11 = madd32(x11, y9, t11, C)
+	C, t12 = madd32(x12, y9, t12, C)
+	C, t13 = madd32(x13, y9, t13, C)
+	C, t14 = madd32(x14, y9, t14, C)
+	C, t15 = madd32(x15, y9, t15, C)
+	C, t16 = madd32(x16, y9, t16, C)
+	C, t17 = madd32(x17, y9, t17, C)
+	C, t18 = madd32(x18, y9, t18, C)
+	C, t19 = madd32(x19, y9, t19, C)
+	C += t20
+	t20, t21 = C&mask32, C>>32
+
+	// (C,t) := (t + m⋅q) / 2³²
+	m = (t0 * qInvNeg) & mask32
+	C, _ = madd32(m, q0&mask32, t0, 0)
+	C, t0 = madd32(m, q0>>32, t1, C)
+	C, t1 = madd32(m, q1&mask32, t2, C)
+	C, t2 = madd32(m, q1>>32, t3, C)
+	C, t3 = madd32(m, q2&mask32, t4, C)
+	C, t4 = madd32(m, q2>>32, t5, C)
+	C, t5 = madd32(m, q3&mask32, t6, C)
+	C, t6 = madd32(m, q3>>32, t7, C)
+	C, t7 = madd32(m, q4&mask32, t8, C)
+	C, t8 = madd32(m, q4>>32, t9, C)
+	C, t9 = madd32(m, q5&mask32, t10, C)
+	C, t10 = madd32(m, q5>>32, t11, C)
+	C, t11 = madd32(m, q6&mask32, t12, C)
+	C, t12 = madd32(m, q6>>32, t13, C)
+	C, t13 = madd32(m, q7&mask32, t14, C)
+	C, t14 = madd32(m, q7>>32, t15, C)
+	C, t15 = madd32(m, q8&mask32, t16, C)
+	C, t16 = madd32(m, q8>>32, t17, C)
+	C, t17 = madd32(m, q9&mask32, t18, C)
+	C, t18 = madd32(m, q9>>32, t19, C)
+	C += t20
+	t19, t20 = C&mask32, t21+C>>32
+
+	// round 10: (C,t) := t + x⋅y10
+	C, t0 = madd32(x0, y10, t0, 0)
+	C, t1 = madd32(x1, y10, t1, C)
+	C, t2 = madd32(x2, y10, t2, C)
+	C, t3 = madd32(x3, y10, t3, C)
+	C, t4 = madd32(x4, y10, t4, C)
+	C, t5 = madd32(x5, y10, t5, C)
+	C, t6 = madd32(x6, y10, t6, C)
+	C, t7 = madd32(x7, y10, t7, C)
+	C, t8 = madd32(x8, y10, t8, C)
+	C, t9 = madd32(x9, y10, t9, C)
+	C, t10 = madd32(x10, y10, t10, C)
+	C, t11 = madd32(x11, y10, t11, C)
+	C, t12 = madd32(x12, y10, t12, C)
+	C, t13 = madd32(x13, y10, t13, C)
+	C, t14 = madd32(x14, y10, t14, C)
+	C, t15 = madd32(x15, y10, t15, C)
+	C, t16 = madd32(x16, y10, t16, C)
+	C, t17 = madd32(x17, y10, t17, C)
+	C, t18 = madd32(x18, y10, t18, C)
+	C, t19 = madd32(x19, y10, t19, C)
+	C += t20
+	t20, t21 = C&mask32, C>>32
+
+	// (C,t) := (t + m⋅q) / 2³²
+	m = (t0 * qInvNeg) & mask32
+	C, _ = madd32(m, q0&mask32, t0, 0)
+	C, t0 = madd32(m, q0>>32, t1, C)
+	C, t1 = madd32(m, q1&mask32, t2, C)
+	C, t2 = madd32(m, q1>>32, t3, C)
+	C, t3 = madd32(m, q2&mask32, t4, C)
+	C, t4 = madd32(m, q2>>32, t5, C)
+	C, t5 = madd32(m, q3&mask32, t6, C)
+	C, t6 = madd32(m, q3>>32, t7, C)
+	C, t7 = madd32(m, q4&mask32, t8, C)
+	C, t8 = madd32(m, q4>>32, t9, C)
+	C, t9 = madd32(m, q5&mask32, t10, C)
+	C, t10 = madd32(m, q5>>32, t11, C)
+	C, t11 = madd32(m, q6&mask32, t12, C)
+	C, t12 = madd32(m, q6>>32, t13, C)
+	C, t13 = madd32(m, q7&mask32, t14, C)
+	C, t14 = madd32(m, q7>>32, t15, C)
+	C, t15 = madd32(m, q8&mask32, t16, C)
+	C, t16 = madd32(m, q8>>32, t17, C)
+	C, t17 = madd32(m, q9&mask32, t18, C)
+	C, t18 = madd32(m, q9>>32, t19, C)
+	C += t20
+	t19, t20 = C&mask32, t21+C>>32
+
+	// round 11: (C,t) := t + x⋅y11
+	C, t0 = madd32(x0, y11, t0, 0)
+	C, t1 = madd32(x1, y11, t1, C)
+	C, t2 = madd32(x2, y11, t2, C)
+	C, t3 = madd32(x3, y11, t3, C)
+	C, t4 = madd32(x4, y11, t4, C)
+	C, t5 = madd32(x5, y11, t5, C)
+	C, t6 = madd32(x6, y11, t6, C)
+	C, t7 = madd32(x7, y11, t7, C)
+	C, t8 = madd32(x8, y11, t8, C)
+	C, t9 = madd32(x9, y11, t9, C)
+	C, t10 = madd32(x10, y11, t10, C)
+	C, t11 = madd32(x11, y11, t11, C)
+	C, t12 = madd32(x12, y11, t12, C)
+	C, t13 = madd32(x13, y11, t13, C)
+	C, t14 = madd32(x14, y11, t14, C)
+	C, t15 = madd32(x15, y11, t15, C)
+	C, t16 = madd32(x16, y11, t16, C)
+	C, t17 = madd32(x17, y11, t17, C)
+	C, t18 = madd32(x18, y11, t18, C)
+	C, t19 = madd32(x19, y11, t19, C)
+	C += t20
+	t20, t21 = C&mask32, C>>32
+
+	// (C,t) := (t + m⋅q) / 2³²
+	m = (t0 * qInvNeg) & mask32
+	C, _ = madd32(m, q0&mask32, t0, 0)
+	C, t0 = madd32(m, q0>>32, t1, C)
+	C, t1 = madd32(m, q1&mask32, t2, C)
+	C, t2 = madd32(m, q1>>32, t3, C)
+	C, t3 = madd32(m, q2&mask32, t4, C)
+	C, t4 = madd32(m, q2>>32, t5, C)
+	C, t5 = madd32(m, q3&mask32, t6, C)
+	C, t6 = madd32(m, q3>>32, t7, C)
+	C, t7 = madd32(m, q4&mask32, t8, C)
+	C, t8 = madd32(m, q4>>32, t9, C)
+	C, t9 = madd32(m, q5&mask32, t10, C)
+	C, t10 = madd32(m, q5>>32, t11, C)
+	C, t11 = madd32(m, q6&mask32, t12, C)
+	C, t12 = madd32(m, q6>>32, t13, C)
+	C, t13 = madd32(m, q7&mask32, t14, C)
+	C, t14 = madd32(m, q7>>32, t15, C)
+	C, t15 = madd32(m, q8&mask32, t16, C)
+	C, t16 = madd32(m, q8>>32, t17, C)
+	C, t17 = madd32(m, q9&mask32, t18, C)
+	C, t18 = madd32(m, q9>>32, t19, C)
+	C += t20
+	t19, t20 = C&mask32, t21+C>>32
+
+	// round 12: (C,t) := t + x⋅y12
+	C, t0 = madd32(x0, y12, t0, 0)
+	C, t1 = madd32(x1, y12, t1, C)
+	C, t2 = madd32(x2, y12, t2, C)
+	C, t3 = madd32(x3, y12, t3, C)
+	C, t4 = madd32(x4, y12, t4, C)
+	C, t5 = madd32(x5, y12, t5, C)
+	C, t6 = madd32(x6, y12, t6, C)
+	C, t7 = madd32(x7, y12, t7, C)
+	C, t8 = madd32(x8, y12, t8, C)
+	C, t9 = madd32(x9, y12, t9, C)
+	C, t10 = madd32(x10, y12, t10, C)
+	C, t11 = madd32(x11, y12, t11, C)
+	C, t12 = madd32(x12, y12, t12, C)
+	C, t13 = madd32(x13, y12, t13, C)
+	C, t14 = madd32(x14, y12, t14, C)
+	C, t15 = madd32(x15, y12, t15, C)
+	C, t16 = madd32(x16, y12, t16, C)
+	C, t17 = madd32(x17, y12, t17, C)
+	C, t18 = madd32(x18, y12, t18, C)
+	C, t19 = madd32(x19, y12, t19, C)
+	C += t20
+	t20, t21 = C&mask32, C>>32
+
+	// (C,t) := (t + m⋅q) / 2³²
+	m = (t0 * qInvNeg) & mask32
+	C, _ = madd32(m, q0&mask32, t0, 0)
+	C, t0 = madd32(m, q0>>32, t1, C)
+	C, t1 = madd32(m, q1&mask32, t2, C)
+	C, t2 = madd32(m, q1>>32, t3, C)
+	C, t3 = madd32(m, q2&mask32, t4, C)
+	C, t4 = madd32(m, q2>>32, t5, C)
+	C, t5 = madd32(m, q3&mask32, t6, C)
+	C, t6 = madd32(m, q3>>32, t7, C)
+	C, t7 = madd32(m, q4&mask32, t8, C)
+	C, t8 = madd32(m, q4>>32, t9, C)
+	C, t9 = madd32(m, q5&mask32, t10, C)
+	C, t10 = madd32(m, q5>>32, t11, C)
+	C, t11 = madd32(m, q6&mask32, t12, C)
+	C, t12 = madd32(m, q6>>32, t13, C)
+	C, t13 = madd32(m, q7&mask32, t14, C)
+	C, t14 = madd32(m, q7>>32, t15, C)
+	C, t15 = madd32(m, q8&mask32, t16, C)
+	C, t16 = madd32(m, q8>>32, t17, C)
+	C, t17 = madd32(m, q9&mask32, t18, C)
+	C, t18 = madd32(m, q9>>32, t19, C)
+	C += t20
+	t19, t20 = C&mask32, t21+C>>32
+
+	// round 13: (C,t) := t + x⋅y13
+	C, t0 = madd32(x0, y13, t0, 0)
+	C, t1 = madd32(x1, y13, t1, C)
+	C, t2 = madd32(x2, y13, t2, C)
+	C, t3 = madd32(x3, y13, t3, C)
+	C, t4 = madd32(x4, y13, t4, C)
+	C, t5 = madd32(x5, y13, t5, C)
+	C, t6 = madd32(x6, y13, t6, C)
+	C, t7 = madd32(x7, y13, t7, C)
+	C, t8 = madd32(x8, y13, t8, C)
+	C, t9 = madd32(x9, y13, t9, C)
+	C, t10 = madd32(x10, y13, t10, C)
+	C, t11 = madd32(x11, y13, t11, C)
+	C, t12 = madd32(x12, y13, t12, C)
+	C, t13 = madd32(x13, y13, t13, C)
+	C, t14 = madd32(x14, y13, t14, C)
+	C, t15 = madd32(x15, y13, t15, C)
+	C, t16 = madd32(x16, y13, t16, C)
+	C, t17 = madd32(x17, y13, t17, C)
+	C, t18 = madd32(x18, y13, t18, C)
+	C, t19 = madd32(x19, y13, t19, C)
+	C += t20
+	t20, t21 = C&mask32, C>>32
+
+	// (C,t) := (t + m⋅q) / 2³²
+	m = (t0 * qInvNeg) & mask32
+	C, _ = madd32(m, q0&mask32, t0, 0)
+	C, t0 = madd32(m, q0>>32, t1, C)
+	C, t1 = madd32(m, q1&mask32, t2, C)
+	C, t2 = madd32(m, q1>>32, t3, C)
+	C, t3 = madd32(m, q2&mask32, t4, C)
+	C, t4 = madd32(m, q2>>32, t5, C)
+	C, t5 = madd32(m, q3&mask32, t6, C)
+	C, t6 = madd32(m, q3>>32, t7, C)
+	C, t7 = madd32(m, q4&mask32, t8, C)
+	C, t8 = madd32(m, q4>>32, t9, C)
+	C, t9 = madd32(m, q5&mask32, t10, C)
+	C, t10 = madd32(m, q5>>32, t11, C)
+	C, t11 = madd32(m, q6&mask32, t12, C)
+	C, t12 = madd32(m, q6>>32, t13, C)
+	C, t13 = madd32(m, q7&mask32, t14, C)
+	C, t14 = madd32(m, q7>>32, t15, C)
+	C, t15 = madd32(m, q8&mask32, t16, C)
+	C, t16 = madd32(m, q8>>32, t17, C)
+	C, t17 = madd32(m, q9&mask32, t18, C)
+	C, t18 = madd32(m, q9>>32, t19, C)
+	C += t20
+	t19, t20 = C&mask32, t21+C>>32
+
+	// round 14: (C,t) := t + x⋅y14
+	C, t0 = madd32(x0, y14, t0, 0)
+	C, t1 = madd32(x1, y14, t1, C)
+	C, t2 = madd32(x2, y14, t2, C)
+	C, t3 = madd32(x3, y14, t3, C)
+	C, t4 = madd32(x4, y14, t4, C)
+	C, t5 = madd32(x5, y14, t5, C)
+	C, t6 = madd32(x6, y14, t6, C)
+	C, t7 = madd32(x7, y14, t7, C)
+	C, t8 = madd32(x8, y14, t8, C)
+	C, t9 = madd32(x9, y14, t9, C)
+	C, t10 = madd32(x10, y14, t10, C)
+	C, t11 = madd32(x11, y14, t11, C)
+	C, t12 = madd32(x12, y14, t12, C)
+	C, t13 = madd32(x13, y14, t13, C)
+	C, t14 = madd32(x14, y14, t14, C)
+	C, t15 = madd32(x15, y14, t15, C)
+	C, t16 = madd32(x16, y14, t16, C)
+	C, t17 = madd32(x17, y14, t17, C)
+	C, t18 = madd32(x18, y14, t18, C)
+	C, t19 = madd32(x19, y14, t19, C)
+	C += t20
+	t20, t21 = C&mask32, C>>32
+
+	// (C,t) := (t + m⋅q) / 2³²
+	m = (t0 * qInvNeg) & mask32
+	C, _ = madd32(m, q0&mask32, t0, 0)
+	C, t0 = madd32(m, q0>>32, t1, C)
+	C, t1 = madd32(m, q1&mask32, t2, C)
+	C, t2 = madd32(m, q1>>32, t3, C)
+	C, t3 = madd32(m, q2&mask32, t4, C)
+	C, t4 = madd32(m, q2>>32, t5, C)
+	C, t5 = madd32(m, q3&mask32, t6, C)
+	C, t6 = madd32(m, q3>>32, t7, C)
+	C, t7 = madd32(m, q4&mask32, t8, C)
+	C, t8 = madd32(m, q4>>32, t9, C)
+	C, t9 = madd32(m, q5&mask32, t10, C)
+	C, t10 = madd32(m, q5>>32, t11, C)
+	C, t11 = madd32(m, q6&mask32, t12, C)
+	C, t12 = madd32(m, q6>>32, t13, C)
+	C, t13 = madd32(m, q7&mask32, t14, C)
+	C, t14 = madd32(m, q7>>32, t15, C)
+	C, t15 = madd32(m, q8&mask32, t16, C)
+	C, t16 = madd32(m, q8>>32, t17, C)
+	C, t17 = madd32(m, q9&mask32, t18, C)
+	C, t18 = madd32(m, q9>>32, t19, C)
+	C += t20
+	t19, t20 = C&mask32, t21+C>>32
+
+	// round 15: (C,t) := t + x⋅y15
+	C, t0 = madd32(x0, y15, t0, 0)
+	C, t1 = madd32(x1, y15, t1, C)
+	C, t2 = madd32(x2, y15, t2, C)
+	C, t3 = madd32(x3, y15, t3, C)
+	C, t4 = madd32(x4, y15, t4, C)
+	C, t5 = madd32(x5, y15, t5, C)
+	C, t6 = madd32(x6, y15, t6, C)
+	C, t7 = madd32(x7, y15, t7, C)
+	C, t8 = madd32(x8, y15, t8, C)
+	C, t9 = madd32(x9, y15, t9, C)
+	C, t10 = madd32(x10, y15, t10, C)
+	C, t11 = madd32(x11, y15, t11, C)
+	C, t12 = madd32(x12, y15, t12, C)
+	C, t13 = madd32(x13, y15, t13, C)
+	C, t14 = madd32(x14, y15, t14, C)
+	C, t15 = madd32(x15, y15, t15, C)
+	C, t16 = madd32(x16, y15, t16, C)
+	C, t17 = madd32(x17, y15, t17, C)
+	C, t18 = madd32(x18, y15, t18, C)
+	C, t19 = madd32(x19, y15, t19, C)
+	C += t20
+	t20, t21 = C&mask32, C>>32
+
+	// (C,t) := (t + m⋅q) / 2³²
+	m = (t0 * qInvNeg) & mask32
+	C, _ = madd32(m, q0&mask32, t0, 0)
+	C, t0 = madd32(m, q0>>32, t1, C)
+	C, t1 = madd32(m, q1&mask32, t2, C)
+	C, t2 = madd32(m, q1>>32, t3, C)
+	C, t3 = madd32(m, q2&mask32, t4, C)
+	C, t4 = madd32(m, q2>>32, t5, C)
+	C, t5 = madd32(m, q3&mask32, t6, C)
+	C, t6 = madd32(m, q3>>32, t7, C)
+	C, t7 = madd32(m, q4&mask32, t8, C)
+	C, t8 = madd32(m, q4>>32, t9, C)
+	C, t9 = madd32(m, q5&mask32, t10, C)
+	C, t10 = madd32(m, q5>>32, t11, C)
+	C, t11 = madd32(m, q6&mask32, t12, C)
+	C, t12 = madd32(m, q6>>32, t13, C)
+	C, t13 = madd32(m, q7&mask32, t14, C)
+	C, t14 = madd32(m, q7>>32, t15, C)
+	C, t15 = madd32(m, q8&mask32, t16, C)
+	C, t16 = madd32(m, q8>>32, t17, C)
+	C, t17 = madd32(m, q9&mask32, t18, C)
+	C, t18 = madd32(m, q9>>32, t19, C)
+	C += t20
+	t19, t20 = C&mask32, t21+C>>32
+
+	// round 16: (C,t) := t + x⋅y16
+	C, t0 = madd32(x0, y16, t0, 0)
+	C, t1 = madd32(x1, y16, t1, C)
+	C, t2 = madd32(x2, y16, t2, C)
+	C, t3 = madd32(x3, y16, t3, C)
+	C, t4 = madd32(x4, y16, t4, C)
+	C, t5 = madd32(x5, y16, t5, C)
+	C, t6 = madd32(x6, y16, t6, C)
+	C, t7 = madd32(x7, y16, t7, C)
+	C, t8 = madd32(x8, y16, t8, C)
+	C, t9 = madd32(x9, y16, t9, C)
+	C, t10 = madd32(x10, y16, t10, C)
+	C, t11 = madd32(x11, y16, t11, C)
+	C, t12 = madd32(x12, y16, t12, C)
+	C, t13 = madd32(x13, y16, t13, C)
+	C, t14 = madd32(x14, y16, t14, C)
+	C, t15 = madd32(x15, y16, t15, C)
+	C, t16 = madd32(x16, y16, t16, C)
+	C, t17 = madd32(x17, y16, t17, C)
+	C, t18 = madd32(x18, y16, t18, C)
+	C, t19 = madd32(x19, y16, t19, C)
+	C += t20
+	t20, t21 = C&mask32, C>>32
+
+	// (C,t) := (t + m⋅q) / 2³²
+	m = (t0 * qInvNeg) & mask32
+	C, _ = madd32(m, q0&mask32, t0, 0)
+	C, t0 = madd32(m, q0>>32, t1, C)
+	C, t1 = madd32(m, q1&mask32, t2, C)
+	C, t2 = madd32(m, q1>>32, t3, C)
+	C, t3 = madd32(m, q2&mask32, t4, C)
+	C, t4 = madd32(m, q2>>32, t5, C)
+	C, t5 = madd32(m, q3&mask32, t6, C)
+	C, t6 = madd32(m, q3>>32, t7, C)
+	C, t7 = madd32(m, q4&mask32, t8, C)
+	C, t8 = madd32(m, q4>>32, t9, C)
+	C, t9 = madd32(m, q5&mask32, t10, C)
+	C, t10 = madd32(m, q5>>32, t11, C)
+	C, t11 = madd32(m, q6&mask32, t12, C)
+	C, t12 = madd32(m, q6>>32, t13, C)
+	C, t13 = madd32(m, q7&mask32, t14, C)
+	C, t14 = madd32(m, q7>>32, t15, C)
+	C, t15 = madd32(m, q8&mask32, t16, C)
+	C, t16 = madd32(m, q8>>32, t17, C)
+	C, t17 = madd32(m, q9&mask32, t18, C)
+	C, t18 = madd32(m, q9>>32, t19, C)
+	C += t20
+	t19, t20 = C&mask32, t21+C>>32
+
+	// round 17: (C,t) := t + x⋅y17
+	C, t0 = madd32(x0, y17, t0, 0)
+	C, t1 = madd32(x1, y17, t1, C)
+	C, t2 = madd32(x2, y17, t2, C)
+	C, t3 = madd32(x3, y17, t3, C)
+	C, t4 = madd32(x4, y17, t4, C)
+	C, t5 = madd32(x5, y17, t5, C)
+	C, t6 = madd32(x6, y17, t6, C)
+	C, t7 = madd32(x7, y17, t7, C)
+	C, t8 = madd32(x8, y17, t8, C)
+	C, t9 = madd32(x9, y17, t9, C)
+	C, t10 = madd32(x10, y17, t10, C)
+	C, t11 = madd32(x11, y17, t11, C)
+	C, t12 = madd32(x12, y17, t12, C)
+	C, t13 = madd32(x13, y17, t13, C)
+	C, t14 = madd32(x14, y17, t14, C)
+	C, t15 = madd32(x15, y17, t15, C)
+	C, t16 = madd32(x16, y17, t16, C)
+	C, t17 = madd32(x17, y17, t17, C)
+	C, t18 = madd32(x18, y17, t18, C)
+	C, t19 = madd32(x19, y17, t19, C)
+	C += t20
+	t20, t21 = C&mask32, C>>32
+
+	// (C,t) := (t + m⋅q) / 2³²
+	m = (t0 * qInvNeg) & mask32
+	C, _ = madd32(m, q0&mask32, t0, 0)
+	C, t0 = madd32(m, q0>>32, t1, C)
+	C, t1 = madd32(m, q1&mask32, t2, C)
+	C, t2 = madd32(m, q1>>32, t3, C)
+	C, t3 = madd32(m, q2&mask32, t4, C)
+	C, t4 = madd32(m, q2>>32, t5, C)
+	C, t5 = madd32(m, q3&mask32, t6, C)
+	C, t6 = madd32(m, q3>>32, t7, C)
+	C, t7 = madd32(m, q4&mask32, t8, C)
+	C, t8 = madd32(m, q4>>32, t9, C)
+	C, t9 = madd32(m, q5&mask32, t10, C)
+	C, t10 = madd32(m, q5>>32, t11, C)
+	C, t11 = madd32(m, q6&mask32, t12, C)
+	C, t12 = madd32(m, q6>>32, t13, C)
+	C, t13 = madd32(m, q7&mask32, t14, C)
+	C, t14 = madd32(m, q7>>32, t15, C)
+	C, t15 = madd32(m, q8&mask32, t16, C)
+	C, t16 = madd32(m, q8>>32, t17, C)
+	C, t17 = madd32(m, q9&mask32, t18, C)
+	C, t18 = madd32(m, q9>>32, t19, C)
+	C += t20
+	t19, t20 = C&mask32, t21+C>>32
+
+	// round 18: (C,t) := t + x⋅y18
+	C, t0 = madd32(x0, y18, t0, 0)
+	C, t1 = madd32(x1, y18, t1, C)
+	C, t2 = madd32(x2, y18, t2, C)
+	C, t3 = madd32(x3, y18, t3, C)
+	C, t4 = madd32(x4, y18, t4, C)
+	C, t5 = madd32(x5, y18, t5, C)
+	C, t6 = madd32(x6, y18, t6, C)
+	C, t7 = madd32(x7, y18, t7, C)
+	C, t8 = madd32(x8, y18, t8, C)
+	C, t9 = madd32(x9, y18, t9, C)
+	C, t10 = madd32(x10, y18, t10, C)
+	C, t11 = madd32(x11, y18, t11, C)
+	C, t12 = madd32(x12, y18, t12, C)
+	C, t13 = madd32(x13, y18, t13, C)
+	C, t14 = madd32(x14, y18, t14, C)
+	C, t15 = madd32(x15, y18, t15, C)
+	C, t16 = madd32(x16, y18, t16, C)
+	C, t17 = madd32(x17, y18, t17, C)
+	C, t18 = madd32(x18, y18, t18, C)
+	C, t19 = madd32(x19, y18, t19, C)
+	C += t20
+	t20, t21 = C&mask32, C>>32
+
+	// (C,t) := (t + m⋅q) / 2³²
+	m = (t0 * qInvNeg) & mask32
+	C, _ = madd32(m, q0&mask32, t0, 0)
+	C, t0 = madd32(m, q0>>32, t1, C)
+	C, t1 = madd32(m, q1&mask32, t2, C)
+	C, t2 = madd32(m, q1>>32, t3, C)
+	C, t3 = madd32(m, q2&mask32, t4, C)
+	C, t4 = madd32(m, q2>>32, t5, C)
+	C, t5 = madd32(m, q3&mask32, t6, C)
+	C, t6 = madd32(m, q3>>32, t7, C)
+	C, t7 = madd32(m, q4&mask32, t8, C)
+	C, t8 = madd32(m, q4>>32, t9, C)
+	C, t9 = madd32(m, q5&mask32, t10, C)
+	C, t10 = madd32(m, q5>>32, t11, C)
+	C, t11 = madd32(m, q6&mask32, t12, C)
+	C, t12 = madd32(m, q6>>32, t13, C)
+	C, t13 = madd32(m, q7&mask32, t14, C)
+	C, t14 = madd32(m, q7>>32, t15, C)
+	C, t15 = madd32(m, q8&mask32, t16, C)
+	C, t16 = madd32(m, q8>>32, t17, C)
+	C, t17 = madd32(m, q9&mask32, t18, C)
+	C, t18 = madd32(m, q9>>32, t19, C)
+	C += t20
+	t19, t20 = C&mask32, t21+C>>32
+
+	// round 19: (C,t) := t + x⋅y19
+	C, t0 = madd32(x0, y19, t0, 0)
+	C, t1 = madd32(x1, y19, t1, C)
+	C, t2 = madd32(x2, y19, t2, C)
+	C, t3 = madd32(x3, y19, t3, C)
+	C, t4 = madd32(x4, y19, t4, C)
+	C, t5 = madd32(x5, y19, t5, C)
+	C, t6 = madd32(x6, y19, t6, C)
+	C, t7 = madd32(x7, y19, t7, C)
+	C, t8 = madd32(x8, y19, t8, C)
+	C, t9 = madd32(x9, y19, t9, C)
+	C, t10 = madd32(x10, y19, t10, C)
+	C, t11 = madd32(x11, y19, t11, C)
+	C, t12 = madd32(x12, y19, t12, C)
+	C, t13 = madd32(x13, y19, t13, C)
+	C, t14 = madd32(x14, y19, t14, C)
+	C, t15 = madd32(x15, y19, t15, C)
+	C, t16 = madd32(x16, y19, t16, C)
+	C, t17 = madd32(x17, y19, t17, C)
+	C, t18 = madd32(x18, y19, t18, C)
+	C, t19 = madd32(x19, y19, t19, C)
+	C += t20
+	t20, t21 = C&mask32, C>>32
+
+	// (C,t) := (t + m⋅q) / 2³²
+	m = (t0 * qInvNeg) & mask32
+	C, _ = madd32(m, q0&mask32, t0, 0)
+	C, t0 = madd32(m, q0>>32, t1, C)
+	C, t1 = madd32(m, q1&mask32, t2, C)
+	C, t2 = madd32(m, q1>>32, t3, C)
+	C, t3 = madd32(m, q2&mask32, t4, C)
+	C, t4 = madd32(m, q2>>32, t5, C)
+	C, t5 = madd32(m, q3&mask32, t6, C)
+	C, t6 = madd32(m, q3>>32, t7, C)
+	C, t7 = madd32(m, q4&mask32, t8, C)
+	C, t8 = madd32(m, q4>>32, t9, C)
+	C, t9 = madd32(m, q5&mask32, t10, C)
+	C, t10 = madd32(m, q5>>32, t11, C)
+	C, t11 = madd32(m, q6&mask32, t12, C)
+	C, t12 = madd32(m, q6>>32, t13, C)
+	C, t13 = madd32(m, q7&mask32, t14, C)
+	C, t14 = madd32(m, q7>>32, t15, C)
+	C, t15 = madd32(m, q8&mask32, t16, C)
+	C, t16 = madd32(m, q8>>32, t17, C)
+	C, t17 = madd32(m, q9&mask32, t18, C)
+	C, t18 = madd32(m, q9>>32, t19, C)
+	C += t20
+	t19, t20 = C&mask32, t21+C>>32
+	z[0] = t0 | t1<<32
+	z[1] = t2 | t3<<32
+	z[2] = t4 | t5<<32
+	z[3] = t6 | t7<<32
+	z[4] = t8 | t9<<32
+	z[5] = t10 | t11<<32
+	z[6] = t12 | t13<<32
+	z[7] = t14 | t15<<32
+	z[8] = t16 | t17<<32
+	z[9] = t18 | t19<<32
+
+	// if z >= q or t20 != 0 → z -= q
+	// this is done without branching on the value of z
+	{
+		r0, b := bits.Sub64(z[0], q0, 0)
+		r1, b := bits.Sub64(z[1], q1, b)
+		r2, b := bits.Sub64(z[2], q2, b)
+		r3, b := bits.Sub64(z[3], q3, b)
+		r4, b := bits.Sub64(z[4], q4, b)
+		r5, b := bits.Sub64(z[5], q5, b)
+		r6, b := bits.Sub64(z[6], q6, b)
+		r7, b := bits.Sub64(z[7], q7, b)
+		r8, b := bits.Sub64(z[8], q8, b)
+		r9, b := bits.Sub64(z[9], q9, b)
+		mask := -(t20 | (b ^ 1)) // all ones if we need to subtract q
+		z[0] ^= mask & (z[0] ^ r0)
+		z[1] ^= mask & (z[1] ^ r1)
+		z[2] ^= mask & (z[2] ^ r2)
+		z[3] ^= mask & (z[3] ^ r3)
+		z[4] ^= mask & (z[4] ^ r4)
+		z[5] ^= mask & (z[5] ^ r5)
+		z[6] ^= mask & (z[6] ^ r6)
+		z[7] ^= mask & (z[7] ^ r7)
+		z[8] ^= mask & (z[8] ^ r8)
+		z[9] ^= mask & (z[9] ^ r9)
+	}
+}
+
+func fromMont(z *Element) {
+	_fromMontGeneric(z)
+}
+
+func reduce(z *Element) {
+	_reduceGeneric(z)
+}
@@ -0,0 +1,37 @@
+//go:build !purego
+// +build !purego
+
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package fr
+
+import (
+	"unsafe"
+
+	"github.com/consensys/gnark-crypto/ecc"
+)
+
+// MakeFromArena returns a slice of n zeroed elements, backed by the memory of arena.
+// If arena is nil or exhausted, the slice is allocated on the heap.
+func MakeFromArena(arena *ecc.Arena, n int) []Element {
+	if n == 0 {
+		return []Element{}
+	}
+	words := arena.Words(n * Limbs)
+	// an Element is an array of Limbs words, so the layouts match
+	return unsafe.Slice((*Element)(unsafe.Pointer(&words[0])), n)
+}
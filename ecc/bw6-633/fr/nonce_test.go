@@ -0,0 +1,111 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package fr
+
+import (
+	"crypto/sha256"
+	"math/big"
+	"testing"
+)
+
+func TestNonceRFC6979Vectors(t *testing.T) {
+	t.Parallel()
+
+	// RFC 6979, appendix A.1 (q of 163 bits) and A.2.5 (P-256), SHA-256, message "sample"
+	vectors := []struct {
+		q, x, k string
+	}{
+		{
+			q: "4000000000000000000020108A2E0CC0D99F8A5EF",
+			x: "09A4D6792295A7F730FC3F2B49CBC0F62E862272F",
+			k: "23AF4074C90A02B3FE61D286D5C87F425E6BDD81B",
+		},
+		{
+			q: "FFFFFFFF00000000FFFFFFFFFFFFFFFFBCE6FAADA7179E84F3B9CAC2FC632551",
+			x: "C9AFA9D845BA75166B5C215767B1D6934E50C3DB36E89B127B8A622B120F6721",
+			k: "A6E3C57DD01ABE90086538398355DD4C3B17AA873382B0F24D6129493D8AAD60",
+		},
+	}
+	msgHash := sha256.Sum256([]byte("sample"))
+	for _, v := range vectors {
+		var q, x, expected, k big.Int
+		q.SetString(v.q, 16)
+		x.SetString(v.x, 16)
+		expected.SetString(v.k, 16)
+
+		d := newDRBG(sha256.New, &q, q.BitLen(), &x, msgHash[:])
+		d.next(&k)
+		if k.Cmp(&expected) != 0 {
+			t.Fatalf("expected %x, got %x", &expected, &k)
+		}
+	}
+}
+
+func TestNonceRFC6979(t *testing.T) {
+	t.Parallel()
+
+	var key Element
+	key.SetRandom()
+	h1 := sha256.Sum256([]byte("message"))
+	h2 := sha256.Sum256([]byte("other message"))
+
+	k1, err := NonceRFC6979(&key, h1[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	k1b, err := NonceRFC6979(&key, h1[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	k2, err := NonceRFC6979(&key, h2[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !k1.Equal(&k1b) {
+		t.Fatal("the nonce should be deterministic")
+	}
+	if k1.Equal(&k2) || k1.IsZero() {
+		t.Fatal("unexpected nonce")
+	}
+
+	// the nonce is the first element of the stream, the next ones are distinct
+	d, err := NewDRBG(sha256.New, &key, h1[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first := d.Next(); !first.Equal(&k1) {
+		t.Fatal("the nonce should be the first element of the DRBG stream")
+	}
+	if second := d.Next(); second.Equal(&k1) {
+		t.Fatal("the DRBG should produce distinct elements")
+	}
+
+	var zero Element
+	if _, err := NonceRFC6979(&zero, h1[:]); err != ErrNonceKey {
+		t.Fatalf("expected %v, got %v", ErrNonceKey, err)
+	}
+}
+
+func BenchmarkNonceRFC6979(b *testing.B) {
+	var key Element
+	key.SetRandom()
+	h := sha256.Sum256([]byte("message"))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = NonceRFC6979(&key, h[:])
+	}
+}
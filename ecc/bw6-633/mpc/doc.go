@@ -0,0 +1,28 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+// Package mpc implements phase 1 (powers of tau) of a multi-party
+// computation trusted setup ceremony.
+//
+// Each participant multiplies the secret τ by a random x, and publishes a proof of
+// knowledge of x along with the updated powers [τⁱ]G₁ and [τ]G₂. Anyone can check
+// that a contribution is consistent with the previous state using pairings, and the
+// ceremony is secure as long as one participant destroyed their x. Each state is
+// chained to the previous one by the hash of the previous state, and the last
+// contribution is usually a random beacon.
+//
+// The output can be used as a KZG SRS.
+package mpc
@@ -20,7 +20,8 @@ import (
 	"fmt"
 	"math/big"
 	"math/bits"
-	"runtime"
+	"path/filepath"
+	"reflect"
 	"sync"
 	"testing"
 
@@ -71,7 +72,7 @@ func TestMultiExpG1(t *testing.T) {
 				copy(samplePointsLarge[i*nbSamples:], samplePoints[:])
 			}
 
-			var r16, splitted1, splitted2 G1Jac
+			var r16, splitted1, splitted2, mont G1Jac
 
 			// mixer ensures that all the words of a fpElement are set
 			var sampleScalars [nbSamples * 13]fr.Element
@@ -82,12 +83,17 @@ func TestMultiExpG1(t *testing.T) {
 					FromMont()
 			}
 
-			scalars16, _ := partitionScalars(sampleScalars[:], 16, false, runtime.NumCPU())
-			r16.msmC16(samplePoints[:], scalars16, true)
+			r16.msmC16(samplePoints[:], sampleScalars[:], false, true)
 
 			splitted1.MultiExp(samplePointsLarge[:], sampleScalars[:], ecc.MultiExpConfig{NbTasks: 128})
 			splitted2.MultiExp(samplePointsLarge[:], sampleScalars[:], ecc.MultiExpConfig{NbTasks: 51})
-			return r16.Equal(&splitted1) && r16.Equal(&splitted2)
+			// the digits of scalars in Montgomery form are extracted on the fly
+			sampleScalarsMont := sampleScalars
+			for i := range sampleScalarsMont {
+				sampleScalarsMont[i].ToMont()
+			}
+			mont.MultiExp(samplePointsLarge[:], sampleScalarsMont[:], ecc.MultiExpConfig{ScalarsMont: true})
+			return r16.Equal(&splitted1) && r16.Equal(&splitted2) && r16.Equal(&mont)
 		},
 		genScalar,
 	))
@@ -120,11 +126,10 @@ func TestMultiExpG1(t *testing.T) {
 
 			results := make([]G1Jac, len(cRange)+1)
 			for i, c := range cRange {
-				scalars, _ := partitionScalars(sampleScalars[:], c, false, runtime.NumCPU())
-				msmInnerG1Jac(&results[i], int(c), samplePoints[:], scalars, false)
+				msmInnerG1Jac(&results[i], int(c), samplePoints[:], sampleScalars[:], false, false)
 				if c == 16 {
 					// split the first chunk
-					msmInnerG1Jac(&results[len(results)-1], 16, samplePoints[:], scalars, true)
+					msmInnerG1Jac(&results[len(results)-1], 16, samplePoints[:], sampleScalars[:], false, true)
 				}
 			}
 			for i := 1; i < len(results); i++ {
@@ -175,6 +180,62 @@ func TestMultiExpG1(t *testing.T) {
 	properties.TestingRun(t, gopter.ConsoleReporter(false))
 }
 
+func TestMultiExpTuningG1(t *testing.T) {
+	const logSize = 6
+
+	var sampleScalars [1 << logSize]fr.Element
+	for i := range sampleScalars {
+		sampleScalars[i].SetRandom()
+	}
+	samplePoints := BatchScalarMultiplicationG1(&g1GenAff, sampleScalars[:])
+	for i := range sampleScalars {
+		sampleScalars[i].SetRandom()
+	}
+
+	var expected G1Jac
+	expected.MultiExp(samplePoints, sampleScalars[:], ecc.MultiExpConfig{})
+
+	// window size set in the config
+	var r G1Jac
+	if _, err := r.MultiExp(samplePoints, sampleScalars[:], ecc.MultiExpConfig{C: 5}); err != nil {
+		t.Fatal(err)
+	}
+	if !r.Equal(&expected) {
+		t.Fatal("MultiExp with config.C set is wrong")
+	}
+	if _, err := r.MultiExp(samplePoints, sampleScalars[:], ecc.MultiExpConfig{C: 3}); err == nil {
+		t.Fatal("MultiExp should fail with a window size which is not implemented")
+	}
+
+	// calibration, persisted and loaded back
+	path := filepath.Join(t.TempDir(), "tuning.json")
+	if err := AutoCalibrateMultiExpG1(path, logSize); err != nil {
+		t.Fatal(err)
+	}
+	defer SetMultiExpTuningG1(nil)
+	if len(multiExpTuningG1) != logSize-minLogSizeCalibration+1 {
+		t.Fatal("unexpected number of calibrated sizes")
+	}
+	calibrated := multiExpTuningG1
+	if err := AutoCalibrateMultiExpG1(path, logSize); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(calibrated, multiExpTuningG1) {
+		t.Fatal("the persisted tuning should be loaded")
+	}
+
+	if _, err := r.MultiExp(samplePoints, sampleScalars[:], ecc.MultiExpConfig{}); err != nil {
+		t.Fatal(err)
+	}
+	if !r.Equal(&expected) {
+		t.Fatal("MultiExp with a calibrated window size is wrong")
+	}
+
+	if err := SetMultiExpTuningG1(ecc.MultiExpTuning{logSize: 3}); err == nil {
+		t.Fatal("SetMultiExpTuning should fail with a window size which is not implemented")
+	}
+}
+
 func BenchmarkMultiExpG1(b *testing.B) {
 
 	const (
@@ -316,7 +377,7 @@ func TestMultiExpG2(t *testing.T) {
 				copy(samplePointsLarge[i*nbSamples:], samplePoints[:])
 			}
 
-			var r16, splitted1, splitted2 G2Jac
+			var r16, splitted1, splitted2, mont G2Jac
 
 			// mixer ensures that all the words of a fpElement are set
 			var sampleScalars [nbSamples * 13]fr.Element
@@ -327,12 +388,17 @@ func TestMultiExpG2(t *testing.T) {
 					FromMont()
 			}
 
-			scalars16, _ := partitionScalars(sampleScalars[:], 16, false, runtime.NumCPU())
-			r16.msmC16(samplePoints[:], scalars16, true)
+			r16.msmC16(samplePoints[:], sampleScalars[:], false, true)
 
 			splitted1.MultiExp(samplePointsLarge[:], sampleScalars[:], ecc.MultiExpConfig{NbTasks: 128})
 			splitted2.MultiExp(samplePointsLarge[:], sampleScalars[:], ecc.MultiExpConfig{NbTasks: 51})
-			return r16.Equal(&splitted1) && r16.Equal(&splitted2)
+			// the digits of scalars in Montgomery form are extracted on the fly
+			sampleScalarsMont := sampleScalars
+			for i := range sampleScalarsMont {
+				sampleScalarsMont[i].ToMont()
+			}
+			mont.MultiExp(samplePointsLarge[:], sampleScalarsMont[:], ecc.MultiExpConfig{ScalarsMont: true})
+			return r16.Equal(&splitted1) && r16.Equal(&splitted2) && r16.Equal(&mont)
 		},
 		genScalar,
 	))
@@ -363,11 +429,10 @@ func TestMultiExpG2(t *testing.T) {
 
 			results := make([]G2Jac, len(cRange)+1)
 			for i, c := range cRange {
-				scalars, _ := partitionScalars(sampleScalars[:], c, false, runtime.NumCPU())
-				msmInnerG2Jac(&results[i], int(c), samplePoints[:], scalars, false)
+				msmInnerG2Jac(&results[i], int(c), samplePoints[:], sampleScalars[:], false, false)
 				if c == 16 {
 					// split the first chunk
-					msmInnerG2Jac(&results[len(results)-1], 16, samplePoints[:], scalars, true)
+					msmInnerG2Jac(&results[len(results)-1], 16, samplePoints[:], sampleScalars[:], false, true)
 				}
 			}
 			for i := 1; i < len(results); i++ {
@@ -418,6 +483,62 @@ func TestMultiExpG2(t *testing.T) {
 	properties.TestingRun(t, gopter.ConsoleReporter(false))
 }
 
+func TestMultiExpTuningG2(t *testing.T) {
+	const logSize = 6
+
+	var sampleScalars [1 << logSize]fr.Element
+	for i := range sampleScalars {
+		sampleScalars[i].SetRandom()
+	}
+	samplePoints := BatchScalarMultiplicationG2(&g2GenAff, sampleScalars[:])
+	for i := range sampleScalars {
+		sampleScalars[i].SetRandom()
+	}
+
+	var expected G2Jac
+	expected.MultiExp(samplePoints, sampleScalars[:], ecc.MultiExpConfig{})
+
+	// window size set in the config
+	var r G2Jac
+	if _, err := r.MultiExp(samplePoints, sampleScalars[:], ecc.MultiExpConfig{C: 5}); err != nil {
+		t.Fatal(err)
+	}
+	if !r.Equal(&expected) {
+		t.Fatal("MultiExp with config.C set is wrong")
+	}
+	if _, err := r.MultiExp(samplePoints, sampleScalars[:], ecc.MultiExpConfig{C: 3}); err == nil {
+		t.Fatal("MultiExp should fail with a window size which is not implemented")
+	}
+
+	// calibration, persisted and loaded back
+	path := filepath.Join(t.TempDir(), "tuning.json")
+	if err := AutoCalibrateMultiExpG2(path, logSize); err != nil {
+		t.Fatal(err)
+	}
+	defer SetMultiExpTuningG2(nil)
+	if len(multiExpTuningG2) != logSize-minLogSizeCalibration+1 {
+		t.Fatal("unexpected number of calibrated sizes")
+	}
+	calibrated := multiExpTuningG2
+	if err := AutoCalibrateMultiExpG2(path, logSize); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(calibrated, multiExpTuningG2) {
+		t.Fatal("the persisted tuning should be loaded")
+	}
+
+	if _, err := r.MultiExp(samplePoints, sampleScalars[:], ecc.MultiExpConfig{}); err != nil {
+		t.Fatal(err)
+	}
+	if !r.Equal(&expected) {
+		t.Fatal("MultiExp with a calibrated window size is wrong")
+	}
+
+	if err := SetMultiExpTuningG2(ecc.MultiExpTuning{logSize: 3}); err == nil {
+		t.Fatal("SetMultiExpTuning should fail with a window size which is not implemented")
+	}
+}
+
 func BenchmarkMultiExpG2(b *testing.B) {
 
 	const (
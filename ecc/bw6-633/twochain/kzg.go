@@ -0,0 +1,116 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package twochain
+
+import (
+	"math/big"
+
+	bls24315 "github.com/consensys/gnark-crypto/ecc/bls24-315"
+	bls24315fr "github.com/consensys/gnark-crypto/ecc/bls24-315/fr"
+	"github.com/consensys/gnark-crypto/ecc/bls24-315/fr/kzg"
+	"github.com/consensys/gnark-crypto/ecc/bw6-633/fr"
+)
+
+const (
+	// NbElementsKZGVerifyingKey is the number of fr elements of the encoding of a bls24-315
+	// KZG verifying key: G₁, G₂ and [α]G₂
+	NbElementsKZGVerifyingKey = NbElementsG1 + 2*NbElementsG2
+
+	// NbElementsKZGStatement is the number of fr elements of the encoding of a bls24-315
+	// KZG opening statement: the digest, the quotient H, the point and the claimed value
+	NbElementsKZGStatement = 2*NbElementsG1 + 2
+)
+
+// KZGStatement is the statement of a bls24-315 KZG opening proof: the polynomial committed in
+// Digest evaluates to Proof.ClaimedValue at Point.
+type KZGStatement struct {
+	Digest kzg.Digest
+	Proof  kzg.OpeningProof
+	Point  bls24315fr.Element
+}
+
+// KZGVerifyingKeyToFr returns the encoding of the part of the SRS used by the verifier,
+// G₁ = srs.G1[0], G₂ = srs.G2[0] and [α]G₂ = srs.G2[1], in this order.
+func KZGVerifyingKeyToFr(srs *kzg.SRS) [NbElementsKZGVerifyingKey]fr.Element {
+	var res [NbElementsKZGVerifyingKey]fr.Element
+	g1 := G1ToFr(&srs.G1[0])
+	g2 := G2ToFr(&srs.G2[0])
+	alphaG2 := G2ToFr(&srs.G2[1])
+	n := copy(res[:], g1[:])
+	n += copy(res[n:], g2[:])
+	copy(res[n:], alphaG2[:])
+	return res
+}
+
+// ToFr returns the encoding of the statement: the digest, the quotient H, the point
+// and the claimed value, in this order. The scalars are embedded with ScalarToFr.
+func (s *KZGStatement) ToFr() [NbElementsKZGStatement]fr.Element {
+	var res [NbElementsKZGStatement]fr.Element
+	digest := G1ToFr(&s.Digest)
+	h := G1ToFr(&s.Proof.H)
+	n := copy(res[:], digest[:])
+	n += copy(res[n:], h[:])
+	res[n] = ScalarToFr(&s.Point)
+	res[n+1] = ScalarToFr(&s.Proof.ClaimedValue)
+	return res
+}
+
+// KZGStatementFromFr returns the statement encoded in c, as output by KZGStatement.ToFr.
+// It returns ErrInvalidPoint or ErrNotInScalarField if c is not a valid encoding.
+func KZGStatementFromFr(c [NbElementsKZGStatement]fr.Element) (KZGStatement, error) {
+	var s KZGStatement
+	var err error
+	if s.Digest, err = G1FromFr([NbElementsG1]fr.Element{c[0], c[1]}); err != nil {
+		return s, err
+	}
+	if s.Proof.H, err = G1FromFr([NbElementsG1]fr.Element{c[2], c[3]}); err != nil {
+		return s, err
+	}
+	if s.Point, err = FrToScalar(&c[4]); err != nil {
+		return s, err
+	}
+	if s.Proof.ClaimedValue, err = FrToScalar(&c[5]); err != nil {
+		return s, err
+	}
+	return s, nil
+}
+
+// PairingInputs returns the inputs of the pairing check equivalent to kzg.Verify:
+//
+//	e(Digest - [ClaimedValue]G₁ + [Point]H, G₂)·e(-H, [α]G₂) == 1
+//
+// Unlike the check of kzg.Verify, e(Digest - [ClaimedValue]G₁, G₂)·e(-H, [α - Point]G₂) == 1,
+// the G₂ arguments are the ones of the verifying key: a verifier circuit needs no G₂ arithmetic,
+// and can precompute the lines of the Miller loop.
+func (s *KZGStatement) PairingInputs(srs *kzg.SRS) ([2]bls24315.G1Affine, [2]bls24315.G2Affine) {
+	var b big.Int
+	var folded, tmp bls24315.G1Jac
+	folded.FromAffine(&s.Digest)
+
+	s.Proof.ClaimedValue.ToBigIntRegular(&b)
+	tmp.FromAffine(&srs.G1[0])
+	tmp.ScalarMultiplication(&tmp, &b)
+	folded.SubAssign(&tmp)
+
+	s.Point.ToBigIntRegular(&b)
+	tmp.FromAffine(&s.Proof.H)
+	tmp.ScalarMultiplication(&tmp, &b)
+	folded.AddAssign(&tmp)
+
+	var p [2]bls24315.G1Affine
+	p[0].FromJacobian(&folded)
+	p[1].Neg(&s.Proof.H)
+	return p, [2]bls24315.G2Affine{srs.G2[0], srs.G2[1]}
+}
@@ -0,0 +1,100 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package twochain
+
+import (
+	"math/big"
+	"testing"
+
+	bls24315 "github.com/consensys/gnark-crypto/ecc/bls24-315"
+	bls24315fr "github.com/consensys/gnark-crypto/ecc/bls24-315/fr"
+	"github.com/consensys/gnark-crypto/ecc/bls24-315/fr/kzg"
+	"github.com/consensys/gnark-crypto/ecc/bw6-633/fr"
+)
+
+func TestKZGStatement(t *testing.T) {
+	const size = 16
+	srs, err := kzg.NewSRS(size, big.NewInt(42))
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := make([]bls24315fr.Element, size)
+	for i := range p {
+		p[i].SetRandom()
+	}
+
+	var s KZGStatement
+	s.Point.SetRandom()
+	if s.Digest, err = kzg.Commit(p, srs); err != nil {
+		t.Fatal(err)
+	}
+	if s.Proof, err = kzg.Open(p, s.Point, srs); err != nil {
+		t.Fatal(err)
+	}
+
+	// encoding round trip
+	s2, err := KZGStatementFromFr(s.ToFr())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !s2.Digest.Equal(&s.Digest) || !s2.Proof.H.Equal(&s.Proof.H) ||
+		!s2.Point.Equal(&s.Point) || !s2.Proof.ClaimedValue.Equal(&s.Proof.ClaimedValue) {
+		t.Fatal("KZGStatementFromFr(s.ToFr()) != s")
+	}
+
+	vk := KZGVerifyingKeyToFr(srs)
+	var c2 [NbElementsG2]fr.Element
+	copy(c2[:], vk[NbElementsG1+NbElementsG2:])
+	if alphaG2, err := G2FromFr(c2); err != nil || !alphaG2.Equal(&srs.G2[1]) {
+		t.Fatal("wrong encoding of [α]G₂")
+	}
+
+	// the pairing check agrees with kzg.Verify
+	g1, g2 := s.PairingInputs(srs)
+	ok, err := bls24315.PairingCheck(g1[:], g2[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !ok {
+		t.Fatal("the pairing check of a valid statement should hold")
+	}
+
+	var one bls24315fr.Element
+	one.SetOne()
+	s.Proof.ClaimedValue.Add(&s.Proof.ClaimedValue, &one)
+	if kzg.Verify(&s.Digest, &s.Proof, s.Point, srs) == nil {
+		t.Fatal("kzg.Verify should reject a wrong claimed value")
+	}
+	g1, g2 = s.PairingInputs(srs)
+	ok, err = bls24315.PairingCheck(g1[:], g2[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ok {
+		t.Fatal("the pairing check of a wrong claimed value should not hold")
+	}
+
+	// invalid encodings
+	c := s.ToFr()
+	c[5].SetBigInt(bls24315fr.Modulus())
+	if _, err := KZGStatementFromFr(c); err != ErrNotInScalarField {
+		t.Fatalf("expected %v, got %v", ErrNotInScalarField, err)
+	}
+	c = s.ToFr()
+	c[3].Add(&c[3], &c[2])
+	if _, err := KZGStatementFromFr(c); err != ErrInvalidPoint {
+		t.Fatalf("expected %v, got %v", ErrInvalidPoint, err)
+	}
+}
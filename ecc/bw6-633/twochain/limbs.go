@@ -0,0 +1,107 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package twochain
+
+import (
+	"errors"
+	"math/big"
+
+	bls24315fr "github.com/consensys/gnark-crypto/ecc/bls24-315/fr"
+	"github.com/consensys/gnark-crypto/ecc/bw6-633/fr"
+)
+
+const (
+	// LimbBits is the number of bits of the limbs of ScalarToLimbs
+	LimbBits = 64
+
+	// NbLimbsScalar is the number of limbs of a bls24-315 scalar
+	NbLimbsScalar = (bls24315fr.Bits + LimbBits - 1) / LimbBits
+)
+
+var (
+	ErrInvalidLimbBits = errors.New("the number of bits of a limb must be in [1, fr.Bits-1]")
+	ErrLimbOverflow    = errors.New("value does not fit in the limbs")
+)
+
+// Decompose returns the nbLimbs limbs of limbBits bits of x ≥ 0, least significant first,
+// as bw6-633 scalar field elements: x = ∑ᵢ limbs[i]·2^(i·limbBits).
+//
+// It returns ErrLimbOverflow if x ≥ 2^(nbLimbs·limbBits).
+func Decompose(x *big.Int, limbBits, nbLimbs int) ([]fr.Element, error) {
+	if limbBits < 1 || limbBits >= fr.Bits {
+		return nil, ErrInvalidLimbBits
+	}
+	if x.Sign() < 0 || x.BitLen() > limbBits*nbLimbs {
+		return nil, ErrLimbOverflow
+	}
+	var mask, tmp big.Int
+	mask.Lsh(big.NewInt(1), uint(limbBits)).Sub(&mask, big.NewInt(1))
+	limbs := make([]fr.Element, nbLimbs)
+	for i := range limbs {
+		tmp.Rsh(x, uint(i*limbBits)).And(&tmp, &mask)
+		limbs[i].SetBigInt(&tmp)
+	}
+	return limbs, nil
+}
+
+// Recompose returns ∑ᵢ limbs[i]·2^(i·limbBits), the inverse of Decompose.
+//
+// It returns ErrLimbOverflow if one of the limbs doesn't fit on limbBits bits.
+func Recompose(limbs []fr.Element, limbBits int) (*big.Int, error) {
+	if limbBits < 1 || limbBits >= fr.Bits {
+		return nil, ErrInvalidLimbBits
+	}
+	var res, limb big.Int
+	for i := len(limbs) - 1; i >= 0; i-- {
+		limbs[i].ToBigIntRegular(&limb)
+		if limb.BitLen() > limbBits {
+			return nil, ErrLimbOverflow
+		}
+		res.Lsh(&res, uint(limbBits)).Add(&res, &limb)
+	}
+	return &res, nil
+}
+
+// ScalarToLimbs returns the limbs of LimbBits bits of the bls24-315 scalar s, least significant first.
+// Unlike ScalarToFr, it is meant for the arithmetic modulo the bls24-315 scalar field, which is
+// emulated in the bw6-633 scalar field with range checked limbs.
+func ScalarToLimbs(s *bls24315fr.Element) [NbLimbsScalar]fr.Element {
+	var b big.Int
+	s.ToBigIntRegular(&b)
+	var res [NbLimbsScalar]fr.Element
+	limbs, err := Decompose(&b, LimbBits, NbLimbsScalar)
+	if err != nil {
+		// s < 2^bls24315fr.Bits
+		panic(err)
+	}
+	copy(res[:], limbs)
+	return res
+}
+
+// ScalarFromLimbs returns the bls24-315 scalar with limbs c, as output by ScalarToLimbs.
+// It returns ErrLimbOverflow if a limb doesn't fit on LimbBits bits, and ErrNotInScalarField
+// if the value is not smaller than the bls24-315 scalar field modulus.
+func ScalarFromLimbs(c [NbLimbsScalar]fr.Element) (bls24315fr.Element, error) {
+	var res bls24315fr.Element
+	b, err := Recompose(c[:], LimbBits)
+	if err != nil {
+		return res, err
+	}
+	if b.Cmp(bls24315fr.Modulus()) >= 0 {
+		return res, ErrNotInScalarField
+	}
+	res.SetBigInt(b)
+	return res, nil
+}
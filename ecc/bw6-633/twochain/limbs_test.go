@@ -0,0 +1,84 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package twochain
+
+import (
+	"math/big"
+	"testing"
+
+	bls24315fr "github.com/consensys/gnark-crypto/ecc/bls24-315/fr"
+	"github.com/consensys/gnark-crypto/ecc/bw6-633/fr"
+)
+
+func TestLimbs(t *testing.T) {
+	for i := 0; i < 10; i++ {
+		var s bls24315fr.Element
+		s.SetRandom()
+		limbs := ScalarToLimbs(&s)
+
+		// x = ∑ᵢ limbs[i]·2^(64i)
+		var b, expected big.Int
+		s.ToBigIntRegular(&expected)
+		for j := NbLimbsScalar - 1; j >= 0; j-- {
+			var limb big.Int
+			limbs[j].ToBigIntRegular(&limb)
+			if limb.BitLen() > LimbBits {
+				t.Fatal("limb too large")
+			}
+			b.Lsh(&b, LimbBits).Add(&b, &limb)
+		}
+		if b.Cmp(&expected) != 0 {
+			t.Fatal("wrong decomposition")
+		}
+
+		s2, err := ScalarFromLimbs(limbs)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !s2.Equal(&s) {
+			t.Fatal("ScalarFromLimbs(ScalarToLimbs(s)) != s")
+		}
+	}
+
+	// other limb sizes
+	x := new(big.Int).Lsh(big.NewInt(1), 100)
+	x.Sub(x, big.NewInt(1))
+	limbs, err := Decompose(x, 30, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if y, err := Recompose(limbs, 30); err != nil || y.Cmp(x) != 0 {
+		t.Fatal("Recompose(Decompose(x)) != x")
+	}
+
+	// errors
+	if _, err := Decompose(x, 30, 3); err != ErrLimbOverflow {
+		t.Fatalf("expected %v, got %v", ErrLimbOverflow, err)
+	}
+	if _, err := Decompose(x, fr.Bits, 1); err != ErrInvalidLimbBits {
+		t.Fatalf("expected %v, got %v", ErrInvalidLimbBits, err)
+	}
+	limbs[1].SetBigInt(new(big.Int).Lsh(big.NewInt(1), 30))
+	if _, err := Recompose(limbs, 30); err != ErrLimbOverflow {
+		t.Fatalf("expected %v, got %v", ErrLimbOverflow, err)
+	}
+	var tooLarge [NbLimbsScalar]fr.Element
+	for i := range tooLarge {
+		tooLarge[i].SetUint64(^uint64(0))
+	}
+	if _, err := ScalarFromLimbs(tooLarge); err != ErrNotInScalarField {
+		t.Fatalf("expected %v, got %v", ErrNotInScalarField, err)
+	}
+}
@@ -0,0 +1,136 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package twochain provides helpers for one-layer proof recursion over the
+// bls24-315 / bw6-633 2-chain.
+//
+// The scalar field of bw6-633 is the base field of bls24-315, so bls24-315
+// base field elements, and the coordinates of bls24-315 G1 and G2 points, are
+// bw6-633 scalar field elements without any non-native arithmetic. The scalar
+// field of bls24-315 is smaller than the scalar field of bw6-633, so bls24-315
+// scalars embed as well.
+//
+// The statements of bls24-315 KZG opening proofs are encoded in the same way, and can be
+// rewritten so that the verifier only pairs with the G2 points of the verifying key. The
+// arithmetic modulo the bls24-315 scalar field, which is not native, works on limbs.
+package twochain
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	bls24315 "github.com/consensys/gnark-crypto/ecc/bls24-315"
+	bls24315fp "github.com/consensys/gnark-crypto/ecc/bls24-315/fp"
+	bls24315fr "github.com/consensys/gnark-crypto/ecc/bls24-315/fr"
+	"github.com/consensys/gnark-crypto/ecc/bw6-633/fr"
+)
+
+const (
+	// Inner is the curve whose points and fields are embedded
+	Inner = ecc.BLS24_315
+
+	// Outer is the curve whose scalar field receives the embedding
+	Outer = ecc.BW6_633
+
+	// NbElementsG1 is the number of fr elements needed to represent a bls24-315 G1 point
+	NbElementsG1 = 2
+
+	// NbElementsG2 is the number of fr elements needed to represent a bls24-315 G2 point
+	NbElementsG2 = 8
+)
+
+var (
+	ErrNotInScalarField = errors.New("element is not in the bls24-315 scalar field")
+	ErrInvalidPoint     = errors.New("point is not on the curve or not in the correct subgroup")
+)
+
+// FpToFr returns the bls24-315 base field element x as a bw6-633 scalar field element.
+// The two fields are the same, so this is a plain copy of the Montgomery representation.
+func FpToFr(x *bls24315fp.Element) fr.Element {
+	return fr.Element(*x)
+}
+
+// FrToFp returns the bw6-633 scalar field element x as a bls24-315 base field element.
+func FrToFp(x *fr.Element) bls24315fp.Element {
+	return bls24315fp.Element(*x)
+}
+
+// ScalarToFr embeds the bls24-315 scalar s in the bw6-633 scalar field.
+func ScalarToFr(s *bls24315fr.Element) fr.Element {
+	var b big.Int
+	s.ToBigIntRegular(&b)
+	var res fr.Element
+	res.SetBigInt(&b)
+	return res
+}
+
+// FrToScalar returns the bls24-315 scalar embedded in x by ScalarToFr.
+// It returns ErrNotInScalarField if x is not smaller than the bls24-315 scalar field modulus.
+func FrToScalar(x *fr.Element) (bls24315fr.Element, error) {
+	var b big.Int
+	x.ToBigIntRegular(&b)
+	var res bls24315fr.Element
+	if b.Cmp(bls24315fr.Modulus()) >= 0 {
+		return res, ErrNotInScalarField
+	}
+	res.SetBigInt(&b)
+	return res, nil
+}
+
+// G1ToFr returns the affine coordinates (X, Y) of p as bw6-633 scalar field elements.
+// The point at infinity is (0, 0).
+func G1ToFr(p *bls24315.G1Affine) [NbElementsG1]fr.Element {
+	return [NbElementsG1]fr.Element{FpToFr(&p.X), FpToFr(&p.Y)}
+}
+
+// G1FromFr returns the bls24-315 G1 point with affine coordinates c, as output by G1ToFr.
+// It returns ErrInvalidPoint if the point is not on the curve or not in the prime order subgroup.
+func G1FromFr(c [NbElementsG1]fr.Element) (bls24315.G1Affine, error) {
+	var p bls24315.G1Affine
+	p.X = FrToFp(&c[0])
+	p.Y = FrToFp(&c[1])
+	if !p.IsOnCurve() || !p.IsInSubGroup() {
+		return p, ErrInvalidPoint
+	}
+	return p, nil
+}
+
+// G2ToFr returns the affine coordinates of p as bw6-633 scalar field elements,
+// in the order X.B0.A0, X.B0.A1, X.B1.A0, X.B1.A1, then the same for Y.
+// The point at infinity is all zeroes.
+func G2ToFr(p *bls24315.G2Affine) [NbElementsG2]fr.Element {
+	return [NbElementsG2]fr.Element{
+		FpToFr(&p.X.B0.A0), FpToFr(&p.X.B0.A1), FpToFr(&p.X.B1.A0), FpToFr(&p.X.B1.A1),
+		FpToFr(&p.Y.B0.A0), FpToFr(&p.Y.B0.A1), FpToFr(&p.Y.B1.A0), FpToFr(&p.Y.B1.A1),
+	}
+}
+
+// G2FromFr returns the bls24-315 G2 point with affine coordinates c, as output by G2ToFr.
+// It returns ErrInvalidPoint if the point is not on the curve or not in the prime order subgroup.
+func G2FromFr(c [NbElementsG2]fr.Element) (bls24315.G2Affine, error) {
+	var p bls24315.G2Affine
+	p.X.B0.A0 = FrToFp(&c[0])
+	p.X.B0.A1 = FrToFp(&c[1])
+	p.X.B1.A0 = FrToFp(&c[2])
+	p.X.B1.A1 = FrToFp(&c[3])
+	p.Y.B0.A0 = FrToFp(&c[4])
+	p.Y.B0.A1 = FrToFp(&c[5])
+	p.Y.B1.A0 = FrToFp(&c[6])
+	p.Y.B1.A1 = FrToFp(&c[7])
+	if !p.IsOnCurve() || !p.IsInSubGroup() {
+		return p, ErrInvalidPoint
+	}
+	return p, nil
+}
@@ -0,0 +1,121 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package twochain
+
+import (
+	"math/big"
+	"testing"
+
+	bls24315 "github.com/consensys/gnark-crypto/ecc/bls24-315"
+	bls24315fp "github.com/consensys/gnark-crypto/ecc/bls24-315/fp"
+	bls24315fr "github.com/consensys/gnark-crypto/ecc/bls24-315/fr"
+	"github.com/consensys/gnark-crypto/ecc/bw6-633/fr"
+)
+
+func TestModuli(t *testing.T) {
+	if fr.Modulus().Cmp(bls24315fp.Modulus()) != 0 {
+		t.Fatal("bw6-633 scalar field and bls24-315 base field differ")
+	}
+	if bls24315fr.Modulus().Cmp(fr.Modulus()) >= 0 {
+		t.Fatal("bls24-315 scalar field does not embed in bw6-633 scalar field")
+	}
+}
+
+func TestFieldEmbedding(t *testing.T) {
+	for i := 0; i < 10; i++ {
+		var x bls24315fp.Element
+		x.SetRandom()
+		y := FpToFr(&x)
+		if x.String() != y.String() {
+			t.Fatal("FpToFr does not preserve the value")
+		}
+		if z := FrToFp(&y); !z.Equal(&x) {
+			t.Fatal("FrToFp(FpToFr(x)) != x")
+		}
+
+		var s bls24315fr.Element
+		s.SetRandom()
+		e := ScalarToFr(&s)
+		if s.String() != e.String() {
+			t.Fatal("ScalarToFr does not preserve the value")
+		}
+		s2, err := FrToScalar(&e)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !s2.Equal(&s) {
+			t.Fatal("FrToScalar(ScalarToFr(s)) != s")
+		}
+	}
+
+	var tooLarge fr.Element
+	tooLarge.SetBigInt(bls24315fr.Modulus())
+	if _, err := FrToScalar(&tooLarge); err != ErrNotInScalarField {
+		t.Fatal("FrToScalar should reject elements larger than the bls24-315 scalar field modulus")
+	}
+}
+
+func TestPointEmbedding(t *testing.T) {
+	_, _, g1, g2 := bls24315.Generators()
+
+	for i := 0; i < 5; i++ {
+		var s bls24315fr.Element
+		s.SetRandom()
+		var b big.Int
+		s.ToBigIntRegular(&b)
+
+		var p1 bls24315.G1Affine
+		p1.ScalarMultiplication(&g1, &b)
+		q1, err := G1FromFr(G1ToFr(&p1))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !q1.Equal(&p1) {
+			t.Fatal("G1FromFr(G1ToFr(p)) != p")
+		}
+
+		var p2 bls24315.G2Affine
+		p2.ScalarMultiplication(&g2, &b)
+		q2, err := G2FromFr(G2ToFr(&p2))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !q2.Equal(&p2) {
+			t.Fatal("G2FromFr(G2ToFr(p)) != p")
+		}
+	}
+
+	// infinity
+	var inf1 bls24315.G1Affine
+	if _, err := G1FromFr(G1ToFr(&inf1)); err != nil {
+		t.Fatal(err)
+	}
+	var inf2 bls24315.G2Affine
+	if _, err := G2FromFr(G2ToFr(&inf2)); err != nil {
+		t.Fatal(err)
+	}
+
+	// invalid points
+	c1 := G1ToFr(&g1)
+	c1[1].Add(&c1[1], &c1[0])
+	if _, err := G1FromFr(c1); err != ErrInvalidPoint {
+		t.Fatal("G1FromFr should reject points not on the curve")
+	}
+	c2 := G2ToFr(&g2)
+	c2[7].Add(&c2[7], &c2[0])
+	if _, err := G2FromFr(c2); err != ErrInvalidPoint {
+		t.Fatal("G2FromFr should reject points not on the curve")
+	}
+}
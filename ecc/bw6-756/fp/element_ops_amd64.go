@@ -1,3 +1,6 @@
+//go:build !purego
+// +build !purego
+
 // Copyright 2020 ConsenSys Software Inc.
 //
 // Licensed under the Apache License, Version 2.0 (the "License");
@@ -28,6 +31,10 @@ func MulBy13(x *Element)
 //go:noescape
 func mul(res, x, y *Element)
 
+func square(z, x *Element) {
+	mul(z, x, x)
+}
+
 //go:noescape
 func fromMont(res *Element)
 
@@ -0,0 +1,36 @@
+//go:build purego
+// +build purego
+
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package fr
+
+import (
+	"github.com/consensys/gnark-crypto/ecc"
+)
+
+// MakeFromArena returns a slice of n zeroed elements.
+//
+// With the purego build tag, the words of arena can't be reinterpreted as elements
+// without unsafe: they are reserved in the arena, but the slice is allocated on the heap.
+func MakeFromArena(arena *ecc.Arena, n int) []Element {
+	if n == 0 {
+		return []Element{}
+	}
+	_ = arena.Words(n * Limbs)
+	return make([]Element, n)
+}
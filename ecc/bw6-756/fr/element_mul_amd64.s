@@ -1,4 +1,5 @@
-// +build !amd64_adx
+//go:build !amd64_adx && !purego
+// +build !amd64_adx,!purego
 
 // Copyright 2020 ConsenSys Software Inc.
 //
@@ -580,6 +581,472 @@ l1:
 	CALL ·_mulGeneric(SB)
 	RET
 
+// square(res, x *Element)
+TEXT ·square(SB), $16-16
+
+	// the algorithm is described in the Element.Square declaration (.go)
+	// this is the CIOS multiplication of x by x (see mul), where round i
+	// only computes the products of x[i] with x[i], 2x[i+1], ..., 2x[N-1]
+
+	NO_LOCAL_POINTERS
+	CMPB ·supportAdx(SB), $1
+	JNE  l2
+	MOVQ x+8(FP), R14
+
+	// y = 2x
+	MOVQ 0(R14), AX
+	ADDQ AX, AX
+	MOVQ 8(R14), AX
+	ADCQ AX, AX
+	MOVQ 16(R14), AX
+	ADCQ AX, AX
+	MOVQ AX, R11
+	MOVQ 24(R14), AX
+	ADCQ AX, AX
+	MOVQ AX, R12
+	MOVQ 32(R14), AX
+	ADCQ AX, AX
+	MOVQ AX, R13
+	MOVQ 40(R14), AX
+	ADCQ AX, AX
+	MOVQ AX, s0-8(SP)
+
+	// A -> BP
+	// t[0] -> R15
+	// t[1] -> CX
+	// t[2] -> BX
+	// t[3] -> SI
+	// t[4] -> DI
+	// t[5] -> R8
+	MOVQ 8(R14), R10
+	ADDQ R10, R10
+
+	// clear the flags
+	XORQ AX, AX
+	MOVQ 0(R14), DX
+
+	// (A,t[0])  := x[0]*x[0] + A
+	MULXQ DX, R15, CX
+
+	// (A,t[1])  := 2x[1]*x[0] + A
+	MULXQ R10, AX, BX
+	ADOXQ AX, CX
+
+	// (A,t[2])  := 2x[2]*x[0] + A
+	MULXQ R11, AX, SI
+	ADOXQ AX, BX
+
+	// (A,t[3])  := 2x[3]*x[0] + A
+	MULXQ R12, AX, DI
+	ADOXQ AX, SI
+
+	// (A,t[4])  := 2x[4]*x[0] + A
+	MULXQ R13, AX, R8
+	ADOXQ AX, DI
+
+	// (A,t[5])  := 2x[5]*x[0] + A
+	MULXQ s0-8(SP), AX, BP
+	ADOXQ AX, R8
+
+	// A += carries from ADCXQ and ADOXQ
+	MOVQ  $0, AX
+	ADOXQ AX, BP
+
+	// m := t[0]*q'[0] mod W
+	MOVQ  qInv0<>(SB), DX
+	IMULQ R15, DX
+
+	// clear the flags
+	XORQ AX, AX
+
+	// C,_ := t[0] + m*q[0]
+	MULXQ q<>+0(SB), AX, R9
+	ADCXQ R15, AX
+	MOVQ  R9, R15
+
+	// (C,t[0]) := t[1] + m*q[1] + C
+	ADCXQ CX, R15
+	MULXQ q<>+8(SB), AX, CX
+	ADOXQ AX, R15
+
+	// (C,t[1]) := t[2] + m*q[2] + C
+	ADCXQ BX, CX
+	MULXQ q<>+16(SB), AX, BX
+	ADOXQ AX, CX
+
+	// (C,t[2]) := t[3] + m*q[3] + C
+	ADCXQ SI, BX
+	MULXQ q<>+24(SB), AX, SI
+	ADOXQ AX, BX
+
+	// (C,t[3]) := t[4] + m*q[4] + C
+	ADCXQ DI, SI
+	MULXQ q<>+32(SB), AX, DI
+	ADOXQ AX, SI
+
+	// (C,t[4]) := t[5] + m*q[5] + C
+	ADCXQ R8, DI
+	MULXQ q<>+40(SB), AX, R8
+	ADOXQ AX, DI
+
+	// t[5] = C + A
+	MOVQ  $0, AX
+	ADCXQ AX, R8
+	ADOXQ BP, R8
+	MOVQ  16(R14), R10
+	ADDQ  R10, R10
+
+	// clear the flags
+	XORQ AX, AX
+	MOVQ 8(R14), DX
+
+	// (A,t[1])  := t[1] + x[1]*x[1]
+	MULXQ DX, AX, BP
+	ADOXQ AX, CX
+
+	// (A,t[2])  := t[2] + 2x[2]*x[1] + A
+	ADCXQ BP, BX
+	MULXQ R10, AX, BP
+	ADOXQ AX, BX
+
+	// (A,t[3])  := t[3] + 2x[3]*x[1] + A
+	ADCXQ BP, SI
+	MULXQ R12, AX, BP
+	ADOXQ AX, SI
+
+	// (A,t[4])  := t[4] + 2x[4]*x[1] + A
+	ADCXQ BP, DI
+	MULXQ R13, AX, BP
+	ADOXQ AX, DI
+
+	// (A,t[5])  := t[5] + 2x[5]*x[1] + A
+	ADCXQ BP, R8
+	MULXQ s0-8(SP), AX, BP
+	ADOXQ AX, R8
+
+	// A += carries from ADCXQ and ADOXQ
+	MOVQ  $0, AX
+	ADCXQ AX, BP
+	ADOXQ AX, BP
+
+	// m := t[0]*q'[0] mod W
+	MOVQ  qInv0<>(SB), DX
+	IMULQ R15, DX
+
+	// clear the flags
+	XORQ AX, AX
+
+	// C,_ := t[0] + m*q[0]
+	MULXQ q<>+0(SB), AX, R9
+	ADCXQ R15, AX
+	MOVQ  R9, R15
+
+	// (C,t[0]) := t[1] + m*q[1] + C
+	ADCXQ CX, R15
+	MULXQ q<>+8(SB), AX, CX
+	ADOXQ AX, R15
+
+	// (C,t[1]) := t[2] + m*q[2] + C
+	ADCXQ BX, CX
+	MULXQ q<>+16(SB), AX, BX
+	ADOXQ AX, CX
+
+	// (C,t[2]) := t[3] + m*q[3] + C
+	ADCXQ SI, BX
+	MULXQ q<>+24(SB), AX, SI
+	ADOXQ AX, BX
+
+	// (C,t[3]) := t[4] + m*q[4] + C
+	ADCXQ DI, SI
+	MULXQ q<>+32(SB), AX, DI
+	ADOXQ AX, SI
+
+	// (C,t[4]) := t[5] + m*q[5] + C
+	ADCXQ R8, DI
+	MULXQ q<>+40(SB), AX, R8
+	ADOXQ AX, DI
+
+	// t[5] = C + A
+	MOVQ  $0, AX
+	ADCXQ AX, R8
+	ADOXQ BP, R8
+	MOVQ  24(R14), R10
+	ADDQ  R10, R10
+
+	// clear the flags
+	XORQ AX, AX
+	MOVQ 16(R14), DX
+
+	// (A,t[2])  := t[2] + x[2]*x[2]
+	MULXQ DX, AX, BP
+	ADOXQ AX, BX
+
+	// (A,t[3])  := t[3] + 2x[3]*x[2] + A
+	ADCXQ BP, SI
+	MULXQ R10, AX, BP
+	ADOXQ AX, SI
+
+	// (A,t[4])  := t[4] + 2x[4]*x[2] + A
+	ADCXQ BP, DI
+	MULXQ R13, AX, BP
+	ADOXQ AX, DI
+
+	// (A,t[5])  := t[5] + 2x[5]*x[2] + A
+	ADCXQ BP, R8
+	MULXQ s0-8(SP), AX, BP
+	ADOXQ AX, R8
+
+	// A += carries from ADCXQ and ADOXQ
+	MOVQ  $0, AX
+	ADCXQ AX, BP
+	ADOXQ AX, BP
+
+	// m := t[0]*q'[0] mod W
+	MOVQ  qInv0<>(SB), DX
+	IMULQ R15, DX
+
+	// clear the flags
+	XORQ AX, AX
+
+	// C,_ := t[0] + m*q[0]
+	MULXQ q<>+0(SB), AX, R9
+	ADCXQ R15, AX
+	MOVQ  R9, R15
+
+	// (C,t[0]) := t[1] + m*q[1] + C
+	ADCXQ CX, R15
+	MULXQ q<>+8(SB), AX, CX
+	ADOXQ AX, R15
+
+	// (C,t[1]) := t[2] + m*q[2] + C
+	ADCXQ BX, CX
+	MULXQ q<>+16(SB), AX, BX
+	ADOXQ AX, CX
+
+	// (C,t[2]) := t[3] + m*q[3] + C
+	ADCXQ SI, BX
+	MULXQ q<>+24(SB), AX, SI
+	ADOXQ AX, BX
+
+	// (C,t[3]) := t[4] + m*q[4] + C
+	ADCXQ DI, SI
+	MULXQ q<>+32(SB), AX, DI
+	ADOXQ AX, SI
+
+	// (C,t[4]) := t[5] + m*q[5] + C
+	ADCXQ R8, DI
+	MULXQ q<>+40(SB), AX, R8
+	ADOXQ AX, DI
+
+	// t[5] = C + A
+	MOVQ  $0, AX
+	ADCXQ AX, R8
+	ADOXQ BP, R8
+	MOVQ  32(R14), R10
+	ADDQ  R10, R10
+
+	// clear the flags
+	XORQ AX, AX
+	MOVQ 24(R14), DX
+
+	// (A,t[3])  := t[3] + x[3]*x[3]
+	MULXQ DX, AX, BP
+	ADOXQ AX, SI
+
+	// (A,t[4])  := t[4] + 2x[4]*x[3] + A
+	ADCXQ BP, DI
+	MULXQ R10, AX, BP
+	ADOXQ AX, DI
+
+	// (A,t[5])  := t[5] + 2x[5]*x[3] + A
+	ADCXQ BP, R8
+	MULXQ s0-8(SP), AX, BP
+	ADOXQ AX, R8
+
+	// A += carries from ADCXQ and ADOXQ
+	MOVQ  $0, AX
+	ADCXQ AX, BP
+	ADOXQ AX, BP
+
+	// m := t[0]*q'[0] mod W
+	MOVQ  qInv0<>(SB), DX
+	IMULQ R15, DX
+
+	// clear the flags
+	XORQ AX, AX
+
+	// C,_ := t[0] + m*q[0]
+	MULXQ q<>+0(SB), AX, R9
+	ADCXQ R15, AX
+	MOVQ  R9, R15
+
+	// (C,t[0]) := t[1] + m*q[1] + C
+	ADCXQ CX, R15
+	MULXQ q<>+8(SB), AX, CX
+	ADOXQ AX, R15
+
+	// (C,t[1]) := t[2] + m*q[2] + C
+	ADCXQ BX, CX
+	MULXQ q<>+16(SB), AX, BX
+	ADOXQ AX, CX
+
+	// (C,t[2]) := t[3] + m*q[3] + C
+	ADCXQ SI, BX
+	MULXQ q<>+24(SB), AX, SI
+	ADOXQ AX, BX
+
+	// (C,t[3]) := t[4] + m*q[4] + C
+	ADCXQ DI, SI
+	MULXQ q<>+32(SB), AX, DI
+	ADOXQ AX, SI
+
+	// (C,t[4]) := t[5] + m*q[5] + C
+	ADCXQ R8, DI
+	MULXQ q<>+40(SB), AX, R8
+	ADOXQ AX, DI
+
+	// t[5] = C + A
+	MOVQ  $0, AX
+	ADCXQ AX, R8
+	ADOXQ BP, R8
+	MOVQ  40(R14), R10
+	ADDQ  R10, R10
+
+	// clear the flags
+	XORQ AX, AX
+	MOVQ 32(R14), DX
+
+	// (A,t[4])  := t[4] + x[4]*x[4]
+	MULXQ DX, AX, BP
+	ADOXQ AX, DI
+
+	// (A,t[5])  := t[5] + 2x[5]*x[4] + A
+	ADCXQ BP, R8
+	MULXQ R10, AX, BP
+	ADOXQ AX, R8
+
+	// A += carries from ADCXQ and ADOXQ
+	MOVQ  $0, AX
+	ADCXQ AX, BP
+	ADOXQ AX, BP
+
+	// m := t[0]*q'[0] mod W
+	MOVQ  qInv0<>(SB), DX
+	IMULQ R15, DX
+
+	// clear the flags
+	XORQ AX, AX
+
+	// C,_ := t[0] + m*q[0]
+	MULXQ q<>+0(SB), AX, R9
+	ADCXQ R15, AX
+	MOVQ  R9, R15
+
+	// (C,t[0]) := t[1] + m*q[1] + C
+	ADCXQ CX, R15
+	MULXQ q<>+8(SB), AX, CX
+	ADOXQ AX, R15
+
+	// (C,t[1]) := t[2] + m*q[2] + C
+	ADCXQ BX, CX
+	MULXQ q<>+16(SB), AX, BX
+	ADOXQ AX, CX
+
+	// (C,t[2]) := t[3] + m*q[3] + C
+	ADCXQ SI, BX
+	MULXQ q<>+24(SB), AX, SI
+	ADOXQ AX, BX
+
+	// (C,t[3]) := t[4] + m*q[4] + C
+	ADCXQ DI, SI
+	MULXQ q<>+32(SB), AX, DI
+	ADOXQ AX, SI
+
+	// (C,t[4]) := t[5] + m*q[5] + C
+	ADCXQ R8, DI
+	MULXQ q<>+40(SB), AX, R8
+	ADOXQ AX, DI
+
+	// t[5] = C + A
+	MOVQ  $0, AX
+	ADCXQ AX, R8
+	ADOXQ BP, R8
+
+	// clear the flags
+	XORQ AX, AX
+	MOVQ 40(R14), DX
+
+	// (A,t[5])  := t[5] + x[5]*x[5]
+	MULXQ DX, AX, BP
+	ADOXQ AX, R8
+
+	// A += carries from ADCXQ and ADOXQ
+	MOVQ  $0, AX
+	ADCXQ AX, BP
+	ADOXQ AX, BP
+
+	// m := t[0]*q'[0] mod W
+	MOVQ  qInv0<>(SB), DX
+	IMULQ R15, DX
+
+	// clear the flags
+	XORQ AX, AX
+
+	// C,_ := t[0] + m*q[0]
+	MULXQ q<>+0(SB), AX, R9
+	ADCXQ R15, AX
+	MOVQ  R9, R15
+
+	// (C,t[0]) := t[1] + m*q[1] + C
+	ADCXQ CX, R15
+	MULXQ q<>+8(SB), AX, CX
+	ADOXQ AX, R15
+
+	// (C,t[1]) := t[2] + m*q[2] + C
+	ADCXQ BX, CX
+	MULXQ q<>+16(SB), AX, BX
+	ADOXQ AX, CX
+
+	// (C,t[2]) := t[3] + m*q[3] + C
+	ADCXQ SI, BX
+	MULXQ q<>+24(SB), AX, SI
+	ADOXQ AX, BX
+
+	// (C,t[3]) := t[4] + m*q[4] + C
+	ADCXQ DI, SI
+	MULXQ q<>+32(SB), AX, DI
+	ADOXQ AX, SI
+
+	// (C,t[4]) := t[5] + m*q[5] + C
+	ADCXQ R8, DI
+	MULXQ q<>+40(SB), AX, R8
+	ADOXQ AX, DI
+
+	// t[5] = C + A
+	MOVQ  $0, AX
+	ADCXQ AX, R8
+	ADOXQ BP, R8
+
+	// reduce element(R15,CX,BX,SI,DI,R8) using temp registers (R11,R12,R13,R9,R10,R14)
+	REDUCE(R15,CX,BX,SI,DI,R8,R11,R12,R13,R9,R10,R14)
+
+	MOVQ res+0(FP), AX
+	MOVQ R15, 0(AX)
+	MOVQ CX, 8(AX)
+	MOVQ BX, 16(AX)
+	MOVQ SI, 24(AX)
+	MOVQ DI, 32(AX)
+	MOVQ R8, 40(AX)
+	RET
+
+l2:
+	MOVQ res+0(FP), AX
+	MOVQ AX, (SP)
+	MOVQ x+8(FP), AX
+	MOVQ AX, 8(SP)
+	CALL ·_squareGeneric(SB)
+	RET
+
 TEXT ·fromMont(SB), $8-8
 	NO_LOCAL_POINTERS
 
@@ -595,7 +1062,7 @@ TEXT ·fromMont(SB), $8-8
 	// 		    (C,t[j-1]) := t[j] + m*q[j] + C
 	// 		t[N-1] = C
 	CMPB ·supportAdx(SB), $1
-	JNE  l2
+	JNE  l3
 	MOVQ res+0(FP), DX
 	MOVQ 0(DX), R14
 	MOVQ 8(DX), R15
@@ -850,7 +1317,7 @@ TEXT ·fromMont(SB), $8-8
 	MOVQ DI, 40(AX)
 	RET
 
-l2:
+l3:
 	MOVQ res+0(FP), AX
 	MOVQ AX, (SP)
 	CALL ·_fromMontGeneric(SB)
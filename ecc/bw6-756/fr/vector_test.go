@@ -0,0 +1,142 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package fr
+
+import (
+	"bytes"
+	"compress/flate"
+	"encoding/binary"
+	"io"
+	"reflect"
+	"testing"
+)
+
+func TestVectorSerialization(t *testing.T) {
+	t.Parallel()
+
+	// spans several chunks
+	v := make(Vector, vectorChunkSize+10)
+	for i := range v {
+		v[i].SetRandom()
+	}
+
+	compress := WithCompression(func(w io.Writer) (io.WriteCloser, error) {
+		return flate.NewWriter(w, flate.BestSpeed)
+	})
+	decompress := WithDecompression(func(r io.Reader) (io.Reader, error) {
+		return flate.NewReader(r), nil
+	})
+
+	for _, opts := range [][]VectorOption{
+		nil,
+		{WithByteOrder(binary.LittleEndian)},
+		{compress},
+		{compress, WithByteOrder(binary.LittleEndian)},
+	} {
+		var buf bytes.Buffer
+		written, err := v.WriteToWithOptions(&buf, opts...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if written != int64(buf.Len()) {
+			t.Fatal("unexpected number of bytes written")
+		}
+
+		var res Vector
+		if _, err := res.ReadFromWithOptions(&buf, decompress); err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(v, res) {
+			t.Fatal("ReadFrom(WriteTo()) failed")
+		}
+	}
+
+	// the default encoding is uncompressed
+	var buf bytes.Buffer
+	written, err := v.WriteTo(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if written != int64(vectorHeaderSize+len(v)*Bytes) {
+		t.Fatal("unexpected size of the encoding")
+	}
+	var res Vector
+	read, err := res.ReadFrom(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if read != written || !reflect.DeepEqual(v, res) {
+		t.Fatal("ReadFrom(WriteTo()) failed")
+	}
+
+	// empty vector
+	buf.Reset()
+	if _, err := (Vector{}).WriteTo(&buf); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := res.ReadFrom(&buf); err != nil || len(res) != 0 {
+		t.Fatal("failed to read an empty vector")
+	}
+}
+
+func TestVectorInvalid(t *testing.T) {
+	t.Parallel()
+
+	v := make(Vector, 4)
+	var buf bytes.Buffer
+
+	// compressed payload without a decompressor
+	_, err := v.WriteToWithOptions(&buf, WithCompression(func(w io.Writer) (io.WriteCloser, error) {
+		return flate.NewWriter(w, flate.BestSpeed)
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var res Vector
+	if _, err := res.ReadFrom(&buf); err != ErrVectorCompressed {
+		t.Fatalf("expected ErrVectorCompressed, got %v", err)
+	}
+
+	// wrong curve, wrong field, wrong magic and truncated payload
+	buf.Reset()
+	if _, err := v.WriteTo(&buf); err != nil {
+		t.Fatal(err)
+	}
+	encoded := buf.Bytes()
+
+	wrongCurve := append([]byte{}, encoded...)
+	wrongCurve[6] ^= 0xff
+	if _, err := res.ReadFrom(bytes.NewReader(wrongCurve)); err != ErrVectorCurve {
+		t.Fatalf("expected ErrVectorCurve, got %v", err)
+	}
+
+	wrongField := append([]byte{}, encoded...)
+	wrongField[7] ^= vectorBaseField
+	if _, err := res.ReadFrom(bytes.NewReader(wrongField)); err != ErrVectorCurve {
+		t.Fatalf("expected ErrVectorCurve, got %v", err)
+	}
+
+	wrongMagic := append([]byte{}, encoded...)
+	wrongMagic[0] ^= 0xff
+	if _, err := res.ReadFrom(bytes.NewReader(wrongMagic)); err != ErrVectorHeader {
+		t.Fatalf("expected ErrVectorHeader, got %v", err)
+	}
+
+	if _, err := res.ReadFrom(bytes.NewReader(encoded[:len(encoded)-1])); err != io.ErrUnexpectedEOF {
+		t.Fatalf("expected io.ErrUnexpectedEOF, got %v", err)
+	}
+}
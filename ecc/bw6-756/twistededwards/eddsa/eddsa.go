@@ -60,6 +60,21 @@ type Signature struct {
 
 // GenerateKey generates a public and private key pair.
 func GenerateKey(r io.Reader) (*PrivateKey, error) {
+	var seed [32]byte
+	_, err := r.Read(seed[:])
+	if err != nil {
+		return nil, err
+	}
+	return GenerateKeyFromSeed(seed), nil
+}
+
+// GenerateKeyFromSeed deterministically derives a public and private key pair from seed,
+// as in https://tools.ietf.org/html/rfc8032#section-5.1.5 (with blake2b as hash function):
+// the secret scalar and the source of randomness of the signatures are derived from the
+// digest of the seed. GenerateKey(r) is GenerateKeyFromSeed on 32 bytes read from r.
+//
+// The seed must be kept secret, as the private key.
+func GenerateKeyFromSeed(seed [32]byte) *PrivateKey {
 	c := twistededwards.GetEdwardsCurve()
 
 	var pub PublicKey
@@ -71,11 +86,6 @@ func GenerateKey(r io.Reader) (*PrivateKey, error) {
 	// randomness and the scalar.
 
 	// used for random scalar (aka private key)
-	seed := make([]byte, 32)
-	_, err := r.Read(seed)
-	if err != nil {
-		return nil, err
-	}
 	h1 := blake2b.Sum512(seed[:])
 
 	// used for the source of randomness when hashing the message
@@ -102,7 +112,7 @@ func GenerateKey(r io.Reader) (*PrivateKey, error) {
 
 	priv.PublicKey = pub
 
-	return &priv, nil
+	return &priv
 }
 
 // Equal compares 2 public keys
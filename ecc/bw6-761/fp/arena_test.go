@@ -0,0 +1,54 @@
+//go:build !purego
+// +build !purego
+
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package fp
+
+import (
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+)
+
+func TestMakeFromArena(t *testing.T) {
+	t.Parallel()
+
+	arena := ecc.NewArena(8 * Limbs)
+	a := MakeFromArena(arena, 5)
+	if len(a) != 5 || cap(a) != 5 || arena.Available() != 3*Limbs {
+		t.Fatal("unexpected allocation from the arena")
+	}
+	for i := range a {
+		a[i].SetRandom()
+	}
+
+	arena.Reset()
+	b := MakeFromArena(arena, 5)
+	if &b[0] != &a[0] {
+		t.Fatal("the arena memory should be reused after Reset")
+	}
+	for i := range b {
+		if !b[i].IsZero() {
+			t.Fatal("MakeFromArena should return zeroed elements")
+		}
+	}
+
+	if len(MakeFromArena(nil, 3)) != 3 || len(MakeFromArena(arena, 0)) != 0 {
+		t.Fatal("unexpected length")
+	}
+}
@@ -0,0 +1,95 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package fp
+
+import (
+	"crypto/sha256"
+	"math/big"
+	"testing"
+
+	"golang.org/x/crypto/chacha20"
+)
+
+func TestSamplerDeterministic(t *testing.T) {
+	t.Parallel()
+
+	a := NewSampler([]byte("seed"))
+	b := NewSampler([]byte("seed"))
+	c := NewSampler([]byte("other seed"))
+
+	va := make([]Element, 64)
+	a.Fill(va)
+	for i := range va {
+		if vb := b.Next(); !vb.Equal(&va[i]) {
+			t.Fatal("samplers with the same seed should produce the same stream")
+		}
+	}
+
+	vc := make([]Element, len(va))
+	c.Fill(vc)
+	if vc[0].Equal(&va[0]) && vc[1].Equal(&va[1]) {
+		t.Fatal("samplers with different seeds should produce different streams")
+	}
+}
+
+func TestSamplerKeyStream(t *testing.T) {
+	t.Parallel()
+
+	seed := []byte("seed")
+	s := NewSampler(seed)
+
+	// reference: read the key stream directly and reject candidates with math/big
+	key := sha256.Sum256(append([]byte(samplerDomain), seed...))
+	var nonce [chacha20.NonceSize]byte
+	cipher, err := chacha20.NewUnauthenticatedCipher(key[:], nonce[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	const k = (Bits + 7) / 8
+	q := Modulus()
+	var expected Element
+	for i := 0; i < 32; i++ {
+		buf := make([]byte, k)
+		for {
+			cipher.XORKeyStream(buf[:k], make([]byte, k))
+			be := make([]byte, k)
+			for j := range be {
+				be[j] = buf[k-1-j]
+			}
+			var v big.Int
+			v.SetBytes(be)
+			for j := v.BitLen() - 1; j >= Bits; j-- {
+				v.SetBit(&v, j, 0)
+			}
+			if v.Cmp(q) < 0 {
+				expected.SetBigInt(&v)
+				break
+			}
+		}
+		if got := s.Next(); !got.Equal(&expected) {
+			t.Fatal("sampler output doesn't match the ChaCha20 key stream")
+		}
+	}
+}
+
+func BenchmarkSamplerNext(b *testing.B) {
+	s := NewSampler([]byte("seed"))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		s.Next()
+	}
+}
@@ -0,0 +1,107 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package fr
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestRootOfUnity(t *testing.T) {
+	t.Parallel()
+
+	var one Element
+	one.SetOne()
+
+	// the largest root of unity has order exactly 2^TwoAdicity
+	root, err := RootOfUnity(uint64(1) << TwoAdicity)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var r Element
+	r.Set(&root)
+	for i := 0; i < TwoAdicity-1; i++ {
+		r.Square(&r)
+		if r.IsOne() {
+			t.Fatalf("root of unity of order 2^%d is not primitive", TwoAdicity)
+		}
+	}
+	r.Square(&r)
+	if !r.IsOne() {
+		t.Fatalf("root of unity of order 2^%d has the wrong order", TwoAdicity)
+	}
+
+	// 2^TwoAdicity is the largest power of 2 dividing r-1
+	var rMinusOne big.Int
+	rMinusOne.Sub(Modulus(), big.NewInt(1))
+	if rMinusOne.TrailingZeroBits() != TwoAdicity {
+		t.Fatal("wrong two-adicity")
+	}
+
+	// roots of smaller orders are consistent
+	for k := 1; k <= TwoAdicity; k++ {
+		w, err := RootOfUnity(uint64(1) << k)
+		if err != nil {
+			t.Fatal(err)
+		}
+		wHalf, err := RootOfUnity(uint64(1) << (k - 1))
+		if err != nil {
+			t.Fatal(err)
+		}
+		w.Square(&w)
+		if !w.Equal(&wHalf) {
+			t.Fatalf("RootOfUnity(2^%d)² != RootOfUnity(2^%d)", k, k-1)
+		}
+	}
+	if w, _ := RootOfUnity(1); !w.Equal(&one) {
+		t.Fatal("RootOfUnity(1) should be 1")
+	}
+
+	// invalid orders
+	for _, order := range []uint64{0, 3, 12, uint64(1) << (TwoAdicity + 1)} {
+		if _, err := RootOfUnity(order); err != ErrRootOfUnityOrder {
+			t.Fatalf("RootOfUnity(%d) should fail", order)
+		}
+	}
+}
+
+func TestMultiplicativeGenerator(t *testing.T) {
+	t.Parallel()
+
+	// g generates the multiplicative group iff g^((r-1)/p) != 1 for every prime p | r-1;
+	// we check it for p = 2, which is the property needed for the cosets.
+	g := MultiplicativeGenerator()
+	var e big.Int
+	e.Sub(Modulus(), big.NewInt(1)).Rsh(&e, 1)
+	g.Exp(g, &e)
+	if g.IsOne() {
+		t.Fatal("multiplicative generator is a square")
+	}
+
+	cosets := CosetGenerators(4)
+	if len(cosets) != 4 || !cosets[0].IsOne() {
+		t.Fatal("unexpected coset generators")
+	}
+	for i := 1; i < len(cosets); i++ {
+		// gⁱ must not lie in the largest 2-adic subgroup
+		var c Element
+		c.ExpUint64(cosets[i], uint64(1)<<TwoAdicity)
+		if c.IsOne() {
+			t.Fatalf("coset generator %d lies in the 2-adic subgroup", i)
+		}
+	}
+}
@@ -0,0 +1,129 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package ringsig
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"testing"
+)
+
+func generateRing(t *testing.T, n int) ([]*PrivateKey, []PublicKey) {
+	privs := make([]*PrivateKey, n)
+	ring := make([]PublicKey, n)
+	for i := 0; i < n; i++ {
+		var err error
+		privs[i], err = GenerateKey(rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ring[i] = privs[i].PublicKey
+	}
+	return privs, ring
+}
+
+func TestSignVerify(t *testing.T) {
+
+	privs, ring := generateRing(t, 5)
+	msg := []byte("message")
+	hFunc := sha256.New()
+
+	for _, signer := range []int{0, 2, 4} {
+		sig, err := privs[signer].Sign(rand.Reader, ring, msg, hFunc)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ok, err := Verify(ring, msg, &sig, hFunc)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !ok {
+			t.Fatal("valid signature rejected")
+		}
+
+		// wrong message
+		ok, err = Verify(ring, []byte("other message"), &sig, hFunc)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if ok {
+			t.Fatal("signature of a different message accepted")
+		}
+
+		// serialization
+		var reconstructed Signature
+		if _, err = reconstructed.SetBytes(sig.Bytes(), len(ring)); err != nil {
+			t.Fatal(err)
+		}
+		ok, err = Verify(ring, msg, &reconstructed, hFunc)
+		if err != nil || !ok {
+			t.Fatal("SetBytes(Bytes()) failed")
+		}
+	}
+
+	// signer not in the ring
+	outsider, err := GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = outsider.Sign(rand.Reader, ring, msg, hFunc); err != ErrNotInRing {
+		t.Fatal("expected ErrNotInRing")
+	}
+
+	// ring of size 1
+	sig, err := privs[0].Sign(rand.Reader, ring[:1], msg, hFunc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ok, err := Verify(ring[:1], msg, &sig, hFunc); err != nil || !ok {
+		t.Fatal("valid signature rejected")
+	}
+}
+
+func TestLinkability(t *testing.T) {
+
+	privs, ring := generateRing(t, 4)
+	hFunc := sha256.New()
+
+	// same signer, different messages and rings
+	a, err := privs[1].Sign(rand.Reader, ring, []byte("a"), hFunc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := privs[1].Sign(rand.Reader, ring[:2], []byte("b"), hFunc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !Linked(&a, &b) {
+		t.Fatal("signatures of the same signer should be linked")
+	}
+
+	// different signers
+	c, err := privs[2].Sign(rand.Reader, ring, []byte("a"), hFunc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if Linked(&a, &c) {
+		t.Fatal("signatures of different signers should not be linked")
+	}
+
+	// the key image cannot be replaced by another one
+	c.KeyImage = a.KeyImage
+	if ok, _ := Verify(ring, []byte("a"), &c, hFunc); ok {
+		t.Fatal("signature with a forged key image accepted")
+	}
+}
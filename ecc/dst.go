@@ -0,0 +1,35 @@
+package ecc
+
+// Suite identifiers of RFC 9380 (section 8), to be suffixed to a domain separation tag.
+// The suites for the other curves follow the same naming, e.g. the test vectors of bn254 use
+// "BN254G1_XMD:SHA-256_SVDW_RO_".
+const (
+	SuiteBLS12381G1RO = "BLS12381G1_XMD:SHA-256_SSWU_RO_"
+	SuiteBLS12381G1NU = "BLS12381G1_XMD:SHA-256_SSWU_NU_"
+	SuiteBLS12381G2RO = "BLS12381G2_XMD:SHA-256_SSWU_RO_"
+	SuiteBLS12381G2NU = "BLS12381G2_XMD:SHA-256_SSWU_NU_"
+)
+
+// Domain separation tags of the BLS signature ciphersuites (draft-irtf-cfrg-bls-signature-05, section 4.2),
+// for the basic, message augmentation and proof of possession schemes.
+// The signatures are in G2 (minimal public key size) or in G1 (minimal signature size).
+const (
+	DSTBLSSigG2Basic = "BLS_SIG_" + SuiteBLS12381G2RO + "NUL_"
+	DSTBLSSigG2Aug   = "BLS_SIG_" + SuiteBLS12381G2RO + "AUG_"
+	DSTBLSSigG2PoP   = "BLS_SIG_" + SuiteBLS12381G2RO + "POP_"
+	DSTBLSPoPG2      = "BLS_POP_" + SuiteBLS12381G2RO + "POP_"
+
+	DSTBLSSigG1Basic = "BLS_SIG_" + SuiteBLS12381G1RO + "NUL_"
+	DSTBLSSigG1Aug   = "BLS_SIG_" + SuiteBLS12381G1RO + "AUG_"
+	DSTBLSSigG1PoP   = "BLS_SIG_" + SuiteBLS12381G1RO + "POP_"
+	DSTBLSPoPG1      = "BLS_POP_" + SuiteBLS12381G1RO + "POP_"
+)
+
+// TestVectorDST returns the domain separation tag of the test vectors of RFC 9380 (appendix J)
+// for the given suite, e.g. "QUUX-V01-CS02-with-BLS12381G1_XMD:SHA-256_SSWU_RO_".
+//
+// Together with HashToG1Trace and HashToG2Trace (resp. EncodeTo...) of the curve packages, it
+// allows to reproduce the values u, Q0, Q1 and P of the appendix.
+func TestVectorDST(suite string) []byte {
+	return []byte("QUUX-V01-CS02-with-" + suite)
+}
@@ -24,6 +24,7 @@ limitations under the License.
 //	* MiMC
 //	* twisted edwards "companion curves"
 //	* EdDSA (on the "companion" twisted edwards curves)
+//	* Runtime curve selection, see ID.Curve
 package ecc
 
 import (
@@ -109,6 +110,8 @@ func modulus(c *config.Curve, scalarField bool) *big.Int {
 
 // MultiExpConfig enables to set optional configuration attribute to a call to MultiExp
 type MultiExpConfig struct {
-	NbTasks     int  // go routines to be used in the multiexp. can be larger than num cpus.
-	ScalarsMont bool // indicates if the scalars are in montgommery form. Default to false.
+	NbTasks     int    // go routines to be used in the multiexp. can be larger than num cpus.
+	ScalarsMont bool   // indicates if the scalars are in montgommery form. Default to false.
+	Arena       *Arena // Deprecated: MultiExp no longer copies the scalars, and ignores the arena.
+	C           uint64 // window size, must be implemented for the group. Default to the calibrated or heuristic choice.
 }
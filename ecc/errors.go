@@ -0,0 +1,50 @@
+package ecc
+
+import (
+	"errors"
+	"strconv"
+)
+
+// Errors wrapped by the verifiers of the proof systems (kzg, plookup, permutation, ...), so that
+// the reason a proof is rejected can be tested with errors.Is, whatever the curve.
+var (
+	// ErrPairingCheckFailed is wrapped when the pairing equation of a verifier doesn't hold
+	ErrPairingCheckFailed = errors.New("pairing check failed")
+
+	// ErrTranscriptMismatch is wrapped when the claimed values of a proof don't satisfy the
+	// relation checked at the Fiat-Shamir challenges
+	ErrTranscriptMismatch = errors.New("claimed values don't match the transcript")
+
+	// ErrCommitmentMismatch is wrapped when a commitment of a proof is not the expected one
+	ErrCommitmentMismatch = errors.New("commitment mismatch")
+)
+
+// Errors returned by the point decoders (SetBytes, SetBytesChecked, ...), so that the reason an
+// encoding is rejected can be tested with errors.Is, whatever the curve.
+var (
+	// ErrNonCanonicalEncoding is wrapped when an encoding is malformed: wrong length, invalid metadata
+	// bits, or a coordinate which is not the canonical encoding of a field element
+	ErrNonCanonicalEncoding = errors.New("invalid point encoding: not canonical")
+
+	// ErrPointNotOnCurve is wrapped when the decoded coordinates don't satisfy the curve equation
+	ErrPointNotOnCurve = errors.New("invalid point: not on the curve")
+
+	// ErrPointNotInSubGroup is wrapped when the decoded point is not in the prime order subgroup
+	ErrPointNotInSubGroup = errors.New("invalid point: subgroup check failed")
+)
+
+// IndexedError is an error caused by the element of index Index of an input, for instance
+// the point of an SRS which is not in the subgroup. It unwraps to Err.
+type IndexedError struct {
+	Index int
+	Err   error
+}
+
+func (e *IndexedError) Error() string {
+	return e.Err.Error() + " (index " + strconv.Itoa(e.Index) + ")"
+}
+
+// Unwrap returns e.Err
+func (e *IndexedError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,24 @@
+package ecc
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestIndexedError(t *testing.T) {
+	t.Parallel()
+
+	errPoint := errors.New("invalid point")
+	var err error = &IndexedError{Index: 3, Err: fmt.Errorf("srs: %w", errPoint)}
+	if err.Error() != "srs: invalid point (index 3)" {
+		t.Fatalf("unexpected message %q", err.Error())
+	}
+	if !errors.Is(err, errPoint) {
+		t.Fatal("IndexedError should unwrap to its error")
+	}
+	var indexed *IndexedError
+	if !errors.As(fmt.Errorf("verify: %w", err), &indexed) || indexed.Index != 3 {
+		t.Fatal("IndexedError should be found with errors.As")
+	}
+}
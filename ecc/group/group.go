@@ -0,0 +1,117 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package group defines abstract prime order groups, so that protocols (σ-protocols,
+// Pedersen commitments, distributed key generation, ...) can be written once and
+// instantiated with any of the groups implemented in gnark-crypto.
+//
+// Implementations are provided by the curve packages:
+//   - G1 and G2 of each pairing friendly curve, e.g. bn254.G1Group() and bn254.G2Group()
+//   - the companion twisted Edwards curves, e.g. twistededwards.Group() in ecc/bn254/twistededwards
+//
+// Scalars and points of a group are only compatible with scalars and points of the
+// same group; mixing them results in a panic.
+package group
+
+import (
+	"errors"
+	"math/big"
+)
+
+var (
+	ErrInvalidEncoding = errors.New("invalid encoding")
+	ErrInvalidPoint    = errors.New("point is not in the prime order subgroup")
+	ErrInvalidScalar   = errors.New("scalar is not canonical")
+	ErrSizeMismatch    = errors.New("points and scalars must have the same length")
+)
+
+// Group is a cyclic group of prime order q, written additively.
+type Group interface {
+	// String returns a name for the group, e.g. "bn254/G1"
+	String() string
+
+	// Order returns the prime order q of the group
+	Order() *big.Int
+
+	// NewScalar returns a new scalar set to 0
+	NewScalar() Scalar
+
+	// NewPoint returns a new point set to the identity
+	NewPoint() Point
+
+	// Generator returns a new point set to the generator of the group
+	Generator() Point
+
+	// ScalarSize returns the size in bytes of an encoded scalar
+	ScalarSize() int
+
+	// PointSize returns the size in bytes of an encoded point
+	PointSize() int
+
+	// MultiScalarMul returns ∑ᵢ sᵢPᵢ
+	MultiScalarMul(points []Point, scalars []Scalar) (Point, error)
+}
+
+// Scalar is an element of the scalar field 𝔽_q of a Group.
+//
+// Methods follow math/big: the receiver is set to the result and returned.
+type Scalar interface {
+	Set(a Scalar) Scalar
+	SetUint64(v uint64) Scalar
+	SetBigInt(v *big.Int) Scalar
+	SetRandom() (Scalar, error)
+
+	Add(a, b Scalar) Scalar
+	Sub(a, b Scalar) Scalar
+	Mul(a, b Scalar) Scalar
+	Neg(a Scalar) Scalar
+	// Inverse sets the receiver to a⁻¹, or 0 if a == 0
+	Inverse(a Scalar) Scalar
+
+	Equal(a Scalar) bool
+	IsZero() bool
+
+	// BigInt sets res to the value of the scalar, in [0, q), and returns res
+	BigInt(res *big.Int) *big.Int
+
+	// Bytes returns the big endian encoding of the scalar, of size Group.ScalarSize()
+	Bytes() []byte
+	// SetBytes sets the receiver from its encoding, and returns ErrInvalidScalar
+	// if it is not the canonical encoding of an element of 𝔽_q
+	SetBytes(buf []byte) error
+}
+
+// Point is an element of a Group.
+//
+// Methods follow math/big: the receiver is set to the result and returned.
+type Point interface {
+	Set(a Point) Point
+	SetIdentity() Point
+	SetGenerator() Point
+
+	Add(a, b Point) Point
+	Sub(a, b Point) Point
+	Neg(a Point) Point
+	Double(a Point) Point
+	ScalarMul(a Point, s Scalar) Point
+
+	Equal(a Point) bool
+	IsIdentity() bool
+
+	// Bytes returns the compressed encoding of the point, of size Group.PointSize()
+	Bytes() []byte
+	// SetBytes sets the receiver from its compressed encoding, and returns an error
+	// if it is not the encoding of an element of the group
+	SetBytes(buf []byte) error
+}
@@ -0,0 +1,248 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package group_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-377"
+	tedwards_bls12377 "github.com/consensys/gnark-crypto/ecc/bls12-377/twistededwards"
+	"github.com/consensys/gnark-crypto/ecc/bls12-378"
+	tedwards_bls12378 "github.com/consensys/gnark-crypto/ecc/bls12-378/twistededwards"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/bandersnatch"
+	tedwards_bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381/twistededwards"
+	"github.com/consensys/gnark-crypto/ecc/bls24-315"
+	tedwards_bls24315 "github.com/consensys/gnark-crypto/ecc/bls24-315/twistededwards"
+	"github.com/consensys/gnark-crypto/ecc/bls24-317"
+	tedwards_bls24317 "github.com/consensys/gnark-crypto/ecc/bls24-317/twistededwards"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	tedwards_bn254 "github.com/consensys/gnark-crypto/ecc/bn254/twistededwards"
+	"github.com/consensys/gnark-crypto/ecc/bw6-633"
+	tedwards_bw6633 "github.com/consensys/gnark-crypto/ecc/bw6-633/twistededwards"
+	"github.com/consensys/gnark-crypto/ecc/bw6-756"
+	tedwards_bw6756 "github.com/consensys/gnark-crypto/ecc/bw6-756/twistededwards"
+	"github.com/consensys/gnark-crypto/ecc/bw6-761"
+	tedwards_bw6761 "github.com/consensys/gnark-crypto/ecc/bw6-761/twistededwards"
+	"github.com/consensys/gnark-crypto/ecc/group"
+)
+
+func allGroups() []group.Group {
+	return []group.Group{
+		bn254.G1Group(), bn254.G2Group(), tedwards_bn254.Group(),
+		bls12377.G1Group(), bls12377.G2Group(), tedwards_bls12377.Group(),
+		bls12378.G1Group(), bls12378.G2Group(), tedwards_bls12378.Group(),
+		bls12381.G1Group(), bls12381.G2Group(), tedwards_bls12381.Group(), bandersnatch.Group(),
+		bls24315.G1Group(), bls24315.G2Group(), tedwards_bls24315.Group(),
+		bls24317.G1Group(), bls24317.G2Group(), tedwards_bls24317.Group(),
+		bw6761.G1Group(), bw6761.G2Group(), tedwards_bw6761.Group(),
+		bw6633.G1Group(), bw6633.G2Group(), tedwards_bw6633.Group(),
+		bw6756.G1Group(), bw6756.G2Group(), tedwards_bw6756.Group(),
+	}
+}
+
+// pedersen is written once for all groups: it returns mG + rH
+func pedersen(g group.Group, G, H group.Point, m, r group.Scalar) group.Point {
+	mG := g.NewPoint().ScalarMul(G, m)
+	rH := g.NewPoint().ScalarMul(H, r)
+	return mG.Add(mG, rH)
+}
+
+func randomScalar(t *testing.T, g group.Group) group.Scalar {
+	s, err := g.NewScalar().SetRandom()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+func TestScalar(t *testing.T) {
+	for _, g := range allGroups() {
+		t.Run(g.String(), func(t *testing.T) {
+			a, b := randomScalar(t, g), randomScalar(t, g)
+
+			c := g.NewScalar().Add(a, b)
+			c.Sub(c, b)
+			if !c.Equal(a) {
+				t.Fatal("a + b - b != a")
+			}
+			c.Neg(a).Add(c, a)
+			if !c.IsZero() {
+				t.Fatal("-a + a != 0")
+			}
+			c.Inverse(a).Mul(c, a)
+			if !c.Equal(g.NewScalar().SetUint64(1)) {
+				t.Fatal("a⁻¹a != 1")
+			}
+			if !g.NewScalar().Inverse(g.NewScalar()).IsZero() {
+				t.Fatal("0⁻¹ != 0")
+			}
+
+			var q, v big.Int
+			q.Set(g.Order())
+			if !g.NewScalar().SetBigInt(&q).IsZero() {
+				t.Fatal("q != 0")
+			}
+			v.Add(&q, big.NewInt(5))
+			if g.NewScalar().SetBigInt(&v).BigInt(&v).Cmp(big.NewInt(5)) != 0 {
+				t.Fatal("SetBigInt should reduce modulo q")
+			}
+
+			buf := a.Bytes()
+			if len(buf) != g.ScalarSize() {
+				t.Fatal("unexpected scalar size")
+			}
+			if err := c.SetBytes(buf); err != nil || !c.Equal(a) {
+				t.Fatal("scalar encoding round trip failed")
+			}
+			if err := c.SetBytes(q.FillBytes(make([]byte, g.ScalarSize()))); err != group.ErrInvalidScalar {
+				t.Fatal("non canonical scalar encoding should be rejected")
+			}
+			if err := c.SetBytes(buf[1:]); err != group.ErrInvalidScalar {
+				t.Fatal("short scalar encoding should be rejected")
+			}
+		})
+	}
+}
+
+func TestPoint(t *testing.T) {
+	for _, g := range allGroups() {
+		t.Run(g.String(), func(t *testing.T) {
+			a, b := randomScalar(t, g), randomScalar(t, g)
+			G := g.Generator()
+
+			if !g.NewPoint().IsIdentity() || G.IsIdentity() {
+				t.Fatal("unexpected identity")
+			}
+
+			// (a+b)G == aG + bG
+			aG := g.NewPoint().ScalarMul(G, a)
+			bG := g.NewPoint().ScalarMul(G, b)
+			p := g.NewPoint().Add(aG, bG)
+			if !p.Equal(g.NewPoint().ScalarMul(G, g.NewScalar().Add(a, b))) {
+				t.Fatal("(a+b)G != aG + bG")
+			}
+			p.Sub(p, bG)
+			if !p.Equal(aG) {
+				t.Fatal("aG + bG - bG != aG")
+			}
+			p.Neg(aG).Add(p, aG)
+			if !p.IsIdentity() {
+				t.Fatal("-aG + aG != 0")
+			}
+			p.Set(aG)
+			p.Add(p, p)
+			if !p.Equal(g.NewPoint().Double(aG)) {
+				t.Fatal("aG + aG != 2aG")
+			}
+
+			// qG == 0, (q-1)G == -G
+			var qm1 big.Int
+			qm1.Sub(g.Order(), big.NewInt(1))
+			if !p.ScalarMul(G, g.NewScalar().SetBigInt(&qm1)).Equal(g.NewPoint().Neg(G)) {
+				t.Fatal("(q-1)G != -G")
+			}
+			if !p.ScalarMul(G, g.NewScalar()).IsIdentity() {
+				t.Fatal("0G != 0")
+			}
+			if !p.ScalarMul(g.NewPoint(), a).IsIdentity() {
+				t.Fatal("a0 != 0")
+			}
+
+			// encoding
+			for _, p := range []group.Point{aG, g.NewPoint()} {
+				buf := p.Bytes()
+				if len(buf) != g.PointSize() {
+					t.Fatal("unexpected point size")
+				}
+				q := g.NewPoint()
+				if err := q.SetBytes(buf); err != nil || !q.Equal(p) {
+					t.Fatal("point encoding round trip failed", err)
+				}
+				if err := q.SetBytes(buf[1:]); err == nil {
+					t.Fatal("short point encoding should be rejected")
+				}
+			}
+
+			// multi scalar multiplication
+			points := []group.Point{G, aG, bG}
+			scalars := []group.Scalar{randomScalar(t, g), randomScalar(t, g), randomScalar(t, g)}
+			expected := g.NewPoint()
+			for i := range points {
+				expected.Add(expected, g.NewPoint().ScalarMul(points[i], scalars[i]))
+			}
+			res, err := g.MultiScalarMul(points, scalars)
+			if err != nil || !res.Equal(expected) {
+				t.Fatal("MultiScalarMul failed", err)
+			}
+			if _, err := g.MultiScalarMul(points, scalars[1:]); err != group.ErrSizeMismatch {
+				t.Fatal("expected ErrSizeMismatch")
+			}
+		})
+	}
+}
+
+func TestMultiExp(t *testing.T) {
+	for _, g := range allGroups() {
+		t.Run(g.String(), func(t *testing.T) {
+			for _, n := range []int{0, 1, 3, 70} {
+				points := make([]group.Point, n)
+				scalars := make([]group.Scalar, n)
+				for i := range points {
+					points[i] = g.NewPoint().ScalarMul(g.Generator(), randomScalar(t, g))
+					scalars[i] = randomScalar(t, g)
+				}
+				if n > 1 {
+					// edge cases: the identity, and the scalars 0 and q-1
+					var qm1 big.Int
+					qm1.Sub(g.Order(), big.NewInt(1))
+					points[0].SetIdentity()
+					scalars[1].SetUint64(0)
+					scalars[n-1].SetBigInt(&qm1)
+				}
+				expected, err := g.MultiScalarMul(points, scalars)
+				if err != nil {
+					t.Fatal(err)
+				}
+				res, err := group.MultiExp(g, points, scalars)
+				if err != nil || !res.Equal(expected) {
+					t.Fatal("MultiExp and MultiScalarMul should compute the same point", err)
+				}
+			}
+			if _, err := group.MultiExp(g, []group.Point{g.Generator()}, nil); err != group.ErrSizeMismatch {
+				t.Fatal("expected ErrSizeMismatch")
+			}
+		})
+	}
+}
+
+func TestPedersen(t *testing.T) {
+	for _, g := range allGroups() {
+		t.Run(g.String(), func(t *testing.T) {
+			G := g.Generator()
+			H := g.NewPoint().ScalarMul(G, randomScalar(t, g))
+
+			m1, r1 := randomScalar(t, g), randomScalar(t, g)
+			m2, r2 := randomScalar(t, g), randomScalar(t, g)
+			c := g.NewPoint().Add(pedersen(g, G, H, m1, r1), pedersen(g, G, H, m2, r2))
+
+			expected := pedersen(g, G, H, g.NewScalar().Add(m1, m2), g.NewScalar().Add(r1, r2))
+			if !c.Equal(expected) {
+				t.Fatal("pedersen commitments should be additively homomorphic")
+			}
+		})
+	}
+}
@@ -0,0 +1,123 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package group
+
+import (
+	"math/big"
+	"math/bits"
+
+	"github.com/consensys/gnark-crypto/internal/parallel"
+)
+
+// maxC is the largest window size of MultiExp
+const maxC = 16
+
+// MultiExp returns ∑ᵢ sᵢPᵢ in the group g, with the bucket method (Pippenger), using
+// only the methods of the Point and Scalar interfaces.
+//
+// It is a generic fallback, for code written against the interfaces of this package and
+// for groups without an optimized multi-scalar multiplication. It does not replace the
+// MultiExp methods of G1, G2 and of the twisted Edwards curves, which work on the concrete
+// point types and are faster: they don't pay for the interface calls and allocations.
+func MultiExp(g Group, points []Point, scalars []Scalar) (Point, error) {
+	if len(points) != len(scalars) {
+		return nil, ErrSizeMismatch
+	}
+	res := g.NewPoint()
+	if len(points) == 0 {
+		return res, nil
+	}
+
+	// scalars as little endian words
+	nbBits := g.Order().BitLen()
+	words := make([][]big.Word, len(scalars))
+	parallel.Execute(len(scalars), func(start, end int) {
+		for i := start; i < end; i++ {
+			words[i] = scalars[i].BigInt(new(big.Int)).Bits()
+		}
+	})
+
+	c := bestC(len(points), nbBits)
+	nbWindows := (nbBits + c - 1) / c
+	windows := make([]Point, nbWindows)
+	parallel.Execute(nbWindows, func(start, end int) {
+		buckets := make([]Point, (1<<c)-1)
+		for k := range buckets {
+			buckets[k] = g.NewPoint()
+		}
+		for j := start; j < end; j++ {
+			windows[j] = g.NewPoint()
+			msmWindow(g, windows[j], buckets, points, words, j*c, c)
+		}
+	})
+
+	// ∑ 2^{jc}⋅windows[j]
+	res.Set(windows[nbWindows-1])
+	for j := nbWindows - 2; j >= 0; j-- {
+		for k := 0; k < c; k++ {
+			res.Double(res)
+		}
+		res.Add(res, windows[j])
+	}
+	return res, nil
+}
+
+// msmWindow sets res to ∑ᵢ dᵢPᵢ, where dᵢ is the window of c bits of sᵢ starting at start.
+// The buckets are reset before use.
+func msmWindow(g Group, res Point, buckets []Point, points []Point, words [][]big.Word, start, c int) {
+	for k := range buckets {
+		buckets[k].SetIdentity()
+	}
+	for i := range points {
+		d := window(words[i], start, c)
+		if d == 0 {
+			continue
+		}
+		buckets[d-1].Add(buckets[d-1], points[i])
+	}
+
+	// ∑ k⋅buckets[k-1], with running sums
+	sum := g.NewPoint()
+	res.SetIdentity()
+	for k := len(buckets) - 1; k >= 0; k-- {
+		sum.Add(sum, buckets[k])
+		res.Add(res, sum)
+	}
+}
+
+// window returns the c bits of the little endian words w starting at bit start
+func window(w []big.Word, start, c int) uint64 {
+	i, shift := start/bits.UintSize, start%bits.UintSize
+	if i >= len(w) {
+		return 0
+	}
+	d := uint64(w[i]) >> shift
+	if shift+c > bits.UintSize && i+1 < len(w) {
+		d |= uint64(w[i+1]) << (bits.UintSize - shift)
+	}
+	return d & ((1 << c) - 1)
+}
+
+// bestC returns the window size minimizing the number of group operations
+func bestC(nbPoints, nbBits int) int {
+	best, bestCost := 1, -1
+	for c := 1; c <= maxC; c++ {
+		cost := ((nbBits + c - 1) / c) * (nbPoints + (1 << (c + 1)))
+		if bestCost < 0 || cost < bestCost {
+			best, bestCost = c, cost
+		}
+	}
+	return best
+}
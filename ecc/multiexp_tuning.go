@@ -0,0 +1,52 @@
+package ecc
+
+import (
+	"encoding/json"
+	"io"
+	"math/bits"
+)
+
+// MultiExpTuning records, for MultiExp instances of size up to 2ᵏ, the window size c which
+// performed best on a given machine. It is indexed by k, and sizes which are not in the
+// table fall back to the default heuristic.
+//
+// A tuning is computed by the CalibrateMultiExp functions of the curve packages, and can
+// be persisted with WriteTo and ReadFrom (JSON encoded) to avoid calibrating again.
+type MultiExpTuning map[int]uint64
+
+// Window returns the window size recorded for a MultiExp of nbPoints points
+func (t MultiExpTuning) Window(nbPoints int) (c uint64, ok bool) {
+	if nbPoints <= 0 {
+		return 0, false
+	}
+	c, ok = t[bits.Len(uint(nbPoints-1))]
+	return
+}
+
+// WriteTo writes the tuning to w, JSON encoded
+//
+// implements io.WriterTo
+func (t MultiExpTuning) WriteTo(w io.Writer) (int64, error) {
+	b, err := json.Marshal(t)
+	if err != nil {
+		return 0, err
+	}
+	n, err := w.Write(b)
+	return int64(n), err
+}
+
+// ReadFrom reads a JSON encoded tuning from r, until EOF
+//
+// implements io.ReaderFrom
+func (t *MultiExpTuning) ReadFrom(r io.Reader) (int64, error) {
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return int64(len(b)), err
+	}
+	var res MultiExpTuning
+	if err := json.Unmarshal(b, &res); err != nil {
+		return int64(len(b)), err
+	}
+	*t = res
+	return int64(len(b)), nil
+}
@@ -0,0 +1,40 @@
+package ecc
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+func TestMultiExpTuning(t *testing.T) {
+	t.Parallel()
+
+	tuning := MultiExpTuning{10: 8, 16: 13}
+
+	if c, ok := tuning.Window(1 << 10); !ok || c != 8 {
+		t.Fatal("expected the window of 2¹⁰ points")
+	}
+	if c, ok := tuning.Window(1<<15 + 1); !ok || c != 13 {
+		t.Fatal("sizes should be rounded up to the next power of 2")
+	}
+	if _, ok := tuning.Window(1 << 12); ok {
+		t.Fatal("no window was recorded for 2¹² points")
+	}
+	if _, ok := tuning.Window(0); ok {
+		t.Fatal("no window should be returned for 0 points")
+	}
+
+	var buf bytes.Buffer
+	written, err := tuning.WriteTo(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var res MultiExpTuning
+	read, err := res.ReadFrom(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if read != written || !reflect.DeepEqual(tuning, res) {
+		t.Fatal("ReadFrom(WriteTo()) failed")
+	}
+}
@@ -0,0 +1,123 @@
+package ecc
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+	"sync"
+
+	"github.com/consensys/gnark-crypto/ecc/group"
+)
+
+var (
+	ErrUnknownCurve       = errors.New("unknown curve")
+	ErrCurveNotRegistered = errors.New("curve package is not linked in the binary, import it to register the curve")
+)
+
+// Field is a prime field 𝔽_p, whose elements implement group.Scalar
+type Field interface {
+	// String returns a name for the field, e.g. "bn254/fr"
+	String() string
+
+	// Modulus returns the modulus p of the field
+	Modulus() *big.Int
+
+	// NewElement returns a new element set to 0
+	NewElement() group.Scalar
+
+	// ElementSize returns the size in bytes of an encoded element
+	ElementSize() int
+}
+
+// GT is an element of the target group of a pairing
+type GT interface {
+	Equal(a GT) bool
+	IsOne() bool
+
+	// Bytes returns the encoding of the element
+	Bytes() []byte
+}
+
+// Curve gathers the primitives of a pairing friendly curve behind curve agnostic
+// interfaces, so that the curve can be selected at runtime (see ID.Curve).
+//
+// Points passed to HashToG1, HashToG2, Pair and PairingCheck must belong to the
+// groups G1 and G2 of the same curve.
+type Curve struct {
+	ID ID
+
+	// Fr is the scalar field, the elements are the scalars of G1 and G2
+	Fr Field
+	// Fp is the base field
+	Fp Field
+
+	G1 group.Group
+	G2 group.Group
+
+	// HashToG1 and HashToG2 hash msg to a point, using the domain separation tag dst
+	HashToG1 func(msg, dst []byte) (group.Point, error)
+	HashToG2 func(msg, dst []byte) (group.Point, error)
+
+	// Pair returns ∏ᵢ e(Pᵢ, Qᵢ), Pᵢ in G1 and Qᵢ in G2
+	Pair func(P, Q []group.Point) (GT, error)
+	// PairingCheck returns true if ∏ᵢ e(Pᵢ, Qᵢ) = 1
+	PairingCheck func(P, Q []group.Point) (bool, error)
+}
+
+var registry struct {
+	sync.RWMutex
+	curves map[ID]*Curve
+}
+
+// Register makes the curve available through ID.Curve.
+//
+// It is called by the curve packages when they are initialized, and panics if the
+// curve is registered twice.
+func Register(c Curve) {
+	registry.Lock()
+	defer registry.Unlock()
+	if registry.curves == nil {
+		registry.curves = make(map[ID]*Curve)
+	}
+	if _, ok := registry.curves[c.ID]; ok {
+		panic("ecc: curve " + c.ID.String() + " registered twice")
+	}
+	registry.curves[c.ID] = &c
+}
+
+// Curve returns the primitives of the curve.
+//
+// The curve package (e.g. github.com/consensys/gnark-crypto/ecc/bn254) must be linked in
+// the binary, a blank import is enough; otherwise ErrCurveNotRegistered is returned.
+func (id ID) Curve() (*Curve, error) {
+	if !id.isImplemented() {
+		return nil, ErrUnknownCurve
+	}
+	registry.RLock()
+	defer registry.RUnlock()
+	c, ok := registry.curves[id]
+	if !ok {
+		return nil, ErrCurveNotRegistered
+	}
+	return c, nil
+}
+
+// IDFromString returns the ID of the curve named s, as returned by ID.String (e.g. "bn254")
+func IDFromString(s string) (ID, error) {
+	s = strings.ToLower(s)
+	for _, id := range Implemented() {
+		if id.String() == s {
+			return id, nil
+		}
+	}
+	return UNKNOWN, ErrUnknownCurve
+}
+
+func (id ID) isImplemented() bool {
+	for _, i := range Implemented() {
+		if i == id {
+			return true
+		}
+	}
+	return false
+}
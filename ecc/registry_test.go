@@ -0,0 +1,111 @@
+package ecc_test
+
+import (
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/group"
+
+	_ "github.com/consensys/gnark-crypto/ecc/bls12-377"
+	_ "github.com/consensys/gnark-crypto/ecc/bls12-378"
+	_ "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	_ "github.com/consensys/gnark-crypto/ecc/bls24-315"
+	_ "github.com/consensys/gnark-crypto/ecc/bls24-317"
+	_ "github.com/consensys/gnark-crypto/ecc/bn254"
+	_ "github.com/consensys/gnark-crypto/ecc/bw6-633"
+	_ "github.com/consensys/gnark-crypto/ecc/bw6-756"
+	_ "github.com/consensys/gnark-crypto/ecc/bw6-761"
+)
+
+func TestIDFromString(t *testing.T) {
+	t.Parallel()
+	for _, id := range ecc.Implemented() {
+		res, err := ecc.IDFromString(id.String())
+		if err != nil || res != id {
+			t.Fatalf("%s: expected %d, got %d (%v)", id, id, res, err)
+		}
+	}
+	if _, err := ecc.IDFromString("secp256k1"); err != ecc.ErrUnknownCurve {
+		t.Fatal("expected ErrUnknownCurve")
+	}
+	if _, err := ecc.UNKNOWN.Curve(); err != ecc.ErrUnknownCurve {
+		t.Fatal("expected ErrUnknownCurve")
+	}
+}
+
+func TestRegistry(t *testing.T) {
+	t.Parallel()
+	for _, id := range ecc.Implemented() {
+		id := id
+		t.Run(id.String(), func(t *testing.T) {
+			t.Parallel()
+			c, err := id.Curve()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if c.ID != id {
+				t.Fatal("wrong curve ID")
+			}
+			if c.Fr.Modulus().Cmp(id.ScalarField()) != 0 || c.G1.Order().Cmp(id.ScalarField()) != 0 {
+				t.Fatal("wrong scalar field")
+			}
+			if c.Fp.Modulus().Cmp(id.BaseField()) != 0 {
+				t.Fatal("wrong base field")
+			}
+
+			// fp arithmetic: a * a⁻¹ = 1
+			a, err := c.Fp.NewElement().SetRandom()
+			if err != nil {
+				t.Fatal(err)
+			}
+			one := c.Fp.NewElement().SetUint64(1)
+			if !c.Fp.NewElement().Mul(a, c.Fp.NewElement().Inverse(a)).Equal(one) {
+				t.Fatal("fp: a * a⁻¹ != 1")
+			}
+			b := c.Fp.NewElement()
+			if err := b.SetBytes(a.Bytes()); err != nil || !b.Equal(a) || len(a.Bytes()) != c.Fp.ElementSize() {
+				t.Fatal("fp: encoding round trip failed")
+			}
+
+			// e([s]H₁, H₂) = e(H₁, [s]H₂)
+			dst := []byte("REGISTRY_TEST")
+			h1, err := c.HashToG1([]byte("g1"), dst)
+			if err != nil {
+				t.Fatal(err)
+			}
+			h2, err := c.HashToG2([]byte("g2"), dst)
+			if err != nil {
+				t.Fatal(err)
+			}
+			s, err := c.Fr.NewElement().SetRandom()
+			if err != nil {
+				t.Fatal(err)
+			}
+			sh1 := c.G1.NewPoint().ScalarMul(h1, s)
+			sh2 := c.G2.NewPoint().ScalarMul(h2, s)
+			lhs, err := c.Pair([]group.Point{sh1}, []group.Point{h2})
+			if err != nil {
+				t.Fatal(err)
+			}
+			rhs, err := c.Pair([]group.Point{h1}, []group.Point{sh2})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !lhs.Equal(rhs) || lhs.IsOne() {
+				t.Fatal("pairing is not bilinear")
+			}
+
+			// e([s]H₁, H₂) ⋅ e(-H₁, [s]H₂) = 1
+			ok, err := c.PairingCheck(
+				[]group.Point{sh1, c.G1.NewPoint().Neg(h1)},
+				[]group.Point{h2, sh2},
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !ok {
+				t.Fatal("pairing check failed")
+			}
+		})
+	}
+}
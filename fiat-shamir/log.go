@@ -0,0 +1,157 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fiatshamir
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"hash"
+)
+
+// EventKind is the kind of operation recorded in a transcript log.
+type EventKind uint8
+
+const (
+	// EventBind records a value binded to a challenge.
+	EventBind EventKind = iota
+	// EventChallenge records the value of a computed challenge.
+	EventChallenge
+)
+
+func (k EventKind) String() string {
+	switch k {
+	case EventBind:
+		return "bind"
+	case EventChallenge:
+		return "challenge"
+	default:
+		return "unknown"
+	}
+}
+
+// Event is a single operation on a Transcript: either a value binded to a
+// challenge, or the value of a computed challenge.
+type Event struct {
+	Kind        EventKind
+	ChallengeID string
+	Value       []byte
+}
+
+func (e Event) String() string {
+	return fmt.Sprintf("%s %q: %s", e.Kind, e.ChallengeID, hex.EncodeToString(e.Value))
+}
+
+// Equal returns true if e and other describe the same operation.
+func (e Event) Equal(other Event) bool {
+	return e.Kind == other.Kind && e.ChallengeID == other.ChallengeID && bytes.Equal(e.Value, other.Value)
+}
+
+// Record starts recording every Bind and ComputeChallenge call on the transcript.
+// Events recorded so far are discarded. Copies of the transcript made after the call
+// share the same log.
+func (t *Transcript) Record() {
+	t.log = new([]Event)
+}
+
+// Events returns a copy of the recorded events, nil if Record was not called.
+// Challenges that are read again after being computed are recorded only once.
+func (t *Transcript) Events() []Event {
+	if t.log == nil {
+		return nil
+	}
+	res := make([]Event, len(*t.log))
+	copy(res, *t.log)
+	return res
+}
+
+func (t *Transcript) record(kind EventKind, challengeID string, value []byte) {
+	if t.log == nil {
+		return
+	}
+	v := make([]byte, len(value))
+	copy(v, value)
+	*t.log = append(*t.log, Event{Kind: kind, ChallengeID: challengeID, Value: v})
+}
+
+// Divergence describes the first difference between two transcript logs.
+type Divergence struct {
+	// Index is the position of the first divergent event.
+	Index int
+	// Expected and Actual are the events at Index, nil if the corresponding log is too short.
+	Expected, Actual *Event
+}
+
+func (d *Divergence) Error() string {
+	describe := func(e *Event) string {
+		if e == nil {
+			return "<none>"
+		}
+		return e.String()
+	}
+	return fmt.Sprintf("transcripts diverge at event %d: expected %s, got %s", d.Index, describe(d.Expected), describe(d.Actual))
+}
+
+// Diff returns the first divergence between the expected and actual logs,
+// or nil if they are identical.
+func Diff(expected, actual []Event) *Divergence {
+	n := len(expected)
+	if len(actual) < n {
+		n = len(actual)
+	}
+	for i := 0; i < n; i++ {
+		if !expected[i].Equal(actual[i]) {
+			return &Divergence{Index: i, Expected: &expected[i], Actual: &actual[i]}
+		}
+	}
+	if len(expected) == len(actual) {
+		return nil
+	}
+	d := &Divergence{Index: n}
+	if n < len(expected) {
+		d.Expected = &expected[n]
+	} else {
+		d.Actual = &actual[n]
+	}
+	return d
+}
+
+// Replay replays the binded values of a log on a new transcript using h and
+// challengesID, and checks that the recomputed challenges match the recorded ones.
+// It returns a *Divergence pointing to the first challenge that doesn't match,
+// or the transcript error if the log is not consistent with challengesID.
+func Replay(h hash.Hash, events []Event, challengesID ...string) error {
+	t := NewTranscript(h, challengesID...)
+	for i, e := range events {
+		switch e.Kind {
+		case EventBind:
+			if err := t.Bind(e.ChallengeID, e.Value); err != nil {
+				return fmt.Errorf("event %d: %w", i, err)
+			}
+		case EventChallenge:
+			value, err := t.ComputeChallenge(e.ChallengeID)
+			if err != nil {
+				return fmt.Errorf("event %d: %w", i, err)
+			}
+			if !bytes.Equal(value, e.Value) {
+				actual := Event{Kind: EventChallenge, ChallengeID: e.ChallengeID, Value: value}
+				return &Divergence{Index: i, Expected: &events[i], Actual: &actual}
+			}
+		default:
+			return fmt.Errorf("event %d: unknown event kind %d", i, e.Kind)
+		}
+	}
+	return nil
+}
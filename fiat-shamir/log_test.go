@@ -0,0 +1,106 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fiatshamir
+
+import (
+	"crypto/sha256"
+	"errors"
+	"testing"
+)
+
+func TestRecordReplay(t *testing.T) {
+	t.Parallel()
+
+	fs := NewTranscript(sha256.New(), "alpha", "beta", "gamma")
+	fs.Record()
+	if err := fs.Bind("alpha", []byte("v1")); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := fs.ComputeChallenge("alpha"); err != nil {
+		t.Fatal(err)
+	}
+	if err := fs.Bind("beta", []byte("v2")); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := fs.ComputeChallenge("beta"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := fs.ComputeChallenge("gamma"); err != nil {
+		t.Fatal(err)
+	}
+
+	events := fs.Events()
+	if len(events) != 5 {
+		t.Fatalf("expected 5 events, got %d", len(events))
+	}
+
+	// replaying the log should give the same challenges
+	if err := Replay(sha256.New(), events, "alpha", "beta", "gamma"); err != nil {
+		t.Fatal(err)
+	}
+
+	// tamper with a binded value, the next challenge should diverge
+	events[2].Value = []byte("v3")
+	err := Replay(sha256.New(), events, "alpha", "beta", "gamma")
+	var d *Divergence
+	if !errors.As(err, &d) {
+		t.Fatal("expected a divergence")
+	}
+	if d.Index != 3 || d.Expected.ChallengeID != "beta" {
+		t.Fatal("wrong divergence", d)
+	}
+}
+
+func TestDiff(t *testing.T) {
+	t.Parallel()
+
+	prover := initTranscript()
+	prover.Record()
+	verifier := initTranscript()
+	verifier.Record()
+
+	if err := prover.Bind("gamma", []byte("v7")); err != nil {
+		t.Fatal(err)
+	}
+	for _, id := range []string{"alpha", "beta"} {
+		if _, err := prover.ComputeChallenge(id); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := verifier.ComputeChallenge(id); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if d := Diff(prover.Events()[1:], verifier.Events()); d != nil {
+		t.Fatal("logs should be identical", d)
+	}
+
+	// the verifier forgets to bind v7 to gamma
+	if _, err := prover.ComputeChallenge("gamma"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := verifier.ComputeChallenge("gamma"); err != nil {
+		t.Fatal(err)
+	}
+	d := Diff(prover.Events(), verifier.Events())
+	if d == nil || d.Index != 0 || d.Expected.Kind != EventBind || d.Actual.Kind != EventChallenge {
+		t.Fatal("wrong divergence", d)
+	}
+
+	// one log is a prefix of the other
+	d = Diff(prover.Events(), prover.Events()[:2])
+	if d == nil || d.Index != 2 || d.Actual != nil {
+		t.Fatal("wrong divergence", d)
+	}
+}
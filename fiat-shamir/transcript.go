@@ -33,6 +33,9 @@ type Transcript struct {
 
 	challenges map[string]challenge
 	previous   *challenge
+
+	// log records the operations on the transcript, if not nil (see Record).
+	log *[]Event
 }
 
 type challenge struct {
@@ -43,7 +46,8 @@ type challenge struct {
 }
 
 // NewTranscript returns a new transcript.
-// h is the hash function that is used to compute the challenges.
+// h is the hash function that is used to compute the challenges, e.g. sha256.New()
+// or blake3.New() from gnark-crypto/hash/blake3.
 // challenges are the name of the challenges. The order of the challenges IDs matters.
 func NewTranscript(h hash.Hash, challengesID ...string) Transcript {
 	n := len(challengesID)
@@ -75,6 +79,7 @@ func (t *Transcript) Bind(challengeID string, bValue []byte) error {
 	}
 	challenge.bindings = append(challenge.bindings, bValue...)
 	t.challenges[challengeID] = challenge
+	t.record(EventBind, challengeID, bValue)
 
 	return nil
 
@@ -130,6 +135,7 @@ func (t *Transcript) ComputeChallenge(challengeID string) ([]byte, error) {
 
 	t.challenges[challengeID] = challenge
 	t.previous = &challenge
+	t.record(EventChallenge, challengeID, res)
 
 	return res, nil
 
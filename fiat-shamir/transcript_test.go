@@ -18,6 +18,8 @@ import (
 	"bytes"
 	"crypto/sha256"
 	"testing"
+
+	"github.com/consensys/gnark-crypto/hash/blake3"
 )
 
 func initTranscript() Transcript {
@@ -139,3 +141,31 @@ func TestBindToComputedChallenge(t *testing.T) {
 	}
 
 }
+
+func TestTranscriptBlake3(t *testing.T) {
+	t.Parallel()
+
+	// H(name || binded_values)
+	fs := NewTranscript(blake3.New(), "alpha", "beta")
+	if err := fs.Bind("alpha", []byte("v1")); err != nil {
+		t.Fatal(err)
+	}
+	alpha, err := fs.ComputeChallenge("alpha")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := blake3.Sum256([]byte("alphav1"))
+	if !bytes.Equal(alpha, expected[:]) {
+		t.Fatal("unexpected challenge")
+	}
+
+	// H(name || previous_challenge || binded_values)
+	beta, err := fs.ComputeChallenge("beta")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected = blake3.Sum256(append([]byte("beta"), alpha...))
+	if !bytes.Equal(beta, expected[:]) {
+		t.Fatal("unexpected challenge")
+	}
+}
@@ -18,4 +18,5 @@ import "errors"
 
 var (
 	errMissingArgument = errors.New("missing argument")
+	errInvalidExponent = errors.New("invalid fixed exponent, expected name=value")
 )
@@ -17,6 +17,7 @@ package cmd
 
 import (
 	"fmt"
+	"math/big"
 	"math/bits"
 	"os"
 	"path/filepath"
@@ -40,6 +41,7 @@ var (
 	fOutputDir   string
 	fPackageName string
 	fElementName string
+	fExponents   []string
 )
 
 func init() {
@@ -48,6 +50,7 @@ func init() {
 	rootCmd.PersistentFlags().StringVarP(&fModulus, "modulus", "m", "", "field modulus (base 10)")
 	rootCmd.PersistentFlags().StringVarP(&fOutputDir, "output", "o", "", "destination path to create output files")
 	rootCmd.PersistentFlags().StringVarP(&fPackageName, "package", "p", "", "package name in generated files")
+	rootCmd.PersistentFlags().StringArrayVarP(&fExponents, "exp", "x", nil, "fixed exponent name=value, generates ExpBy<name> with an addition chain (can be repeated)")
 	if bits.UintSize != 64 {
 		panic("goff only supports 64bits architectures")
 	}
@@ -66,11 +69,29 @@ func cmdGenerate(cmd *cobra.Command, args []string) {
 	}
 
 	// generate code
-	F, err := field.NewFieldConfig(fPackageName, fElementName, fModulus, false)
+	// addition chains are only searched for if fixed exponents are requested
+	F, err := field.NewFieldConfig(fPackageName, fElementName, fModulus, len(fExponents) != 0)
 	if err != nil {
 		fmt.Printf("\n%s\n", err.Error())
 		os.Exit(-1)
 	}
+	for _, exp := range fExponents {
+		nameValue := strings.SplitN(exp, "=", 2)
+		if len(nameValue) != 2 {
+			fmt.Printf("\n%s: %s\n", errInvalidExponent.Error(), exp)
+			os.Exit(-1)
+		}
+		name := nameValue[0]
+		e, ok := new(big.Int).SetString(nameValue[1], 0)
+		if !ok {
+			fmt.Printf("\n%s: %s\n", errInvalidExponent.Error(), exp)
+			os.Exit(-1)
+		}
+		if err := F.AddFixedExponent(name, e); err != nil {
+			fmt.Printf("\n%s\n", err.Error())
+			os.Exit(-1)
+		}
+	}
 	if err := generator.GenerateFF(F, fOutputDir); err != nil {
 		fmt.Printf("\n%s\n", err.Error())
 		os.Exit(-1)
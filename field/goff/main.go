@@ -19,6 +19,9 @@
 // Example usage:
 //		goff -m 0xffffffff00000001 -o ./goldilocks/ -p goldilocks -e Element
 //
+// Fixed exponents can be registered to get methods implemented with short addition chains:
+//		goff -m 0xffffffff00000001 -o ./goldilocks/ -p goldilocks -e Element -x Inverse=0xfffffffeffffffff
+//
 // Warning
 //
 // The generated code has not been audited for all moduli (only bn254 and bls12-381) and is provided as-is. In particular, there is no security guarantees such as constant time implementation or side-channel attack resistance.
@@ -0,0 +1,284 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package blake3 implements the BLAKE3 hash function (unkeyed hash mode, 256-bit output).
+//
+// It is a portable implementation of the reference specification, meant for
+// Fiat-Shamir transcripts (see fiatshamir.NewTranscript) of protocols that
+// standardize on BLAKE3, not for hashing large amounts of data.
+//
+// See https://github.com/BLAKE3-team/BLAKE3-specs for the specification.
+package blake3
+
+import (
+	"encoding/binary"
+	"hash"
+	"math/bits"
+)
+
+const (
+	// Size the size of a BLAKE3 digest in bytes
+	Size = 32
+
+	// BlockSize the block size of BLAKE3 in bytes
+	BlockSize = 64
+
+	chunkLen = 1024
+)
+
+// domain separation flags
+const (
+	flagChunkStart uint32 = 1 << iota
+	flagChunkEnd
+	flagParent
+	flagRoot
+)
+
+var iv = [8]uint32{
+	0x6A09E667, 0xBB67AE85, 0x3C6EF372, 0xA54FF53A, 0x510E527F, 0x9B05688C, 0x1F83D9AB, 0x5BE0CD19,
+}
+
+var msgPermutation = [16]int{2, 6, 3, 10, 7, 0, 4, 13, 1, 11, 12, 5, 9, 14, 15, 8}
+
+// g is the quarter-round function
+func g(state *[16]uint32, a, b, c, d int, mx, my uint32) {
+	state[a] += state[b] + mx
+	state[d] = bits.RotateLeft32(state[d]^state[a], -16)
+	state[c] += state[d]
+	state[b] = bits.RotateLeft32(state[b]^state[c], -12)
+	state[a] += state[b] + my
+	state[d] = bits.RotateLeft32(state[d]^state[a], -8)
+	state[c] += state[d]
+	state[b] = bits.RotateLeft32(state[b]^state[c], -7)
+}
+
+func round(state *[16]uint32, m *[16]uint32) {
+	// columns
+	g(state, 0, 4, 8, 12, m[0], m[1])
+	g(state, 1, 5, 9, 13, m[2], m[3])
+	g(state, 2, 6, 10, 14, m[4], m[5])
+	g(state, 3, 7, 11, 15, m[6], m[7])
+	// diagonals
+	g(state, 0, 5, 10, 15, m[8], m[9])
+	g(state, 1, 6, 11, 12, m[10], m[11])
+	g(state, 2, 7, 8, 13, m[12], m[13])
+	g(state, 3, 4, 9, 14, m[14], m[15])
+}
+
+func compress(cv *[8]uint32, block *[16]uint32, counter uint64, blockLen, flags uint32) [16]uint32 {
+	state := [16]uint32{
+		cv[0], cv[1], cv[2], cv[3], cv[4], cv[5], cv[6], cv[7],
+		iv[0], iv[1], iv[2], iv[3],
+		uint32(counter), uint32(counter >> 32), blockLen, flags,
+	}
+	m := *block
+	for r := 0; r < 7; r++ {
+		round(&state, &m)
+		if r < 6 {
+			var permuted [16]uint32
+			for i := range permuted {
+				permuted[i] = m[msgPermutation[i]]
+			}
+			m = permuted
+		}
+	}
+	for i := 0; i < 8; i++ {
+		state[i] ^= state[i+8]
+		state[i+8] ^= cv[i]
+	}
+	return state
+}
+
+func first8(words [16]uint32) (res [8]uint32) {
+	copy(res[:], words[:8])
+	return
+}
+
+func wordsFromBlock(block *[BlockSize]byte) (res [16]uint32) {
+	for i := range res {
+		res[i] = binary.LittleEndian.Uint32(block[4*i:])
+	}
+	return
+}
+
+// output is the state just before the chaining value or the root output is computed
+type output struct {
+	inputCV  [8]uint32
+	block    [16]uint32
+	counter  uint64
+	blockLen uint32
+	flags    uint32
+}
+
+func (o *output) chainingValue() [8]uint32 {
+	return first8(compress(&o.inputCV, &o.block, o.counter, o.blockLen, o.flags))
+}
+
+func (o *output) rootBytes(out []byte) {
+	for counter := uint64(0); len(out) > 0; counter++ {
+		words := compress(&o.inputCV, &o.block, counter, o.blockLen, o.flags|flagRoot)
+		var buf [BlockSize]byte
+		for i, w := range words {
+			binary.LittleEndian.PutUint32(buf[4*i:], w)
+		}
+		out = out[copy(out, buf[:]):]
+	}
+}
+
+func parentOutput(left, right [8]uint32) output {
+	o := output{inputCV: iv, blockLen: BlockSize, flags: flagParent}
+	copy(o.block[:8], left[:])
+	copy(o.block[8:], right[:])
+	return o
+}
+
+// chunkState hashes a chunk of up to chunkLen bytes
+type chunkState struct {
+	cv               [8]uint32
+	counter          uint64
+	block            [BlockSize]byte
+	blockLen         int
+	blocksCompressed int
+}
+
+func newChunkState(counter uint64) chunkState {
+	return chunkState{cv: iv, counter: counter}
+}
+
+func (c *chunkState) len() int {
+	return BlockSize*c.blocksCompressed + c.blockLen
+}
+
+func (c *chunkState) startFlag() uint32 {
+	if c.blocksCompressed == 0 {
+		return flagChunkStart
+	}
+	return 0
+}
+
+func (c *chunkState) update(p []byte) {
+	for len(p) > 0 {
+		// the last block of a chunk is compressed by output, with the end flag
+		if c.blockLen == BlockSize {
+			words := wordsFromBlock(&c.block)
+			c.cv = first8(compress(&c.cv, &words, c.counter, BlockSize, c.startFlag()))
+			c.blocksCompressed++
+			c.block = [BlockSize]byte{}
+			c.blockLen = 0
+		}
+		n := copy(c.block[c.blockLen:], p)
+		c.blockLen += n
+		p = p[n:]
+	}
+}
+
+func (c *chunkState) output() output {
+	return output{
+		inputCV:  c.cv,
+		block:    wordsFromBlock(&c.block),
+		counter:  c.counter,
+		blockLen: uint32(c.blockLen),
+		flags:    c.startFlag() | flagChunkEnd,
+	}
+}
+
+// digest implements hash.Hash
+type digest struct {
+	chunk   chunkState
+	cvStack [][8]uint32 // chaining values of the complete subtrees, the smallest last
+}
+
+// New returns a new hash.Hash computing the 256-bit BLAKE3 digest
+func New() hash.Hash {
+	d := new(digest)
+	d.Reset()
+	return d
+}
+
+// Sum256 returns the 256-bit BLAKE3 digest of data
+func Sum256(data []byte) [Size]byte {
+	var d digest
+	d.Reset()
+	_, _ = d.Write(data)
+	var res [Size]byte
+	d.finalize(res[:])
+	return res
+}
+
+// Reset resets the Hash to its initial state.
+func (d *digest) Reset() {
+	d.chunk = newChunkState(0)
+	d.cvStack = d.cvStack[:0]
+}
+
+// Size returns the number of bytes Sum will return.
+func (d *digest) Size() int {
+	return Size
+}
+
+// BlockSize returns the hash's underlying block size.
+func (d *digest) BlockSize() int {
+	return BlockSize
+}
+
+// Write (via the embedded io.Writer interface) adds more data to the running hash.
+// It never returns an error.
+func (d *digest) Write(p []byte) (int, error) {
+	n := len(p)
+	for len(p) > 0 {
+		// a chunk is only finalized once more input arrives, since the last
+		// chunk is the root if it is the only one
+		if d.chunk.len() == chunkLen {
+			totalChunks := d.chunk.counter + 1
+			o := d.chunk.output()
+			d.addChunkChainingValue(o.chainingValue(), totalChunks)
+			d.chunk = newChunkState(totalChunks)
+		}
+		take := chunkLen - d.chunk.len()
+		if take > len(p) {
+			take = len(p)
+		}
+		d.chunk.update(p[:take])
+		p = p[take:]
+	}
+	return n, nil
+}
+
+// addChunkChainingValue merges the complete subtrees: there are as many entries
+// in the stack as bits set in totalChunks
+func (d *digest) addChunkChainingValue(cv [8]uint32, totalChunks uint64) {
+	for totalChunks&1 == 0 {
+		o := parentOutput(d.cvStack[len(d.cvStack)-1], cv)
+		cv = o.chainingValue()
+		d.cvStack = d.cvStack[:len(d.cvStack)-1]
+		totalChunks >>= 1
+	}
+	d.cvStack = append(d.cvStack, cv)
+}
+
+// Sum appends the current hash to b and returns the resulting slice.
+// It does not change the underlying hash state.
+func (d *digest) Sum(b []byte) []byte {
+	var res [Size]byte
+	d.finalize(res[:])
+	return append(b, res[:]...)
+}
+
+func (d *digest) finalize(out []byte) {
+	o := d.chunk.output()
+	for i := len(d.cvStack) - 1; i >= 0; i-- {
+		o = parentOutput(d.cvStack[i], o.chainingValue())
+	}
+	o.rootBytes(out)
+}
@@ -0,0 +1,95 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blake3
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+)
+
+func TestVectors(t *testing.T) {
+	for _, v := range []struct {
+		input    string
+		expected string
+	}{
+		{"", "af1349b9f5f9a1a6a0404dea36dcc9499bcb25c9adc112b7cc9a93cae41f3262"},
+		{"abc", "6437b3ac38465133ffb63b75273a8db548c558465d79db03fd359c6cd5bd9d85"},
+	} {
+		res := Sum256([]byte(v.input))
+		if got := hex.EncodeToString(res[:]); got != v.expected {
+			t.Fatalf("BLAKE3(%q): unexpected hash %s", v.input, got)
+		}
+	}
+
+	// official test vectors, the input being the bytes i mod 251
+	for _, v := range []struct {
+		size     int
+		expected string
+	}{
+		{1024, "42214739f095a406f3fc83deb889744ac00df831c10daa55189b5d121c855af7"},
+		{1025, "d00278ae47eb27b34faecf67b4fe263f82d5412916c1ffd97c8cb7fb814b8444"},
+		{2048, "e776b6028c7cd22a4d0ba182a8bf62205d2ef576467e838ed6f2529b85fba24a"},
+	} {
+		input := make([]byte, v.size)
+		for i := range input {
+			input[i] = byte(i % 251)
+		}
+		res := Sum256(input)
+		if got := hex.EncodeToString(res[:]); got != v.expected {
+			t.Fatalf("BLAKE3 of %d bytes: unexpected hash %s", v.size, got)
+		}
+	}
+}
+
+func TestWrite(t *testing.T) {
+	// several chunks and a partial tree
+	data := make([]byte, 5*chunkLen+37)
+	for i := range data {
+		data[i] = byte(i % 251)
+	}
+	for _, size := range []int{0, 1, BlockSize, chunkLen, chunkLen + 1, 2 * chunkLen, len(data)} {
+		expected := Sum256(data[:size])
+
+		h := New()
+		for p := data[:size]; len(p) > 0; {
+			n := 97
+			if n > len(p) {
+				n = len(p)
+			}
+			_, _ = h.Write(p[:n])
+			p = p[n:]
+		}
+		if !bytes.Equal(h.Sum(nil), expected[:]) {
+			t.Fatalf("size %d: the hash should not depend on how the data is written", size)
+		}
+		if !bytes.Equal(h.Sum(nil), expected[:]) {
+			t.Fatal("Sum should not change the state")
+		}
+		h.Reset()
+		_, _ = h.Write(data[:size])
+		if !bytes.Equal(h.Sum(nil), expected[:]) {
+			t.Fatal("Reset should restore the initial state")
+		}
+	}
+}
+
+func BenchmarkSum256(b *testing.B) {
+	data := make([]byte, 1024)
+	b.SetBytes(int64(len(data)))
+	for i := 0; i < b.N; i++ {
+		_ = Sum256(data)
+	}
+}
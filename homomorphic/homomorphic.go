@@ -0,0 +1,58 @@
+/*
+Copyright © 2020 ConsenSys
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package homomorphic defines interfaces for additively homomorphic encryption
+// schemes whose plaintexts are elements of a scalar field.
+//
+// Ciphertexts are handled as byte slices so that backends can be swapped
+// without changing the calling code.
+package homomorphic
+
+import (
+	"io"
+	"math/big"
+)
+
+// PublicKey public key interface.
+// The public key encrypts messages and combines ciphertexts.
+type PublicKey interface {
+
+	// Encrypt encrypts m, reduced modulo the scalar field, using r as the
+	// source of randomness. Returns the ciphertext or an error.
+	Encrypt(r io.Reader, m *big.Int) ([]byte, error)
+
+	// Add returns a ciphertext of the sum of the messages encrypted in a and b.
+	// Implementations may bound the number of additions and return an error
+	// when the bound is exceeded.
+	Add(a, b []byte) ([]byte, error)
+
+	// SetBytes sets the public key from its binary representation in buf.
+	// It returns the number of bytes read from the buffer.
+	SetBytes(buf []byte) (int, error)
+
+	// Bytes returns the binary representation of the public key.
+	Bytes() []byte
+}
+
+// Decrypter private key interface.
+type Decrypter interface {
+
+	// Public returns the public key associated to the private key.
+	Public() PublicKey
+
+	// Decrypt returns the message encrypted in c, as an element of the scalar field.
+	Decrypt(c []byte) (*big.Int, error)
+}
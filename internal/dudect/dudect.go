@@ -0,0 +1,154 @@
+// Package dudect checks statistically that a function runs in time independent of its inputs.
+//
+// It follows "dude, is my code constant time?" (Reparaz, Balasch, Verbauwhede,
+// https://eprint.iacr.org/2016/1123.pdf): the function is timed on inputs of two classes,
+// typically a fixed input and random inputs, interleaved at random. A Welch t-test on the two
+// distributions of execution times, possibly cropped to remove the outliers of the upper tail,
+// tells whether they differ. A large |t| (above Threshold) shows a timing leak; a small one is
+// evidence of constant time only up to the number of measurements and the timer resolution.
+//
+// The tests of the curve packages using this package are behind the "dudect" build tag, as they
+// take time and are sensitive to the load of the machine:
+//
+//	go test -tags dudect -run Timing ./ecc/...
+package dudect
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+	"testing"
+	"time"
+)
+
+// Threshold is the value of |t| above which the execution times of the two classes are
+// considered to differ (as in the reference implementation of dudect).
+const Threshold = 10
+
+// percentiles of the execution times at which the measurements are cropped, in addition to
+// the test on all the measurements
+var percentiles = []float64{0.5, 0.75, 0.9, 0.95, 0.99}
+
+// Config configures a test.
+type Config struct {
+	// Samples is the number of measurements, split at random between the two classes
+	Samples int
+
+	// Inner is the number of calls of the function per measurement, so that
+	// each measurement is well above the resolution of the timer
+	Inner int
+}
+
+// Result of a test
+type Result struct {
+	// T is the largest |t| statistic of the Welch t-tests, on all the measurements and
+	// on the measurements cropped at several percentiles
+	T float64
+
+	// Samples is the number of measurements
+	Samples int
+}
+
+// Leaks returns true if the two classes have distinguishable execution times
+func (r Result) Leaks() bool {
+	return r.T > Threshold
+}
+
+// Run measures the execution times of run, on inputs set by prepare for the given class (0 or 1).
+// prepare is called before each measurement and is not timed, but it leaves the caches in a state
+// which influences the measurement: it should do the same work for both classes (e.g. draw a random
+// input, and replace it with the fixed one for class 0).
+func Run(cfg Config, prepare func(class int), run func()) Result {
+	if cfg.Inner < 1 {
+		cfg.Inner = 1
+	}
+	classes := make([]int, cfg.Samples)
+	times := make([]float64, cfg.Samples)
+
+	// the seed only decides the interleaving of the classes
+	rng := rand.New(rand.NewSource(time.Now().UnixNano())) //#nosec G404 weak rng is fine here
+
+	// warm up the caches and the branch predictors
+	for i := 0; i < cfg.Samples/100+1; i++ {
+		prepare(i & 1)
+		run()
+	}
+
+	for i := range times {
+		classes[i] = rng.Intn(2)
+		prepare(classes[i])
+		start := time.Now()
+		for j := 0; j < cfg.Inner; j++ {
+			run()
+		}
+		times[i] = float64(time.Since(start).Nanoseconds())
+	}
+
+	return Result{T: maxT(classes, times), Samples: cfg.Samples}
+}
+
+// Assert runs the test and fails tb if the execution times of the two classes differ.
+func Assert(tb testing.TB, cfg Config, prepare func(class int), run func()) {
+	tb.Helper()
+	res := Run(cfg, prepare, run)
+	tb.Logf("max |t| = %.2f over %d measurements", res.T, res.Samples)
+	if res.Leaks() {
+		tb.Fatalf("timing leak: max |t| = %.2f > %d", res.T, Threshold)
+	}
+}
+
+// maxT returns the largest |t| of the Welch t-tests on all the measurements, and on the
+// measurements below each of the percentiles
+func maxT(classes []int, times []float64) float64 {
+	sorted := make([]float64, len(times))
+	copy(sorted, times)
+	sort.Float64s(sorted)
+
+	thresholds := []float64{math.Inf(1)}
+	for _, p := range percentiles {
+		thresholds = append(thresholds, sorted[int(p*float64(len(sorted)-1))])
+	}
+
+	res := 0.0
+	for _, threshold := range thresholds {
+		var w [2]welford
+		for i, t := range times {
+			if t <= threshold {
+				w[classes[i]].add(t)
+			}
+		}
+		if t := math.Abs(welchT(&w[0], &w[1])); t > res {
+			res = t
+		}
+	}
+	return res
+}
+
+// welford is an online computation of the mean and the variance
+type welford struct {
+	n        float64
+	mean, m2 float64
+}
+
+func (w *welford) add(x float64) {
+	w.n++
+	delta := x - w.mean
+	w.mean += delta / w.n
+	w.m2 += delta * (x - w.mean)
+}
+
+func (w *welford) variance() float64 {
+	return w.m2 / (w.n - 1)
+}
+
+// welchT returns the t statistic of the Welch t-test, or 0 if there are not enough samples
+func welchT(a, b *welford) float64 {
+	if a.n < 2 || b.n < 2 {
+		return 0
+	}
+	den := math.Sqrt(a.variance()/a.n + b.variance()/b.n)
+	if den == 0 {
+		return 0
+	}
+	return (a.mean - b.mean) / den
+}
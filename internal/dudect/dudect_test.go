@@ -0,0 +1,54 @@
+package dudect
+
+import (
+	"math"
+	"testing"
+)
+
+var sink uint64
+
+func TestRunDetectsLeak(t *testing.T) {
+	var n int
+	prepare := func(class int) {
+		n = 10 + class*10000
+	}
+	run := func() {
+		for i := 0; i < n; i++ {
+			sink += uint64(i) * sink
+		}
+	}
+	if res := Run(Config{Samples: 2000}, prepare, run); !res.Leaks() {
+		t.Fatalf("expected a leak, got max |t| = %.2f", res.T)
+	}
+}
+
+func TestMaxT(t *testing.T) {
+	// same distribution for both classes
+	classes := make([]int, 1000)
+	times := make([]float64, len(classes))
+	for i := range times {
+		classes[i] = i & 1
+		times[i] = float64(100 + (i/2)%10)
+	}
+	if res := maxT(classes, times); res != 0 {
+		t.Fatalf("expected t = 0, got %f", res)
+	}
+
+	// class 1 is slower by three units
+	for i := range times {
+		times[i] += float64(3 * classes[i])
+	}
+	if res := maxT(classes, times); res <= Threshold {
+		t.Fatalf("expected t > %d, got %f", Threshold, res)
+	}
+}
+
+func TestWelford(t *testing.T) {
+	var w welford
+	for _, x := range []float64{2, 4, 4, 4, 5, 5, 7, 9} {
+		w.add(x)
+	}
+	if w.mean != 5 || math.Abs(w.variance()-32.0/7) > 1e-12 {
+		t.Fatalf("wrong mean or variance: %f, %f", w.mean, w.variance())
+	}
+}
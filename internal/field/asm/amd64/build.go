@@ -173,6 +173,11 @@ func GenerateMul(w io.Writer, F *field.FieldConfig) error {
 	// mul
 	f.generateMul(false)
 
+	// square
+	if f.ASMSquare {
+		f.generateSquare(false)
+	}
+
 	// from mont
 	f.generateFromMont(false)
 
@@ -191,6 +196,11 @@ func GenerateMulADX(w io.Writer, F *field.FieldConfig) error {
 	// mul
 	f.generateMul(true)
 
+	// square
+	if f.ASMSquare {
+		f.generateSquare(true)
+	}
+
 	// from mont
 	f.generateFromMont(true)
 
@@ -0,0 +1,215 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package amd64
+
+import (
+	"fmt"
+
+	"github.com/consensys/bavard/amd64"
+)
+
+// SquareADX uses AX, DX and BP
+// sets x * x into t, without modular reduction
+//
+// this is MulADX with y = x, where round i only multiplies x[i] by
+// (x[i], 2x[i+1], ..., 2x[N-1]): x[i]*x[j] (i < j) is computed once,
+// and the products with t[0] ... t[i-1] are skipped.
+func (f *FFAmd64) SquareADX(registers *amd64.Registers, x func(int) string, t []amd64.Register) []amd64.Register {
+	// registers
+	tr := registers.Pop() // temporary register
+	v := registers.Pop()  // 2x[i+1], without the carry from x[i]
+	A := amd64.BP
+
+	// y = 2x, on the stack if we run out of registers; y[0] and y[1] are not needed
+	y := make([]amd64.Register, f.NbWords)
+	for i := 2; i < f.NbWords; i++ {
+		y[i] = f.Pop(registers)
+	}
+	if f.NbWords > 2 {
+		f.Comment("y = 2x")
+		f.MOVQ(x(0), amd64.AX)
+		f.ADDQ(amd64.AX, amd64.AX)
+		f.MOVQ(x(1), amd64.AX)
+		f.ADCQ(amd64.AX, amd64.AX)
+		for i := 2; i < f.NbWords; i++ {
+			f.MOVQ(x(i), amd64.AX)
+			f.ADCQ(amd64.AX, amd64.AX)
+			f.MOVQ(amd64.AX, y[i])
+		}
+	}
+
+	f.LabelRegisters("A", A)
+	f.LabelRegisters("t", t...)
+
+	for i := 0; i < f.NbWords; i++ {
+		if i != f.NbWordsLastIndex {
+			f.MOVQ(x(i+1), v)
+			f.ADDQ(v, v)
+		}
+		vAt := func(j int) amd64.Register {
+			if j == i+1 {
+				return v
+			}
+			return y[j]
+		}
+
+		f.Comment("clear the flags")
+		f.XORQ(amd64.AX, amd64.AX)
+
+		f.MOVQ(x(i), amd64.DX)
+
+		// for j=i to N-1
+		//    (A,t[j])  := t[j] + x[i]*v[j] + A
+		if i == 0 {
+			for j := 0; j < f.NbWords; j++ {
+				if j == 0 {
+					f.Comment("(A,t[0])  := x[0]*x[0] + A")
+					f.MULXQ(amd64.DX, t[j], t[j+1])
+				} else {
+					f.Comment(fmt.Sprintf("(A,t[%[1]d])  := 2x[%[1]d]*x[0] + A", j))
+					highBits := A
+					if j != f.NbWordsLastIndex {
+						highBits = t[j+1]
+					}
+					f.MULXQ(vAt(j), amd64.AX, highBits)
+					f.ADOXQ(amd64.AX, t[j])
+				}
+			}
+		} else {
+			for j := i; j < f.NbWords; j++ {
+				if j == i {
+					f.Comment(fmt.Sprintf("(A,t[%[1]d])  := t[%[1]d] + x[%[1]d]*x[%[1]d]", j))
+					f.MULXQ(amd64.DX, amd64.AX, A)
+				} else {
+					f.Comment(fmt.Sprintf("(A,t[%[1]d])  := t[%[1]d] + 2x[%[1]d]*x[%[2]d] + A", j, i))
+					f.ADCXQ(A, t[j])
+					f.MULXQ(vAt(j), amd64.AX, A)
+				}
+				f.ADOXQ(amd64.AX, t[j])
+			}
+		}
+
+		f.Comment("A += carries from ADCXQ and ADOXQ")
+		f.MOVQ(0, amd64.AX)
+		if i != 0 {
+			f.ADCXQ(amd64.AX, A)
+		}
+		f.ADOXQ(amd64.AX, A)
+
+		// m := t[0]*q'[0] mod W
+		f.Comment("m := t[0]*q'[0] mod W")
+		m := amd64.DX
+		f.MOVQ(f.qInv0(), m)
+		f.IMULQ(t[0], m)
+
+		// clear the carry flags
+		f.Comment("clear the flags")
+		f.XORQ(amd64.AX, amd64.AX)
+
+		// C,_ := t[0] + m*q[0]
+		f.Comment("C,_ := t[0] + m*q[0]")
+
+		f.MULXQ(f.qAt(0), amd64.AX, tr)
+		f.ADCXQ(t[0], amd64.AX)
+		f.MOVQ(tr, t[0])
+
+		// for j=1 to N-1
+		//    (C,t[j-1]) := t[j] + m*q[j] + C
+		for j := 1; j < f.NbWords; j++ {
+			f.Comment(fmt.Sprintf("(C,t[%[1]d]) := t[%[2]d] + m*q[%[2]d] + C", j-1, j))
+			f.ADCXQ(t[j], t[j-1])
+			f.MULXQ(f.qAt(j), amd64.AX, t[j])
+			f.ADOXQ(amd64.AX, t[j-1])
+		}
+
+		f.Comment(fmt.Sprintf("t[%d] = C + A", f.NbWordsLastIndex))
+		f.MOVQ(0, amd64.AX)
+		f.ADCXQ(amd64.AX, t[f.NbWordsLastIndex])
+		f.ADOXQ(A, t[f.NbWordsLastIndex])
+	}
+
+	f.Push(registers, y[2:]...)
+	registers.Push(tr, v)
+
+	return t
+}
+
+func (f *FFAmd64) generateSquare(forceADX bool) {
+	f.Comment("square(res, x *Element)")
+
+	const argSize = 2 * 8
+	minStackSize := argSize
+	if forceADX {
+		minStackSize = 0
+	}
+	reserved := []amd64.Register{amd64.DX, amd64.AX}
+	if f.NbWords <= 5 {
+		// when dynamic linking, R15 is clobbered by a global variable access
+		// this is a temporary workaround --> don't use R15 when we can avoid it.
+		// see https://github.com/ConsenSys/gnark-crypto/issues/113
+		reserved = append(reserved, amd64.R15)
+	}
+	// t, x, 2 temporary registers and y[2] ... y[N-1]
+	stackSize := f.StackSize(2*f.NbWords+1, len(reserved), minStackSize)
+	registers := f.FnHeader("square", stackSize, argSize, reserved...)
+	defer f.AssertCleanStack(stackSize, minStackSize)
+
+	f.WriteLn(fmt.Sprintf(`
+	// the algorithm is described in the %s.Square declaration (.go)
+	// this is the CIOS multiplication of x by x (see mul), where round i
+	// only computes the products of x[i] with x[i], 2x[i+1], ..., 2x[N-1]
+	`, f.ElementName))
+	if stackSize > 0 {
+		f.WriteLn("NO_LOCAL_POINTERS")
+	}
+
+	noAdx := f.NewLabel()
+
+	if !forceADX {
+		// check ADX instruction support
+		f.CMPB("·supportAdx(SB)", 1)
+		f.JNE(noAdx)
+	}
+
+	{
+		x := registers.Pop()
+		f.MOVQ("x+8(FP)", x)
+
+		t := registers.PopN(f.NbWords)
+		t = f.SquareADX(&registers, func(i int) string { return x.At(i) }, t)
+		registers.Push(x)
+
+		// ---------------------------------------------------------------------------------------------
+		// reduce
+		f.Reduce(&registers, t)
+
+		f.MOVQ("res+0(FP)", amd64.AX)
+		f.Mov(t, amd64.AX)
+		f.RET()
+	}
+
+	// ---------------------------------------------------------------------------------------------
+	// no MULX, ADX instructions
+	if !forceADX {
+		f.LABEL(noAdx)
+
+		f.MOVQ("res+0(FP)", amd64.AX)
+		f.MOVQ(amd64.AX, "(SP)")
+		f.MOVQ("x+8(FP)", amd64.AX)
+		f.MOVQ(amd64.AX, "8(SP)")
+		f.WriteLn("CALL ·_squareGeneric(SB)")
+		f.RET()
+	}
+}
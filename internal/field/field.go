@@ -28,7 +28,11 @@ import (
 )
 
 var (
-	errParseModulus = errors.New("can't parse modulus")
+	errParseModulus        = errors.New("can't parse modulus")
+	errAddChainDisabled    = errors.New("fixed exponents need the field config to be created with useAddChain")
+	errInvalidExponentName = errors.New("fixed exponent name must start with an upper case letter and contain only letters and digits")
+	errDuplicateExponent   = errors.New("fixed exponent name is already used")
+	errNonPositiveExponent = errors.New("fixed exponent must be strictly positive")
 )
 
 // FieldConfig precomputed values used in template for code generation of field element APIs
@@ -51,9 +55,12 @@ type FieldConfig struct {
 	QInverse                  []uint64
 	QMinusOneHalvedP          []uint64 // ((q-1) / 2 ) + 1
 	ASM                       bool
+	ASMSquare                 bool // dedicated squaring in assembly, see NoCarrySquare
 	RSquare                   []uint64
 	One, Thirteen             []uint64
 	LegendreExponent          string // big.Int to base16 string
+	TwoAdicity                uint64 // q-1 = 2^TwoAdicity * t, t odd
+	QuadraticNonResidue       uint64 // smallest positive integer which is not a square mod q
 	NoCarry                   bool
 	NoCarrySquare             bool // used if NoCarry is set, but some op may overflow in square optimization
 	SqrtQ3Mod4                bool
@@ -70,9 +77,17 @@ type FieldConfig struct {
 	SqrtAtkinExponentData     *addchain.AddChainData
 	SqrtSMinusOneOver2Data    *addchain.AddChainData
 	SqrtQ3Mod4ExponentData    *addchain.AddChainData
+	FixedExponents            []FixedExponent // see AddFixedExponent
 	UseAddChain               bool
 }
 
+// FixedExponent is an exponent registered with AddFixedExponent
+type FixedExponent struct {
+	Name     string // the generated method is ExpBy<Name>
+	Exponent string // big.Int to base16 string
+	Data     *addchain.AddChainData
+}
+
 // NewFieldConfig returns a data structure with needed information to generate apis for field element
 //
 // See field/generator package
@@ -181,6 +196,23 @@ func NewFieldConfig(packageName, elementName, modulus string, useAddChain bool)
 		F.LegendreExponentData = addchain.GetAddChain(&legendreExponent)
 	}
 
+	// q-1 = 2ˢ * t, t odd
+	{
+		var qMinusOne big.Int
+		qMinusOne.Sub(&bModulus, bOne)
+		F.TwoAdicity = uint64(qMinusOne.TrailingZeroBits())
+	}
+
+	// smallest quadratic non residue
+	{
+		var n big.Int
+		n.SetUint64(2)
+		for big.Jacobi(&n, &bModulus) != -1 {
+			n.Add(&n, bOne)
+		}
+		F.QuadraticNonResidue = n.Uint64()
+	}
+
 	// Sqrt pre computes
 	var qMod big.Int
 	qMod.SetUint64(4)
@@ -259,9 +291,53 @@ func NewFieldConfig(packageName, elementName, modulus string, useAddChain bool)
 	// asm code generation for moduli with more than 6 words can be optimized further
 	F.ASM = F.NoCarry && F.NbWords <= 12 && F.NbWords > 1
 
+	// the assembly squaring needs the intermediate results to fit on N words,
+	// and keeps 2x in registers; other moduli use the assembly multiplication instead
+	F.ASMSquare = F.ASM && F.NoCarrySquare && F.NbWords <= 6
+
 	return F, nil
 }
 
+// AddFixedExponent registers e, such that the generated code has a method
+//
+//	func (z *Element) ExpBy<name>(x Element) *Element
+//
+// equivalent to z.Exp(x, e) and implemented with a short addition chain
+// (for example, name = "SqrtQ3Mod4" and e = (q+1)/4).
+//
+// F must be created with useAddChain set.
+func (F *FieldConfig) AddFixedExponent(name string, e *big.Int) error {
+	if !F.UseAddChain {
+		return errAddChainDisabled
+	}
+	if name == "" || name[0] < 'A' || name[0] > 'Z' {
+		return errInvalidExponentName
+	}
+	for _, c := range name {
+		if !(c >= 'a' && c <= 'z') && !(c >= 'A' && c <= 'Z') && !(c >= '0' && c <= '9') {
+			return errInvalidExponentName
+		}
+	}
+	if name == "SqrtExp" || name == "LegendreExp" {
+		return errDuplicateExponent
+	}
+	for _, fe := range F.FixedExponents {
+		if fe.Name == name {
+			return errDuplicateExponent
+		}
+	}
+	if e.Sign() <= 0 {
+		return errNonPositiveExponent
+	}
+
+	F.FixedExponents = append(F.FixedExponents, FixedExponent{
+		Name:     name,
+		Exponent: e.Text(16),
+		Data:     addchain.GetAddChain(e),
+	})
+	return nil
+}
+
 func toUint64Slice(b *big.Int, nbWords ...int) (s []uint64) {
 	if len(nbWords) > 0 && nbWords[0] > len(b.Bits()) {
 		s = make([]uint64, nbWords[0])
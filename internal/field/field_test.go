@@ -227,3 +227,29 @@ func genField(t *testing.T) gopter.Gen {
 		return genResult
 	}
 }
+
+func TestAddFixedExponent(t *testing.T) {
+	e := big.NewInt(42)
+
+	f, err := NewFieldConfig("dummy", "DummyElement", "47", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := f.AddFixedExponent("Dummy", e); err != errAddChainDisabled {
+		t.Fatal("expected errAddChainDisabled, got", err)
+	}
+
+	// checks happen before any addition chain search, no need for useAddChain
+	f.UseAddChain = true
+	for _, name := range []string{"", "dummy", "Dum_my", "Dum my"} {
+		if err := f.AddFixedExponent(name, e); err != errInvalidExponentName {
+			t.Fatal(name, "expected errInvalidExponentName, got", err)
+		}
+	}
+	if err := f.AddFixedExponent("SqrtExp", e); err != errDuplicateExponent {
+		t.Fatal("expected errDuplicateExponent, got", err)
+	}
+	if err := f.AddFixedExponent("Dummy", big.NewInt(0)); err != errNonPositiveExponent {
+		t.Fatal("expected errNonPositiveExponent, got", err)
+	}
+}
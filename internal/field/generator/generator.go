@@ -34,9 +34,13 @@ func GenerateFF(F *field.FieldConfig, outputDir string) error {
 		element.Conv,
 		element.MulCIOS,
 		element.MulNoCarry,
+		element.SquareNoCarry,
 		element.Sqrt,
 		element.Inverse,
 		element.BigNum,
+		element.Recoding,
+		element.Unreduced,
+		element.Reduction,
 	}
 
 	// test file templates
@@ -46,6 +50,9 @@ func GenerateFF(F *field.FieldConfig, outputDir string) error {
 		element.Reduce,
 		element.Test,
 		element.InverseTests,
+		element.RecodingTests,
+		element.UnreducedTests,
+		element.ReductionTests,
 	}
 	// output files
 	eName := strings.ToLower(F.ElementName)
@@ -119,6 +126,8 @@ func GenerateFF(F *field.FieldConfig, outputDir string) error {
 				return err
 			}
 
+			_, _ = io.WriteString(f, "//go:build !purego\n// +build !purego\n\n")
+
 			if err := amd64.Generate(f, F); err != nil {
 				_ = f.Close()
 				return err
@@ -143,7 +152,7 @@ func GenerateFF(F *field.FieldConfig, outputDir string) error {
 				return err
 			}
 
-			_, _ = io.WriteString(f, "// +build !amd64_adx\n")
+			_, _ = io.WriteString(f, "//go:build !amd64_adx && !purego\n// +build !amd64_adx,!purego\n")
 
 			if err := amd64.GenerateMul(f, F); err != nil {
 				_ = f.Close()
@@ -169,7 +178,7 @@ func GenerateFF(F *field.FieldConfig, outputDir string) error {
 				return err
 			}
 
-			_, _ = io.WriteString(f, "// +build amd64_adx\n")
+			_, _ = io.WriteString(f, "//go:build amd64_adx && !purego\n// +build amd64_adx,!purego\n")
 
 			if err := amd64.GenerateMulADX(f, F); err != nil {
 				_ = f.Close()
@@ -195,7 +204,10 @@ func GenerateFF(F *field.FieldConfig, outputDir string) error {
 			element.OpsAMD64,
 		}
 		pathSrc := filepath.Join(outputDir, eName+"_ops_amd64.go")
-		if err := bavard.GenerateFromString(pathSrc, src, F, bavardOpts...); err != nil {
+		bavardOptsCpy := make([]func(*bavard.Bavard) error, len(bavardOpts))
+		copy(bavardOptsCpy, bavardOpts)
+		bavardOptsCpy = append(bavardOptsCpy, bavard.BuildTag("!purego"))
+		if err := bavard.GenerateFromString(pathSrc, src, F, bavardOptsCpy...); err != nil {
 			return err
 		}
 	}
@@ -204,6 +216,7 @@ func GenerateFF(F *field.FieldConfig, outputDir string) error {
 		// generate ops.go
 		src := []string{
 			element.OpsNoAsm,
+			element.OpsMulGeneric,
 			element.MulCIOS,
 			element.MulNoCarry,
 			element.Reduce,
@@ -212,8 +225,28 @@ func GenerateFF(F *field.FieldConfig, outputDir string) error {
 		bavardOptsCpy := make([]func(*bavard.Bavard) error, len(bavardOpts))
 		copy(bavardOptsCpy, bavardOpts)
 		if F.ASM {
-			bavardOptsCpy = append(bavardOptsCpy, bavard.BuildTag("!amd64"))
+			bavardOptsCpy = append(bavardOptsCpy, bavard.BuildTag("!amd64,!wasm purego"))
+		} else if F.NbWords > 1 {
+			bavardOptsCpy = append(bavardOptsCpy, bavard.BuildTag("!wasm purego"))
+		}
+		if err := bavard.GenerateFromString(pathSrc, src, F, bavardOptsCpy...); err != nil {
+			return err
+		}
+	}
+
+	if F.NbWords > 1 {
+		// generate ops_wasm.go, with a multiplication on 32-bit limbs
+		src := []string{
+			element.OpsMulWasm,
+			element.OpsNoAsm,
+			element.MulCIOS,
+			element.MulNoCarry,
+			element.Reduce,
 		}
+		pathSrc := filepath.Join(outputDir, eName+"_ops_wasm.go")
+		bavardOptsCpy := make([]func(*bavard.Bavard) error, len(bavardOpts))
+		copy(bavardOptsCpy, bavardOpts)
+		bavardOptsCpy = append(bavardOptsCpy, bavard.BuildTag("!purego"))
 		if err := bavard.GenerateFromString(pathSrc, src, F, bavardOptsCpy...); err != nil {
 			return err
 		}
@@ -86,6 +86,25 @@ func TestIntegration(t *testing.T) {
 		}
 	}
 
+	// fixed exponents need addition chains, which are cached in ./addchain
+	defer os.RemoveAll("addchain")
+	{
+		const elementName = "e_fixed_exp"
+		q, _ := new(big.Int).SetString(moduli["e_secp256k1"], 10)
+		fIntegration, err := field.NewFieldConfig("integration", elementName, q.String(), true)
+		if err != nil {
+			t.Fatal(elementName, err)
+		}
+		// x⁻¹ = x^(q-2)
+		e := new(big.Int).Sub(q, big.NewInt(2))
+		if err = fIntegration.AddFixedExponent("Inverse", e); err != nil {
+			t.Fatal(elementName, err)
+		}
+		if err = GenerateFF(fIntegration, filepath.Join(rootDir, elementName)); err != nil {
+			t.Fatal(elementName, err)
+		}
+	}
+
 	// run go test
 	wd, err := os.Getwd()
 	if err != nil {
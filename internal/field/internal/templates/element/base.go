@@ -11,7 +11,9 @@ import (
 	"sync"
 	"strconv"
 	"errors"
+	"fmt"
 	"reflect"
+	"runtime"
 	"strings"
 )
 
@@ -65,6 +67,36 @@ func Modulus() *big.Int {
 // used for Montgomery reduction
 const qInvNeg uint64 = {{index .QInverse 0}}
 
+// QInvNeg = - q⁻¹ mod 2⁶⁴, the constant used for Montgomery reduction
+const QInvNeg = qInvNeg
+
+// TwoAdicity is the largest s such that 2ˢ divides q-1, i.e. q-1 = 2ˢ⋅t with t odd;
+// 2^TwoAdicity is the largest power of 2 order of a root of unity in the field
+const TwoAdicity = {{.TwoAdicity}}
+
+// QuadraticNonResidue is the smallest positive integer which is not a square modulo q
+const QuadraticNonResidue = {{.QuadraticNonResidue}}
+
+// OddPart returns t, the odd part of q-1 = 2ˢ⋅t where s = TwoAdicity
+func OddPart() *big.Int {
+	t := new(big.Int).Sub(&_modulus, big.NewInt(1))
+	return t.Rsh(t, TwoAdicity)
+}
+
+// MontgomeryR returns R mod q, where R = 2^(64⋅Limbs) is the Montgomery radix.
+// It is the Montgomery form of 1.
+func MontgomeryR() *big.Int {
+	r := new(big.Int).Lsh(big.NewInt(1), Limbs*64)
+	return r.Mod(r, &_modulus)
+}
+
+// MontgomeryRSquare returns R² mod q, used to convert an integer to Montgomery form
+func MontgomeryRSquare() *big.Int {
+	r := MontgomeryR()
+	r.Mul(r, r)
+	return r.Mod(r, &_modulus)
+}
+
 var bigIntPool = sync.Pool{
 	New: func() interface{} {
 		return new(big.Int)
@@ -117,7 +149,7 @@ func (z *{{.ElementName}}) Set(x *{{.ElementName}}) *{{.ElementName}} {
 }
 
 // SetInterface converts provided interface into {{.ElementName}}
-// returns an error if provided type is not supported
+// returns an error wrapping ErrInvalidEncoding if provided type is not supported
 // supported types:
 //  {{.ElementName}}
 //  *{{.ElementName}}
@@ -129,7 +161,7 @@ func (z *{{.ElementName}}) Set(x *{{.ElementName}}) *{{.ElementName}} {
 //  []byte
 func (z *{{.ElementName}}) SetInterface(i1 interface{}) (*{{.ElementName}}, error) {
 	if i1 == nil {
-		return nil, errors.New("can't set {{.PackageName}}.{{.ElementName}} with <nil>")
+		return nil, fmt.Errorf("%w: can't set {{.PackageName}}.{{.ElementName}} with <nil>", ErrInvalidEncoding)
 	}
 
 	switch c1 := i1.(type) {
@@ -137,7 +169,7 @@ func (z *{{.ElementName}}) SetInterface(i1 interface{}) (*{{.ElementName}}, erro
 		return z.Set(&c1), nil
 	case *{{.ElementName}}:
 		if c1 == nil {
-			return nil, errors.New("can't set {{.PackageName}}.{{.ElementName}} with <nil>")
+			return nil, fmt.Errorf("%w: can't set {{.PackageName}}.{{.ElementName}} with <nil>", ErrInvalidEncoding)
 		}
 		return z.Set(c1), nil
 	case uint8:
@@ -164,7 +196,7 @@ func (z *{{.ElementName}}) SetInterface(i1 interface{}) (*{{.ElementName}}, erro
 		return z.SetString(c1)
 	case *big.Int:
 		if c1 == nil {
-			return nil, errors.New("can't set {{.PackageName}}.{{.ElementName}} with <nil>")
+			return nil, fmt.Errorf("%w: can't set {{.PackageName}}.{{.ElementName}} with <nil>", ErrInvalidEncoding)
 		}
 		return z.SetBigInt(c1), nil
 	case big.Int:
@@ -172,7 +204,7 @@ func (z *{{.ElementName}}) SetInterface(i1 interface{}) (*{{.ElementName}}, erro
 	case []byte:
 		return z.SetBytes(c1), nil
 	default:
-		return nil, errors.New("can't set {{.PackageName}}.{{.ElementName}} from type " + reflect.TypeOf(i1).String())
+		return nil, fmt.Errorf("%w: can't set {{.PackageName}}.{{.ElementName}} from type %s", ErrInvalidEncoding, reflect.TypeOf(i1).String())
 	}
 }
 
@@ -288,7 +320,15 @@ func (z *{{.ElementName}}) Cmp(x *{{.ElementName}}) int {
 
 // LexicographicallyLargest returns true if this element is strictly lexicographically
 // larger than its negation, false otherwise
+//
+// It is kept for consistency with the extension field types, see IsLexLargerThanHalf.
 func (z *{{.ElementName}}) LexicographicallyLargest() bool {
+	return z.IsLexLargerThanHalf()
+}
+
+// IsLexLargerThanHalf returns true if z > (q-1)/2 in regular form, that is, if
+// z is strictly lexicographically larger than its negation; false otherwise
+func (z *{{.ElementName}}) IsLexLargerThanHalf() bool {
 	// adapted from github.com/zkcrypto/bls12_381
 	// we check if the element is larger than (q-1) / 2
 	// if z - (((q -1) / 2) + 1) have no underflow, then z > (q-1) / 2
@@ -305,6 +345,15 @@ func (z *{{.ElementName}}) LexicographicallyLargest() bool {
 	return b == 0
 }
 
+// Sign0 returns the parity of z in regular form (0 or 1), as the sgn0 function of
+// https://datatracker.ietf.org/doc/html/rfc9380#section-4.1 for a prime field.
+// The sign of an element is not obviously related to that of its Montgomery form.
+func (z *{{.ElementName}}) Sign0() uint64 {
+	_z := *z
+	_z.FromMont()
+	return _z[0] & 1
+}
+
 // SetRandom sets z to a uniform random value in [0, q).
 //
 // This might error only if reading from crypto/rand.Reader errors, 
@@ -377,26 +426,24 @@ func (z *{{.ElementName}}) Halve()  {
 		var carry uint64
 	{{- end}}
 
-	if z[0]&1 == 1 {
-		{{- template "add_q" dict "all" . "V1" "z" }}
-	}
+	// if z is odd, we add q; the mask avoids branching on the parity of z
+	mask := -(z[0] & 1)
+	{{- template "add_q" dict "all" . "V1" "z" "Mask" "mask" }}
 	{{- rsh "z" .NbWords}}
 
 	{{- if not .NoCarry}}
-		if carry != 0 {
-			// when we added q, the result was larger than our avaible limbs
-			// when we shift right, we need to set the highest bit
-			z[{{.NbWordsLastIndex}}] |= (1 << 63)
-		}
+		// when we added q, the result may be larger than our available limbs
+		// when we shift right, we need to set the highest bit to the carry
+		z[{{.NbWordsLastIndex}}] |= carry << 63
 	{{end}}
 }
 
 {{ define "add_q" }}
-	// {{$.V1}} = {{$.V1}} + q 
+	// {{$.V1}} = {{$.V1}} + {{- if $.Mask}} (q & {{$.Mask}}){{- else}} q{{- end}}
 	{{- range $i := $.all.NbWordsIndexesFull }}
 		{{- $carryIn := ne $i 0}}
 		{{- $carryOut := or (ne $i $.all.NbWordsLastIndex) (and (eq $i $.all.NbWordsLastIndex) (not $.all.NoCarry))}}
-		{{$.V1}}[{{$i}}], {{- if $carryOut}}carry{{- else}}_{{- end}} = bits.Add64({{$.V1}}[{{$i}}], q{{$i}}, {{- if $carryIn}}carry{{- else}}0{{- end}})
+		{{$.V1}}[{{$i}}], {{- if $carryOut}}carry{{- else}}_{{- end}} = bits.Add64({{$.V1}}[{{$i}}], q{{$i}} {{- if $.Mask}} & {{$.Mask}}{{- end}}, {{- if $carryIn}}carry{{- else}}0{{- end}})
 	{{- end}}
 {{ end }}
 
@@ -465,11 +512,21 @@ func (z *{{.ElementName}}) Mul(x, y *{{.ElementName}}) *{{.ElementName}} {
 // x must be strictly inferior to q
 {{- end }}
 func (z *{{.ElementName}}) Square(x *{{.ElementName}}) *{{.ElementName}} {
-	// see Mul for algorithm documentation
 	{{- if eq $.NbWords 1}}
+		// see Mul for algorithm documentation
 		{{ template "mul_cios_one_limb" dict "all" . "V1" "x" "V2" "x" }}
 	{{- else }}
-		mul(z, x, x)
+		{{- if $.NoCarrySquare}}
+		// Implements CIOS squaring (see Mul), with y = 2x precomputed.
+		//
+		// Since x[i]*x[j] == x[j]*x[i], round i only multiplies x[i] by
+		// (x[i], 2x[i+1], ..., 2x[N-1]), which saves N(N-1)/2 word multiplications
+		// compared to mul(z, x, x). The intermediate results fit on N words
+		// since the most significant word of q is smaller than 2^62.
+		{{- else}}
+		// see Mul for algorithm documentation
+		{{- end}}
+		square(z, x)
 	{{- end }}
 	return z
 }
@@ -492,44 +549,16 @@ func (z *{{.ElementName}}) Add( x, y *{{.ElementName}}) *{{.ElementName}} {
 		z[{{$i}}], {{- if $hasCarry}}carry{{- else}}_{{- end}} = bits.Add64(x[{{$i}}], y[{{$i}}], {{- if eq $i 0}}0{{- else}}carry{{- end}})
 	{{- end}}
 
-	{{- if eq $.NbWords 1}}
-		if {{- if not .NoCarry}} carry != 0 ||{{- end }} z[0] >= q {
-			z[0] -= q
-		}
-	{{- else}}
-		{{- if not .NoCarry}}
-			// if we overflowed the last addition, z >= q
-			// if z >= q, z = z - q
-			if carry != 0 {
-				var b uint64
-				// we overflowed, so z >= q
-				{{- range $i := iterate 0 $.NbWords}}
-					{{- $hasBorrow := lt $i $.NbWordsLastIndex}}
-					z[{{$i}}], {{- if $hasBorrow}}b{{- else}}_{{- end}} = bits.Sub64(z[{{$i}}], q{{$i}}, {{- if eq $i 0}}0{{- else}}b{{- end}})
-				{{- end}}
-				return z
-			}
-		{{- end}}
-
+	{{- if .NoCarry}}
 		{{ template "reduce" .}}
+	{{- else}}
+		{{ template "cond_sub_q" dict "all" . "Carry" "carry"}}
 	{{- end}}
 	return z
 }
 
 // Double z = x + x (mod q), aka Lsh 1
 func (z *{{.ElementName}}) Double( x *{{.ElementName}}) *{{.ElementName}} {
-	{{- if eq .NbWords 1}}
-	if x[0] & (1 << 63) == (1 << 63) {
-		// if highest bit is set, then we have a carry to x + x, we shift and subtract q
-		z[0] = (x[0] << 1) - q 
-	} else {
-		// highest bit is not set, but x + x can still be >= q
-		z[0] = (x[0] << 1)
-		if z[0] >= q {
-			z[0] -= q
-		}
-	}
-	{{- else}}
 	{{ $hasCarry := or (not $.NoCarry) (gt $.NbWords 1)}}
 	{{- if $hasCarry}}
 		var carry uint64
@@ -538,21 +567,11 @@ func (z *{{.ElementName}}) Double( x *{{.ElementName}}) *{{.ElementName}} {
 		{{- $hasCarry := or (not $.NoCarry) (lt $i $.NbWordsLastIndex)}}
 		z[{{$i}}], {{- if $hasCarry}}carry{{- else}}_{{- end}} = bits.Add64(x[{{$i}}], x[{{$i}}], {{- if eq $i 0}}0{{- else}}carry{{- end}})
 	{{- end}}
-	{{- if not .NoCarry}}
-		// if we overflowed the last addition, z >= q
-		// if z >= q, z = z - q
-		if carry != 0 {
-			var b uint64
-			// we overflowed, so z >= q
-			{{- range $i := iterate 0 $.NbWords}}
-				{{- $hasBorrow := lt $i $.NbWordsLastIndex}}
-				z[{{$i}}], {{- if $hasBorrow}}b{{- else}}_{{- end}} = bits.Sub64(z[{{$i}}], q{{$i}}, {{- if eq $i 0}}0{{- else}}b{{- end}})
-			{{- end}}
-			return z
-		}
-	{{- end}}
 
-	{{ template "reduce" .}}
+	{{- if .NoCarry}}
+		{{ template "reduce" .}}
+	{{- else}}
+		{{ template "cond_sub_q" dict "all" . "Carry" "carry"}}
 	{{- end}}
 	return z
 }
@@ -565,32 +584,32 @@ func (z *{{.ElementName}}) Sub( x, y *{{.ElementName}}) *{{.ElementName}} {
 	{{- range $i := .NbWordsIndexesNoZero}}
 		z[{{$i}}], b = bits.Sub64(x[{{$i}}], y[{{$i}}], b)
 	{{- end}}
-	if b != 0 {
-		{{- if eq .NbWords 1}}
-			z[0] += q
-		{{- else}}
-			var c uint64
-			z[0], c = bits.Add64(z[0], q0, 0)
-			{{- range $i := .NbWordsIndexesNoZero}}
-				{{- if eq $i $.NbWordsLastIndex}}
-					z[{{$i}}], _ = bits.Add64(z[{{$i}}], q{{$i}}, c)
-				{{- else}}
-					z[{{$i}}], c = bits.Add64(z[{{$i}}], q{{$i}}, c)
-				{{- end}}
+
+	// if we borrowed, z += q; the mask avoids branching on the borrow
+	mask := -b
+	{{- if eq .NbWords 1}}
+		z[0] += q & mask
+	{{- else}}
+		var c uint64
+		z[0], c = bits.Add64(z[0], q0 & mask, 0)
+		{{- range $i := .NbWordsIndexesNoZero}}
+			{{- if eq $i $.NbWordsLastIndex}}
+				z[{{$i}}], _ = bits.Add64(z[{{$i}}], q{{$i}} & mask, c)
+			{{- else}}
+				z[{{$i}}], c = bits.Add64(z[{{$i}}], q{{$i}} & mask, c)
 			{{- end}}
 		{{- end}}
-	}
+	{{- end}}
 	return z
 }
 
 // Neg z = q - x
 func (z *{{.ElementName}}) Neg( x *{{.ElementName}}) *{{.ElementName}} {
-	if x.IsZero() {
-		z.SetZero()
-		return z
-	}
+	// mask is 0 if x == 0, all ones otherwise, so that -0 = 0 without branching
+	nz := x[0] {{- range $i := .NbWordsIndexesNoZero}} | x[{{$i}}]{{- end}}
+	mask := uint64(int64(nz | -nz) >> 63)
 	{{- if eq .NbWords 1}}
-		z[0] = q - x[0]
+		z[0] = (q - x[0]) & mask
 	{{- else}}
 		var borrow uint64
 		z[0], borrow = bits.Sub64(q0, x[0], 0)
@@ -601,6 +620,9 @@ func (z *{{.ElementName}}) Neg( x *{{.ElementName}}) *{{.ElementName}} {
 				z[{{$i}}], borrow = bits.Sub64(q{{$i}}, x[{{$i}}], borrow)
 			{{- end}}
 		{{- end}}
+		{{- range $i := .NbWordsIndexesFull}}
+			z[{{$i}}] &= mask
+		{{- end}}
 	{{- end}}
 	return z
 }
@@ -625,11 +647,21 @@ func _mulGeneric(z,x,y *{{.ElementName}}) {
 		{{ template "reduce"  . }}
 	{{ else }}
 		{{ template "mul_cios" dict "all" . "V1" "x" "V2" "y" }}
-		{{ template "reduce"  . }}
 	{{ end }}
 }
 
 
+func _squareGeneric(z, x *{{.ElementName}}) {
+	// see Square for algorithm documentation
+	{{ if eq $.NbWords 1}}
+		{{ template "mul_cios_one_limb" dict "all" . "V1" "x" "V2" "x" }}
+	{{ else if .NoCarrySquare}}
+		{{ template "square_nocarry" . }}
+		{{ template "reduce"  . }}
+	{{ else }}
+		_mulGeneric(z, x, x)
+	{{ end }}
+}
 func _fromMontGeneric(z *{{.ElementName}}) {
 	// the following lines implement z = z * 1
 	// with a modified CIOS montgomery multiplication
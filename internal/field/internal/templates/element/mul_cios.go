@@ -37,27 +37,14 @@ const MulCIOS = `
 	{{- end}}
 
 
-	if t[{{$.all.NbWords}}] != 0 {
-		// we need to reduce, we have a result on {{add 1 $.all.NbWords}} words
-		{{- if gt $.all.NbWords 1}}
-		var b uint64
-		{{- end}}
-		z[0], {{- if gt $.all.NbWords 1}}b{{- else}}_{{- end}} = bits.Sub64(t[0], q0, 0)
-		{{- range $i := .all.NbWordsIndexesNoZero}}
-			{{-  if eq $i $.all.NbWordsLastIndex}}
-				z[{{$i}}], _ = bits.Sub64(t[{{$i}}], q{{$i}}, b)
-			{{-  else  }}
-				z[{{$i}}], b = bits.Sub64(t[{{$i}}], q{{$i}}, b)
-			{{- end}}
-		{{- end}}
-		return
-	}
-
 	// copy t into z 
 	{{- range $i := $.all.NbWordsIndexesFull}}
 		z[{{$i}}] = t[{{$i}}]
 	{{- end}}
 
+	// if t[{{$.all.NbWords}}] != 0, we have a result on {{add 1 $.all.NbWords}} words and need to reduce
+	{{ template "cond_sub_q" dict "all" .all "Carry" (print "t[" $.all.NbWords "]")}}
+
 {{ end }}
 
 {{ define "mul_cios_one_limb" }}
@@ -68,10 +55,9 @@ const MulCIOS = `
 	_, carry := bits.Add64(lo2, lo, 0)
 	r, carry = bits.Add64(hi2, hi, carry)
 
-	if carry != 0 || r >= q  {
-		// we need to reduce
-		r -= q 
-	}
-	z[0] = r 
+	// if carry != 0 || r >= q, we need to reduce
+	r1, b := bits.Sub64(r, q, 0)
+	mask := -(carry | (b ^ 1))
+	z[0] = r ^ (mask & (r ^ r1))
 {{ end }}
 `
@@ -17,6 +17,17 @@ func MulBy13(x *{{.ElementName}})
 //go:noescape
 func mul(res,x,y *{{.ElementName}})
 
+{{- if .ASMSquare}}
+
+//go:noescape
+func square(res,x *{{.ElementName}})
+{{- else}}
+
+func square(z, x *{{.ElementName}}) {
+	mul(z, x, x)
+}
+{{- end}}
+
 //go:noescape
 func fromMont(res *{{.ElementName}})
 
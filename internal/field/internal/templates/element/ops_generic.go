@@ -39,11 +39,7 @@ func Butterfly(a, b *{{.ElementName}}) {
 	_butterflyGeneric(a, b)
 }
 
-{{- if ne .NbWords 1}}
-func mul(z, x, y *{{.ElementName}}) {
-	_mulGeneric(z, x, y)
-}
-{{- end}}
+{{ template "ops_mul" . }}
 
 func fromMont(z *{{.ElementName}} ) {
 	_fromMontGeneric(z)
@@ -53,3 +49,18 @@ func reduce(z *{{.ElementName}})  {
 	_reduceGeneric(z)
 }
 `
+
+// OpsMulGeneric defines mul and square for OpsNoAsm, using the 64-bit limbs CIOS
+const OpsMulGeneric = `
+{{ define "ops_mul" }}
+{{- if ne .NbWords 1}}
+func mul(z, x, y *{{.ElementName}}) {
+	_mulGeneric(z, x, y)
+}
+
+func square(z, x *{{.ElementName}}) {
+	_squareGeneric(z, x)
+}
+{{- end}}
+{{- end}}
+`
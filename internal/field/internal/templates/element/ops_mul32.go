@@ -0,0 +1,72 @@
+package element
+
+// OpsMulWasm defines mul and square for OpsNoAsm, using 32-bit limbs on wasm
+const OpsMulWasm = `
+import "math/bits"
+
+{{ define "ops_mul" }}
+{{- $nbLimbs := mul 2 .NbWords}}
+func mul(z, x, y *{{.ElementName}}) {
+	_mulWasm(z, x, y)
+}
+
+func square(z, x *{{.ElementName}}) {
+	_mulWasm(z, x, x)
+}
+
+const mask32 = 0xFFFFFFFF
+
+// madd32 returns hi, lo such that hi⋅2³² + lo = a⋅b + t + c, for a, b, t, c < 2³²
+func madd32(a, b, t, c uint64) (hi, lo uint64) {
+	r := a*b + t + c
+	return r >> 32, r & mask32
+}
+
+// _mulWasm sets z = x * y (mod q) with a CIOS Montgomery multiplication on {{$nbLimbs}} 32-bit limbs.
+//
+// wasm has no 64x64 -> 128 bits multiplication, so bits.Mul64 is emulated with four
+// 32-bit multiplications and a handful of shifts and additions. Here, each 32x32 -> 64 bits
+// product is a single i64.mul, and the carries are propagated in the upper half of the 64-bit words.
+//
+// Since R = 2^(64⋅{{.NbWords}}) = 2^(32⋅{{$nbLimbs}}), the result is in Montgomery form, as with _mulGeneric.
+// Note that -q⁻¹ mod 2³² is qInvNeg mod 2³².
+func _mulWasm(z, x, y *{{.ElementName}}) {
+	// x and y on 32-bit limbs
+	{{- range $i := .NbWordsIndexesFull}}
+	x{{mul $i 2}}, x{{add (mul $i 2) 1}} := x[{{$i}}]&mask32, x[{{$i}}]>>32
+	{{- end}}
+	{{- range $i := .NbWordsIndexesFull}}
+	y{{mul $i 2}}, y{{add (mul $i 2) 1}} := y[{{$i}}]&mask32, y[{{$i}}]>>32
+	{{- end}}
+
+	var {{range $j := iterate 0 (add $nbLimbs 2)}}t{{$j}}, {{end}}C, m uint64
+	{{- range $i := iterate 0 $nbLimbs}}
+
+	// round {{$i}}: (C,t) := t + x⋅y{{$i}}
+	{{- range $j := iterate 0 $nbLimbs}}
+	C, t{{$j}} = madd32(x{{$j}}, y{{$i}}, t{{$j}}, {{- if eq $j 0}} 0{{- else}} C{{- end}})
+	{{- end}}
+	C += t{{$nbLimbs}}
+	t{{$nbLimbs}}, t{{add $nbLimbs 1}} = C&mask32, C>>32
+
+	// (C,t) := (t + m⋅q) / 2³²
+	m = (t0 * qInvNeg) & mask32
+	C, _ = madd32(m, {{template "q32" 0}}, t0, 0)
+	{{- range $j := iterate 1 $nbLimbs}}
+	C, t{{sub $j 1}} = madd32(m, {{template "q32" $j}}, t{{$j}}, C)
+	{{- end}}
+	C += t{{$nbLimbs}}
+	t{{sub $nbLimbs 1}}, t{{$nbLimbs}} = C&mask32, t{{add $nbLimbs 1}}+C>>32
+	{{- end}}
+
+	{{- range $i := .NbWordsIndexesFull}}
+	z[{{$i}}] = t{{mul $i 2}} | t{{add (mul $i 2) 1}}<<32
+	{{- end}}
+	{{ template "cond_sub_q" dict "all" . "Carry" (print "t" $nbLimbs)}}
+}
+{{- end}}
+
+{{ define "q32" }}
+{{- if eq (mod . 2) 0}}q{{div . 2}}&mask32{{else}}q{{div . 2}}>>32{{end}}
+{{- end}}
+`
@@ -2,21 +2,24 @@ package element
 
 const Reduce = `
 {{ define "reduce" }}
-// if z >= q → z -= q
-if !z.smallerThanModulus() {
-{{- if eq $.NbWords 1}}
-		z[0] -= q
-{{- else}}
-	var b uint64
-	z[0], b = bits.Sub64(z[0], q0, 0)
-	{{- range $i := .NbWordsIndexesNoZero}}
-		{{-  if eq $i $.NbWordsLastIndex}}
-			z[{{$i}}], _ = bits.Sub64(z[{{$i}}], q{{$i}}, b)
-		{{-  else  }}
-			z[{{$i}}], b = bits.Sub64(z[{{$i}}], q{{$i}}, b)
-		{{- end}}
-	{{- end}}
+{{- template "cond_sub_q" dict "all" . "Carry" ""}}
 {{-  end }}
+
+{{ define "cond_sub_q" }}
+// if z >= q {{- if .Carry}} or {{.Carry}} != 0{{- end}} → z -= q
+// this is done without branching on the value of z
+{
+	{{- range $i := .all.NbWordsIndexesFull}}
+		r{{$i}}, b := bits.Sub64(z[{{$i}}], q{{$i}}, {{- if eq $i 0}}0{{- else}}b{{- end}})
+	{{- end}}
+	{{- if .Carry}}
+		mask := -({{.Carry}} | (b ^ 1)) // all ones if we need to subtract q
+	{{- else}}
+		mask := b - 1 // all ones if z >= q
+	{{- end}}
+	{{- range $i := .all.NbWordsIndexesFull}}
+		z[{{$i}}] ^= mask & (z[{{$i}}] ^ r{{$i}})
+	{{- end}}
 }
 
 {{-  end }}
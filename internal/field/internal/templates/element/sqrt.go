@@ -27,7 +27,7 @@ func (z *{{.ElementName}}) Legendre() int {
 	var l {{.ElementName}}
 	// z^((q-1)/2)
 	{{- if .UseAddChain}}
-	l.expByLegendreExp(*z)
+	l.ExpByLegendreExp(*z)
 	{{- else}}
 	l.Exp(*z, _bLegendreExponent{{.ElementName}})
 	{{- end}}
@@ -53,7 +53,7 @@ func (z *{{.ElementName}}) Sqrt(x *{{.ElementName}}) *{{.ElementName}} {
 		// using  z ≡ ± x^((p+1)/4) (mod q)
 		var y, square {{.ElementName}}
 		{{- if .UseAddChain}}
-		y.expBySqrtExp(*x)
+		y.ExpBySqrtExp(*x)
 		{{- else}}
 		y.Exp(*x, _bSqrtExponent{{.ElementName}})
 		{{- end }}
@@ -70,7 +70,7 @@ func (z *{{.ElementName}}) Sqrt(x *{{.ElementName}}) *{{.ElementName}} {
 		one.SetOne()
 		tx.Double(x)
 		{{- if .UseAddChain}}
-		alpha.expBySqrtExp(tx)
+		alpha.ExpBySqrtExp(tx)
 		{{ else }}
 		alpha.Exp(tx, _bSqrtExponent{{.ElementName}})
 		{{- end }}
@@ -94,7 +94,7 @@ func (z *{{.ElementName}}) Sqrt(x *{{.ElementName}}) *{{.ElementName}} {
 		var y, b,t, w  {{.ElementName}}
 		// w = x^((s-1)/2))
 		{{- if .UseAddChain}}
-		w.expBySqrtExp(*x)
+		w.ExpBySqrtExp(*x)
 		{{- else}}
 		w.Exp(*x, _bSqrtExponent{{.ElementName}})
 		{{- end}}
@@ -0,0 +1,51 @@
+package element
+
+// SquareNoCarry is a CIOS squaring, see Square for more info on the algorithm
+const SquareNoCarry = `
+{{ define "square_nocarry" }}
+var t [{{.NbWords}}]uint64
+var c [3]uint64
+{{- if gt .NbWords 2}}
+
+// y = 2x, which fits on {{.NbWords}} words
+{{- range $k := iterate 2 .NbWords}}
+y{{$k}} := x[{{$k}}]<<1 | x[{{sub $k 1}}]>>63
+{{- end}}
+{{- end}}
+{{- range $i := .NbWordsIndexesFull}}
+{
+	// round {{$i}}: x[{{$i}}] * (x[{{$i}}] {{- range $k := iterate (add $i 1) $.NbWords}}, {{ if eq $k (add $i 1)}}x[{{$k}}]<<1{{else}}y{{$k}}{{end}}{{- end}})
+	{{- $out := "t"}}
+	{{- if eq $i $.NbWordsLastIndex}}{{ $out = "z" }}{{- end}}
+	v := x[{{$i}}]
+	{{- if eq $i 0}}
+		c[1], c[0] = bits.Mul64(v, v)
+		m := c[0] * qInvNeg
+		c[2] = madd0(m, q0, c[0])
+	{{- else}}
+		m := t[0] * qInvNeg
+		c[2] = madd0(m, q0, t[0])
+	{{- end}}
+	{{- range $k := $.NbWordsIndexesNoZero}}
+		{{- if lt $k $i}}
+			c[2], {{$out}}[{{sub $k 1}}] = madd2(m, q{{$k}}, c[2], t[{{$k}}])
+		{{- else}}
+			{{- if eq $k $i}}
+				c[1], c[0] = madd1(v, v, t[{{$k}}])
+			{{- else if eq $i 0}}
+				c[1], c[0] = madd1(v, {{ if eq $k (add $i 1)}}x[{{$k}}]<<1{{else}}y{{$k}}{{end}}, c[1])
+			{{- else}}
+				c[1], c[0] = madd2(v, {{ if eq $k (add $i 1)}}x[{{$k}}]<<1{{else}}y{{$k}}{{end}}, c[1], t[{{$k}}])
+			{{- end}}
+			{{- if eq $k $.NbWordsLastIndex}}
+				{{$out}}[{{$.NbWordsLastIndex}}], {{$out}}[{{sub $k 1}}] = madd3(m, q{{$k}}, c[0], c[2], c[1])
+			{{- else}}
+				c[2], {{$out}}[{{sub $k 1}}] = madd2(m, q{{$k}}, c[2], c[0])
+			{{- end}}
+		{{- end}}
+	{{- end}}
+}
+{{- end}}
+{{ end }}
+
+`
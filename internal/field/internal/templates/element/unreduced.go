@@ -0,0 +1,210 @@
+package element
+
+const Unreduced = `
+
+// {{.ElementName}}Unreduced is an accumulator for sums of products of {{.ElementName}}, performing
+// a single Montgomery reduction when the final value is needed.
+//
+// It stores the full (unreduced) integer value of ∑ aᵢ⋅bᵢ + ∑ xⱼ⋅R on 2⋅Limbs+1 words,
+// which is enough for 2⁶⁴ accumulations.
+type {{.ElementName}}Unreduced [2*Limbs + 1]uint64
+
+// SetZero sets z to 0 and returns z
+func (z *{{.ElementName}}Unreduced) SetZero() *{{.ElementName}}Unreduced {
+	*z = {{.ElementName}}Unreduced{}
+	return z
+}
+
+// MulAcc z = z + x * y (unreduced) and returns z
+func (z *{{.ElementName}}Unreduced) MulAcc(x, y *{{.ElementName}}) *{{.ElementName}}Unreduced {
+	// t = x * y (schoolbook, fits on 2⋅Limbs words)
+	var t [2 * Limbs]uint64
+	var c, hi, lo, cc uint64
+	{{- range $i := .NbWordsIndexesFull}}
+	c = 0
+	{{- range $j := $.NbWordsIndexesFull}}
+	hi, lo = bits.Mul64(x[{{$i}}], y[{{$j}}])
+	lo, cc = bits.Add64(lo, t[{{add $i $j}}], 0)
+	hi += cc
+	t[{{add $i $j}}], cc = bits.Add64(lo, c, 0)
+	c = hi + cc
+	{{- end}}
+	t[{{add $i $.NbWords}}] = c
+	{{- end}}
+
+	// z = z + t
+	{{- range $i := iterate 0 (mul 2 .NbWords)}}
+	z[{{$i}}], c = bits.Add64(z[{{$i}}], t[{{$i}}], {{if eq $i 0}}0{{else}}c{{end}})
+	{{- end}}
+	z[2*Limbs] += c
+
+	return z
+}
+
+// Add z = z + x (unreduced) and returns z
+func (z *{{.ElementName}}Unreduced) Add(x *{{.ElementName}}) *{{.ElementName}}Unreduced {
+	// x is in Montgomery form; to be consistent with the products accumulated
+	// by MulAcc, we add x⋅R, i.e. x shifted by Limbs words.
+	var carry uint64
+	for i := 0; i < Limbs; i++ {
+		z[Limbs+i], carry = bits.Add64(z[Limbs+i], x[i], carry)
+	}
+	z[2*Limbs] += carry
+	return z
+}
+
+// Reduce sets res to the value accumulated in z, reduced modulo q, and returns res
+func (z *{{.ElementName}}Unreduced) Reduce(res *{{.ElementName}}) *{{.ElementName}} {
+	t := *z
+
+	// Montgomery reduction: t = (t + m⋅q) / R, with m such that the Limbs low words vanish
+	for i := 0; i < Limbs; i++ {
+		m := t[i] * qInvNeg
+		var carry uint64
+		for j := 0; j < Limbs; j++ {
+			hi, lo := bits.Mul64(m, q{{.ElementName}}[j])
+			var c uint64
+			lo, c = bits.Add64(lo, t[i+j], 0)
+			hi += c
+			lo, c = bits.Add64(lo, carry, 0)
+			hi += c
+			t[i+j] = lo
+			carry = hi
+		}
+		for k := i + Limbs; carry != 0 && k < len(t); k++ {
+			t[k], carry = bits.Add64(t[k], carry, 0)
+		}
+	}
+
+	// the result is now lo + h⋅R, with lo < R, which may still be larger than q.
+	var lo, h {{.ElementName}}
+	copy(lo[:], t[Limbs:2*Limbs])
+	fromMont(&lo)
+	lo.Mul(&lo, &rSquare)
+	h.SetUint64(t[2*Limbs])
+
+	return res.Add(&lo, &h)
+}
+
+// Dot returns ∑ aᵢ⋅bᵢ, using a single reduction.
+//
+// It panics if len(a) != len(b).
+func Dot(a, b []{{.ElementName}}) {{.ElementName}} {
+	if len(a) != len(b) {
+		panic("Dot: vectors don't have the same length")
+	}
+	var acc {{.ElementName}}Unreduced
+	for i := 0; i < len(a); i++ {
+		acc.MulAcc(&a[i], &b[i])
+	}
+	var res {{.ElementName}}
+	acc.Reduce(&res)
+	return res
+}
+
+`
+
+const UnreducedTests = `
+
+func Test{{toTitle .ElementName}}Unreduced(t *testing.T) {
+	t.Parallel()
+	parameters := gopter.DefaultTestParameters()
+	if testing.Short() {
+		parameters.MinSuccessfulTests = nbFuzzShort
+	} else {
+		parameters.MinSuccessfulTests = nbFuzz
+	}
+
+	properties := gopter.NewProperties(parameters)
+
+	genA := gen()
+	genB := gen()
+	genC := gen()
+
+	properties.Property("MulAcc and Add should output the same result as Mul and Add", prop.ForAll(
+		func(a, b, c testPair{{.ElementName}}) bool {
+			var acc {{.ElementName}}Unreduced
+			acc.MulAcc(&a.element, &b.element).Add(&c.element).MulAcc(&c.element, &a.element)
+
+			var expected, tmp {{.ElementName}}
+			expected.Mul(&a.element, &b.element).Add(&expected, &c.element)
+			tmp.Mul(&c.element, &a.element)
+			expected.Add(&expected, &tmp)
+
+			var res {{.ElementName}}
+			acc.Reduce(&res)
+			return res.Equal(&expected)
+		},
+		genA,
+		genB,
+		genC,
+	))
+
+	properties.Property("Dot should output the same result as a naive inner product", prop.ForAll(
+		func(a, b testPair{{.ElementName}}) bool {
+			const n = 17
+			va := make([]{{.ElementName}}, n)
+			vb := make([]{{.ElementName}}, n)
+			var expected, tmp {{.ElementName}}
+			for i := 0; i < n; i++ {
+				va[i].SetUint64(uint64(i)).Add(&va[i], &a.element)
+				vb[i].SetUint64(uint64(i)).Mul(&vb[i], &b.element)
+				tmp.Mul(&va[i], &vb[i])
+				expected.Add(&expected, &tmp)
+			}
+			res := Dot(va, vb)
+			return res.Equal(&expected)
+		},
+		genA,
+		genB,
+	))
+
+	properties.TestingRun(t, gopter.ConsoleReporter(false))
+
+	// largest limbs, many times
+	var qMinusOne, expected, tmp, res {{.ElementName}}
+	qMinusOne.SetOne().Neg(&qMinusOne)
+	var acc {{.ElementName}}Unreduced
+	for i := 0; i < 1000; i++ {
+		acc.MulAcc(&qMinusOne, &qMinusOne).Add(&qMinusOne)
+		tmp.Mul(&qMinusOne, &qMinusOne).Add(&tmp, &qMinusOne)
+		expected.Add(&expected, &tmp)
+	}
+	if !acc.Reduce(&res).Equal(&expected) {
+		t.Fatal("accumulating (q-1)² + (q-1) failed")
+	}
+
+	acc.SetZero()
+	if !acc.Reduce(&res).IsZero() {
+		t.Fatal("empty accumulator should reduce to 0")
+	}
+}
+
+func Benchmark{{toTitle .ElementName}}Dot(b *testing.B) {
+	const n = 1024
+	va := make([]{{.ElementName}}, n)
+	vb := make([]{{.ElementName}}, n)
+	for i := 0; i < n; i++ {
+		va[i].SetRandom()
+		vb[i].SetRandom()
+	}
+
+	b.Run("naive", func(b *testing.B) {
+		for j := 0; j < b.N; j++ {
+			var tmp {{.ElementName}}
+			benchRes{{.ElementName}}.SetZero()
+			for i := 0; i < n; i++ {
+				tmp.Mul(&va[i], &vb[i])
+				benchRes{{.ElementName}}.Add(&benchRes{{.ElementName}}, &tmp)
+			}
+		}
+	})
+
+	b.Run("unreduced", func(b *testing.B) {
+		for j := 0; j < b.N; j++ {
+			benchRes{{.ElementName}} = Dot(va, vb)
+		}
+	})
+}
+
+`
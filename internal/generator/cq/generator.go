@@ -0,0 +1,23 @@
+package cq
+
+import (
+	"path/filepath"
+
+	"github.com/consensys/bavard"
+	"github.com/consensys/gnark-crypto/internal/generator/config"
+)
+
+func Generate(conf config.Curve, baseDir string, bgen *bavard.BatchGenerator) error {
+
+	// cq lookup argument
+	conf.Package = "cq"
+	entries := []bavard.Entry{
+		{File: filepath.Join(baseDir, "doc.go"), Templates: []string{"doc.go.tmpl"}},
+		{File: filepath.Join(baseDir, "setup.go"), Templates: []string{"setup.go.tmpl"}},
+		{File: filepath.Join(baseDir, "cq.go"), Templates: []string{"cq.go.tmpl"}},
+		{File: filepath.Join(baseDir, "marshal.go"), Templates: []string{"marshal.go.tmpl"}},
+		{File: filepath.Join(baseDir, "cq_test.go"), Templates: []string{"cq.test.go.tmpl"}},
+	}
+	return bgen.Generate(conf, conf.Package, "./cq/template/", entries...)
+
+}
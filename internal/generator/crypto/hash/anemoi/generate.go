@@ -0,0 +1,60 @@
+package anemoi
+
+import (
+	"fmt"
+	"math/big"
+	"path/filepath"
+
+	"github.com/consensys/bavard"
+	"github.com/consensys/gnark-crypto/internal/generator/config"
+)
+
+// nbRounds number of rounds of Anemoi with a single column (state of two elements)
+// at the 128-bit security level, indexed by α (table 5 of the Anemoi paper)
+var nbRounds = map[uint64]int{
+	3:  21,
+	5:  21,
+	7:  20,
+	11: 19,
+}
+
+type anemoiConfig struct {
+	config.Curve
+	Alpha    uint64 // smallest prime such that x -> x^α is a permutation of fr
+	AlphaInv string // α⁻¹ mod r-1
+	NbRounds int
+}
+
+func Generate(conf config.Curve, baseDir string, bgen *bavard.BatchGenerator) error {
+	conf.Package = "anemoi"
+
+	data := anemoiConfig{Curve: conf}
+	if err := data.setParameters(conf.FrInfo.Modulus()); err != nil {
+		return fmt.Errorf("%s: %w", conf.Name, err)
+	}
+
+	entries := []bavard.Entry{
+		{File: filepath.Join(baseDir, "doc.go"), Templates: []string{"doc.go.tmpl"}},
+		{File: filepath.Join(baseDir, "anemoi.go"), Templates: []string{"anemoi.go.tmpl"}},
+		{File: filepath.Join(baseDir, "anemoi_test.go"), Templates: []string{"tests/anemoi.go.tmpl"}},
+	}
+	return bgen.Generate(data, conf.Package, "./crypto/hash/anemoi/template", entries...)
+
+}
+
+// setParameters sets α, α⁻¹ and the number of rounds for the modulus r
+func (c *anemoiConfig) setParameters(r *big.Int) error {
+	rMinusOne := new(big.Int).Sub(r, big.NewInt(1))
+	var gcd big.Int
+	for _, alpha := range []uint64{3, 5, 7, 11} {
+		a := new(big.Int).SetUint64(alpha)
+		if gcd.GCD(nil, nil, a, rMinusOne).Cmp(big.NewInt(1)) != 0 {
+			continue
+		}
+		c.Alpha = alpha
+		c.AlphaInv = new(big.Int).ModInverse(a, rMinusOne).String()
+		c.NbRounds = nbRounds[alpha]
+		return nil
+	}
+	return fmt.Errorf("no supported α for the modulus %s", r.String())
+}
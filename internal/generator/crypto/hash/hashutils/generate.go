@@ -0,0 +1,21 @@
+package hashutils
+
+import (
+	"path/filepath"
+
+	"github.com/consensys/bavard"
+	"github.com/consensys/gnark-crypto/internal/generator/config"
+)
+
+func Generate(conf config.Curve, baseDir string, bgen *bavard.BatchGenerator) error {
+	conf.Package = "hashutils"
+	entries := []bavard.Entry{
+		{File: filepath.Join(baseDir, "doc.go"), Templates: []string{"doc.go.tmpl"}},
+		{File: filepath.Join(baseDir, "hashutils.go"), Templates: []string{"hashutils.go.tmpl"}},
+		{File: filepath.Join(baseDir, "hashutils_test.go"), Templates: []string{"hashutils.test.go.tmpl"}},
+		{File: filepath.Join(baseDir, "stream.go"), Templates: []string{"stream.go.tmpl"}},
+		{File: filepath.Join(baseDir, "stream_test.go"), Templates: []string{"stream.test.go.tmpl"}},
+	}
+	return bgen.Generate(conf, conf.Package, "./crypto/hash/hashutils/template", entries...)
+
+}
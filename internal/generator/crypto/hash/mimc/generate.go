@@ -12,6 +12,11 @@ func Generate(conf config.Curve, baseDir string, bgen *bavard.BatchGenerator) er
 	entries := []bavard.Entry{
 		{File: filepath.Join(baseDir, "doc.go"), Templates: []string{"doc.go.tmpl"}},
 		{File: filepath.Join(baseDir, "mimc.go"), Templates: []string{"mimc.go.tmpl"}},
+		{File: filepath.Join(baseDir, "options_test.go"), Templates: []string{"tests/mimc.go.tmpl"}},
+		{File: filepath.Join(baseDir, "prf.go"), Templates: []string{"prf.go.tmpl"}},
+		{File: filepath.Join(baseDir, "prf_test.go"), Templates: []string{"tests/prf.go.tmpl"}},
+		{File: filepath.Join(baseDir, "transcript.go"), Templates: []string{"transcript.go.tmpl"}},
+		{File: filepath.Join(baseDir, "transcript_test.go"), Templates: []string{"tests/transcript.go.tmpl"}},
 	}
 	return bgen.Generate(conf, conf.Package, "./crypto/hash/mimc/template", entries...)
 
@@ -18,6 +18,25 @@ func Generate(conf config.Curve, baseDir string, bgen *bavard.BatchGenerator) er
 		{File: filepath.Join(baseDir, "multiexp_test.go"), Templates: []string{"tests/multiexp.go.tmpl"}},
 		{File: filepath.Join(baseDir, "marshal.go"), Templates: []string{"marshal.go.tmpl"}},
 		{File: filepath.Join(baseDir, "marshal_test.go"), Templates: []string{"tests/marshal.go.tmpl"}},
+		{File: filepath.Join(baseDir, "group.go"), Templates: []string{"group.go.tmpl"}},
+		{File: filepath.Join(baseDir, "registry.go"), Templates: []string{"registry.go.tmpl"}},
+		{File: filepath.Join(baseDir, "glv.go"), Templates: []string{"glv.go.tmpl"}},
+		{File: filepath.Join(baseDir, "glv_test.go"), Templates: []string{"tests/glv.go.tmpl"}},
+		{File: filepath.Join(baseDir, "dudect_test.go"), Templates: []string{"tests/dudect.go.tmpl"}, BuildTag: "dudect"},
+	}
+	if conf.Equal(config.BN254) || conf.Equal(config.BLS12_381) {
+		// arkworks compatible serialization
+		entries = append(entries,
+			bavard.Entry{File: filepath.Join(baseDir, "marshal_arkworks.go"), Templates: []string{"marshal_arkworks.go.tmpl"}},
+			bavard.Entry{File: filepath.Join(baseDir, "marshal_arkworks_test.go"), Templates: []string{"tests/marshal_arkworks.go.tmpl"}},
+		)
+	}
+	if conf.Equal(config.BLS12_381) {
+		// conventions of the G2 compressed encoding
+		entries = append(entries,
+			bavard.Entry{File: filepath.Join(baseDir, "marshal_g2encoding.go"), Templates: []string{"marshal_g2encoding.go.tmpl"}},
+			bavard.Entry{File: filepath.Join(baseDir, "marshal_g2encoding_test.go"), Templates: []string{"tests/marshal_g2encoding.go.tmpl"}},
+		)
 	}
 	conf.Package = packageName
 	if err := bgen.Generate(conf, packageName, "./ecc/template", entries...); err != nil {
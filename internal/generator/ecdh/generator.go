@@ -0,0 +1,21 @@
+package ecdh
+
+import (
+	"path/filepath"
+
+	"github.com/consensys/bavard"
+	"github.com/consensys/gnark-crypto/internal/generator/config"
+)
+
+func Generate(conf config.Curve, baseDir string, bgen *bavard.BatchGenerator) error {
+
+	// Diffie-Hellman key exchange on G1
+	conf.Package = "ecdh"
+	entries := []bavard.Entry{
+		{File: filepath.Join(baseDir, "doc.go"), Templates: []string{"doc.go.tmpl"}},
+		{File: filepath.Join(baseDir, "ecdh.go"), Templates: []string{"ecdh.go.tmpl"}},
+		{File: filepath.Join(baseDir, "ecdh_test.go"), Templates: []string{"ecdh.test.go.tmpl"}},
+	}
+	return bgen.Generate(conf, conf.Package, "./ecdh/template/", entries...)
+
+}
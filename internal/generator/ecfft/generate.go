@@ -0,0 +1,22 @@
+package ecfft
+
+import (
+	"path/filepath"
+
+	"github.com/consensys/bavard"
+	"github.com/consensys/gnark-crypto/internal/generator/config"
+)
+
+func Generate(conf config.Curve, baseDir string, bgen *bavard.BatchGenerator) error {
+
+	// elliptic curve fft on fp, whose multiplicative group has no large 2-adic subgroup
+	conf.Package = "ecfft"
+	entries := []bavard.Entry{
+		{File: filepath.Join(baseDir, "doc.go"), Templates: []string{"doc.go.tmpl"}},
+		{File: filepath.Join(baseDir, "ecfft.go"), Templates: []string{"ecfft.go.tmpl"}},
+		{File: filepath.Join(baseDir, "params.go"), Templates: []string{"params.go.tmpl"}},
+		{File: filepath.Join(baseDir, "ecfft_test.go"), Templates: []string{"ecfft.test.go.tmpl"}},
+	}
+	return bgen.Generate(conf, conf.Package, "./ecfft/template/", entries...)
+
+}
@@ -0,0 +1,26 @@
+package ecdh
+
+import (
+	"path/filepath"
+
+	"github.com/consensys/bavard"
+	"github.com/consensys/gnark-crypto/internal/generator/config"
+)
+
+func Generate(conf config.TwistedEdwardsCurve, baseDir string, bgen *bavard.BatchGenerator) error {
+	// ecdh
+	data := struct {
+		config.TwistedEdwardsCurve
+		CurvePackage string
+	}{conf, conf.Package}
+	data.Package = "ecdh"
+	baseDir = filepath.Join(baseDir, data.Package)
+
+	entries := []bavard.Entry{
+		{File: filepath.Join(baseDir, "doc.go"), Templates: []string{"doc.go.tmpl"}},
+		{File: filepath.Join(baseDir, "ecdh.go"), Templates: []string{"ecdh.go.tmpl"}},
+		{File: filepath.Join(baseDir, "ecdh_test.go"), Templates: []string{"ecdh.test.go.tmpl"}},
+	}
+	return bgen.Generate(data, data.Package, "./edwards/ecdh/template", entries...)
+
+}
@@ -0,0 +1,27 @@
+package ecies
+
+import (
+	"path/filepath"
+
+	"github.com/consensys/bavard"
+	"github.com/consensys/gnark-crypto/internal/generator/config"
+)
+
+func Generate(conf config.TwistedEdwardsCurve, baseDir string, bgen *bavard.BatchGenerator) error {
+	// ecies
+	data := struct {
+		config.TwistedEdwardsCurve
+		CurvePackage string
+	}{conf, conf.Package}
+	data.Package = "ecies"
+	baseDir = filepath.Join(baseDir, data.Package)
+
+	entries := []bavard.Entry{
+		{File: filepath.Join(baseDir, "doc.go"), Templates: []string{"doc.go.tmpl"}},
+		{File: filepath.Join(baseDir, "ecies.go"), Templates: []string{"ecies.go.tmpl"}},
+		{File: filepath.Join(baseDir, "ecies_test.go"), Templates: []string{"ecies.test.go.tmpl"}},
+		{File: filepath.Join(baseDir, "dudect_test.go"), Templates: []string{"dudect.go.tmpl"}, BuildTag: "dudect"},
+	}
+	return bgen.Generate(data, data.Package, "./edwards/ecies/template", entries...)
+
+}
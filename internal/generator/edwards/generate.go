@@ -13,6 +13,14 @@ func Generate(conf config.TwistedEdwardsCurve, baseDir string, bgen *bavard.Batc
 		{File: filepath.Join(baseDir, "point_test.go"), Templates: []string{"tests/point.go.tmpl"}},
 		{File: filepath.Join(baseDir, "doc.go"), Templates: []string{"doc.go.tmpl"}},
 		{File: filepath.Join(baseDir, "curve.go"), Templates: []string{"curve.go.tmpl"}},
+		{File: filepath.Join(baseDir, "group.go"), Templates: []string{"group.go.tmpl"}},
+		{File: filepath.Join(baseDir, "multiexp.go"), Templates: []string{"multiexp.go.tmpl"}},
+		{File: filepath.Join(baseDir, "multiexp_test.go"), Templates: []string{"tests/multiexp.go.tmpl"}},
+		{File: filepath.Join(baseDir, "montgomery.go"), Templates: []string{"montgomery.go.tmpl"}},
+		{File: filepath.Join(baseDir, "montgomery_test.go"), Templates: []string{"tests/montgomery.go.tmpl"}},
+		{File: filepath.Join(baseDir, "elligator.go"), Templates: []string{"elligator.go.tmpl"}},
+		{File: filepath.Join(baseDir, "elligator_test.go"), Templates: []string{"tests/elligator.go.tmpl"}},
+		{File: filepath.Join(baseDir, "dudect_test.go"), Templates: []string{"tests/dudect.go.tmpl"}, BuildTag: "dudect"},
 	}
 
 	return bgen.Generate(conf, conf.Package, "./edwards/template", entries...)
@@ -0,0 +1,26 @@
+package hdkey
+
+import (
+	"path/filepath"
+
+	"github.com/consensys/bavard"
+	"github.com/consensys/gnark-crypto/internal/generator/config"
+)
+
+func Generate(conf config.TwistedEdwardsCurve, baseDir string, bgen *bavard.BatchGenerator) error {
+	// hierarchical deterministic keys
+	data := struct {
+		config.TwistedEdwardsCurve
+		CurvePackage string
+	}{conf, conf.Package}
+	data.Package = "hdkey"
+	baseDir = filepath.Join(baseDir, data.Package)
+
+	entries := []bavard.Entry{
+		{File: filepath.Join(baseDir, "doc.go"), Templates: []string{"doc.go.tmpl"}},
+		{File: filepath.Join(baseDir, "hdkey.go"), Templates: []string{"hdkey.go.tmpl"}},
+		{File: filepath.Join(baseDir, "hdkey_test.go"), Templates: []string{"hdkey.test.go.tmpl"}},
+	}
+	return bgen.Generate(data, data.Package, "./edwards/hdkey/template", entries...)
+
+}
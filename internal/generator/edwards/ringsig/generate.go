@@ -0,0 +1,27 @@
+package ringsig
+
+import (
+	"path/filepath"
+
+	"github.com/consensys/bavard"
+	"github.com/consensys/gnark-crypto/internal/generator/config"
+)
+
+func Generate(conf config.TwistedEdwardsCurve, baseDir string, bgen *bavard.BatchGenerator) error {
+	// linkable ring signatures
+	data := struct {
+		config.TwistedEdwardsCurve
+		CurvePackage string
+	}{conf, conf.Package}
+	data.Package = "ringsig"
+	baseDir = filepath.Join(baseDir, data.Package)
+
+	entries := []bavard.Entry{
+		{File: filepath.Join(baseDir, "doc.go"), Templates: []string{"doc.go.tmpl"}},
+		{File: filepath.Join(baseDir, "ringsig.go"), Templates: []string{"ringsig.go.tmpl"}},
+		{File: filepath.Join(baseDir, "marshal.go"), Templates: []string{"marshal.go.tmpl"}},
+		{File: filepath.Join(baseDir, "ringsig_test.go"), Templates: []string{"ringsig.test.go.tmpl"}},
+	}
+	return bgen.Generate(data, data.Package, "./edwards/ringsig/template", entries...)
+
+}
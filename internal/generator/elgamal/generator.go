@@ -0,0 +1,23 @@
+package elgamal
+
+import (
+	"path/filepath"
+
+	"github.com/consensys/bavard"
+	"github.com/consensys/gnark-crypto/internal/generator/config"
+)
+
+func Generate(conf config.Curve, baseDir string, bgen *bavard.BatchGenerator) error {
+
+	// exponential ElGamal on G1
+	conf.Package = "elgamal"
+	entries := []bavard.Entry{
+		{File: filepath.Join(baseDir, "doc.go"), Templates: []string{"doc.go.tmpl"}},
+		{File: filepath.Join(baseDir, "elgamal.go"), Templates: []string{"elgamal.go.tmpl"}},
+		{File: filepath.Join(baseDir, "elgamal_test.go"), Templates: []string{"elgamal.test.go.tmpl"}},
+		{File: filepath.Join(baseDir, "field.go"), Templates: []string{"field.go.tmpl"}},
+		{File: filepath.Join(baseDir, "field_test.go"), Templates: []string{"field.test.go.tmpl"}},
+	}
+	return bgen.Generate(conf, conf.Package, "./elgamal/template/", entries...)
+
+}
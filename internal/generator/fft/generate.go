@@ -15,6 +15,18 @@ func Generate(conf config.Curve, baseDir string, bgen *bavard.BatchGenerator) er
 		{File: filepath.Join(baseDir, "domain.go"), Templates: []string{"domain.go.tmpl", "imports.go.tmpl"}},
 		{File: filepath.Join(baseDir, "fft_test.go"), Templates: []string{"tests/fft.go.tmpl", "imports.go.tmpl"}},
 		{File: filepath.Join(baseDir, "fft.go"), Templates: []string{"fft.go.tmpl", "imports.go.tmpl"}},
+		{File: filepath.Join(baseDir, "toeplitz.go"), Templates: []string{"toeplitz.go.tmpl", "imports.go.tmpl"}},
+		{File: filepath.Join(baseDir, "toeplitz_test.go"), Templates: []string{"tests/toeplitz.go.tmpl", "imports.go.tmpl"}},
 	}
-	return bgen.Generate(conf, conf.Package, "./fft/template/", entries...)
+	if err := bgen.Generate(conf, conf.Package, "./fft/template/", entries...); err != nil {
+		return err
+	}
+
+	// roots of unity on fr
+	frDir := filepath.Dir(baseDir)
+	entries = []bavard.Entry{
+		{File: filepath.Join(frDir, "roots.go"), Templates: []string{"roots.go.tmpl"}},
+		{File: filepath.Join(frDir, "roots_test.go"), Templates: []string{"tests/roots.go.tmpl"}},
+	}
+	return bgen.Generate(conf, "fr", "./fft/template/", entries...)
 }
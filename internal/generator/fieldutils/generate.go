@@ -0,0 +1,30 @@
+package fieldutils
+
+import (
+	"path/filepath"
+
+	"github.com/consensys/bavard"
+	"github.com/consensys/gnark-crypto/internal/generator/config"
+)
+
+// Generate generates the vector container, arena helpers and sampler
+// next to the field element in baseDir (fp or fr), and the deterministic nonces in fr
+func Generate(conf config.Curve, baseDir string, bgen *bavard.BatchGenerator) error {
+	conf.Package = filepath.Base(baseDir)
+	entries := []bavard.Entry{
+		{File: filepath.Join(baseDir, "vector.go"), Templates: []string{"vector.go.tmpl"}},
+		{File: filepath.Join(baseDir, "vector_test.go"), Templates: []string{"tests/vector.go.tmpl"}},
+		{File: filepath.Join(baseDir, "arena.go"), Templates: []string{"arena.go.tmpl"}, BuildTag: "!purego"},
+		{File: filepath.Join(baseDir, "arena_purego.go"), Templates: []string{"arena.purego.go.tmpl"}, BuildTag: "purego"},
+		{File: filepath.Join(baseDir, "arena_test.go"), Templates: []string{"tests/arena.go.tmpl"}, BuildTag: "!purego"},
+		{File: filepath.Join(baseDir, "sampler.go"), Templates: []string{"sampler.go.tmpl"}},
+		{File: filepath.Join(baseDir, "sampler_test.go"), Templates: []string{"tests/sampler.go.tmpl"}},
+	}
+	if conf.Package == "fr" {
+		entries = append(entries,
+			bavard.Entry{File: filepath.Join(baseDir, "nonce.go"), Templates: []string{"nonce.go.tmpl"}},
+			bavard.Entry{File: filepath.Join(baseDir, "nonce_test.go"), Templates: []string{"tests/nonce.go.tmpl"}},
+		)
+	}
+	return bgen.Generate(conf, conf.Package, "./fieldutils/template/", entries...)
+}
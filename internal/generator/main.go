@@ -10,19 +10,39 @@ import (
 	"github.com/consensys/bavard"
 	"github.com/consensys/gnark-crypto/internal/field"
 	"github.com/consensys/gnark-crypto/internal/field/generator"
+	"github.com/consensys/gnark-crypto/internal/generator/accumulator"
 	"github.com/consensys/gnark-crypto/internal/generator/config"
+	"github.com/consensys/gnark-crypto/internal/generator/cq"
+	"github.com/consensys/gnark-crypto/internal/generator/crypto/hash/anemoi"
+	"github.com/consensys/gnark-crypto/internal/generator/crypto/hash/hashutils"
 	"github.com/consensys/gnark-crypto/internal/generator/crypto/hash/mimc"
 	"github.com/consensys/gnark-crypto/internal/generator/ecc"
+	"github.com/consensys/gnark-crypto/internal/generator/ecdh"
+	"github.com/consensys/gnark-crypto/internal/generator/ecfft"
 	"github.com/consensys/gnark-crypto/internal/generator/edwards"
+	edwardsecdh "github.com/consensys/gnark-crypto/internal/generator/edwards/ecdh"
+	"github.com/consensys/gnark-crypto/internal/generator/edwards/ecies"
 	"github.com/consensys/gnark-crypto/internal/generator/edwards/eddsa"
+	"github.com/consensys/gnark-crypto/internal/generator/edwards/hdkey"
+	"github.com/consensys/gnark-crypto/internal/generator/edwards/ringsig"
+	"github.com/consensys/gnark-crypto/internal/generator/elgamal"
 	"github.com/consensys/gnark-crypto/internal/generator/fft"
+	"github.com/consensys/gnark-crypto/internal/generator/fieldutils"
 	fri "github.com/consensys/gnark-crypto/internal/generator/fri/template"
 	"github.com/consensys/gnark-crypto/internal/generator/kzg"
+	"github.com/consensys/gnark-crypto/internal/generator/logup"
+	"github.com/consensys/gnark-crypto/internal/generator/mpc"
+	"github.com/consensys/gnark-crypto/internal/generator/oprf"
 	"github.com/consensys/gnark-crypto/internal/generator/pairing"
+	"github.com/consensys/gnark-crypto/internal/generator/pcs"
 	"github.com/consensys/gnark-crypto/internal/generator/permutation"
 	"github.com/consensys/gnark-crypto/internal/generator/plookup"
 	"github.com/consensys/gnark-crypto/internal/generator/polynomial"
+	"github.com/consensys/gnark-crypto/internal/generator/selftest"
+	"github.com/consensys/gnark-crypto/internal/generator/shplonk"
+	"github.com/consensys/gnark-crypto/internal/generator/sigma"
 	"github.com/consensys/gnark-crypto/internal/generator/tower"
+	"github.com/consensys/gnark-crypto/internal/generator/zerocheck"
 )
 
 const (
@@ -56,9 +76,16 @@ func main() {
 			assertNoError(generator.GenerateFF(conf.Fr, filepath.Join(curveDir, "fr")))
 			assertNoError(generator.GenerateFF(conf.Fp, filepath.Join(curveDir, "fp")))
 
+			// generate vector, arena and sampler helpers on fr and fp
+			assertNoError(fieldutils.Generate(conf, filepath.Join(curveDir, "fr"), bgen))
+			assertNoError(fieldutils.Generate(conf, filepath.Join(curveDir, "fp"), bgen))
+
 			// generate tower of extension
 			assertNoError(tower.Generate(conf, filepath.Join(curveDir, "internal", "fptower"), bgen))
 
+			// generate ecfft on fp
+			assertNoError(ecfft.Generate(conf, filepath.Join(curveDir, "fp", "ecfft"), bgen))
+
 			// generate fft on fr
 			assertNoError(fft.Generate(conf, filepath.Join(curveDir, "fr", "fft"), bgen))
 
@@ -68,21 +95,63 @@ func main() {
 			// generate kzg on fr
 			assertNoError(kzg.Generate(conf, filepath.Join(curveDir, "fr", "kzg"), bgen))
 
+			// generate shplonk on fr
+			assertNoError(shplonk.Generate(conf, filepath.Join(curveDir, "fr", "shplonk"), bgen))
+
+			// generate powers of tau ceremony
+			assertNoError(mpc.Generate(conf, filepath.Join(curveDir, "mpc"), bgen))
+
 			// generate plookup on fr
 			assertNoError(plookup.Generate(conf, filepath.Join(curveDir, "fr", "plookup"), bgen))
 
+			// generate logup on fr
+			assertNoError(logup.Generate(conf, filepath.Join(curveDir, "fr", "logup"), bgen))
+
+			// generate zerocheck on fr
+			assertNoError(zerocheck.Generate(conf, filepath.Join(curveDir, "fr", "zerocheck"), bgen))
+
+			// generate cq on fr
+			assertNoError(cq.Generate(conf, filepath.Join(curveDir, "fr", "cq"), bgen))
+
 			// generate permutation on fr
 			assertNoError(permutation.Generate(conf, filepath.Join(curveDir, "fr", "permutation"), bgen))
 
 			// generate mimc on fr
 			assertNoError(mimc.Generate(conf, filepath.Join(curveDir, "fr", "mimc"), bgen))
 
+			// generate anemoi on fr
+			assertNoError(anemoi.Generate(conf, filepath.Join(curveDir, "fr", "anemoi"), bgen))
+
+			// generate hash to fr helpers
+			assertNoError(hashutils.Generate(conf, filepath.Join(curveDir, "fr", "hashutils"), bgen))
+
 			// generate eddsa on companion curves
 			assertNoError(fri.Generate(conf, filepath.Join(curveDir, "fr", "fri"), bgen))
 
+			// generate polynomial commitment interface on fr
+			assertNoError(pcs.Generate(conf, filepath.Join(curveDir, "fr", "pcs"), bgen))
+
 			// generate G1, G2, multiExp, ...
 			assertNoError(ecc.Generate(conf, curveDir, bgen))
 
+			// generate ecdh on G1
+			assertNoError(ecdh.Generate(conf, filepath.Join(curveDir, "ecdh"), bgen))
+
+			// generate exponential elgamal on G1
+			assertNoError(elgamal.Generate(conf, filepath.Join(curveDir, "elgamal"), bgen))
+
+			// generate sigma protocols on G1
+			assertNoError(sigma.Generate(conf, filepath.Join(curveDir, "sigma"), bgen))
+
+			// generate oprf on G1
+			assertNoError(oprf.Generate(conf, filepath.Join(curveDir, "oprf"), bgen))
+
+			// generate pairing-based accumulator
+			assertNoError(accumulator.Generate(conf, filepath.Join(curveDir, "accumulator"), bgen))
+
+			// generate deployment self tests
+			assertNoError(selftest.Generate(conf, filepath.Join(curveDir, "selftest"), bgen))
+
 			// generate pairing tests
 			assertNoError(pairing.Generate(conf, curveDir, bgen))
 
@@ -104,6 +173,18 @@ func main() {
 
 			// generate eddsa on companion curves
 			assertNoError(eddsa.Generate(conf, curveDir, bgen))
+
+			// generate ecdh on companion curves
+			assertNoError(edwardsecdh.Generate(conf, curveDir, bgen))
+
+			// generate ecies on companion curves
+			assertNoError(ecies.Generate(conf, curveDir, bgen))
+
+			// generate linkable ring signatures on companion curves
+			assertNoError(ringsig.Generate(conf, curveDir, bgen))
+
+			// generate hierarchical deterministic keys on companion curves
+			assertNoError(hdkey.Generate(conf, curveDir, bgen))
 		}(conf)
 
 	}
@@ -0,0 +1,22 @@
+package mpc
+
+import (
+	"path/filepath"
+
+	"github.com/consensys/bavard"
+	"github.com/consensys/gnark-crypto/internal/generator/config"
+)
+
+func Generate(conf config.Curve, baseDir string, bgen *bavard.BatchGenerator) error {
+
+	// powers of tau ceremony
+	conf.Package = "mpc"
+	entries := []bavard.Entry{
+		{File: filepath.Join(baseDir, "doc.go"), Templates: []string{"doc.go.tmpl"}},
+		{File: filepath.Join(baseDir, "phase1.go"), Templates: []string{"phase1.go.tmpl"}},
+		{File: filepath.Join(baseDir, "marshal.go"), Templates: []string{"marshal.go.tmpl"}},
+		{File: filepath.Join(baseDir, "phase1_test.go"), Templates: []string{"phase1.test.go.tmpl"}},
+	}
+	return bgen.Generate(conf, conf.Package, "./mpc/template/", entries...)
+
+}
@@ -0,0 +1,21 @@
+package oprf
+
+import (
+	"path/filepath"
+
+	"github.com/consensys/bavard"
+	"github.com/consensys/gnark-crypto/internal/generator/config"
+)
+
+func Generate(conf config.Curve, baseDir string, bgen *bavard.BatchGenerator) error {
+
+	// (verifiable) oblivious pseudo-random functions on G1
+	conf.Package = "oprf"
+	entries := []bavard.Entry{
+		{File: filepath.Join(baseDir, "doc.go"), Templates: []string{"doc.go.tmpl"}},
+		{File: filepath.Join(baseDir, "oprf.go"), Templates: []string{"oprf.go.tmpl"}},
+		{File: filepath.Join(baseDir, "oprf_test.go"), Templates: []string{"oprf.test.go.tmpl"}},
+	}
+	return bgen.Generate(conf, conf.Package, "./oprf/template/", entries...)
+
+}
@@ -0,0 +1,24 @@
+package pcs
+
+import (
+	"path/filepath"
+
+	"github.com/consensys/bavard"
+	"github.com/consensys/gnark-crypto/internal/generator/config"
+)
+
+func Generate(conf config.Curve, baseDir string, bgen *bavard.BatchGenerator) error {
+
+	// polynomial commitment interface and its KZG, IPA and FRI backends
+	conf.Package = "pcs"
+	entries := []bavard.Entry{
+		{File: filepath.Join(baseDir, "doc.go"), Templates: []string{"doc.go.tmpl"}},
+		{File: filepath.Join(baseDir, "pcs.go"), Templates: []string{"pcs.go.tmpl"}},
+		{File: filepath.Join(baseDir, "kzg.go"), Templates: []string{"kzg.go.tmpl"}},
+		{File: filepath.Join(baseDir, "ipa.go"), Templates: []string{"ipa.go.tmpl"}},
+		{File: filepath.Join(baseDir, "fri.go"), Templates: []string{"fri.go.tmpl"}},
+		{File: filepath.Join(baseDir, "pcs_test.go"), Templates: []string{"pcs.test.go.tmpl"}},
+	}
+	return bgen.Generate(conf, conf.Package, "./pcs/template/", entries...)
+
+}
@@ -15,8 +15,10 @@ func Generate(conf config.Curve, baseDir string, bgen *bavard.BatchGenerator) er
 		{File: filepath.Join(baseDir, "polynomial.go"), Templates: []string{"polynomial.go.tmpl"}},
 		{File: filepath.Join(baseDir, "multilin.go"), Templates: []string{"multilin.go.tmpl"}},
 		{File: filepath.Join(baseDir, "pool.go"), Templates: []string{"pool.go.tmpl"}},
+		{File: filepath.Join(baseDir, "vanishing.go"), Templates: []string{"vanishing.go.tmpl"}},
 		{File: filepath.Join(baseDir, "polynomial_test.go"), Templates: []string{"polynomial.test.go.tmpl"}},
 		{File: filepath.Join(baseDir, "multilin_test.go"), Templates: []string{"multilin.test.go.tmpl"}},
+		{File: filepath.Join(baseDir, "vanishing_test.go"), Templates: []string{"vanishing.test.go.tmpl"}},
 	}
 	return bgen.Generate(conf, conf.Package, "./polynomial/template/", entries...)
 }
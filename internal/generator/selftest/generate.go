@@ -0,0 +1,21 @@
+package selftest
+
+import (
+	"path/filepath"
+
+	"github.com/consensys/bavard"
+	"github.com/consensys/gnark-crypto/internal/generator/config"
+)
+
+func Generate(conf config.Curve, baseDir string, bgen *bavard.BatchGenerator) error {
+
+	// randomized consistency checks of the curve, fields, fft and kzg
+	conf.Package = "selftest"
+	entries := []bavard.Entry{
+		{File: filepath.Join(baseDir, "doc.go"), Templates: []string{"doc.go.tmpl"}},
+		{File: filepath.Join(baseDir, "selftest.go"), Templates: []string{"selftest.go.tmpl"}},
+		{File: filepath.Join(baseDir, "selftest_test.go"), Templates: []string{"selftest.test.go.tmpl"}},
+	}
+	return bgen.Generate(conf, conf.Package, "./selftest/template/", entries...)
+
+}
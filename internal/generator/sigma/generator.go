@@ -0,0 +1,21 @@
+package sigma
+
+import (
+	"path/filepath"
+
+	"github.com/consensys/bavard"
+	"github.com/consensys/gnark-crypto/internal/generator/config"
+)
+
+func Generate(conf config.Curve, baseDir string, bgen *bavard.BatchGenerator) error {
+
+	// sigma protocols on G1
+	conf.Package = "sigma"
+	entries := []bavard.Entry{
+		{File: filepath.Join(baseDir, "doc.go"), Templates: []string{"doc.go.tmpl"}},
+		{File: filepath.Join(baseDir, "sigma.go"), Templates: []string{"sigma.go.tmpl"}},
+		{File: filepath.Join(baseDir, "sigma_test.go"), Templates: []string{"sigma.test.go.tmpl"}},
+	}
+	return bgen.Generate(conf, conf.Package, "./sigma/template/", entries...)
+
+}
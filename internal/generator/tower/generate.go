@@ -18,8 +18,8 @@ func Generate(conf config.Curve, baseDir string, bgen *bavard.BatchGenerator) er
 	}
 
 	entries := []bavard.Entry{
-		{File: filepath.Join(baseDir, "e2_amd64.go"), Templates: []string{"amd64.fq2.go.tmpl"}},
-		{File: filepath.Join(baseDir, "e2_fallback.go"), Templates: []string{"fallback.fq2.go.tmpl"}, BuildTag: "!amd64"},
+		{File: filepath.Join(baseDir, "e2_amd64.go"), Templates: []string{"amd64.fq2.go.tmpl"}, BuildTag: "!purego"},
+		{File: filepath.Join(baseDir, "e2_fallback.go"), Templates: []string{"fallback.fq2.go.tmpl"}, BuildTag: "!amd64 purego"},
 		{File: filepath.Join(baseDir, "asm.go"), Templates: []string{"asm.go.tmpl"}, BuildTag: "!noadx"},
 		{File: filepath.Join(baseDir, "asm_noadx.go"), Templates: []string{"asm_noadx.go.tmpl"}, BuildTag: "noadx"},
 	}
@@ -79,7 +79,9 @@ func Generate(conf config.Curve, baseDir string, bgen *bavard.BatchGenerator) er
 		}
 
 		if conf.Equal(config.BN254) || conf.Equal(config.BLS12_381) {
-			_, _ = io.WriteString(f, "// +build !amd64_adx\n")
+			_, _ = io.WriteString(f, "//go:build !amd64_adx && !purego\n// +build !amd64_adx,!purego\n")
+		} else {
+			_, _ = io.WriteString(f, "//go:build !purego\n// +build !purego\n\n")
 		}
 		Fq2Amd64 := amd64.NewFq2Amd64(f, conf.Fp, conf)
 		if err := Fq2Amd64.Generate(true); err != nil {
@@ -99,7 +101,7 @@ func Generate(conf config.Curve, baseDir string, bgen *bavard.BatchGenerator) er
 				return err
 			}
 
-			_, _ = io.WriteString(f, "// +build amd64_adx\n")
+			_, _ = io.WriteString(f, "//go:build amd64_adx && !purego\n// +build amd64_adx,!purego\n")
 			Fq2Amd64 := amd64.NewFq2Amd64(f, conf.Fp, conf)
 			if err := Fq2Amd64.Generate(false); err != nil {
 				_ = f.Close()
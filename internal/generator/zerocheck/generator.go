@@ -0,0 +1,21 @@
+package zerocheck
+
+import (
+	"path/filepath"
+
+	"github.com/consensys/bavard"
+	"github.com/consensys/gnark-crypto/internal/generator/config"
+)
+
+func Generate(conf config.Curve, baseDir string, bgen *bavard.BatchGenerator) error {
+
+	// zero-check (quotient-check) helper protocol
+	conf.Package = "zerocheck"
+	entries := []bavard.Entry{
+		{File: filepath.Join(baseDir, "doc.go"), Templates: []string{"doc.go.tmpl"}},
+		{File: filepath.Join(baseDir, "zerocheck.go"), Templates: []string{"zerocheck.go.tmpl"}},
+		{File: filepath.Join(baseDir, "zerocheck_test.go"), Templates: []string{"zerocheck.test.go.tmpl"}},
+	}
+	return bgen.Generate(conf, conf.Package, "./zerocheck/template/", entries...)
+
+}
@@ -0,0 +1,34 @@
+package parallel
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+var errCPUList = errors.New("invalid cpu list")
+
+// parseCPUList parses a list of CPUs in the format of the Linux kernel, e.g. "0-3,8,10-11"
+func parseCPUList(s string) ([]int, error) {
+	var cpus []int
+	if s == "" {
+		return cpus, nil
+	}
+	for _, r := range strings.Split(s, ",") {
+		bounds := strings.SplitN(r, "-", 2)
+		first, err := strconv.Atoi(bounds[0])
+		if err != nil {
+			return nil, errCPUList
+		}
+		last := first
+		if len(bounds) == 2 {
+			if last, err = strconv.Atoi(bounds[1]); err != nil || last < first {
+				return nil, errCPUList
+			}
+		}
+		for c := first; c <= last; c++ {
+			cpus = append(cpus, c)
+		}
+	}
+	return cpus, nil
+}
@@ -3,15 +3,47 @@ package parallel
 import (
 	"runtime"
 	"sync"
+	"sync/atomic"
 )
 
+// maxProcs is the default number of go routines of Execute, 0 for runtime.NumCPU()
+var maxProcs int32
+
+// SetMaxProcs sets the number of go routines Execute and ExecuteChunked use when
+// maxCpus is not specified, and returns the previous setting. n <= 0 restores the
+// default, runtime.NumCPU().
+func SetMaxProcs(n int) int {
+	if n < 0 {
+		n = 0
+	}
+	return int(atomic.SwapInt32(&maxProcs, int32(n)))
+}
+
+// MaxProcs returns the number of go routines Execute uses when maxCpus is not specified
+func MaxProcs() int {
+	if n := atomic.LoadInt32(&maxProcs); n > 0 {
+		return int(n)
+	}
+	return runtime.NumCPU()
+}
+
 // Execute process in parallel the work function
 func Execute(nbIterations int, work func(int, int), maxCpus ...int) {
 
-	nbTasks := runtime.NumCPU()
+	nbTasks := MaxProcs()
 	if len(maxCpus) == 1 {
 		nbTasks = maxCpus[0]
 	}
+	if nbTasks < 1 {
+		nbTasks = 1
+	}
+
+	// one block of iterations per NUMA node, processed by threads pinned to the node
+	if nodes := numaNodes(); len(nodes) > 1 && nbTasks >= len(nodes) && nbIterations >= nbTasks {
+		executeNUMA(nbIterations, work, nbTasks, nodes)
+		return
+	}
+
 	nbIterationsPerCpus := nbIterations / nbTasks
 
 	// more CPUs than tasks: a CPU will work on exactly one iteration
@@ -42,3 +74,50 @@ func Execute(nbIterations int, work func(int, int), maxCpus ...int) {
 
 	wg.Wait()
 }
+
+// ExecuteChunked process in parallel the work function, on chunks of chunkSize iterations
+// (the last one may be smaller). Unlike Execute, which splits the iterations in one block
+// per go routine, the go routines take the next chunk as soon as they are done with the
+// previous one, which balances the load when the cost of the iterations varies.
+func ExecuteChunked(nbIterations, chunkSize int, work func(int, int), maxCpus ...int) {
+	if nbIterations <= 0 {
+		return
+	}
+	if chunkSize < 1 {
+		chunkSize = 1
+	}
+	nbChunks := (nbIterations + chunkSize - 1) / chunkSize
+
+	nbTasks := MaxProcs()
+	if len(maxCpus) == 1 {
+		nbTasks = maxCpus[0]
+	}
+	if nbTasks > nbChunks {
+		nbTasks = nbChunks
+	}
+	if nbTasks < 1 {
+		nbTasks = 1
+	}
+
+	var next int64
+	var wg sync.WaitGroup
+	wg.Add(nbTasks)
+	for i := 0; i < nbTasks; i++ {
+		go func() {
+			defer wg.Done()
+			for {
+				chunk := int(atomic.AddInt64(&next, 1) - 1)
+				if chunk >= nbChunks {
+					return
+				}
+				start := chunk * chunkSize
+				end := start + chunkSize
+				if end > nbIterations {
+					end = nbIterations
+				}
+				work(start, end)
+			}
+		}()
+	}
+	wg.Wait()
+}
@@ -0,0 +1,124 @@
+package parallel
+
+import (
+	"reflect"
+	"runtime"
+	"sync/atomic"
+	"testing"
+)
+
+// checkCoverage checks that run calls work on every iteration exactly once
+func checkCoverage(t *testing.T, nbIterations int, run func(work func(int, int))) {
+	t.Helper()
+	counts := make([]int32, nbIterations)
+	run(func(start, end int) {
+		for i := start; i < end; i++ {
+			atomic.AddInt32(&counts[i], 1)
+		}
+	})
+	for i, c := range counts {
+		if c != 1 {
+			t.Fatalf("iteration %d processed %d times", i, c)
+		}
+	}
+}
+
+func TestExecute(t *testing.T) {
+	for _, nbIterations := range []int{0, 1, 7, 64, 1000} {
+		for _, nbTasks := range []int{1, 3, 8, 2000} {
+			checkCoverage(t, nbIterations, func(work func(int, int)) {
+				Execute(nbIterations, work, nbTasks)
+			})
+			checkCoverage(t, nbIterations, func(work func(int, int)) {
+				ExecuteChunked(nbIterations, 10, work, nbTasks)
+			})
+		}
+	}
+}
+
+func TestSetMaxProcs(t *testing.T) {
+	defer SetMaxProcs(0)
+	if MaxProcs() != runtime.NumCPU() {
+		t.Fatal("MaxProcs should default to runtime.NumCPU()")
+	}
+	SetMaxProcs(3)
+	if MaxProcs() != 3 {
+		t.Fatal("MaxProcs should be 3")
+	}
+	var nbTasks int32
+	Execute(100, func(int, int) { atomic.AddInt32(&nbTasks, 1) })
+	if nbTasks != 3 {
+		t.Fatalf("Execute should use 3 go routines, got %d", nbTasks)
+	}
+	if SetMaxProcs(-1) != 3 || MaxProcs() != runtime.NumCPU() {
+		t.Fatal("SetMaxProcs(-1) should restore the default")
+	}
+}
+
+func TestNUMA(t *testing.T) {
+	defer SetNUMA(false)
+	if SetNUMA(true) {
+		t.Fatal("the NUMA-aware mode should be disabled by default")
+	}
+	checkCoverage(t, 1000, func(work func(int, int)) {
+		Execute(1000, work)
+	})
+	t.Logf("NUMA nodes of the host: %v", NUMANodes())
+
+	// two nodes, both on the first CPU
+	nodes := []Node{{ID: 0, CPUs: []int{0}}, {ID: 1, CPUs: []int{0}}}
+	checkCoverage(t, 1000, func(work func(int, int)) {
+		executeNUMA(1000, work, 4, nodes)
+	})
+}
+
+func TestNUMASplit(t *testing.T) {
+	nodes := []Node{
+		{ID: 0, CPUs: []int{0, 1, 2, 3, 4, 5}},
+		{ID: 1, CPUs: []int{6, 7}},
+	}
+	tasks := numaSplit(80, 4, nodes)
+	expected := []numaTask{
+		{node: 0, start: 0, end: 20},
+		{node: 0, start: 20, end: 40},
+		{node: 0, start: 40, end: 60},
+		{node: 1, start: 60, end: 80},
+	}
+	if !reflect.DeepEqual(tasks, expected) {
+		t.Fatalf("unexpected split %v", tasks)
+	}
+
+	// each node gets at least a task, and the blocks cover the iterations
+	nodes = append(nodes, Node{ID: 2, CPUs: []int{8}})
+	for _, nbTasks := range []int{3, 4, 5, 16} {
+		tasks := numaSplit(1000, nbTasks, nodes)
+		if len(tasks) != nbTasks {
+			t.Fatalf("expected %d tasks, got %d", nbTasks, len(tasks))
+		}
+		end := 0
+		for i, task := range tasks {
+			if task.start != end || task.end < task.start {
+				t.Fatal("the blocks should be contiguous")
+			}
+			if i > 0 && task.node < tasks[i-1].node {
+				t.Fatal("the blocks should be ordered by node")
+			}
+			end = task.end
+		}
+		if end != 1000 || tasks[len(tasks)-1].node != 2 {
+			t.Fatal("the blocks should cover the iterations, on all the nodes")
+		}
+	}
+}
+
+func TestParseCPUList(t *testing.T) {
+	cpus, err := parseCPUList("0-3,8,10-11")
+	if err != nil || !reflect.DeepEqual(cpus, []int{0, 1, 2, 3, 8, 10, 11}) {
+		t.Fatal("unexpected cpu list", cpus, err)
+	}
+	for _, s := range []string{"a", "3-1", "0-", ",1"} {
+		if _, err := parseCPUList(s); err == nil {
+			t.Fatalf("%q should be rejected", s)
+		}
+	}
+}
@@ -0,0 +1,127 @@
+package parallel
+
+import (
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+// Node is a NUMA node of the host
+type Node struct {
+	ID   int
+	CPUs []int // logical CPUs of the node
+}
+
+var (
+	numaEnabled int32
+	numaOnce    sync.Once
+	numaHost    []Node
+)
+
+// SetNUMA enables (or disables) the NUMA-aware mode of Execute, and returns the previous
+// setting. It is disabled by default.
+//
+// In NUMA-aware mode, on a host with several NUMA nodes, Execute splits the iterations in one
+// contiguous block per node, proportional to its number of CPUs, and the go routines working
+// on a block run on OS threads pinned to the CPUs of the node (on Linux). As memory pages are
+// allocated on the node of the thread which first touches them, a prover which fills and then
+// processes its vectors with Execute mostly accesses the memory of the local node; on dual-socket
+// machines, this is what keeps the throughput growing with the number of cores.
+//
+// The pinned threads are released to the OS at the end of their work, so it only pays off
+// for large workloads.
+func SetNUMA(enabled bool) bool {
+	var v int32
+	if enabled {
+		v = 1
+	}
+	return atomic.SwapInt32(&numaEnabled, v) == 1
+}
+
+// NUMANodes returns the NUMA nodes of the host, with their CPUs. It returns nil if the
+// topology is not available (e.g. not on Linux), which is handled as a single node.
+func NUMANodes() []Node {
+	numaOnce.Do(func() {
+		numaHost = readNUMANodes()
+	})
+	res := make([]Node, len(numaHost))
+	copy(res, numaHost)
+	return res
+}
+
+// numaNodes returns the NUMA nodes if the NUMA-aware mode is enabled, nil otherwise
+func numaNodes() []Node {
+	if atomic.LoadInt32(&numaEnabled) == 0 {
+		return nil
+	}
+	numaOnce.Do(func() {
+		numaHost = readNUMANodes()
+	})
+	return numaHost
+}
+
+// numaTask is a block of iterations, processed on a node
+type numaTask struct {
+	node       int
+	start, end int
+}
+
+// numaSplit splits the iterations in contiguous blocks, one per node proportional to the
+// number of CPUs of the node, and each block between the go routines of the node.
+// It assumes nbIterations >= nbTasks >= len(nodes).
+func numaSplit(nbIterations, nbTasks int, nodes []Node) []numaTask {
+	totalCPUs := 0
+	for _, n := range nodes {
+		totalCPUs += len(n.CPUs)
+	}
+
+	tasks := make([]numaTask, 0, nbTasks)
+	start, cpus, assignedTasks := 0, 0, 0
+	for i, n := range nodes {
+		cpus += len(n.CPUs)
+		end, nodeTasks := nbIterations, nbTasks-assignedTasks
+		if i < len(nodes)-1 {
+			end = nbIterations * cpus / totalCPUs
+			nodeTasks = nbTasks*cpus/totalCPUs - assignedTasks
+			if nodeTasks < 1 {
+				nodeTasks = 1
+			}
+			// leave a task to each of the remaining nodes
+			if maxTasks := nbTasks - assignedTasks - (len(nodes) - 1 - i); nodeTasks > maxTasks {
+				nodeTasks = maxTasks
+			}
+		}
+		assignedTasks += nodeTasks
+
+		// split [start, end) between the nodeTasks go routines of the node
+		size := end - start
+		for j := 0; j < nodeTasks; j++ {
+			tasks = append(tasks, numaTask{
+				node:  i,
+				start: start + size*j/nodeTasks,
+				end:   start + size*(j+1)/nodeTasks,
+			})
+		}
+		start = end
+	}
+	return tasks
+}
+
+func executeNUMA(nbIterations int, work func(int, int), nbTasks int, nodes []Node) {
+	var wg sync.WaitGroup
+	for _, t := range numaSplit(nbIterations, nbTasks, nodes) {
+		if t.start == t.end {
+			continue
+		}
+		wg.Add(1)
+		go func(t numaTask) {
+			defer wg.Done()
+			// the thread is not unlocked: it exits with the go routine, and its
+			// affinity doesn't leak to other go routines
+			runtime.LockOSThread()
+			_ = setAffinity(nodes[t.node].CPUs)
+			work(t.start, t.end)
+		}(t)
+	}
+	wg.Wait()
+}
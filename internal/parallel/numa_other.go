@@ -0,0 +1,12 @@
+//go:build !linux
+// +build !linux
+
+package parallel
+
+func readNUMANodes() []Node {
+	return nil
+}
+
+func setAffinity(cpus []int) error {
+	return nil
+}
@@ -23,6 +23,10 @@ import (
 type SRS interface {
 	io.ReaderFrom
 	io.WriterTo
+
+	// Verify checks the subgroup membership and the consistency of the powers,
+	// typically after reading an SRS from a third-party source
+	Verify() error
 }
 
 // NewSRS returns an empty curved-typed SRS object
@@ -0,0 +1,53 @@
+// Package selftest runs randomized consistency checks of a curve, for deployment-time
+// verification that the optimized (assembly) code paths are correct on the host CPU.
+//
+// For more details, see ecc/XXX/selftest package
+package selftest
+
+import (
+	"fmt"
+
+	"github.com/consensys/gnark-crypto/ecc"
+
+	selftest_bls12377 "github.com/consensys/gnark-crypto/ecc/bls12-377/selftest"
+	selftest_bls12378 "github.com/consensys/gnark-crypto/ecc/bls12-378/selftest"
+	selftest_bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381/selftest"
+	selftest_bls24315 "github.com/consensys/gnark-crypto/ecc/bls24-315/selftest"
+	selftest_bls24317 "github.com/consensys/gnark-crypto/ecc/bls24-317/selftest"
+	selftest_bn254 "github.com/consensys/gnark-crypto/ecc/bn254/selftest"
+	selftest_bw6633 "github.com/consensys/gnark-crypto/ecc/bw6-633/selftest"
+	selftest_bw6756 "github.com/consensys/gnark-crypto/ecc/bw6-756/selftest"
+	selftest_bw6761 "github.com/consensys/gnark-crypto/ecc/bw6-761/selftest"
+)
+
+// Run runs the checks of the fields, multi-exponentiation, pairing, FFT and KZG of the curve,
+// and returns the first failure.
+func Run(curveID ecc.ID) error {
+	var err error
+	switch curveID {
+	case ecc.BN254:
+		err = selftest_bn254.Run()
+	case ecc.BLS12_377:
+		err = selftest_bls12377.Run()
+	case ecc.BLS12_378:
+		err = selftest_bls12378.Run()
+	case ecc.BLS12_381:
+		err = selftest_bls12381.Run()
+	case ecc.BLS24_315:
+		err = selftest_bls24315.Run()
+	case ecc.BLS24_317:
+		err = selftest_bls24317.Run()
+	case ecc.BW6_761:
+		err = selftest_bw6761.Run()
+	case ecc.BW6_633:
+		err = selftest_bw6633.Run()
+	case ecc.BW6_756:
+		err = selftest_bw6756.Run()
+	default:
+		return ecc.ErrUnknownCurve
+	}
+	if err != nil {
+		return fmt.Errorf("%s: %w", curveID, err)
+	}
+	return nil
+}
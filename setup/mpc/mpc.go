@@ -0,0 +1,160 @@
+// Package mpc provides curve-agnostic entry points for the phase 1 (powers of tau)
+// trusted setup ceremony.
+//
+// For more details, see ecc/XXX/mpc package
+package mpc
+
+import (
+	"errors"
+	"io"
+
+	"github.com/consensys/gnark-crypto/ecc"
+
+	mpc_bls12377 "github.com/consensys/gnark-crypto/ecc/bls12-377/mpc"
+	mpc_bls12378 "github.com/consensys/gnark-crypto/ecc/bls12-378/mpc"
+	mpc_bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381/mpc"
+	mpc_bls24315 "github.com/consensys/gnark-crypto/ecc/bls24-315/mpc"
+	mpc_bls24317 "github.com/consensys/gnark-crypto/ecc/bls24-317/mpc"
+	mpc_bn254 "github.com/consensys/gnark-crypto/ecc/bn254/mpc"
+	mpc_bw6633 "github.com/consensys/gnark-crypto/ecc/bw6-633/mpc"
+	mpc_bw6756 "github.com/consensys/gnark-crypto/ecc/bw6-756/mpc"
+	mpc_bw6761 "github.com/consensys/gnark-crypto/ecc/bw6-761/mpc"
+)
+
+var (
+	ErrCurveMismatch = errors.New("the states are not on the same curve")
+	ErrMinSize       = errors.New("minimum number of powers is 2")
+)
+
+// Phase1 is the state of a powers of tau ceremony
+type Phase1 interface {
+	io.ReaderFrom
+	io.WriterTo
+
+	// Contribute updates the state with a random contribution
+	Contribute() error
+
+	// ApplyBeacon updates the state with a contribution derived from a public random beacon
+	ApplyBeacon(beacon []byte, nbIterations int) error
+
+	// Hash returns the hash of the state, which chains the next contribution
+	Hash() []byte
+}
+
+// InitPhase1 returns the initial state of a ceremony for n powers of tau on the given curve
+func InitPhase1(curveID ecc.ID, n uint64) (Phase1, error) {
+	if n < 2 {
+		// checked here so that a nil state is not wrapped in a non-nil interface
+		return nil, ErrMinSize
+	}
+	switch curveID {
+	case ecc.BN254:
+		return mpc_bn254.InitPhase1(n)
+	case ecc.BLS12_377:
+		return mpc_bls12377.InitPhase1(n)
+	case ecc.BLS12_378:
+		return mpc_bls12378.InitPhase1(n)
+	case ecc.BLS12_381:
+		return mpc_bls12381.InitPhase1(n)
+	case ecc.BLS24_315:
+		return mpc_bls24315.InitPhase1(n)
+	case ecc.BLS24_317:
+		return mpc_bls24317.InitPhase1(n)
+	case ecc.BW6_761:
+		return mpc_bw6761.InitPhase1(n)
+	case ecc.BW6_633:
+		return mpc_bw6633.InitPhase1(n)
+	case ecc.BW6_756:
+		return mpc_bw6756.InitPhase1(n)
+	default:
+		panic("not implemented")
+	}
+}
+
+// NewPhase1 returns an empty curve-typed state, to be filled with ReadFrom
+func NewPhase1(curveID ecc.ID) Phase1 {
+	switch curveID {
+	case ecc.BN254:
+		return &mpc_bn254.Phase1{}
+	case ecc.BLS12_377:
+		return &mpc_bls12377.Phase1{}
+	case ecc.BLS12_378:
+		return &mpc_bls12378.Phase1{}
+	case ecc.BLS12_381:
+		return &mpc_bls12381.Phase1{}
+	case ecc.BLS24_315:
+		return &mpc_bls24315.Phase1{}
+	case ecc.BLS24_317:
+		return &mpc_bls24317.Phase1{}
+	case ecc.BW6_761:
+		return &mpc_bw6761.Phase1{}
+	case ecc.BW6_633:
+		return &mpc_bw6633.Phase1{}
+	case ecc.BW6_756:
+		return &mpc_bw6756.Phase1{}
+	default:
+		panic("not implemented")
+	}
+}
+
+// Verify checks that next is a valid contribution on top of prev
+func Verify(next, prev Phase1) error {
+	switch n := next.(type) {
+	case *mpc_bn254.Phase1:
+		p, ok := prev.(*mpc_bn254.Phase1)
+		if !ok {
+			return ErrCurveMismatch
+		}
+		return n.Verify(p)
+	case *mpc_bls12377.Phase1:
+		p, ok := prev.(*mpc_bls12377.Phase1)
+		if !ok {
+			return ErrCurveMismatch
+		}
+		return n.Verify(p)
+	case *mpc_bls12378.Phase1:
+		p, ok := prev.(*mpc_bls12378.Phase1)
+		if !ok {
+			return ErrCurveMismatch
+		}
+		return n.Verify(p)
+	case *mpc_bls12381.Phase1:
+		p, ok := prev.(*mpc_bls12381.Phase1)
+		if !ok {
+			return ErrCurveMismatch
+		}
+		return n.Verify(p)
+	case *mpc_bls24315.Phase1:
+		p, ok := prev.(*mpc_bls24315.Phase1)
+		if !ok {
+			return ErrCurveMismatch
+		}
+		return n.Verify(p)
+	case *mpc_bls24317.Phase1:
+		p, ok := prev.(*mpc_bls24317.Phase1)
+		if !ok {
+			return ErrCurveMismatch
+		}
+		return n.Verify(p)
+	case *mpc_bw6761.Phase1:
+		p, ok := prev.(*mpc_bw6761.Phase1)
+		if !ok {
+			return ErrCurveMismatch
+		}
+		return n.Verify(p)
+	case *mpc_bw6633.Phase1:
+		p, ok := prev.(*mpc_bw6633.Phase1)
+		if !ok {
+			return ErrCurveMismatch
+		}
+		return n.Verify(p)
+	case *mpc_bw6756.Phase1:
+		p, ok := prev.(*mpc_bw6756.Phase1)
+		if !ok {
+			return ErrCurveMismatch
+		}
+		return n.Verify(p)
+	default:
+		panic("not implemented")
+	}
+}
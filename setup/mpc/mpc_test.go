@@ -0,0 +1,42 @@
+package mpc
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+)
+
+func TestPhase1(t *testing.T) {
+	for _, curveID := range ecc.Implemented() {
+		prev, err := InitPhase1(curveID, 4)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// contribute on a copy obtained through serialization
+		var buf bytes.Buffer
+		if _, err := prev.WriteTo(&buf); err != nil {
+			t.Fatal(err)
+		}
+		next := NewPhase1(curveID)
+		if _, err := next.ReadFrom(&buf); err != nil {
+			t.Fatal(err)
+		}
+		if err := next.Contribute(); err != nil {
+			t.Fatal(err)
+		}
+		if err := Verify(next, prev); err != nil {
+			t.Fatal(curveID, err)
+		}
+	}
+
+	a, _ := InitPhase1(ecc.BN254, 4)
+	b, _ := InitPhase1(ecc.BLS12_381, 4)
+	if err := Verify(a, b); err != ErrCurveMismatch {
+		t.Fatal("expected ErrCurveMismatch")
+	}
+	if p, err := InitPhase1(ecc.BN254, 1); err != ErrMinSize || p != nil {
+		t.Fatal("expected ErrMinSize")
+	}
+}
@@ -0,0 +1,64 @@
+// Copyright 2020 ConsenSys Software Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package parallel exposes the parallel execution utilities used throughout gnark-crypto
+// (multi-exponentiations, FFTs, KZG, ...), and their package-level settings.
+//
+// The settings apply to the whole library: for instance SetMaxProcs(32) limits the MultiExp
+// (when ecc.MultiExpConfig.NbTasks is not set) and the FFTs to 32 go routines, and SetNUMA(true)
+// makes the loops parallelized with Execute NUMA-aware.
+package parallel
+
+import (
+	"github.com/consensys/gnark-crypto/internal/parallel"
+)
+
+// Node is a NUMA node of the host
+type Node = parallel.Node
+
+// Execute calls work on [0, nbIterations) split in one block of consecutive iterations per
+// go routine. The number of go routines is maxCpus if set, MaxProcs() otherwise.
+func Execute(nbIterations int, work func(start, end int), maxCpus ...int) {
+	parallel.Execute(nbIterations, work, maxCpus...)
+}
+
+// ExecuteChunked calls work on [0, nbIterations) split in chunks of chunkSize iterations,
+// which the go routines process as soon as they are done with the previous one. It balances
+// the load better than Execute when the cost of the iterations varies.
+func ExecuteChunked(nbIterations, chunkSize int, work func(start, end int), maxCpus ...int) {
+	parallel.ExecuteChunked(nbIterations, chunkSize, work, maxCpus...)
+}
+
+// SetMaxProcs sets the default number of go routines of the library, and returns the
+// previous setting. n <= 0 restores the default, runtime.NumCPU().
+func SetMaxProcs(n int) int {
+	return parallel.SetMaxProcs(n)
+}
+
+// MaxProcs returns the default number of go routines of the library
+func MaxProcs() int {
+	return parallel.MaxProcs()
+}
+
+// SetNUMA enables (or disables) the NUMA-aware mode of Execute, and returns the previous
+// setting. In this mode, on a host with several NUMA nodes, the iterations are split in
+// one block per node, processed by threads pinned to the CPUs of the node (on Linux).
+func SetNUMA(enabled bool) bool {
+	return parallel.SetNUMA(enabled)
+}
+
+// NUMANodes returns the NUMA nodes of the host, or nil if the topology is not available
+func NUMANodes() []Node {
+	return parallel.NUMANodes()
+}